@@ -0,0 +1,25 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"poymanov/todo/internal/app"
+)
+
+func main() {
+	email := flag.String("email", "", "email зарегистрированного пользователя, которого нужно назначить администратором")
+	flag.Parse()
+
+	if *email == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := app.PromoteAdmin(*email); err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	fmt.Printf("User %s is now an administrator\n", *email)
+}
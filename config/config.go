@@ -15,11 +15,83 @@ type DB struct {
 
 type Auth struct {
 	Secret string `env-required:"true" yaml:"secret"`
+	// PasswordResetTTLHours - срок действия токена смены пароля, выданного администратором, в часах
+	PasswordResetTTLHours int `yaml:"password_reset_ttl_hours" env-default:"24"`
+}
+
+type Tasks struct {
+	ForbidBlockedCompletion bool `yaml:"forbid_blocked_completion" env-default:"false"`
+	// TrashRetentionDays - срок хранения задач в корзине в днях, 0 отключает автоматическую очистку
+	TrashRetentionDays int `yaml:"trash_retention_days" env-default:"30"`
+	// UndoWindowSeconds - время в секундах, в течение которого последнее действие с задачей можно отменить
+	UndoWindowSeconds int `yaml:"undo_window_seconds" env-default:"60"`
+}
+
+type Search struct {
+	// Language - язык полнотекстового поиска: russian (ru) или english (en)
+	Language string `yaml:"language" env-default:"russian"`
+}
+
+type Idempotency struct {
+	// TTLHours - срок хранения результатов запросов с заголовком Idempotency-Key в часах
+	TTLHours int `yaml:"ttl_hours" env-default:"24"`
+}
+
+type Invites struct {
+	// TTLHours - срок действия ссылок-приглашений в часах, если он не указан при создании
+	TTLHours int `yaml:"ttl_hours" env-default:"72"`
+}
+
+type Webhooks struct {
+	// MaxAttempts - количество попыток доставки события, после которого доставка считается неудачной
+	MaxAttempts int `yaml:"max_attempts" env-default:"8"`
+	// TimeoutSeconds - время ожидания ответа получателя в секундах
+	TimeoutSeconds int `yaml:"timeout_seconds" env-default:"10"`
+	// AllowPrivateNetworks разрешает вебхуки на локальные и частные адреса, по умолчанию они запрещены
+	AllowPrivateNetworks bool `yaml:"allow_private_networks" env-default:"false"`
+}
+
+type Outbox struct {
+	// BatchSize - сколько событий публикуется за один проход
+	BatchSize int `yaml:"batch_size" env-default:"100"`
+	// MaxAttempts - количество попыток публикации события, после которого событие пропускается
+	MaxAttempts int `yaml:"max_attempts" env-default:"10"`
+	// RetentionHours - срок хранения опубликованных событий в часах, 0 отключает автоматическую очистку
+	RetentionHours int   `yaml:"retention_hours" env-default:"168"`
+	Nats           Nats  `yaml:"nats"`
+	Kafka          Kafka `yaml:"kafka"`
+}
+
+type Nats struct {
+	// URL - адрес сервера NATS (nats://host:4222), пустое значение отключает публикацию в NATS
+	URL string `yaml:"url"`
+	// Subject - префикс темы, к которому добавляется тип события
+	Subject string `yaml:"subject" env-default:"todo"`
+}
+
+type Kafka struct {
+	// RestProxyURL - адрес Kafka REST Proxy, пустое значение отключает публикацию в Kafka
+	RestProxyURL string `yaml:"rest_proxy_url"`
+	Topic        string `yaml:"topic" env-default:"todo.events"`
+}
+
+type Live struct {
+	// PresenceTTLSeconds - через сколько секунд без признаков жизни соединения пользователь перестает считаться зрителем списка
+	PresenceTTLSeconds int `yaml:"presence_ttl_seconds" env-default:"90"`
+	// SendBuffer - сколько сообщений может ожидать отправки клиенту, прежде чем соединение будет закрыто
+	SendBuffer int `yaml:"send_buffer" env-default:"64"`
 }
 
 type Config struct {
-	DB   DB   `yaml:"db"`
-	Auth Auth `yaml:"auth"`
+	DB          DB          `yaml:"db"`
+	Auth        Auth        `yaml:"auth"`
+	Tasks       Tasks       `yaml:"tasks"`
+	Search      Search      `yaml:"search"`
+	Idempotency Idempotency `yaml:"idempotency"`
+	Invites     Invites     `yaml:"invites"`
+	Webhooks    Webhooks    `yaml:"webhooks"`
+	Outbox      Outbox      `yaml:"outbox"`
+	Live        Live        `yaml:"live"`
 }
 
 func (db *DB) DbConnectionAsString() string {
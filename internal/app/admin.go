@@ -0,0 +1,32 @@
+package app
+
+import (
+	"poymanov/todo/config"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/db"
+	"poymanov/todo/pkg/jwt"
+)
+
+// PromoteAdmin назначает администратором зарегистрированного пользователя с адресом email.
+// Используется для создания первого администратора.
+func PromoteAdmin(email string) error {
+	conf := config.NewConfig()
+	database := db.NewDb(conf)
+
+	services := service.NewServices(repository.NewRepositories(database), jwt.NewJWT(conf.Auth.Secret), conf)
+
+	user, err := services.Admin.Promote(email)
+
+	if err != nil {
+		return err
+	}
+
+	return services.AuditEvent.Record(domain.AuditEvent{
+		Action:    domain.AuditUserRoleChange,
+		TargetId:  &user.ID,
+		Details:   "role=" + domain.UserRoleAdmin,
+		UserAgent: "cmd/admin",
+	})
+}
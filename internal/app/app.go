@@ -9,11 +9,12 @@ import (
 	"poymanov/todo/internal/service"
 	"poymanov/todo/pkg/db"
 	"poymanov/todo/pkg/jwt"
+	"poymanov/todo/pkg/pgnotify"
 )
 
 // @title						To-Do App API
 // @version					1.0
-// @description				API приложения для ведения списка дел
+// @description				API приложения для ведения списка дел. Рабочее пространство запроса выбирается заголовком X-Workspace-Id, без него используется личное пространство пользователя.
 // @contact.name				Николай Пойманов
 // @contact.email				n.poymanov@gmail.com
 //
@@ -31,7 +32,19 @@ func Run() {
 	jwtHelper := jwt.NewJWT(conf.Auth.Secret)
 
 	repositories := repository.NewRepositories(database)
-	services := service.NewServices(repositories, jwtHelper)
+	services := service.NewServices(repositories, jwtHelper, conf).WithEventBus(service.NewEventBus())
+	outboxRelay := newOutboxRelay(repositories, conf.Outbox)
+
+	go runTrashRetention(services.Task, conf.Tasks.TrashRetentionDays)
+	go runAutoArchive(services.Task)
+	go runIdempotencyKeysCleanup(services.IdempotencyKey)
+	go runWebhookDeliveries(services.Webhook)
+	go runOutboxRelay(outboxRelay)
+	go runOutboxCleanup(outboxRelay, conf.Outbox.RetentionHours)
+	go runOutboxListener(pgnotify.NewListener(conf.DB.DbConnectionAsString(), repository.OutboxEventsChannel), services.EventStream)
+	go runPresenceListener(pgnotify.NewListener(conf.DB.DbConnectionAsString(), repository.ListPresenceChannel), services.Live)
+	go runPresenceCleanup(services.Presence)
+	go services.Live.Run()
 
 	handler := controllerHandler.NewHandler(services, jwtHelper)
 	router := handler.Init()
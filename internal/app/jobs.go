@@ -0,0 +1,171 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/pgnotify"
+	"strconv"
+	"time"
+)
+
+const (
+	trashPurgeInterval          = time.Hour
+	autoArchiveInterval         = time.Hour
+	idempotencyKeyPurgeInterval = time.Hour
+	webhookDeliveryInterval     = 10 * time.Second
+	outboxRelayInterval         = time.Second
+	outboxPurgeInterval         = time.Hour
+	presencePurgeInterval       = 30 * time.Second
+	listenRetryDelay            = 5 * time.Second
+)
+
+// runTrashRetention периодически окончательно удаляет задачи, пролежавшие в корзине дольше retentionDays дней
+func runTrashRetention(taskService service.Task, retentionDays int) {
+	if retentionDays <= 0 {
+		return
+	}
+
+	runPeriodically(trashPurgeInterval, func() {
+		purged, err := taskService.PurgeTrash(time.Now().AddDate(0, 0, -retentionDays))
+
+		if err != nil {
+			fmt.Println("failed to purge trash: " + err.Error())
+		} else if purged > 0 {
+			fmt.Printf("Purged %d tasks from trash\n", purged)
+		}
+	})
+}
+
+// runAutoArchive периодически архивирует завершенные задачи пользователей, включивших автоархивацию
+func runAutoArchive(taskService service.Task) {
+	runPeriodically(autoArchiveInterval, func() {
+		archived, err := taskService.ArchiveCompleted()
+
+		if err != nil {
+			fmt.Println("failed to archive completed tasks: " + err.Error())
+		} else if archived > 0 {
+			fmt.Printf("Archived %d completed tasks\n", archived)
+		}
+	})
+}
+
+// runIdempotencyKeysCleanup периодически удаляет сохраненные результаты запросов с истекшим сроком хранения
+func runIdempotencyKeysCleanup(idempotencyKeyService service.IdempotencyKey) {
+	runPeriodically(idempotencyKeyPurgeInterval, func() {
+		purged, err := idempotencyKeyService.PurgeExpired()
+
+		if err != nil {
+			fmt.Println("failed to purge idempotency keys: " + err.Error())
+		} else if purged > 0 {
+			fmt.Printf("Purged %d expired idempotency keys\n", purged)
+		}
+	})
+}
+
+// runWebhookDeliveries периодически отправляет вебхукам события, время доставки которых наступило
+func runWebhookDeliveries(webhookService service.Webhook) {
+	runPeriodically(webhookDeliveryInterval, func() {
+		delivered, err := webhookService.DeliverPending()
+
+		if err != nil {
+			fmt.Println("failed to deliver webhook events: " + err.Error())
+		} else if delivered > 0 {
+			fmt.Printf("Delivered %d webhook events\n", delivered)
+		}
+	})
+}
+
+// runOutboxRelay периодически публикует накопленные доменные события
+func runOutboxRelay(outboxRelayService service.OutboxRelay) {
+	runPeriodically(outboxRelayInterval, func() {
+		if _, err := outboxRelayService.Relay(); err != nil {
+			fmt.Println("failed to publish outbox events: " + err.Error())
+		}
+	})
+}
+
+// runOutboxCleanup периодически удаляет опубликованные события, хранящиеся дольше retentionHours часов
+func runOutboxCleanup(outboxRelayService service.OutboxRelay, retentionHours int) {
+	if retentionHours <= 0 {
+		return
+	}
+
+	runPeriodically(outboxPurgeInterval, func() {
+		purged, err := outboxRelayService.PurgePublished(time.Now().Add(-time.Duration(retentionHours) * time.Hour))
+
+		if err != nil {
+			fmt.Println("failed to purge outbox events: " + err.Error())
+		} else if purged > 0 {
+			fmt.Printf("Purged %d published outbox events\n", purged)
+		}
+	})
+}
+
+// runOutboxListener передает в поток событий этого экземпляра события, опубликованные любым экземпляром приложения.
+// После переподключения досылаются события, опубликованные, пока соединения не было.
+func runOutboxListener(listener *pgnotify.Listener, eventStreamService service.EventStream) {
+	catchUp := func() {
+		if err := eventStreamService.CatchUp(); err != nil {
+			fmt.Println("failed to catch up outbox events: " + err.Error())
+		}
+	}
+
+	runListener(listener, catchUp, func(payload string) {
+		publishedSequence, err := strconv.ParseInt(payload, 10, 64)
+
+		if err != nil {
+			return
+		}
+
+		if err = eventStreamService.Forward(publishedSequence); err != nil {
+			fmt.Println("failed to forward outbox event: " + err.Error())
+		}
+	})
+}
+
+// runPresenceListener сообщает соединениям этого экземпляра об изменении зрителей списков на любом экземпляре приложения
+func runPresenceListener(listener *pgnotify.Listener, liveService service.Live) {
+	runListener(listener, nil, func(payload string) {
+		listId, err := uuid.Parse(payload)
+
+		if err != nil {
+			return
+		}
+
+		liveService.PresenceChanged(listId)
+	})
+}
+
+// runPresenceCleanup периодически убирает из зрителей списков соединения, переставшие подавать признаки жизни
+func runPresenceCleanup(presenceService service.Presence) {
+	runPeriodically(presencePurgeInterval, func() {
+		if _, err := presenceService.PurgeExpired(); err != nil {
+			fmt.Println("failed to purge expired presence: " + err.Error())
+		}
+	})
+}
+
+// runListener передает handler оповещения Postgres NOTIFY. При разрыве соединения с базой данных подписка восстанавливается,
+// после каждой подписки вызывается onListen.
+func runListener(listener *pgnotify.Listener, onListen func(), handler func(payload string)) {
+	for {
+		err := listener.Listen(context.Background(), onListen, handler)
+
+		fmt.Println("notifications listener stopped: " + err.Error())
+		time.Sleep(listenRetryDelay)
+	}
+}
+
+// runPeriodically выполняет job сразу и затем каждые interval
+func runPeriodically(interval time.Duration, job func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		job()
+
+		<-ticker.C
+	}
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"poymanov/todo/config"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/kafka"
+	"poymanov/todo/pkg/nats"
+	"time"
+)
+
+// brokerTimeout - время ожидания подтверждения публикации от брокера сообщений
+const brokerTimeout = 5 * time.Second
+
+// newOutboxRelay создает публикатор доменных событий во вебхуки, потоки событий всех экземпляров приложения
+// и брокеры, включенные в конфигурации
+func newOutboxRelay(repos *repository.Repositories, conf config.Outbox) *service.OutboxRelayService {
+	txSinks := []service.TxEventSink{service.NewWebhookEventSink(), service.NewNotifyEventSink()}
+	sinks := make([]service.EventSink, 0)
+
+	if conf.Nats.URL != "" {
+		sinks = append(sinks, service.NewNatsEventSink(nats.NewClient(conf.Nats.URL, brokerTimeout), conf.Nats.Subject))
+	}
+
+	if conf.Kafka.RestProxyURL != "" {
+		sinks = append(sinks, service.NewKafkaEventSink(kafka.NewClient(conf.Kafka.RestProxyURL, brokerTimeout), conf.Kafka.Topic))
+	}
+
+	return service.NewOutboxRelayService(repos.OutboxEvent, repos.Transactor, txSinks, sinks, conf.BatchSize, conf.MaxAttempts)
+}
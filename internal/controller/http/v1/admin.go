@@ -0,0 +1,262 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/response"
+	"time"
+)
+
+const (
+	ErrFailedToGetUsers        = "failed to get users"
+	ErrFailedToUpdateUser      = "failed to update user"
+	ErrFailedToResetPassword   = "failed to reset password"
+	ErrFailedToCountUserTasks  = "failed to count user tasks"
+	defaultAdminUsersPageLimit = 50
+)
+
+type GetAdminUsersRequest struct {
+	Query  string `form:"query"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=200"`
+	Offset int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+type AdminUserResponse struct {
+	ID                    string     `json:"id"`
+	Name                  string     `json:"name"`
+	Email                 string     `json:"email"`
+	Role                  string     `json:"role"`
+	DisabledAt            *time.Time `json:"disabled_at"`
+	PasswordResetRequired bool       `json:"password_reset_required"`
+	CreatedAt             time.Time  `json:"created_at"`
+}
+
+type PasswordResetResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type UserTaskCountsResponse struct {
+	Total     int64 `json:"total"`
+	Completed int64 `json:"completed"`
+	Archived  int64 `json:"archived"`
+	Trashed   int64 `json:"trashed"`
+}
+
+func (h *Handler) initAdminRoutes(api *gin.RouterGroup) {
+	admin := api.Group("/admin", h.auth, h.admin)
+	{
+		admin.GET("/users", h.getAdminUsers)
+		admin.POST("/users/:id/disable", h.disableUser)
+		admin.POST("/users/:id/enable", h.enableUser)
+		admin.POST("/users/:id/reset-password", h.resetUserPassword)
+		admin.GET("/users/:id/task-counts", h.getUserTaskCounts)
+	}
+}
+
+// @Description	Получение пользователей с поиском по имени и email (только для администраторов)
+// @Tags			admin
+// @Param			query	query		string	false	"Часть имени или email"
+// @Param			limit	query		int		false	"Количество пользователей на странице (по умолчанию 50, не более 200)"
+// @Param			offset	query		int		false	"Смещение от начала выборки"
+// @Success		200		{array}		AdminUserResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		403		{object}	response.ErrorResponse
+// @Failure		422		{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/admin/users [get]
+func (h *Handler) getAdminUsers(c *gin.Context) {
+	var query GetAdminUsersRequest
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	users, err := h.services.Admin.GetUsers(query.Query, query.Limit, query.Offset)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUsers)
+		return
+	}
+
+	var usersResponse = make([]AdminUserResponse, 0)
+
+	for _, user := range *users {
+		usersResponse = append(usersResponse, newAdminUserResponse(user))
+	}
+
+	c.JSON(http.StatusOK, usersResponse)
+}
+
+// @Description	Блокировка учетной записи пользователя (только для администраторов). Заблокированный пользователь не может войти и работать с выданными ранее токенами.
+// @Tags			admin
+// @Param			id	path		string	true	"ID пользователя"
+// @Success		200	{object}	AdminUserResponse
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		403	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Failure		409	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/admin/users/{id}/disable [post]
+func (h *Handler) disableUser(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusNotFound, service.ErrUserNotFound)
+		return
+	}
+
+	user, err := h.services.Admin.Disable(existedUser.ID, id)
+
+	if err != nil {
+		respondAdminError(c, err, ErrFailedToUpdateUser)
+		return
+	}
+
+	h.auditAdminAction(c, existedUser, domain.AuditUserDisable, id)
+
+	c.JSON(http.StatusOK, newAdminUserResponse(*user))
+}
+
+// @Description	Снятие блокировки с учетной записи пользователя (только для администраторов)
+// @Tags			admin
+// @Param			id	path		string	true	"ID пользователя"
+// @Success		200	{object}	AdminUserResponse
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		403	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/admin/users/{id}/enable [post]
+func (h *Handler) enableUser(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusNotFound, service.ErrUserNotFound)
+		return
+	}
+
+	user, err := h.services.Admin.Enable(id)
+
+	if err != nil {
+		respondAdminError(c, err, ErrFailedToUpdateUser)
+		return
+	}
+
+	h.auditAdminAction(c, existedUser, domain.AuditUserEnable, id)
+
+	c.JSON(http.StatusOK, newAdminUserResponse(*user))
+}
+
+// @Description	Принудительный сброс пароля пользователя (только для администраторов). Возвращает токен, по которому пользователь задает новый пароль в /auth/password-reset. До смены пароля пользователь не может войти и работать с выданными ранее токенами.
+// @Tags			admin
+// @Param			id	path		string	true	"ID пользователя"
+// @Success		200	{object}	PasswordResetResponse
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		403	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/admin/users/{id}/reset-password [post]
+func (h *Handler) resetUserPassword(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusNotFound, service.ErrUserNotFound)
+		return
+	}
+
+	reset, err := h.services.Admin.ResetPassword(id)
+
+	if err != nil {
+		respondAdminError(c, err, ErrFailedToResetPassword)
+		return
+	}
+
+	h.auditAdminAction(c, existedUser, domain.AuditPasswordReset, id)
+
+	c.JSON(http.StatusOK, PasswordResetResponse{Token: reset.Token, ExpiresAt: reset.ExpiresAt})
+}
+
+// @Description	Количество задач, созданных пользователем (только для администраторов)
+// @Tags			admin
+// @Param			id	path		string	true	"ID пользователя"
+// @Success		200	{object}	UserTaskCountsResponse
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		403	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/admin/users/{id}/task-counts [get]
+func (h *Handler) getUserTaskCounts(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusNotFound, service.ErrUserNotFound)
+		return
+	}
+
+	counts, err := h.services.Admin.GetTaskCounts(id)
+
+	if err != nil {
+		respondAdminError(c, err, ErrFailedToCountUserTasks)
+		return
+	}
+
+	c.JSON(http.StatusOK, UserTaskCountsResponse{
+		Total:     counts.Total,
+		Completed: counts.Completed,
+		Archived:  counts.Archived,
+		Trashed:   counts.Trashed,
+	})
+}
+
+// auditAdminAction записывает в журнал аудита действие администратора admin над пользователем targetId
+func (h *Handler) auditAdminAction(c *gin.Context, admin *domain.User, action string, targetId uuid.UUID) {
+	h.audit(c, domain.AuditEvent{Action: action, ActorId: &admin.ID, ActorEmail: admin.Email, TargetId: &targetId})
+}
+
+func respondAdminError(c *gin.Context, err error, fallback string) {
+	switch err.Error() {
+	case service.ErrUserNotFound:
+		response.NewErrorResponse(c, http.StatusNotFound, err.Error())
+	case service.ErrCannotDisableSelf:
+		response.NewErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		response.NewErrorResponse(c, http.StatusBadRequest, fallback)
+	}
+}
+
+func newAdminUserResponse(user domain.User) AdminUserResponse {
+	return AdminUserResponse{
+		ID:                    user.ID.String(),
+		Name:                  user.Name,
+		Email:                 user.Email,
+		Role:                  user.Role,
+		DisabledAt:            user.DisabledAt,
+		PasswordResetRequired: user.PasswordResetRequired,
+		CreatedAt:             user.CreatedAt,
+	}
+}
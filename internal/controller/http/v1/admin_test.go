@@ -0,0 +1,395 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/config"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"poymanov/todo/pkg/helpers"
+	"poymanov/todo/pkg/jwt"
+	"testing"
+	"time"
+)
+
+var (
+	fixtureAdminUserId   = uuid.MustParse("9e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a")
+	fixtureAdminTime     = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	fixtureAdminUserJSON = `{"id":"9e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a","name":"John","email":"john@example.com","role":"user",` +
+		`"disabled_at":"2026-10-19T12:00:00Z","password_reset_required":false,"created_at":"2026-10-19T12:00:00Z"}`
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+	disabledAt := time.Now()
+
+	testCases := []struct {
+		name       string
+		user       *domain.User
+		response   string
+		statusCode int
+	}{
+		{
+			name:       "Disabled account",
+			user:       &domain.User{ID: userId, DisabledAt: &disabledAt},
+			response:   `{"message":"Account is disabled"}`,
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "Password reset required",
+			user:       &domain.User{ID: userId, PasswordResetRequired: true},
+			response:   `{"message":"Password reset required"}`,
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "Active account",
+			user:       &domain.User{ID: userId},
+			response:   `"ok"`,
+			statusCode: http.StatusOK,
+		},
+	}
+
+	jwtHelper := jwt.NewJWT("secret")
+	token, err := jwtHelper.Create(jwt.JWTData{Email: "john@example.com"})
+	require.NoError(t, err)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			mockedDatabase, _ := helpers.InitMockDatabase()
+			services := service.NewServices(repository.NewRepositories(mockedDatabase), jwtHelper, &config.Config{})
+
+			userService := mock_service.NewMockUser(c)
+			workspaceService := mock_service.NewMockWorkspace(c)
+
+			userService.EXPECT().FindByEmail("john@example.com").Return(tc.user, nil).AnyTimes()
+			workspaceService.EXPECT().Resolve(userId, nil).Return(&domain.Workspace{ID: fixtureWorkspaceId}, nil).AnyTimes()
+
+			services.User = userService
+			services.Workspace = workspaceService
+			handler := Handler{services: services, jwt: jwtHelper}
+
+			r := gin.New()
+			r.GET("/profile", handler.auth, func(c *gin.Context) {
+				c.JSON(http.StatusOK, "ok")
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/profile", nil)
+			req.Header.Set(authorizationHeader, "Bearer "+token)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestAdminMiddleware(t *testing.T) {
+	testCases := []struct {
+		name       string
+		role       string
+		response   string
+		statusCode int
+	}{
+		{
+			name:       "Regular user",
+			role:       domain.UserRoleUser,
+			response:   `{"message":"Insufficient permissions"}`,
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "Administrator",
+			role:       domain.UserRoleAdmin,
+			response:   `"ok"`,
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userService := mock_service.NewMockUser(c)
+			userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{Role: tc.role}, nil)
+			handler := Handler{services: &service.Services{User: userService}}
+
+			r := gin.New()
+			r.GET("/admin/users", setContextEmail, handler.admin, func(c *gin.Context) {
+				c.JSON(http.StatusOK, "ok")
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/admin/users", nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestGetAdminUsers(t *testing.T) {
+	testCases := []struct {
+		name         string
+		query        string
+		response     string
+		statusCode   int
+		mockFunction func(adminService *mock_service.MockAdmin)
+	}{
+		{
+			name:         "Invalid limit",
+			query:        "?limit=500",
+			response:     `{"message":"Key: 'GetAdminUsersRequest.Limit' Error:Field validation for 'Limit' failed on the 'max' tag"}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(adminService *mock_service.MockAdmin) {},
+		},
+		{
+			name:       "Failed",
+			response:   `{"message":"Failed to get users"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(adminService *mock_service.MockAdmin) {
+				adminService.EXPECT().GetUsers("", 0, 0).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Success",
+			query:      "?query=john&limit=10&offset=20",
+			response:   `[` + fixtureAdminUserJSON + `]`,
+			statusCode: http.StatusOK,
+			mockFunction: func(adminService *mock_service.MockAdmin) {
+				adminService.EXPECT().GetUsers("john", 10, 20).Return(&[]domain.User{newFixtureAdminUser()}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serveAdminRequest(t, tc.mockFunction, "GET", "/admin/users"+tc.query)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestDisableUser(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		id           string
+		response     string
+		statusCode   int
+		mockFunction func(adminService *mock_service.MockAdmin)
+	}{
+		{
+			name:         "Failed to parse id",
+			id:           "user",
+			response:     `{"message":"User not found"}`,
+			statusCode:   http.StatusNotFound,
+			mockFunction: func(adminService *mock_service.MockAdmin) {},
+		},
+		{
+			name:       "Own account",
+			id:         userId.String(),
+			response:   `{"message":"Administrator cannot disable own account"}`,
+			statusCode: http.StatusConflict,
+			mockFunction: func(adminService *mock_service.MockAdmin) {
+				adminService.EXPECT().Disable(userId, userId).Return(nil, errors.New(service.ErrCannotDisableSelf))
+			},
+		},
+		{
+			name:       "Not found",
+			id:         fixtureAdminUserId.String(),
+			response:   `{"message":"User not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(adminService *mock_service.MockAdmin) {
+				adminService.EXPECT().Disable(userId, fixtureAdminUserId).Return(nil, errors.New(service.ErrUserNotFound))
+			},
+		},
+		{
+			name:       "Success",
+			id:         fixtureAdminUserId.String(),
+			response:   fixtureAdminUserJSON,
+			statusCode: http.StatusOK,
+			mockFunction: func(adminService *mock_service.MockAdmin) {
+				user := newFixtureAdminUser()
+				adminService.EXPECT().Disable(userId, fixtureAdminUserId).Return(&user, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serveAdminRequest(t, tc.mockFunction, "POST", "/admin/users/"+tc.id+"/disable")
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestEnableUser(t *testing.T) {
+	testCases := []struct {
+		name         string
+		response     string
+		statusCode   int
+		mockFunction func(adminService *mock_service.MockAdmin)
+	}{
+		{
+			name:       "Failed",
+			response:   `{"message":"Failed to update user"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(adminService *mock_service.MockAdmin) {
+				adminService.EXPECT().Enable(fixtureAdminUserId).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Success",
+			response:   `"disabled_at":null`,
+			statusCode: http.StatusOK,
+			mockFunction: func(adminService *mock_service.MockAdmin) {
+				adminService.EXPECT().Enable(fixtureAdminUserId).Return(&domain.User{ID: fixtureAdminUserId}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serveAdminRequest(t, tc.mockFunction, "POST", "/admin/users/"+fixtureAdminUserId.String()+"/enable")
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Contains(t, w.Body.String(), tc.response)
+		})
+	}
+}
+
+func TestResetUserPassword(t *testing.T) {
+	testCases := []struct {
+		name         string
+		response     string
+		statusCode   int
+		mockFunction func(adminService *mock_service.MockAdmin)
+	}{
+		{
+			name:       "Not found",
+			response:   `{"message":"User not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(adminService *mock_service.MockAdmin) {
+				adminService.EXPECT().ResetPassword(fixtureAdminUserId).Return(nil, errors.New(service.ErrUserNotFound))
+			},
+		},
+		{
+			name:       "Success",
+			response:   `{"token":"token","expires_at":"2026-10-19T12:00:00Z"}`,
+			statusCode: http.StatusOK,
+			mockFunction: func(adminService *mock_service.MockAdmin) {
+				adminService.EXPECT().ResetPassword(fixtureAdminUserId).
+					Return(&service.PasswordReset{Token: "token", ExpiresAt: fixtureAdminTime}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serveAdminRequest(t, tc.mockFunction, "POST", "/admin/users/"+fixtureAdminUserId.String()+"/reset-password")
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestGetUserTaskCounts(t *testing.T) {
+	testCases := []struct {
+		name         string
+		response     string
+		statusCode   int
+		mockFunction func(adminService *mock_service.MockAdmin)
+	}{
+		{
+			name:       "Failed",
+			response:   `{"message":"Failed to count user tasks"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(adminService *mock_service.MockAdmin) {
+				adminService.EXPECT().GetTaskCounts(fixtureAdminUserId).Return(domain.UserTaskCounts{}, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Success",
+			response:   `{"total":10,"completed":4,"archived":2,"trashed":1}`,
+			statusCode: http.StatusOK,
+			mockFunction: func(adminService *mock_service.MockAdmin) {
+				adminService.EXPECT().GetTaskCounts(fixtureAdminUserId).
+					Return(domain.UserTaskCounts{Total: 10, Completed: 4, Archived: 2, Trashed: 1}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serveAdminRequest(t, tc.mockFunction, "GET", "/admin/users/"+fixtureAdminUserId.String()+"/task-counts")
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func newFixtureAdminUser() domain.User {
+	return domain.User{
+		ID:         fixtureAdminUserId,
+		Name:       "John",
+		Email:      "john@example.com",
+		Role:       domain.UserRoleUser,
+		DisabledAt: &fixtureAdminTime,
+		CreatedAt:  fixtureAdminTime,
+	}
+}
+
+func serveAdminRequest(
+	t *testing.T,
+	mockFunction func(adminService *mock_service.MockAdmin),
+	method, url string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	userService := mock_service.NewMockUser(c)
+	adminService := mock_service.NewMockAdmin(c)
+	auditEventService := mock_service.NewMockAuditEvent(c)
+
+	userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId, Role: domain.UserRoleAdmin}, nil).AnyTimes()
+	auditEventService.EXPECT().Record(gomock.Any()).Return(nil).AnyTimes()
+	mockFunction(adminService)
+	handler := Handler{services: &service.Services{User: userService, Admin: adminService, AuditEvent: auditEventService}}
+
+	r := gin.New()
+	r.GET("/admin/users", setContextEmail, handler.getAdminUsers)
+	r.POST("/admin/users/:id/disable", setContextEmail, handler.disableUser)
+	r.POST("/admin/users/:id/enable", setContextEmail, handler.enableUser)
+	r.POST("/admin/users/:id/reset-password", setContextEmail, handler.resetUserPassword)
+	r.GET("/admin/users/:id/task-counts", setContextEmail, handler.getUserTaskCounts)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, url, bytes.NewBufferString(""))
+	r.ServeHTTP(w, req)
+
+	return w
+}
@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/response"
+	"time"
+)
+
+const ErrFailedToUnarchiveTask = "failed to unarchive task"
+
+type ArchivedTaskResponse struct {
+	Id          string     `json:"id"`
+	ListId      *string    `json:"list_id"`
+	Description string     `json:"description"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	ArchivedAt  time.Time  `json:"archived_at"`
+}
+
+func (h *Handler) initArchiveRoutes(api *gin.RouterGroup) {
+	tasks := api.Group("/tasks", h.auth)
+	{
+		tasks.GET("/archive", h.getArchive)
+		tasks.POST("/:id/unarchive", h.unarchiveTask)
+	}
+}
+
+// @Description	Получение архивных задач пользователя
+// @Tags			archive
+// @Param			If-None-Match	header	string	false	"ETag ранее полученного ответа"
+// @Success		200				{array}	ArchivedTaskResponse
+// @Success		304
+// @Failure		400	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/archive [get]
+func (h *Handler) getArchive(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	tasks := h.scoped(c).Task.GetArchive(existedUser.ID)
+	archiveResponse := make([]ArchivedTaskResponse, 0, len(*tasks))
+
+	for _, task := range *tasks {
+		archiveResponse = append(archiveResponse, ArchivedTaskResponse{
+			Id:          task.ID.String(),
+			ListId:      uuidToString(task.ListId),
+			Description: task.Description,
+			CreatedAt:   task.CreatedAt,
+			CompletedAt: task.CompletedAt,
+			ArchivedAt:  *task.ArchivedAt,
+		})
+	}
+
+	writeJSONWithETag(c, archiveResponse)
+}
+
+// @Description	Возврат задачи из архива
+// @Tags			archive
+// @Param			id	path	string	true	"ID задачи"
+// @Success		204
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		403	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/{id}/unarchive [post]
+func (h *Handler) unarchiveTask(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	task, err := h.getUserTask(c, "id", existedUser.ID, domain.ListRoleEditor)
+
+	if err != nil {
+		respondAccessError(c, err, ErrTaskNotFound)
+		return
+	}
+
+	if err = h.scoped(c).Task.WithActor(existedUser.ID).Unarchive(task.ID); err != nil {
+		if err.Error() == service.ErrTaskIsNotArchived {
+			response.NewErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToUnarchiveTask)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,156 @@
+package v1
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"testing"
+	"time"
+)
+
+func TestGetArchive(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, taskService *mock_service.MockTask)
+	}{
+		{
+			name:       "Not existed user",
+			response:   `{"message":"Failed to get user"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Success",
+			response:   `[{"id":"8d306d55-4301-4770-8a90-e64f771dc3f9","list_id":null,"description":"Description","created_at":"2026-10-01T10:00:00Z","completed_at":"2026-10-02T10:00:00Z","archived_at":"2026-10-19T10:00:00Z"}]`,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				taskId, _ := uuid.Parse("8d306d55-4301-4770-8a90-e64f771dc3f9")
+				completedAt := time.Date(2026, 10, 2, 10, 0, 0, 0, time.UTC)
+				archivedAt := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().GetArchive(userId).Return(&[]domain.Task{{
+					ID:          taskId,
+					Description: "Description",
+					CreatedAt:   time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC),
+					CompletedAt: &completedAt,
+					ArchivedAt:  &archivedAt,
+				}})
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			taskService := mock_service.NewMockTask(c)
+
+			tc.mockFunction(userService, taskService)
+			handler := Handler{services: &service.Services{User: userService, Task: taskService}}
+
+			r := gin.New()
+			r.GET("/tasks/archive", setContextEmail, handler.getArchive)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/tasks/archive", nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestUnarchiveTask(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, taskService *mock_service.MockTask)
+	}{
+		{
+			name:       "Task of another user",
+			response:   `{"message":"Task not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{}, nil)
+			},
+		},
+		{
+			name:       "Task is not archived",
+			response:   `{"message":"Task is not archived"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().Unarchive(gomock.Any()).Return(errors.New(service.ErrTaskIsNotArchived))
+			},
+		},
+		{
+			name:       "Failed to unarchive",
+			response:   `{"message":"Failed to unarchive task"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().Unarchive(gomock.Any()).Return(errors.New("failed"))
+			},
+		},
+		{
+			name:       "Success",
+			response:   ``,
+			statusCode: http.StatusNoContent,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().Unarchive(gomock.Any()).Return(nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			taskService := mock_service.NewMockTask(c)
+
+			tc.mockFunction(userService, taskService)
+			handler := Handler{services: &service.Services{User: userService, Task: taskService}}
+
+			r := gin.New()
+			r.POST("/tasks/:id/unarchive", setContextEmail, handler.unarchiveTask)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/tasks/"+faker.UUIDHyphenated()+"/unarchive", nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package v1
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/pkg/response"
+	"strings"
+	"time"
+)
+
+const ErrFailedToGetAuditEvents = "failed to get audit events"
+
+// auditExportTruncatedHeader - заголовок ответа, сообщающий, что выгрузка содержит не все записи по фильтру
+const auditExportTruncatedHeader = "X-Export-Truncated"
+
+// csvFormulaPrefixes - первые символы, с которых табличные редакторы начинают формулу
+const csvFormulaPrefixes = "=+-@\t\r"
+
+type GetAuditEventsRequest struct {
+	Action   string     `form:"action"`
+	ActorId  string     `form:"actor_id" binding:"omitempty,uuid"`
+	TargetId string     `form:"target_id" binding:"omitempty,uuid"`
+	From     *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To       *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit    int        `form:"limit" binding:"omitempty,min=1,max=1000"`
+	Offset   int        `form:"offset" binding:"omitempty,min=0"`
+	Format   string     `form:"format" binding:"omitempty,oneof=json csv"`
+}
+
+type AuditEventResponse struct {
+	Id         string    `json:"id"`
+	Action     string    `json:"action"`
+	ActorId    *string   `json:"actor_id"`
+	ActorEmail string    `json:"actor_email"`
+	TargetId   *string   `json:"target_id"`
+	Details    string    `json:"details"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	RequestId  string    `json:"request_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (h *Handler) initAuditEventsRoutes(api *gin.RouterGroup) {
+	api.GET("/admin/audit-events", h.auth, h.admin, h.getAuditEvents)
+}
+
+// @Description	Журнал аудита: входы, смена пароля и ролей, действия администраторов (только для администраторов). В формате csv выгружаются записи по фильтру без учета limit и offset, но не более 100000; если записей больше, выгрузка содержит последние из них и заголовок X-Export-Truncated.
+// @Tags			admin
+// @Produce		json
+// @Produce		text/csv
+// @Param			action		query		string	false	"Действие, например auth.login_failed"
+// @Param			actor_id	query		string	false	"ID пользователя, выполнившего действие"
+// @Param			target_id	query		string	false	"ID пользователя или объекта, над которым выполнено действие"
+// @Param			from		query		string	false	"Начало периода (RFC 3339)"
+// @Param			to			query		string	false	"Конец периода, не включая (RFC 3339)"
+// @Param			limit		query		int		false	"Количество записей на странице (по умолчанию 100, не более 1000)"
+// @Param			offset		query		int		false	"Смещение от начала выборки"
+// @Param			format		query		string	false	"Формат ответа"	Enums(json, csv)
+// @Success		200			{array}		AuditEventResponse
+// @Header		200			{string}	X-Export-Truncated	"true, если выгрузка csv содержит не все записи по фильтру"
+// @Failure		400			{object}	response.ErrorResponse
+// @Failure		403			{object}	response.ErrorResponse
+// @Failure		422			{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/admin/audit-events [get]
+func (h *Handler) getAuditEvents(c *gin.Context) {
+	var query GetAuditEventsRequest
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	filter := domain.AuditFilter{
+		Action:   query.Action,
+		ActorId:  parseOptionalUuid(query.ActorId),
+		TargetId: parseOptionalUuid(query.TargetId),
+		From:     query.From,
+		To:       query.To,
+	}
+
+	var events *[]domain.AuditEvent
+	var truncated bool
+	var err error
+
+	if query.Format == "csv" {
+		events, truncated, err = h.services.AuditEvent.Export(filter)
+	} else {
+		events, err = h.services.AuditEvent.Search(filter, query.Limit, query.Offset)
+	}
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetAuditEvents)
+		return
+	}
+
+	eventsResponse := make([]AuditEventResponse, 0, len(*events))
+
+	for _, event := range *events {
+		eventsResponse = append(eventsResponse, newAuditEventResponse(event))
+	}
+
+	if query.Format == "csv" {
+		if truncated {
+			c.Header(auditExportTruncatedHeader, "true")
+		}
+
+		writeAuditEventsCSV(c, eventsResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, eventsResponse)
+}
+
+// audit записывает в журнал аудита событие, дополняя его адресом клиента, User-Agent и идентификатором запроса.
+// Ошибка записи не прерывает обработку запроса.
+func (h *Handler) audit(c *gin.Context, event domain.AuditEvent) {
+	event.IP = c.ClientIP()
+	event.UserAgent = c.Request.UserAgent()
+	event.RequestId = c.GetString(contextRequestIdKey)
+
+	if err := h.services.AuditEvent.Record(event); err != nil {
+		fmt.Println("failed to record audit event " + event.Action + ": " + err.Error())
+	}
+}
+
+func writeAuditEventsCSV(c *gin.Context, rows []AuditEventResponse) {
+	c.Header("Content-Disposition", `attachment; filename="audit-events.csv"`)
+	c.Status(http.StatusOK)
+	c.Writer.Header().Set("Content-Type", "text/csv; charset=utf-8")
+
+	writer := csv.NewWriter(c.Writer)
+	_ = writer.Write([]string{"id", "created_at", "action", "actor_id", "actor_email", "target_id", "details", "ip", "user_agent", "request_id"})
+
+	for _, row := range rows {
+		_ = writer.Write([]string{
+			row.Id,
+			row.CreatedAt.Format(time.RFC3339),
+			csvCell(row.Action),
+			stringOrEmpty(row.ActorId),
+			csvCell(row.ActorEmail),
+			stringOrEmpty(row.TargetId),
+			csvCell(row.Details),
+			csvCell(row.IP),
+			csvCell(row.UserAgent),
+			csvCell(row.RequestId),
+		})
+	}
+
+	writer.Flush()
+}
+
+// csvCell экранирует значение, которое табличный редактор принял бы за формулу
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune(csvFormulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+// parseOptionalUuid возвращает nil для пустой строки. Формат непустой строки проверяется при разборе запроса.
+func parseOptionalUuid(value string) *uuid.UUID {
+	id, err := uuid.Parse(value)
+
+	if err != nil {
+		return nil
+	}
+
+	return &id
+}
+
+func stringOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
+
+func newAuditEventResponse(event domain.AuditEvent) AuditEventResponse {
+	return AuditEventResponse{
+		Id:         event.ID.String(),
+		Action:     event.Action,
+		ActorId:    uuidToString(event.ActorId),
+		ActorEmail: event.ActorEmail,
+		TargetId:   uuidToString(event.TargetId),
+		Details:    event.Details,
+		IP:         event.IP,
+		UserAgent:  event.UserAgent,
+		RequestId:  event.RequestId,
+		CreatedAt:  event.CreatedAt,
+	}
+}
@@ -0,0 +1,150 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"testing"
+	"time"
+)
+
+func TestGetAuditEvents(t *testing.T) {
+	actorId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	event := domain.AuditEvent{
+		ID:         fixtureAdminUserId,
+		Action:     domain.AuditUserDisable,
+		ActorId:    &actorId,
+		ActorEmail: "admin@example.com",
+		TargetId:   &fixtureMemberId,
+		IP:         "192.0.2.1",
+		UserAgent:  "curl/8.0",
+		RequestId:  "request-1",
+		CreatedAt:  fixtureAdminTime,
+	}
+
+	testCases := []struct {
+		name         string
+		query        string
+		response     string
+		statusCode   int
+		mockFunction func(auditEventService *mock_service.MockAuditEvent)
+	}{
+		{
+			name:         "Invalid actor id",
+			query:        "?actor_id=actor",
+			response:     `{"message":"Key: 'GetAuditEventsRequest.ActorId' Error:Field validation for 'ActorId' failed on the 'uuid' tag"}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(auditEventService *mock_service.MockAuditEvent) {},
+		},
+		{
+			name:       "Failed",
+			response:   `{"message":"Failed to get audit events"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(auditEventService *mock_service.MockAuditEvent) {
+				auditEventService.EXPECT().Search(domain.AuditFilter{}, 0, 0).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:  "Filtered",
+			query: "?action=admin.user_disable&actor_id=" + actorId.String() + "&from=2026-10-01T00:00:00Z&limit=10",
+			response: `[{"id":"9e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a","action":"admin.user_disable",` +
+				`"actor_id":"64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b","actor_email":"admin@example.com",` +
+				`"target_id":"` + fixtureMemberId.String() + `","details":"","ip":"192.0.2.1","user_agent":"curl/8.0",` +
+				`"request_id":"request-1","created_at":"2026-10-19T12:00:00Z"}]`,
+			statusCode: http.StatusOK,
+			mockFunction: func(auditEventService *mock_service.MockAuditEvent) {
+				auditEventService.EXPECT().Search(gomock.Any(), 10, 0).
+					DoAndReturn(func(filter domain.AuditFilter, limit, offset int) (*[]domain.AuditEvent, error) {
+						require.Equal(t, domain.AuditUserDisable, filter.Action)
+						require.Equal(t, actorId, *filter.ActorId)
+						require.Nil(t, filter.TargetId)
+						require.True(t, from.Equal(*filter.From))
+						require.Nil(t, filter.To)
+
+						return &[]domain.AuditEvent{event}, nil
+					})
+			},
+		},
+		{
+			name:  "CSV",
+			query: "?format=csv&limit=10",
+			response: "id,created_at,action,actor_id,actor_email,target_id,details,ip,user_agent,request_id\n" +
+				"9e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a,2026-10-19T12:00:00Z,admin.user_disable,64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b," +
+				"admin@example.com," + fixtureMemberId.String() + ",,192.0.2.1,curl/8.0,request-1\n",
+			statusCode: http.StatusOK,
+			mockFunction: func(auditEventService *mock_service.MockAuditEvent) {
+				auditEventService.EXPECT().Export(domain.AuditFilter{}).Return(&[]domain.AuditEvent{event}, false, nil)
+			},
+		},
+		{
+			name:  "CSV formulas",
+			query: "?format=csv",
+			response: "id,created_at,action,actor_id,actor_email,target_id,details,ip,user_agent,request_id\n" +
+				"9e8d7c6b-5a49-4382-9170-6f5e4d3c2b1a,2026-10-19T12:00:00Z,auth.login_failed,,\"'=HYPERLINK(\"\"x\"\")\",,'+1,'-1,'@SUM(A1),'\tid\n",
+			statusCode: http.StatusOK,
+			mockFunction: func(auditEventService *mock_service.MockAuditEvent) {
+				auditEventService.EXPECT().Export(domain.AuditFilter{}).Return(&[]domain.AuditEvent{{
+					ID:         fixtureAdminUserId,
+					Action:     domain.AuditLoginFailed,
+					ActorEmail: `=HYPERLINK("x")`,
+					Details:    "+1",
+					IP:         "-1",
+					UserAgent:  "@SUM(A1)",
+					RequestId:  "\tid",
+					CreatedAt:  fixtureAdminTime,
+				}}, false, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auditEventService := mock_service.NewMockAuditEvent(c)
+			tc.mockFunction(auditEventService)
+			handler := Handler{services: &service.Services{AuditEvent: auditEventService}}
+
+			r := gin.New()
+			r.GET("/admin/audit-events", handler.getAuditEvents)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/admin/audit-events"+tc.query, bytes.NewBufferString(""))
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+			require.Empty(t, w.Header().Get("X-Export-Truncated"))
+		})
+	}
+}
+
+func TestGetAuditEvents_CSVTruncated(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	auditEventService := mock_service.NewMockAuditEvent(c)
+	auditEventService.EXPECT().Export(domain.AuditFilter{}).Return(&[]domain.AuditEvent{}, true, nil)
+	handler := Handler{services: &service.Services{AuditEvent: auditEventService}}
+
+	r := gin.New()
+	r.GET("/admin/audit-events", handler.getAuditEvents)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/admin/audit-events?format=csv", bytes.NewBufferString(""))
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "true", w.Header().Get("X-Export-Truncated"))
+}
@@ -3,6 +3,7 @@ package v1
 import (
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"poymanov/todo/internal/domain"
 	"poymanov/todo/internal/service"
 	"poymanov/todo/pkg/response"
 )
@@ -26,9 +27,15 @@ type LoginResponse struct {
 	Token string `json:"token"`
 }
 
+type PasswordResetRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
 func (h *Handler) initAuthRoutes(api *gin.RouterGroup) {
 	api.POST("/auth/register", h.register)
 	api.POST("/auth/login", h.login)
+	api.POST("/auth/password-reset", h.resetPassword)
 }
 
 // @Description	Регистрация пользователя
@@ -57,6 +64,8 @@ func (h *Handler) register(c *gin.Context) {
 		return
 	}
 
+	h.audit(c, domain.AuditEvent{Action: domain.AuditRegister, ActorEmail: body.Email})
+
 	c.JSON(http.StatusOK, RegisterResponse{Token: token})
 }
 
@@ -65,6 +74,7 @@ func (h *Handler) register(c *gin.Context) {
 // @Param			login	body		LoginRequest	true	"Данные зарегистрированного  пользователя"
 // @Success		200		{object}	LoginResponse
 // @Failure		400		{object}	response.ErrorResponse
+// @Failure		403		{object}	response.ErrorResponse
 // @Failure		422		{object}	response.ErrorResponse
 // @Router			/auth/login [post]
 func (h *Handler) login(c *gin.Context) {
@@ -81,9 +91,50 @@ func (h *Handler) login(c *gin.Context) {
 	})
 
 	if err != nil {
-		response.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		h.audit(c, domain.AuditEvent{Action: domain.AuditLoginFailed, ActorEmail: body.Email, Details: err.Error()})
+		respondAuthError(c, err)
 		return
 	}
 
+	h.audit(c, domain.AuditEvent{Action: domain.AuditLogin, ActorEmail: body.Email})
+
 	c.JSON(http.StatusOK, LoginResponse{Token: token})
 }
+
+// @Description	Смена пароля по токену, выданному администратором при сбросе пароля
+// @Tags			auth
+// @Param			data	body		PasswordResetRequest	true	"Токен сброса и новый пароль"
+// @Success		200		{object}	LoginResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		403		{object}	response.ErrorResponse
+// @Failure		422		{object}	response.ErrorResponse
+// @Router			/auth/password-reset [post]
+func (h *Handler) resetPassword(c *gin.Context) {
+	var body PasswordResetRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	token, err := h.services.Auth.ResetPassword(body.Token, body.Password)
+
+	if err != nil {
+		respondAuthError(c, err)
+		return
+	}
+
+	userId, _ := h.jwt.ParsePasswordReset(body.Token)
+	h.audit(c, domain.AuditEvent{Action: domain.AuditPasswordChange, ActorId: parseOptionalUuid(userId)})
+
+	c.JSON(http.StatusOK, LoginResponse{Token: token})
+}
+
+func respondAuthError(c *gin.Context, err error) {
+	switch err.Error() {
+	case service.ErrUserDisabled, service.ErrPasswordResetRequired:
+		response.NewErrorResponse(c, http.StatusForbidden, err.Error())
+	default:
+		response.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+}
@@ -2,13 +2,16 @@ package v1
 
 import (
 	"bytes"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"net/http"
 	"net/http/httptest"
+	"poymanov/todo/internal/domain"
 	"poymanov/todo/internal/service"
 	mock_service "poymanov/todo/internal/service/mocks"
+	"poymanov/todo/pkg/jwt"
 	"testing"
 )
 
@@ -72,8 +75,10 @@ func TestAuthRegister(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			authService := mock_service.NewMockAuth(c)
+			auditEventService := mock_service.NewMockAuditEvent(c)
+			auditEventService.EXPECT().Record(gomock.Any()).Return(nil).AnyTimes()
 			tc.mockFunction(authService)
-			handler := Handler{services: &service.Services{Auth: authService}}
+			handler := Handler{services: &service.Services{Auth: authService, AuditEvent: auditEventService}}
 
 			r := gin.New()
 			r.POST("/auth/register", handler.register)
@@ -124,6 +129,15 @@ func TestAuthLogin(t *testing.T) {
 			statusCode:   http.StatusUnprocessableEntity,
 			mockFunction: func(authService *mock_service.MockAuth) {},
 		},
+		{
+			name:       "Disabled account",
+			body:       `{"email": "test@test.com", "password": "test"}`,
+			response:   `{"message":"Account is disabled"}`,
+			statusCode: http.StatusForbidden,
+			mockFunction: func(authService *mock_service.MockAuth) {
+				authService.EXPECT().Login(gomock.Any()).Return("", errors.New(service.ErrUserDisabled))
+			},
+		},
 		{
 			name:       "Success",
 			body:       `{"email": "test@test.com", "password": "test"}`,
@@ -141,8 +155,10 @@ func TestAuthLogin(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			authService := mock_service.NewMockAuth(c)
+			auditEventService := mock_service.NewMockAuditEvent(c)
+			auditEventService.EXPECT().Record(gomock.Any()).Return(nil).AnyTimes()
 			tc.mockFunction(authService)
-			handler := Handler{services: &service.Services{Auth: authService}}
+			handler := Handler{services: &service.Services{Auth: authService, AuditEvent: auditEventService}}
 
 			r := gin.New()
 			r.POST("/auth/login", handler.login)
@@ -159,3 +175,113 @@ func TestAuthLogin(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthResetPassword(t *testing.T) {
+	testCases := []struct {
+		name         string
+		body         string
+		response     string
+		statusCode   int
+		mockFunction func(authService *mock_service.MockAuth)
+	}{
+		{
+			name:         "Missing token",
+			body:         `{"password": "test"}`,
+			response:     `{"message":"Key: 'PasswordResetRequest.Token' Error:Field validation for 'Token' failed on the 'required' tag"}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(authService *mock_service.MockAuth) {},
+		},
+		{
+			name:       "Invalid token",
+			body:       `{"token": "token", "password": "test"}`,
+			response:   `{"message":"Password reset token is invalid or expired"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(authService *mock_service.MockAuth) {
+				authService.EXPECT().ResetPassword("token", "test").Return("", errors.New(service.ErrInvalidPasswordResetToken))
+			},
+		},
+		{
+			name:       "Success",
+			body:       `{"token": "token", "password": "test"}`,
+			response:   `{"token":"auth"}`,
+			statusCode: http.StatusOK,
+			mockFunction: func(authService *mock_service.MockAuth) {
+				authService.EXPECT().ResetPassword("token", "test").Return("auth", nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			authService := mock_service.NewMockAuth(c)
+			auditEventService := mock_service.NewMockAuditEvent(c)
+			auditEventService.EXPECT().Record(gomock.Any()).Return(nil).AnyTimes()
+			tc.mockFunction(authService)
+			handler := Handler{services: &service.Services{Auth: authService, AuditEvent: auditEventService}, jwt: jwt.NewJWT("secret")}
+
+			r := gin.New()
+			r.POST("/auth/password-reset", handler.resetPassword)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/auth/password-reset", bytes.NewBufferString(tc.body))
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestAuthLoginAudit(t *testing.T) {
+	testCases := []struct {
+		name    string
+		err     error
+		action  string
+		details string
+	}{
+		{
+			name:    "Failed",
+			err:     errors.New(service.ErrWrongCredentials),
+			action:  domain.AuditLoginFailed,
+			details: service.ErrWrongCredentials,
+		},
+		{
+			name:   "Success",
+			action: domain.AuditLogin,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			authService := mock_service.NewMockAuth(c)
+			auditEventService := mock_service.NewMockAuditEvent(c)
+
+			authService.EXPECT().Login(gomock.Any()).Return("token", tc.err)
+			auditEventService.EXPECT().Record(domain.AuditEvent{
+				Action:     tc.action,
+				ActorEmail: "test@test.com",
+				Details:    tc.details,
+				IP:         "192.0.2.1",
+				UserAgent:  "test-agent",
+				RequestId:  "request-1",
+			}).Return(nil)
+
+			handler := Handler{services: &service.Services{Auth: authService, AuditEvent: auditEventService}}
+
+			r := gin.New()
+			r.POST("/auth/login", handler.requestId, handler.login)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/auth/login", bytes.NewBufferString(`{"email": "test@test.com", "password": "test"}`))
+			req.Header.Set("User-Agent", "test-agent")
+			req.Header.Set(requestIdHeader, "request-1")
+			r.ServeHTTP(w, req)
+		})
+	}
+}
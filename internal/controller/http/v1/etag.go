@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/response"
+	"strconv"
+	"strings"
+)
+
+const ErrTaskVersionMismatch = "task has been modified, reload it and try again"
+
+// taskETag возвращает ETag задачи, построенный по номеру ее версии
+func taskETag(task domain.Task) string {
+	return `"` + strconv.Itoa(task.Version) + `"`
+}
+
+// checkTaskPrecondition проверяет заголовок If-Match запроса на изменение задачи. Запрос без заголовка
+// допускается, при несовпадении версии клиенту возвращается 412 и проверка не проходит.
+func checkTaskPrecondition(c *gin.Context, task domain.Task) bool {
+	ifMatch := c.GetHeader("If-Match")
+
+	if ifMatch == "" || matchETag(ifMatch, taskETag(task), false) {
+		return true
+	}
+
+	c.Header("ETag", taskETag(task))
+	response.NewErrorResponse(c, http.StatusPreconditionFailed, ErrTaskVersionMismatch)
+
+	return false
+}
+
+// withTaskPrecondition возвращает сервис задач, который для запроса с If-Match изменяет задачу, только если
+// в момент записи ее версия совпадает с проверенной checkTaskPrecondition. Так изменение, сделанное другим
+// запросом между проверкой и записью, не будет перезаписано.
+func withTaskPrecondition(c *gin.Context, taskService service.Task, task domain.Task) service.Task {
+	if c.GetHeader("If-Match") == "" {
+		return taskService
+	}
+
+	return taskService.WithVersion(task.Version)
+}
+
+// matchETag проверяет, содержит ли значение заголовка If-Match или If-None-Match тег etag.
+// При weak слабые теги (W/) сравниваются без учета префикса, как требует If-None-Match.
+func matchETag(header, etag string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+
+		if candidate == "*" {
+			return true
+		}
+
+		if weak {
+			candidate = strings.TrimPrefix(candidate, "W/")
+			etag = strings.TrimPrefix(etag, "W/")
+		}
+
+		if candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeJSONWithETag отвечает 200 с телом obj и слабым ETag по его содержимому.
+// Если ETag совпадает с If-None-Match запроса, вместо тела возвращается 304.
+func writeJSONWithETag(c *gin.Context, obj any) {
+	body, err := json.Marshal(obj)
+
+	if err != nil {
+		c.JSON(http.StatusOK, obj)
+		return
+	}
+
+	hash := sha256.Sum256(body)
+	etag := `W/"` + hex.EncodeToString(hash[:16]) + `"`
+
+	c.Header("ETag", etag)
+
+	if ifNoneMatch := c.GetHeader("If-None-Match"); ifNoneMatch != "" && matchETag(ifNoneMatch, etag, true) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.JSON(http.StatusOK, obj)
+}
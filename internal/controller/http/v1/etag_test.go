@@ -0,0 +1,94 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"testing"
+)
+
+func TestMatchETag(t *testing.T) {
+	testCases := []struct {
+		name     string
+		header   string
+		etag     string
+		weak     bool
+		expected bool
+	}{
+		{name: "Equal", header: `"3"`, etag: `"3"`, expected: true},
+		{name: "Different", header: `"2"`, etag: `"3"`, expected: false},
+		{name: "List", header: `"1", "3"`, etag: `"3"`, expected: true},
+		{name: "Any", header: `*`, etag: `"3"`, expected: true},
+		{name: "Weak in strong comparison", header: `W/"3"`, etag: `"3"`, expected: false},
+		{name: "Weak in weak comparison", header: `"abc"`, etag: `W/"abc"`, weak: true, expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, matchETag(tc.header, tc.etag, tc.weak))
+		})
+	}
+}
+
+func TestCheckTaskPrecondition(t *testing.T) {
+	testCases := []struct {
+		name       string
+		ifMatch    string
+		response   string
+		statusCode int
+	}{
+		{name: "Without header", ifMatch: "", response: ``, statusCode: http.StatusNoContent},
+		{name: "Actual version", ifMatch: `"4"`, response: ``, statusCode: http.StatusNoContent},
+		{
+			name:       "Stale version",
+			ifMatch:    `"3"`,
+			response:   `{"message":"Task has been modified, reload it and try again"}`,
+			statusCode: http.StatusPreconditionFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := gin.New()
+			r.PATCH("/tasks", func(c *gin.Context) {
+				if checkTaskPrecondition(c, domain.Task{Version: 4}) {
+					c.Status(http.StatusNoContent)
+				}
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PATCH", "/tasks", nil)
+			req.Header.Set("If-Match", tc.ifMatch)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestWriteJSONWithETag(t *testing.T) {
+	r := gin.New()
+	r.GET("/tasks", func(c *gin.Context) {
+		writeJSONWithETag(c, []string{"task"})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/tasks", nil))
+
+	etag := w.Header().Get("ETag")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `["task"]`, w.Body.String())
+	require.Regexp(t, `^W/"[0-9a-f]{32}"$`, etag)
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/tasks", nil)
+	req.Header.Set("If-None-Match", etag)
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotModified, w.Code)
+	require.Empty(t, w.Body.String())
+}
@@ -0,0 +1,134 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/pkg/response"
+	"strconv"
+	"time"
+)
+
+const (
+	ErrInvalidLastEventId = "invalid last event id"
+)
+
+const (
+	lastEventIdHeader = "Last-Event-ID"
+	// eventStreamHeartbeatInterval - период отправки комментария, не дающего прокси закрыть простаивающее соединение
+	eventStreamHeartbeatInterval = 15 * time.Second
+	// eventStreamRetry - через сколько миллисекунд клиенту переподключаться после разрыва соединения
+	eventStreamRetry = 3000
+	// eventStreamReset - событие, после которого клиенту нужно заново загрузить задачи: пропущенные события досылать нельзя
+	eventStreamReset = "reset"
+)
+
+func (h *Handler) initEventsRoutes(api *gin.RouterGroup) {
+	api.GET("/events", h.auth, h.streamEvents)
+}
+
+// @Description	Поток событий задач (Server-Sent Events) из списков, доступных пользователю. Каждое событие содержит id - номер события,
+// @Description	event - тип события и data - событие задачи. Чтобы получить события, пропущенные при разрыве соединения, передайте в заголовке
+// @Description	Last-Event-ID номер последнего полученного события. Если пропущенных событий слишком много или они уже удалены,
+// @Description	приходит событие reset.
+// @Tags			event
+// @Produce		text/event-stream
+// @Param			Last-Event-ID	header		int	false	"Номер последнего полученного события"
+// @Success		200				{object}	domain.TaskEvent
+// @Failure		400				{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/events [get]
+func (h *Handler) streamEvents(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	var lastSequence int64
+	header := c.GetHeader(lastEventIdHeader)
+
+	if header != "" {
+		lastSequence, err = strconv.ParseInt(header, 10, 64)
+
+		if err != nil || lastSequence < 0 {
+			response.NewErrorResponse(c, http.StatusBadRequest, ErrInvalidLastEventId)
+			return
+		}
+	}
+
+	// подписка оформляется до чтения пропущенных событий, чтобы не потерять опубликованные в промежутке
+	subscription := h.services.EventStream.Subscribe()
+	defer subscription.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", eventStreamRetry)
+
+	if header != "" {
+		events, complete := h.services.EventStream.Replay(lastSequence)
+
+		if complete {
+			for _, event := range *events {
+				lastSequence = h.writeEvent(c, existedUser.ID, event, lastSequence)
+			}
+		} else {
+			fmt.Fprintf(c.Writer, "event: %s\ndata: {}\n\n", eventStreamReset)
+		}
+	}
+
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(eventStreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-subscription.C:
+			// подписка закрывается, если клиент не успевает читать события, - он переподключится с Last-Event-ID
+			if !ok {
+				return
+			}
+
+			lastSequence = h.writeEvent(c, existedUser.ID, event, lastSequence)
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+		}
+
+		c.Writer.Flush()
+	}
+}
+
+// writeEvent отправляет событие, если оно доступно пользователю и еще не было отправлено, и возвращает номер последнего события
+func (h *Handler) writeEvent(c *gin.Context, userId uuid.UUID, event domain.OutboxEvent, lastSequence int64) int64 {
+	if event.PublishedSequence == nil || *event.PublishedSequence <= lastSequence {
+		return lastSequence
+	}
+
+	sequence := *event.PublishedSequence
+	taskEvent, ok := h.services.EventStream.Visible(userId, event)
+
+	if !ok {
+		return sequence
+	}
+
+	data, err := json.Marshal(taskEvent)
+
+	if err != nil {
+		return sequence
+	}
+
+	fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", sequence, event.Type, data)
+
+	return sequence
+}
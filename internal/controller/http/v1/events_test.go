@@ -0,0 +1,160 @@
+package v1
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"testing"
+	"time"
+)
+
+var fixtureEventTaskId = uuid.MustParse("3b8d6f1a-2c4e-4a7b-9d0f-5e1a3c7b9d2e")
+
+func TestStreamEvents_InvalidLastEventId(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	w := serveEventsRequest(t, userId, func(eventStreamService *mock_service.MockEventStream, _ *service.EventBus, _ context.CancelFunc) {}, "abc")
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, `{"message":"Invalid last event id"}`, w.Body.String())
+}
+
+func TestStreamEvents_LiveEvents(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	w := serveEventsRequest(t, userId, func(eventStreamService *mock_service.MockEventStream, bus *service.EventBus, cancel context.CancelFunc) {
+		eventStreamService.EXPECT().Subscribe().DoAndReturn(func() *service.EventSubscription {
+			subscription := bus.Subscribe(10)
+
+			require.NoError(t, bus.Publish(publishedEvent(7, domain.TaskEventUpdated)))
+			require.NoError(t, bus.Publish(publishedEvent(8, domain.TaskEventCreated)))
+
+			return subscription
+		})
+		eventStreamService.EXPECT().Visible(userId, gomock.Any()).Return(nil, false)
+		eventStreamService.EXPECT().Visible(userId, gomock.Any()).DoAndReturn(func(_ uuid.UUID, event domain.OutboxEvent) (*domain.TaskEvent, bool) {
+			cancel()
+
+			return &domain.TaskEvent{Type: event.Type, TaskId: fixtureEventTaskId, UserId: userId}, true
+		})
+	}, "")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	require.Equal(t, "retry: 3000\n\n"+
+		"id: 8\nevent: task.created\n"+
+		`data: {"id":"00000000-0000-0000-0000-000000000000","event":"task.created","task_id":"3b8d6f1a-2c4e-4a7b-9d0f-5e1a3c7b9d2e",`+
+		`"user_id":"64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b","list_id":null,"revision":0,"actor_id":null,"changes":null,"occurred_at":"0001-01-01T00:00:00Z"}`+"\n\n",
+		w.Body.String())
+}
+
+func TestStreamEvents_ResumeSkipsDeliveredEvents(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	w := serveEventsRequest(t, userId, func(eventStreamService *mock_service.MockEventStream, bus *service.EventBus, cancel context.CancelFunc) {
+		eventStreamService.EXPECT().Subscribe().DoAndReturn(func() *service.EventSubscription {
+			subscription := bus.Subscribe(10)
+
+			// событие 6 пришло и при досылке, и из шины - отправляется один раз
+			require.NoError(t, bus.Publish(publishedEvent(6, domain.TaskEventDeleted)))
+			require.NoError(t, bus.Publish(publishedEvent(7, domain.TaskEventCompleted)))
+
+			return subscription
+		})
+		eventStreamService.EXPECT().Replay(int64(5)).Return(&[]domain.OutboxEvent{publishedEvent(6, domain.TaskEventDeleted)}, true)
+		eventStreamService.EXPECT().Visible(userId, gomock.Any()).Return(&domain.TaskEvent{Type: domain.TaskEventDeleted}, true)
+		eventStreamService.EXPECT().Visible(userId, gomock.Any()).DoAndReturn(func(_ uuid.UUID, event domain.OutboxEvent) (*domain.TaskEvent, bool) {
+			cancel()
+
+			return &domain.TaskEvent{Type: event.Type}, true
+		})
+	}, "5")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), "id: 6\nevent: task.deleted\n")
+	require.Contains(t, w.Body.String(), "id: 7\nevent: task.completed\n")
+}
+
+func TestStreamEvents_ResumeTooFarBehind(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	w := serveEventsRequest(t, userId, func(eventStreamService *mock_service.MockEventStream, bus *service.EventBus, cancel context.CancelFunc) {
+		eventStreamService.EXPECT().Subscribe().DoAndReturn(func() *service.EventSubscription {
+			return bus.Subscribe(10)
+		})
+		eventStreamService.EXPECT().Replay(int64(1)).DoAndReturn(func(int64) (*[]domain.OutboxEvent, bool) {
+			cancel()
+
+			return &[]domain.OutboxEvent{}, false
+		})
+	}, "1")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "retry: 3000\n\nevent: reset\ndata: {}\n\n", w.Body.String())
+}
+
+func TestStreamEvents_SlowClientDisconnected(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	w := serveEventsRequest(t, userId, func(eventStreamService *mock_service.MockEventStream, bus *service.EventBus, _ context.CancelFunc) {
+		eventStreamService.EXPECT().Subscribe().DoAndReturn(func() *service.EventSubscription {
+			subscription := bus.Subscribe(0)
+
+			require.NoError(t, bus.Publish(publishedEvent(1, "")))
+
+			return subscription
+		})
+	}, "")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "retry: 3000\n\n", w.Body.String())
+}
+
+func publishedEvent(publishedSequence int64, eventType string) domain.OutboxEvent {
+	return domain.OutboxEvent{PublishedSequence: &publishedSequence, Type: eventType}
+}
+
+func serveEventsRequest(
+	t *testing.T,
+	userId uuid.UUID,
+	mockFunction func(eventStreamService *mock_service.MockEventStream, bus *service.EventBus, cancel context.CancelFunc),
+	lastEventId string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	userService := mock_service.NewMockUser(c)
+	eventStreamService := mock_service.NewMockEventStream(c)
+
+	userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil).AnyTimes()
+	mockFunction(eventStreamService, service.NewEventBus(), cancel)
+	handler := Handler{services: &service.Services{User: userService, EventStream: eventStreamService}}
+
+	r := gin.New()
+	r.GET("/events", setContextEmail, handler.streamEvents)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequestWithContext(ctx, "GET", "/events", nil)
+
+	if lastEventId != "" {
+		req.Header.Set(lastEventIdHeader, lastEventId)
+	}
+
+	r.ServeHTTP(w, req)
+
+	require.NotErrorIs(t, ctx.Err(), context.DeadlineExceeded, "stream was not closed")
+
+	return w
+}
@@ -16,10 +16,33 @@ func NewHandler(services *service.Services, jwt *jwt.JWT) *Handler {
 }
 
 func (h *Handler) Init(api *gin.RouterGroup) {
-	v1 := api.Group("/v1")
+	v1 := api.Group("/v1", h.requestId, h.idempotency)
 	{
 		h.initProfileRoutes(v1)
 		h.initAuthRoutes(v1)
 		h.initTasksRoutes(v1)
+		h.initTaskSearchRoutes(v1)
+		h.initTaskBulkRoutes(v1)
+		h.initSyncRoutes(v1)
+		h.initUndoRoutes(v1)
+		h.initTrashRoutes(v1)
+		h.initArchiveRoutes(v1)
+		h.initTaskHistoryRoutes(v1)
+		h.initTaskAssignmentRoutes(v1)
+		h.initTaskDependenciesRoutes(v1)
+		h.initTaskTagsRoutes(v1)
+		h.initTimeEntriesRoutes(v1)
+		h.initListsRoutes(v1)
+		h.initListMembersRoutes(v1)
+		h.initSmartListsRoutes(v1)
+		h.initStatusesRoutes(v1)
+		h.initNotificationsRoutes(v1)
+		h.initWorkspacesRoutes(v1)
+		h.initInvitesRoutes(v1)
+		h.initAdminRoutes(v1)
+		h.initAuditEventsRoutes(v1)
+		h.initWebhooksRoutes(v1)
+		h.initEventsRoutes(v1)
+		h.initLiveRoutes(v1)
 	}
 }
@@ -0,0 +1,162 @@
+package v1
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/response"
+)
+
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotencyReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+)
+
+const (
+	ErrIdempotencyKeyTooLong       = "idempotency key must not be longer than 255 characters"
+	ErrFailedToCheckIdempotencyKey = "failed to check idempotency key"
+	ErrFailedToReadRequestBody     = "failed to read request body"
+)
+
+// replayedHeaders - заголовки ответа, которые сохраняются вместе с телом для повторной отправки
+var replayedHeaders = []string{"Content-Type", "Location", "ETag"}
+
+// storedClientErrors - ошибки клиента, которые повторятся при повторе того же запроса, поэтому сохраняются
+// как ответ по ключу. 400 не сохраняется: им обработчики отвечают и на сбои при работе с базой данных.
+// 409 зависит от текущего состояния данных и после его изменения повтор может завершиться успешно.
+// Ответы middleware проверки пользователя (reject) не сохраняются с любым кодом.
+var storedClientErrors = map[int]bool{
+	http.StatusForbidden:           true,
+	http.StatusNotFound:            true,
+	http.StatusPreconditionFailed:  true,
+	http.StatusUnprocessableEntity: true,
+}
+
+// responseRecorder копирует тело ответа, чтобы сохранить его для повторных запросов
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
+
+// idempotency обрабатывает POST-запросы аутентифицированных пользователей с заголовком Idempotency-Key.
+// Ответ на первый запрос сохраняется, повторный запрос с тем же ключом и телом получает сохраненный ответ
+// без повторного выполнения, запрос с тем же ключом и другим телом отклоняется.
+func (h *Handler) idempotency(c *gin.Context) {
+	key := c.GetHeader(idempotencyKeyHeader)
+
+	if c.Request.Method != http.MethodPost || key == "" {
+		return
+	}
+
+	if len(key) > maxIdempotencyKeyLength {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, ErrIdempotencyKeyTooLong)
+		return
+	}
+
+	email, err := h.parseAuthHeader(c)
+
+	if err != nil {
+		return
+	}
+
+	existedUser, _ := h.services.User.FindByEmail(email)
+
+	if existedUser == nil {
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToReadRequestBody)
+		return
+	}
+
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	stored, err := h.services.IdempotencyKey.Begin(existedUser.ID, key, requestFingerprint(c, body))
+
+	if err != nil {
+		switch err.Error() {
+		case service.ErrIdempotencyKeyReused:
+			response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		case service.ErrIdempotencyKeyInProgress:
+			response.NewErrorResponse(c, http.StatusConflict, err.Error())
+		default:
+			response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToCheckIdempotencyKey)
+		}
+		return
+	}
+
+	if stored != nil {
+		for name, value := range stored.Headers {
+			c.Header(name, value)
+		}
+
+		c.Header(idempotencyReplayedHeader, "true")
+		c.Status(stored.StatusCode)
+		_, _ = c.Writer.Write(stored.Body)
+		c.Abort()
+		return
+	}
+
+	// если обработчик завершился паникой, ключ освобождается, иначе повторы получали бы 409 до истечения ключа
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			_ = h.services.IdempotencyKey.Release(existedUser.ID, key)
+			panic(recovered)
+		}
+	}()
+
+	recorder := &responseRecorder{ResponseWriter: c.Writer}
+	c.Writer = recorder
+
+	c.Next()
+
+	if c.GetBool(contextRejectedKey) || !isStoredStatus(recorder.Status()) {
+		_ = h.services.IdempotencyKey.Release(existedUser.ID, key)
+		return
+	}
+
+	headers := make(map[string]string, len(replayedHeaders))
+
+	for _, name := range replayedHeaders {
+		if value := recorder.Header().Get(name); value != "" {
+			headers[name] = value
+		}
+	}
+
+	_ = h.services.IdempotencyKey.Complete(existedUser.ID, key, recorder.Status(), headers, recorder.body.Bytes())
+}
+
+// isStoredStatus проверяет, сохраняется ли ответ с кодом status для повторных запросов с тем же ключом.
+// Для остальных ответов ключ освобождается, и клиент может повторить запрос.
+func isStoredStatus(status int) bool {
+	return status < http.StatusBadRequest || storedClientErrors[status]
+}
+
+// requestFingerprint возвращает отпечаток запроса: метод, адрес, рабочее пространство и тело. Пространство
+// входит в отпечаток, чтобы ключ, использованный в одном пространстве, не вернул его ответ в другом.
+func requestFingerprint(c *gin.Context, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(c.Request.Method + " " + c.Request.URL.RequestURI() + "\n"))
+	hash.Write([]byte(workspaceHeader + ": " + c.GetHeader(workspaceHeader) + "\n"))
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
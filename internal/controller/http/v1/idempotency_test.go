@@ -0,0 +1,291 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"poymanov/todo/pkg/jwt"
+	"strings"
+	"testing"
+)
+
+func TestIdempotency(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name            string
+		key             string
+		response        string
+		statusCode      int
+		handlerExecuted bool
+		mockFunction    func(userService *mock_service.MockUser, idempotencyKeyService *mock_service.MockIdempotencyKey)
+	}{
+		{
+			name:            "Without key",
+			key:             "",
+			response:        `{"id":"1"}`,
+			statusCode:      http.StatusCreated,
+			handlerExecuted: true,
+			mockFunction:    func(userService *mock_service.MockUser, idempotencyKeyService *mock_service.MockIdempotencyKey) {},
+		},
+		{
+			name:         "Too long key",
+			key:          strings.Repeat("k", 256),
+			response:     `{"message":"Idempotency key must not be longer than 255 characters"}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(userService *mock_service.MockUser, idempotencyKeyService *mock_service.MockIdempotencyKey) {},
+		},
+		{
+			name:       "Key used for another request",
+			key:        "key",
+			response:   `{"message":"Idempotency key is already used for another request"}`,
+			statusCode: http.StatusUnprocessableEntity,
+			mockFunction: func(userService *mock_service.MockUser, idempotencyKeyService *mock_service.MockIdempotencyKey) {
+				userService.EXPECT().FindByEmail("test@test.ru").Return(&domain.User{ID: userId}, nil)
+				idempotencyKeyService.EXPECT().Begin(userId, "key", gomock.Any()).Return(nil, errors.New(service.ErrIdempotencyKeyReused))
+			},
+		},
+		{
+			name:       "Request in progress",
+			key:        "key",
+			response:   `{"message":"Request with this idempotency key is still in progress"}`,
+			statusCode: http.StatusConflict,
+			mockFunction: func(userService *mock_service.MockUser, idempotencyKeyService *mock_service.MockIdempotencyKey) {
+				userService.EXPECT().FindByEmail("test@test.ru").Return(&domain.User{ID: userId}, nil)
+				idempotencyKeyService.EXPECT().Begin(userId, "key", gomock.Any()).Return(nil, errors.New(service.ErrIdempotencyKeyInProgress))
+			},
+		},
+		{
+			name:       "Replay",
+			key:        "key",
+			response:   `{"id":"0"}`,
+			statusCode: http.StatusCreated,
+			mockFunction: func(userService *mock_service.MockUser, idempotencyKeyService *mock_service.MockIdempotencyKey) {
+				userService.EXPECT().FindByEmail("test@test.ru").Return(&domain.User{ID: userId}, nil)
+				idempotencyKeyService.EXPECT().Begin(userId, "key", gomock.Any()).Return(&domain.IdempotencyKey{
+					StatusCode: http.StatusCreated,
+					Headers:    map[string]string{"Content-Type": "application/json; charset=utf-8"},
+					Body:       []byte(`{"id":"0"}`),
+				}, nil)
+			},
+		},
+		{
+			name:            "First request",
+			key:             "key",
+			response:        `{"id":"1"}`,
+			statusCode:      http.StatusCreated,
+			handlerExecuted: true,
+			mockFunction: func(userService *mock_service.MockUser, idempotencyKeyService *mock_service.MockIdempotencyKey) {
+				userService.EXPECT().FindByEmail("test@test.ru").Return(&domain.User{ID: userId}, nil)
+				idempotencyKeyService.EXPECT().Begin(userId, "key", gomock.Any()).Return(nil, nil)
+				idempotencyKeyService.EXPECT().Complete(userId, "key", http.StatusCreated, map[string]string{
+					"Content-Type": "application/json; charset=utf-8",
+					"Location":     "/tasks/1",
+				}, []byte(`{"id":"1"}`)).Return(nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	jwtHelper := jwt.NewJWT("secret")
+	token, err := jwtHelper.Create(jwt.JWTData{Email: "test@test.ru"})
+	require.NoError(t, err)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			idempotencyKeyService := mock_service.NewMockIdempotencyKey(c)
+
+			tc.mockFunction(userService, idempotencyKeyService)
+			handler := Handler{
+				services: &service.Services{User: userService, IdempotencyKey: idempotencyKeyService},
+				jwt:      jwtHelper,
+			}
+
+			handlerExecuted := false
+
+			r := gin.New()
+			r.POST("/tasks", handler.idempotency, func(c *gin.Context) {
+				handlerExecuted = true
+				c.Header("Location", "/tasks/1")
+				c.JSON(http.StatusCreated, gin.H{"id": "1"})
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/tasks", bytes.NewBufferString(`{"description":"test"}`))
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set("Idempotency-Key", tc.key)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+			require.Equal(t, tc.handlerExecuted, handlerExecuted)
+		})
+	}
+}
+
+func TestIdempotencyReleasesKey(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		statusCode   int
+		mockFunction func(idempotencyKeyService *mock_service.MockIdempotencyKey)
+	}{
+		{
+			name:       "Transient failure",
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(idempotencyKeyService *mock_service.MockIdempotencyKey) {
+				idempotencyKeyService.EXPECT().Release(userId, "key").Return(nil)
+			},
+		},
+		{
+			name:       "State conflict",
+			statusCode: http.StatusConflict,
+			mockFunction: func(idempotencyKeyService *mock_service.MockIdempotencyKey) {
+				idempotencyKeyService.EXPECT().Release(userId, "key").Return(nil)
+			},
+		},
+		{
+			name:       "Server error",
+			statusCode: http.StatusInternalServerError,
+			mockFunction: func(idempotencyKeyService *mock_service.MockIdempotencyKey) {
+				idempotencyKeyService.EXPECT().Release(userId, "key").Return(nil)
+			},
+		},
+		{
+			name:       "Validation error",
+			statusCode: http.StatusUnprocessableEntity,
+			mockFunction: func(idempotencyKeyService *mock_service.MockIdempotencyKey) {
+				idempotencyKeyService.EXPECT().Complete(userId, "key", http.StatusUnprocessableEntity, gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	jwtHelper := jwt.NewJWT("secret")
+	token, err := jwtHelper.Create(jwt.JWTData{Email: "test@test.ru"})
+	require.NoError(t, err)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			idempotencyKeyService := mock_service.NewMockIdempotencyKey(c)
+
+			userService.EXPECT().FindByEmail("test@test.ru").Return(&domain.User{ID: userId}, nil)
+			idempotencyKeyService.EXPECT().Begin(userId, "key", gomock.Any()).Return(nil, nil)
+			tc.mockFunction(idempotencyKeyService)
+			handler := Handler{
+				services: &service.Services{User: userService, IdempotencyKey: idempotencyKeyService},
+				jwt:      jwtHelper,
+			}
+
+			r := gin.New()
+			r.POST("/tasks", handler.idempotency, func(c *gin.Context) {
+				c.JSON(tc.statusCode, gin.H{"message": "failed"})
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/tasks", bytes.NewBufferString(`{"description":"test"}`))
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set("Idempotency-Key", "key")
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
+
+func TestRequestFingerprintIncludesWorkspace(t *testing.T) {
+	fingerprint := func(workspaceId string) string {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("POST", "/tasks", nil)
+
+		if workspaceId != "" {
+			c.Request.Header.Set("X-Workspace-Id", workspaceId)
+		}
+
+		return requestFingerprint(c, []byte(`{"description":"test"}`))
+	}
+
+	personal := fingerprint("")
+	first := fingerprint("1b8f8b7e-6a0c-4c9f-9d0e-2f7a3c5b6d1e")
+	second := fingerprint("9c2d4e6f-8a0b-4c1d-9e2f-3a4b5c6d7e8f")
+
+	require.NotEqual(t, personal, first)
+	require.NotEqual(t, first, second)
+	require.Equal(t, first, fingerprint("1b8f8b7e-6a0c-4c9f-9d0e-2f7a3c5b6d1e"))
+}
+
+func TestIdempotencyReleasesKeyWithoutHandlerResponse(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name       string
+		statusCode int
+		handler    gin.HandlerFunc
+	}{
+		{
+			name:       "Password reset required",
+			statusCode: http.StatusForbidden,
+			handler: func(c *gin.Context) {
+				reject(c, http.StatusForbidden, service.ErrPasswordResetRequired)
+			},
+		},
+		{
+			name:       "Handler panic",
+			statusCode: http.StatusInternalServerError,
+			handler: func(c *gin.Context) {
+				panic("failed")
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	jwtHelper := jwt.NewJWT("secret")
+	token, err := jwtHelper.Create(jwt.JWTData{Email: "test@test.ru"})
+	require.NoError(t, err)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			idempotencyKeyService := mock_service.NewMockIdempotencyKey(c)
+
+			userService.EXPECT().FindByEmail("test@test.ru").Return(&domain.User{ID: userId}, nil)
+			idempotencyKeyService.EXPECT().Begin(userId, "key", gomock.Any()).Return(nil, nil)
+			idempotencyKeyService.EXPECT().Release(userId, "key").Return(nil)
+			handler := Handler{
+				services: &service.Services{User: userService, IdempotencyKey: idempotencyKeyService},
+				jwt:      jwtHelper,
+			}
+
+			r := gin.New()
+			r.Use(gin.CustomRecovery(func(c *gin.Context, _ any) {
+				c.AbortWithStatus(http.StatusInternalServerError)
+			}))
+			r.POST("/tasks", handler.idempotency, tc.handler)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/tasks", bytes.NewBufferString(`{"description":"test"}`))
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set("Idempotency-Key", "key")
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
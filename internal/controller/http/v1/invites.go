@@ -0,0 +1,233 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/response"
+	"time"
+)
+
+const (
+	ErrFailedToCreateInvite = "failed to create invite"
+	ErrFailedToAcceptInvite = "failed to accept invite"
+	ErrInviteTarget         = "exactly one of workspace_id or list_id is required"
+)
+
+// Типы приглашений в ответах
+const (
+	inviteTypeWorkspace = "workspace"
+	inviteTypeList      = "list"
+)
+
+type CreateInviteRequest struct {
+	WorkspaceId    *string `json:"workspace_id" binding:"omitempty,uuid"`
+	ListId         *string `json:"list_id" binding:"omitempty,uuid"`
+	Role           string  `json:"role" binding:"required"`
+	SingleUse      bool    `json:"single_use"`
+	ExpiresInHours int     `json:"expires_in_hours" binding:"omitempty,min=1,max=720"`
+}
+
+type InviteResponse struct {
+	Token     string    `json:"token"`
+	Type      string    `json:"type"`
+	Role      string    `json:"role"`
+	SingleUse bool      `json:"single_use"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type InvitePreviewResponse struct {
+	Type        string    `json:"type"`
+	Name        string    `json:"name"`
+	InviterName string    `json:"inviter_name"`
+	Role        string    `json:"role"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type AcceptInviteResponse struct {
+	WorkspaceId string  `json:"workspace_id"`
+	ListId      *string `json:"list_id"`
+}
+
+func (h *Handler) initInvitesRoutes(api *gin.RouterGroup) {
+	invites := api.Group("/invites")
+	{
+		invites.POST("", h.auth, h.createInvite)
+		invites.GET("/:token", h.getInvite)
+		invites.POST("/:token/accept", h.auth, h.acceptInvite)
+	}
+}
+
+// @Description	Создание ссылки-приглашения в рабочее пространство (роль member или admin) или в список
+// @Description	текущего пространства (роль viewer, editor или admin). Создавать приглашения может администратор.
+// @Tags			invite
+// @Param			data	body		CreateInviteRequest	true	"Параметры приглашения"
+// @Success		201		{object}	InviteResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		403		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		422		{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/invites [post]
+func (h *Handler) createInvite(c *gin.Context) {
+	var body CreateInviteRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if (body.WorkspaceId == nil) == (body.ListId == nil) {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, ErrInviteTarget)
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	data := service.InviteData{
+		UserId:    existedUser.ID,
+		Role:      body.Role,
+		SingleUse: body.SingleUse,
+		TTL:       time.Duration(body.ExpiresInHours) * time.Hour,
+	}
+
+	if body.ListId != nil {
+		list, err := h.findUserList(c, uuid.MustParse(*body.ListId), existedUser.ID, domain.ListRoleAdmin)
+
+		if err != nil {
+			respondAccessError(c, err, service.ErrListNotFound)
+			return
+		}
+
+		data.WorkspaceId, data.ListId = list.WorkspaceId, &list.ID
+	} else {
+		workspace, err := h.findUserWorkspace(uuid.MustParse(*body.WorkspaceId), existedUser.ID, domain.WorkspaceRoleAdmin)
+
+		if err != nil {
+			respondAccessError(c, err, service.ErrWorkspaceNotFound)
+			return
+		}
+
+		data.WorkspaceId = workspace.ID
+	}
+
+	invite, token, err := h.services.Invite.Create(data)
+
+	if err != nil {
+		if err.Error() == service.ErrInvalidInviteRole {
+			response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToCreateInvite)
+		return
+	}
+
+	c.JSON(http.StatusCreated, InviteResponse{
+		Token:     token,
+		Type:      inviteType(invite),
+		Role:      invite.Role,
+		SingleUse: invite.SingleUse,
+		ExpiresAt: invite.ExpiresAt,
+	})
+}
+
+// @Description	Просмотр приглашения по токену, доступен без аутентификации
+// @Tags			invite
+// @Param			token	path		string	true	"Токен приглашения"
+// @Success		200		{object}	InvitePreviewResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		409		{object}	response.ErrorResponse
+// @Router			/invites/{token} [get]
+func (h *Handler) getInvite(c *gin.Context) {
+	invite, err := h.services.Invite.Preview(c.Param("token"))
+
+	if err != nil {
+		respondInviteError(c, err, service.ErrInviteNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, InvitePreviewResponse{
+		Type:        inviteType(invite),
+		Name:        invite.TargetName,
+		InviterName: invite.InviterName,
+		Role:        invite.Role,
+		ExpiresAt:   invite.ExpiresAt,
+	})
+}
+
+// @Description	Принятие приглашения текущим пользователем, в том числе только что зарегистрированным.
+// @Description	Приглашенный в список становится и участником рабочего пространства списка.
+// @Tags			invite
+// @Param			token	path		string	true	"Токен приглашения"
+// @Success		200		{object}	AcceptInviteResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		409		{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/invites/{token}/accept [post]
+func (h *Handler) acceptInvite(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	invite, err := h.services.Invite.Accept(c.Param("token"), existedUser.ID)
+
+	if err != nil {
+		respondInviteError(c, err, ErrFailedToAcceptInvite)
+		return
+	}
+
+	h.audit(c, domain.AuditEvent{
+		Action:     domain.AuditInviteAccept,
+		ActorId:    &existedUser.ID,
+		ActorEmail: existedUser.Email,
+		TargetId:   &existedUser.ID,
+		Details:    inviteAuditDetails(invite),
+	})
+
+	c.JSON(http.StatusOK, AcceptInviteResponse{
+		WorkspaceId: invite.WorkspaceId.String(),
+		ListId:      uuidToString(invite.ListId),
+	})
+}
+
+func respondInviteError(c *gin.Context, err error, fallback string) {
+	switch err.Error() {
+	case service.ErrInviteNotFound:
+		response.NewErrorResponse(c, http.StatusNotFound, err.Error())
+	case service.ErrInviteUsed, service.ErrInviteAlreadyJoined:
+		response.NewErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		response.NewErrorResponse(c, http.StatusBadRequest, fallback)
+	}
+}
+
+// inviteAuditDetails описывает для журнала аудита приглашение и выданную по нему роль
+func inviteAuditDetails(invite *domain.Invite) string {
+	details := "invite_id=" + invite.ID.String() + " inviter_id=" + invite.UserId.String() + " workspace_id=" + invite.WorkspaceId.String()
+
+	if invite.IsList() {
+		details += " list_id=" + invite.ListId.String()
+	}
+
+	return details + " role=" + invite.Role
+}
+
+func inviteType(invite *domain.Invite) string {
+	if invite.IsList() {
+		return inviteTypeList
+	}
+
+	return inviteTypeWorkspace
+}
@@ -0,0 +1,255 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"testing"
+	"time"
+)
+
+var fixtureInviteExpiresAt = time.Date(2026, 10, 22, 0, 0, 0, 0, time.UTC)
+
+type inviteMocks struct {
+	invite     *mock_service.MockInvite
+	workspace  *mock_service.MockWorkspace
+	list       *mock_service.MockList
+	listMember *mock_service.MockListMember
+	auditEvent *mock_service.MockAuditEvent
+}
+
+func TestCreateInvite(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		body         string
+		response     string
+		statusCode   int
+		mockFunction func(mocks inviteMocks)
+	}{
+		{
+			name:         "Without target",
+			body:         `{"role":"member"}`,
+			response:     `{"message":"Exactly one of workspace_id or list_id is required"}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(mocks inviteMocks) {},
+		},
+		{
+			name:         "Too long expiration",
+			body:         `{"workspace_id":"3b9e1f5a-7c2d-4e8f-a1b3-c5d7e9f1a2b4","role":"member","expires_in_hours":1000}`,
+			response:     `{"message":"Key: 'CreateInviteRequest.ExpiresInHours' Error:Field validation for 'ExpiresInHours' failed on the 'max' tag"}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(mocks inviteMocks) {},
+		},
+		{
+			name:       "Workspace member can't invite",
+			body:       `{"workspace_id":"3b9e1f5a-7c2d-4e8f-a1b3-c5d7e9f1a2b4","role":"member"}`,
+			response:   `{"message":"Insufficient permissions"}`,
+			statusCode: http.StatusForbidden,
+			mockFunction: func(mocks inviteMocks) {
+				mocks.workspace.EXPECT().Find(fixtureWorkspaceId, userId).
+					Return(&domain.Workspace{ID: fixtureWorkspaceId, Role: domain.WorkspaceRoleMember}, nil)
+			},
+		},
+		{
+			name:       "List editor can't invite",
+			body:       `{"list_id":"8d306d55-4301-4770-8a90-e64f771dc3f9","role":"viewer"}`,
+			response:   `{"message":"Insufficient permissions"}`,
+			statusCode: http.StatusForbidden,
+			mockFunction: func(mocks inviteMocks) {
+				mocks.list.EXPECT().FindById(fixtureListId).Return(&domain.List{ID: fixtureListId}, nil)
+				mocks.listMember.EXPECT().GetRole(gomock.Any(), userId).Return(domain.ListRoleEditor)
+			},
+		},
+		{
+			name:       "Invalid role",
+			body:       `{"workspace_id":"3b9e1f5a-7c2d-4e8f-a1b3-c5d7e9f1a2b4","role":"owner"}`,
+			response:   `{"message":"Invalid role"}`,
+			statusCode: http.StatusUnprocessableEntity,
+			mockFunction: func(mocks inviteMocks) {
+				mocks.workspace.EXPECT().Find(fixtureWorkspaceId, userId).
+					Return(&domain.Workspace{ID: fixtureWorkspaceId, Role: domain.WorkspaceRoleOwner}, nil)
+				mocks.invite.EXPECT().Create(gomock.Any()).Return(nil, "", errors.New(service.ErrInvalidInviteRole))
+			},
+		},
+		{
+			name:       "List invite",
+			body:       `{"list_id":"8d306d55-4301-4770-8a90-e64f771dc3f9","role":"viewer","single_use":true,"expires_in_hours":24}`,
+			response:   `{"token":"token","type":"list","role":"viewer","single_use":true,"expires_at":"2026-10-22T00:00:00Z"}`,
+			statusCode: http.StatusCreated,
+			mockFunction: func(mocks inviteMocks) {
+				mocks.list.EXPECT().FindById(fixtureListId).Return(&domain.List{ID: fixtureListId, UserId: userId, WorkspaceId: fixtureWorkspaceId}, nil)
+				mocks.invite.EXPECT().Create(service.InviteData{
+					UserId: userId, WorkspaceId: fixtureWorkspaceId, ListId: &fixtureListId, Role: "viewer", SingleUse: true, TTL: 24 * time.Hour,
+				}).Return(&domain.Invite{ListId: &fixtureListId, Role: "viewer", SingleUse: true, ExpiresAt: fixtureInviteExpiresAt}, "token", nil)
+			},
+		},
+		{
+			name:       "Workspace invite",
+			body:       `{"workspace_id":"3b9e1f5a-7c2d-4e8f-a1b3-c5d7e9f1a2b4","role":"admin"}`,
+			response:   `{"token":"token","type":"workspace","role":"admin","single_use":false,"expires_at":"2026-10-22T00:00:00Z"}`,
+			statusCode: http.StatusCreated,
+			mockFunction: func(mocks inviteMocks) {
+				mocks.workspace.EXPECT().Find(fixtureWorkspaceId, userId).
+					Return(&domain.Workspace{ID: fixtureWorkspaceId, Role: domain.WorkspaceRoleAdmin}, nil)
+				mocks.invite.EXPECT().Create(service.InviteData{UserId: userId, WorkspaceId: fixtureWorkspaceId, Role: "admin"}).
+					Return(&domain.Invite{WorkspaceId: fixtureWorkspaceId, Role: "admin", ExpiresAt: fixtureInviteExpiresAt}, "token", nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serveInvitesRequest(t, userId, tc.mockFunction, "POST", "/invites", tc.body)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestGetInvite(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		response     string
+		statusCode   int
+		mockFunction func(mocks inviteMocks)
+	}{
+		{
+			name:       "Not found",
+			response:   `{"message":"Invite not found or expired"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(mocks inviteMocks) {
+				mocks.invite.EXPECT().Preview("token").Return(nil, errors.New(service.ErrInviteNotFound))
+			},
+		},
+		{
+			name:       "Used",
+			response:   `{"message":"Invite has already been used"}`,
+			statusCode: http.StatusConflict,
+			mockFunction: func(mocks inviteMocks) {
+				mocks.invite.EXPECT().Preview("token").Return(nil, errors.New(service.ErrInviteUsed))
+			},
+		},
+		{
+			name:       "Success",
+			response:   `{"type":"workspace","name":"Team","inviter_name":"John","role":"member","expires_at":"2026-10-22T00:00:00Z"}`,
+			statusCode: http.StatusOK,
+			mockFunction: func(mocks inviteMocks) {
+				mocks.invite.EXPECT().Preview("token").Return(&domain.Invite{
+					TargetName: "Team", InviterName: "John", Role: domain.WorkspaceRoleMember, ExpiresAt: fixtureInviteExpiresAt,
+				}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serveInvitesRequest(t, userId, tc.mockFunction, "GET", "/invites/token", "")
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestAcceptInvite(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		response     string
+		statusCode   int
+		mockFunction func(mocks inviteMocks)
+	}{
+		{
+			name:       "Already joined",
+			response:   `{"message":"User already has access"}`,
+			statusCode: http.StatusConflict,
+			mockFunction: func(mocks inviteMocks) {
+				mocks.invite.EXPECT().Accept("token", userId).Return(nil, errors.New(service.ErrInviteAlreadyJoined))
+			},
+		},
+		{
+			name:       "Failed",
+			response:   `{"message":"Failed to accept invite"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(mocks inviteMocks) {
+				mocks.invite.EXPECT().Accept("token", userId).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Success",
+			response:   `{"workspace_id":"3b9e1f5a-7c2d-4e8f-a1b3-c5d7e9f1a2b4","list_id":"8d306d55-4301-4770-8a90-e64f771dc3f9"}`,
+			statusCode: http.StatusOK,
+			mockFunction: func(mocks inviteMocks) {
+				mocks.invite.EXPECT().Accept("token", userId).Return(&domain.Invite{WorkspaceId: fixtureWorkspaceId, ListId: &fixtureListId, Role: domain.ListRoleEditor}, nil)
+				mocks.auditEvent.EXPECT().Record(gomock.Any()).DoAndReturn(func(event domain.AuditEvent) error {
+					require.Equal(t, domain.AuditInviteAccept, event.Action)
+					require.Equal(t, userId, *event.TargetId)
+					require.Contains(t, event.Details, "list_id="+fixtureListId.String()+" role=editor")
+					return nil
+				})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serveInvitesRequest(t, userId, tc.mockFunction, "POST", "/invites/token/accept", "")
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func serveInvitesRequest(
+	t *testing.T,
+	userId uuid.UUID,
+	mockFunction func(mocks inviteMocks),
+	method, url, body string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	userService := mock_service.NewMockUser(c)
+	mocks := inviteMocks{
+		invite:     mock_service.NewMockInvite(c),
+		workspace:  mock_service.NewMockWorkspace(c),
+		list:       mock_service.NewMockList(c),
+		listMember: mock_service.NewMockListMember(c),
+		auditEvent: mock_service.NewMockAuditEvent(c),
+	}
+
+	userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil).AnyTimes()
+	mockFunction(mocks)
+	handler := Handler{services: &service.Services{
+		User: userService, Invite: mocks.invite, Workspace: mocks.workspace, List: mocks.list, ListMember: mocks.listMember,
+		AuditEvent: mocks.auditEvent,
+	}}
+
+	r := gin.New()
+	r.POST("/invites", setContextEmail, handler.createInvite)
+	r.GET("/invites/:token", handler.getInvite)
+	r.POST("/invites/:token/accept", setContextEmail, handler.acceptInvite)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, url, bytes.NewBufferString(body))
+	r.ServeHTTP(w, req)
+
+	return w
+}
@@ -0,0 +1,272 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/response"
+)
+
+const (
+	ErrFailedToInviteListMember = "failed to invite list member"
+	ErrFailedToUpdateListMember = "failed to update list member"
+	ErrFailedToRemoveListMember = "failed to remove list member"
+)
+
+type InviteListMemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required"`
+}
+
+type UpdateListMemberRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type ListMemberResponse struct {
+	UserId string `json:"user_id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Role   string `json:"role"`
+}
+
+func (h *Handler) initListMembersRoutes(api *gin.RouterGroup) {
+	lists := api.Group("/lists/:id", h.auth)
+	{
+		lists.GET("/members", h.getListMembers)
+		lists.POST("/members", h.inviteListMember)
+		lists.PATCH("/members/:userId", h.updateListMember)
+		lists.DELETE("/members/:userId", h.removeListMember)
+		lists.POST("/leave", h.leaveList)
+	}
+}
+
+// @Description	Получение участников списка (кроме владельца)
+// @Tags			list-member
+// @Param			id	path		string	true	"ID списка"
+// @Success		200	{array}		ListMemberResponse
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/lists/{id}/members [get]
+func (h *Handler) getListMembers(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	list, err := h.getUserList(c, "id", existedUser.ID, domain.ListRoleViewer)
+
+	if err != nil {
+		respondAccessError(c, err, service.ErrListNotFound)
+		return
+	}
+
+	var membersResponse = make([]ListMemberResponse, 0)
+
+	for _, member := range *h.scoped(c).ListMember.GetAll(list.ID) {
+		membersResponse = append(membersResponse, newListMemberResponse(member))
+	}
+
+	c.JSON(http.StatusOK, membersResponse)
+}
+
+// @Description	Открытие доступа к списку пользователю с ролью viewer, editor или admin
+// @Tags			list-member
+// @Param			id		path		string					true	"ID списка"
+// @Param			data	body		InviteListMemberRequest	true	"Адрес пользователя и его роль"
+// @Success		201		{object}	ListMemberResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		403		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		409		{object}	response.ErrorResponse
+// @Failure		422		{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/lists/{id}/members [post]
+func (h *Handler) inviteListMember(c *gin.Context) {
+	var body InviteListMemberRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	list, err := h.getUserList(c, "id", existedUser.ID, domain.ListRoleAdmin)
+
+	if err != nil {
+		respondAccessError(c, err, service.ErrListNotFound)
+		return
+	}
+
+	member, err := h.scoped(c).ListMember.Invite(list, body.Email, body.Role)
+
+	if err != nil {
+		respondListMemberError(c, err, ErrFailedToInviteListMember)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newListMemberResponse(*member))
+}
+
+// @Description	Изменение роли участника списка
+// @Tags			list-member
+// @Param			id		path	string					true	"ID списка"
+// @Param			userId	path	string					true	"ID участника"
+// @Param			data	body	UpdateListMemberRequest	true	"Новая роль"
+// @Success		204
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		403	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Failure		409	{object}	response.ErrorResponse
+// @Failure		422	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/lists/{id}/members/{userId} [patch]
+func (h *Handler) updateListMember(c *gin.Context) {
+	var body UpdateListMemberRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	list, err := h.getUserList(c, "id", existedUser.ID, domain.ListRoleAdmin)
+
+	if err != nil {
+		respondAccessError(c, err, service.ErrListNotFound)
+		return
+	}
+
+	memberId, err := uuid.Parse(c.Param("userId"))
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusNotFound, service.ErrListMemberNotFound)
+		return
+	}
+
+	if err = h.scoped(c).ListMember.UpdateRole(list, memberId, body.Role); err != nil {
+		respondListMemberError(c, err, ErrFailedToUpdateListMember)
+		return
+	}
+
+	h.audit(c, domain.AuditEvent{
+		Action:     domain.AuditListMemberRoleChange,
+		ActorId:    &existedUser.ID,
+		ActorEmail: existedUser.Email,
+		TargetId:   &memberId,
+		Details:    "list_id=" + list.ID.String() + " role=" + body.Role,
+	})
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Description	Закрытие доступа к списку для участника
+// @Tags			list-member
+// @Param			id		path	string	true	"ID списка"
+// @Param			userId	path	string	true	"ID участника"
+// @Success		204
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		403	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Failure		409	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/lists/{id}/members/{userId} [delete]
+func (h *Handler) removeListMember(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	list, err := h.getUserList(c, "id", existedUser.ID, domain.ListRoleAdmin)
+
+	if err != nil {
+		respondAccessError(c, err, service.ErrListNotFound)
+		return
+	}
+
+	memberId, err := uuid.Parse(c.Param("userId"))
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusNotFound, service.ErrListMemberNotFound)
+		return
+	}
+
+	if err = h.scoped(c).ListMember.Remove(existedUser.ID, list, memberId); err != nil {
+		respondListMemberError(c, err, ErrFailedToRemoveListMember)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Description	Выход из списка, к которому пользователю открыт доступ
+// @Tags			list-member
+// @Param			id	path	string	true	"ID списка"
+// @Success		204
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Failure		409	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/lists/{id}/leave [post]
+func (h *Handler) leaveList(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	list, err := h.getUserList(c, "id", existedUser.ID, domain.ListRoleViewer)
+
+	if err != nil {
+		respondAccessError(c, err, service.ErrListNotFound)
+		return
+	}
+
+	if err = h.scoped(c).ListMember.Remove(existedUser.ID, list, existedUser.ID); err != nil {
+		respondListMemberError(c, err, ErrFailedToRemoveListMember)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func respondListMemberError(c *gin.Context, err error, fallback string) {
+	switch err.Error() {
+	case service.ErrInvalidListRole:
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+	case service.ErrListMemberUserNotFound, service.ErrListMemberNotFound:
+		response.NewErrorResponse(c, http.StatusNotFound, err.Error())
+	case service.ErrListMemberExists, service.ErrListOwnerIsNotMember, service.ErrListMemberNotInWorkspace:
+		response.NewErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		response.NewErrorResponse(c, http.StatusBadRequest, fallback)
+	}
+}
+
+func newListMemberResponse(member domain.ListMember) ListMemberResponse {
+	return ListMemberResponse{
+		UserId: member.UserId.String(),
+		Name:   member.Name,
+		Email:  member.Email,
+		Role:   member.Role,
+	}
+}
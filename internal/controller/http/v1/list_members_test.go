@@ -0,0 +1,335 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"testing"
+)
+
+var (
+	fixtureListId   = uuid.MustParse("8d306d55-4301-4770-8a90-e64f771dc3f9")
+	fixtureMemberId = uuid.MustParse("5c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f")
+)
+
+func TestGetListMembers(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		response     string
+		statusCode   int
+		mockFunction func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember)
+	}{
+		{
+			name:       "List not shared with user",
+			response:   `{"message":"List not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember) {
+				listService.EXPECT().FindById(fixtureListId).Return(&domain.List{ID: fixtureListId}, nil)
+				listMemberService.EXPECT().GetRole(gomock.Any(), userId).Return("")
+			},
+		},
+		{
+			name:       "Success",
+			response:   `[{"user_id":"5c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f","name":"John","email":"john@example.com","role":"editor"}]`,
+			statusCode: http.StatusOK,
+			mockFunction: func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember) {
+				listService.EXPECT().FindById(fixtureListId).Return(&domain.List{ID: fixtureListId}, nil)
+				listMemberService.EXPECT().GetRole(gomock.Any(), userId).Return(domain.ListRoleViewer)
+				listMemberService.EXPECT().GetAll(fixtureListId).Return(&[]domain.ListMember{
+					{ListId: fixtureListId, UserId: fixtureMemberId, Role: domain.ListRoleEditor, Name: "John", Email: "john@example.com"},
+				})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serveListMembersRequest(t, userId, tc.mockFunction, "GET", "/lists/"+fixtureListId.String()+"/members", "")
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestInviteListMember(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		body         string
+		response     string
+		statusCode   int
+		mockFunction func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember)
+	}{
+		{
+			name:         "Invalid email",
+			body:         `{"email":"john","role":"editor"}`,
+			response:     `{"message":"Key: 'InviteListMemberRequest.Email' Error:Field validation for 'Email' failed on the 'email' tag"}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember) {},
+		},
+		{
+			name:       "Editor can't invite",
+			body:       `{"email":"john@example.com","role":"editor"}`,
+			response:   `{"message":"Insufficient permissions"}`,
+			statusCode: http.StatusForbidden,
+			mockFunction: func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember) {
+				listService.EXPECT().FindById(fixtureListId).Return(&domain.List{ID: fixtureListId}, nil)
+				listMemberService.EXPECT().GetRole(gomock.Any(), userId).Return(domain.ListRoleEditor)
+			},
+		},
+		{
+			name:       "Invalid role",
+			body:       `{"email":"john@example.com","role":"owner"}`,
+			response:   `{"message":"Invalid role"}`,
+			statusCode: http.StatusUnprocessableEntity,
+			mockFunction: func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember) {
+				listService.EXPECT().FindById(fixtureListId).Return(&domain.List{ID: fixtureListId, UserId: userId}, nil)
+				listMemberService.EXPECT().Invite(gomock.Any(), "john@example.com", "owner").Return(nil, errors.New(service.ErrInvalidListRole))
+			},
+		},
+		{
+			name:       "User not found",
+			body:       `{"email":"john@example.com","role":"editor"}`,
+			response:   `{"message":"User not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember) {
+				listService.EXPECT().FindById(fixtureListId).Return(&domain.List{ID: fixtureListId, UserId: userId}, nil)
+				listMemberService.EXPECT().Invite(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New(service.ErrListMemberUserNotFound))
+			},
+		},
+		{
+			name:       "Already a member",
+			body:       `{"email":"john@example.com","role":"editor"}`,
+			response:   `{"message":"User already has access to the list"}`,
+			statusCode: http.StatusConflict,
+			mockFunction: func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember) {
+				listService.EXPECT().FindById(fixtureListId).Return(&domain.List{ID: fixtureListId, UserId: userId}, nil)
+				listMemberService.EXPECT().Invite(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New(service.ErrListMemberExists))
+			},
+		},
+		{
+			name:       "Not a workspace member",
+			body:       `{"email":"john@example.com","role":"editor"}`,
+			response:   `{"message":"User is not a member of the list workspace"}`,
+			statusCode: http.StatusConflict,
+			mockFunction: func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember) {
+				listService.EXPECT().FindById(fixtureListId).Return(&domain.List{ID: fixtureListId, UserId: userId}, nil)
+				listMemberService.EXPECT().Invite(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New(service.ErrListMemberNotInWorkspace))
+			},
+		},
+		{
+			name:       "Success",
+			body:       `{"email":"john@example.com","role":"editor"}`,
+			response:   `{"user_id":"5c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f","name":"John","email":"john@example.com","role":"editor"}`,
+			statusCode: http.StatusCreated,
+			mockFunction: func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember) {
+				listService.EXPECT().FindById(fixtureListId).Return(&domain.List{ID: fixtureListId}, nil)
+				listMemberService.EXPECT().GetRole(gomock.Any(), userId).Return(domain.ListRoleAdmin)
+				listMemberService.EXPECT().Invite(gomock.Any(), "john@example.com", "editor").Return(&domain.ListMember{
+					ListId: fixtureListId, UserId: fixtureMemberId, Role: domain.ListRoleEditor, Name: "John", Email: "john@example.com",
+				}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serveListMembersRequest(t, userId, tc.mockFunction, "POST", "/lists/"+fixtureListId.String()+"/members", tc.body)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestUpdateListMember(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		memberId     string
+		body         string
+		response     string
+		statusCode   int
+		mockFunction func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember)
+	}{
+		{
+			name:       "Missing role",
+			memberId:   fixtureMemberId.String(),
+			body:       `{}`,
+			response:   `{"message":"Key: 'UpdateListMemberRequest.Role' Error:Field validation for 'Role' failed on the 'required' tag"}`,
+			statusCode: http.StatusUnprocessableEntity,
+			mockFunction: func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember) {
+			},
+		},
+		{
+			name:       "Failed to parse member id",
+			memberId:   "member",
+			body:       `{"role":"viewer"}`,
+			response:   `{"message":"List member not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember) {
+				listService.EXPECT().FindById(fixtureListId).Return(&domain.List{ID: fixtureListId, UserId: userId}, nil)
+			},
+		},
+		{
+			name:       "Owner",
+			memberId:   userId.String(),
+			body:       `{"role":"viewer"}`,
+			response:   `{"message":"List owner can't be removed from the list or change role"}`,
+			statusCode: http.StatusConflict,
+			mockFunction: func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember) {
+				listService.EXPECT().FindById(fixtureListId).Return(&domain.List{ID: fixtureListId, UserId: userId}, nil)
+				listMemberService.EXPECT().UpdateRole(gomock.Any(), userId, "viewer").Return(errors.New(service.ErrListOwnerIsNotMember))
+			},
+		},
+		{
+			name:       "Success",
+			memberId:   fixtureMemberId.String(),
+			body:       `{"role":"viewer"}`,
+			response:   ``,
+			statusCode: http.StatusNoContent,
+			mockFunction: func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember) {
+				listService.EXPECT().FindById(fixtureListId).Return(&domain.List{ID: fixtureListId, UserId: userId}, nil)
+				listMemberService.EXPECT().UpdateRole(gomock.Any(), fixtureMemberId, "viewer").Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serveListMembersRequest(t, userId, tc.mockFunction, "PATCH", "/lists/"+fixtureListId.String()+"/members/"+tc.memberId, tc.body)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestRemoveListMember(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		response     string
+		statusCode   int
+		mockFunction func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember)
+	}{
+		{
+			name:       "Not a member",
+			response:   `{"message":"List member not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember) {
+				listService.EXPECT().FindById(fixtureListId).Return(&domain.List{ID: fixtureListId, UserId: userId}, nil)
+				listMemberService.EXPECT().Remove(gomock.Any(), gomock.Any(), fixtureMemberId).Return(errors.New(service.ErrListMemberNotFound))
+			},
+		},
+		{
+			name:       "Success",
+			response:   ``,
+			statusCode: http.StatusNoContent,
+			mockFunction: func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember) {
+				listService.EXPECT().FindById(fixtureListId).Return(&domain.List{ID: fixtureListId, UserId: userId}, nil)
+				listMemberService.EXPECT().Remove(gomock.Any(), gomock.Any(), fixtureMemberId).Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serveListMembersRequest(t, userId, tc.mockFunction, "DELETE", "/lists/"+fixtureListId.String()+"/members/"+fixtureMemberId.String(), "")
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestLeaveList(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		response     string
+		statusCode   int
+		mockFunction func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember)
+	}{
+		{
+			name:       "Owner",
+			response:   `{"message":"List owner can't be removed from the list or change role"}`,
+			statusCode: http.StatusConflict,
+			mockFunction: func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember) {
+				listService.EXPECT().FindById(fixtureListId).Return(&domain.List{ID: fixtureListId, UserId: userId}, nil)
+				listMemberService.EXPECT().Remove(gomock.Any(), gomock.Any(), userId).Return(errors.New(service.ErrListOwnerIsNotMember))
+			},
+		},
+		{
+			name:       "Success",
+			response:   ``,
+			statusCode: http.StatusNoContent,
+			mockFunction: func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember) {
+				listService.EXPECT().FindById(fixtureListId).Return(&domain.List{ID: fixtureListId}, nil)
+				listMemberService.EXPECT().GetRole(gomock.Any(), userId).Return(domain.ListRoleViewer)
+				listMemberService.EXPECT().Remove(gomock.Any(), gomock.Any(), userId).Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serveListMembersRequest(t, userId, tc.mockFunction, "POST", "/lists/"+fixtureListId.String()+"/leave", "")
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func serveListMembersRequest(
+	t *testing.T,
+	userId uuid.UUID,
+	mockFunction func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember),
+	method, url, body string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	userService := mock_service.NewMockUser(c)
+	listService := mock_service.NewMockList(c)
+	listMemberService := mock_service.NewMockListMember(c)
+	auditEventService := mock_service.NewMockAuditEvent(c)
+
+	userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil).AnyTimes()
+	auditEventService.EXPECT().Record(gomock.Any()).Return(nil).AnyTimes()
+	mockFunction(listService, listMemberService)
+	handler := Handler{services: &service.Services{
+		User: userService, List: listService, ListMember: listMemberService, AuditEvent: auditEventService,
+	}}
+
+	r := gin.New()
+	r.GET("/lists/:id/members", setContextEmail, handler.getListMembers)
+	r.POST("/lists/:id/members", setContextEmail, handler.inviteListMember)
+	r.PATCH("/lists/:id/members/:userId", setContextEmail, handler.updateListMember)
+	r.DELETE("/lists/:id/members/:userId", setContextEmail, handler.removeListMember)
+	r.POST("/lists/:id/leave", setContextEmail, handler.leaveList)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, url, bytes.NewBufferString(body))
+	r.ServeHTTP(w, req)
+
+	return w
+}
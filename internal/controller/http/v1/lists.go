@@ -0,0 +1,193 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/response"
+)
+
+const (
+	ErrFailedToCreateList = "failed to create list"
+	ErrFailedToDeleteList = "failed to delete list"
+	ErrFailedToGetBoard   = "failed to get board"
+)
+
+type CreateListRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type ListResponse struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+type BoardColumnResponse struct {
+	Status StatusResponse           `json:"status"`
+	Tasks  []GetAllByUserIdResponse `json:"tasks"`
+}
+
+type BoardResponse struct {
+	List    ListResponse          `json:"list"`
+	Columns []BoardColumnResponse `json:"columns"`
+}
+
+func (h *Handler) initListsRoutes(api *gin.RouterGroup) {
+	lists := api.Group("/lists", h.auth)
+	{
+		lists.GET("", h.getAllLists)
+		lists.POST("", h.createList)
+		lists.DELETE("/:id", h.deleteList)
+		lists.GET("/:id/board", h.getListBoard)
+	}
+}
+
+// @Description	Получение списков задач пользователя
+// @Tags			list
+// @Success		200	{array}		ListResponse
+// @Failure		400	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/lists [get]
+func (h *Handler) getAllLists(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	lists := h.scoped(c).List.GetAllByUserId(existedUser.ID)
+
+	var listsResponse = make([]ListResponse, 0)
+
+	for _, list := range *lists {
+		listsResponse = append(listsResponse, newListResponse(list))
+	}
+
+	c.JSON(http.StatusOK, listsResponse)
+}
+
+// @Description	Создание списка задач
+// @Tags			list
+// @Param			data	body		CreateListRequest	true	"Данные нового списка"
+// @Success		201		{object}	ListResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		422		{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/lists [post]
+func (h *Handler) createList(c *gin.Context) {
+	var body CreateListRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	list, err := h.scoped(c).List.Create(body.Name, existedUser.ID)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToCreateList)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newListResponse(*list))
+}
+
+// @Description	Удаление списка задач (задачи списка остаются без списка)
+// @Tags			list
+// @Param			id	path	string	true	"ID списка"
+// @Success		204
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		403	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/lists/{id} [delete]
+func (h *Handler) deleteList(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	list, err := h.getUserList(c, "id", existedUser.ID, domain.ListRoleOwner)
+
+	if err != nil {
+		respondAccessError(c, err, service.ErrListNotFound)
+		return
+	}
+
+	err = h.scoped(c).List.Delete(list.ID)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToDeleteList)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Description	Получение задач списка, сгруппированных по статусам
+// @Tags			list
+// @Param			id	path		string	true	"ID списка"
+// @Success		200	{object}	BoardResponse
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		403	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/lists/{id}/board [get]
+func (h *Handler) getListBoard(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	list, err := h.getUserList(c, "id", existedUser.ID, domain.ListRoleViewer)
+
+	if err != nil {
+		respondAccessError(c, err, service.ErrListNotFound)
+		return
+	}
+
+	columns, err := h.scoped(c).List.GetBoard(list)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetBoard)
+		return
+	}
+
+	boardResponse := BoardResponse{
+		List:    newListResponse(*list),
+		Columns: make([]BoardColumnResponse, 0, len(*columns)),
+	}
+
+	for _, column := range *columns {
+		boardResponse.Columns = append(boardResponse.Columns, BoardColumnResponse{
+			Status: newStatusResponse(column.Status),
+			Tasks:  newTasksResponse(column.Tasks),
+		})
+	}
+
+	c.JSON(http.StatusOK, boardResponse)
+}
+
+func newListResponse(list domain.List) ListResponse {
+	role := list.Role
+
+	if role == "" {
+		role = domain.ListRoleOwner
+	}
+
+	return ListResponse{Id: list.ID.String(), Name: list.Name, Role: role}
+}
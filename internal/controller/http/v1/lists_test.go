@@ -0,0 +1,292 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"testing"
+	"time"
+)
+
+func TestGetAllLists(t *testing.T) {
+	testCases := []struct {
+		name         string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, listService *mock_service.MockList)
+	}{
+		{
+			name:       "Not existed user",
+			response:   `{"message":"Failed to get user"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(userService *mock_service.MockUser, listService *mock_service.MockList) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Success",
+			response:   `[{"id":"8d306d55-4301-4770-8a90-e64f771dc3f9","name":"Work","role":"owner"},{"id":"8d306d55-4301-4770-8a90-e64f771dc3f9","name":"Shared","role":"editor"}]`,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, listService *mock_service.MockList) {
+				listId, _ := uuid.Parse("8d306d55-4301-4770-8a90-e64f771dc3f9")
+
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{}, nil)
+				listService.EXPECT().GetAllByUserId(gomock.Any()).Return(&[]domain.List{
+					{ID: listId, Name: "Work", Role: domain.ListRoleOwner},
+					{ID: listId, Name: "Shared", Role: domain.ListRoleEditor},
+				})
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			listService := mock_service.NewMockList(c)
+
+			tc.mockFunction(userService, listService)
+			handler := Handler{services: &service.Services{User: userService, List: listService}}
+
+			r := gin.New()
+			r.GET("/lists", setContextEmail, handler.getAllLists)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/lists", nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestCreateList(t *testing.T) {
+	testCases := []struct {
+		name         string
+		body         string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, listService *mock_service.MockList)
+	}{
+		{
+			name:         "Missing name",
+			body:         `{}`,
+			response:     `{"message":"Key: 'CreateListRequest.Name' Error:Field validation for 'Name' failed on the 'required' tag"}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(userService *mock_service.MockUser, listService *mock_service.MockList) {},
+		},
+		{
+			name:       "Failed to create list",
+			body:       `{"name":"Work"}`,
+			response:   `{"message":"Failed to create list"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(userService *mock_service.MockUser, listService *mock_service.MockList) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{}, nil)
+				listService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Success",
+			body:       `{"name":"Work"}`,
+			response:   `{"id":"8d306d55-4301-4770-8a90-e64f771dc3f9","name":"Work","role":"owner"}`,
+			statusCode: http.StatusCreated,
+			mockFunction: func(userService *mock_service.MockUser, listService *mock_service.MockList) {
+				listId, _ := uuid.Parse("8d306d55-4301-4770-8a90-e64f771dc3f9")
+
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{}, nil)
+				listService.EXPECT().Create("Work", gomock.Any()).Return(&domain.List{ID: listId, Name: "Work"}, nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			listService := mock_service.NewMockList(c)
+
+			tc.mockFunction(userService, listService)
+			handler := Handler{services: &service.Services{User: userService, List: listService}}
+
+			r := gin.New()
+			r.POST("/lists", setContextEmail, handler.createList)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/lists", bytes.NewBufferString(tc.body))
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestDeleteList(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		listId       string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, listService *mock_service.MockList, listMemberService *mock_service.MockListMember)
+	}{
+		{
+			name:       "Failed to parse list id",
+			listId:     faker.Word(),
+			response:   `{"message":"List not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, listService *mock_service.MockList, listMemberService *mock_service.MockListMember) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+			},
+		},
+		{
+			name:       "List of another user",
+			listId:     faker.UUIDHyphenated(),
+			response:   `{"message":"List not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, listService *mock_service.MockList, listMemberService *mock_service.MockListMember) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				listService.EXPECT().FindById(gomock.Any()).Return(&domain.List{}, nil)
+				listMemberService.EXPECT().GetRole(gomock.Any(), userId).Return("")
+			},
+		},
+		{
+			name:       "Shared list",
+			listId:     faker.UUIDHyphenated(),
+			response:   `{"message":"Insufficient permissions"}`,
+			statusCode: http.StatusForbidden,
+			mockFunction: func(userService *mock_service.MockUser, listService *mock_service.MockList, listMemberService *mock_service.MockListMember) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				listService.EXPECT().FindById(gomock.Any()).Return(&domain.List{}, nil)
+				listMemberService.EXPECT().GetRole(gomock.Any(), userId).Return(domain.ListRoleAdmin)
+			},
+		},
+		{
+			name:       "Success",
+			listId:     faker.UUIDHyphenated(),
+			response:   ``,
+			statusCode: http.StatusNoContent,
+			mockFunction: func(userService *mock_service.MockUser, listService *mock_service.MockList, listMemberService *mock_service.MockListMember) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				listService.EXPECT().FindById(gomock.Any()).Return(&domain.List{UserId: userId}, nil)
+				listService.EXPECT().Delete(gomock.Any()).Return(nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			listService := mock_service.NewMockList(c)
+			listMemberService := mock_service.NewMockListMember(c)
+
+			tc.mockFunction(userService, listService, listMemberService)
+			handler := Handler{services: &service.Services{User: userService, List: listService, ListMember: listMemberService}}
+
+			r := gin.New()
+			r.DELETE("/lists/:id", setContextEmail, handler.deleteList)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/lists/"+tc.listId, nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestGetListBoard(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+	listId, _ := uuid.Parse("8d306d55-4301-4770-8a90-e64f771dc3f9")
+
+	testCases := []struct {
+		name         string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, listService *mock_service.MockList)
+	}{
+		{
+			name:       "Failed to get board",
+			response:   `{"message":"Failed to get board"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(userService *mock_service.MockUser, listService *mock_service.MockList) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				listService.EXPECT().FindById(gomock.Any()).Return(&domain.List{ID: listId, UserId: userId}, nil)
+				listService.EXPECT().GetBoard(gomock.Any()).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name: "Success",
+			response: `{"list":{"id":"8d306d55-4301-4770-8a90-e64f771dc3f9","name":"Work","role":"owner"},"columns":[` +
+				`{"status":{"id":"3f0c9b2e-6c1a-4d2b-9f7e-1a2b3c4d5e6f","list_id":null,"name":"Backlog","position":0,"is_done":false},"tasks":[` +
+				`{"id":"7a8b9c0d-1e2f-4a5b-8c6d-7e8f9a0b1c2d","list_id":"8d306d55-4301-4770-8a90-e64f771dc3f9","assignee_id":null,"status_id":"3f0c9b2e-6c1a-4d2b-9f7e-1a2b3c4d5e6f","description":"Description","is_completed":false,"is_blocked":false,"created_at":"2006-01-02T15:04:05Z"}]}]}`,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, listService *mock_service.MockList) {
+				statusId, _ := uuid.Parse("3f0c9b2e-6c1a-4d2b-9f7e-1a2b3c4d5e6f")
+				taskId, _ := uuid.Parse("7a8b9c0d-1e2f-4a5b-8c6d-7e8f9a0b1c2d")
+				createdAt, _ := time.Parse("2006-01-02 15:04:05", "2006-01-02 15:04:05")
+				isCompleted := false
+
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				listService.EXPECT().FindById(gomock.Any()).Return(&domain.List{ID: listId, UserId: userId, Name: "Work"}, nil)
+				listService.EXPECT().GetBoard(gomock.Any()).Return(&[]service.BoardColumn{
+					{
+						Status: domain.Status{ID: statusId, Name: "Backlog"},
+						Tasks: []domain.Task{
+							{
+								ID:          taskId,
+								ListId:      &listId,
+								StatusId:    &statusId,
+								Description: "Description",
+								IsCompleted: &isCompleted,
+								CreatedAt:   createdAt,
+							},
+						},
+					},
+				}, nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			listService := mock_service.NewMockList(c)
+
+			tc.mockFunction(userService, listService)
+			handler := Handler{services: &service.Services{User: userService, List: listService}}
+
+			r := gin.New()
+			r.GET("/lists/:id/board", setContextEmail, handler.getListBoard)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/lists/"+listId.String()+"/board", nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package v1
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"golang.org/x/net/websocket"
+	"net/http"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/helpers"
+	"poymanov/todo/pkg/response"
+	"strings"
+	"time"
+)
+
+const (
+	ErrInvalidLiveMessage    = "invalid message"
+	ErrUnknownLiveMessage    = "unknown message type"
+	ErrLiveConnectionTooSlow = "connection is too slow"
+)
+
+// Типы сообщений, которые присылает клиент совместного редактирования
+const (
+	liveRequestSubscribe   = "subscribe"
+	liveRequestUnsubscribe = "unsubscribe"
+	liveRequestPong        = "pong"
+)
+
+const (
+	// liveTokenProtocol - подпротокол, за которым в заголовке Sec-WebSocket-Protocol следует JWT-токен: браузер
+	// не позволяет передать другие заголовки при открытии WebSocket, а параметры запроса попадают в журнал
+	liveTokenProtocol = "access_token"
+	// livePingInterval - период отправки клиенту сообщения ping и продления его присутствия в списках
+	livePingInterval = 30 * time.Second
+	// liveReadTimeout - если клиент молчит дольше, соединение считается оборванным
+	liveReadTimeout = 75 * time.Second
+	// liveWriteTimeout - время на отправку одного сообщения клиенту
+	liveWriteTimeout = 10 * time.Second
+	// liveMaxMessageBytes - максимальный размер сообщения клиента
+	liveMaxMessageBytes = 4096
+)
+
+// LiveRequest - сообщение клиента: подписка (subscribe) или отписка (unsubscribe) от списка list_id
+// либо ответ на ping (pong)
+type LiveRequest struct {
+	Type   string `json:"type"`
+	ListId string `json:"list_id"`
+}
+
+func (h *Handler) initLiveRoutes(api *gin.RouterGroup) {
+	api.GET("/live", h.liveToken, h.auth, h.live)
+}
+
+// liveToken передает токен из заголовка Sec-WebSocket-Protocol ("access_token, <токен>") в заголовок Authorization,
+// если заголовок не указан
+func (h *Handler) liveToken(c *gin.Context) {
+	if c.GetHeader(authorizationHeader) != "" {
+		return
+	}
+
+	protocols := liveProtocols(c.Request)
+
+	for i := 0; i < len(protocols)-1; i++ {
+		if protocols[i] == liveTokenProtocol {
+			c.Request.Header.Set(authorizationHeader, "Bearer "+protocols[i+1])
+			return
+		}
+	}
+}
+
+// liveHandshake выбирает подпротокол access_token, если клиент передал в нем токен: браузер закрывает
+// соединение, если сервер не подтвердил ни один из предложенных подпротоколов
+func liveHandshake(config *websocket.Config, r *http.Request) error {
+	config.Protocol = nil
+
+	for _, protocol := range liveProtocols(r) {
+		if protocol == liveTokenProtocol {
+			config.Protocol = []string{liveTokenProtocol}
+			break
+		}
+	}
+
+	return nil
+}
+
+func liveProtocols(r *http.Request) []string {
+	var protocols []string
+
+	for _, header := range r.Header.Values("Sec-WebSocket-Protocol") {
+		for _, protocol := range strings.Split(header, ",") {
+			if protocol = strings.TrimSpace(protocol); protocol != "" {
+				protocols = append(protocols, protocol)
+			}
+		}
+	}
+
+	return protocols
+}
+
+// @Description	WebSocket для совместной работы со списками. JWT-токен передается в заголовке Authorization или в заголовке Sec-WebSocket-Protocol: "access_token, <токен>".
+// @Description	Клиент отправляет {"type":"subscribe","list_id":"..."} и {"type":"unsubscribe","list_id":"..."}, отвечает {"type":"pong"} на ping.
+// @Description	Сервер присылает сообщения subscribed, unsubscribed, task (изменение задачи списка), presence (кто просматривает список), ping и error.
+// @Description	Если клиент не успевает принимать сообщения или сервер пропустил часть изменений, соединение закрывается с ошибкой: клиенту нужно переподключиться и заново загрузить списки.
+// @Tags			live
+// @Success		101	{object}	service.LiveMessage
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		401	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/live [get]
+func (h *Handler) live(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	// токен уже проверен, а cookie не используются, поэтому соединения принимаются с любого Origin
+	server := websocket.Server{Handshake: liveHandshake, Handler: func(conn *websocket.Conn) {
+		h.serveLive(conn, existedUser.ID)
+	}}
+
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// serveLive читает сообщения клиента, пока соединение открыто. Отправкой занимается writeLive.
+func (h *Handler) serveLive(conn *websocket.Conn, userId uuid.UUID) {
+	conn.MaxPayloadBytes = liveMaxMessageBytes
+
+	session := h.services.Live.Connect(userId)
+	defer h.services.Live.Disconnect(session)
+
+	go h.writeLive(conn, session)
+
+	for {
+		var data []byte
+
+		if err := conn.SetReadDeadline(time.Now().Add(liveReadTimeout)); err != nil {
+			return
+		}
+
+		if err := websocket.Message.Receive(conn, &data); err != nil {
+			return
+		}
+
+		h.handleLiveRequest(session, data)
+	}
+}
+
+func (h *Handler) handleLiveRequest(session *service.LiveSession, data []byte) {
+	var request LiveRequest
+
+	if err := json.Unmarshal(data, &request); err != nil {
+		h.sendLiveError(session, nil, ErrInvalidLiveMessage)
+		return
+	}
+
+	switch request.Type {
+	case liveRequestPong:
+		return
+	case liveRequestSubscribe, liveRequestUnsubscribe:
+	default:
+		h.sendLiveError(session, nil, ErrUnknownLiveMessage)
+		return
+	}
+
+	listId, err := uuid.Parse(request.ListId)
+
+	if err != nil {
+		h.sendLiveError(session, nil, service.ErrListNotFound)
+		return
+	}
+
+	if request.Type == liveRequestSubscribe {
+		err = h.services.Live.Subscribe(session, listId)
+	} else {
+		err = h.services.Live.Unsubscribe(session, listId)
+	}
+
+	if err != nil {
+		h.sendLiveError(session, &listId, err.Error())
+	}
+}
+
+// writeLive отправляет клиенту сообщения соединения и ping. Закрывает соединение, когда очередь сообщений закрыта
+// или отправка не удалась.
+func (h *Handler) writeLive(conn *websocket.Conn, session *service.LiveSession) {
+	defer conn.Close()
+
+	ping := time.NewTicker(livePingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case message, ok := <-session.C:
+			if !ok {
+				if session.Overflowed() {
+					_ = sendLiveMessage(conn, service.LiveMessage{Type: service.LiveMessageError, Message: helpers.FirstToUpper(ErrLiveConnectionTooSlow)})
+				}
+
+				return
+			}
+
+			if err := sendLiveMessage(conn, message); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := sendLiveMessage(conn, service.LiveMessage{Type: service.LiveMessagePing}); err != nil {
+				return
+			}
+
+			_ = h.services.Live.Refresh(session)
+		}
+	}
+}
+
+func (h *Handler) sendLiveError(session *service.LiveSession, listId *uuid.UUID, message string) {
+	h.services.Live.Send(session, service.LiveMessage{Type: service.LiveMessageError, ListId: listId, Message: helpers.FirstToUpper(message)})
+}
+
+func sendLiveMessage(conn *websocket.Conn, message service.LiveMessage) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(liveWriteTimeout)); err != nil {
+		return err
+	}
+
+	return websocket.JSON.Send(conn, message)
+}
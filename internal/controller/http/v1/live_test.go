@@ -0,0 +1,280 @@
+package v1
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/net/websocket"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+var fixtureLiveListId = uuid.MustParse("7d2f4b6a-8c1e-4f3a-b5d7-9e0c2a4f6b8d")
+
+func TestLive_Subscribe(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	conn := serveLiveConnection(t, userId, func(liveService *mock_service.MockLive, liveHub *service.LiveHub) {
+		liveService.EXPECT().Subscribe(gomock.Any(), fixtureLiveListId).DoAndReturn(func(session *service.LiveSession, listId uuid.UUID) error {
+			liveHub.Send(session, service.LiveMessage{Type: service.LiveMessageSubscribed, ListId: &listId})
+
+			return nil
+		})
+	})
+
+	sendLiveRequest(t, conn, `{"type":"subscribe","list_id":"7d2f4b6a-8c1e-4f3a-b5d7-9e0c2a4f6b8d"}`)
+
+	require.Equal(t, `{"type":"subscribed","list_id":"7d2f4b6a-8c1e-4f3a-b5d7-9e0c2a4f6b8d"}`, receiveLiveResponse(t, conn))
+}
+
+func TestLive_Unsubscribe(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	conn := serveLiveConnection(t, userId, func(liveService *mock_service.MockLive, liveHub *service.LiveHub) {
+		liveService.EXPECT().Unsubscribe(gomock.Any(), fixtureLiveListId).DoAndReturn(func(session *service.LiveSession, listId uuid.UUID) error {
+			liveHub.Send(session, service.LiveMessage{Type: service.LiveMessageUnsubscribed, ListId: &listId})
+
+			return nil
+		})
+	})
+
+	sendLiveRequest(t, conn, `{"type":"unsubscribe","list_id":"7d2f4b6a-8c1e-4f3a-b5d7-9e0c2a4f6b8d"}`)
+
+	require.Equal(t, `{"type":"unsubscribed","list_id":"7d2f4b6a-8c1e-4f3a-b5d7-9e0c2a4f6b8d"}`, receiveLiveResponse(t, conn))
+}
+
+func TestLive_Errors(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		request      string
+		response     string
+		mockFunction func(liveService *mock_service.MockLive)
+	}{
+		{
+			name:         "Invalid message",
+			request:      `not json`,
+			response:     `{"type":"error","message":"Invalid message"}`,
+			mockFunction: func(liveService *mock_service.MockLive) {},
+		},
+		{
+			name:         "Unknown message type",
+			request:      `{"type":"edit"}`,
+			response:     `{"type":"error","message":"Unknown message type"}`,
+			mockFunction: func(liveService *mock_service.MockLive) {},
+		},
+		{
+			name:         "Invalid list id",
+			request:      `{"type":"subscribe","list_id":"abc"}`,
+			response:     `{"type":"error","message":"List not found"}`,
+			mockFunction: func(liveService *mock_service.MockLive) {},
+		},
+		{
+			name:     "List not available",
+			request:  `{"type":"subscribe","list_id":"7d2f4b6a-8c1e-4f3a-b5d7-9e0c2a4f6b8d"}`,
+			response: `{"type":"error","list_id":"7d2f4b6a-8c1e-4f3a-b5d7-9e0c2a4f6b8d","message":"List not found"}`,
+			mockFunction: func(liveService *mock_service.MockLive) {
+				liveService.EXPECT().Subscribe(gomock.Any(), fixtureLiveListId).Return(errors.New(service.ErrListNotFound))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conn := serveLiveConnection(t, userId, func(liveService *mock_service.MockLive, liveHub *service.LiveHub) {
+				liveService.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(liveHub.Send)
+				tc.mockFunction(liveService)
+			})
+
+			sendLiveRequest(t, conn, tc.request)
+
+			require.Equal(t, tc.response, receiveLiveResponse(t, conn))
+		})
+	}
+}
+
+func TestLive_PongIgnored(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	conn := serveLiveConnection(t, userId, func(liveService *mock_service.MockLive, liveHub *service.LiveHub) {
+		liveService.EXPECT().Unsubscribe(gomock.Any(), fixtureLiveListId).DoAndReturn(func(session *service.LiveSession, listId uuid.UUID) error {
+			liveHub.Send(session, service.LiveMessage{Type: service.LiveMessageUnsubscribed, ListId: &listId})
+
+			return nil
+		})
+	})
+
+	sendLiveRequest(t, conn, `{"type":"pong"}`)
+	sendLiveRequest(t, conn, `{"type":"unsubscribe","list_id":"7d2f4b6a-8c1e-4f3a-b5d7-9e0c2a4f6b8d"}`)
+
+	require.Equal(t, `{"type":"unsubscribed","list_id":"7d2f4b6a-8c1e-4f3a-b5d7-9e0c2a4f6b8d"}`, receiveLiveResponse(t, conn))
+}
+
+func TestLive_SlowClientDisconnected(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	conn := serveLiveConnection(t, userId, func(liveService *mock_service.MockLive, liveHub *service.LiveHub) {
+		liveService.EXPECT().Subscribe(gomock.Any(), fixtureLiveListId).DoAndReturn(func(session *service.LiveSession, listId uuid.UUID) error {
+			// сообщения ставятся в очередь на 8 сообщений быстрее, чем отправляются клиенту
+			for i := 0; i < 1000; i++ {
+				liveHub.Send(session, service.LiveMessage{Type: service.LiveMessagePing})
+			}
+
+			return nil
+		})
+	})
+
+	sendLiveRequest(t, conn, `{"type":"subscribe","list_id":"7d2f4b6a-8c1e-4f3a-b5d7-9e0c2a4f6b8d"}`)
+
+	response := receiveLiveResponse(t, conn)
+
+	for response == `{"type":"ping"}` {
+		response = receiveLiveResponse(t, conn)
+	}
+
+	require.Equal(t, `{"type":"error","message":"Connection is too slow"}`, response)
+
+	var data string
+	require.Error(t, websocket.Message.Receive(conn, &data))
+}
+
+func TestLiveToken(t *testing.T) {
+	testCases := []struct {
+		name     string
+		protocol string
+		header   string
+		result   string
+	}{
+		{name: "Token in protocol", protocol: "access_token, token", result: "Bearer token"},
+		{name: "Token after other protocol", protocol: "chat, access_token, token", result: "Bearer token"},
+		{name: "Header has priority", protocol: "access_token, token", header: "Bearer header", result: "Bearer header"},
+		{name: "Protocol without token", protocol: "access_token", result: ""},
+		{name: "Without token", result: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := Handler{}
+
+			r := gin.New()
+			r.GET("/live", handler.liveToken, func(c *gin.Context) {
+				c.String(http.StatusOK, c.GetHeader(authorizationHeader))
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/live", nil)
+
+			if tc.protocol != "" {
+				req.Header.Set("Sec-WebSocket-Protocol", tc.protocol)
+			}
+
+			if tc.header != "" {
+				req.Header.Set(authorizationHeader, tc.header)
+			}
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.result, w.Body.String())
+		})
+	}
+}
+
+func TestLiveHandshake(t *testing.T) {
+	testCases := []struct {
+		name     string
+		protocol string
+		result   []string
+	}{
+		{name: "Token protocol selected", protocol: "access_token, token", result: []string{"access_token"}},
+		{name: "Without protocol", result: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/live", nil)
+
+			if tc.protocol != "" {
+				req.Header.Set("Sec-WebSocket-Protocol", tc.protocol)
+			}
+
+			config := &websocket.Config{Protocol: []string{"access_token", "token"}}
+
+			require.NoError(t, liveHandshake(config, req))
+			require.Equal(t, tc.result, config.Protocol)
+		})
+	}
+}
+
+func sendLiveRequest(t *testing.T, conn *websocket.Conn, request string) {
+	t.Helper()
+
+	require.NoError(t, websocket.Message.Send(conn, request))
+}
+
+func receiveLiveResponse(t *testing.T, conn *websocket.Conn) string {
+	t.Helper()
+
+	var data string
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	require.NoError(t, websocket.Message.Receive(conn, &data))
+
+	return strings.TrimSpace(data)
+}
+
+// serveLiveConnection открывает WebSocket-соединение с обработчиком. Очереди соединений создает настоящий хаб,
+// остальные вызовы сервиса проверяются моком.
+func serveLiveConnection(
+	t *testing.T,
+	userId uuid.UUID,
+	mockFunction func(liveService *mock_service.MockLive, liveHub *service.LiveHub),
+) *websocket.Conn {
+	t.Helper()
+
+	c := gomock.NewController(t)
+
+	userService := mock_service.NewMockUser(c)
+	liveService := mock_service.NewMockLive(c)
+	liveHub := service.NewLiveHub(nil, nil, nil, service.NewEventBus(), 8)
+	disconnected := make(chan struct{})
+
+	userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil).AnyTimes()
+	liveService.EXPECT().Connect(userId).DoAndReturn(liveHub.Connect)
+	liveService.EXPECT().Disconnect(gomock.Any()).DoAndReturn(func(session *service.LiveSession) error {
+		defer close(disconnected)
+
+		return liveHub.Disconnect(session)
+	})
+	mockFunction(liveService, liveHub)
+	handler := Handler{services: &service.Services{User: userService, Live: liveService}}
+
+	r := gin.New()
+	r.GET("/live", setContextEmail, handler.live)
+
+	server := httptest.NewServer(r)
+	conn, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/live", "", server.URL)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+
+		select {
+		case <-disconnected:
+		case <-time.After(time.Second):
+			t.Error("connection was not closed")
+		}
+
+		server.Close()
+	})
+
+	return conn
+}
@@ -3,34 +3,130 @@ package v1
 import (
 	"errors"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"net/http"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
 	"poymanov/todo/pkg/response"
 	"strings"
 )
 
 const (
 	authorizationHeader = "Authorization"
+	workspaceHeader     = "X-Workspace-Id"
 	ContextEmailKey     = "ContextEmailKey"
+	ContextWorkspaceKey = "ContextWorkspaceKey"
+	contextServicesKey  = "ContextServicesKey"
+	contextRejectedKey  = "ContextRejectedKey"
+	ErrAccessDenied     = "insufficient permissions"
 )
 
+var errAccessDenied = errors.New(ErrAccessDenied)
+
 func (h *Handler) auth(c *gin.Context) {
-	header := c.GetHeader(authorizationHeader)
+	email, err := h.parseAuthHeader(c)
 
-	if !strings.HasPrefix(header, "Bearer ") {
-		response.NewErrorResponse(c, http.StatusUnauthorized, "empty auth header")
+	if err != nil {
+		reject(c, http.StatusUnauthorized, err.Error())
 		return
 	}
 
-	token := strings.TrimPrefix(header, "Bearer ")
+	c.Set(ContextEmailKey, email)
 
-	isValid, data := h.jwt.Parse(token)
+	if existedUser, err := h.getContextUser(c); err == nil {
+		switch {
+		case existedUser.IsDisabled():
+			reject(c, http.StatusForbidden, service.ErrUserDisabled)
+			return
+		case existedUser.PasswordResetRequired:
+			reject(c, http.StatusForbidden, service.ErrPasswordResetRequired)
+			return
+		}
+	}
 
-	if !isValid {
-		response.NewErrorResponse(c, http.StatusUnauthorized, "invalid auth header")
+	h.selectWorkspace(c)
+}
+
+// reject отклоняет запрос в middleware проверки пользователя. Такой ответ зависит от состояния учетной записи,
+// а не от самого запроса, поэтому idempotency не сохраняет его по ключу.
+func reject(c *gin.Context, statusCode int, message string) {
+	c.Set(contextRejectedKey, true)
+	response.NewErrorResponse(c, statusCode, message)
+}
+
+// admin пропускает дальше только запросы администраторов. Используется после auth.
+func (h *Handler) admin(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		reject(c, http.StatusBadRequest, ErrFailedToGetUser)
 		return
 	}
 
-	c.Set(ContextEmailKey, data.Email)
+	if !existedUser.IsAdmin() {
+		reject(c, http.StatusForbidden, ErrAccessDenied)
+		return
+	}
+}
+
+// selectWorkspace выбирает рабочее пространство запроса по заголовку X-Workspace-Id, без заголовка -
+// личное пространство пользователя. Дальнейшая обработка запроса ведется сервисами, которым доступны
+// только задачи и списки выбранного пространства.
+func (h *Handler) selectWorkspace(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		return
+	}
+
+	var workspaceId *uuid.UUID
+
+	if header := c.GetHeader(workspaceHeader); header != "" {
+		id, err := uuid.Parse(header)
+
+		if err != nil {
+			reject(c, http.StatusNotFound, service.ErrWorkspaceNotFound)
+			return
+		}
+
+		workspaceId = &id
+	}
+
+	workspace, err := h.services.Workspace.Resolve(existedUser.ID, workspaceId)
+
+	if err != nil {
+		reject(c, http.StatusNotFound, service.ErrWorkspaceNotFound)
+		return
+	}
+
+	c.Set(ContextWorkspaceKey, workspace)
+	c.Set(contextServicesKey, h.services.ForWorkspace(workspace.ID))
+}
+
+// scoped возвращает сервисы рабочего пространства, выбранного для запроса, или общие сервисы, если оно не выбрано
+func (h *Handler) scoped(c *gin.Context) *service.Services {
+	if services, ok := c.Get(contextServicesKey); ok {
+		return services.(*service.Services)
+	}
+
+	return h.services
+}
+
+// parseAuthHeader возвращает email пользователя из JWT-токена заголовка Authorization
+func (h *Handler) parseAuthHeader(c *gin.Context) (string, error) {
+	header := c.GetHeader(authorizationHeader)
+
+	if !strings.HasPrefix(header, "Bearer ") {
+		return "", errors.New("empty auth header")
+	}
+
+	isValid, data := h.jwt.Parse(strings.TrimPrefix(header, "Bearer "))
+
+	if !isValid {
+		return "", errors.New("invalid auth header")
+	}
+
+	return data.Email, nil
 }
 
 func getContextEmail(c *gin.Context) (string, error) {
@@ -46,3 +142,143 @@ func getContextEmail(c *gin.Context) (string, error) {
 
 	return email, nil
 }
+
+func (h *Handler) getContextUser(c *gin.Context) (*domain.User, error) {
+	email, err := getContextEmail(c)
+	if err != nil {
+		return nil, err
+	}
+
+	existedUser, _ := h.services.User.FindByEmail(email)
+	if existedUser == nil {
+		return nil, errors.New("user not found")
+	}
+
+	return existedUser, nil
+}
+
+// getUserTask возвращает задачу из параметра маршрута param, если роль пользователя userId для нее не ниже role
+func (h *Handler) getUserTask(c *gin.Context, param string, userId uuid.UUID, role string) (*domain.Task, error) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := h.scoped(c).Task.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.checkTaskRole(c, task, userId, role); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// getUserTaskWithTrashed возвращает задачу из параметра маршрута param, учитывая задачи в корзине,
+// если роль пользователя userId для нее не ниже role
+func (h *Handler) getUserTaskWithTrashed(c *gin.Context, param string, userId uuid.UUID, role string) (*domain.Task, error) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := h.scoped(c).Task.FindWithTrashedById(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.checkTaskRole(c, task, userId, role); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// checkTaskRole проверяет роль пользователя userId для задачи. Задачи вне списков доступны только их автору.
+func (h *Handler) checkTaskRole(c *gin.Context, task *domain.Task, userId uuid.UUID, role string) error {
+	if task.ListId == nil {
+		if task.UserId != userId {
+			return errors.New("task belongs to another user")
+		}
+
+		return nil
+	}
+
+	return checkListRole(h.scoped(c).ListMember.GetTaskRole(task, userId), role)
+}
+
+// getUserList возвращает список из параметра маршрута param, если роль пользователя userId в нем не ниже role
+func (h *Handler) getUserList(c *gin.Context, param string, userId uuid.UUID, role string) (*domain.List, error) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		return nil, err
+	}
+
+	return h.findUserList(c, id, userId, role)
+}
+
+// findUserList возвращает список id, если роль пользователя userId в нем не ниже role.
+// Поле Role найденного списка заполняется ролью пользователя.
+func (h *Handler) findUserList(c *gin.Context, id, userId uuid.UUID, role string) (*domain.List, error) {
+	list, err := h.scoped(c).List.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+
+	list.Role = domain.ListRoleOwner
+
+	if list.UserId != userId {
+		list.Role = h.scoped(c).ListMember.GetRole(list, userId)
+	}
+
+	if err = checkListRole(list.Role, role); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// checkListRole возвращает errAccessDenied, если роль current не дает прав роли required,
+// и ошибку отсутствия доступа, если роли нет совсем
+func checkListRole(current, required string) error {
+	if current == "" {
+		return errors.New("list is not shared with user")
+	}
+
+	if !domain.ListRoleAllows(current, required) {
+		return errAccessDenied
+	}
+
+	return nil
+}
+
+// respondAccessError отвечает 403, если роли пользователя недостаточно, и 404 с сообщением notFound в остальных случаях
+func respondAccessError(c *gin.Context, err error, notFound string) {
+	if errors.Is(err, errAccessDenied) {
+		response.NewErrorResponse(c, http.StatusForbidden, ErrAccessDenied)
+		return
+	}
+
+	response.NewErrorResponse(c, http.StatusNotFound, notFound)
+}
+
+// getUserSmartList возвращает умный список из параметра маршрута param, если он принадлежит пользователю userId
+func (h *Handler) getUserSmartList(c *gin.Context, param string, userId uuid.UUID) (*domain.SmartList, error) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		return nil, err
+	}
+
+	smartList, err := h.scoped(c).SmartList.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if smartList.UserId != userId {
+		return nil, errors.New("smart list belongs to another user")
+	}
+
+	return smartList, nil
+}
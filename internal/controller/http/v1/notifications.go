@@ -0,0 +1,136 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/response"
+	"time"
+)
+
+const ErrFailedToReadNotifications = "failed to mark notifications as read"
+
+type GetNotificationsRequest struct {
+	Unread bool `form:"unread"`
+}
+
+type NotificationResponse struct {
+	Id        string     `json:"id"`
+	Type      string     `json:"type"`
+	TaskId    *string    `json:"task_id"`
+	ActorId   *string    `json:"actor_id"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (h *Handler) initNotificationsRoutes(api *gin.RouterGroup) {
+	notifications := api.Group("/notifications", h.auth)
+	{
+		notifications.GET("", h.getNotifications)
+		notifications.POST("/read", h.readAllNotifications)
+		notifications.POST("/:id/read", h.readNotification)
+	}
+}
+
+// @Description	Получение последних уведомлений пользователя
+// @Tags			notification
+// @Param			unread	query		bool	false	"Только непрочитанные"
+// @Success		200		{array}		NotificationResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		422		{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/notifications [get]
+func (h *Handler) getNotifications(c *gin.Context) {
+	var query GetNotificationsRequest
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	var notificationsResponse = make([]NotificationResponse, 0)
+
+	for _, notification := range *h.scoped(c).Notification.GetAll(existedUser.ID, query.Unread) {
+		notificationsResponse = append(notificationsResponse, newNotificationResponse(notification))
+	}
+
+	c.JSON(http.StatusOK, notificationsResponse)
+}
+
+// @Description	Отметка уведомления прочитанным
+// @Tags			notification
+// @Param			id	path	string	true	"ID уведомления"
+// @Success		204
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/notifications/{id}/read [post]
+func (h *Handler) readNotification(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusNotFound, service.ErrNotificationNotFound)
+		return
+	}
+
+	if err = h.scoped(c).Notification.MarkRead(existedUser.ID, id); err != nil {
+		switch err.Error() {
+		case service.ErrNotificationNotFound:
+			response.NewErrorResponse(c, http.StatusNotFound, err.Error())
+		default:
+			response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToReadNotifications)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Description	Отметка всех уведомлений пользователя прочитанными
+// @Tags			notification
+// @Success		204
+// @Failure		400	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/notifications/read [post]
+func (h *Handler) readAllNotifications(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	if err = h.scoped(c).Notification.MarkAllRead(existedUser.ID); err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToReadNotifications)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func newNotificationResponse(notification domain.Notification) NotificationResponse {
+	return NotificationResponse{
+		Id:        notification.ID.String(),
+		Type:      notification.Type,
+		TaskId:    uuidToString(notification.TaskId),
+		ActorId:   uuidToString(notification.ActorId),
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+	}
+}
@@ -0,0 +1,161 @@
+package v1
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"testing"
+	"time"
+)
+
+func TestGetNotifications(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		query        string
+		response     string
+		statusCode   int
+		mockFunction func(notificationService *mock_service.MockNotification)
+	}{
+		{
+			name:         "Invalid unread flag",
+			query:        "?unread=maybe",
+			response:     `{"message":"Strconv.ParseBool: parsing \"maybe\": invalid syntax"}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(notificationService *mock_service.MockNotification) {},
+		},
+		{
+			name:       "Success",
+			query:      "?unread=true",
+			response:   `[{"id":"5c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f","type":"task_assigned","task_id":"2b7e3c1a-5d4f-4e6a-9b8c-0d1e2f3a4b5c","actor_id":null,"read_at":null,"created_at":"2026-10-01T10:00:00Z"}]`,
+			statusCode: http.StatusOK,
+			mockFunction: func(notificationService *mock_service.MockNotification) {
+				notificationService.EXPECT().GetAll(userId, true).Return(&[]domain.Notification{{
+					ID:        uuid.MustParse("5c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f"),
+					Type:      domain.NotificationTaskAssigned,
+					TaskId:    &fixtureTaskId,
+					CreatedAt: time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC),
+				}})
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			notificationService := mock_service.NewMockNotification(c)
+
+			userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil).AnyTimes()
+			tc.mockFunction(notificationService)
+			handler := Handler{services: &service.Services{User: userService, Notification: notificationService}}
+
+			r := gin.New()
+			r.GET("/notifications", setContextEmail, handler.getNotifications)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/notifications"+tc.query, nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestReadNotification(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+	notificationId, _ := uuid.Parse("5c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f")
+
+	testCases := []struct {
+		name           string
+		notificationId string
+		response       string
+		statusCode     int
+		mockFunction   func(notificationService *mock_service.MockNotification)
+	}{
+		{
+			name:           "Failed to parse notification id",
+			notificationId: faker.Word(),
+			response:       `{"message":"Notification not found"}`,
+			statusCode:     http.StatusNotFound,
+			mockFunction:   func(notificationService *mock_service.MockNotification) {},
+		},
+		{
+			name:           "Notification of another user",
+			notificationId: notificationId.String(),
+			response:       `{"message":"Notification not found"}`,
+			statusCode:     http.StatusNotFound,
+			mockFunction: func(notificationService *mock_service.MockNotification) {
+				notificationService.EXPECT().MarkRead(userId, notificationId).Return(errors.New(service.ErrNotificationNotFound))
+			},
+		},
+		{
+			name:           "Success",
+			notificationId: notificationId.String(),
+			response:       ``,
+			statusCode:     http.StatusNoContent,
+			mockFunction: func(notificationService *mock_service.MockNotification) {
+				notificationService.EXPECT().MarkRead(userId, notificationId).Return(nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			notificationService := mock_service.NewMockNotification(c)
+
+			userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+			tc.mockFunction(notificationService)
+			handler := Handler{services: &service.Services{User: userService, Notification: notificationService}}
+
+			r := gin.New()
+			r.POST("/notifications/:id/read", setContextEmail, handler.readNotification)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/notifications/"+tc.notificationId+"/read", nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestReadAllNotifications(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	userService := mock_service.NewMockUser(c)
+	notificationService := mock_service.NewMockNotification(c)
+
+	userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+	notificationService.EXPECT().MarkAllRead(userId).Return(nil)
+	handler := Handler{services: &service.Services{User: userService, Notification: notificationService}}
+
+	r := gin.New()
+	r.POST("/notifications/read", setContextEmail, handler.readAllNotifications)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/notifications/read", nil)
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+}
@@ -6,16 +6,25 @@ import (
 	"poymanov/todo/pkg/response"
 )
 
-const ErrFailedToGetProfile = "failed to get profile"
+const (
+	ErrFailedToGetProfile          = "failed to get profile"
+	ErrFailedToUpdateArchivePolicy = "failed to update archive policy"
+)
 
 type Profile struct {
-	ID    string `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Email           string `json:"email"`
+	AutoArchiveDays *int   `json:"auto_archive_days"`
+}
+
+type UpdateArchivePolicyRequest struct {
+	AutoArchiveDays *int `json:"auto_archive_days" binding:"omitempty,min=1,max=3650"`
 }
 
 func (h *Handler) initProfileRoutes(api *gin.RouterGroup) {
 	api.GET("/profile", h.auth, h.getProfile)
+	api.PUT("/profile/archive-policy", h.auth, h.updateArchivePolicy)
 }
 
 // @Description	Получение профиля текущего авторизованного пользователя
@@ -40,10 +49,42 @@ func (h *Handler) getProfile(c *gin.Context) {
 	}
 
 	profileResponse := &Profile{
-		ID:    existedUser.ID.String(),
-		Name:  existedUser.Name,
-		Email: existedUser.Email,
+		ID:              existedUser.ID.String(),
+		Name:            existedUser.Name,
+		Email:           existedUser.Email,
+		AutoArchiveDays: existedUser.AutoArchiveDays,
 	}
 
 	c.JSON(http.StatusOK, profileResponse)
 }
+
+// @Description	Настройка автоматической архивации завершенных задач. Значение null отключает архивацию.
+// @Tags			profile
+// @Param			data	body	UpdateArchivePolicyRequest	true	"Через сколько дней после завершения архивировать задачи"
+// @Success		204
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		422	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/profile/archive-policy [put]
+func (h *Handler) updateArchivePolicy(c *gin.Context) {
+	var body UpdateArchivePolicyRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	if err = h.services.User.UpdateAutoArchiveDays(existedUser.ID, body.AutoArchiveDays); err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToUpdateArchivePolicy)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"bytes"
 	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/go-faker/faker/v4"
@@ -43,7 +44,7 @@ func TestGetProfile(t *testing.T) {
 		},
 		{
 			name:       "Success",
-			response:   `{"id":"64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b","name":"test","email":"test@test.ru"}`,
+			response:   `{"id":"64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b","name":"test","email":"test@test.ru","auto_archive_days":null}`,
 			statusCode: http.StatusOK,
 			mockFunction: func(userService *mock_service.MockUser) {
 				userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
@@ -78,3 +79,77 @@ func TestGetProfile(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateArchivePolicy(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		body         string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser)
+	}{
+		{
+			name:         "Invalid days",
+			body:         `{"auto_archive_days":0}`,
+			response:     `{"message":"Key: 'UpdateArchivePolicyRequest.AutoArchiveDays' Error:Field validation for 'AutoArchiveDays' failed on the 'min' tag"}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(userService *mock_service.MockUser) {},
+		},
+		{
+			name:       "Failed to update policy",
+			body:       `{"auto_archive_days":7}`,
+			response:   `{"message":"Failed to update archive policy"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(userService *mock_service.MockUser) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				userService.EXPECT().UpdateAutoArchiveDays(userId, gomock.Any()).Return(errors.New("failed"))
+			},
+		},
+		{
+			name:       "Disable",
+			body:       `{"auto_archive_days":null}`,
+			response:   ``,
+			statusCode: http.StatusNoContent,
+			mockFunction: func(userService *mock_service.MockUser) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				userService.EXPECT().UpdateAutoArchiveDays(userId, nil).Return(nil)
+			},
+		},
+		{
+			name:       "Success",
+			body:       `{"auto_archive_days":7}`,
+			response:   ``,
+			statusCode: http.StatusNoContent,
+			mockFunction: func(userService *mock_service.MockUser) {
+				days := 7
+
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				userService.EXPECT().UpdateAutoArchiveDays(userId, &days).Return(nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+
+			tc.mockFunction(userService)
+			handler := Handler{services: &service.Services{User: userService}}
+
+			r := gin.New()
+			r.PUT("/profile/archive-policy", setContextEmail, handler.updateArchivePolicy)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/profile/archive-policy", bytes.NewBufferString(tc.body))
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	requestIdHeader     = "X-Request-Id"
+	contextRequestIdKey = "ContextRequestIdKey"
+	maxRequestIdLength  = 128
+)
+
+// requestId присваивает запросу идентификатор: переданный клиентом в заголовке X-Request-Id или новый.
+// Идентификатор возвращается в том же заголовке ответа и записывается в журнал аудита.
+func (h *Handler) requestId(c *gin.Context) {
+	id := c.GetHeader(requestIdHeader)
+
+	if id == "" || len(id) > maxRequestIdLength {
+		id = uuid.NewString()
+	}
+
+	c.Set(contextRequestIdKey, id)
+	c.Header(requestIdHeader, id)
+}
@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestId(t *testing.T) {
+	testCases := []struct {
+		name      string
+		header    string
+		generated bool
+	}{
+		{
+			name:      "Generated",
+			generated: true,
+		},
+		{
+			name:   "From header",
+			header: "client-request-1",
+		},
+		{
+			name:      "Too long header",
+			header:    strings.Repeat("a", maxRequestIdLength+1),
+			generated: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := Handler{}
+
+			r := gin.New()
+			r.GET("/request", handler.requestId, func(c *gin.Context) {
+				c.String(http.StatusOK, c.GetString(contextRequestIdKey))
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/request", nil)
+
+			if tc.header != "" {
+				req.Header.Set(requestIdHeader, tc.header)
+			}
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, w.Body.String(), w.Header().Get(requestIdHeader))
+
+			if tc.generated {
+				_, err := uuid.Parse(w.Body.String())
+				require.NoError(t, err)
+			} else {
+				require.Equal(t, tc.header, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/response"
+)
+
+const (
+	ErrFailedToCreateSmartList = "failed to create smart list"
+	ErrFailedToDeleteSmartList = "failed to delete smart list"
+)
+
+type CreateSmartListRequest struct {
+	Name   string          `json:"name" binding:"required,max=100"`
+	Filter json.RawMessage `json:"filter" binding:"required" swaggertype:"object"`
+}
+
+type SmartListTasksRequest struct {
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=200"`
+	Cursor string `form:"cursor"`
+	Sort   string `form:"sort" binding:"omitempty,oneof=created_at -created_at updated_at -updated_at description -description"`
+}
+
+type SmartListResponse struct {
+	Id     string                 `json:"id"`
+	Name   string                 `json:"name"`
+	Filter domain.SmartListFilter `json:"filter"`
+}
+
+func (h *Handler) initSmartListsRoutes(api *gin.RouterGroup) {
+	smartLists := api.Group("/smart-lists", h.auth)
+	{
+		smartLists.GET("", h.getAllSmartLists)
+		smartLists.POST("", h.createSmartList)
+		smartLists.DELETE("/:id", h.deleteSmartList)
+		smartLists.GET("/:id/tasks", h.getSmartListTasks)
+	}
+}
+
+// @Description	Получение умных списков пользователя
+// @Tags			smart-list
+// @Success		200	{array}		SmartListResponse
+// @Failure		400	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/smart-lists [get]
+func (h *Handler) getAllSmartLists(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	smartLists := h.scoped(c).SmartList.GetAllByUserId(existedUser.ID)
+
+	var smartListsResponse = make([]SmartListResponse, 0, len(*smartLists))
+
+	for _, smartList := range *smartLists {
+		smartListsResponse = append(smartListsResponse, newSmartListResponse(smartList))
+	}
+
+	c.JSON(http.StatusOK, smartListsResponse)
+}
+
+// @Description	Создание умного списка. Фильтр может содержать поля completed, blocked, list_id, status_id,
+// @Description	tags_all, tags_any, created_within_days, overdue и q; условия объединяются через "и", неизвестные поля отклоняются.
+// @Description	overdue=true отбирает незавершенные задачи с истекшим сроком выполнения.
+// @Tags			smart-list
+// @Param			data	body		CreateSmartListRequest	true	"Название и фильтр умного списка"
+// @Success		201		{object}	SmartListResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		422		{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/smart-lists [post]
+func (h *Handler) createSmartList(c *gin.Context) {
+	var body CreateSmartListRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	smartList, err := h.scoped(c).SmartList.Create(existedUser.ID, body.Name, body.Filter)
+
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidSmartListFilter) {
+			response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToCreateSmartList)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newSmartListResponse(*smartList))
+}
+
+// @Description	Удаление умного списка
+// @Tags			smart-list
+// @Param			id	path	string	true	"ID умного списка"
+// @Success		204
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/smart-lists/{id} [delete]
+func (h *Handler) deleteSmartList(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	smartList, err := h.getUserSmartList(c, "id", existedUser.ID)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusNotFound, service.ErrSmartListNotFound)
+		return
+	}
+
+	if err = h.scoped(c).SmartList.Delete(smartList.ID); err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToDeleteSmartList)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Description	Получение задач, удовлетворяющих фильтру умного списка
+// @Tags			smart-list
+// @Param			id				path		string	true	"ID умного списка"
+// @Param			limit			query		int		false	"Количество задач на странице (по умолчанию 50, не более 200)"
+// @Param			cursor			query		string	false	"Курсор следующей страницы из next_cursor"
+// @Param			sort			query		string	false	"Поле сортировки, префикс - для обратного порядка"	Enums(created_at, -created_at, updated_at, -updated_at, description, -description)
+// @Param			If-None-Match	header		string	false	"ETag ранее полученного ответа"
+// @Success		200				{object}	TasksPageResponse
+// @Success		304
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Failure		422	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/smart-lists/{id}/tasks [get]
+func (h *Handler) getSmartListTasks(c *gin.Context) {
+	var query SmartListTasksRequest
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	smartList, err := h.getUserSmartList(c, "id", existedUser.ID)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusNotFound, service.ErrSmartListNotFound)
+		return
+	}
+
+	page, err := h.scoped(c).SmartList.GetTasks(smartList, query.Sort, query.Cursor, query.Limit)
+
+	if err != nil {
+		switch err.Error() {
+		case service.ErrInvalidTaskCursor, service.ErrInvalidTaskSort:
+			response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		default:
+			response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetTasks)
+		}
+		return
+	}
+
+	writeJSONWithETag(c, newTasksPageResponse(page))
+}
+
+func newSmartListResponse(smartList domain.SmartList) SmartListResponse {
+	return SmartListResponse{
+		Id:     smartList.ID.String(),
+		Name:   smartList.Name,
+		Filter: smartList.Filter,
+	}
+}
@@ -0,0 +1,182 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"testing"
+)
+
+func TestCreateSmartList(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		body         string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, smartListService *mock_service.MockSmartList)
+	}{
+		{
+			name:         "Filter is missing",
+			body:         `{"name":"Urgent"}`,
+			response:     `{"message":"Key: 'CreateSmartListRequest.Filter' Error:Field validation for 'Filter' failed on the 'required' tag"}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(userService *mock_service.MockUser, smartListService *mock_service.MockSmartList) {},
+		},
+		{
+			name:       "Unknown filter field",
+			body:       `{"name":"Urgent","filter":{"priority":"high"}}`,
+			response:   `{"message":"Invalid smart list filter: json: unknown field \"priority\""}`,
+			statusCode: http.StatusUnprocessableEntity,
+			mockFunction: func(userService *mock_service.MockUser, smartListService *mock_service.MockSmartList) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				smartListService.EXPECT().Create(userId, "Urgent", gomock.Any()).
+					Return(nil, fmt.Errorf("%w: %s", service.ErrInvalidSmartListFilter, `json: unknown field "priority"`))
+			},
+		},
+		{
+			name:       "Failed to create",
+			body:       `{"name":"Urgent","filter":{"tags_all":["urgent"]}}`,
+			response:   `{"message":"Failed to create smart list"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(userService *mock_service.MockUser, smartListService *mock_service.MockSmartList) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				smartListService.EXPECT().Create(userId, "Urgent", gomock.Any()).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Overdue filter",
+			body:       `{"name":"Overdue urgent","filter":{"overdue":true,"tags_all":["urgent"]}}`,
+			response:   `{"id":"8d306d55-4301-4770-8a90-e64f771dc3f9","name":"Overdue urgent","filter":{"tags_all":["urgent"],"overdue":true}}`,
+			statusCode: http.StatusCreated,
+			mockFunction: func(userService *mock_service.MockUser, smartListService *mock_service.MockSmartList) {
+				smartListId, _ := uuid.Parse("8d306d55-4301-4770-8a90-e64f771dc3f9")
+				isOverdue := true
+
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				smartListService.EXPECT().Create(userId, "Overdue urgent", []byte(`{"overdue":true,"tags_all":["urgent"]}`)).
+					Return(&domain.SmartList{
+						ID:     smartListId,
+						Name:   "Overdue urgent",
+						Filter: domain.SmartListFilter{Overdue: &isOverdue, TagsAll: []string{"urgent"}},
+					}, nil)
+			},
+		},
+		{
+			name:       "Success",
+			body:       `{"name":"Urgent","filter":{"completed":false,"tags_all":["urgent"]}}`,
+			response:   `{"id":"8d306d55-4301-4770-8a90-e64f771dc3f9","name":"Urgent","filter":{"completed":false,"tags_all":["urgent"]}}`,
+			statusCode: http.StatusCreated,
+			mockFunction: func(userService *mock_service.MockUser, smartListService *mock_service.MockSmartList) {
+				smartListId, _ := uuid.Parse("8d306d55-4301-4770-8a90-e64f771dc3f9")
+				isCompleted := false
+
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				smartListService.EXPECT().Create(userId, "Urgent", []byte(`{"completed":false,"tags_all":["urgent"]}`)).
+					Return(&domain.SmartList{
+						ID:     smartListId,
+						Name:   "Urgent",
+						Filter: domain.SmartListFilter{Completed: &isCompleted, TagsAll: []string{"urgent"}},
+					}, nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			smartListService := mock_service.NewMockSmartList(c)
+
+			tc.mockFunction(userService, smartListService)
+			handler := Handler{services: &service.Services{User: userService, SmartList: smartListService}}
+
+			r := gin.New()
+			r.POST("/smart-lists", setContextEmail, handler.createSmartList)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/smart-lists", bytes.NewBufferString(tc.body))
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestGetSmartListTasks(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, smartListService *mock_service.MockSmartList)
+	}{
+		{
+			name:       "Smart list of another user",
+			response:   `{"message":"Smart list not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, smartListService *mock_service.MockSmartList) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				smartListService.EXPECT().FindById(gomock.Any()).Return(&domain.SmartList{}, nil)
+			},
+		},
+		{
+			name:       "Failed to get tasks",
+			response:   `{"message":"Failed to get tasks"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(userService *mock_service.MockUser, smartListService *mock_service.MockSmartList) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				smartListService.EXPECT().FindById(gomock.Any()).Return(&domain.SmartList{UserId: userId}, nil)
+				smartListService.EXPECT().GetTasks(gomock.Any(), "", "", 0).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Success",
+			response:   `{"items":[],"next_cursor":null,"total":0,"total_completed":0}`,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, smartListService *mock_service.MockSmartList) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				smartListService.EXPECT().FindById(gomock.Any()).Return(&domain.SmartList{UserId: userId}, nil)
+				smartListService.EXPECT().GetTasks(gomock.Any(), "", "", 0).Return(&domain.TaskPage{}, nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			smartListService := mock_service.NewMockSmartList(c)
+
+			tc.mockFunction(userService, smartListService)
+			handler := Handler{services: &service.Services{User: userService, SmartList: smartListService}}
+
+			r := gin.New()
+			r.GET("/smart-lists/:id/tasks", setContextEmail, handler.getSmartListTasks)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/smart-lists/"+faker.UUIDHyphenated()+"/tasks", nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/response"
+)
+
+const (
+	ErrFailedToGetStatuses  = "failed to get statuses"
+	ErrFailedToCreateStatus = "failed to create status"
+	ErrFailedToDeleteStatus = "failed to delete status"
+)
+
+type CreateStatusRequest struct {
+	Name   string  `json:"name" binding:"required"`
+	ListId *string `json:"list_id" binding:"omitempty,uuid"`
+}
+
+type StatusResponse struct {
+	Id       string  `json:"id"`
+	ListId   *string `json:"list_id"`
+	Name     string  `json:"name"`
+	Position int     `json:"position"`
+	IsDone   bool    `json:"is_done"`
+}
+
+func (h *Handler) initStatusesRoutes(api *gin.RouterGroup) {
+	statuses := api.Group("/statuses", h.auth)
+	{
+		statuses.GET("", h.getAllStatuses)
+		statuses.POST("", h.createStatus)
+		statuses.DELETE("/:id", h.deleteStatus)
+	}
+}
+
+// @Description	Получение статусов рабочего процесса пользователя или списка
+// @Tags			status
+// @Param			list_id	query		string	false	"ID списка"
+// @Success		200		{array}		StatusResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/statuses [get]
+func (h *Handler) getAllStatuses(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	list, ok := h.getQueryUserList(c, existedUser.ID)
+
+	if !ok {
+		response.NewErrorResponse(c, http.StatusNotFound, service.ErrListNotFound)
+		return
+	}
+
+	ownerId, listId := existedUser.ID, (*uuid.UUID)(nil)
+
+	if list != nil {
+		ownerId, listId = list.UserId, &list.ID
+	}
+
+	statuses, err := h.scoped(c).Status.GetAll(ownerId, listId)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetStatuses)
+		return
+	}
+
+	var statusesResponse = make([]StatusResponse, 0)
+
+	for _, status := range *statuses {
+		statusesResponse = append(statusesResponse, newStatusResponse(status))
+	}
+
+	c.JSON(http.StatusOK, statusesResponse)
+}
+
+// @Description	Добавление статуса в рабочий процесс пользователя или списка
+// @Tags			status
+// @Param			data	body		CreateStatusRequest	true	"Данные нового статуса"
+// @Success		201		{object}	StatusResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		403		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		422		{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/statuses [post]
+func (h *Handler) createStatus(c *gin.Context) {
+	var body CreateStatusRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	ownerId, listId := existedUser.ID, (*uuid.UUID)(nil)
+
+	if body.ListId != nil {
+		list, err := h.findUserList(c, uuid.MustParse(*body.ListId), existedUser.ID, domain.ListRoleAdmin)
+
+		if err != nil {
+			respondAccessError(c, err, service.ErrListNotFound)
+			return
+		}
+
+		ownerId, listId = list.UserId, &list.ID
+	}
+
+	status, err := h.scoped(c).Status.Create(ownerId, listId, body.Name)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToCreateStatus)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newStatusResponse(*status))
+}
+
+// @Description	Удаление статуса рабочего процесса
+// @Tags			status
+// @Param			id	path	string	true	"ID статуса"
+// @Success		204
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		403	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Failure		409	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/statuses/{id} [delete]
+func (h *Handler) deleteStatus(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusNotFound, service.ErrStatusNotFound)
+		return
+	}
+
+	status, err := h.scoped(c).Status.FindById(id)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusNotFound, service.ErrStatusNotFound)
+		return
+	}
+
+	if status.ListId != nil {
+		if _, err = h.findUserList(c, *status.ListId, existedUser.ID, domain.ListRoleAdmin); err != nil {
+			respondAccessError(c, err, service.ErrStatusNotFound)
+			return
+		}
+	} else if status.UserId != existedUser.ID {
+		response.NewErrorResponse(c, http.StatusNotFound, service.ErrStatusNotFound)
+		return
+	}
+
+	err = h.scoped(c).Status.Delete(status.ID)
+
+	if err != nil {
+		switch err.Error() {
+		case service.ErrStatusIsDone, service.ErrStatusIsUsed, service.ErrStatusIsLast:
+			response.NewErrorResponse(c, http.StatusConflict, err.Error())
+		default:
+			response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToDeleteStatus)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// getQueryUserList возвращает список из параметра запроса list_id.
+// Второе значение равно false, если параметр задан, но список недоступен пользователю.
+func (h *Handler) getQueryUserList(c *gin.Context, userId uuid.UUID) (*domain.List, bool) {
+	value, ok := c.GetQuery("list_id")
+
+	if !ok {
+		return nil, true
+	}
+
+	id, err := uuid.Parse(value)
+
+	if err != nil {
+		return nil, false
+	}
+
+	list, err := h.findUserList(c, id, userId, domain.ListRoleViewer)
+
+	if err != nil {
+		return nil, false
+	}
+
+	return list, true
+}
+
+func newStatusResponse(status domain.Status) StatusResponse {
+	return StatusResponse{
+		Id:       status.ID.String(),
+		ListId:   uuidToString(status.ListId),
+		Name:     status.Name,
+		Position: status.Position,
+		IsDone:   status.IsDone,
+	}
+}
@@ -0,0 +1,257 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"testing"
+)
+
+func TestGetAllStatuses(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		query        string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, listService *mock_service.MockList, listMemberService *mock_service.MockListMember, statusService *mock_service.MockStatus)
+	}{
+		{
+			name:       "List of another user",
+			query:      "?list_id=" + faker.UUIDHyphenated(),
+			response:   `{"message":"List not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, listService *mock_service.MockList, listMemberService *mock_service.MockListMember, statusService *mock_service.MockStatus) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				listService.EXPECT().FindById(gomock.Any()).Return(&domain.List{}, nil)
+				listMemberService.EXPECT().GetRole(gomock.Any(), userId).Return("")
+			},
+		},
+		{
+			name:       "Shared list",
+			query:      "?list_id=8d306d55-4301-4770-8a90-e64f771dc3f9",
+			response:   `[]`,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, listService *mock_service.MockList, listMemberService *mock_service.MockListMember, statusService *mock_service.MockStatus) {
+				listId, ownerId := uuid.MustParse("8d306d55-4301-4770-8a90-e64f771dc3f9"), uuid.New()
+
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				listService.EXPECT().FindById(listId).Return(&domain.List{ID: listId, UserId: ownerId}, nil)
+				listMemberService.EXPECT().GetRole(gomock.Any(), userId).Return(domain.ListRoleViewer)
+				statusService.EXPECT().GetAll(ownerId, &listId).Return(&[]domain.Status{}, nil)
+			},
+		},
+		{
+			name:       "Failed to get statuses",
+			response:   `{"message":"Failed to get statuses"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(userService *mock_service.MockUser, listService *mock_service.MockList, listMemberService *mock_service.MockListMember, statusService *mock_service.MockStatus) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				statusService.EXPECT().GetAll(userId, nil).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Success",
+			response:   `[{"id":"3f0c9b2e-6c1a-4d2b-9f7e-1a2b3c4d5e6f","list_id":null,"name":"Done","position":3,"is_done":true}]`,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, listService *mock_service.MockList, listMemberService *mock_service.MockListMember, statusService *mock_service.MockStatus) {
+				statusId, _ := uuid.Parse("3f0c9b2e-6c1a-4d2b-9f7e-1a2b3c4d5e6f")
+
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				statusService.EXPECT().GetAll(userId, nil).Return(&[]domain.Status{
+					{ID: statusId, Name: "Done", Position: 3, IsDone: true},
+				}, nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			listService := mock_service.NewMockList(c)
+			listMemberService := mock_service.NewMockListMember(c)
+			statusService := mock_service.NewMockStatus(c)
+
+			tc.mockFunction(userService, listService, listMemberService, statusService)
+			handler := Handler{services: &service.Services{
+				User: userService, List: listService, ListMember: listMemberService, Status: statusService,
+			}}
+
+			r := gin.New()
+			r.GET("/statuses", setContextEmail, handler.getAllStatuses)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/statuses"+tc.query, nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestCreateStatus(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		body         string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, listService *mock_service.MockList, statusService *mock_service.MockStatus)
+	}{
+		{
+			name:       "Missing name",
+			body:       `{}`,
+			response:   `{"message":"Key: 'CreateStatusRequest.Name' Error:Field validation for 'Name' failed on the 'required' tag"}`,
+			statusCode: http.StatusUnprocessableEntity,
+			mockFunction: func(userService *mock_service.MockUser, listService *mock_service.MockList, statusService *mock_service.MockStatus) {
+			},
+		},
+		{
+			name:       "List not existed",
+			body:       `{"name":"Testing","list_id":"` + faker.UUIDHyphenated() + `"}`,
+			response:   `{"message":"List not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, listService *mock_service.MockList, statusService *mock_service.MockStatus) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				listService.EXPECT().FindById(gomock.Any()).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Success",
+			body:       `{"name":"Testing"}`,
+			response:   `{"id":"3f0c9b2e-6c1a-4d2b-9f7e-1a2b3c4d5e6f","list_id":null,"name":"Testing","position":4,"is_done":false}`,
+			statusCode: http.StatusCreated,
+			mockFunction: func(userService *mock_service.MockUser, listService *mock_service.MockList, statusService *mock_service.MockStatus) {
+				statusId, _ := uuid.Parse("3f0c9b2e-6c1a-4d2b-9f7e-1a2b3c4d5e6f")
+
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				statusService.EXPECT().Create(userId, nil, "Testing").Return(&domain.Status{ID: statusId, Name: "Testing", Position: 4}, nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			listService := mock_service.NewMockList(c)
+			statusService := mock_service.NewMockStatus(c)
+
+			tc.mockFunction(userService, listService, statusService)
+			handler := Handler{services: &service.Services{User: userService, List: listService, Status: statusService}}
+
+			r := gin.New()
+			r.POST("/statuses", setContextEmail, handler.createStatus)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/statuses", bytes.NewBufferString(tc.body))
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestDeleteStatus(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		statusId     string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, listService *mock_service.MockList, listMemberService *mock_service.MockListMember, statusService *mock_service.MockStatus)
+	}{
+		{
+			name:       "Status of another user",
+			statusId:   faker.UUIDHyphenated(),
+			response:   `{"message":"Status not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, listService *mock_service.MockList, listMemberService *mock_service.MockListMember, statusService *mock_service.MockStatus) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				statusService.EXPECT().FindById(gomock.Any()).Return(&domain.Status{}, nil)
+			},
+		},
+		{
+			name:       "Shared list status without admin role",
+			statusId:   faker.UUIDHyphenated(),
+			response:   `{"message":"Insufficient permissions"}`,
+			statusCode: http.StatusForbidden,
+			mockFunction: func(userService *mock_service.MockUser, listService *mock_service.MockList, listMemberService *mock_service.MockListMember, statusService *mock_service.MockStatus) {
+				listId := uuid.New()
+
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				statusService.EXPECT().FindById(gomock.Any()).Return(&domain.Status{ListId: &listId}, nil)
+				listService.EXPECT().FindById(listId).Return(&domain.List{ID: listId}, nil)
+				listMemberService.EXPECT().GetRole(gomock.Any(), userId).Return(domain.ListRoleEditor)
+			},
+		},
+		{
+			name:       "Done status",
+			statusId:   faker.UUIDHyphenated(),
+			response:   `{"message":"Done status can't be deleted"}`,
+			statusCode: http.StatusConflict,
+			mockFunction: func(userService *mock_service.MockUser, listService *mock_service.MockList, listMemberService *mock_service.MockListMember, statusService *mock_service.MockStatus) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				statusService.EXPECT().FindById(gomock.Any()).Return(&domain.Status{UserId: userId, IsDone: true}, nil)
+				statusService.EXPECT().Delete(gomock.Any()).Return(errors.New(service.ErrStatusIsDone))
+			},
+		},
+		{
+			name:       "Success",
+			statusId:   faker.UUIDHyphenated(),
+			response:   ``,
+			statusCode: http.StatusNoContent,
+			mockFunction: func(userService *mock_service.MockUser, listService *mock_service.MockList, listMemberService *mock_service.MockListMember, statusService *mock_service.MockStatus) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				statusService.EXPECT().FindById(gomock.Any()).Return(&domain.Status{UserId: userId}, nil)
+				statusService.EXPECT().Delete(gomock.Any()).Return(nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			listService := mock_service.NewMockList(c)
+			listMemberService := mock_service.NewMockListMember(c)
+			statusService := mock_service.NewMockStatus(c)
+
+			tc.mockFunction(userService, listService, listMemberService, statusService)
+			handler := Handler{services: &service.Services{
+				User: userService, List: listService, ListMember: listMemberService, Status: statusService,
+			}}
+
+			r := gin.New()
+			r.DELETE("/statuses/:id", setContextEmail, handler.deleteStatus)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/statuses/"+tc.statusId, nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
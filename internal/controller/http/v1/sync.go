@@ -0,0 +1,190 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/response"
+)
+
+const (
+	ErrFailedToGetChanges   = "failed to get changes"
+	ErrFailedToApplyChanges = "failed to apply changes"
+)
+
+type PullChangesRequest struct {
+	Since string `form:"since"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=1000"`
+}
+
+type SyncChangesResponse struct {
+	Created   []TaskResponse `json:"created"`
+	Updated   []TaskResponse `json:"updated"`
+	Deleted   []string       `json:"deleted"`
+	SyncToken string         `json:"sync_token"`
+	HasMore   bool           `json:"has_more"`
+}
+
+type SyncChangeRequest struct {
+	Op          string    `json:"op" binding:"required,oneof=create update delete"`
+	Id          uuid.UUID `json:"id" binding:"required"`
+	BaseVersion *int      `json:"base_version" binding:"omitempty,min=1"`
+	Description *string   `json:"description"`
+	IsCompleted *bool     `json:"is_completed"`
+}
+
+type PushChangesRequest struct {
+	Changes []SyncChangeRequest `json:"changes" binding:"required,min=1,max=500,dive"`
+}
+
+type SyncResultResponse struct {
+	Id     string        `json:"id"`
+	Op     string        `json:"op"`
+	Status string        `json:"status"`
+	Error  string        `json:"error,omitempty"`
+	Task   *TaskResponse `json:"task"`
+}
+
+type PushChangesResponse struct {
+	Results []SyncResultResponse `json:"results"`
+}
+
+func (h *Handler) initSyncRoutes(api *gin.RouterGroup) {
+	sync := api.Group("/sync", h.auth)
+	{
+		sync.GET("", h.pullChanges)
+		sync.POST("", h.pushChanges)
+	}
+}
+
+// @Description	Получение изменений задач после токена синхронизации since. Без since возвращаются все задачи.
+// @Description	Удаленные задачи, в том числе удаленные окончательно и ставшие недоступными (задача перенесена в другой
+// @Description	список, пользователь исключен из списка), возвращаются списком идентификаторов. Если has_more=true, следующую порцию
+// @Description	нужно запросить с полученным sync_token.
+// @Tags			sync
+// @Param			since	query		string	false	"Токен синхронизации из предыдущего ответа"
+// @Param			limit	query		int		false	"Количество изменений (по умолчанию 500, не более 1000)"
+// @Success		200		{object}	SyncChangesResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		422		{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/sync [get]
+func (h *Handler) pullChanges(c *gin.Context) {
+	var query PullChangesRequest
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	page, err := h.scoped(c).Sync.Pull(existedUser.ID, query.Since, query.Limit)
+
+	if err != nil {
+		if err.Error() == service.ErrInvalidSyncToken {
+			response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetChanges)
+		return
+	}
+
+	changesResponse := SyncChangesResponse{
+		Created:   newTaskResponses(page.Created),
+		Updated:   newTaskResponses(page.Updated),
+		Deleted:   make([]string, 0, len(page.Deleted)),
+		SyncToken: page.Token,
+		HasMore:   page.HasMore,
+	}
+
+	for _, id := range page.Deleted {
+		changesResponse.Deleted = append(changesResponse.Deleted, id.String())
+	}
+
+	c.JSON(http.StatusOK, changesResponse)
+}
+
+// @Description	Применение изменений, сделанных клиентом без связи с сервером. Для create идентификатор задачи
+// @Description	генерирует клиент, для update и delete передается base_version - версия задачи, которую изменял клиент.
+// @Description	Для каждого изменения возвращается статус: applied, conflict (задача изменена или удалена на сервере,
+// @Description	в task - ее актуальное состояние) или rejected.
+// @Tags			sync
+// @Param			data	body		PushChangesRequest	true	"Изменения клиента"
+// @Success		200		{object}	PushChangesResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		422		{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/sync [post]
+func (h *Handler) pushChanges(c *gin.Context) {
+	var body PushChangesRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	changes := make([]service.SyncChange, 0, len(body.Changes))
+
+	for _, change := range body.Changes {
+		changes = append(changes, service.SyncChange{
+			Op:          change.Op,
+			Id:          change.Id,
+			BaseVersion: change.BaseVersion,
+			Description: change.Description,
+			IsCompleted: change.IsCompleted,
+		})
+	}
+
+	results, err := h.scoped(c).Sync.Push(existedUser.ID, changes)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToApplyChanges)
+		return
+	}
+
+	pushResponse := PushChangesResponse{Results: make([]SyncResultResponse, 0, len(results))}
+
+	for _, result := range results {
+		resultResponse := SyncResultResponse{
+			Id:     result.Id.String(),
+			Op:     result.Op,
+			Status: result.Status,
+			Error:  result.Error,
+		}
+
+		if result.Task != nil {
+			task := newTaskResponse(*result.Task)
+			resultResponse.Task = &task
+		}
+
+		pushResponse.Results = append(pushResponse.Results, resultResponse)
+	}
+
+	c.JSON(http.StatusOK, pushResponse)
+}
+
+func newTaskResponses(tasks []domain.Task) []TaskResponse {
+	tasksResponse := make([]TaskResponse, 0, len(tasks))
+
+	for _, task := range tasks {
+		tasksResponse = append(tasksResponse, newTaskResponse(task))
+	}
+
+	return tasksResponse
+}
@@ -0,0 +1,166 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"testing"
+)
+
+func TestPullChanges(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+	deletedId, _ := uuid.Parse("8d306d55-4301-4770-8a90-e64f771dc3f9")
+
+	testCases := []struct {
+		name         string
+		query        string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, syncService *mock_service.MockSync)
+	}{
+		{
+			name:       "Invalid token",
+			query:      "?since=abc",
+			response:   `{"message":"Invalid sync token"}`,
+			statusCode: http.StatusUnprocessableEntity,
+			mockFunction: func(userService *mock_service.MockUser, syncService *mock_service.MockSync) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				syncService.EXPECT().Pull(userId, "abc", 0).Return(nil, errors.New(service.ErrInvalidSyncToken))
+			},
+		},
+		{
+			name:       "Failed to get changes",
+			query:      "",
+			response:   `{"message":"Failed to get changes"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(userService *mock_service.MockUser, syncService *mock_service.MockSync) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				syncService.EXPECT().Pull(userId, "", 0).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Success",
+			query:      "?since=MTA&limit=10",
+			response:   `{"created":[` + fixtureTaskResponse + `],"updated":[],"deleted":["8d306d55-4301-4770-8a90-e64f771dc3f9"],"sync_token":"MTI","has_more":false}`,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, syncService *mock_service.MockSync) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				syncService.EXPECT().Pull(userId, "MTA", 10).Return(&service.SyncPage{
+					Created: []domain.Task{*fixtureTask(userId)},
+					Updated: []domain.Task{},
+					Deleted: []uuid.UUID{deletedId},
+					Token:   "MTI",
+				}, nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			syncService := mock_service.NewMockSync(c)
+
+			tc.mockFunction(userService, syncService)
+			handler := Handler{services: &service.Services{User: userService, Sync: syncService}}
+
+			r := gin.New()
+			r.GET("/sync", setContextEmail, handler.pullChanges)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/sync"+tc.query, nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestPushChanges(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+	deletedId, _ := uuid.Parse("8d306d55-4301-4770-8a90-e64f771dc3f9")
+
+	testCases := []struct {
+		name         string
+		body         string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, syncService *mock_service.MockSync)
+	}{
+		{
+			name:         "Unknown operation",
+			body:         `{"changes":[{"op":"move","id":"8d306d55-4301-4770-8a90-e64f771dc3f9"}]}`,
+			response:     `{"message":"Key: 'PushChangesRequest.Changes[0].Op' Error:Field validation for 'Op' failed on the 'oneof' tag"}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(userService *mock_service.MockUser, syncService *mock_service.MockSync) {},
+		},
+		{
+			name:       "Failed to apply changes",
+			body:       `{"changes":[{"op":"delete","id":"8d306d55-4301-4770-8a90-e64f771dc3f9","base_version":1}]}`,
+			response:   `{"message":"Failed to apply changes"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(userService *mock_service.MockUser, syncService *mock_service.MockSync) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				syncService.EXPECT().Push(userId, gomock.Any()).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Success",
+			body:       `{"changes":[{"op":"update","id":"2b7e3c1a-5d4f-4e6a-9b8c-0d1e2f3a4b5c","base_version":1,"description":"test"},{"op":"delete","id":"8d306d55-4301-4770-8a90-e64f771dc3f9"}]}`,
+			response:   `{"results":[{"id":"2b7e3c1a-5d4f-4e6a-9b8c-0d1e2f3a4b5c","op":"update","status":"conflict","error":"task was modified on the server","task":` + fixtureTaskResponse + `},{"id":"8d306d55-4301-4770-8a90-e64f771dc3f9","op":"delete","status":"applied","task":null}]}`,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, syncService *mock_service.MockSync) {
+				description, baseVersion := "test", 1
+
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				syncService.EXPECT().Push(userId, []service.SyncChange{
+					{Op: service.SyncOperationUpdate, Id: fixtureTaskId, BaseVersion: &baseVersion, Description: &description},
+					{Op: service.SyncOperationDelete, Id: deletedId},
+				}).Return([]service.SyncResult{
+					{
+						Op:     service.SyncOperationUpdate,
+						Id:     fixtureTaskId,
+						Status: service.SyncStatusConflict,
+						Error:  service.ErrSyncTaskModified,
+						Task:   fixtureTask(userId),
+					},
+					{Op: service.SyncOperationDelete, Id: deletedId, Status: service.SyncStatusApplied},
+				}, nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			syncService := mock_service.NewMockSync(c)
+
+			tc.mockFunction(userService, syncService)
+			handler := Handler{services: &service.Services{User: userService, Sync: syncService}}
+
+			r := gin.New()
+			r.POST("/sync", setContextEmail, handler.pushChanges)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/sync", bytes.NewBufferString(tc.body))
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/response"
+)
+
+const ErrFailedToAssignTask = "failed to assign task"
+
+type AssignTaskRequest struct {
+	AssigneeId *string `json:"assignee_id" binding:"omitempty,uuid"`
+}
+
+func (h *Handler) initTaskAssignmentRoutes(api *gin.RouterGroup) {
+	tasks := api.Group("/tasks", h.auth)
+	{
+		tasks.GET("/assigned-to-me", h.getAssignedTasks)
+		tasks.PATCH("/:id/assignee", h.assignTask)
+	}
+}
+
+// @Description	Получение задач, назначенных пользователю
+// @Tags			task-assignment
+// @Param			If-None-Match	header	string	false	"ETag ранее полученного ответа"
+// @Success		200				{array}	GetAllByUserIdResponse
+// @Success		304
+// @Failure		400	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/assigned-to-me [get]
+func (h *Handler) getAssignedTasks(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	writeJSONWithETag(c, newTasksResponse(*h.scoped(c).Task.GetAssignedToUserId(existedUser.ID)))
+}
+
+// @Description	Назначение задачи участнику ее списка. Пустой assignee_id снимает назначение.
+// @Tags			task-assignment
+// @Param			id			path		string				true	"ID задачи"
+// @Param			If-Match	header		string				false	"ETag задачи, полученный при чтении"
+// @Param			data		body		AssignTaskRequest	true	"ID назначаемого пользователя"
+// @Success		200			{object}	TaskResponse
+// @Header			200			{string}	ETag	"Версия задачи"
+// @Failure		400			{object}	response.ErrorResponse
+// @Failure		403			{object}	response.ErrorResponse
+// @Failure		404			{object}	response.ErrorResponse
+// @Failure		412			{object}	response.ErrorResponse
+// @Failure		422			{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/{id}/assignee [patch]
+func (h *Handler) assignTask(c *gin.Context) {
+	var body AssignTaskRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	task, err := h.getUserTask(c, "id", existedUser.ID, domain.ListRoleEditor)
+
+	if err != nil {
+		respondAccessError(c, err, ErrTaskNotFound)
+		return
+	}
+
+	if !checkTaskPrecondition(c, *task) {
+		return
+	}
+
+	var assigneeId *uuid.UUID
+
+	if body.AssigneeId != nil {
+		id := uuid.MustParse(*body.AssigneeId)
+		assigneeId = &id
+	}
+
+	assignedTask, err := h.scoped(c).TaskAssignment.Assign(existedUser.ID, task, assigneeId)
+
+	if err != nil {
+		switch err.Error() {
+		case service.ErrAssigneeNotMember:
+			response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		case service.ErrTaskVersionConflict:
+			response.NewErrorResponse(c, http.StatusPreconditionFailed, ErrTaskVersionMismatch)
+		default:
+			response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToAssignTask)
+		}
+		return
+	}
+
+	c.Header("ETag", taskETag(*assignedTask))
+	c.JSON(http.StatusOK, newTaskResponse(*assignedTask))
+}
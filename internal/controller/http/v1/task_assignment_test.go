@@ -0,0 +1,129 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"testing"
+)
+
+func TestGetAssignedTasks(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	userService := mock_service.NewMockUser(c)
+	taskService := mock_service.NewMockTask(c)
+
+	task := fixtureTask(uuid.Nil)
+	task.AssigneeId = &userId
+
+	userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+	taskService.EXPECT().GetAssignedToUserId(userId).Return(&[]domain.Task{*task})
+	handler := Handler{services: &service.Services{User: userService, Task: taskService}}
+
+	r := gin.New()
+	r.GET("/tasks/assigned-to-me", setContextEmail, handler.getAssignedTasks)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/tasks/assigned-to-me", nil)
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `[{"id":"2b7e3c1a-5d4f-4e6a-9b8c-0d1e2f3a4b5c","list_id":null,"assignee_id":"64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b",`+
+		`"status_id":null,"description":"test","is_completed":false,"is_blocked":false,"created_at":"2026-10-01T10:00:00Z"}]`, w.Body.String())
+}
+
+func TestAssignTask(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+	assigneeId, _ := uuid.Parse("5c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f")
+
+	testCases := []struct {
+		name         string
+		body         string
+		response     string
+		statusCode   int
+		mockFunction func(taskService *mock_service.MockTask, assignmentService *mock_service.MockTaskAssignment)
+	}{
+		{
+			name:         "Invalid assignee id",
+			body:         `{"assignee_id":"john"}`,
+			response:     `{"message":"Key: 'AssignTaskRequest.AssigneeId' Error:Field validation for 'AssigneeId' failed on the 'uuid' tag"}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(taskService *mock_service.MockTask, assignmentService *mock_service.MockTaskAssignment) {},
+		},
+		{
+			name:       "Task of another user",
+			body:       `{"assignee_id":"5c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f"}`,
+			response:   `{"message":"Task not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(taskService *mock_service.MockTask, assignmentService *mock_service.MockTaskAssignment) {
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(uuid.Nil), nil)
+			},
+		},
+		{
+			name:       "Assignee is not a member",
+			body:       `{"assignee_id":"5c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f"}`,
+			response:   `{"message":"Assignee has no access to the task"}`,
+			statusCode: http.StatusUnprocessableEntity,
+			mockFunction: func(taskService *mock_service.MockTask, assignmentService *mock_service.MockTaskAssignment) {
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(userId), nil)
+				assignmentService.EXPECT().Assign(userId, gomock.Any(), &assigneeId).Return(nil, errors.New(service.ErrAssigneeNotMember))
+			},
+		},
+		{
+			name:       "Failed to assign",
+			body:       `{"assignee_id":null}`,
+			response:   `{"message":"Failed to assign task"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(taskService *mock_service.MockTask, assignmentService *mock_service.MockTaskAssignment) {
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(userId), nil)
+				assignmentService.EXPECT().Assign(userId, gomock.Any(), nil).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Success",
+			body:       `{"assignee_id":"5c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f"}`,
+			response:   fixtureTaskResponse,
+			statusCode: http.StatusOK,
+			mockFunction: func(taskService *mock_service.MockTask, assignmentService *mock_service.MockTaskAssignment) {
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(userId), nil)
+				assignmentService.EXPECT().Assign(userId, gomock.Any(), &assigneeId).Return(fixtureTask(userId), nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			taskService := mock_service.NewMockTask(c)
+			assignmentService := mock_service.NewMockTaskAssignment(c)
+
+			userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil).AnyTimes()
+			tc.mockFunction(taskService, assignmentService)
+			handler := Handler{services: &service.Services{User: userService, Task: taskService, TaskAssignment: assignmentService}}
+
+			r := gin.New()
+			r.PATCH("/tasks/:id/assignee", setContextEmail, handler.assignTask)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PATCH", "/tasks/"+fixtureTaskId.String()+"/assignee", bytes.NewBufferString(tc.body))
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
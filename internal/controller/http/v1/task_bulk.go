@@ -0,0 +1,104 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/response"
+)
+
+const ErrFailedToExecuteBulkOperations = "failed to execute bulk operations"
+
+type BulkOperationRequest struct {
+	Op     string     `json:"op" binding:"required,oneof=complete incomplete delete move tag"`
+	TaskId uuid.UUID  `json:"task_id" binding:"required"`
+	ListId *uuid.UUID `json:"list_id"`
+	Tags   []string   `json:"tags" binding:"omitempty,dive,max=50"`
+}
+
+type BulkOperationsRequest struct {
+	Atomic     bool                   `json:"atomic"`
+	Operations []BulkOperationRequest `json:"operations" binding:"required,min=1,max=100,dive"`
+}
+
+type BulkOperationResultResponse struct {
+	TaskId string `json:"task_id"`
+	Op     string `json:"op"`
+	Ok     bool   `json:"ok"`
+	Error  string `json:"error,omitempty"`
+}
+
+type BulkOperationsResponse struct {
+	Results   []BulkOperationResultResponse `json:"results"`
+	Succeeded int                           `json:"succeeded"`
+	Failed    int                           `json:"failed"`
+}
+
+func (h *Handler) initTaskBulkRoutes(api *gin.RouterGroup) {
+	api.POST("/tasks/bulk", h.auth, h.executeBulkOperations)
+}
+
+// @Description	Массовые операции над задачами: complete, incomplete, delete, move (list_id, null - вне списков)
+// @Description	и tag (добавление тегов). Операции выполняются по порядку, результат возвращается для каждой.
+// @Description	При atomic=true ошибка любой операции отменяет все остальные.
+// @Tags			task
+// @Param			data	body		BulkOperationsRequest	true	"Операции"
+// @Success		200		{object}	BulkOperationsResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		422		{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/bulk [post]
+func (h *Handler) executeBulkOperations(c *gin.Context) {
+	var body BulkOperationsRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	operations := make([]service.BulkOperation, 0, len(body.Operations))
+
+	for _, operation := range body.Operations {
+		operations = append(operations, service.BulkOperation{
+			Op: operation.Op, TaskId: operation.TaskId, ListId: operation.ListId, Tags: operation.Tags,
+		})
+	}
+
+	results, err := h.scoped(c).TaskBulk.Execute(existedUser.ID, operations, body.Atomic)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToExecuteBulkOperations)
+		return
+	}
+
+	c.JSON(http.StatusOK, newBulkOperationsResponse(results))
+}
+
+func newBulkOperationsResponse(results []service.BulkResult) BulkOperationsResponse {
+	bulkResponse := BulkOperationsResponse{Results: make([]BulkOperationResultResponse, 0, len(results))}
+
+	for _, result := range results {
+		bulkResponse.Results = append(bulkResponse.Results, BulkOperationResultResponse{
+			TaskId: result.TaskId.String(),
+			Op:     result.Op,
+			Ok:     result.Error == "",
+			Error:  result.Error,
+		})
+
+		if result.Error == "" {
+			bulkResponse.Succeeded++
+		} else {
+			bulkResponse.Failed++
+		}
+	}
+
+	return bulkResponse
+}
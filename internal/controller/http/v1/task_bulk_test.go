@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"testing"
+)
+
+func TestExecuteBulkOperations(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+	taskId, _ := uuid.Parse("8d306d55-4301-4770-8a90-e64f771dc3f9")
+
+	testCases := []struct {
+		name         string
+		body         string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, taskBulkService *mock_service.MockTaskBulk)
+	}{
+		{
+			name:         "Empty operations",
+			body:         `{"operations":[]}`,
+			response:     `{"message":"Key: 'BulkOperationsRequest.Operations' Error:Field validation for 'Operations' failed on the 'min' tag"}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(userService *mock_service.MockUser, taskBulkService *mock_service.MockTaskBulk) {},
+		},
+		{
+			name:         "Unknown operation",
+			body:         `{"operations":[{"op":"archive","task_id":"8d306d55-4301-4770-8a90-e64f771dc3f9"}]}`,
+			response:     `{"message":"Key: 'BulkOperationsRequest.Operations[0].Op' Error:Field validation for 'Op' failed on the 'oneof' tag"}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(userService *mock_service.MockUser, taskBulkService *mock_service.MockTaskBulk) {},
+		},
+		{
+			name:       "Failed to execute",
+			body:       `{"operations":[{"op":"delete","task_id":"8d306d55-4301-4770-8a90-e64f771dc3f9"}]}`,
+			response:   `{"message":"Failed to execute bulk operations"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(userService *mock_service.MockUser, taskBulkService *mock_service.MockTaskBulk) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskBulkService.EXPECT().Execute(userId, gomock.Any(), false).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Success",
+			body:       `{"atomic":true,"operations":[{"op":"tag","task_id":"8d306d55-4301-4770-8a90-e64f771dc3f9","tags":["home"]},{"op":"complete","task_id":"8d306d55-4301-4770-8a90-e64f771dc3f9"}]}`,
+			response:   `{"results":[{"task_id":"8d306d55-4301-4770-8a90-e64f771dc3f9","op":"tag","ok":true},{"task_id":"8d306d55-4301-4770-8a90-e64f771dc3f9","op":"complete","ok":false,"error":"task is blocked by uncompleted tasks"}],"succeeded":1,"failed":1}`,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, taskBulkService *mock_service.MockTaskBulk) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskBulkService.EXPECT().Execute(userId, []service.BulkOperation{
+					{Op: service.BulkOperationTag, TaskId: taskId, Tags: []string{"home"}},
+					{Op: service.BulkOperationComplete, TaskId: taskId},
+				}, true).Return([]service.BulkResult{
+					{Op: service.BulkOperationTag, TaskId: taskId},
+					{Op: service.BulkOperationComplete, TaskId: taskId, Error: service.ErrTaskIsBlocked},
+				}, nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			taskBulkService := mock_service.NewMockTaskBulk(c)
+
+			tc.mockFunction(userService, taskBulkService)
+			handler := Handler{services: &service.Services{User: userService, TaskBulk: taskBulkService}}
+
+			r := gin.New()
+			r.POST("/tasks/bulk", setContextEmail, handler.executeBulkOperations)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/tasks/bulk", bytes.NewBufferString(tc.body))
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/response"
+)
+
+const (
+	ErrFailedToCreateTaskDependency = "failed to create task dependency"
+	ErrFailedToDeleteTaskDependency = "failed to delete task dependency"
+)
+
+type CreateTaskDependencyRequest struct {
+	BlockedById string `json:"blocked_by_id" binding:"required,uuid"`
+}
+
+type TaskBlockerResponse struct {
+	Id          string `json:"id"`
+	Description string `json:"description"`
+	IsCompleted bool   `json:"is_completed"`
+}
+
+func (h *Handler) initTaskDependenciesRoutes(api *gin.RouterGroup) {
+	dependencies := api.Group("/tasks/:id/dependencies", h.auth)
+	{
+		dependencies.GET("", h.getTaskDependencies)
+		dependencies.POST("", h.createTaskDependency)
+		dependencies.DELETE("/:blockedById", h.deleteTaskDependency)
+	}
+}
+
+// @Description	Получение списка задач, блокирующих задачу
+// @Tags			task-dependency
+// @Param			id	path		string	true	"ID задачи"
+// @Success		200	{array}		TaskBlockerResponse
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/{id}/dependencies [get]
+func (h *Handler) getTaskDependencies(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	task, err := h.getUserTask(c, "id", existedUser.ID, domain.ListRoleViewer)
+
+	if err != nil {
+		respondAccessError(c, err, ErrTaskNotFound)
+		return
+	}
+
+	blockers := h.scoped(c).TaskDependency.GetBlockers(task.ID)
+
+	var blockersResponse = make([]TaskBlockerResponse, 0)
+
+	for _, blocker := range *blockers {
+		blockersResponse = append(blockersResponse, TaskBlockerResponse{
+			Id:          blocker.ID.String(),
+			Description: blocker.Description,
+			IsCompleted: *blocker.IsCompleted,
+		})
+	}
+
+	c.JSON(http.StatusOK, blockersResponse)
+}
+
+// @Description	Добавление блокирующей задачи
+// @Tags			task-dependency
+// @Param			id		path	string						true	"ID задачи"
+// @Param			data	body	CreateTaskDependencyRequest	true	"ID блокирующей задачи"
+// @Success		204
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		403	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Failure		409	{object}	response.ErrorResponse
+// @Failure		422	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/{id}/dependencies [post]
+func (h *Handler) createTaskDependency(c *gin.Context) {
+	var body CreateTaskDependencyRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	task, err := h.getUserTask(c, "id", existedUser.ID, domain.ListRoleEditor)
+
+	if err != nil {
+		respondAccessError(c, err, ErrTaskNotFound)
+		return
+	}
+
+	err = h.scoped(c).TaskDependency.Link(existedUser.ID, task.ID, uuid.MustParse(body.BlockedById))
+
+	if err != nil {
+		switch err.Error() {
+		case service.ErrBlockingTaskNotFound:
+			response.NewErrorResponse(c, http.StatusNotFound, err.Error())
+		case service.ErrBlockingTaskOtherList:
+			response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		case service.ErrTaskDependencySelf, service.ErrTaskDependencyExists, service.ErrTaskDependencyCycle:
+			response.NewErrorResponse(c, http.StatusConflict, err.Error())
+		default:
+			response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToCreateTaskDependency)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Description	Удаление блокирующей задачи
+// @Tags			task-dependency
+// @Param			id			path	string	true	"ID задачи"
+// @Param			blockedById	path	string	true	"ID блокирующей задачи"
+// @Success		204
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		403	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/{id}/dependencies/{blockedById} [delete]
+func (h *Handler) deleteTaskDependency(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	task, err := h.getUserTask(c, "id", existedUser.ID, domain.ListRoleEditor)
+
+	if err != nil {
+		respondAccessError(c, err, ErrTaskNotFound)
+		return
+	}
+
+	blockedById, err := uuid.Parse(c.Param("blockedById"))
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusNotFound, service.ErrTaskDependencyNotFound)
+		return
+	}
+
+	err = h.scoped(c).TaskDependency.Unlink(task.ID, blockedById)
+
+	if err != nil {
+		if err.Error() == service.ErrTaskDependencyNotFound {
+			response.NewErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToDeleteTaskDependency)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,262 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"testing"
+)
+
+func TestGetTaskDependencies(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		taskId       string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, taskService *mock_service.MockTask, dependencyService *mock_service.MockTaskDependency)
+	}{
+		{
+			name:       "Not existed user",
+			taskId:     faker.UUIDHyphenated(),
+			response:   `{"message":"Failed to get user"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, dependencyService *mock_service.MockTaskDependency) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Task of another user",
+			taskId:     faker.UUIDHyphenated(),
+			response:   `{"message":"Task not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, dependencyService *mock_service.MockTaskDependency) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{}, nil)
+			},
+		},
+		{
+			name:       "Success",
+			taskId:     faker.UUIDHyphenated(),
+			response:   `[{"id":"8d306d55-4301-4770-8a90-e64f771dc3f9","description":"Description","is_completed":false}]`,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, dependencyService *mock_service.MockTaskDependency) {
+				blockerId, _ := uuid.Parse("8d306d55-4301-4770-8a90-e64f771dc3f9")
+				isCompleted := false
+
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil)
+				dependencyService.EXPECT().GetBlockers(gomock.Any()).Return(&[]domain.Task{
+					{ID: blockerId, Description: "Description", IsCompleted: &isCompleted},
+				})
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			taskService := mock_service.NewMockTask(c)
+			dependencyService := mock_service.NewMockTaskDependency(c)
+
+			tc.mockFunction(userService, taskService, dependencyService)
+			handler := Handler{services: &service.Services{User: userService, Task: taskService, TaskDependency: dependencyService}}
+
+			r := gin.New()
+			r.GET("/tasks/:id/dependencies", setContextEmail, handler.getTaskDependencies)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/tasks/"+tc.taskId+"/dependencies", nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestCreateTaskDependency(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+	blockedById := faker.UUIDHyphenated()
+
+	testCases := []struct {
+		name         string
+		body         string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, taskService *mock_service.MockTask, dependencyService *mock_service.MockTaskDependency)
+	}{
+		{
+			name:       "Missing blocking task",
+			body:       `{}`,
+			response:   `{"message":"Key: 'CreateTaskDependencyRequest.BlockedById' Error:Field validation for 'BlockedById' failed on the 'required' tag"}`,
+			statusCode: http.StatusUnprocessableEntity,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, dependencyService *mock_service.MockTaskDependency) {
+			},
+		},
+		{
+			name:       "Task not existed",
+			body:       `{"blocked_by_id":"` + blockedById + `"}`,
+			response:   `{"message":"Task not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, dependencyService *mock_service.MockTaskDependency) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Cycle",
+			body:       `{"blocked_by_id":"` + blockedById + `"}`,
+			response:   `{"message":"Dependency creates a cycle"}`,
+			statusCode: http.StatusConflict,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, dependencyService *mock_service.MockTaskDependency) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil)
+				dependencyService.EXPECT().Link(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New(service.ErrTaskDependencyCycle))
+			},
+		},
+		{
+			name:       "Blocking task not found",
+			body:       `{"blocked_by_id":"` + blockedById + `"}`,
+			response:   `{"message":"Blocking task not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, dependencyService *mock_service.MockTaskDependency) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil)
+				dependencyService.EXPECT().Link(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New(service.ErrBlockingTaskNotFound))
+			},
+		},
+		{
+			name:       "Blocking task in another list",
+			body:       `{"blocked_by_id":"` + blockedById + `"}`,
+			response:   `{"message":"Blocking task must be in the same list"}`,
+			statusCode: http.StatusUnprocessableEntity,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, dependencyService *mock_service.MockTaskDependency) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil)
+				dependencyService.EXPECT().Link(userId, gomock.Any(), gomock.Any()).Return(errors.New(service.ErrBlockingTaskOtherList))
+			},
+		},
+		{
+			name:       "Success",
+			body:       `{"blocked_by_id":"` + blockedById + `"}`,
+			response:   ``,
+			statusCode: http.StatusNoContent,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, dependencyService *mock_service.MockTaskDependency) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil)
+				dependencyService.EXPECT().Link(gomock.Any(), gomock.Any(), uuid.MustParse(blockedById)).Return(nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			taskService := mock_service.NewMockTask(c)
+			dependencyService := mock_service.NewMockTaskDependency(c)
+
+			tc.mockFunction(userService, taskService, dependencyService)
+			handler := Handler{services: &service.Services{User: userService, Task: taskService, TaskDependency: dependencyService}}
+
+			r := gin.New()
+			r.POST("/tasks/:id/dependencies", setContextEmail, handler.createTaskDependency)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/tasks/"+faker.UUIDHyphenated()+"/dependencies", bytes.NewBufferString(tc.body))
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestDeleteTaskDependency(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		blockedById  string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, taskService *mock_service.MockTask, dependencyService *mock_service.MockTaskDependency)
+	}{
+		{
+			name:        "Failed to parse blocking task id",
+			blockedById: faker.Word(),
+			response:    `{"message":"Dependency not found"}`,
+			statusCode:  http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, dependencyService *mock_service.MockTaskDependency) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil)
+			},
+		},
+		{
+			name:        "Dependency not existed",
+			blockedById: faker.UUIDHyphenated(),
+			response:    `{"message":"Dependency not found"}`,
+			statusCode:  http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, dependencyService *mock_service.MockTaskDependency) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil)
+				dependencyService.EXPECT().Unlink(gomock.Any(), gomock.Any()).Return(errors.New(service.ErrTaskDependencyNotFound))
+			},
+		},
+		{
+			name:        "Success",
+			blockedById: faker.UUIDHyphenated(),
+			response:    ``,
+			statusCode:  http.StatusNoContent,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, dependencyService *mock_service.MockTaskDependency) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil)
+				dependencyService.EXPECT().Unlink(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			taskService := mock_service.NewMockTask(c)
+			dependencyService := mock_service.NewMockTaskDependency(c)
+
+			tc.mockFunction(userService, taskService, dependencyService)
+			handler := Handler{services: &service.Services{User: userService, Task: taskService, TaskDependency: dependencyService}}
+
+			r := gin.New()
+			r.DELETE("/tasks/:id/dependencies/:blockedById", setContextEmail, handler.deleteTaskDependency)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/tasks/"+faker.UUIDHyphenated()+"/dependencies/"+tc.blockedById, nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func setContextEmail(c *gin.Context) {
+	c.Set(ContextEmailKey, faker.Email())
+}
@@ -0,0 +1,146 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/response"
+	"strconv"
+	"time"
+)
+
+const (
+	ErrInvalidRevision    = "invalid revision"
+	ErrFailedToRevertTask = "failed to revert task"
+)
+
+type TaskChangeResponse struct {
+	Field    string  `json:"field"`
+	OldValue *string `json:"old_value"`
+	NewValue *string `json:"new_value"`
+}
+
+type TaskRevisionResponse struct {
+	Revision  int                  `json:"revision"`
+	ActorId   *string              `json:"actor_id"`
+	CreatedAt time.Time            `json:"created_at"`
+	Changes   []TaskChangeResponse `json:"changes"`
+}
+
+func (h *Handler) initTaskHistoryRoutes(api *gin.RouterGroup) {
+	tasks := api.Group("/tasks/:id", h.auth)
+	{
+		tasks.GET("/history", h.getTaskHistory)
+		tasks.POST("/revert/:revision", h.revertTask)
+	}
+}
+
+// @Description	Получение истории изменений задачи, начиная с последней ревизии
+// @Tags			task-history
+// @Param			id	path		string	true	"ID задачи"
+// @Success		200	{array}		TaskRevisionResponse
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/{id}/history [get]
+func (h *Handler) getTaskHistory(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	task, err := h.getUserTask(c, "id", existedUser.ID, domain.ListRoleViewer)
+
+	if err != nil {
+		respondAccessError(c, err, ErrTaskNotFound)
+		return
+	}
+
+	revisions := h.scoped(c).Task.History(task.ID)
+	historyResponse := make([]TaskRevisionResponse, 0, len(revisions))
+
+	for _, revision := range revisions {
+		historyResponse = append(historyResponse, newTaskRevisionResponse(revision))
+	}
+
+	c.JSON(http.StatusOK, historyResponse)
+}
+
+// @Description	Возврат полей задачи к значениям указанной ревизии. Откат записывается в историю новой ревизией.
+// @Tags			task-history
+// @Param			id			path		string	true	"ID задачи"
+// @Param			revision	path		int		true	"Номер ревизии"
+// @Param			If-Match	header		string	false	"ETag задачи, полученный при чтении"
+// @Success		200			{object}	TaskResponse
+// @Failure		400			{object}	response.ErrorResponse
+// @Failure		403			{object}	response.ErrorResponse
+// @Failure		404			{object}	response.ErrorResponse
+// @Failure		409			{object}	response.ErrorResponse
+// @Failure		412			{object}	response.ErrorResponse
+// @Failure		422			{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/{id}/revert/{revision} [post]
+func (h *Handler) revertTask(c *gin.Context) {
+	revision, err := strconv.Atoi(c.Param("revision"))
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, ErrInvalidRevision)
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	task, err := h.getUserTask(c, "id", existedUser.ID, domain.ListRoleEditor)
+
+	if err != nil {
+		respondAccessError(c, err, ErrTaskNotFound)
+		return
+	}
+
+	if !checkTaskPrecondition(c, *task) {
+		return
+	}
+
+	if _, err = withTaskPrecondition(c, h.scoped(c).Task.WithActor(existedUser.ID), *task).Revert(task.ID, revision); err != nil {
+		switch err.Error() {
+		case service.ErrRevisionNotFound:
+			response.NewErrorResponse(c, http.StatusNotFound, err.Error())
+		case service.ErrTaskIsBlocked, service.ErrAssigneeNotMember:
+			response.NewErrorResponse(c, http.StatusConflict, err.Error())
+		case service.ErrTaskVersionConflict:
+			response.NewErrorResponse(c, http.StatusPreconditionFailed, ErrTaskVersionMismatch)
+		default:
+			response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToRevertTask)
+		}
+		return
+	}
+
+	h.writeTask(c, task.ID)
+}
+
+func newTaskRevisionResponse(revision domain.TaskRevision) TaskRevisionResponse {
+	changes := make([]TaskChangeResponse, 0, len(revision.Changes))
+
+	for _, change := range revision.Changes {
+		changes = append(changes, TaskChangeResponse{
+			Field:    change.Field,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+
+	return TaskRevisionResponse{
+		Revision:  revision.Revision,
+		ActorId:   uuidToString(revision.ActorId),
+		CreatedAt: revision.CreatedAt,
+		Changes:   changes,
+	}
+}
@@ -0,0 +1,188 @@
+package v1
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"testing"
+	"time"
+)
+
+func TestGetTaskHistory(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, taskService *mock_service.MockTask)
+	}{
+		{
+			name:       "Task of another user",
+			response:   `{"message":"Task not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(uuid.Nil), nil)
+			},
+		},
+		{
+			name: "Success",
+			response: `[{"revision":2,"actor_id":"64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b","created_at":"2025-02-08T12:00:00Z",` +
+				`"changes":[{"field":"description","old_value":"old","new_value":"test"}]}]`,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				oldValue, newValue := "old", "test"
+
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(userId), nil)
+				taskService.EXPECT().History(fixtureTaskId).Return([]domain.TaskRevision{{
+					Revision:  2,
+					ActorId:   &userId,
+					CreatedAt: time.Date(2025, 2, 8, 12, 0, 0, 0, time.UTC),
+					Changes:   []domain.TaskChange{{Field: domain.TaskFieldDescription, OldValue: &oldValue, NewValue: &newValue}},
+				}})
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			taskService := mock_service.NewMockTask(c)
+
+			tc.mockFunction(userService, taskService)
+			handler := Handler{services: &service.Services{User: userService, Task: taskService}}
+
+			r := gin.New()
+			r.GET("/tasks/:id/history", setContextEmail, handler.getTaskHistory)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/tasks/"+fixtureTaskId.String()+"/history", nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestRevertTask(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		revision     string
+		ifMatch      string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, taskService *mock_service.MockTask)
+	}{
+		{
+			name:         "Invalid revision",
+			revision:     "first",
+			response:     `{"message":"Invalid revision"}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {},
+		},
+		{
+			name:       "Version mismatch",
+			revision:   "1",
+			ifMatch:    `"5"`,
+			response:   `{"message":"Task has been modified, reload it and try again"}`,
+			statusCode: http.StatusPreconditionFailed,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(userId), nil)
+			},
+		},
+		{
+			name:       "Revision not found",
+			revision:   "7",
+			response:   `{"message":"Revision not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(userId), nil)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().Revert(fixtureTaskId, 7).Return(nil, errors.New(service.ErrRevisionNotFound))
+			},
+		},
+		{
+			name:       "Task is blocked",
+			revision:   "1",
+			response:   `{"message":"Task is blocked by uncompleted tasks"}`,
+			statusCode: http.StatusConflict,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(userId), nil)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().Revert(fixtureTaskId, 1).Return(nil, errors.New(service.ErrTaskIsBlocked))
+			},
+		},
+		{
+			name:       "Assignee has no access",
+			revision:   "1",
+			response:   `{"message":"Assignee has no access to the task"}`,
+			statusCode: http.StatusConflict,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(userId), nil)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().Revert(fixtureTaskId, 1).Return(nil, errors.New(service.ErrAssigneeNotMember))
+			},
+		},
+		{
+			name:       "Success",
+			revision:   "1",
+			ifMatch:    `"1"`,
+			response:   fixtureTaskResponse,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(userId), nil).Times(2)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().WithVersion(1).Return(taskService)
+				taskService.EXPECT().Revert(fixtureTaskId, 1).Return(fixtureTask(userId), nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			taskService := mock_service.NewMockTask(c)
+
+			tc.mockFunction(userService, taskService)
+			handler := Handler{services: &service.Services{User: userService, Task: taskService}}
+
+			r := gin.New()
+			r.POST("/tasks/:id/revert/:revision", setContextEmail, handler.revertTask)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/tasks/"+fixtureTaskId.String()+"/revert/"+tc.revision, nil)
+
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
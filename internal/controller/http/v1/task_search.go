@@ -0,0 +1,87 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/response"
+	"time"
+)
+
+const ErrFailedToSearchTasks = "failed to search tasks"
+
+type SearchTasksRequest struct {
+	Query string `form:"q" binding:"required,max=200"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type TaskSearchResultResponse struct {
+	Id          string    `json:"id"`
+	ListId      *string   `json:"list_id"`
+	Description string    `json:"description"`
+	IsCompleted bool      `json:"is_completed"`
+	IsArchived  bool      `json:"is_archived"`
+	CreatedAt   time.Time `json:"created_at"`
+	Rank        float64   `json:"rank"`
+	Snippet     string    `json:"snippet"`
+}
+
+func (h *Handler) initTaskSearchRoutes(api *gin.RouterGroup) {
+	api.GET("/tasks/search", h.auth, h.searchTasks)
+}
+
+// @Description	Полнотекстовый поиск по задачам пользователя, включая архивные. Слова запроса ищутся по префиксу,
+// @Description	результаты упорядочены по релевантности, в snippet найденные слова выделены тегом <b>.
+// @Description	Остальной текст snippet экранирован, поэтому его можно выводить как HTML.
+// @Tags			task
+// @Param			q		query		string	true	"Поисковый запрос"
+// @Param			limit	query		int		false	"Количество результатов (по умолчанию 20, не более 100)"
+// @Success		200		{array}		TaskSearchResultResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		422		{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/search [get]
+func (h *Handler) searchTasks(c *gin.Context) {
+	var query SearchTasksRequest
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	results, err := h.scoped(c).TaskSearch.Search(existedUser.ID, query.Query, query.Limit)
+
+	if err != nil {
+		if err.Error() == service.ErrEmptySearchQuery {
+			response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToSearchTasks)
+		return
+	}
+
+	searchResponse := make([]TaskSearchResultResponse, 0, len(*results))
+
+	for _, result := range *results {
+		searchResponse = append(searchResponse, TaskSearchResultResponse{
+			Id:          result.ID.String(),
+			ListId:      uuidToString(result.ListId),
+			Description: result.Description,
+			IsCompleted: *result.IsCompleted,
+			IsArchived:  result.ArchivedAt != nil,
+			CreatedAt:   result.CreatedAt,
+			Rank:        result.Rank,
+			Snippet:     result.Snippet,
+		})
+	}
+
+	c.JSON(http.StatusOK, searchResponse)
+}
@@ -0,0 +1,104 @@
+package v1
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"testing"
+	"time"
+)
+
+func TestSearchTasks(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		query        string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, taskSearchService *mock_service.MockTaskSearch)
+	}{
+		{
+			name:         "Empty query",
+			query:        "",
+			response:     `{"message":"Key: 'SearchTasksRequest.Query' Error:Field validation for 'Query' failed on the 'required' tag"}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(userService *mock_service.MockUser, taskSearchService *mock_service.MockTaskSearch) {},
+		},
+		{
+			name:       "Query without words",
+			query:      "&!",
+			response:   `{"message":"Search query must contain at least one word"}`,
+			statusCode: http.StatusUnprocessableEntity,
+			mockFunction: func(userService *mock_service.MockUser, taskSearchService *mock_service.MockTaskSearch) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskSearchService.EXPECT().Search(userId, "&!", 0).Return(nil, errors.New(service.ErrEmptySearchQuery))
+			},
+		},
+		{
+			name:       "Failed to search",
+			query:      "milk",
+			response:   `{"message":"Failed to search tasks"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(userService *mock_service.MockUser, taskSearchService *mock_service.MockTaskSearch) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskSearchService.EXPECT().Search(userId, "milk", 0).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Success",
+			query:      "молок",
+			response:   `[{"id":"8d306d55-4301-4770-8a90-e64f771dc3f9","list_id":null,"description":"Купить молоко","is_completed":true,"is_archived":true,"created_at":"2026-10-01T10:00:00Z","rank":0.06,"snippet":"Купить \u003cb\u003eмолоко\u003c/b\u003e"}]`,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, taskSearchService *mock_service.MockTaskSearch) {
+				taskId, _ := uuid.Parse("8d306d55-4301-4770-8a90-e64f771dc3f9")
+				isCompleted := true
+				archivedAt := time.Now()
+
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskSearchService.EXPECT().Search(userId, "молок", 0).Return(&[]domain.TaskSearchResult{{
+					Task: domain.Task{
+						ID:          taskId,
+						Description: "Купить молоко",
+						IsCompleted: &isCompleted,
+						ArchivedAt:  &archivedAt,
+						CreatedAt:   time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC),
+					},
+					Rank:    0.06,
+					Snippet: "Купить <b>молоко</b>",
+				}}, nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			taskSearchService := mock_service.NewMockTaskSearch(c)
+
+			tc.mockFunction(userService, taskSearchService)
+			handler := Handler{services: &service.Services{User: userService, TaskSearch: taskSearchService}}
+
+			r := gin.New()
+			r.GET("/tasks/search", setContextEmail, handler.searchTasks)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/tasks/search?q="+url.QueryEscape(tc.query), nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/pkg/response"
+)
+
+const ErrFailedToUpdateTaskTags = "failed to update task tags"
+
+type UpdateTaskTagsRequest struct {
+	Tags []string `json:"tags" binding:"omitempty,dive,max=50"`
+}
+
+type TaskTagsResponse struct {
+	Tags []string `json:"tags"`
+}
+
+func (h *Handler) initTaskTagsRoutes(api *gin.RouterGroup) {
+	tags := api.Group("/tasks/:id/tags", h.auth)
+	{
+		tags.GET("", h.getTaskTags)
+		tags.PUT("", h.updateTaskTags)
+	}
+}
+
+// @Description	Получение тегов задачи
+// @Tags			task-tag
+// @Param			id	path		string	true	"ID задачи"
+// @Success		200	{object}	TaskTagsResponse
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/{id}/tags [get]
+func (h *Handler) getTaskTags(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	task, err := h.getUserTask(c, "id", existedUser.ID, domain.ListRoleViewer)
+
+	if err != nil {
+		respondAccessError(c, err, ErrTaskNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, newTaskTagsResponse(h.scoped(c).TaskTag.GetByTaskId(task.ID)))
+}
+
+// @Description	Замена тегов задачи
+// @Tags			task-tag
+// @Param			id		path		string					true	"ID задачи"
+// @Param			data	body		UpdateTaskTagsRequest	true	"Новый набор тегов"
+// @Success		200		{object}	TaskTagsResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		403		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		422		{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/{id}/tags [put]
+func (h *Handler) updateTaskTags(c *gin.Context) {
+	var body UpdateTaskTagsRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	task, err := h.getUserTask(c, "id", existedUser.ID, domain.ListRoleEditor)
+
+	if err != nil {
+		respondAccessError(c, err, ErrTaskNotFound)
+		return
+	}
+
+	tags, err := h.scoped(c).TaskTag.Set(task.ID, body.Tags)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToUpdateTaskTags)
+		return
+	}
+
+	c.JSON(http.StatusOK, newTaskTagsResponse(tags))
+}
+
+func newTaskTagsResponse(tags []string) TaskTagsResponse {
+	if tags == nil {
+		tags = make([]string, 0)
+	}
+
+	return TaskTagsResponse{Tags: tags}
+}
@@ -0,0 +1,105 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"testing"
+)
+
+func TestUpdateTaskTags(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		body         string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, taskService *mock_service.MockTask, taskTagService *mock_service.MockTaskTag)
+	}{
+		{
+			name:       "Invalid body",
+			body:       `{"tags":"home"}`,
+			response:   `{"message":"Json: cannot unmarshal string into Go struct field UpdateTaskTagsRequest.tags of type []string"}`,
+			statusCode: http.StatusUnprocessableEntity,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, taskTagService *mock_service.MockTaskTag) {
+			},
+		},
+		{
+			name:       "Task of another user",
+			body:       `{"tags":["home"]}`,
+			response:   `{"message":"Task not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, taskTagService *mock_service.MockTaskTag) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{}, nil)
+			},
+		},
+		{
+			name:       "Failed to update tags",
+			body:       `{"tags":["home"]}`,
+			response:   `{"message":"Failed to update task tags"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, taskTagService *mock_service.MockTaskTag) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil)
+				taskTagService.EXPECT().Set(gomock.Any(), []string{"home"}).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Clear tags",
+			body:       `{"tags":[]}`,
+			response:   `{"tags":[]}`,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, taskTagService *mock_service.MockTaskTag) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil)
+				taskTagService.EXPECT().Set(gomock.Any(), []string{}).Return(nil, nil)
+			},
+		},
+		{
+			name:       "Success",
+			body:       `{"tags":["Urgent","home"]}`,
+			response:   `{"tags":["home","urgent"]}`,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, taskTagService *mock_service.MockTaskTag) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil)
+				taskTagService.EXPECT().Set(gomock.Any(), []string{"Urgent", "home"}).Return([]string{"home", "urgent"}, nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			taskService := mock_service.NewMockTask(c)
+			taskTagService := mock_service.NewMockTaskTag(c)
+
+			tc.mockFunction(userService, taskService, taskTagService)
+			handler := Handler{services: &service.Services{User: userService, Task: taskService, TaskTag: taskTagService}}
+
+			r := gin.New()
+			r.PUT("/tasks/:id/tags", setContextEmail, handler.updateTaskTags)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/tasks/"+faker.UUIDHyphenated()+"/tags", bytes.NewBufferString(tc.body))
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
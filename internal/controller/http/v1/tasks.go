@@ -4,7 +4,10 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"net/http"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
 	"poymanov/todo/pkg/response"
+	"strings"
 	"time"
 )
 
@@ -14,20 +17,70 @@ const (
 	ErrTaskNotFound       = "task not found"
 	ErrFailedToUpdateTask = "failed to update task"
 	ErrFailedToDeleteTask = "failed to delete task"
+	ErrFailedToGetTasks   = "failed to get tasks"
+	ErrFailedToGetTask    = "failed to get task"
 )
 
 type CreateTaskRequest struct {
-	Description string `json:"description" binding:"required"`
+	Description string  `json:"description" binding:"required"`
+	ListId      *string `json:"list_id" binding:"omitempty,uuid"`
 }
 
 type UpdateTaskRequest struct {
 	Description string `json:"description" binding:"required"`
 }
 
+type UpdateTaskDueRequest struct {
+	DueAt *time.Time `json:"due_at"`
+}
+
+type UpdateTaskStatusRequest struct {
+	StatusId string `json:"status_id" binding:"required,uuid"`
+}
+
+type GetTasksRequest struct {
+	Limit       int        `form:"limit" binding:"omitempty,min=1,max=200"`
+	Cursor      string     `form:"cursor"`
+	Completed   *bool      `form:"completed"`
+	CreatedFrom *time.Time `form:"created_from" time_format:"2006-01-02" time_utc:"1"`
+	CreatedTo   *time.Time `form:"created_to" time_format:"2006-01-02" time_utc:"1"`
+	Query       string     `form:"q" binding:"max=200"`
+	Sort        string     `form:"sort" binding:"omitempty,oneof=created_at -created_at updated_at -updated_at description -description"`
+}
+
+type TasksPageResponse struct {
+	Items          []GetAllByUserIdResponse `json:"items"`
+	NextCursor     *string                  `json:"next_cursor"`
+	Total          int64                    `json:"total"`
+	TotalCompleted int64                    `json:"total_completed"`
+}
+
+// TaskResponse - полное представление задачи
+type TaskResponse struct {
+	Id              string     `json:"id"`
+	ListId          *string    `json:"list_id"`
+	AssigneeId      *string    `json:"assignee_id"`
+	StatusId        *string    `json:"status_id"`
+	Description     string     `json:"description"`
+	IsCompleted     bool       `json:"is_completed"`
+	IsBlocked       bool       `json:"is_blocked"`
+	Version         int        `json:"version"`
+	EstimateMinutes *int       `json:"estimate_minutes"`
+	DueAt           *time.Time `json:"due_at"`
+	CompletedAt     *time.Time `json:"completed_at"`
+	ArchivedAt      *time.Time `json:"archived_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
 type GetAllByUserIdResponse struct {
 	Id          string    `json:"id"`
+	ListId      *string   `json:"list_id"`
+	AssigneeId  *string   `json:"assignee_id"`
+	StatusId    *string   `json:"status_id"`
 	Description string    `json:"description"`
 	IsCompleted bool      `json:"is_completed"`
+	IsBlocked   bool      `json:"is_blocked"`
 	CreatedAt   time.Time `json:"created_at"`
 }
 
@@ -36,19 +89,27 @@ func (h *Handler) initTasksRoutes(api *gin.RouterGroup) {
 	{
 		tasks.GET("", h.getAllTasksByUserId)
 		tasks.POST("", h.createTask)
+		tasks.GET("/:id", h.getTask)
 		tasks.PATCH("/:id", h.updateTaskDescription)
 		tasks.PATCH("/:id/complete", h.updateTaskIsComplete(true))
 		tasks.PATCH("/:id/incomplete", h.updateTaskIsComplete(false))
+		tasks.PATCH("/:id/status", h.updateTaskStatus)
+		tasks.PATCH("/:id/due", h.updateTaskDue)
 		tasks.DELETE("/:id", h.deleteTask)
 	}
 }
 
-// @Description	Создание задачи
+// @Description	Создание задачи. Адрес созданной задачи возвращается в заголовке Location.
 // @Tags			task
-// @Param			data	body	CreateTaskRequest	true	"Данные новой задачи"
-// @Success		204
-// @Failure		400	{object}	response.ErrorResponse
-// @Failure		422	{object}	response.ErrorResponse
+// @Param			data			body		CreateTaskRequest	true	"Данные новой задачи"
+// @Param			Idempotency-Key	header		string				false	"Ключ идемпотентности: повтор запроса с тем же ключом вернет сохраненный ответ"
+// @Success		201				{object}	TaskResponse
+// @Header			201				{string}	Location	"Адрес созданной задачи"
+// @Failure		400				{object}	response.ErrorResponse
+// @Failure		403				{object}	response.ErrorResponse
+// @Failure		404				{object}	response.ErrorResponse
+// @Failure		409				{object}	response.ErrorResponse
+// @Failure		422				{object}	response.ErrorResponse
 // @Security		ApiKeyAuth
 // @Router			/tasks [post]
 func (h *Handler) createTask(c *gin.Context) {
@@ -66,31 +127,77 @@ func (h *Handler) createTask(c *gin.Context) {
 		return
 	}
 
-	existedUser, _ := h.services.User.FindByEmail(userEmail)
+	existedUser, _ := h.scoped(c).User.FindByEmail(userEmail)
 
 	if existedUser == nil {
 		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
 		return
 	}
 
-	_, err = h.services.Task.Create(body.Description, existedUser.ID)
+	var listId *uuid.UUID
+
+	if body.ListId != nil {
+		list, err := h.findUserList(c, uuid.MustParse(*body.ListId), existedUser.ID, domain.ListRoleEditor)
+
+		if err != nil {
+			respondAccessError(c, err, service.ErrListNotFound)
+			return
+		}
+
+		listId = &list.ID
+	}
+
+	createdTask, err := h.scoped(c).Task.WithActor(existedUser.ID).Create(body.Description, existedUser.ID, listId)
 
 	if err != nil {
 		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToCreateTask)
 		return
 	}
 
-	c.JSON(http.StatusNoContent, nil)
+	c.Header("Location", strings.TrimSuffix(c.Request.URL.Path, "/")+"/"+createdTask.ID.String())
+	c.Header("ETag", taskETag(*createdTask))
+	c.JSON(http.StatusCreated, newTaskResponse(*createdTask))
 }
 
-// @Description	Обновление задачи
+// @Description	Получение задачи
 // @Tags			task
-// @Param			id		path	string				true	"ID задачи"
-// @Param			data	body	UpdateTaskRequest	true	"Новые данные для задачи"
-// @Success		204
+// @Param			id	path		string	true	"ID задачи"
+// @Success		200	{object}	TaskResponse
+// @Header			200	{string}	ETag	"Версия задачи"
 // @Failure		400	{object}	response.ErrorResponse
 // @Failure		404	{object}	response.ErrorResponse
-// @Failure		422	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/{id} [get]
+func (h *Handler) getTask(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	task, err := h.getUserTask(c, "id", existedUser.ID, domain.ListRoleViewer)
+
+	if err != nil {
+		respondAccessError(c, err, ErrTaskNotFound)
+		return
+	}
+
+	c.Header("ETag", taskETag(*task))
+	c.JSON(http.StatusOK, newTaskResponse(*task))
+}
+
+// @Description	Обновление задачи
+// @Tags			task
+// @Param			id			path		string				true	"ID задачи"
+// @Param			data		body		UpdateTaskRequest	true	"Новые данные для задачи"
+// @Param			If-Match	header		string				false	"ETag задачи, полученный при чтении"
+// @Success		200			{object}	TaskResponse
+// @Failure		400			{object}	response.ErrorResponse
+// @Failure		403			{object}	response.ErrorResponse
+// @Failure		404			{object}	response.ErrorResponse
+// @Failure		422			{object}	response.ErrorResponse
+// @Failure		412			{object}	response.ErrorResponse
 // @Security		ApiKeyAuth
 // @Router			/tasks/{id} [patch]
 func (h *Handler) updateTaskDescription(c *gin.Context) {
@@ -101,100 +208,276 @@ func (h *Handler) updateTaskDescription(c *gin.Context) {
 		return
 	}
 
-	id, err := uuid.Parse(c.Param("id"))
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	task, err := h.getUserTask(c, "id", existedUser.ID, domain.ListRoleEditor)
 
 	if err != nil {
-		response.NewErrorResponse(c, http.StatusNotFound, ErrTaskNotFound)
+		respondAccessError(c, err, ErrTaskNotFound)
 		return
 	}
 
-	if !h.services.Task.IsExistsById(id) {
-		response.NewErrorResponse(c, http.StatusNotFound, ErrTaskNotFound)
+	if !checkTaskPrecondition(c, *task) {
 		return
 	}
 
-	_, err = h.services.Task.UpdateDescription(id, body.Description)
+	_, err = withTaskPrecondition(c, h.scoped(c).Task.WithActor(existedUser.ID), *task).UpdateDescription(task.ID, body.Description)
 
 	if err != nil {
-		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToUpdateTask)
+		switch err.Error() {
+		case service.ErrTaskVersionConflict:
+			response.NewErrorResponse(c, http.StatusPreconditionFailed, ErrTaskVersionMismatch)
+		default:
+			response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToUpdateTask)
+		}
 		return
 	}
 
-	c.Status(http.StatusNoContent)
+	h.writeTask(c, task.ID)
 }
 
 // @Description	Обновление статуса завершения задачи
 // @Tags			task
-// @Param			id	path	string	true	"ID задачи"
-// @Success		204
-// @Failure		400	{object}	response.ErrorResponse
-// @Failure		404	{object}	response.ErrorResponse
+// @Param			id			path		string	true	"ID задачи"
+// @Param			If-Match	header		string	false	"ETag задачи, полученный при чтении"
+// @Success		200			{object}	TaskResponse
+// @Failure		400			{object}	response.ErrorResponse
+// @Failure		403			{object}	response.ErrorResponse
+// @Failure		404			{object}	response.ErrorResponse
+// @Failure		409			{object}	response.ErrorResponse
+// @Failure		412			{object}	response.ErrorResponse
 // @Security		ApiKeyAuth
 // @Router			/tasks/{id}/complete [patch]
 // @Router			/tasks/{id}/incomplete [patch]
 func (h *Handler) updateTaskIsComplete(isComplete bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		id, err := uuid.Parse(c.Param("id"))
+		existedUser, err := h.getContextUser(c)
 
 		if err != nil {
-			response.NewErrorResponse(c, http.StatusNotFound, ErrTaskNotFound)
+			response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
 			return
 		}
 
-		if !h.services.Task.IsExistsById(id) {
-			response.NewErrorResponse(c, http.StatusNotFound, ErrTaskNotFound)
+		task, err := h.getUserTask(c, "id", existedUser.ID, domain.ListRoleEditor)
+
+		if err != nil {
+			respondAccessError(c, err, ErrTaskNotFound)
+			return
+		}
+
+		if !checkTaskPrecondition(c, *task) {
 			return
 		}
 
-		_, err = h.services.Task.UpdateIsCompleted(id, isComplete)
+		_, err = withTaskPrecondition(c, h.scoped(c).Task.WithActor(existedUser.ID), *task).UpdateIsCompleted(task.ID, isComplete)
 
 		if err != nil {
-			response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToUpdateTask)
+			switch err.Error() {
+			case service.ErrTaskIsBlocked:
+				response.NewErrorResponse(c, http.StatusConflict, err.Error())
+			case service.ErrTaskVersionConflict:
+				response.NewErrorResponse(c, http.StatusPreconditionFailed, ErrTaskVersionMismatch)
+			default:
+				response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToUpdateTask)
+			}
 			return
 		}
 
-		c.Status(http.StatusNoContent)
+		h.writeTask(c, task.ID)
+	}
+
+}
+
+// @Description	Перевод задачи в другой статус рабочего процесса
+// @Tags			task
+// @Param			id			path		string					true	"ID задачи"
+// @Param			data		body		UpdateTaskStatusRequest	true	"Новый статус задачи"
+// @Param			If-Match	header		string					false	"ETag задачи, полученный при чтении"
+// @Success		200			{object}	TaskResponse
+// @Failure		400			{object}	response.ErrorResponse
+// @Failure		403			{object}	response.ErrorResponse
+// @Failure		404			{object}	response.ErrorResponse
+// @Failure		409			{object}	response.ErrorResponse
+// @Failure		422			{object}	response.ErrorResponse
+// @Failure		412			{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/{id}/status [patch]
+func (h *Handler) updateTaskStatus(c *gin.Context) {
+	var body UpdateTaskStatusRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	task, err := h.getUserTask(c, "id", existedUser.ID, domain.ListRoleEditor)
+
+	if err != nil {
+		respondAccessError(c, err, ErrTaskNotFound)
+		return
+	}
+
+	if !checkTaskPrecondition(c, *task) {
+		return
+	}
+
+	_, err = withTaskPrecondition(c, h.scoped(c).Task.WithActor(existedUser.ID), *task).UpdateStatus(task.ID, uuid.MustParse(body.StatusId))
+
+	if err != nil {
+		switch err.Error() {
+		case service.ErrStatusNotFound:
+			response.NewErrorResponse(c, http.StatusNotFound, err.Error())
+		case service.ErrTaskIsBlocked:
+			response.NewErrorResponse(c, http.StatusConflict, err.Error())
+		case service.ErrTaskVersionConflict:
+			response.NewErrorResponse(c, http.StatusPreconditionFailed, ErrTaskVersionMismatch)
+		default:
+			response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToUpdateTask)
+		}
+		return
+	}
+
+	h.writeTask(c, task.ID)
+}
+
+// @Description	Установка срока выполнения задачи. Значение null снимает срок.
+// @Tags			task
+// @Param			id			path		string					true	"ID задачи"
+// @Param			data		body		UpdateTaskDueRequest	true	"Срок выполнения"
+// @Param			If-Match	header		string					false	"ETag задачи, полученный при чтении"
+// @Success		200			{object}	TaskResponse
+// @Failure		400			{object}	response.ErrorResponse
+// @Failure		403			{object}	response.ErrorResponse
+// @Failure		404			{object}	response.ErrorResponse
+// @Failure		422			{object}	response.ErrorResponse
+// @Failure		412			{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/{id}/due [patch]
+func (h *Handler) updateTaskDue(c *gin.Context) {
+	var body UpdateTaskDueRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	task, err := h.getUserTask(c, "id", existedUser.ID, domain.ListRoleEditor)
+
+	if err != nil {
+		respondAccessError(c, err, ErrTaskNotFound)
+		return
 	}
 
+	if !checkTaskPrecondition(c, *task) {
+		return
+	}
+
+	if _, err = withTaskPrecondition(c, h.scoped(c).Task.WithActor(existedUser.ID), *task).UpdateDueAt(task.ID, body.DueAt); err != nil {
+		switch err.Error() {
+		case service.ErrTaskVersionConflict:
+			response.NewErrorResponse(c, http.StatusPreconditionFailed, ErrTaskVersionMismatch)
+		default:
+			response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToUpdateTask)
+		}
+		return
+	}
+
+	h.writeTask(c, task.ID)
 }
 
-// @Description	Удаление задачи
+// @Description	Удаление задачи. По умолчанию задача перемещается в корзину, с permanent=true удаляется окончательно.
+// @Description	Окончательно удалить чужую задачу списка может только администратор списка.
 // @Tags			task
-// @Param			id	path	string	true	"ID задачи"
+// @Param			id			path	string	true	"ID задачи"
+// @Param			permanent	query	bool	false	"Удалить окончательно, минуя корзину"
+// @Param			If-Match	header	string	false	"ETag задачи, полученный при чтении"
 // @Success		204
 // @Failure		400	{object}	response.ErrorResponse
+// @Failure		403	{object}	response.ErrorResponse
 // @Failure		404	{object}	response.ErrorResponse
+// @Failure		412	{object}	response.ErrorResponse
 // @Security		ApiKeyAuth
 // @Router			/tasks/{id} [delete]
 func (h *Handler) deleteTask(c *gin.Context) {
-	id, err := uuid.Parse(c.Param("id"))
+	if c.Query("permanent") == "true" {
+		h.purgeTask(c)
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
 
 	if err != nil {
-		response.NewErrorResponse(c, http.StatusNotFound, ErrTaskNotFound)
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
 		return
 	}
 
-	if !h.services.Task.IsExistsById(id) {
-		response.NewErrorResponse(c, http.StatusNotFound, ErrTaskNotFound)
+	task, err := h.getUserTask(c, "id", existedUser.ID, domain.ListRoleEditor)
+
+	if err != nil {
+		respondAccessError(c, err, ErrTaskNotFound)
 		return
 	}
 
-	err = h.services.Task.Delete(id)
+	if !checkTaskPrecondition(c, *task) {
+		return
+	}
 
-	if err != nil {
-		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToDeleteTask)
+	if err = withTaskPrecondition(c, h.scoped(c).Task.WithActor(existedUser.ID), *task).Delete(task.ID); err != nil {
+		switch err.Error() {
+		case service.ErrTaskVersionConflict:
+			response.NewErrorResponse(c, http.StatusPreconditionFailed, ErrTaskVersionMismatch)
+		default:
+			response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToDeleteTask)
+		}
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
 
-// @Description	Получение списка задач пользователя
+// @Description	Получение списка задач пользователя с постраничной выборкой по курсору, фильтрами и сортировкой
 // @Tags			task
-// @Success		200	{array}		GetAllByUserIdResponse
+// @Param			limit			query		int		false	"Количество задач на странице (по умолчанию 50, не более 200)"
+// @Param			cursor			query		string	false	"Курсор следующей страницы из next_cursor"
+// @Param			completed		query		bool	false	"Отбор по признаку завершенности"
+// @Param			created_from	query		string	false	"Созданы не раньше даты (YYYY-MM-DD)"
+// @Param			created_to		query		string	false	"Созданы не позже даты включительно (YYYY-MM-DD)"
+// @Param			q				query		string	false	"Подстрока описания"
+// @Param			sort			query		string	false	"Поле сортировки, префикс - для обратного порядка"	Enums(created_at, -created_at, updated_at, -updated_at, description, -description)
+// @Param			If-None-Match	header		string	false	"ETag ранее полученного ответа"
+// @Success		200				{object}	TasksPageResponse
+// @Success		304
 // @Failure		400	{object}	response.ErrorResponse
+// @Failure		422	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
 // @Router			/tasks [get]
 func (h *Handler) getAllTasksByUserId(c *gin.Context) {
+	var query GetTasksRequest
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
 	userEmail, err := getContextEmail(c)
 
 	if err != nil {
@@ -202,25 +485,111 @@ func (h *Handler) getAllTasksByUserId(c *gin.Context) {
 		return
 	}
 
-	existedUser, err := h.services.User.FindByEmail(userEmail)
+	existedUser, err := h.scoped(c).User.FindByEmail(userEmail)
 
 	if err != nil {
 		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
 		return
 	}
 
-	tasks := h.services.Task.GetAllByUserId(existedUser.ID)
+	filter := domain.TaskFilter{
+		UserId:      existedUser.ID,
+		Completed:   query.Completed,
+		CreatedFrom: query.CreatedFrom,
+		Query:       query.Query,
+	}
+
+	if query.CreatedTo != nil {
+		createdTo := query.CreatedTo.AddDate(0, 0, 1)
+		filter.CreatedTo = &createdTo
+	}
+
+	page, err := h.scoped(c).Task.GetPageByUserId(filter, query.Sort, query.Cursor, query.Limit)
+
+	if err != nil {
+		switch err.Error() {
+		case service.ErrInvalidTaskCursor, service.ErrInvalidTaskSort:
+			response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		default:
+			response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetTasks)
+		}
+		return
+	}
+
+	writeJSONWithETag(c, newTasksPageResponse(page))
+}
+
+// writeTask отвечает актуальным состоянием задачи id после ее изменения
+func (h *Handler) writeTask(c *gin.Context, id uuid.UUID) {
+	task, err := h.scoped(c).Task.FindById(id)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetTask)
+		return
+	}
+
+	c.Header("ETag", taskETag(*task))
+	c.JSON(http.StatusOK, newTaskResponse(*task))
+}
 
-	var tasksResponse = make([]GetAllByUserIdResponse, 0)
+func newTaskResponse(task domain.Task) TaskResponse {
+	return TaskResponse{
+		Id:              task.ID.String(),
+		ListId:          uuidToString(task.ListId),
+		AssigneeId:      uuidToString(task.AssigneeId),
+		StatusId:        uuidToString(task.StatusId),
+		Description:     task.Description,
+		IsCompleted:     task.IsCompleted != nil && *task.IsCompleted,
+		IsBlocked:       task.IsBlocked,
+		Version:         task.Version,
+		EstimateMinutes: task.EstimateMinutes,
+		DueAt:           task.DueAt,
+		CompletedAt:     task.CompletedAt,
+		ArchivedAt:      task.ArchivedAt,
+		CreatedAt:       task.CreatedAt,
+		UpdatedAt:       task.UpdatedAt,
+	}
+}
+
+func newTasksPageResponse(page *domain.TaskPage) TasksPageResponse {
+	pageResponse := TasksPageResponse{
+		Items:          newTasksResponse(page.Tasks),
+		Total:          page.Counts.Total,
+		TotalCompleted: page.Counts.Completed,
+	}
 
-	for _, task := range *tasks {
+	if page.NextCursor != "" {
+		pageResponse.NextCursor = &page.NextCursor
+	}
+
+	return pageResponse
+}
+
+func newTasksResponse(tasks []domain.Task) []GetAllByUserIdResponse {
+	var tasksResponse = make([]GetAllByUserIdResponse, 0, len(tasks))
+
+	for _, task := range tasks {
 		tasksResponse = append(tasksResponse, GetAllByUserIdResponse{
 			Id:          task.ID.String(),
+			ListId:      uuidToString(task.ListId),
+			AssigneeId:  uuidToString(task.AssigneeId),
+			StatusId:    uuidToString(task.StatusId),
 			Description: task.Description,
 			IsCompleted: *task.IsCompleted,
+			IsBlocked:   task.IsBlocked,
 			CreatedAt:   task.CreatedAt,
 		})
 	}
 
-	c.JSON(http.StatusOK, tasksResponse)
+	return tasksResponse
+}
+
+func uuidToString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+
+	value := id.String()
+
+	return &value
 }
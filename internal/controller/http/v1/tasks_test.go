@@ -73,20 +73,22 @@ func TestCreateTask(t *testing.T) {
 			},
 			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
 				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{}, nil)
-				taskService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("failed"))
+				taskService.EXPECT().WithActor(uuid.Nil).Return(taskService)
+				taskService.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("failed"))
 			},
 		},
 		{
 			name:       "Success",
 			body:       `{"description": "test"}`,
-			response:   ``,
-			statusCode: http.StatusNoContent,
+			response:   fixtureTaskResponse,
+			statusCode: http.StatusCreated,
 			contextModifier: func(c *gin.Context) {
 				c.Set(ContextEmailKey, faker.Email())
 			},
 			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
 				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{}, nil)
-				taskService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&domain.Task{}, nil)
+				taskService.EXPECT().WithActor(uuid.Nil).Return(taskService)
+				taskService.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(fixtureTask(uuid.Nil), nil)
 			},
 		},
 	}
@@ -111,18 +113,242 @@ func TestCreateTask(t *testing.T) {
 
 			require.Equal(t, tc.statusCode, w.Code)
 			require.Equal(t, tc.response, w.Body.String())
+
+			if tc.statusCode == http.StatusCreated {
+				require.Equal(t, "/tasks/"+fixtureTaskId.String(), w.Header().Get("Location"))
+			}
 		})
 	}
 }
 
-func TestUpdateTaskDescription(t *testing.T) {
+func TestCreateTaskInList(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+	listId, _ := uuid.Parse("8d306d55-4301-4770-8a90-e64f771dc3f9")
+
 	testCases := []struct {
 		name         string
 		body         string
+		response     string
+		statusCode   int
+		mockFunction func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember, taskService *mock_service.MockTask)
+	}{
+		{
+			name:       "List of another user",
+			body:       `{"description": "test", "list_id": "8d306d55-4301-4770-8a90-e64f771dc3f9"}`,
+			response:   `{"message":"List not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember, taskService *mock_service.MockTask) {
+				listService.EXPECT().FindById(listId).Return(&domain.List{ID: listId}, nil)
+				listMemberService.EXPECT().GetRole(gomock.Any(), userId).Return("")
+			},
+		},
+		{
+			name:       "Viewer of shared list",
+			body:       `{"description": "test", "list_id": "8d306d55-4301-4770-8a90-e64f771dc3f9"}`,
+			response:   `{"message":"Insufficient permissions"}`,
+			statusCode: http.StatusForbidden,
+			mockFunction: func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember, taskService *mock_service.MockTask) {
+				listService.EXPECT().FindById(listId).Return(&domain.List{ID: listId}, nil)
+				listMemberService.EXPECT().GetRole(gomock.Any(), userId).Return(domain.ListRoleViewer)
+			},
+		},
+		{
+			name:       "Editor of shared list",
+			body:       `{"description": "test", "list_id": "8d306d55-4301-4770-8a90-e64f771dc3f9"}`,
+			response:   fixtureTaskResponse,
+			statusCode: http.StatusCreated,
+			mockFunction: func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember, taskService *mock_service.MockTask) {
+				listService.EXPECT().FindById(listId).Return(&domain.List{ID: listId}, nil)
+				listMemberService.EXPECT().GetRole(gomock.Any(), userId).Return(domain.ListRoleEditor)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().Create("test", userId, &listId).Return(fixtureTask(userId), nil)
+			},
+		},
+		{
+			name:       "Success",
+			body:       `{"description": "test", "list_id": "8d306d55-4301-4770-8a90-e64f771dc3f9"}`,
+			response:   fixtureTaskResponse,
+			statusCode: http.StatusCreated,
+			mockFunction: func(listService *mock_service.MockList, listMemberService *mock_service.MockListMember, taskService *mock_service.MockTask) {
+				listService.EXPECT().FindById(listId).Return(&domain.List{ID: listId, UserId: userId}, nil)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().Create("test", userId, &listId).Return(fixtureTask(userId), nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			listService := mock_service.NewMockList(c)
+			listMemberService := mock_service.NewMockListMember(c)
+			taskService := mock_service.NewMockTask(c)
+
+			userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+			tc.mockFunction(listService, listMemberService, taskService)
+			handler := Handler{services: &service.Services{
+				User: userService, List: listService, ListMember: listMemberService, Task: taskService,
+			}}
+
+			r := gin.New()
+			r.POST("/tasks", setContextEmail, handler.createTask)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/tasks", bytes.NewBufferString(tc.body))
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestGetTask(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
 		taskId       string
 		response     string
 		statusCode   int
 		mockFunction func(taskService *mock_service.MockTask)
+	}{
+		{
+			name:         "Failed to parse task id",
+			taskId:       faker.Word(),
+			response:     `{"message":"Task not found"}`,
+			statusCode:   http.StatusNotFound,
+			mockFunction: func(taskService *mock_service.MockTask) {},
+		},
+		{
+			name:       "Task of another user",
+			taskId:     fixtureTaskId.String(),
+			response:   `{"message":"Task not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(taskService *mock_service.MockTask) {
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(uuid.Nil), nil)
+			},
+		},
+		{
+			name:       "Success",
+			taskId:     fixtureTaskId.String(),
+			response:   fixtureTaskResponse,
+			statusCode: http.StatusOK,
+			mockFunction: func(taskService *mock_service.MockTask) {
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(userId), nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			taskService := mock_service.NewMockTask(c)
+
+			userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+			tc.mockFunction(taskService)
+			handler := Handler{services: &service.Services{User: userService, Task: taskService}}
+
+			r := gin.New()
+			r.GET("/tasks/:id", setContextEmail, handler.getTask)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/tasks/"+tc.taskId, nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestUpdateTaskDescriptionInSharedList(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+	listId, _ := uuid.Parse("8d306d55-4301-4770-8a90-e64f771dc3f9")
+
+	testCases := []struct {
+		name         string
+		role         string
+		response     string
+		statusCode   int
+		mockFunction func(taskService *mock_service.MockTask)
+	}{
+		{
+			name:         "Not a member",
+			role:         "",
+			response:     `{"message":"Task not found"}`,
+			statusCode:   http.StatusNotFound,
+			mockFunction: func(taskService *mock_service.MockTask) {},
+		},
+		{
+			name:         "Viewer",
+			role:         domain.ListRoleViewer,
+			response:     `{"message":"Insufficient permissions"}`,
+			statusCode:   http.StatusForbidden,
+			mockFunction: func(taskService *mock_service.MockTask) {},
+		},
+		{
+			name:       "Editor",
+			role:       domain.ListRoleEditor,
+			statusCode: http.StatusOK,
+			mockFunction: func(taskService *mock_service.MockTask) {
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().UpdateDescription(fixtureTaskId, "test").Return(&domain.Task{}, nil)
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(uuid.Nil), nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			listMemberService := mock_service.NewMockListMember(c)
+			taskService := mock_service.NewMockTask(c)
+
+			task := fixtureTask(uuid.Nil)
+			task.ListId = &listId
+
+			userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+			taskService.EXPECT().FindById(fixtureTaskId).Return(task, nil)
+			listMemberService.EXPECT().GetTaskRole(task, userId).Return(tc.role)
+			tc.mockFunction(taskService)
+			handler := Handler{services: &service.Services{User: userService, ListMember: listMemberService, Task: taskService}}
+
+			r := gin.New()
+			r.PATCH("/tasks/:id", setContextEmail, handler.updateTaskDescription)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PATCH", "/tasks/"+fixtureTaskId.String(), bytes.NewBufferString(`{"description": "test"}`))
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+
+			if tc.response != "" {
+				require.Equal(t, tc.response, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestUpdateTaskDescription(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		body         string
+		taskId       string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, taskService *mock_service.MockTask)
 	}{
 		{
 			name:         "Empty",
@@ -130,7 +356,7 @@ func TestUpdateTaskDescription(t *testing.T) {
 			taskId:       faker.UUIDHyphenated(),
 			response:     `{"message":"EOF"}`,
 			statusCode:   http.StatusUnprocessableEntity,
-			mockFunction: func(taskService *mock_service.MockTask) {},
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {},
 		},
 		{
 			name:         "Missing description",
@@ -138,35 +364,51 @@ func TestUpdateTaskDescription(t *testing.T) {
 			taskId:       faker.UUIDHyphenated(),
 			response:     `{"message":"Key: 'UpdateTaskRequest.Description' Error:Field validation for 'Description' failed on the 'required' tag"}`,
 			statusCode:   http.StatusUnprocessableEntity,
-			mockFunction: func(taskService *mock_service.MockTask) {},
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {},
 		},
 		{
-			name:         "Failed to parse task id",
-			body:         `{"description": "test"}`,
-			taskId:       faker.Word(),
-			response:     `{"message":"Task not found"}`,
-			statusCode:   http.StatusNotFound,
-			mockFunction: func(taskService *mock_service.MockTask) {},
+			name:       "Failed to parse task id",
+			body:       `{"description": "test"}`,
+			taskId:     faker.Word(),
+			response:   `{"message":"Task not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+			},
 		},
 		{
 			name:       "Task not existed",
 			body:       `{"description": "test"}`,
-			taskId:     faker.UUIDHyphenated(),
+			taskId:     fixtureTaskId.String(),
 			response:   `{"message":"Task not found"}`,
 			statusCode: http.StatusNotFound,
-			mockFunction: func(taskService *mock_service.MockTask) {
-				taskService.EXPECT().IsExistsById(gomock.Any()).Return(false)
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(fixtureTaskId).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Task of another user",
+			body:       `{"description": "test"}`,
+			taskId:     fixtureTaskId.String(),
+			response:   `{"message":"Task not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(uuid.Nil), nil)
 			},
 		},
 		{
 			name:       "Success",
 			body:       `{"description": "test"}`,
-			taskId:     faker.UUIDHyphenated(),
-			response:   ``,
-			statusCode: http.StatusNoContent,
-			mockFunction: func(taskService *mock_service.MockTask) {
-				taskService.EXPECT().IsExistsById(gomock.Any()).Return(true)
-				taskService.EXPECT().UpdateDescription(gomock.Any(), gomock.Any()).Return(&domain.Task{}, nil)
+			taskId:     fixtureTaskId.String(),
+			response:   fixtureTaskResponse,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(userId), nil).Times(2)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().UpdateDescription(fixtureTaskId, "test").Return(&domain.Task{}, nil)
 			},
 		},
 	}
@@ -176,13 +418,14 @@ func TestUpdateTaskDescription(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
 			taskService := mock_service.NewMockTask(c)
 
-			tc.mockFunction(taskService)
-			handler := Handler{services: &service.Services{Task: taskService}}
+			tc.mockFunction(userService, taskService)
+			handler := Handler{services: &service.Services{User: userService, Task: taskService}}
 
 			r := gin.New()
-			r.PATCH("/tasks/:id", handler.updateTaskDescription)
+			r.PATCH("/tasks/:id", setContextEmail, handler.updateTaskDescription)
 
 			w := httptest.NewRecorder()
 			req := httptest.NewRequest("PATCH", "/tasks/"+tc.taskId, bytes.NewBufferString(tc.body))
@@ -195,9 +438,10 @@ func TestUpdateTaskDescription(t *testing.T) {
 }
 
 func TestUpdateTaskIsComplete(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
 	testCases := []struct {
 		name         string
-		taskId       string
 		response     string
 		statusCode   int
 		isComplete   bool
@@ -207,7 +451,6 @@ func TestUpdateTaskIsComplete(t *testing.T) {
 	}{
 		{
 			name:         "Failed to parse task id (incomplete)",
-			taskId:       faker.Word(),
 			response:     `{"message":"Task not found"}`,
 			statusCode:   http.StatusNotFound,
 			isComplete:   false,
@@ -217,62 +460,63 @@ func TestUpdateTaskIsComplete(t *testing.T) {
 		},
 		{
 			name:        "Task not existed (incomplete)",
-			taskId:      faker.UUIDHyphenated(),
 			response:    `{"message":"Task not found"}`,
 			statusCode:  http.StatusNotFound,
 			isComplete:  false,
 			routerPath:  "/tasks/:id/incomplete",
-			requestPath: fmt.Sprintf("/tasks/%s/incomplete", faker.UUIDHyphenated()),
+			requestPath: fmt.Sprintf("/tasks/%s/incomplete", fixtureTaskId),
 			mockFunction: func(taskService *mock_service.MockTask) {
-				taskService.EXPECT().IsExistsById(gomock.Any()).Return(false)
+				taskService.EXPECT().FindById(fixtureTaskId).Return(nil, errors.New("failed"))
 			},
 		},
 		{
 			name:        "Success (incomplete)",
-			taskId:      faker.UUIDHyphenated(),
-			response:    ``,
-			statusCode:  http.StatusNoContent,
+			response:    fixtureTaskResponse,
+			statusCode:  http.StatusOK,
 			isComplete:  false,
 			routerPath:  "/tasks/:id/incomplete",
-			requestPath: fmt.Sprintf("/tasks/%s/incomplete", faker.UUIDHyphenated()),
+			requestPath: fmt.Sprintf("/tasks/%s/incomplete", fixtureTaskId),
 			mockFunction: func(taskService *mock_service.MockTask) {
-				taskService.EXPECT().IsExistsById(gomock.Any()).Return(true)
-				taskService.EXPECT().UpdateIsCompleted(gomock.Any(), gomock.Any()).Return(&domain.Task{}, nil)
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(userId), nil).Times(2)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().UpdateIsCompleted(fixtureTaskId, false).Return(&domain.Task{}, nil)
 			},
 		},
 		{
-			name:         "Failed to parse task id (complete)",
-			taskId:       faker.Word(),
-			response:     `{"message":"Task not found"}`,
-			statusCode:   http.StatusNotFound,
-			isComplete:   false,
-			routerPath:   "/tasks/:id/complete",
-			requestPath:  fmt.Sprintf("/tasks/%s/complete", faker.Word()),
-			mockFunction: func(taskService *mock_service.MockTask) {},
+			name:        "Task is blocked (complete)",
+			response:    `{"message":"Task is blocked by uncompleted tasks"}`,
+			statusCode:  http.StatusConflict,
+			isComplete:  true,
+			routerPath:  "/tasks/:id/complete",
+			requestPath: fmt.Sprintf("/tasks/%s/complete", fixtureTaskId),
+			mockFunction: func(taskService *mock_service.MockTask) {
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(userId), nil)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().UpdateIsCompleted(fixtureTaskId, true).Return(nil, errors.New(service.ErrTaskIsBlocked))
+			},
 		},
 		{
-			name:        "Task not existed (complete)",
-			taskId:      faker.UUIDHyphenated(),
+			name:        "Task of another user (complete)",
 			response:    `{"message":"Task not found"}`,
 			statusCode:  http.StatusNotFound,
-			isComplete:  false,
+			isComplete:  true,
 			routerPath:  "/tasks/:id/complete",
-			requestPath: fmt.Sprintf("/tasks/%s/complete", faker.UUIDHyphenated()),
+			requestPath: fmt.Sprintf("/tasks/%s/complete", fixtureTaskId),
 			mockFunction: func(taskService *mock_service.MockTask) {
-				taskService.EXPECT().IsExistsById(gomock.Any()).Return(false)
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(uuid.Nil), nil)
 			},
 		},
 		{
 			name:        "Success (complete)",
-			taskId:      faker.UUIDHyphenated(),
-			response:    ``,
-			statusCode:  http.StatusNoContent,
-			isComplete:  false,
+			response:    fixtureTaskResponse,
+			statusCode:  http.StatusOK,
+			isComplete:  true,
 			routerPath:  "/tasks/:id/complete",
-			requestPath: fmt.Sprintf("/tasks/%s/complete", faker.UUIDHyphenated()),
+			requestPath: fmt.Sprintf("/tasks/%s/complete", fixtureTaskId),
 			mockFunction: func(taskService *mock_service.MockTask) {
-				taskService.EXPECT().IsExistsById(gomock.Any()).Return(true)
-				taskService.EXPECT().UpdateIsCompleted(gomock.Any(), gomock.Any()).Return(&domain.Task{}, nil)
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(userId), nil).Times(2)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().UpdateIsCompleted(fixtureTaskId, true).Return(&domain.Task{}, nil)
 			},
 		},
 	}
@@ -282,13 +526,15 @@ func TestUpdateTaskIsComplete(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
 			taskService := mock_service.NewMockTask(c)
 
+			userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
 			tc.mockFunction(taskService)
-			handler := Handler{services: &service.Services{Task: taskService}}
+			handler := Handler{services: &service.Services{User: userService, Task: taskService}}
 
 			r := gin.New()
-			r.PATCH(tc.routerPath, handler.updateTaskIsComplete(tc.isComplete))
+			r.PATCH(tc.routerPath, setContextEmail, handler.updateTaskIsComplete(tc.isComplete))
 
 			w := httptest.NewRecorder()
 			req := httptest.NewRequest("PATCH", tc.requestPath, nil)
@@ -300,10 +546,158 @@ func TestUpdateTaskIsComplete(t *testing.T) {
 	}
 }
 
+func TestUpdateTaskStatus(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		body         string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, taskService *mock_service.MockTask)
+	}{
+		{
+			name:         "Missing status",
+			body:         `{}`,
+			response:     `{"message":"Key: 'UpdateTaskStatusRequest.StatusId' Error:Field validation for 'StatusId' failed on the 'required' tag"}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {},
+		},
+		{
+			name:       "Task of another user",
+			body:       `{"status_id":"` + faker.UUIDHyphenated() + `"}`,
+			response:   `{"message":"Task not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{}, nil)
+			},
+		},
+		{
+			name:       "Status not existed",
+			body:       `{"status_id":"` + faker.UUIDHyphenated() + `"}`,
+			response:   `{"message":"Status not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).Return(nil, errors.New(service.ErrStatusNotFound))
+			},
+		},
+		{
+			name:       "Success",
+			body:       `{"status_id":"` + faker.UUIDHyphenated() + `"}`,
+			response:   fixtureTaskResponse,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(fixtureTask(userId), nil).Times(2)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().UpdateStatus(fixtureTaskId, gomock.Any()).Return(&domain.Task{}, nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			taskService := mock_service.NewMockTask(c)
+
+			tc.mockFunction(userService, taskService)
+			handler := Handler{services: &service.Services{User: userService, Task: taskService}}
+
+			r := gin.New()
+			r.PATCH("/tasks/:id/status", setContextEmail, handler.updateTaskStatus)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PATCH", "/tasks/"+faker.UUIDHyphenated()+"/status", bytes.NewBufferString(tc.body))
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestUpdateTaskDue(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+	dueAt := time.Date(2026, 10, 25, 18, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		body         string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, taskService *mock_service.MockTask)
+	}{
+		{
+			name:         "Invalid date",
+			body:         `{"due_at":"tomorrow"}`,
+			response:     `{"message":"Parsing time \"tomorrow\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"tomorrow\" as \"2006\""}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {},
+		},
+		{
+			name:       "Failed to update",
+			body:       `{"due_at":"2026-10-25T18:00:00Z"}`,
+			response:   `{"message":"Failed to update task"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(fixtureTask(userId), nil)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().UpdateDueAt(fixtureTaskId, &dueAt).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Clear due date",
+			body:       `{"due_at":null}`,
+			response:   fixtureTaskResponse,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(fixtureTask(userId), nil).Times(2)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().UpdateDueAt(fixtureTaskId, (*time.Time)(nil)).Return(&domain.Task{}, nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			taskService := mock_service.NewMockTask(c)
+
+			tc.mockFunction(userService, taskService)
+			handler := Handler{services: &service.Services{User: userService, Task: taskService}}
+
+			r := gin.New()
+			r.PATCH("/tasks/:id/due", setContextEmail, handler.updateTaskDue)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PATCH", "/tasks/"+faker.UUIDHyphenated()+"/due", bytes.NewBufferString(tc.body))
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
 func TestDeleteTask(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
 	testCases := []struct {
 		name         string
 		taskId       string
+		ifMatch      string
 		response     string
 		statusCode   int
 		mockFunction func(taskService *mock_service.MockTask)
@@ -317,31 +711,67 @@ func TestDeleteTask(t *testing.T) {
 		},
 		{
 			name:       "Task not existed",
-			taskId:     faker.UUIDHyphenated(),
+			taskId:     fixtureTaskId.String(),
+			response:   `{"message":"Task not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(taskService *mock_service.MockTask) {
+				taskService.EXPECT().FindById(fixtureTaskId).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Task of another user",
+			taskId:     fixtureTaskId.String(),
 			response:   `{"message":"Task not found"}`,
 			statusCode: http.StatusNotFound,
 			mockFunction: func(taskService *mock_service.MockTask) {
-				taskService.EXPECT().IsExistsById(gomock.Any()).Return(false)
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(uuid.Nil), nil)
+			},
+		},
+		{
+			name:       "Version mismatch",
+			taskId:     fixtureTaskId.String(),
+			ifMatch:    `"2"`,
+			response:   `{"message":"Task has been modified, reload it and try again"}`,
+			statusCode: http.StatusPreconditionFailed,
+			mockFunction: func(taskService *mock_service.MockTask) {
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(userId), nil)
 			},
 		},
 		{
 			name:       "Failed to delete task",
-			taskId:     faker.UUIDHyphenated(),
+			taskId:     fixtureTaskId.String(),
 			response:   `{"message":"Failed to delete task"}`,
 			statusCode: http.StatusBadRequest,
 			mockFunction: func(taskService *mock_service.MockTask) {
-				taskService.EXPECT().IsExistsById(gomock.Any()).Return(true)
-				taskService.EXPECT().Delete(gomock.Any()).Return(errors.New("failed"))
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(userId), nil)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().Delete(fixtureTaskId).Return(errors.New("failed"))
+			},
+		},
+		{
+			name:       "Modified after precondition check",
+			taskId:     fixtureTaskId.String(),
+			ifMatch:    `"1"`,
+			response:   `{"message":"Task has been modified, reload it and try again"}`,
+			statusCode: http.StatusPreconditionFailed,
+			mockFunction: func(taskService *mock_service.MockTask) {
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(userId), nil)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().WithVersion(1).Return(taskService)
+				taskService.EXPECT().Delete(fixtureTaskId).Return(errors.New(service.ErrTaskVersionConflict))
 			},
 		},
 		{
 			name:       "Success",
-			taskId:     faker.UUIDHyphenated(),
+			taskId:     fixtureTaskId.String(),
+			ifMatch:    `"1"`,
 			response:   ``,
 			statusCode: http.StatusNoContent,
 			mockFunction: func(taskService *mock_service.MockTask) {
-				taskService.EXPECT().IsExistsById(gomock.Any()).Return(true)
-				taskService.EXPECT().Delete(gomock.Any()).Return(nil)
+				taskService.EXPECT().FindById(fixtureTaskId).Return(fixtureTask(userId), nil)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().WithVersion(1).Return(taskService)
+				taskService.EXPECT().Delete(fixtureTaskId).Return(nil)
 			},
 		},
 	}
@@ -351,16 +781,19 @@ func TestDeleteTask(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
 			taskService := mock_service.NewMockTask(c)
 
+			userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
 			tc.mockFunction(taskService)
-			handler := Handler{services: &service.Services{Task: taskService}}
+			handler := Handler{services: &service.Services{User: userService, Task: taskService}}
 
 			r := gin.New()
-			r.DELETE("/tasks/:id", handler.deleteTask)
+			r.DELETE("/tasks/:id", setContextEmail, handler.deleteTask)
 
 			w := httptest.NewRecorder()
 			req := httptest.NewRequest("DELETE", "/tasks/"+tc.taskId, nil)
+			req.Header.Set("If-Match", tc.ifMatch)
 			r.ServeHTTP(w, req)
 
 			require.Equal(t, tc.statusCode, w.Code)
@@ -372,6 +805,7 @@ func TestDeleteTask(t *testing.T) {
 func TestGetAllTasksByUserId(t *testing.T) {
 	testCases := []struct {
 		name            string
+		query           string
 		response        string
 		statusCode      int
 		contextModifier func(c *gin.Context)
@@ -395,21 +829,75 @@ func TestGetAllTasksByUserId(t *testing.T) {
 				userService.EXPECT().FindByEmail(gomock.Any()).Return(nil, errors.New("failed"))
 			},
 		},
+		{
+			name:            "Unknown sort",
+			query:           "?sort=is_completed",
+			response:        `{"message":"Key: 'GetTasksRequest.Sort' Error:Field validation for 'Sort' failed on the 'oneof' tag"}`,
+			statusCode:      http.StatusUnprocessableEntity,
+			contextModifier: func(c *gin.Context) {},
+			mockFunction:    func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {},
+		},
+		{
+			name:            "Limit too large",
+			query:           "?limit=1000",
+			response:        `{"message":"Key: 'GetTasksRequest.Limit' Error:Field validation for 'Limit' failed on the 'max' tag"}`,
+			statusCode:      http.StatusUnprocessableEntity,
+			contextModifier: func(c *gin.Context) {},
+			mockFunction:    func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {},
+		},
+		{
+			name:       "Invalid cursor",
+			query:      "?cursor=invalid",
+			response:   `{"message":"Invalid cursor"}`,
+			statusCode: http.StatusUnprocessableEntity,
+			contextModifier: func(c *gin.Context) {
+				c.Set(ContextEmailKey, faker.Email())
+			},
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{}, nil)
+				taskService.EXPECT().GetPageByUserId(gomock.Any(), "", "invalid", 0).
+					Return(nil, errors.New(service.ErrInvalidTaskCursor))
+			},
+		},
+		{
+			name:       "Filters",
+			query:      "?completed=false&created_from=2026-10-01&created_to=2026-10-07&q=milk&sort=description&limit=10",
+			response:   `{"items":[],"next_cursor":null,"total":0,"total_completed":0}`,
+			statusCode: http.StatusOK,
+			contextModifier: func(c *gin.Context) {
+				c.Set(ContextEmailKey, faker.Email())
+			},
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+				isCompleted := false
+				createdFrom := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+				createdTo := time.Date(2026, 10, 8, 0, 0, 0, 0, time.UTC)
+
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().GetPageByUserId(domain.TaskFilter{
+					UserId:      userId,
+					Completed:   &isCompleted,
+					CreatedFrom: &createdFrom,
+					CreatedTo:   &createdTo,
+					Query:       "milk",
+				}, "description", "", 10).Return(&domain.TaskPage{}, nil)
+			},
+		},
 		{
 			name:       "Tasks no exists",
-			response:   `[]`,
+			response:   `{"items":[],"next_cursor":null,"total":0,"total_completed":0}`,
 			statusCode: http.StatusOK,
 			contextModifier: func(c *gin.Context) {
 				c.Set(ContextEmailKey, faker.Email())
 			},
 			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
 				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{}, nil)
-				taskService.EXPECT().GetAllByUserId(gomock.Any()).Return(&[]domain.Task{})
+				taskService.EXPECT().GetPageByUserId(gomock.Any(), "", "", 0).Return(&domain.TaskPage{}, nil)
 			},
 		},
 		{
 			name:       "Success",
-			response:   `[{"id":"8d306d55-4301-4770-8a90-e64f771dc3f9","description":"Description","is_completed":true,"created_at":"2006-01-02T15:04:05Z"}]`,
+			response:   `{"items":[{"id":"8d306d55-4301-4770-8a90-e64f771dc3f9","list_id":null,"assignee_id":null,"status_id":null,"description":"Description","is_completed":true,"is_blocked":false,"created_at":"2006-01-02T15:04:05Z"}],"next_cursor":"next","total":2,"total_completed":1}`,
 			statusCode: http.StatusOK,
 			contextModifier: func(c *gin.Context) {
 				c.Set(ContextEmailKey, faker.Email())
@@ -420,14 +908,18 @@ func TestGetAllTasksByUserId(t *testing.T) {
 				createdAt, _ := time.Parse("2006-01-02 15:04:05", "2006-01-02 15:04:05")
 
 				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{}, nil)
-				taskService.EXPECT().GetAllByUserId(gomock.Any()).Return(&[]domain.Task{
-					{
-						ID:          taskId,
-						Description: "Description",
-						IsCompleted: &isCompleted,
-						CreatedAt:   createdAt,
+				taskService.EXPECT().GetPageByUserId(gomock.Any(), "", "", 0).Return(&domain.TaskPage{
+					Tasks: []domain.Task{
+						{
+							ID:          taskId,
+							Description: "Description",
+							IsCompleted: &isCompleted,
+							CreatedAt:   createdAt,
+						},
 					},
-				})
+					NextCursor: "next",
+					Counts:     domain.TaskCounts{Total: 2, Completed: 1},
+				}, nil)
 			},
 		},
 	}
@@ -447,7 +939,7 @@ func TestGetAllTasksByUserId(t *testing.T) {
 			r.GET("/tasks", tc.contextModifier, handler.getAllTasksByUserId)
 
 			w := httptest.NewRecorder()
-			req := httptest.NewRequest("GET", "/tasks", nil)
+			req := httptest.NewRequest("GET", "/tasks"+tc.query, nil)
 			r.ServeHTTP(w, req)
 
 			require.Equal(t, tc.statusCode, w.Code)
@@ -455,3 +947,21 @@ func TestGetAllTasksByUserId(t *testing.T) {
 		})
 	}
 }
+
+var fixtureTaskId = uuid.MustParse("2b7e3c1a-5d4f-4e6a-9b8c-0d1e2f3a4b5c")
+
+const fixtureTaskResponse = `{"id":"2b7e3c1a-5d4f-4e6a-9b8c-0d1e2f3a4b5c","list_id":null,"assignee_id":null,"status_id":null,"description":"test","is_completed":false,"is_blocked":false,"version":1,"estimate_minutes":null,"due_at":null,"completed_at":null,"archived_at":null,"created_at":"2026-10-01T10:00:00Z","updated_at":"2026-10-02T10:00:00Z"}`
+
+func fixtureTask(userId uuid.UUID) *domain.Task {
+	isCompleted := false
+
+	return &domain.Task{
+		ID:          fixtureTaskId,
+		UserId:      userId,
+		Description: "test",
+		IsCompleted: &isCompleted,
+		Version:     1,
+		CreatedAt:   time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2026, 10, 2, 10, 0, 0, 0, time.UTC),
+	}
+}
@@ -0,0 +1,431 @@
+package v1
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"math"
+	"net/http"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/response"
+	"strconv"
+	"time"
+)
+
+const (
+	ErrFailedToStartTimer      = "failed to start timer"
+	ErrFailedToStopTimer       = "failed to stop timer"
+	ErrFailedToCreateTimeEntry = "failed to create time entry"
+	ErrFailedToDeleteTimeEntry = "failed to delete time entry"
+	ErrTimeEntryNotFound       = "time entry not found"
+	ErrFailedToGetTimeReport   = "failed to get time report"
+)
+
+type CreateTimeEntryRequest struct {
+	StartedAt time.Time `json:"started_at" binding:"required"`
+	StoppedAt time.Time `json:"stopped_at" binding:"required"`
+	Note      string    `json:"note"`
+}
+
+type UpdateTaskEstimateRequest struct {
+	Minutes *int `json:"minutes" binding:"required,min=0"`
+}
+
+type TimeReportRequest struct {
+	From    time.Time `form:"from" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	To      time.Time `form:"to" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	GroupBy string    `form:"group_by" binding:"required,oneof=list tag day"`
+	Format  string    `form:"format" binding:"omitempty,oneof=json csv"`
+}
+
+type TimeEntryResponse struct {
+	Id              string     `json:"id"`
+	StartedAt       time.Time  `json:"started_at"`
+	StoppedAt       *time.Time `json:"stopped_at"`
+	DurationSeconds int64      `json:"duration_seconds"`
+	Note            string     `json:"note"`
+}
+
+type TaskTimeResponse struct {
+	EstimateMinutes *int                `json:"estimate_minutes"`
+	TrackedSeconds  int64               `json:"tracked_seconds"`
+	Entries         []TimeEntryResponse `json:"entries"`
+}
+
+type TimeReportRowResponse struct {
+	Key     string  `json:"key"`
+	Label   string  `json:"label"`
+	Seconds int64   `json:"seconds"`
+	Hours   float64 `json:"hours"`
+}
+
+func (h *Handler) initTimeEntriesRoutes(api *gin.RouterGroup) {
+	task := api.Group("/tasks/:id", h.auth)
+	{
+		task.GET("/time", h.getTaskTime)
+		task.POST("/time", h.createTimeEntry)
+		task.DELETE("/time/:entryId", h.deleteTimeEntry)
+		task.POST("/timer", h.startTimer)
+		task.DELETE("/timer", h.stopTimer)
+		task.PATCH("/estimate", h.updateTaskEstimate)
+	}
+
+	api.GET("/reports/time", h.auth, h.getTimeReport)
+}
+
+// @Description	Получение учтенного времени задачи и ее оценки
+// @Tags			time-tracking
+// @Param			id	path		string	true	"ID задачи"
+// @Success		200	{object}	TaskTimeResponse
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/{id}/time [get]
+func (h *Handler) getTaskTime(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	task, err := h.getUserTask(c, "id", existedUser.ID, domain.ListRoleViewer)
+
+	if err != nil {
+		respondAccessError(c, err, ErrTaskNotFound)
+		return
+	}
+
+	entries := h.scoped(c).TimeEntry.GetAllByTaskId(task.ID)
+	now := time.Now().UTC()
+
+	timeResponse := TaskTimeResponse{
+		EstimateMinutes: task.EstimateMinutes,
+		TrackedSeconds:  int64(h.scoped(c).TimeEntry.GetTrackedTime(*entries).Seconds()),
+		Entries:         make([]TimeEntryResponse, 0, len(*entries)),
+	}
+
+	for _, entry := range *entries {
+		timeResponse.Entries = append(timeResponse.Entries, newTimeEntryResponse(entry, now))
+	}
+
+	c.JSON(http.StatusOK, timeResponse)
+}
+
+// @Description	Добавление интервала работы над задачей вручную
+// @Tags			time-tracking
+// @Param			id		path		string					true	"ID задачи"
+// @Param			data	body		CreateTimeEntryRequest	true	"Интервал работы"
+// @Success		201		{object}	TimeEntryResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		403		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		422		{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/{id}/time [post]
+func (h *Handler) createTimeEntry(c *gin.Context) {
+	var body CreateTimeEntryRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	task, err := h.getUserTask(c, "id", existedUser.ID, domain.ListRoleEditor)
+
+	if err != nil {
+		respondAccessError(c, err, ErrTaskNotFound)
+		return
+	}
+
+	entry, err := h.scoped(c).TimeEntry.CreateManual(task.ID, existedUser.ID, body.StartedAt, body.StoppedAt, body.Note)
+
+	if err != nil {
+		if err.Error() == service.ErrTimeEntryInvalidPeriod {
+			response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToCreateTimeEntry)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newTimeEntryResponse(*entry, time.Now().UTC()))
+}
+
+// @Description	Удаление интервала работы над задачей
+// @Tags			time-tracking
+// @Param			id		path	string	true	"ID задачи"
+// @Param			entryId	path	string	true	"ID интервала"
+// @Success		204
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		403	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/{id}/time/{entryId} [delete]
+func (h *Handler) deleteTimeEntry(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	task, err := h.getUserTask(c, "id", existedUser.ID, domain.ListRoleEditor)
+
+	if err != nil {
+		respondAccessError(c, err, ErrTaskNotFound)
+		return
+	}
+
+	entryId, err := uuid.Parse(c.Param("entryId"))
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusNotFound, ErrTimeEntryNotFound)
+		return
+	}
+
+	entry, err := h.scoped(c).TimeEntry.FindById(entryId)
+
+	if err != nil || entry.TaskId != task.ID {
+		response.NewErrorResponse(c, http.StatusNotFound, ErrTimeEntryNotFound)
+		return
+	}
+
+	if err = h.scoped(c).TimeEntry.Delete(entry.ID); err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToDeleteTimeEntry)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Description	Запуск таймера по задаче. У пользователя может быть запущен только один таймер.
+// @Tags			time-tracking
+// @Param			id	path		string	true	"ID задачи"
+// @Success		201	{object}	TimeEntryResponse
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		403	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Failure		409	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/{id}/timer [post]
+func (h *Handler) startTimer(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	task, err := h.getUserTask(c, "id", existedUser.ID, domain.ListRoleEditor)
+
+	if err != nil {
+		respondAccessError(c, err, ErrTaskNotFound)
+		return
+	}
+
+	entry, err := h.scoped(c).TimeEntry.Start(task.ID, existedUser.ID)
+
+	if err != nil {
+		if err.Error() == service.ErrTimerIsRunning {
+			response.NewErrorResponse(c, http.StatusConflict, err.Error())
+			return
+		}
+
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToStartTimer)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newTimeEntryResponse(*entry, time.Now().UTC()))
+}
+
+// @Description	Остановка таймера по задаче
+// @Tags			time-tracking
+// @Param			id	path		string	true	"ID задачи"
+// @Success		200	{object}	TimeEntryResponse
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		403	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Failure		409	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/{id}/timer [delete]
+func (h *Handler) stopTimer(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	task, err := h.getUserTask(c, "id", existedUser.ID, domain.ListRoleEditor)
+
+	if err != nil {
+		respondAccessError(c, err, ErrTaskNotFound)
+		return
+	}
+
+	entry, err := h.scoped(c).TimeEntry.Stop(task.ID, existedUser.ID)
+
+	if err != nil {
+		if err.Error() == service.ErrTimerIsNotRunning {
+			response.NewErrorResponse(c, http.StatusConflict, err.Error())
+			return
+		}
+
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToStopTimer)
+		return
+	}
+
+	c.JSON(http.StatusOK, newTimeEntryResponse(*entry, time.Now().UTC()))
+}
+
+// @Description	Обновление оценки трудоемкости задачи
+// @Tags			time-tracking
+// @Param			id			path		string						true	"ID задачи"
+// @Param			data		body		UpdateTaskEstimateRequest	true	"Оценка в минутах"
+// @Param			If-Match	header		string						false	"ETag задачи, полученный при чтении"
+// @Success		200			{object}	TaskResponse
+// @Failure		400			{object}	response.ErrorResponse
+// @Failure		403			{object}	response.ErrorResponse
+// @Failure		404			{object}	response.ErrorResponse
+// @Failure		422			{object}	response.ErrorResponse
+// @Failure		412			{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/{id}/estimate [patch]
+func (h *Handler) updateTaskEstimate(c *gin.Context) {
+	var body UpdateTaskEstimateRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	task, err := h.getUserTask(c, "id", existedUser.ID, domain.ListRoleEditor)
+
+	if err != nil {
+		respondAccessError(c, err, ErrTaskNotFound)
+		return
+	}
+
+	if !checkTaskPrecondition(c, *task) {
+		return
+	}
+
+	if _, err = withTaskPrecondition(c, h.scoped(c).Task.WithActor(existedUser.ID), *task).UpdateEstimate(task.ID, *body.Minutes); err != nil {
+		switch err.Error() {
+		case service.ErrTaskVersionConflict:
+			response.NewErrorResponse(c, http.StatusPreconditionFailed, ErrTaskVersionMismatch)
+		default:
+			response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToUpdateTask)
+		}
+		return
+	}
+
+	h.writeTask(c, task.ID)
+}
+
+// @Description	Отчет по учтенному времени за период с группировкой по спискам, тегам или дням
+// @Tags			time-tracking
+// @Produce		json
+// @Produce		text/csv
+// @Param			from		query		string	true	"Начало периода (YYYY-MM-DD)"
+// @Param			to			query		string	true	"Конец периода включительно (YYYY-MM-DD)"
+// @Param			group_by	query		string	true	"Группировка"	Enums(list, tag, day)
+// @Param			format		query		string	false	"Формат ответа"	Enums(json, csv)
+// @Success		200			{array}		TimeReportRowResponse
+// @Failure		400			{object}	response.ErrorResponse
+// @Failure		422			{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/reports/time [get]
+func (h *Handler) getTimeReport(c *gin.Context) {
+	var query TimeReportRequest
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	rows, err := h.scoped(c).TimeEntry.GetReport(existedUser.ID, query.From, query.To, query.GroupBy)
+
+	if err != nil {
+		switch err.Error() {
+		case service.ErrTimeEntryInvalidPeriod, service.ErrTimeReportInvalidGroup:
+			response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		default:
+			response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetTimeReport)
+		}
+		return
+	}
+
+	reportResponse := make([]TimeReportRowResponse, 0, len(*rows))
+
+	for _, row := range *rows {
+		reportResponse = append(reportResponse, TimeReportRowResponse{
+			Key:     row.Key,
+			Label:   row.Label,
+			Seconds: row.Seconds,
+			Hours:   math.Round(float64(row.Seconds)/36) / 100,
+		})
+	}
+
+	if query.Format == "csv" {
+		writeTimeReportCSV(c, reportResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, reportResponse)
+}
+
+func writeTimeReportCSV(c *gin.Context, rows []TimeReportRowResponse) {
+	c.Header("Content-Disposition", `attachment; filename="time-report.csv"`)
+	c.Status(http.StatusOK)
+	c.Writer.Header().Set("Content-Type", "text/csv; charset=utf-8")
+
+	writer := csv.NewWriter(c.Writer)
+	_ = writer.Write([]string{"key", "label", "hours", "seconds"})
+
+	for _, row := range rows {
+		_ = writer.Write([]string{
+			row.Key,
+			row.Label,
+			fmt.Sprintf("%.2f", row.Hours),
+			strconv.FormatInt(row.Seconds, 10),
+		})
+	}
+
+	writer.Flush()
+}
+
+func newTimeEntryResponse(entry domain.TimeEntry, now time.Time) TimeEntryResponse {
+	return TimeEntryResponse{
+		Id:              entry.ID.String(),
+		StartedAt:       entry.StartedAt,
+		StoppedAt:       entry.StoppedAt,
+		DurationSeconds: int64(entry.Duration(now).Seconds()),
+		Note:            entry.Note,
+	}
+}
@@ -0,0 +1,173 @@
+package v1
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"testing"
+	"time"
+)
+
+func TestStartTimer(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, taskService *mock_service.MockTask, timeEntryService *mock_service.MockTimeEntry)
+	}{
+		{
+			name:       "Task of another user",
+			response:   `{"message":"Task not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, timeEntryService *mock_service.MockTimeEntry) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{}, nil)
+			},
+		},
+		{
+			name:       "Another timer is running",
+			response:   `{"message":"Another timer is already running"}`,
+			statusCode: http.StatusConflict,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, timeEntryService *mock_service.MockTimeEntry) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil)
+				timeEntryService.EXPECT().Start(gomock.Any(), userId).Return(nil, errors.New(service.ErrTimerIsRunning))
+			},
+		},
+		{
+			name:       "Failed to start timer",
+			response:   `{"message":"Failed to start timer"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, timeEntryService *mock_service.MockTimeEntry) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil)
+				timeEntryService.EXPECT().Start(gomock.Any(), userId).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Success",
+			response:   `{"id":"8d306d55-4301-4770-8a90-e64f771dc3f9","started_at":"2026-10-19T10:00:00Z","stopped_at":"2026-10-19T11:30:00Z","duration_seconds":5400,"note":""}`,
+			statusCode: http.StatusCreated,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, timeEntryService *mock_service.MockTimeEntry) {
+				entryId, _ := uuid.Parse("8d306d55-4301-4770-8a90-e64f771dc3f9")
+				startedAt := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+				stoppedAt := startedAt.Add(90 * time.Minute)
+
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil)
+				timeEntryService.EXPECT().Start(gomock.Any(), userId).
+					Return(&domain.TimeEntry{ID: entryId, StartedAt: startedAt, StoppedAt: &stoppedAt}, nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			taskService := mock_service.NewMockTask(c)
+			timeEntryService := mock_service.NewMockTimeEntry(c)
+
+			tc.mockFunction(userService, taskService, timeEntryService)
+			handler := Handler{services: &service.Services{User: userService, Task: taskService, TimeEntry: timeEntryService}}
+
+			r := gin.New()
+			r.POST("/tasks/:id/timer", setContextEmail, handler.startTimer)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/tasks/"+faker.UUIDHyphenated()+"/timer", nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestGetTimeReport(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		query        string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, timeEntryService *mock_service.MockTimeEntry)
+	}{
+		{
+			name:         "Unknown grouping",
+			query:        "?from=2026-10-01&to=2026-10-07&group_by=week",
+			response:     `{"message":"Key: 'TimeReportRequest.GroupBy' Error:Field validation for 'GroupBy' failed on the 'oneof' tag"}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(userService *mock_service.MockUser, timeEntryService *mock_service.MockTimeEntry) {},
+		},
+		{
+			name:       "Invalid period",
+			query:      "?from=2026-10-07&to=2026-10-01&group_by=day",
+			response:   `{"message":"Time entry must end after it starts"}`,
+			statusCode: http.StatusUnprocessableEntity,
+			mockFunction: func(userService *mock_service.MockUser, timeEntryService *mock_service.MockTimeEntry) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				timeEntryService.EXPECT().GetReport(userId, gomock.Any(), gomock.Any(), "day").
+					Return(nil, errors.New(service.ErrTimeEntryInvalidPeriod))
+			},
+		},
+		{
+			name:       "Json",
+			query:      "?from=2026-10-01&to=2026-10-07&group_by=tag",
+			response:   `[{"key":"home","label":"home","seconds":5400,"hours":1.5}]`,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, timeEntryService *mock_service.MockTimeEntry) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				timeEntryService.EXPECT().GetReport(userId, gomock.Any(), gomock.Any(), "tag").
+					Return(&[]domain.TimeReportRow{{Key: "home", Label: "home", Seconds: 5400}}, nil)
+			},
+		},
+		{
+			name:       "Csv",
+			query:      "?from=2026-10-01&to=2026-10-07&group_by=day&format=csv",
+			response:   "key,label,hours,seconds\n2026-10-01,2026-10-01,0.25,900\n",
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, timeEntryService *mock_service.MockTimeEntry) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				timeEntryService.EXPECT().GetReport(userId, gomock.Any(), gomock.Any(), "day").
+					Return(&[]domain.TimeReportRow{{Key: "2026-10-01", Label: "2026-10-01", Seconds: 900}}, nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			timeEntryService := mock_service.NewMockTimeEntry(c)
+
+			tc.mockFunction(userService, timeEntryService)
+			handler := Handler{services: &service.Services{User: userService, TimeEntry: timeEntryService}}
+
+			r := gin.New()
+			r.GET("/reports/time", setContextEmail, handler.getTimeReport)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/reports/time"+tc.query, nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
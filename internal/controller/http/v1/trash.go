@@ -0,0 +1,139 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/response"
+	"time"
+)
+
+const ErrFailedToRestoreTask = "failed to restore task"
+
+type TrashedTaskResponse struct {
+	Id          string    `json:"id"`
+	ListId      *string   `json:"list_id"`
+	Description string    `json:"description"`
+	IsCompleted bool      `json:"is_completed"`
+	CreatedAt   time.Time `json:"created_at"`
+	DeletedAt   time.Time `json:"deleted_at"`
+}
+
+func (h *Handler) initTrashRoutes(api *gin.RouterGroup) {
+	tasks := api.Group("/tasks", h.auth)
+	{
+		tasks.GET("/trash", h.getTrash)
+		tasks.POST("/:id/restore", h.restoreTask)
+	}
+}
+
+// @Description	Получение задач пользователя, находящихся в корзине
+// @Tags			trash
+// @Param			If-None-Match	header	string	false	"ETag ранее полученного ответа"
+// @Success		200				{array}	TrashedTaskResponse
+// @Success		304
+// @Failure		400	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/trash [get]
+func (h *Handler) getTrash(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	tasks := h.scoped(c).Task.GetTrash(existedUser.ID)
+	trashResponse := make([]TrashedTaskResponse, 0, len(*tasks))
+
+	for _, task := range *tasks {
+		trashResponse = append(trashResponse, TrashedTaskResponse{
+			Id:          task.ID.String(),
+			ListId:      uuidToString(task.ListId),
+			Description: task.Description,
+			IsCompleted: *task.IsCompleted,
+			CreatedAt:   task.CreatedAt,
+			DeletedAt:   task.DeletedAt.Time,
+		})
+	}
+
+	writeJSONWithETag(c, trashResponse)
+}
+
+// @Description	Восстановление задачи из корзины
+// @Tags			trash
+// @Param			id	path	string	true	"ID задачи"
+// @Success		204
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		403	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/tasks/{id}/restore [post]
+func (h *Handler) restoreTask(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	task, err := h.getUserTaskWithTrashed(c, "id", existedUser.ID, domain.ListRoleEditor)
+
+	if err != nil {
+		respondAccessError(c, err, ErrTaskNotFound)
+		return
+	}
+
+	if err = h.scoped(c).Task.WithActor(existedUser.ID).Restore(task.ID); err != nil {
+		if err.Error() == service.ErrTaskIsNotTrashed {
+			response.NewErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToRestoreTask)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// purgeTask окончательно удаляет задачу пользователя, в том числе находящуюся в корзине. Чужую задачу списка
+// может удалить окончательно только администратор списка.
+func (h *Handler) purgeTask(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	task, err := h.getUserTaskWithTrashed(c, "id", existedUser.ID, domain.ListRoleEditor)
+
+	if err == nil && task.UserId != existedUser.ID {
+		err = h.checkTaskRole(c, task, existedUser.ID, domain.ListRoleAdmin)
+	}
+
+	if err != nil {
+		respondAccessError(c, err, ErrTaskNotFound)
+		return
+	}
+
+	if !checkTaskPrecondition(c, *task) {
+		return
+	}
+
+	if err = withTaskPrecondition(c, h.scoped(c).Task.WithActor(existedUser.ID), *task).Purge(task.ID); err != nil {
+		switch err.Error() {
+		case service.ErrTaskVersionConflict:
+			response.NewErrorResponse(c, http.StatusPreconditionFailed, ErrTaskVersionMismatch)
+		default:
+			response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToDeleteTask)
+		}
+		return
+	}
+
+	h.audit(c, domain.AuditEvent{Action: domain.AuditTaskPurge, ActorId: &existedUser.ID, ActorEmail: existedUser.Email, TargetId: &task.ID})
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,270 @@
+package v1
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"testing"
+	"time"
+)
+
+func TestGetTrash(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, taskService *mock_service.MockTask)
+	}{
+		{
+			name:       "Not existed user",
+			response:   `{"message":"Failed to get user"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Empty",
+			response:   `[]`,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().GetTrash(userId).Return(&[]domain.Task{})
+			},
+		},
+		{
+			name:       "Success",
+			response:   `[{"id":"8d306d55-4301-4770-8a90-e64f771dc3f9","list_id":null,"description":"Description","is_completed":false,"created_at":"2026-10-01T10:00:00Z","deleted_at":"2026-10-19T10:00:00Z"}]`,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				taskId, _ := uuid.Parse("8d306d55-4301-4770-8a90-e64f771dc3f9")
+				isCompleted := false
+
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().GetTrash(userId).Return(&[]domain.Task{{
+					ID:          taskId,
+					Description: "Description",
+					IsCompleted: &isCompleted,
+					CreatedAt:   time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC),
+					DeletedAt:   gorm.DeletedAt{Time: time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC), Valid: true},
+				}})
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			taskService := mock_service.NewMockTask(c)
+
+			tc.mockFunction(userService, taskService)
+			handler := Handler{services: &service.Services{User: userService, Task: taskService}}
+
+			r := gin.New()
+			r.GET("/tasks/trash", setContextEmail, handler.getTrash)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/tasks/trash", nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestRestoreTask(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		taskId       string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, taskService *mock_service.MockTask)
+	}{
+		{
+			name:       "Failed to parse task id",
+			taskId:     faker.Word(),
+			response:   `{"message":"Task not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+			},
+		},
+		{
+			name:       "Task of another user",
+			taskId:     faker.UUIDHyphenated(),
+			response:   `{"message":"Task not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindWithTrashedById(gomock.Any()).Return(&domain.Task{}, nil)
+			},
+		},
+		{
+			name:       "Task is not in trash",
+			taskId:     faker.UUIDHyphenated(),
+			response:   `{"message":"Task is not in trash"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindWithTrashedById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().Restore(gomock.Any()).Return(errors.New(service.ErrTaskIsNotTrashed))
+			},
+		},
+		{
+			name:       "Success",
+			taskId:     faker.UUIDHyphenated(),
+			response:   ``,
+			statusCode: http.StatusNoContent,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindWithTrashedById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().Restore(gomock.Any()).Return(nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			taskService := mock_service.NewMockTask(c)
+
+			tc.mockFunction(userService, taskService)
+			handler := Handler{services: &service.Services{User: userService, Task: taskService}}
+
+			r := gin.New()
+			r.POST("/tasks/:id/restore", setContextEmail, handler.restoreTask)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/tasks/"+tc.taskId+"/restore", nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestDeleteTaskPermanently(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+	authorId, _ := uuid.Parse("0b6e2f1c-2d8a-4a8e-9a51-3f0f6f6b1c2d")
+	listId, _ := uuid.Parse("7d2f4b6a-8c1e-4f3a-b5d7-9e0c2a4f6b8d")
+
+	testCases := []struct {
+		name         string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, taskService *mock_service.MockTask, listMemberService *mock_service.MockListMember, auditEventService *mock_service.MockAuditEvent)
+	}{
+		{
+			name:       "Task of another user",
+			response:   `{"message":"Task not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, listMemberService *mock_service.MockListMember, auditEventService *mock_service.MockAuditEvent) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindWithTrashedById(gomock.Any()).Return(&domain.Task{}, nil)
+			},
+		},
+		{
+			name:       "Task of another list member for editor",
+			response:   `{"message":"Insufficient permissions"}`,
+			statusCode: http.StatusForbidden,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, listMemberService *mock_service.MockListMember, auditEventService *mock_service.MockAuditEvent) {
+				task := &domain.Task{UserId: authorId, ListId: &listId}
+
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindWithTrashedById(gomock.Any()).Return(task, nil)
+				listMemberService.EXPECT().GetTaskRole(task, userId).Return(domain.ListRoleEditor).Times(2)
+			},
+		},
+		{
+			name:       "Task of another list member for admin",
+			response:   ``,
+			statusCode: http.StatusNoContent,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, listMemberService *mock_service.MockListMember, auditEventService *mock_service.MockAuditEvent) {
+				task := &domain.Task{UserId: authorId, ListId: &listId}
+
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindWithTrashedById(gomock.Any()).Return(task, nil)
+				listMemberService.EXPECT().GetTaskRole(task, userId).Return(domain.ListRoleAdmin).Times(2)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().Purge(gomock.Any()).Return(nil)
+				auditEventService.EXPECT().Record(gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:       "Failed to purge task",
+			response:   `{"message":"Failed to delete task"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, listMemberService *mock_service.MockListMember, auditEventService *mock_service.MockAuditEvent) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindWithTrashedById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().Purge(gomock.Any()).Return(errors.New("failed"))
+			},
+		},
+		{
+			name:       "Success",
+			response:   ``,
+			statusCode: http.StatusNoContent,
+			mockFunction: func(userService *mock_service.MockUser, taskService *mock_service.MockTask, listMemberService *mock_service.MockListMember, auditEventService *mock_service.MockAuditEvent) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				taskService.EXPECT().FindWithTrashedById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil)
+				taskService.EXPECT().WithActor(userId).Return(taskService)
+				taskService.EXPECT().Purge(gomock.Any()).Return(nil)
+				auditEventService.EXPECT().Record(gomock.Any()).DoAndReturn(func(event domain.AuditEvent) error {
+					require.Equal(t, domain.AuditTaskPurge, event.Action)
+					require.Equal(t, userId, *event.ActorId)
+
+					return nil
+				})
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			taskService := mock_service.NewMockTask(c)
+			listMemberService := mock_service.NewMockListMember(c)
+			auditEventService := mock_service.NewMockAuditEvent(c)
+
+			tc.mockFunction(userService, taskService, listMemberService, auditEventService)
+			handler := Handler{services: &service.Services{User: userService, Task: taskService, ListMember: listMemberService, AuditEvent: auditEventService}}
+
+			r := gin.New()
+			r.DELETE("/tasks/:id", setContextEmail, handler.deleteTask)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/tasks/"+faker.UUIDHyphenated()+"?permanent=true", nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
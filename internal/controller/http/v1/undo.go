@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/response"
+)
+
+const ErrFailedToUndo = "failed to undo"
+
+// UndoResponse - отмененное действие. Task равен null, если после отмены задача оказалась в корзине.
+type UndoResponse struct {
+	TaskId   string        `json:"task_id"`
+	Revision int           `json:"revision"`
+	Task     *TaskResponse `json:"task"`
+}
+
+func (h *Handler) initUndoRoutes(api *gin.RouterGroup) {
+	api.POST("/undo", h.auth, h.undo)
+}
+
+// @Description	Отмена последнего действия с задачами (создание, изменение, завершение, удаление), сделанного
+// @Description	в пределах окна отмены. Повторный запрос отменяет предыдущее действие.
+// @Description	Отменить действие можно, только пока у пользователя есть право изменять задачу.
+// @Tags			undo
+// @Success		200	{object}	UndoResponse
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		403	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Failure		409	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/undo [post]
+func (h *Handler) undo(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	result, err := h.scoped(c).Undo.Undo(existedUser.ID)
+
+	if err != nil {
+		switch err.Error() {
+		case service.ErrNothingToUndo:
+			response.NewErrorResponse(c, http.StatusNotFound, err.Error())
+		case service.ErrTaskNotFound:
+			response.NewErrorResponse(c, http.StatusNotFound, ErrTaskNotFound)
+		case service.ErrUndoAccessDenied:
+			response.NewErrorResponse(c, http.StatusForbidden, ErrAccessDenied)
+		case service.ErrUndoTaskModified, service.ErrTaskIsBlocked, service.ErrAssigneeNotMember:
+			response.NewErrorResponse(c, http.StatusConflict, err.Error())
+		default:
+			response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToUndo)
+		}
+		return
+	}
+
+	undoResponse := UndoResponse{TaskId: result.TaskId.String(), Revision: result.Revision}
+
+	if result.Task != nil {
+		taskResponse := newTaskResponse(*result.Task)
+		undoResponse.Task = &taskResponse
+	}
+
+	c.JSON(http.StatusOK, undoResponse)
+}
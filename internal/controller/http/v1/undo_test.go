@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"testing"
+)
+
+func TestUndo(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		response     string
+		statusCode   int
+		mockFunction func(userService *mock_service.MockUser, undoService *mock_service.MockUndo)
+	}{
+		{
+			name:       "Not existed user",
+			response:   `{"message":"Failed to get user"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(userService *mock_service.MockUser, undoService *mock_service.MockUndo) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Nothing to undo",
+			response:   `{"message":"Nothing to undo"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, undoService *mock_service.MockUndo) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				undoService.EXPECT().Undo(userId).Return(nil, errors.New(service.ErrNothingToUndo))
+			},
+		},
+		{
+			name:       "Task modified",
+			response:   `{"message":"Task was modified after the last action"}`,
+			statusCode: http.StatusConflict,
+			mockFunction: func(userService *mock_service.MockUser, undoService *mock_service.MockUndo) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				undoService.EXPECT().Undo(userId).Return(nil, errors.New(service.ErrUndoTaskModified))
+			},
+		},
+		{
+			name:       "Task no longer accessible",
+			response:   `{"message":"Task not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(userService *mock_service.MockUser, undoService *mock_service.MockUndo) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				undoService.EXPECT().Undo(userId).Return(nil, errors.New(service.ErrTaskNotFound))
+			},
+		},
+		{
+			name:       "Role downgraded to viewer",
+			response:   `{"message":"Insufficient permissions"}`,
+			statusCode: http.StatusForbidden,
+			mockFunction: func(userService *mock_service.MockUser, undoService *mock_service.MockUndo) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				undoService.EXPECT().Undo(userId).Return(nil, errors.New(service.ErrUndoAccessDenied))
+			},
+		},
+		{
+			name:       "Failed to undo",
+			response:   `{"message":"Failed to undo"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(userService *mock_service.MockUser, undoService *mock_service.MockUndo) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				undoService.EXPECT().Undo(userId).Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Task moved to trash",
+			response:   `{"task_id":"2b7e3c1a-5d4f-4e6a-9b8c-0d1e2f3a4b5c","revision":1,"task":null}`,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, undoService *mock_service.MockUndo) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				undoService.EXPECT().Undo(userId).Return(&service.UndoResult{TaskId: fixtureTaskId, Revision: 1}, nil)
+			},
+		},
+		{
+			name:       "Success",
+			response:   `{"task_id":"2b7e3c1a-5d4f-4e6a-9b8c-0d1e2f3a4b5c","revision":2,"task":` + fixtureTaskResponse + `}`,
+			statusCode: http.StatusOK,
+			mockFunction: func(userService *mock_service.MockUser, undoService *mock_service.MockUndo) {
+				userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil)
+				undoService.EXPECT().Undo(userId).Return(&service.UndoResult{
+					TaskId: fixtureTaskId, Revision: 2, Task: fixtureTask(userId),
+				}, nil)
+			},
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := mock_service.NewMockUser(c)
+			undoService := mock_service.NewMockUndo(c)
+
+			tc.mockFunction(userService, undoService)
+			handler := Handler{services: &service.Services{User: userService, Undo: undoService}}
+
+			r := gin.New()
+			r.POST("/undo", setContextEmail, handler.undo)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/undo", nil)
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package v1
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/response"
+	"time"
+)
+
+const (
+	ErrFailedToCreateWebhook    = "failed to create webhook"
+	ErrFailedToDeleteWebhook    = "failed to delete webhook"
+	ErrFailedToRedeliverWebhook = "failed to redeliver webhook event"
+)
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" binding:"required,url,max=2048"`
+	Events []string `json:"events" binding:"required,min=1"`
+}
+
+type WebhookResponse struct {
+	Id        string    `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreatedWebhookResponse struct {
+	WebhookResponse
+	// Secret - ключ подписи запросов, возвращается только при создании вебхука
+	Secret string `json:"secret"`
+}
+
+type WebhookDeliveryResponse struct {
+	Id             string          `json:"id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at"`
+	ResponseStatus *int            `json:"response_status"`
+	Error          string          `json:"error"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+func (h *Handler) initWebhooksRoutes(api *gin.RouterGroup) {
+	webhooks := api.Group("/webhooks", h.auth)
+	{
+		webhooks.GET("", h.getAllWebhooks)
+		webhooks.POST("", h.createWebhook)
+		webhooks.DELETE("/:id", h.deleteWebhook)
+		webhooks.GET("/:id/deliveries", h.getWebhookDeliveries)
+		webhooks.POST("/:id/deliveries/:deliveryId/redeliver", h.redeliverWebhook)
+	}
+}
+
+// @Description	Получение вебхуков пользователя
+// @Tags			webhook
+// @Success		200	{array}		WebhookResponse
+// @Failure		400	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/webhooks [get]
+func (h *Handler) getAllWebhooks(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	webhooks := h.services.Webhook.GetAll(existedUser.ID)
+
+	var webhooksResponse = make([]WebhookResponse, 0, len(*webhooks))
+
+	for _, webhook := range *webhooks {
+		webhooksResponse = append(webhooksResponse, newWebhookResponse(webhook))
+	}
+
+	c.JSON(http.StatusOK, webhooksResponse)
+}
+
+// @Description	Регистрация вебхука. Вебхук получает события задач, доступных пользователю: task.created, task.updated,
+// @Description	task.completed, task.reopened, task.assigned, task.deleted, task.restored, task.unarchived.
+// @Description	Запросы подписываются HMAC-SHA256 ключом secret, который возвращается только в этом ответе.
+// @Description	Адрес должен быть http или https и указывать на публичный хост, перенаправления не выполняются.
+// @Tags			webhook
+// @Param			data	body		CreateWebhookRequest	true	"Адрес получателя и типы событий"
+// @Success		201		{object}	CreatedWebhookResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		422		{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/webhooks [post]
+func (h *Handler) createWebhook(c *gin.Context) {
+	var body CreateWebhookRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	webhook, err := h.services.Webhook.Create(existedUser.ID, body.URL, body.Events)
+
+	if err != nil {
+		switch err.Error() {
+		case service.ErrInvalidWebhookURL, service.ErrInvalidWebhookEvent, service.ErrWebhookEventsRequired:
+			response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		default:
+			response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToCreateWebhook)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, CreatedWebhookResponse{WebhookResponse: newWebhookResponse(*webhook), Secret: webhook.Secret})
+}
+
+// @Description	Удаление вебхука вместе с журналом доставок
+// @Tags			webhook
+// @Param			id	path	string	true	"ID вебхука"
+// @Success		204
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/webhooks/{id} [delete]
+func (h *Handler) deleteWebhook(c *gin.Context) {
+	webhook, ok := h.getUserWebhook(c)
+
+	if !ok {
+		return
+	}
+
+	if err := h.services.Webhook.Delete(webhook.ID); err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToDeleteWebhook)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Description	Журнал последних доставок вебхука
+// @Tags			webhook
+// @Param			id	path		string	true	"ID вебхука"
+// @Success		200	{array}		WebhookDeliveryResponse
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/webhooks/{id}/deliveries [get]
+func (h *Handler) getWebhookDeliveries(c *gin.Context) {
+	webhook, ok := h.getUserWebhook(c)
+
+	if !ok {
+		return
+	}
+
+	deliveries := h.services.Webhook.GetDeliveries(webhook.ID)
+
+	var deliveriesResponse = make([]WebhookDeliveryResponse, 0, len(*deliveries))
+
+	for _, delivery := range *deliveries {
+		deliveriesResponse = append(deliveriesResponse, newWebhookDeliveryResponse(delivery))
+	}
+
+	c.JSON(http.StatusOK, deliveriesResponse)
+}
+
+// @Description	Повторная отправка события завершенной доставки. Создается новая доставка с тем же содержимым.
+// @Tags			webhook
+// @Param			id			path		string	true	"ID вебхука"
+// @Param			deliveryId	path		string	true	"ID доставки"
+// @Success		202			{object}	WebhookDeliveryResponse
+// @Failure		400			{object}	response.ErrorResponse
+// @Failure		404			{object}	response.ErrorResponse
+// @Failure		409			{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/webhooks/{id}/deliveries/{deliveryId}/redeliver [post]
+func (h *Handler) redeliverWebhook(c *gin.Context) {
+	webhook, ok := h.getUserWebhook(c)
+
+	if !ok {
+		return
+	}
+
+	deliveryId, err := uuid.Parse(c.Param("deliveryId"))
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusNotFound, service.ErrWebhookDeliveryNotFound)
+		return
+	}
+
+	delivery, err := h.services.Webhook.Redeliver(webhook.ID, deliveryId)
+
+	if err != nil {
+		switch err.Error() {
+		case service.ErrWebhookDeliveryNotFound:
+			response.NewErrorResponse(c, http.StatusNotFound, err.Error())
+		case service.ErrWebhookDeliveryIsPending:
+			response.NewErrorResponse(c, http.StatusConflict, err.Error())
+		default:
+			response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToRedeliverWebhook)
+		}
+		return
+	}
+
+	c.JSON(http.StatusAccepted, newWebhookDeliveryResponse(*delivery))
+}
+
+// getUserWebhook возвращает вебхук из параметра id, принадлежащий текущему пользователю,
+// иначе отправляет ответ с ошибкой
+func (h *Handler) getUserWebhook(c *gin.Context) (*domain.Webhook, bool) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusNotFound, service.ErrWebhookNotFound)
+		return nil, false
+	}
+
+	webhook, err := h.services.Webhook.Find(id, existedUser.ID)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusNotFound, service.ErrWebhookNotFound)
+		return nil, false
+	}
+
+	return webhook, true
+}
+
+func newWebhookResponse(webhook domain.Webhook) WebhookResponse {
+	return WebhookResponse{
+		Id:        webhook.ID.String(),
+		URL:       webhook.URL,
+		Events:    webhook.Events,
+		CreatedAt: webhook.CreatedAt,
+	}
+}
+
+func newWebhookDeliveryResponse(delivery domain.WebhookDelivery) WebhookDeliveryResponse {
+	return WebhookDeliveryResponse{
+		Id:             delivery.ID.String(),
+		Event:          delivery.Event,
+		Payload:        json.RawMessage(delivery.Payload),
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		Error:          delivery.Error,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
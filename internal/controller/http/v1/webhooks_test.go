@@ -0,0 +1,259 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"testing"
+	"time"
+)
+
+var (
+	fixtureWebhookId         = uuid.MustParse("5c7e2a91-3f4b-4d6e-8a1c-9b0d2e4f6a8c")
+	fixtureWebhookDeliveryId = uuid.MustParse("9e1f3a5c-7b2d-4c6e-8f0a-1b3c5d7e9f2a")
+	fixtureWebhookCreatedAt  = time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+)
+
+func TestGetAllWebhooks(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	w := serveWebhooksRequest(t, userId, func(webhookService *mock_service.MockWebhook) {
+		webhookService.EXPECT().GetAll(userId).Return(&[]domain.Webhook{{
+			ID: fixtureWebhookId, URL: "https://example.com/hook", Secret: "secret",
+			Events: []string{domain.TaskEventCreated}, CreatedAt: fixtureWebhookCreatedAt,
+		}})
+	}, "GET", "/webhooks", "")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `[{"id":"5c7e2a91-3f4b-4d6e-8a1c-9b0d2e4f6a8c","url":"https://example.com/hook","events":["task.created"],"created_at":"2026-10-20T12:00:00Z"}]`, w.Body.String())
+}
+
+func TestCreateWebhook(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		body         string
+		response     string
+		statusCode   int
+		mockFunction func(webhookService *mock_service.MockWebhook)
+	}{
+		{
+			name:         "Invalid url",
+			body:         `{"url":"not url","events":["task.created"]}`,
+			response:     `{"message":"Key: 'CreateWebhookRequest.URL' Error:Field validation for 'URL' failed on the 'url' tag"}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(webhookService *mock_service.MockWebhook) {},
+		},
+		{
+			name:       "Unknown event",
+			body:       `{"url":"https://example.com/hook","events":["task.moved"]}`,
+			response:   `{"message":"Unknown event type"}`,
+			statusCode: http.StatusUnprocessableEntity,
+			mockFunction: func(webhookService *mock_service.MockWebhook) {
+				webhookService.EXPECT().Create(userId, "https://example.com/hook", []string{"task.moved"}).
+					Return(nil, errors.New(service.ErrInvalidWebhookEvent))
+			},
+		},
+		{
+			name:       "Private url",
+			body:       `{"url":"http://169.254.169.254/latest","events":["task.created"]}`,
+			response:   `{"message":"Webhook url must be an http or https url of a public host"}`,
+			statusCode: http.StatusUnprocessableEntity,
+			mockFunction: func(webhookService *mock_service.MockWebhook) {
+				webhookService.EXPECT().Create(userId, "http://169.254.169.254/latest", []string{domain.TaskEventCreated}).
+					Return(nil, errors.New(service.ErrInvalidWebhookURL))
+			},
+		},
+		{
+			name:       "Success",
+			body:       `{"url":"https://example.com/hook","events":["task.completed"]}`,
+			response:   `{"id":"5c7e2a91-3f4b-4d6e-8a1c-9b0d2e4f6a8c","url":"https://example.com/hook","events":["task.completed"],"created_at":"2026-10-20T12:00:00Z","secret":"secret"}`,
+			statusCode: http.StatusCreated,
+			mockFunction: func(webhookService *mock_service.MockWebhook) {
+				webhookService.EXPECT().Create(userId, "https://example.com/hook", []string{domain.TaskEventCompleted}).
+					Return(&domain.Webhook{
+						ID: fixtureWebhookId, URL: "https://example.com/hook", Secret: "secret",
+						Events: []string{domain.TaskEventCompleted}, CreatedAt: fixtureWebhookCreatedAt,
+					}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serveWebhooksRequest(t, userId, tc.mockFunction, "POST", "/webhooks", tc.body)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		url          string
+		response     string
+		statusCode   int
+		mockFunction func(webhookService *mock_service.MockWebhook)
+	}{
+		{
+			name:         "Invalid id",
+			url:          "/webhooks/invalid",
+			response:     `{"message":"Webhook not found"}`,
+			statusCode:   http.StatusNotFound,
+			mockFunction: func(webhookService *mock_service.MockWebhook) {},
+		},
+		{
+			name:       "Another user",
+			url:        "/webhooks/" + fixtureWebhookId.String(),
+			response:   `{"message":"Webhook not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(webhookService *mock_service.MockWebhook) {
+				webhookService.EXPECT().Find(fixtureWebhookId, userId).Return(nil, errors.New(service.ErrWebhookNotFound))
+			},
+		},
+		{
+			name:       "Success",
+			url:        "/webhooks/" + fixtureWebhookId.String(),
+			statusCode: http.StatusNoContent,
+			mockFunction: func(webhookService *mock_service.MockWebhook) {
+				webhookService.EXPECT().Find(fixtureWebhookId, userId).Return(&domain.Webhook{ID: fixtureWebhookId}, nil)
+				webhookService.EXPECT().Delete(fixtureWebhookId).Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serveWebhooksRequest(t, userId, tc.mockFunction, "DELETE", tc.url, "")
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestGetWebhookDeliveries(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+	responseStatus := http.StatusInternalServerError
+
+	w := serveWebhooksRequest(t, userId, func(webhookService *mock_service.MockWebhook) {
+		webhookService.EXPECT().Find(fixtureWebhookId, userId).Return(&domain.Webhook{ID: fixtureWebhookId}, nil)
+		webhookService.EXPECT().GetDeliveries(fixtureWebhookId).Return(&[]domain.WebhookDelivery{{
+			ID:             fixtureWebhookDeliveryId,
+			Event:          domain.TaskEventDeleted,
+			Payload:        `{"event":"task.deleted"}`,
+			Status:         domain.WebhookDeliveryFailed,
+			Attempts:       8,
+			ResponseStatus: &responseStatus,
+			ResponseBody:   "oops",
+			Error:          "unexpected status 500",
+			CreatedAt:      fixtureWebhookCreatedAt,
+		}})
+	}, "GET", "/webhooks/"+fixtureWebhookId.String()+"/deliveries", "")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `[{"id":"9e1f3a5c-7b2d-4c6e-8f0a-1b3c5d7e9f2a","event":"task.deleted","payload":{"event":"task.deleted"},"status":"failed","attempts":8,"next_attempt_at":null,"response_status":500,"error":"unexpected status 500","delivered_at":null,"created_at":"2026-10-20T12:00:00Z"}]`, w.Body.String())
+}
+
+func TestRedeliverWebhook(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+	url := "/webhooks/" + fixtureWebhookId.String() + "/deliveries/" + fixtureWebhookDeliveryId.String() + "/redeliver"
+
+	testCases := []struct {
+		name         string
+		response     string
+		statusCode   int
+		mockFunction func(webhookService *mock_service.MockWebhook)
+	}{
+		{
+			name:       "Delivery not found",
+			response:   `{"message":"Webhook delivery not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(webhookService *mock_service.MockWebhook) {
+				webhookService.EXPECT().Find(fixtureWebhookId, userId).Return(&domain.Webhook{ID: fixtureWebhookId}, nil)
+				webhookService.EXPECT().Redeliver(fixtureWebhookId, fixtureWebhookDeliveryId).
+					Return(nil, errors.New(service.ErrWebhookDeliveryNotFound))
+			},
+		},
+		{
+			name:       "Pending",
+			response:   `{"message":"Webhook delivery is still pending"}`,
+			statusCode: http.StatusConflict,
+			mockFunction: func(webhookService *mock_service.MockWebhook) {
+				webhookService.EXPECT().Find(fixtureWebhookId, userId).Return(&domain.Webhook{ID: fixtureWebhookId}, nil)
+				webhookService.EXPECT().Redeliver(fixtureWebhookId, fixtureWebhookDeliveryId).
+					Return(nil, errors.New(service.ErrWebhookDeliveryIsPending))
+			},
+		},
+		{
+			name:       "Success",
+			response:   `{"id":"9e1f3a5c-7b2d-4c6e-8f0a-1b3c5d7e9f2a","event":"task.created","payload":{},"status":"pending","attempts":0,"next_attempt_at":"2026-10-20T12:00:00Z","response_status":null,"error":"","delivered_at":null,"created_at":"2026-10-20T12:00:00Z"}`,
+			statusCode: http.StatusAccepted,
+			mockFunction: func(webhookService *mock_service.MockWebhook) {
+				webhookService.EXPECT().Find(fixtureWebhookId, userId).Return(&domain.Webhook{ID: fixtureWebhookId}, nil)
+				webhookService.EXPECT().Redeliver(fixtureWebhookId, fixtureWebhookDeliveryId).Return(&domain.WebhookDelivery{
+					ID:            fixtureWebhookDeliveryId,
+					Event:         domain.TaskEventCreated,
+					Payload:       `{}`,
+					Status:        domain.WebhookDeliveryPending,
+					NextAttemptAt: &fixtureWebhookCreatedAt,
+					CreatedAt:     fixtureWebhookCreatedAt,
+				}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serveWebhooksRequest(t, userId, tc.mockFunction, "POST", url, "")
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func serveWebhooksRequest(
+	t *testing.T,
+	userId uuid.UUID,
+	mockFunction func(webhookService *mock_service.MockWebhook),
+	method, url, body string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	userService := mock_service.NewMockUser(c)
+	webhookService := mock_service.NewMockWebhook(c)
+
+	userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil).AnyTimes()
+	mockFunction(webhookService)
+	handler := Handler{services: &service.Services{User: userService, Webhook: webhookService}}
+
+	r := gin.New()
+	r.GET("/webhooks", setContextEmail, handler.getAllWebhooks)
+	r.POST("/webhooks", setContextEmail, handler.createWebhook)
+	r.DELETE("/webhooks/:id", setContextEmail, handler.deleteWebhook)
+	r.GET("/webhooks/:id/deliveries", setContextEmail, handler.getWebhookDeliveries)
+	r.POST("/webhooks/:id/deliveries/:deliveryId/redeliver", setContextEmail, handler.redeliverWebhook)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, url, bytes.NewBufferString(body))
+	r.ServeHTTP(w, req)
+
+	return w
+}
@@ -0,0 +1,301 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/response"
+)
+
+const (
+	ErrFailedToCreateWorkspace       = "failed to create workspace"
+	ErrFailedToAddWorkspaceMember    = "failed to add workspace member"
+	ErrFailedToRemoveWorkspaceMember = "failed to remove workspace member"
+)
+
+type CreateWorkspaceRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type AddWorkspaceMemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required"`
+}
+
+type WorkspaceResponse struct {
+	Id         string `json:"id"`
+	Name       string `json:"name"`
+	IsPersonal bool   `json:"is_personal"`
+	Role       string `json:"role"`
+}
+
+type WorkspaceMemberResponse struct {
+	UserId string `json:"user_id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Role   string `json:"role"`
+}
+
+func (h *Handler) initWorkspacesRoutes(api *gin.RouterGroup) {
+	workspaces := api.Group("/workspaces", h.auth)
+	{
+		workspaces.GET("", h.getAllWorkspaces)
+		workspaces.POST("", h.createWorkspace)
+		workspaces.GET("/:id/members", h.getWorkspaceMembers)
+		workspaces.POST("/:id/members", h.addWorkspaceMember)
+		workspaces.DELETE("/:id/members/:userId", h.removeWorkspaceMember)
+	}
+}
+
+// @Description	Получение рабочих пространств пользователя. Первым идет личное пространство.
+// @Tags			workspace
+// @Success		200	{array}		WorkspaceResponse
+// @Failure		400	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/workspaces [get]
+func (h *Handler) getAllWorkspaces(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	var workspacesResponse = make([]WorkspaceResponse, 0)
+
+	for _, workspace := range *h.services.Workspace.GetAll(existedUser.ID) {
+		workspacesResponse = append(workspacesResponse, newWorkspaceResponse(workspace))
+	}
+
+	c.JSON(http.StatusOK, workspacesResponse)
+}
+
+// @Description	Создание рабочего пространства, владельцем которого становится пользователь
+// @Tags			workspace
+// @Param			data	body		CreateWorkspaceRequest	true	"Данные нового пространства"
+// @Success		201		{object}	WorkspaceResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		422		{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/workspaces [post]
+func (h *Handler) createWorkspace(c *gin.Context) {
+	var body CreateWorkspaceRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	workspace, err := h.services.Workspace.Create(existedUser.ID, body.Name)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToCreateWorkspace)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newWorkspaceResponse(*workspace))
+}
+
+// @Description	Получение участников рабочего пространства
+// @Tags			workspace
+// @Param			id	path		string	true	"ID пространства"
+// @Success		200	{array}		WorkspaceMemberResponse
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/workspaces/{id}/members [get]
+func (h *Handler) getWorkspaceMembers(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	workspace, err := h.getUserWorkspace(c, "id", existedUser.ID, domain.WorkspaceRoleMember)
+
+	if err != nil {
+		respondAccessError(c, err, service.ErrWorkspaceNotFound)
+		return
+	}
+
+	var membersResponse = make([]WorkspaceMemberResponse, 0)
+
+	for _, member := range *h.services.Workspace.GetMembers(workspace.ID) {
+		membersResponse = append(membersResponse, newWorkspaceMemberResponse(member))
+	}
+
+	c.JSON(http.StatusOK, membersResponse)
+}
+
+// @Description	Добавление в рабочее пространство пользователя с ролью member или admin
+// @Tags			workspace
+// @Param			id		path		string						true	"ID пространства"
+// @Param			data	body		AddWorkspaceMemberRequest	true	"Адрес пользователя и его роль"
+// @Success		201		{object}	WorkspaceMemberResponse
+// @Failure		400		{object}	response.ErrorResponse
+// @Failure		403		{object}	response.ErrorResponse
+// @Failure		404		{object}	response.ErrorResponse
+// @Failure		409		{object}	response.ErrorResponse
+// @Failure		422		{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/workspaces/{id}/members [post]
+func (h *Handler) addWorkspaceMember(c *gin.Context) {
+	var body AddWorkspaceMemberRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	workspace, err := h.getUserWorkspace(c, "id", existedUser.ID, domain.WorkspaceRoleAdmin)
+
+	if err != nil {
+		respondAccessError(c, err, service.ErrWorkspaceNotFound)
+		return
+	}
+
+	member, err := h.services.Workspace.AddMember(workspace, body.Email, body.Role)
+
+	if err != nil {
+		respondWorkspaceMemberError(c, err, ErrFailedToAddWorkspaceMember)
+		return
+	}
+
+	h.audit(c, domain.AuditEvent{
+		Action:     domain.AuditWorkspaceMemberAdd,
+		ActorId:    &existedUser.ID,
+		ActorEmail: existedUser.Email,
+		TargetId:   &member.UserId,
+		Details:    "workspace_id=" + workspace.ID.String() + " role=" + member.Role,
+	})
+
+	c.JSON(http.StatusCreated, newWorkspaceMemberResponse(*member))
+}
+
+// @Description	Исключение участника из рабочего пространства. Участник может покинуть пространство сам.
+// @Tags			workspace
+// @Param			id		path	string	true	"ID пространства"
+// @Param			userId	path	string	true	"ID участника"
+// @Success		204
+// @Failure		400	{object}	response.ErrorResponse
+// @Failure		403	{object}	response.ErrorResponse
+// @Failure		404	{object}	response.ErrorResponse
+// @Failure		409	{object}	response.ErrorResponse
+// @Security		ApiKeyAuth
+// @Router			/workspaces/{id}/members/{userId} [delete]
+func (h *Handler) removeWorkspaceMember(c *gin.Context) {
+	existedUser, err := h.getContextUser(c)
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusBadRequest, ErrFailedToGetUser)
+		return
+	}
+
+	memberId, err := uuid.Parse(c.Param("userId"))
+
+	if err != nil {
+		response.NewErrorResponse(c, http.StatusNotFound, service.ErrWorkspaceMemberNotFound)
+		return
+	}
+
+	role := domain.WorkspaceRoleAdmin
+
+	if memberId == existedUser.ID {
+		role = domain.WorkspaceRoleMember
+	}
+
+	workspace, err := h.getUserWorkspace(c, "id", existedUser.ID, role)
+
+	if err != nil {
+		respondAccessError(c, err, service.ErrWorkspaceNotFound)
+		return
+	}
+
+	if err = h.services.Workspace.RemoveMember(existedUser.ID, workspace, memberId); err != nil {
+		respondWorkspaceMemberError(c, err, ErrFailedToRemoveWorkspaceMember)
+		return
+	}
+
+	h.audit(c, domain.AuditEvent{
+		Action:     domain.AuditWorkspaceMemberRemove,
+		ActorId:    &existedUser.ID,
+		ActorEmail: existedUser.Email,
+		TargetId:   &memberId,
+		Details:    "workspace_id=" + workspace.ID.String(),
+	})
+
+	c.Status(http.StatusNoContent)
+}
+
+// getUserWorkspace возвращает рабочее пространство из параметра маршрута param,
+// если роль пользователя userId в нем не ниже role
+func (h *Handler) getUserWorkspace(c *gin.Context, param string, userId uuid.UUID, role string) (*domain.Workspace, error) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		return nil, err
+	}
+
+	return h.findUserWorkspace(id, userId, role)
+}
+
+// findUserWorkspace возвращает рабочее пространство id, если роль пользователя userId в нем не ниже role
+func (h *Handler) findUserWorkspace(id, userId uuid.UUID, role string) (*domain.Workspace, error) {
+	workspace, err := h.services.Workspace.Find(id, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !domain.WorkspaceRoleAllows(workspace.Role, role) {
+		return nil, errAccessDenied
+	}
+
+	return workspace, nil
+}
+
+func respondWorkspaceMemberError(c *gin.Context, err error, fallback string) {
+	switch err.Error() {
+	case service.ErrInvalidWorkspaceRole:
+		response.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+	case service.ErrWorkspaceMemberUserNotFound, service.ErrWorkspaceMemberNotFound:
+		response.NewErrorResponse(c, http.StatusNotFound, err.Error())
+	case service.ErrWorkspaceMemberExists, service.ErrWorkspaceOwnerIsNotMember:
+		response.NewErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		response.NewErrorResponse(c, http.StatusBadRequest, fallback)
+	}
+}
+
+func newWorkspaceResponse(workspace domain.Workspace) WorkspaceResponse {
+	return WorkspaceResponse{
+		Id:         workspace.ID.String(),
+		Name:       workspace.Name,
+		IsPersonal: workspace.IsPersonal(),
+		Role:       workspace.Role,
+	}
+}
+
+func newWorkspaceMemberResponse(member domain.WorkspaceMember) WorkspaceMemberResponse {
+	return WorkspaceMemberResponse{
+		UserId: member.UserId.String(),
+		Name:   member.Name,
+		Email:  member.Email,
+		Role:   member.Role,
+	}
+}
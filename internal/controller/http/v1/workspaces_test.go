@@ -0,0 +1,379 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/config"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"poymanov/todo/pkg/helpers"
+	"testing"
+)
+
+var fixtureWorkspaceId = uuid.MustParse("3b9e1f5a-7c2d-4e8f-a1b3-c5d7e9f1a2b4")
+
+func TestSelectWorkspace(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		header       string
+		response     string
+		statusCode   int
+		mockFunction func(workspaceService *mock_service.MockWorkspace)
+	}{
+		{
+			name:         "Invalid workspace id",
+			header:       "workspace",
+			response:     `{"message":"Workspace not found"}`,
+			statusCode:   http.StatusNotFound,
+			mockFunction: func(workspaceService *mock_service.MockWorkspace) {},
+		},
+		{
+			name:       "Not a member",
+			header:     fixtureWorkspaceId.String(),
+			response:   `{"message":"Workspace not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(workspaceService *mock_service.MockWorkspace) {
+				workspaceService.EXPECT().Resolve(userId, &fixtureWorkspaceId).Return(nil, errors.New(service.ErrWorkspaceNotFound))
+			},
+		},
+		{
+			name:       "Personal workspace by default",
+			response:   `"d4c3b2a1-0000-4000-8000-000000000001"`,
+			statusCode: http.StatusOK,
+			mockFunction: func(workspaceService *mock_service.MockWorkspace) {
+				workspaceService.EXPECT().Resolve(userId, nil).
+					Return(&domain.Workspace{ID: uuid.MustParse("d4c3b2a1-0000-4000-8000-000000000001"), PersonalUserId: &userId}, nil)
+			},
+		},
+		{
+			name:       "Selected workspace",
+			header:     fixtureWorkspaceId.String(),
+			response:   `"3b9e1f5a-7c2d-4e8f-a1b3-c5d7e9f1a2b4"`,
+			statusCode: http.StatusOK,
+			mockFunction: func(workspaceService *mock_service.MockWorkspace) {
+				workspaceService.EXPECT().Resolve(userId, &fixtureWorkspaceId).
+					Return(&domain.Workspace{ID: fixtureWorkspaceId, Role: domain.WorkspaceRoleMember}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			mockedDatabase, _ := helpers.InitMockDatabase()
+			services := service.NewServices(repository.NewRepositories(mockedDatabase), nil, &config.Config{})
+
+			userService := mock_service.NewMockUser(c)
+			workspaceService := mock_service.NewMockWorkspace(c)
+
+			userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil).AnyTimes()
+			tc.mockFunction(workspaceService)
+
+			services.User = userService
+			services.Workspace = workspaceService
+			handler := Handler{services: services}
+
+			r := gin.New()
+			r.GET("/workspace", setContextEmail, handler.selectWorkspace, func(c *gin.Context) {
+				workspace, _ := c.Get(ContextWorkspaceKey)
+
+				require.NotSame(t, services, handler.scoped(c))
+				c.JSON(http.StatusOK, workspace.(*domain.Workspace).ID)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/workspace", nil)
+
+			if tc.header != "" {
+				req.Header.Set(workspaceHeader, tc.header)
+			}
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestGetAllWorkspaces(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	w := serveWorkspacesRequest(t, userId, func(workspaceService *mock_service.MockWorkspace) {
+		workspaceService.EXPECT().GetAll(userId).Return(&[]domain.Workspace{
+			{ID: fixtureWorkspaceId, Name: "Personal", PersonalUserId: &userId, Role: domain.WorkspaceRoleOwner},
+		})
+	}, "GET", "/workspaces", "")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `[{"id":"3b9e1f5a-7c2d-4e8f-a1b3-c5d7e9f1a2b4","name":"Personal","is_personal":true,"role":"owner"}]`, w.Body.String())
+}
+
+func TestCreateWorkspace(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		body         string
+		response     string
+		statusCode   int
+		mockFunction func(workspaceService *mock_service.MockWorkspace)
+	}{
+		{
+			name:         "Missing name",
+			body:         `{}`,
+			response:     `{"message":"Key: 'CreateWorkspaceRequest.Name' Error:Field validation for 'Name' failed on the 'required' tag"}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(workspaceService *mock_service.MockWorkspace) {},
+		},
+		{
+			name:       "Failed to create",
+			body:       `{"name":"Team"}`,
+			response:   `{"message":"Failed to create workspace"}`,
+			statusCode: http.StatusBadRequest,
+			mockFunction: func(workspaceService *mock_service.MockWorkspace) {
+				workspaceService.EXPECT().Create(userId, "Team").Return(nil, errors.New("failed"))
+			},
+		},
+		{
+			name:       "Success",
+			body:       `{"name":"Team"}`,
+			response:   `{"id":"3b9e1f5a-7c2d-4e8f-a1b3-c5d7e9f1a2b4","name":"Team","is_personal":false,"role":"owner"}`,
+			statusCode: http.StatusCreated,
+			mockFunction: func(workspaceService *mock_service.MockWorkspace) {
+				workspaceService.EXPECT().Create(userId, "Team").
+					Return(&domain.Workspace{ID: fixtureWorkspaceId, Name: "Team", Role: domain.WorkspaceRoleOwner}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serveWorkspacesRequest(t, userId, tc.mockFunction, "POST", "/workspaces", tc.body)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestGetWorkspaceMembers(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		response     string
+		statusCode   int
+		mockFunction func(workspaceService *mock_service.MockWorkspace)
+	}{
+		{
+			name:       "Not a member",
+			response:   `{"message":"Workspace not found"}`,
+			statusCode: http.StatusNotFound,
+			mockFunction: func(workspaceService *mock_service.MockWorkspace) {
+				workspaceService.EXPECT().Find(fixtureWorkspaceId, userId).Return(nil, errors.New(service.ErrWorkspaceNotFound))
+			},
+		},
+		{
+			name:       "Success",
+			response:   `[{"user_id":"5c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f","name":"John","email":"john@example.com","role":"admin"}]`,
+			statusCode: http.StatusOK,
+			mockFunction: func(workspaceService *mock_service.MockWorkspace) {
+				workspaceService.EXPECT().Find(fixtureWorkspaceId, userId).
+					Return(&domain.Workspace{ID: fixtureWorkspaceId, Role: domain.WorkspaceRoleMember}, nil)
+				workspaceService.EXPECT().GetMembers(fixtureWorkspaceId).Return(&[]domain.WorkspaceMember{
+					{WorkspaceId: fixtureWorkspaceId, UserId: fixtureMemberId, Role: domain.WorkspaceRoleAdmin, Name: "John", Email: "john@example.com"},
+				})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serveWorkspacesRequest(t, userId, tc.mockFunction, "GET", "/workspaces/"+fixtureWorkspaceId.String()+"/members", "")
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestAddWorkspaceMember(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		body         string
+		response     string
+		statusCode   int
+		mockFunction func(workspaceService *mock_service.MockWorkspace)
+	}{
+		{
+			name:         "Invalid email",
+			body:         `{"email":"john","role":"member"}`,
+			response:     `{"message":"Key: 'AddWorkspaceMemberRequest.Email' Error:Field validation for 'Email' failed on the 'email' tag"}`,
+			statusCode:   http.StatusUnprocessableEntity,
+			mockFunction: func(workspaceService *mock_service.MockWorkspace) {},
+		},
+		{
+			name:       "Member can't add members",
+			body:       `{"email":"john@example.com","role":"member"}`,
+			response:   `{"message":"Insufficient permissions"}`,
+			statusCode: http.StatusForbidden,
+			mockFunction: func(workspaceService *mock_service.MockWorkspace) {
+				workspaceService.EXPECT().Find(fixtureWorkspaceId, userId).
+					Return(&domain.Workspace{ID: fixtureWorkspaceId, Role: domain.WorkspaceRoleMember}, nil)
+			},
+		},
+		{
+			name:       "Already a member",
+			body:       `{"email":"john@example.com","role":"member"}`,
+			response:   `{"message":"User is already a member of the workspace"}`,
+			statusCode: http.StatusConflict,
+			mockFunction: func(workspaceService *mock_service.MockWorkspace) {
+				workspaceService.EXPECT().Find(fixtureWorkspaceId, userId).
+					Return(&domain.Workspace{ID: fixtureWorkspaceId, Role: domain.WorkspaceRoleAdmin}, nil)
+				workspaceService.EXPECT().AddMember(gomock.Any(), "john@example.com", "member").
+					Return(nil, errors.New(service.ErrWorkspaceMemberExists))
+			},
+		},
+		{
+			name:       "Success",
+			body:       `{"email":"john@example.com","role":"member"}`,
+			response:   `{"user_id":"5c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f","name":"John","email":"john@example.com","role":"member"}`,
+			statusCode: http.StatusCreated,
+			mockFunction: func(workspaceService *mock_service.MockWorkspace) {
+				workspaceService.EXPECT().Find(fixtureWorkspaceId, userId).
+					Return(&domain.Workspace{ID: fixtureWorkspaceId, Role: domain.WorkspaceRoleOwner}, nil)
+				workspaceService.EXPECT().AddMember(gomock.Any(), "john@example.com", "member").Return(&domain.WorkspaceMember{
+					WorkspaceId: fixtureWorkspaceId, UserId: fixtureMemberId, Role: domain.WorkspaceRoleMember, Name: "John", Email: "john@example.com",
+				}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serveWorkspacesRequest(t, userId, tc.mockFunction, "POST", "/workspaces/"+fixtureWorkspaceId.String()+"/members", tc.body)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func TestRemoveWorkspaceMember(t *testing.T) {
+	userId, _ := uuid.Parse("64f7ecf1-cf5d-4f7f-888b-f3b68b68e70b")
+
+	testCases := []struct {
+		name         string
+		memberId     string
+		response     string
+		statusCode   int
+		mockFunction func(workspaceService *mock_service.MockWorkspace)
+	}{
+		{
+			name:         "Failed to parse member id",
+			memberId:     "member",
+			response:     `{"message":"Workspace member not found"}`,
+			statusCode:   http.StatusNotFound,
+			mockFunction: func(workspaceService *mock_service.MockWorkspace) {},
+		},
+		{
+			name:       "Member can't remove others",
+			memberId:   fixtureMemberId.String(),
+			response:   `{"message":"Insufficient permissions"}`,
+			statusCode: http.StatusForbidden,
+			mockFunction: func(workspaceService *mock_service.MockWorkspace) {
+				workspaceService.EXPECT().Find(fixtureWorkspaceId, userId).
+					Return(&domain.Workspace{ID: fixtureWorkspaceId, Role: domain.WorkspaceRoleMember}, nil)
+			},
+		},
+		{
+			name:       "Owner can't leave",
+			memberId:   userId.String(),
+			response:   `{"message":"Workspace owner can't be removed from the workspace"}`,
+			statusCode: http.StatusConflict,
+			mockFunction: func(workspaceService *mock_service.MockWorkspace) {
+				workspaceService.EXPECT().Find(fixtureWorkspaceId, userId).
+					Return(&domain.Workspace{ID: fixtureWorkspaceId, Role: domain.WorkspaceRoleOwner}, nil)
+				workspaceService.EXPECT().RemoveMember(gomock.Any(), gomock.Any(), userId).Return(errors.New(service.ErrWorkspaceOwnerIsNotMember))
+			},
+		},
+		{
+			name:       "Member leaves",
+			memberId:   userId.String(),
+			statusCode: http.StatusNoContent,
+			mockFunction: func(workspaceService *mock_service.MockWorkspace) {
+				workspaceService.EXPECT().Find(fixtureWorkspaceId, userId).
+					Return(&domain.Workspace{ID: fixtureWorkspaceId, Role: domain.WorkspaceRoleMember}, nil)
+				workspaceService.EXPECT().RemoveMember(gomock.Any(), gomock.Any(), userId).Return(nil)
+			},
+		},
+		{
+			name:       "Admin removes member",
+			memberId:   fixtureMemberId.String(),
+			statusCode: http.StatusNoContent,
+			mockFunction: func(workspaceService *mock_service.MockWorkspace) {
+				workspaceService.EXPECT().Find(fixtureWorkspaceId, userId).
+					Return(&domain.Workspace{ID: fixtureWorkspaceId, Role: domain.WorkspaceRoleAdmin}, nil)
+				workspaceService.EXPECT().RemoveMember(gomock.Any(), gomock.Any(), fixtureMemberId).Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serveWorkspacesRequest(t, userId, tc.mockFunction, "DELETE", "/workspaces/"+fixtureWorkspaceId.String()+"/members/"+tc.memberId, "")
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.response, w.Body.String())
+		})
+	}
+}
+
+func serveWorkspacesRequest(
+	t *testing.T,
+	userId uuid.UUID,
+	mockFunction func(workspaceService *mock_service.MockWorkspace),
+	method, url, body string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	userService := mock_service.NewMockUser(c)
+	workspaceService := mock_service.NewMockWorkspace(c)
+	auditEventService := mock_service.NewMockAuditEvent(c)
+
+	userService.EXPECT().FindByEmail(gomock.Any()).Return(&domain.User{ID: userId}, nil).AnyTimes()
+	auditEventService.EXPECT().Record(gomock.Any()).Return(nil).AnyTimes()
+	mockFunction(workspaceService)
+	handler := Handler{services: &service.Services{User: userService, Workspace: workspaceService, AuditEvent: auditEventService}}
+
+	r := gin.New()
+	r.GET("/workspaces", setContextEmail, handler.getAllWorkspaces)
+	r.POST("/workspaces", setContextEmail, handler.createWorkspace)
+	r.GET("/workspaces/:id/members", setContextEmail, handler.getWorkspaceMembers)
+	r.POST("/workspaces/:id/members", setContextEmail, handler.addWorkspaceMember)
+	r.DELETE("/workspaces/:id/members/:userId", setContextEmail, handler.removeWorkspaceMember)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, url, bytes.NewBufferString(body))
+	r.ServeHTTP(w, req)
+
+	return w
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Действия, которые записываются в журнал аудита
+const (
+	AuditRegister              = "auth.register"
+	AuditLogin                 = "auth.login"
+	AuditLoginFailed           = "auth.login_failed"
+	AuditPasswordChange        = "auth.password_change"
+	AuditUserRoleChange        = "user.role_change"
+	AuditListMemberRoleChange  = "list.member_role_change"
+	AuditWorkspaceMemberAdd    = "workspace.member_add"
+	AuditWorkspaceMemberRemove = "workspace.member_remove"
+	AuditInviteAccept          = "invite.accept"
+	AuditTaskPurge             = "task.purge"
+	AuditUserDisable           = "admin.user_disable"
+	AuditUserEnable            = "admin.user_enable"
+	AuditPasswordReset         = "admin.password_reset"
+)
+
+// AuditEvent - запись журнала аудита. Записи только добавляются, изменить или удалить их нельзя.
+type AuditEvent struct {
+	ID     uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primary_key"`
+	Action string
+	// ActorId - пользователь, выполнивший действие, nil для неизвестного пользователя или консольной команды
+	ActorId *uuid.UUID
+	// ActorEmail - адрес, с которым выполнялось действие, в том числе при неудачном входе
+	ActorEmail string
+	// TargetId - пользователь или объект, над которым выполнено действие
+	TargetId  *uuid.UUID
+	Details   string
+	IP        string
+	UserAgent string
+	RequestId string
+	CreatedAt time.Time
+}
+
+// AuditFilter - условия отбора записей журнала аудита, пустые поля не ограничивают выборку
+type AuditFilter struct {
+	Action   string
+	ActorId  *uuid.UUID
+	TargetId *uuid.UUID
+	From     *time.Time
+	To       *time.Time
+}
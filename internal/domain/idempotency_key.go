@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// IdempotencyKey - сохраненный результат запроса с заголовком Idempotency-Key.
+// Нулевой StatusCode означает, что запрос еще выполняется.
+type IdempotencyKey struct {
+	UserId      uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Key         string    `gorm:"primaryKey"`
+	Fingerprint string
+	StatusCode  int
+	Headers     map[string]string `gorm:"serializer:json"`
+	Body        []byte
+	CreatedAt   time.Time
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Invite - приглашение в рабочее пространство или, если задан ListId, в список этого пространства.
+// Role - роль в пространстве для приглашений в пространство и роль в списке для приглашений в список.
+type Invite struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primary_key"`
+	UserId      uuid.UUID
+	WorkspaceId uuid.UUID
+	ListId      *uuid.UUID
+	Role        string
+	SingleUse   bool
+	ExpiresAt   time.Time
+	UsedAt      *time.Time
+	InviterName string `gorm:"->;-:migration"`
+	TargetName  string `gorm:"->;-:migration"`
+	CreatedAt   time.Time
+}
+
+// IsList сообщает, приглашает ли приглашение в список
+func (i *Invite) IsList() bool {
+	return i.ListId != nil
+}
+
+// IsUsed сообщает, использовано ли одноразовое приглашение
+func (i *Invite) IsUsed() bool {
+	return i.SingleUse && i.UsedAt != nil
+}
@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type List struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primary_key"`
+	UserId      uuid.UUID
+	WorkspaceId uuid.UUID
+	Name        string
+	Role        string `gorm:"->;-:migration"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
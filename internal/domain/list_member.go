@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Роли пользователей в списке задач. Владельцем считается автор списка, остальные роли выдаются участникам.
+const (
+	ListRoleOwner  = "owner"
+	ListRoleAdmin  = "admin"
+	ListRoleEditor = "editor"
+	ListRoleViewer = "viewer"
+)
+
+var listRoleRanks = map[string]int{
+	ListRoleViewer: 1,
+	ListRoleEditor: 2,
+	ListRoleAdmin:  3,
+	ListRoleOwner:  4,
+}
+
+type ListMember struct {
+	ListId    uuid.UUID `gorm:"type:uuid;primary_key"`
+	UserId    uuid.UUID `gorm:"type:uuid;primary_key"`
+	Role      string
+	Name      string `gorm:"->;-:migration"`
+	Email     string `gorm:"->;-:migration"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ListRoleAllows сообщает, дает ли роль role права роли required. Пустая роль не дает никаких прав.
+func ListRoleAllows(role, required string) bool {
+	rank, ok := listRoleRanks[role]
+
+	return ok && rank >= listRoleRanks[required]
+}
+
+// IsMemberRole сообщает, можно ли выдать роль role участнику списка
+func IsMemberRole(role string) bool {
+	return role == ListRoleViewer || role == ListRoleEditor || role == ListRoleAdmin
+}
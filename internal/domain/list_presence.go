@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ListPresence - отметка о том, что пользователь просматривает список через соединение ConnectionId.
+// Отметка продлевается, пока соединение открыто, и перестает учитываться после ExpiresAt.
+type ListPresence struct {
+	ConnectionId uuid.UUID `gorm:"type:uuid;primary_key"`
+	ListId       uuid.UUID `gorm:"type:uuid;primary_key"`
+	UserId       uuid.UUID `gorm:"type:uuid"`
+	ExpiresAt    time.Time
+}
+
+// PresenceUser - пользователь, который сейчас просматривает список
+type PresenceUser struct {
+	UserId uuid.UUID `json:"user_id"`
+	Name   string    `json:"name"`
+}
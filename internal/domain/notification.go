@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Типы уведомлений пользователей
+const (
+	NotificationTaskAssigned = "task_assigned"
+)
+
+type Notification struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primary_key"`
+	UserId    uuid.UUID
+	Type      string
+	TaskId    *uuid.UUID
+	ActorId   *uuid.UUID
+	ReadAt    *time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// OutboxEvent - доменное событие, сохраненное в той же транзакции, что и изменение, которое его породило.
+// Событие публикуется во внешние получатели фоновым процессом после фиксации транзакции.
+// Sequence задает порядок публикации, AggregateId - сущность, к которой относится событие.
+// PublishedSequence выдается при публикации и растет в порядке фиксации, по нему клиенты потока событий
+// запоминают последнее полученное событие.
+type OutboxEvent struct {
+	ID                uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primary_key"`
+	Sequence          int64     `gorm:"->"`
+	PublishedSequence *int64    `gorm:"->"`
+	Type              string
+	AggregateId       uuid.UUID
+	Payload           string `gorm:"type:jsonb"`
+	Attempts          int
+	LastError         string
+	CreatedAt         time.Time
+	PublishedAt       *time.Time
+	FailedAt          *time.Time
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+type SmartList struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primary_key"`
+	UserId    uuid.UUID
+	Name      string
+	Filter    SmartListFilter `gorm:"type:jsonb"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// SmartListFilter - сохраненные условия отбора задач. Все заданные условия объединяются через "и".
+type SmartListFilter struct {
+	Completed         *bool      `json:"completed,omitempty"`
+	Blocked           *bool      `json:"blocked,omitempty"`
+	ListId            *uuid.UUID `json:"list_id,omitempty"`
+	StatusId          *uuid.UUID `json:"status_id,omitempty"`
+	TagsAll           []string   `json:"tags_all,omitempty"`
+	TagsAny           []string   `json:"tags_any,omitempty"`
+	CreatedWithinDays *int       `json:"created_within_days,omitempty"`
+	Overdue           *bool      `json:"overdue,omitempty"`
+	Query             string     `json:"q,omitempty"`
+}
+
+// TaskFilter строит фильтр задач пользователя userId на момент now
+func (f SmartListFilter) TaskFilter(userId uuid.UUID, now time.Time) TaskFilter {
+	filter := TaskFilter{
+		UserId:    userId,
+		Completed: f.Completed,
+		Blocked:   f.Blocked,
+		ListId:    f.ListId,
+		StatusId:  f.StatusId,
+		TagsAll:   f.TagsAll,
+		TagsAny:   f.TagsAny,
+		Overdue:   f.Overdue,
+		OverdueAt: now,
+		Query:     f.Query,
+	}
+
+	if f.CreatedWithinDays != nil {
+		createdFrom := now.AddDate(0, 0, -*f.CreatedWithinDays)
+		filter.CreatedFrom = &createdFrom
+	}
+
+	return filter
+}
+
+func (f SmartListFilter) Value() (driver.Value, error) {
+	data, err := json.Marshal(f)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+func (f *SmartListFilter) Scan(value interface{}) error {
+	switch data := value.(type) {
+	case []byte:
+		return json.Unmarshal(data, f)
+	case string:
+		return json.Unmarshal([]byte(data), f)
+	}
+
+	return errors.New("unsupported smart list filter value")
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Status - этап рабочего процесса задачи (колонка доски).
+// Статусы без ListId являются статусами пользователя по умолчанию.
+type Status struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primary_key"`
+	UserId    uuid.UUID
+	ListId    *uuid.UUID
+	Name      string
+	Position  int
+	IsDone    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -7,11 +7,48 @@ import (
 )
 
 type Task struct {
-	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primary_key"`
-	UserId      uuid.UUID
-	Description string
-	IsCompleted *bool `gorm:"default:false"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	ID              uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primary_key"`
+	UserId          uuid.UUID
+	WorkspaceId     uuid.UUID
+	ListId          *uuid.UUID
+	AssigneeId      *uuid.UUID
+	StatusId        *uuid.UUID
+	Description     string
+	IsCompleted     *bool `gorm:"default:false"`
+	EstimateMinutes *int
+	DueAt           *time.Time
+	IsBlocked       bool  `gorm:"->;-:migration"`
+	Version         int   `gorm:"default:1"`
+	ChangeSeq       int64 `gorm:"->"`
+	CreatedSeq      int64 `gorm:"->"`
+	ChangeXid       int64 `gorm:"->"`
+	CreatedXid      int64 `gorm:"->"`
+	CompletedAt     *time.Time
+	ArchivedAt      *time.Time
+	UnarchivedAt    *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
+}
+
+// SyncPosition - место изменения задачи в журнале синхронизации: номер транзакции и номер изменения.
+// Изменения упорядочены сначала по транзакции, затем по номеру изменения.
+type SyncPosition struct {
+	Xid int64
+	Seq int64
+}
+
+// Less проверяет, что позиция p находится в журнале раньше позиции other
+func (p SyncPosition) Less(other SyncPosition) bool {
+	return p.Xid < other.Xid || p.Xid == other.Xid && p.Seq < other.Seq
+}
+
+// ChangePosition возвращает позицию последнего изменения задачи
+func (t Task) ChangePosition() SyncPosition {
+	return SyncPosition{Xid: t.ChangeXid, Seq: t.ChangeSeq}
+}
+
+// CreatePosition возвращает позицию создания задачи
+func (t Task) CreatePosition() SyncPosition {
+	return SyncPosition{Xid: t.CreatedXid, Seq: t.CreatedSeq}
 }
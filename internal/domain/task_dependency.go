@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type TaskDependency struct {
+	TaskId      uuid.UUID `gorm:"type:uuid;primary_key"`
+	BlockedById uuid.UUID `gorm:"type:uuid;primary_key"`
+	CreatedAt   time.Time
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Типы событий задач, на которые можно подписать вебхук
+const (
+	TaskEventCreated    = "task.created"
+	TaskEventUpdated    = "task.updated"
+	TaskEventCompleted  = "task.completed"
+	TaskEventReopened   = "task.reopened"
+	TaskEventAssigned   = "task.assigned"
+	TaskEventDeleted    = "task.deleted"
+	TaskEventRestored   = "task.restored"
+	TaskEventUnarchived = "task.unarchived"
+)
+
+var TaskEventTypes = []string{
+	TaskEventCreated,
+	TaskEventUpdated,
+	TaskEventCompleted,
+	TaskEventReopened,
+	TaskEventAssigned,
+	TaskEventDeleted,
+	TaskEventRestored,
+	TaskEventUnarchived,
+}
+
+// TaskEvent - событие изменения задачи. Changes содержит поля, изменившиеся в ревизии Revision.
+// UserId - автор задачи, ListId - список, в котором задача находится после изменения.
+type TaskEvent struct {
+	ID         uuid.UUID    `json:"id"`
+	Type       string       `json:"event"`
+	TaskId     uuid.UUID    `json:"task_id"`
+	UserId     uuid.UUID    `json:"user_id"`
+	ListId     *uuid.UUID   `json:"list_id"`
+	Revision   int          `json:"revision"`
+	ActorId    *uuid.UUID   `json:"actor_id"`
+	Changes    []TaskChange `json:"changes"`
+	OccurredAt time.Time    `json:"occurred_at"`
+}
+
+func IsTaskEventType(eventType string) bool {
+	for _, t := range TaskEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Поля задачи, изменения которых сохраняются в истории
+const (
+	TaskFieldDescription     = "description"
+	TaskFieldIsCompleted     = "is_completed"
+	TaskFieldStatusId        = "status_id"
+	TaskFieldListId          = "list_id"
+	TaskFieldEstimateMinutes = "estimate_minutes"
+	TaskFieldAssigneeId      = "assignee_id"
+	TaskFieldDueAt           = "due_at"
+	TaskFieldDeleted         = "deleted"
+	TaskFieldArchived        = "archived"
+	// TaskFieldPurged отмечает окончательное удаление задачи. История задачи удаляется вместе с ней,
+	// поэтому изменение остается только в событии task.deleted.
+	TaskFieldPurged = "purged"
+)
+
+// TaskHistory - изменение одного поля задачи. Revision совпадает с версией задачи после изменения,
+// изменения нескольких полей одной операцией имеют общую ревизию. Undone отмечает отмененные
+// операции и сами отмены, чтобы они не попадали в стек отмены.
+type TaskHistory struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primary_key"`
+	TaskId    uuid.UUID
+	Revision  int
+	ActorId   *uuid.UUID
+	Field     string
+	OldValue  *string
+	NewValue  *string
+	Undone    bool
+	CreatedAt time.Time
+}
+
+func (TaskHistory) TableName() string {
+	return "task_history"
+}
+
+type TaskChange struct {
+	Field    string  `json:"field"`
+	OldValue *string `json:"old_value"`
+	NewValue *string `json:"new_value"`
+}
+
+// TaskRevision - изменения задачи, сделанные одной операцией
+type TaskRevision struct {
+	Revision  int
+	ActorId   *uuid.UUID
+	CreatedAt time.Time
+	Changes   []TaskChange
+}
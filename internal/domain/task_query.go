@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Поля, по которым допускается сортировка списка задач
+const (
+	TaskSortCreatedAt   = "created_at"
+	TaskSortUpdatedAt   = "updated_at"
+	TaskSortDescription = "description"
+)
+
+// TaskFilter - условия отбора задач пользователя. Overdue отбирает незавершенные задачи со сроком раньше OverdueAt
+// (или, если false, все остальные).
+type TaskFilter struct {
+	UserId      uuid.UUID
+	Completed   *bool
+	Blocked     *bool
+	ListId      *uuid.UUID
+	StatusId    *uuid.UUID
+	TagsAll     []string
+	TagsAny     []string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Overdue     *bool
+	OverdueAt   time.Time
+	Query       string
+}
+
+type TaskSort struct {
+	Field string
+	Desc  bool
+}
+
+// TaskCursor - позиция последней выданной задачи для постраничной выборки по ключу (значение поля сортировки и id)
+type TaskCursor struct {
+	Value string
+	Id    uuid.UUID
+}
+
+type TaskCounts struct {
+	Total     int64
+	Completed int64
+}
+
+type TaskPage struct {
+	Tasks      []Task
+	NextCursor string
+	Counts     TaskCounts
+}
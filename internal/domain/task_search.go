@@ -0,0 +1,8 @@
+package domain
+
+// TaskSearchResult - задача, найденная полнотекстовым поиском, с релевантностью и фрагментом описания
+type TaskSearchResult struct {
+	Task    `gorm:"embedded"`
+	Rank    float64
+	Snippet string
+}
@@ -0,0 +1,10 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+type TaskTag struct {
+	TaskId uuid.UUID `gorm:"type:uuid;primary_key"`
+	Name   string    `gorm:"primary_key"`
+}
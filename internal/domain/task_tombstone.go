@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// TaskTombstone - отметка для синхронизации о том, что задача TaskId больше не доступна пользователю UserId:
+// задача окончательно удалена, перенесена в недоступный ему список или он исключен из списка задачи
+type TaskTombstone struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primary_key"`
+	TaskId      uuid.UUID
+	UserId      uuid.UUID
+	WorkspaceId *uuid.UUID
+	ChangeXid   int64 `gorm:"->"`
+	ChangeSeq   int64 `gorm:"->"`
+	CreatedAt   time.Time
+}
+
+// ChangePosition возвращает позицию отметки в журнале синхронизации
+func (t TaskTombstone) ChangePosition() SyncPosition {
+	return SyncPosition{Xid: t.ChangeXid, Seq: t.ChangeSeq}
+}
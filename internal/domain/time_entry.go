@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// TimeEntry - интервал работы над задачей. Запись без StoppedAt - запущенный таймер.
+type TimeEntry struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primary_key"`
+	TaskId    uuid.UUID
+	UserId    uuid.UUID
+	StartedAt time.Time
+	StoppedAt *time.Time
+	Note      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Duration возвращает длительность интервала; для запущенного таймера - по момент now
+func (e *TimeEntry) Duration(now time.Time) time.Duration {
+	if e.StoppedAt == nil {
+		return now.Sub(e.StartedAt)
+	}
+
+	return e.StoppedAt.Sub(e.StartedAt)
+}
+
+type TimeReportRow struct {
+	Key     string
+	Label   string
+	Seconds int64
+}
@@ -6,13 +6,43 @@ import (
 	"time"
 )
 
+const (
+	UserRoleUser  = "user"
+	UserRoleAdmin = "admin"
+)
+
 type User struct {
-	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primary_key"`
-	Name      string
-	Email     string `gorm:"uniqueIndex"`
-	Password  string
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
-	Tasks     []Task         `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	ID       uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primary_key"`
+	Name     string
+	Email    string `gorm:"uniqueIndex"`
+	Password string
+	// Role - роль пользователя в приложении: user или admin
+	Role string `gorm:"default:user"`
+	// AutoArchiveDays - через сколько дней после завершения задачи архивируются автоматически, nil отключает архивацию
+	AutoArchiveDays *int
+	// DisabledAt - время блокировки учетной записи администратором, nil - учетная запись активна
+	DisabledAt *time.Time
+	// PasswordResetRequired - пользователь не может работать, пока не задаст новый пароль
+	PasswordResetRequired bool
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	DeletedAt             gorm.DeletedAt `gorm:"index"`
+	Tasks                 []Task         `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+}
+
+func (u *User) IsAdmin() bool {
+	return u.Role == UserRoleAdmin
+}
+
+func (u *User) IsDisabled() bool {
+	return u.DisabledAt != nil
+}
+
+// UserTaskCounts - количество задач, созданных пользователем, включая архивные и удаленные в корзину
+type UserTaskCounts struct {
+	// Total - задачи вне корзины, в том числе архивные
+	Total     int64
+	Completed int64
+	Archived  int64
+	Trashed   int64
 }
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Состояния доставки события вебхуку
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Webhook - адрес, на который отправляются события задач, доступных пользователю UserId.
+// Запросы подписываются ключом Secret.
+type Webhook struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primary_key"`
+	UserId    uuid.UUID
+	URL       string
+	Secret    string
+	Events    WebhookEvents `gorm:"type:jsonb"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// WebhookEvents - типы событий, на которые подписан вебхук
+type WebhookEvents []string
+
+func (e WebhookEvents) Value() (driver.Value, error) {
+	data, err := json.Marshal(e)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+func (e *WebhookEvents) Scan(value interface{}) error {
+	switch data := value.(type) {
+	case []byte:
+		return json.Unmarshal(data, e)
+	case string:
+		return json.Unmarshal([]byte(data), e)
+	}
+
+	return errors.New("unsupported webhook events value")
+}
+
+// WebhookDelivery - доставка события вебхуку. Неудачные попытки повторяются в NextAttemptAt,
+// после исчерпания попыток доставка получает состояние failed.
+type WebhookDelivery struct {
+	ID             uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primary_key"`
+	WebhookId      uuid.UUID
+	Event          string
+	Payload        string `gorm:"type:jsonb"`
+	Status         string `gorm:"default:pending"`
+	Attempts       int
+	NextAttemptAt  *time.Time
+	ResponseStatus *int
+	ResponseBody   string
+	Error          string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
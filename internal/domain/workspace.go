@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Роли пользователей в рабочем пространстве. Владельцем считается создатель пространства.
+const (
+	WorkspaceRoleOwner  = "owner"
+	WorkspaceRoleAdmin  = "admin"
+	WorkspaceRoleMember = "member"
+)
+
+var workspaceRoleRanks = map[string]int{
+	WorkspaceRoleMember: 1,
+	WorkspaceRoleAdmin:  2,
+	WorkspaceRoleOwner:  3,
+}
+
+// Workspace - рабочее пространство, которому принадлежат списки и задачи его участников.
+// У каждого пользователя есть личное пространство, в нем PersonalUserId указывает на пользователя.
+type Workspace struct {
+	ID             uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primary_key"`
+	Name           string
+	PersonalUserId *uuid.UUID
+	Role           string `gorm:"->;-:migration"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type WorkspaceMember struct {
+	WorkspaceId uuid.UUID `gorm:"type:uuid;primary_key"`
+	UserId      uuid.UUID `gorm:"type:uuid;primary_key"`
+	Role        string
+	Name        string `gorm:"->;-:migration"`
+	Email       string `gorm:"->;-:migration"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// IsPersonal сообщает, является ли пространство личным пространством пользователя
+func (w *Workspace) IsPersonal() bool {
+	return w.PersonalUserId != nil
+}
+
+// WorkspaceRoleAllows сообщает, дает ли роль role права роли required. Пустая роль не дает никаких прав.
+func WorkspaceRoleAllows(role, required string) bool {
+	rank, ok := workspaceRoleRanks[role]
+
+	return ok && rank >= workspaceRoleRanks[required]
+}
+
+// IsWorkspaceMemberRole сообщает, можно ли выдать роль role участнику рабочего пространства
+func IsWorkspaceMemberRole(role string) bool {
+	return role == WorkspaceRoleMember || role == WorkspaceRoleAdmin
+}
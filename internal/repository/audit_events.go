@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+)
+
+type AuditEventRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditEventRepository(db *gorm.DB) *AuditEventRepository {
+	return &AuditEventRepository{db}
+}
+
+func (repo *AuditEventRepository) Create(event *domain.AuditEvent) error {
+	return repo.db.Create(event).Error
+}
+
+// Search возвращает страницу записей журнала, удовлетворяющих фильтру, начиная с последних
+func (repo *AuditEventRepository) Search(filter domain.AuditFilter, limit, offset int) (*[]domain.AuditEvent, error) {
+	var events []domain.AuditEvent
+
+	query := repo.db.Model(&domain.AuditEvent{})
+
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+
+	if filter.ActorId != nil {
+		query = query.Where("actor_id = ?", *filter.ActorId)
+	}
+
+	if filter.TargetId != nil {
+		query = query.Where("target_id = ?", *filter.TargetId)
+	}
+
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	result := query.
+		Order("created_at desc, id desc").
+		Limit(limit).
+		Offset(offset).
+		Find(&events)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &events, nil
+}
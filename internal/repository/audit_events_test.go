@@ -0,0 +1,70 @@
+package repository_test
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/helpers"
+	"testing"
+	"time"
+)
+
+func TestAuditEventRepositoryCreate_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	actorId, eventId := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"audit_events\"").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(eventId))
+	mock.ExpectCommit()
+
+	auditEventRepository := repository.NewAuditEventRepository(mockedDatabase)
+
+	event := &domain.AuditEvent{Action: domain.AuditLogin, ActorId: &actorId, IP: "192.0.2.1"}
+
+	require.NoError(t, auditEventRepository.Create(event))
+	require.Equal(t, eventId, event.ID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditEventRepositorySearch_Filtered(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	actorId, targetId := twoUuids(t)
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	mock.ExpectQuery(`SELECT \* FROM "audit_events" WHERE action = \$1 AND actor_id = \$2 AND target_id = \$3 AND created_at >= \$4 AND created_at < \$5 ORDER BY created_at desc, id desc LIMIT \$6 OFFSET \$7`).
+		WithArgs(domain.AuditLoginFailed, actorId, targetId, from, to, 10, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "action"}).AddRow(targetId, domain.AuditLoginFailed))
+
+	auditEventRepository := repository.NewAuditEventRepository(mockedDatabase)
+
+	events, err := auditEventRepository.Search(domain.AuditFilter{
+		Action:   domain.AuditLoginFailed,
+		ActorId:  &actorId,
+		TargetId: &targetId,
+		From:     &from,
+		To:       &to,
+	}, 10, 20)
+
+	require.NoError(t, err)
+	require.Len(t, *events, 1)
+}
+
+func TestAuditEventRepositorySearch_WithoutFilter(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	mock.ExpectQuery(`SELECT \* FROM "audit_events" ORDER BY created_at desc, id desc LIMIT \$1$`).
+		WithArgs(100).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	auditEventRepository := repository.NewAuditEventRepository(mockedDatabase)
+
+	events, err := auditEventRepository.Search(domain.AuditFilter{}, 100, 0)
+
+	require.NoError(t, err)
+	require.Empty(t, *events)
+}
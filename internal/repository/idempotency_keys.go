@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"poymanov/todo/internal/domain"
+	"time"
+)
+
+type IdempotencyKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyKeyRepository(db *gorm.DB) *IdempotencyKeyRepository {
+	return &IdempotencyKeyRepository{db}
+}
+
+// Create сохраняет ключ, если пользователь еще не использовал его. Возвращает false, если ключ уже существует.
+func (repo *IdempotencyKeyRepository) Create(idempotencyKey *domain.IdempotencyKey) (bool, error) {
+	result := repo.db.Clauses(clause.OnConflict{DoNothing: true}).Create(idempotencyKey)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (repo *IdempotencyKeyRepository) Find(userId uuid.UUID, key string) (*domain.IdempotencyKey, error) {
+	var idempotencyKey domain.IdempotencyKey
+	result := repo.db.First(&idempotencyKey, "user_id = ? and key = ?", userId, key)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &idempotencyKey, nil
+}
+
+// SaveResponse сохраняет ответ на запрос с ключом key
+func (repo *IdempotencyKeyRepository) SaveResponse(idempotencyKey *domain.IdempotencyKey) error {
+	result := repo.db.
+		Model(&domain.IdempotencyKey{}).
+		Where("user_id = ? and key = ?", idempotencyKey.UserId, idempotencyKey.Key).
+		Select("status_code", "headers", "body").
+		Updates(idempotencyKey)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (repo *IdempotencyKeyRepository) Delete(userId uuid.UUID, key string) error {
+	result := repo.db.Delete(&domain.IdempotencyKey{}, "user_id = ? and key = ?", userId, key)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+// DeleteCreatedBefore удаляет ключи, созданные раньше before, и возвращает их количество
+func (repo *IdempotencyKeyRepository) DeleteCreatedBefore(before time.Time) (int64, error) {
+	result := repo.db.Where("created_at < ?", before).Delete(&domain.IdempotencyKey{})
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
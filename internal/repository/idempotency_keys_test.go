@@ -0,0 +1,112 @@
+package repository_test
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/helpers"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyKeyRepositoryCreate_Created(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("ON CONFLICT DO NOTHING").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	idempotencyKeyRepository := repository.NewIdempotencyKeyRepository(mockedDatabase)
+
+	created, err := idempotencyKeyRepository.Create(&domain.IdempotencyKey{UserId: userId, Key: "key", Fingerprint: "abc"})
+
+	require.NoError(t, err)
+	require.True(t, created)
+}
+
+func TestIdempotencyKeyRepositoryCreate_AlreadyExists(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("ON CONFLICT DO NOTHING").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	idempotencyKeyRepository := repository.NewIdempotencyKeyRepository(mockedDatabase)
+
+	created, err := idempotencyKeyRepository.Create(&domain.IdempotencyKey{UserId: userId, Key: "key", Fingerprint: "abc"})
+
+	require.NoError(t, err)
+	require.False(t, created)
+}
+
+func TestIdempotencyKeyRepositoryFind_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, _ := twoUuids(t)
+
+	mock.ExpectQuery("SELECT").
+		WithArgs(userId, "key", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "key", "status_code", "headers", "body"}).
+			AddRow(userId, "key", 201, `{"Location":"/tasks/1"}`, []byte(`{}`)))
+
+	idempotencyKeyRepository := repository.NewIdempotencyKeyRepository(mockedDatabase)
+
+	idempotencyKey, err := idempotencyKeyRepository.Find(userId, "key")
+
+	require.NoError(t, err)
+	require.Equal(t, 201, idempotencyKey.StatusCode)
+	require.Equal(t, "/tasks/1", idempotencyKey.Headers["Location"])
+}
+
+func TestIdempotencyKeyRepositoryFind_NotFound(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, _ := twoUuids(t)
+
+	mock.ExpectQuery("SELECT").WillReturnError(gorm.ErrRecordNotFound)
+
+	idempotencyKeyRepository := repository.NewIdempotencyKeyRepository(mockedDatabase)
+
+	idempotencyKey, err := idempotencyKeyRepository.Find(userId, "key")
+
+	require.Error(t, err)
+	require.Nil(t, idempotencyKey)
+}
+
+func TestIdempotencyKeyRepositorySaveResponse_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	idempotencyKeyRepository := repository.NewIdempotencyKeyRepository(mockedDatabase)
+
+	require.NoError(t, idempotencyKeyRepository.SaveResponse(&domain.IdempotencyKey{
+		UserId: userId, Key: "key", StatusCode: 201, Headers: map[string]string{}, Body: []byte(`{}`),
+	}))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdempotencyKeyRepositoryDeleteCreatedBefore_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE").WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	idempotencyKeyRepository := repository.NewIdempotencyKeyRepository(mockedDatabase)
+
+	deleted, err := idempotencyKeyRepository.DeleteCreatedBefore(time.Now())
+
+	require.NoError(t, err)
+	require.Equal(t, int64(3), deleted)
+}
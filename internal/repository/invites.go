@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+	"time"
+)
+
+type InviteRepository struct {
+	db *gorm.DB
+}
+
+func NewInviteRepository(db *gorm.DB) *InviteRepository {
+	return &InviteRepository{db}
+}
+
+func (repo *InviteRepository) Create(invite *domain.Invite) error {
+	return repo.db.Create(invite).Error
+}
+
+// FindById возвращает приглашение вместе с именем пригласившего и названием списка или пространства
+func (repo *InviteRepository) FindById(id uuid.UUID) (*domain.Invite, error) {
+	var invite domain.Invite
+
+	result := repo.db.
+		Table("invites").
+		Select("invites.*, users.name AS inviter_name, COALESCE(lists.name, workspaces.name) AS target_name").
+		Joins("JOIN users ON users.id = invites.user_id").
+		Joins("JOIN workspaces ON workspaces.id = invites.workspace_id").
+		Joins("LEFT JOIN lists ON lists.id = invites.list_id").
+		Where("invites.id = ?", id).
+		Take(&invite)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &invite, nil
+}
+
+// MarkUsed отмечает использование приглашения. Возвращает false, если одноразовое приглашение уже использовано.
+func (repo *InviteRepository) MarkUsed(id uuid.UUID, usedAt time.Time) (bool, error) {
+	result := repo.db.
+		Model(&domain.Invite{}).
+		Where("id = ? AND (single_use = false OR used_at IS NULL)", id).
+		Update("used_at", usedAt)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,99 @@
+package repository_test
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/helpers"
+	"testing"
+	"time"
+)
+
+func TestInviteRepositoryCreate_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	inviteId, workspaceId := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"invites\"").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(inviteId))
+	mock.ExpectCommit()
+
+	inviteRepository := repository.NewInviteRepository(mockedDatabase)
+
+	invite := &domain.Invite{WorkspaceId: workspaceId, Role: domain.WorkspaceRoleMember, ExpiresAt: time.Now()}
+
+	require.NoError(t, inviteRepository.Create(invite))
+	require.Equal(t, inviteId, invite.ID)
+}
+
+func TestInviteRepositoryFindById_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	inviteId, workspaceId := twoUuids(t)
+
+	mock.ExpectQuery("COALESCE\\(lists.name, workspaces.name\\) AS target_name").
+		WithArgs(inviteId, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "workspace_id", "inviter_name", "target_name"}).
+			AddRow(inviteId, workspaceId, "John", "Team"))
+
+	inviteRepository := repository.NewInviteRepository(mockedDatabase)
+
+	invite, err := inviteRepository.FindById(inviteId)
+
+	require.NoError(t, err)
+	require.Equal(t, "John", invite.InviterName)
+	require.Equal(t, "Team", invite.TargetName)
+}
+
+func TestInviteRepositoryFindById_NotFound(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	inviteId, _ := twoUuids(t)
+
+	mock.ExpectQuery("FROM \"invites\"").WillReturnError(gorm.ErrRecordNotFound)
+
+	inviteRepository := repository.NewInviteRepository(mockedDatabase)
+
+	invite, err := inviteRepository.FindById(inviteId)
+
+	require.Nil(t, invite)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestInviteRepositoryMarkUsed_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	inviteId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"invites\" SET \"used_at\"=\\$1 WHERE id = \\$2 AND \\(single_use = false OR used_at IS NULL\\)").
+		WithArgs(sqlmock.AnyArg(), inviteId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	inviteRepository := repository.NewInviteRepository(mockedDatabase)
+
+	used, err := inviteRepository.MarkUsed(inviteId, time.Now())
+
+	require.NoError(t, err)
+	require.True(t, used)
+}
+
+func TestInviteRepositoryMarkUsed_AlreadyUsed(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	inviteId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"invites\"").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	inviteRepository := repository.NewInviteRepository(mockedDatabase)
+
+	used, err := inviteRepository.MarkUsed(inviteId, time.Now())
+
+	require.NoError(t, err)
+	require.False(t, used)
+}
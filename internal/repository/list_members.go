@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+)
+
+type ListMemberRepository struct {
+	db *gorm.DB
+}
+
+func NewListMemberRepository(db *gorm.DB) *ListMemberRepository {
+	return &ListMemberRepository{db}
+}
+
+// NewWorkspaceListMemberRepository создает репозиторий, которому доступны только участники списков рабочего пространства workspaceId
+func NewWorkspaceListMemberRepository(db *gorm.DB, workspaceId uuid.UUID) *ListMemberRepository {
+	return &ListMemberRepository{inWorkspace(db, "list_members.list_id IN ("+workspaceListIds+")", workspaceId)}
+}
+
+func (repo *ListMemberRepository) Create(member *domain.ListMember) error {
+	return repo.db.Create(member).Error
+}
+
+func (repo *ListMemberRepository) Find(listId, userId uuid.UUID) (*domain.ListMember, error) {
+	var member domain.ListMember
+	result := repo.db.First(&member, "list_id = ? and user_id = ?", listId, userId)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &member, nil
+}
+
+// GetByListId возвращает участников списка с именами и адресами электронной почты в порядке добавления
+func (repo *ListMemberRepository) GetByListId(listId uuid.UUID) *[]domain.ListMember {
+	var members []domain.ListMember
+
+	repo.db.
+		Table("list_members").
+		Select("list_members.*, users.name, users.email").
+		Joins("JOIN users ON users.id = list_members.user_id").
+		Where("list_members.list_id = ?", listId).
+		Order("list_members.created_at asc").
+		Scan(&members)
+
+	return &members
+}
+
+func (repo *ListMemberRepository) UpdateRole(listId, userId uuid.UUID, role string) error {
+	result := repo.db.
+		Model(&domain.ListMember{}).
+		Where("list_id = ? and user_id = ?", listId, userId).
+		Update("role", role)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (repo *ListMemberRepository) Delete(listId, userId uuid.UUID) error {
+	result := repo.db.Delete(&domain.ListMember{}, "list_id = ? and user_id = ?", listId, userId)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
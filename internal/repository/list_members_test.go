@@ -0,0 +1,133 @@
+package repository_test
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/helpers"
+	"testing"
+)
+
+func TestListMemberRepositoryCreate_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	listId, userId := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO \"list_members\"").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	listMemberRepository := repository.NewListMemberRepository(mockedDatabase)
+
+	err := listMemberRepository.Create(&domain.ListMember{ListId: listId, UserId: userId, Role: domain.ListRoleEditor})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListMemberRepositoryFind_NotFound(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	listId, userId := twoUuids(t)
+
+	mock.ExpectQuery("SELECT (.+) FROM \"list_members\"").
+		WithArgs(listId, userId, 1).
+		WillReturnError(errors.New("record not found"))
+
+	listMemberRepository := repository.NewListMemberRepository(mockedDatabase)
+
+	member, err := listMemberRepository.Find(listId, userId)
+
+	require.Error(t, err)
+	require.Nil(t, member)
+}
+
+func TestListMemberRepositoryFind_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	listId, userId := twoUuids(t)
+
+	mock.ExpectQuery("SELECT (.+) FROM \"list_members\"").
+		WithArgs(listId, userId, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"list_id", "user_id", "role"}).AddRow(listId, userId, domain.ListRoleViewer))
+
+	listMemberRepository := repository.NewListMemberRepository(mockedDatabase)
+
+	member, err := listMemberRepository.Find(listId, userId)
+
+	require.NoError(t, err)
+	require.Equal(t, domain.ListRoleViewer, member.Role)
+}
+
+func TestListMemberRepositoryGetByListId_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	listId, userId := twoUuids(t)
+
+	mock.ExpectQuery("JOIN users ON users.id = list_members.user_id").
+		WithArgs(listId).
+		WillReturnRows(sqlmock.NewRows([]string{"list_id", "user_id", "role", "name", "email"}).
+			AddRow(listId, userId, domain.ListRoleEditor, "John", "john@example.com"))
+
+	listMemberRepository := repository.NewListMemberRepository(mockedDatabase)
+
+	members := listMemberRepository.GetByListId(listId)
+
+	require.Len(t, *members, 1)
+	require.Equal(t, "john@example.com", (*members)[0].Email)
+}
+
+func TestListMemberRepositoryUpdateRole_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	listId, userId := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"list_members\" SET \"role\"").
+		WithArgs(domain.ListRoleAdmin, sqlmock.AnyArg(), listId, userId).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	listMemberRepository := repository.NewListMemberRepository(mockedDatabase)
+
+	require.NoError(t, listMemberRepository.UpdateRole(listId, userId, domain.ListRoleAdmin))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListMemberRepositoryDelete_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	listId, userId := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM \"list_members\"").
+		WithArgs(listId, userId).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	listMemberRepository := repository.NewListMemberRepository(mockedDatabase)
+
+	require.NoError(t, listMemberRepository.Delete(listId, userId))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListMemberRepositoryWorkspaceFind_OtherWorkspace(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, listId := twoUuids(t)
+	_, userId := twoUuids(t)
+
+	mock.ExpectQuery(`FROM "list_members" WHERE list_members.list_id IN \(SELECT id FROM lists WHERE lists.workspace_id = \$1\) AND \(list_id = \$2 and user_id = \$3\)`).
+		WithArgs(workspaceId, listId, userId, 1).
+		WillReturnError(errors.New("record not found"))
+
+	listMemberRepository := repository.NewWorkspaceListMemberRepository(mockedDatabase, workspaceId)
+
+	member, err := listMemberRepository.Find(listId, userId)
+
+	require.Nil(t, member)
+	require.Error(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
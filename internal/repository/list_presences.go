@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"poymanov/todo/internal/domain"
+	"time"
+)
+
+// ListPresenceChannel - канал NOTIFY, в который передается идентификатор списка, чей состав зрителей изменился
+const ListPresenceChannel = "list_presence"
+
+type ListPresenceRepository struct {
+	db *gorm.DB
+}
+
+func NewListPresenceRepository(db *gorm.DB) *ListPresenceRepository {
+	return &ListPresenceRepository{db}
+}
+
+// NewWorkspaceListPresenceRepository создает репозиторий, которому доступны только отметки присутствия
+// в списках рабочего пространства workspaceId
+func NewWorkspaceListPresenceRepository(db *gorm.DB, workspaceId uuid.UUID) *ListPresenceRepository {
+	return &ListPresenceRepository{inWorkspace(db, "list_presences.list_id IN ("+workspaceListIds+")", workspaceId)}
+}
+
+// Upsert добавляет отметку присутствия или продлевает существующую
+func (repo *ListPresenceRepository) Upsert(presence *domain.ListPresence) error {
+	return repo.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "connection_id"}, {Name: "list_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"expires_at"}),
+	}).Create(presence).Error
+}
+
+func (repo *ListPresenceRepository) Delete(connectionId, listId uuid.UUID) error {
+	return repo.db.Where("connection_id = ? AND list_id = ?", connectionId, listId).Delete(&domain.ListPresence{}).Error
+}
+
+// DeleteByConnectionId удаляет все отметки соединения и возвращает списки, в которых они были
+func (repo *ListPresenceRepository) DeleteByConnectionId(connectionId uuid.UUID) ([]uuid.UUID, error) {
+	var listIds []uuid.UUID
+
+	result := repo.db.Raw("DELETE FROM list_presences WHERE connection_id = ? RETURNING list_id", connectionId).Scan(&listIds)
+
+	return listIds, result.Error
+}
+
+// Touch продлевает до expiresAt все отметки соединения
+func (repo *ListPresenceRepository) Touch(connectionId uuid.UUID, expiresAt time.Time) error {
+	return repo.db.Model(&domain.ListPresence{}).Where("connection_id = ?", connectionId).Update("expires_at", expiresAt).Error
+}
+
+// GetUsers возвращает пользователей, просматривающих список на момент now, в алфавитном порядке
+func (repo *ListPresenceRepository) GetUsers(listId uuid.UUID, now time.Time) *[]domain.PresenceUser {
+	var users []domain.PresenceUser
+
+	repo.db.
+		Table("list_presences").
+		Distinct("users.id AS user_id", "users.name").
+		Joins("JOIN users ON users.id = list_presences.user_id").
+		Where("list_presences.list_id = ? AND list_presences.expires_at > ?", listId, now).
+		Order("users.name").
+		Scan(&users)
+
+	return &users
+}
+
+// PurgeExpired удаляет отметки, истекшие к моменту before, и возвращает списки, в которых они были
+func (repo *ListPresenceRepository) PurgeExpired(before time.Time) ([]uuid.UUID, error) {
+	var listIds []uuid.UUID
+
+	result := repo.db.Raw("DELETE FROM list_presences WHERE expires_at <= ? RETURNING list_id", before).Scan(&listIds)
+
+	return listIds, result.Error
+}
+
+// Notify сообщает экземплярам приложения, слушающим ListPresenceChannel, об изменении зрителей списка listId
+func (repo *ListPresenceRepository) Notify(listId uuid.UUID) error {
+	return repo.db.Exec("SELECT pg_notify(?, ?)", ListPresenceChannel, listId.String()).Error
+}
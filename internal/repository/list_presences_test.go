@@ -0,0 +1,118 @@
+package repository_test
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/helpers"
+	"testing"
+	"time"
+)
+
+func TestListPresenceRepositoryUpsert_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	connectionId, listId := twoUuids(t)
+	userId, _ := twoUuids(t)
+	expiresAt := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "list_presences" \("connection_id","list_id","user_id","expires_at"\) VALUES \(\$1,\$2,\$3,\$4\) `+
+		`ON CONFLICT \("connection_id","list_id"\) DO UPDATE SET "expires_at"="excluded"."expires_at"`).
+		WithArgs(connectionId, listId, userId, expiresAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	listPresenceRepository := repository.NewListPresenceRepository(mockedDatabase)
+
+	err := listPresenceRepository.Upsert(&domain.ListPresence{ConnectionId: connectionId, ListId: listId, UserId: userId, ExpiresAt: expiresAt})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListPresenceRepositoryDeleteByConnectionId_ReturnsLists(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	connectionId, listId := twoUuids(t)
+
+	mock.ExpectQuery(`DELETE FROM list_presences WHERE connection_id = \$1 RETURNING list_id`).
+		WithArgs(connectionId).
+		WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(listId))
+
+	listPresenceRepository := repository.NewListPresenceRepository(mockedDatabase)
+
+	listIds, err := listPresenceRepository.DeleteByConnectionId(connectionId)
+
+	require.NoError(t, err)
+	require.Len(t, listIds, 1)
+	require.Equal(t, listId, listIds[0])
+}
+
+func TestListPresenceRepositoryGetUsers_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	listId, userId := twoUuids(t)
+	now := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT DISTINCT users.id AS user_id,users.name FROM "list_presences" JOIN users ON users.id = list_presences.user_id `+
+		`WHERE list_presences.list_id = \$1 AND list_presences.expires_at > \$2 ORDER BY users.name`).
+		WithArgs(listId, now).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "name"}).AddRow(userId, "Anna"))
+
+	listPresenceRepository := repository.NewListPresenceRepository(mockedDatabase)
+
+	users := listPresenceRepository.GetUsers(listId, now)
+
+	require.Equal(t, []domain.PresenceUser{{UserId: userId, Name: "Anna"}}, *users)
+}
+
+func TestListPresenceRepositoryPurgeExpired_ReturnsLists(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	listId, _ := twoUuids(t)
+	before := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`DELETE FROM list_presences WHERE expires_at <= \$1 RETURNING list_id`).
+		WithArgs(before).
+		WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(listId).AddRow(listId))
+
+	listPresenceRepository := repository.NewListPresenceRepository(mockedDatabase)
+
+	listIds, err := listPresenceRepository.PurgeExpired(before)
+
+	require.NoError(t, err)
+	require.Len(t, listIds, 2)
+}
+
+func TestListPresenceRepositoryNotify_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	listId, _ := twoUuids(t)
+
+	mock.ExpectExec(`SELECT pg_notify\(\$1, \$2\)`).
+		WithArgs(repository.ListPresenceChannel, listId.String()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	listPresenceRepository := repository.NewListPresenceRepository(mockedDatabase)
+
+	require.NoError(t, listPresenceRepository.Notify(listId))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListPresenceRepositoryWorkspaceGetUsers_OtherWorkspace(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, listId := twoUuids(t)
+	now := time.Now()
+
+	mock.ExpectQuery(`WHERE list_presences.list_id IN \(SELECT id FROM lists WHERE lists.workspace_id = \$1\) AND \(list_presences.list_id = \$2`).
+		WithArgs(workspaceId, listId, now).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "name"}))
+
+	listPresenceRepository := repository.NewWorkspaceListPresenceRepository(mockedDatabase, workspaceId)
+
+	require.Empty(t, *listPresenceRepository.GetUsers(listId, now))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+)
+
+type ListRepository struct {
+	db          *gorm.DB
+	workspaceId *uuid.UUID
+}
+
+func NewListRepository(db *gorm.DB) *ListRepository {
+	return &ListRepository{db: db}
+}
+
+// NewWorkspaceListRepository создает репозиторий, которому доступны только списки рабочего пространства workspaceId
+func NewWorkspaceListRepository(db *gorm.DB, workspaceId uuid.UUID) *ListRepository {
+	return &ListRepository{
+		db:          db.Where("lists.workspace_id = ?", workspaceId).Session(&gorm.Session{}),
+		workspaceId: &workspaceId,
+	}
+}
+
+func (repo *ListRepository) Create(list *domain.List) (*domain.List, error) {
+	if repo.workspaceId != nil {
+		list.WorkspaceId = *repo.workspaceId
+	}
+
+	result := repo.db.Create(list)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return list, nil
+}
+
+func (repo *ListRepository) Delete(id uuid.UUID) error {
+	result := repo.db.Delete(&domain.List{}, id)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (repo *ListRepository) FindById(id uuid.UUID) (*domain.List, error) {
+	var list domain.List
+	result := repo.db.First(&list, "id = ?", id)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &list, nil
+}
+
+// GetAllByUserId возвращает собственные списки пользователя и списки, в которых он участник, вместе с его ролью
+func (repo *ListRepository) GetAllByUserId(id uuid.UUID) *[]domain.List {
+	var lists []domain.List
+
+	repo.db.
+		Table("lists").
+		Select("lists.*, CASE WHEN lists.user_id = ? THEN ? ELSE list_members.role END AS role", id, domain.ListRoleOwner).
+		Joins("LEFT JOIN list_members ON list_members.list_id = lists.id AND list_members.user_id = ?", id).
+		Where("lists.user_id = ? OR list_members.user_id IS NOT NULL", id).
+		Order("lists.created_at asc").
+		Scan(&lists)
+
+	return &lists
+}
+
+// GetUserIds возвращает идентификаторы владельца и участников списка id одним запросом
+func (repo *ListRepository) GetUserIds(id uuid.UUID) []uuid.UUID {
+	var userIds []uuid.UUID
+
+	repo.db.
+		Raw("? UNION SELECT user_id FROM list_members WHERE list_id IN (?)",
+			repo.db.Model(&domain.List{}).Select("lists.user_id").Where("lists.id = ?", id),
+			repo.db.Model(&domain.List{}).Select("lists.id").Where("lists.id = ?", id),
+		).
+		Scan(&userIds)
+
+	return userIds
+}
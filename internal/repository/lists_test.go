@@ -0,0 +1,190 @@
+package repository_test
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/helpers"
+	"testing"
+)
+
+func TestListRepositoryCreate_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	listUuid := faker.UUIDHyphenated()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(listUuid))
+	mock.ExpectCommit()
+
+	listRepository := repository.NewListRepository(mockedDatabase)
+
+	createdList, err := listRepository.Create(&domain.List{Name: faker.Word()})
+
+	require.NoError(t, err)
+	require.Equal(t, listUuid, createdList.ID.String())
+}
+
+func TestListRepositoryCreate_Failed(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT").WillReturnError(gorm.ErrInvalidValue)
+	mock.ExpectRollback()
+
+	listRepository := repository.NewListRepository(mockedDatabase)
+
+	createdList, err := listRepository.Create(&domain.List{Name: faker.Word()})
+
+	require.Nil(t, createdList)
+	require.Equal(t, gorm.ErrInvalidValue, err)
+}
+
+func TestListRepositoryDelete_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	listId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	listRepository := repository.NewListRepository(mockedDatabase)
+
+	require.NoError(t, listRepository.Delete(listId))
+}
+
+func TestListRepositoryFindById_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	listId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(listId))
+
+	listRepository := repository.NewListRepository(mockedDatabase)
+
+	list, err := listRepository.FindById(listId)
+
+	require.NoError(t, err)
+	require.Equal(t, listId, list.ID)
+}
+
+func TestListRepositoryFindById_Failed(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	listId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	mock.ExpectQuery("SELECT").WillReturnError(gorm.ErrRecordNotFound)
+
+	listRepository := repository.NewListRepository(mockedDatabase)
+
+	list, err := listRepository.FindById(listId)
+
+	require.Nil(t, list)
+	require.Equal(t, gorm.ErrRecordNotFound, err)
+}
+
+func TestListRepositoryGetAllByUserId_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	mock.ExpectQuery("LEFT JOIN list_members").
+		WithArgs(userId, domain.ListRoleOwner, userId, userId).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "role"}).AddRow(faker.UUIDHyphenated(), domain.ListRoleEditor))
+
+	listRepository := repository.NewListRepository(mockedDatabase)
+
+	lists := listRepository.GetAllByUserId(userId)
+
+	require.Len(t, *lists, 1)
+	require.Equal(t, domain.ListRoleEditor, (*lists)[0].Role)
+}
+
+func TestListRepositoryWorkspaceCreate_SetsWorkspace(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, listId := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"lists\"").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(listId))
+	mock.ExpectCommit()
+
+	listRepository := repository.NewWorkspaceListRepository(mockedDatabase, workspaceId)
+
+	createdList, err := listRepository.Create(&domain.List{Name: faker.Word()})
+
+	require.NoError(t, err)
+	require.Equal(t, workspaceId, createdList.WorkspaceId)
+}
+
+func TestListRepositoryWorkspaceFindById_OtherWorkspace(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, listId := twoUuids(t)
+
+	mock.ExpectQuery(`FROM "lists" WHERE lists.workspace_id = \$1 AND id = \$2`).
+		WithArgs(workspaceId, listId, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	listRepository := repository.NewWorkspaceListRepository(mockedDatabase, workspaceId)
+
+	list, err := listRepository.FindById(listId)
+
+	require.Nil(t, list)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListRepositoryWorkspaceGetAllByUserId_FiltersWorkspace(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, userId := twoUuids(t)
+
+	mock.ExpectQuery(`WHERE lists.workspace_id = \$4 AND`).
+		WithArgs(userId, domain.ListRoleOwner, userId, workspaceId, userId).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	listRepository := repository.NewWorkspaceListRepository(mockedDatabase, workspaceId)
+
+	require.Empty(t, *listRepository.GetAllByUserId(userId))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListRepositoryGetUserIds_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	listId, userId := twoUuids(t)
+
+	mock.ExpectQuery(`^SELECT lists.user_id FROM "lists" WHERE lists.id = \$1 UNION SELECT user_id FROM list_members WHERE list_id IN \(SELECT lists.id FROM "lists" WHERE lists.id = \$2\)$`).
+		WithArgs(listId, listId).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(userId))
+
+	listRepository := repository.NewListRepository(mockedDatabase)
+
+	require.Equal(t, []uuid.UUID{userId}, listRepository.GetUserIds(listId))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListRepositoryWorkspaceGetUserIds_FiltersWorkspace(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, listId := twoUuids(t)
+
+	mock.ExpectQuery(`WHERE lists.workspace_id = \$1 AND lists.id = \$2 UNION .+ WHERE lists.workspace_id = \$3 AND lists.id = \$4\)$`).
+		WithArgs(workspaceId, listId, workspaceId, listId).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+
+	listRepository := repository.NewWorkspaceListRepository(mockedDatabase, workspaceId)
+
+	require.Empty(t, listRepository.GetUserIds(listId))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
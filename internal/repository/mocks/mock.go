@@ -11,7 +11,9 @@ package mock_repository
 
 import (
 	domain "poymanov/todo/internal/domain"
+	repository "poymanov/todo/internal/repository"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +43,51 @@ func (m *MockTask) EXPECT() *MockTaskMockRecorder {
 	return m.recorder
 }
 
+// ArchiveCompleted mocks base method.
+func (m *MockTask) ArchiveCompleted(now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveCompleted", now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveCompleted indicates an expected call of ArchiveCompleted.
+func (mr *MockTaskMockRecorder) ArchiveCompleted(now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveCompleted", reflect.TypeOf((*MockTask)(nil).ArchiveCompleted), now)
+}
+
+// CountAllByAuthorId mocks base method.
+func (m *MockTask) CountAllByAuthorId(id uuid.UUID) (domain.UserTaskCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAllByAuthorId", id)
+	ret0, _ := ret[0].(domain.UserTaskCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAllByAuthorId indicates an expected call of CountAllByAuthorId.
+func (mr *MockTaskMockRecorder) CountAllByAuthorId(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAllByAuthorId", reflect.TypeOf((*MockTask)(nil).CountAllByAuthorId), id)
+}
+
+// CountByUserId mocks base method.
+func (m *MockTask) CountByUserId(filter domain.TaskFilter) (domain.TaskCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByUserId", filter)
+	ret0, _ := ret[0].(domain.TaskCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByUserId indicates an expected call of CountByUserId.
+func (mr *MockTaskMockRecorder) CountByUserId(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByUserId", reflect.TypeOf((*MockTask)(nil).CountByUserId), filter)
+}
+
 // Create mocks base method.
 func (m *MockTask) Create(task *domain.Task) (*domain.Task, error) {
 	m.ctrl.T.Helper()
@@ -70,6 +117,64 @@ func (mr *MockTaskMockRecorder) Delete(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTask)(nil).Delete), id)
 }
 
+// FindById mocks base method.
+func (m *MockTask) FindById(id uuid.UUID) (*domain.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*domain.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockTaskMockRecorder) FindById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockTask)(nil).FindById), id)
+}
+
+// FindWithTrashedById mocks base method.
+func (m *MockTask) FindWithTrashedById(id uuid.UUID) (*domain.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindWithTrashedById", id)
+	ret0, _ := ret[0].(*domain.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindWithTrashedById indicates an expected call of FindWithTrashedById.
+func (mr *MockTaskMockRecorder) FindWithTrashedById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWithTrashedById", reflect.TypeOf((*MockTask)(nil).FindWithTrashedById), id)
+}
+
+// GetAllByIds mocks base method.
+func (m *MockTask) GetAllByIds(ids []uuid.UUID) *[]domain.Task {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByIds", ids)
+	ret0, _ := ret[0].(*[]domain.Task)
+	return ret0
+}
+
+// GetAllByIds indicates an expected call of GetAllByIds.
+func (mr *MockTaskMockRecorder) GetAllByIds(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByIds", reflect.TypeOf((*MockTask)(nil).GetAllByIds), ids)
+}
+
+// GetAllByListId mocks base method.
+func (m *MockTask) GetAllByListId(id uuid.UUID) *[]domain.Task {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByListId", id)
+	ret0, _ := ret[0].(*[]domain.Task)
+	return ret0
+}
+
+// GetAllByListId indicates an expected call of GetAllByListId.
+func (mr *MockTaskMockRecorder) GetAllByListId(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByListId", reflect.TypeOf((*MockTask)(nil).GetAllByListId), id)
+}
+
 // GetAllByUserId mocks base method.
 func (m *MockTask) GetAllByUserId(id uuid.UUID) *[]domain.Task {
 	m.ctrl.T.Helper()
@@ -84,6 +189,106 @@ func (mr *MockTaskMockRecorder) GetAllByUserId(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByUserId", reflect.TypeOf((*MockTask)(nil).GetAllByUserId), id)
 }
 
+// GetArchivedByUserId mocks base method.
+func (m *MockTask) GetArchivedByUserId(id uuid.UUID) *[]domain.Task {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchivedByUserId", id)
+	ret0, _ := ret[0].(*[]domain.Task)
+	return ret0
+}
+
+// GetArchivedByUserId indicates an expected call of GetArchivedByUserId.
+func (mr *MockTaskMockRecorder) GetArchivedByUserId(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedByUserId", reflect.TypeOf((*MockTask)(nil).GetArchivedByUserId), id)
+}
+
+// GetAssignedInList mocks base method.
+func (m *MockTask) GetAssignedInList(listId, assigneeId uuid.UUID) *[]domain.Task {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignedInList", listId, assigneeId)
+	ret0, _ := ret[0].(*[]domain.Task)
+	return ret0
+}
+
+// GetAssignedInList indicates an expected call of GetAssignedInList.
+func (mr *MockTaskMockRecorder) GetAssignedInList(listId, assigneeId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignedInList", reflect.TypeOf((*MockTask)(nil).GetAssignedInList), listId, assigneeId)
+}
+
+// GetAssignedInWorkspace mocks base method.
+func (m *MockTask) GetAssignedInWorkspace(workspaceId, assigneeId uuid.UUID) *[]domain.Task {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignedInWorkspace", workspaceId, assigneeId)
+	ret0, _ := ret[0].(*[]domain.Task)
+	return ret0
+}
+
+// GetAssignedInWorkspace indicates an expected call of GetAssignedInWorkspace.
+func (mr *MockTaskMockRecorder) GetAssignedInWorkspace(workspaceId, assigneeId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignedInWorkspace", reflect.TypeOf((*MockTask)(nil).GetAssignedInWorkspace), workspaceId, assigneeId)
+}
+
+// GetAssignedToUserId mocks base method.
+func (m *MockTask) GetAssignedToUserId(id uuid.UUID) *[]domain.Task {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignedToUserId", id)
+	ret0, _ := ret[0].(*[]domain.Task)
+	return ret0
+}
+
+// GetAssignedToUserId indicates an expected call of GetAssignedToUserId.
+func (mr *MockTaskMockRecorder) GetAssignedToUserId(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignedToUserId", reflect.TypeOf((*MockTask)(nil).GetAssignedToUserId), id)
+}
+
+// GetChangedSince mocks base method.
+func (m *MockTask) GetChangedSince(userId uuid.UUID, since domain.SyncPosition, limit int) (*[]domain.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangedSince", userId, since, limit)
+	ret0, _ := ret[0].(*[]domain.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChangedSince indicates an expected call of GetChangedSince.
+func (mr *MockTaskMockRecorder) GetChangedSince(userId, since, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangedSince", reflect.TypeOf((*MockTask)(nil).GetChangedSince), userId, since, limit)
+}
+
+// GetPageByUserId mocks base method.
+func (m *MockTask) GetPageByUserId(filter domain.TaskFilter, sort domain.TaskSort, after *domain.TaskCursor, limit int) (*[]domain.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPageByUserId", filter, sort, after, limit)
+	ret0, _ := ret[0].(*[]domain.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPageByUserId indicates an expected call of GetPageByUserId.
+func (mr *MockTaskMockRecorder) GetPageByUserId(filter, sort, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPageByUserId", reflect.TypeOf((*MockTask)(nil).GetPageByUserId), filter, sort, after, limit)
+}
+
+// GetTrashByUserId mocks base method.
+func (m *MockTask) GetTrashByUserId(id uuid.UUID) *[]domain.Task {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashByUserId", id)
+	ret0, _ := ret[0].(*[]domain.Task)
+	return ret0
+}
+
+// GetTrashByUserId indicates an expected call of GetTrashByUserId.
+func (mr *MockTaskMockRecorder) GetTrashByUserId(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashByUserId", reflect.TypeOf((*MockTask)(nil).GetTrashByUserId), id)
+}
+
 // IsExistsById mocks base method.
 func (m *MockTask) IsExistsById(id uuid.UUID) bool {
 	m.ctrl.T.Helper()
@@ -98,6 +303,120 @@ func (mr *MockTaskMockRecorder) IsExistsById(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsExistsById", reflect.TypeOf((*MockTask)(nil).IsExistsById), id)
 }
 
+// LockDeletedBefore mocks base method.
+func (m *MockTask) LockDeletedBefore(before time.Time) *[]domain.Task {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockDeletedBefore", before)
+	ret0, _ := ret[0].(*[]domain.Task)
+	return ret0
+}
+
+// LockDeletedBefore indicates an expected call of LockDeletedBefore.
+func (mr *MockTaskMockRecorder) LockDeletedBefore(before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockDeletedBefore", reflect.TypeOf((*MockTask)(nil).LockDeletedBefore), before)
+}
+
+// Move mocks base method.
+func (m *MockTask) Move(id uuid.UUID, listId *uuid.UUID, statusId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", id, listId, statusId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockTaskMockRecorder) Move(id, listId, statusId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockTask)(nil).Move), id, listId, statusId)
+}
+
+// Purge mocks base method.
+func (m *MockTask) Purge(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockTaskMockRecorder) Purge(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockTask)(nil).Purge), id)
+}
+
+// PurgeDeletedBefore mocks base method.
+func (m *MockTask) PurgeDeletedBefore(before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedBefore", before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedBefore indicates an expected call of PurgeDeletedBefore.
+func (mr *MockTaskMockRecorder) PurgeDeletedBefore(before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedBefore", reflect.TypeOf((*MockTask)(nil).PurgeDeletedBefore), before)
+}
+
+// Restore mocks base method.
+func (m *MockTask) Restore(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockTaskMockRecorder) Restore(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTask)(nil).Restore), id)
+}
+
+// Search mocks base method.
+func (m *MockTask) Search(userId uuid.UUID, tsquery, language string, limit int) (*[]domain.TaskSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", userId, tsquery, language, limit)
+	ret0, _ := ret[0].(*[]domain.TaskSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockTaskMockRecorder) Search(userId, tsquery, language, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockTask)(nil).Search), userId, tsquery, language, limit)
+}
+
+// Unarchive mocks base method.
+func (m *MockTask) Unarchive(id uuid.UUID, unarchivedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unarchive", id, unarchivedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unarchive indicates an expected call of Unarchive.
+func (mr *MockTaskMockRecorder) Unarchive(id, unarchivedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unarchive", reflect.TypeOf((*MockTask)(nil).Unarchive), id, unarchivedAt)
+}
+
+// Unassign mocks base method.
+func (m *MockTask) Unassign(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unassign", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unassign indicates an expected call of Unassign.
+func (mr *MockTaskMockRecorder) Unassign(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unassign", reflect.TypeOf((*MockTask)(nil).Unassign), id)
+}
+
 // Update mocks base method.
 func (m *MockTask) Update(task *domain.Task) (*domain.Task, error) {
 	m.ctrl.T.Helper()
@@ -113,56 +432,1987 @@ func (mr *MockTaskMockRecorder) Update(task any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTask)(nil).Update), task)
 }
 
-// MockUser is a mock of User interface.
-type MockUser struct {
+// UpdateColumns mocks base method.
+func (m *MockTask) UpdateColumns(id uuid.UUID, columns map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateColumns", id, columns)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateColumns indicates an expected call of UpdateColumns.
+func (mr *MockTaskMockRecorder) UpdateColumns(id, columns any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateColumns", reflect.TypeOf((*MockTask)(nil).UpdateColumns), id, columns)
+}
+
+// WithVersion mocks base method.
+func (m *MockTask) WithVersion(version int) repository.Task {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithVersion", version)
+	ret0, _ := ret[0].(repository.Task)
+	return ret0
+}
+
+// WithVersion indicates an expected call of WithVersion.
+func (mr *MockTaskMockRecorder) WithVersion(version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithVersion", reflect.TypeOf((*MockTask)(nil).WithVersion), version)
+}
+
+// MockTaskDependency is a mock of TaskDependency interface.
+type MockTaskDependency struct {
 	ctrl     *gomock.Controller
-	recorder *MockUserMockRecorder
+	recorder *MockTaskDependencyMockRecorder
 	isgomock struct{}
 }
 
-// MockUserMockRecorder is the mock recorder for MockUser.
-type MockUserMockRecorder struct {
-	mock *MockUser
+// MockTaskDependencyMockRecorder is the mock recorder for MockTaskDependency.
+type MockTaskDependencyMockRecorder struct {
+	mock *MockTaskDependency
 }
 
-// NewMockUser creates a new mock instance.
-func NewMockUser(ctrl *gomock.Controller) *MockUser {
-	mock := &MockUser{ctrl: ctrl}
-	mock.recorder = &MockUserMockRecorder{mock}
+// NewMockTaskDependency creates a new mock instance.
+func NewMockTaskDependency(ctrl *gomock.Controller) *MockTaskDependency {
+	mock := &MockTaskDependency{ctrl: ctrl}
+	mock.recorder = &MockTaskDependencyMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockUser) EXPECT() *MockUserMockRecorder {
+func (m *MockTaskDependency) EXPECT() *MockTaskDependencyMockRecorder {
 	return m.recorder
 }
 
 // Create mocks base method.
-func (m *MockUser) Create(user *domain.User) (*domain.User, error) {
+func (m *MockTaskDependency) Create(dependency *domain.TaskDependency) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", user)
-	ret0, _ := ret[0].(*domain.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Create", dependency)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockUserMockRecorder) Create(user any) *gomock.Call {
+func (mr *MockTaskDependencyMockRecorder) Create(dependency any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUser)(nil).Create), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTaskDependency)(nil).Create), dependency)
 }
 
-// FindByEmail mocks base method.
-func (m *MockUser) FindByEmail(email string) (*domain.User, error) {
+// Delete mocks base method.
+func (m *MockTaskDependency) Delete(taskId, blockedById uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByEmail", email)
-	ret0, _ := ret[0].(*domain.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Delete", taskId, blockedById)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// FindByEmail indicates an expected call of FindByEmail.
-func (mr *MockUserMockRecorder) FindByEmail(email any) *gomock.Call {
+// Delete indicates an expected call of Delete.
+func (mr *MockTaskDependencyMockRecorder) Delete(taskId, blockedById any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockUser)(nil).FindByEmail), email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTaskDependency)(nil).Delete), taskId, blockedById)
+}
+
+// GetBlockersByTaskId mocks base method.
+func (m *MockTaskDependency) GetBlockersByTaskId(taskId uuid.UUID) *[]domain.Task {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockersByTaskId", taskId)
+	ret0, _ := ret[0].(*[]domain.Task)
+	return ret0
+}
+
+// GetBlockersByTaskId indicates an expected call of GetBlockersByTaskId.
+func (mr *MockTaskDependencyMockRecorder) GetBlockersByTaskId(taskId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockersByTaskId", reflect.TypeOf((*MockTaskDependency)(nil).GetBlockersByTaskId), taskId)
+}
+
+// HasOpenBlockers mocks base method.
+func (m *MockTaskDependency) HasOpenBlockers(taskId uuid.UUID) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasOpenBlockers", taskId)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasOpenBlockers indicates an expected call of HasOpenBlockers.
+func (mr *MockTaskDependencyMockRecorder) HasOpenBlockers(taskId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasOpenBlockers", reflect.TypeOf((*MockTaskDependency)(nil).HasOpenBlockers), taskId)
+}
+
+// IsExists mocks base method.
+func (m *MockTaskDependency) IsExists(taskId, blockedById uuid.UUID) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsExists", taskId, blockedById)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsExists indicates an expected call of IsExists.
+func (mr *MockTaskDependencyMockRecorder) IsExists(taskId, blockedById any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsExists", reflect.TypeOf((*MockTaskDependency)(nil).IsExists), taskId, blockedById)
+}
+
+// IsReachable mocks base method.
+func (m *MockTaskDependency) IsReachable(fromId, toId uuid.UUID) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsReachable", fromId, toId)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsReachable indicates an expected call of IsReachable.
+func (mr *MockTaskDependencyMockRecorder) IsReachable(fromId, toId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsReachable", reflect.TypeOf((*MockTaskDependency)(nil).IsReachable), fromId, toId)
+}
+
+// Lock mocks base method.
+func (m *MockTaskDependency) Lock() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockTaskDependencyMockRecorder) Lock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockTaskDependency)(nil).Lock))
+}
+
+// MockTaskTag is a mock of TaskTag interface.
+type MockTaskTag struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskTagMockRecorder
+	isgomock struct{}
+}
+
+// MockTaskTagMockRecorder is the mock recorder for MockTaskTag.
+type MockTaskTagMockRecorder struct {
+	mock *MockTaskTag
+}
+
+// NewMockTaskTag creates a new mock instance.
+func NewMockTaskTag(ctrl *gomock.Controller) *MockTaskTag {
+	mock := &MockTaskTag{ctrl: ctrl}
+	mock.recorder = &MockTaskTagMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskTag) EXPECT() *MockTaskTagMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockTaskTag) Add(taskId uuid.UUID, names []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", taskId, names)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockTaskTagMockRecorder) Add(taskId, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockTaskTag)(nil).Add), taskId, names)
+}
+
+// GetByTaskId mocks base method.
+func (m *MockTaskTag) GetByTaskId(taskId uuid.UUID) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTaskId", taskId)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetByTaskId indicates an expected call of GetByTaskId.
+func (mr *MockTaskTagMockRecorder) GetByTaskId(taskId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTaskId", reflect.TypeOf((*MockTaskTag)(nil).GetByTaskId), taskId)
+}
+
+// Replace mocks base method.
+func (m *MockTaskTag) Replace(taskId uuid.UUID, names []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", taskId, names)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockTaskTagMockRecorder) Replace(taskId, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockTaskTag)(nil).Replace), taskId, names)
+}
+
+// MockTimeEntry is a mock of TimeEntry interface.
+type MockTimeEntry struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimeEntryMockRecorder
+	isgomock struct{}
+}
+
+// MockTimeEntryMockRecorder is the mock recorder for MockTimeEntry.
+type MockTimeEntryMockRecorder struct {
+	mock *MockTimeEntry
+}
+
+// NewMockTimeEntry creates a new mock instance.
+func NewMockTimeEntry(ctrl *gomock.Controller) *MockTimeEntry {
+	mock := &MockTimeEntry{ctrl: ctrl}
+	mock.recorder = &MockTimeEntryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimeEntry) EXPECT() *MockTimeEntryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTimeEntry) Create(entry *domain.TimeEntry) (*domain.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", entry)
+	ret0, _ := ret[0].(*domain.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTimeEntryMockRecorder) Create(entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTimeEntry)(nil).Create), entry)
+}
+
+// Delete mocks base method.
+func (m *MockTimeEntry) Delete(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTimeEntryMockRecorder) Delete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTimeEntry)(nil).Delete), id)
+}
+
+// FindById mocks base method.
+func (m *MockTimeEntry) FindById(id uuid.UUID) (*domain.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*domain.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockTimeEntryMockRecorder) FindById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockTimeEntry)(nil).FindById), id)
+}
+
+// FindRunningByUserId mocks base method.
+func (m *MockTimeEntry) FindRunningByUserId(userId uuid.UUID) (*domain.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRunningByUserId", userId)
+	ret0, _ := ret[0].(*domain.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRunningByUserId indicates an expected call of FindRunningByUserId.
+func (mr *MockTimeEntryMockRecorder) FindRunningByUserId(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRunningByUserId", reflect.TypeOf((*MockTimeEntry)(nil).FindRunningByUserId), userId)
+}
+
+// GetAllByTaskId mocks base method.
+func (m *MockTimeEntry) GetAllByTaskId(taskId uuid.UUID) *[]domain.TimeEntry {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByTaskId", taskId)
+	ret0, _ := ret[0].(*[]domain.TimeEntry)
+	return ret0
+}
+
+// GetAllByTaskId indicates an expected call of GetAllByTaskId.
+func (mr *MockTimeEntryMockRecorder) GetAllByTaskId(taskId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByTaskId", reflect.TypeOf((*MockTimeEntry)(nil).GetAllByTaskId), taskId)
+}
+
+// GetReport mocks base method.
+func (m *MockTimeEntry) GetReport(userId uuid.UUID, from, to time.Time, groupBy string) (*[]domain.TimeReportRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReport", userId, from, to, groupBy)
+	ret0, _ := ret[0].(*[]domain.TimeReportRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReport indicates an expected call of GetReport.
+func (mr *MockTimeEntryMockRecorder) GetReport(userId, from, to, groupBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReport", reflect.TypeOf((*MockTimeEntry)(nil).GetReport), userId, from, to, groupBy)
+}
+
+// Start mocks base method.
+func (m *MockTimeEntry) Start(entry *domain.TimeEntry) (*domain.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", entry)
+	ret0, _ := ret[0].(*domain.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockTimeEntryMockRecorder) Start(entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockTimeEntry)(nil).Start), entry)
+}
+
+// Update mocks base method.
+func (m *MockTimeEntry) Update(entry *domain.TimeEntry) (*domain.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", entry)
+	ret0, _ := ret[0].(*domain.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTimeEntryMockRecorder) Update(entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTimeEntry)(nil).Update), entry)
+}
+
+// MockList is a mock of List interface.
+type MockList struct {
+	ctrl     *gomock.Controller
+	recorder *MockListMockRecorder
+	isgomock struct{}
+}
+
+// MockListMockRecorder is the mock recorder for MockList.
+type MockListMockRecorder struct {
+	mock *MockList
+}
+
+// NewMockList creates a new mock instance.
+func NewMockList(ctrl *gomock.Controller) *MockList {
+	mock := &MockList{ctrl: ctrl}
+	mock.recorder = &MockListMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockList) EXPECT() *MockListMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockList) Create(list *domain.List) (*domain.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", list)
+	ret0, _ := ret[0].(*domain.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockListMockRecorder) Create(list any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockList)(nil).Create), list)
+}
+
+// Delete mocks base method.
+func (m *MockList) Delete(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockListMockRecorder) Delete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockList)(nil).Delete), id)
+}
+
+// FindById mocks base method.
+func (m *MockList) FindById(id uuid.UUID) (*domain.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*domain.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockListMockRecorder) FindById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockList)(nil).FindById), id)
+}
+
+// GetAllByUserId mocks base method.
+func (m *MockList) GetAllByUserId(id uuid.UUID) *[]domain.List {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByUserId", id)
+	ret0, _ := ret[0].(*[]domain.List)
+	return ret0
+}
+
+// GetAllByUserId indicates an expected call of GetAllByUserId.
+func (mr *MockListMockRecorder) GetAllByUserId(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByUserId", reflect.TypeOf((*MockList)(nil).GetAllByUserId), id)
+}
+
+// GetUserIds mocks base method.
+func (m *MockList) GetUserIds(id uuid.UUID) []uuid.UUID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIds", id)
+	ret0, _ := ret[0].([]uuid.UUID)
+	return ret0
+}
+
+// GetUserIds indicates an expected call of GetUserIds.
+func (mr *MockListMockRecorder) GetUserIds(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIds", reflect.TypeOf((*MockList)(nil).GetUserIds), id)
+}
+
+// MockListMember is a mock of ListMember interface.
+type MockListMember struct {
+	ctrl     *gomock.Controller
+	recorder *MockListMemberMockRecorder
+	isgomock struct{}
+}
+
+// MockListMemberMockRecorder is the mock recorder for MockListMember.
+type MockListMemberMockRecorder struct {
+	mock *MockListMember
+}
+
+// NewMockListMember creates a new mock instance.
+func NewMockListMember(ctrl *gomock.Controller) *MockListMember {
+	mock := &MockListMember{ctrl: ctrl}
+	mock.recorder = &MockListMemberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockListMember) EXPECT() *MockListMemberMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockListMember) Create(member *domain.ListMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockListMemberMockRecorder) Create(member any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockListMember)(nil).Create), member)
+}
+
+// Delete mocks base method.
+func (m *MockListMember) Delete(listId, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", listId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockListMemberMockRecorder) Delete(listId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockListMember)(nil).Delete), listId, userId)
+}
+
+// Find mocks base method.
+func (m *MockListMember) Find(listId, userId uuid.UUID) (*domain.ListMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", listId, userId)
+	ret0, _ := ret[0].(*domain.ListMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockListMemberMockRecorder) Find(listId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockListMember)(nil).Find), listId, userId)
+}
+
+// GetByListId mocks base method.
+func (m *MockListMember) GetByListId(listId uuid.UUID) *[]domain.ListMember {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByListId", listId)
+	ret0, _ := ret[0].(*[]domain.ListMember)
+	return ret0
+}
+
+// GetByListId indicates an expected call of GetByListId.
+func (mr *MockListMemberMockRecorder) GetByListId(listId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByListId", reflect.TypeOf((*MockListMember)(nil).GetByListId), listId)
+}
+
+// UpdateRole mocks base method.
+func (m *MockListMember) UpdateRole(listId, userId uuid.UUID, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", listId, userId, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockListMemberMockRecorder) UpdateRole(listId, userId, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockListMember)(nil).UpdateRole), listId, userId, role)
+}
+
+// MockNotification is a mock of Notification interface.
+type MockNotification struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationMockRecorder is the mock recorder for MockNotification.
+type MockNotificationMockRecorder struct {
+	mock *MockNotification
+}
+
+// NewMockNotification creates a new mock instance.
+func NewMockNotification(ctrl *gomock.Controller) *MockNotification {
+	mock := &MockNotification{ctrl: ctrl}
+	mock.recorder = &MockNotificationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotification) EXPECT() *MockNotificationMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockNotification) Create(notification *domain.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockNotificationMockRecorder) Create(notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockNotification)(nil).Create), notification)
+}
+
+// FindById mocks base method.
+func (m *MockNotification) FindById(id uuid.UUID) (*domain.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*domain.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockNotificationMockRecorder) FindById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockNotification)(nil).FindById), id)
+}
+
+// GetByUserId mocks base method.
+func (m *MockNotification) GetByUserId(userId uuid.UUID, unreadOnly bool, limit int) *[]domain.Notification {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserId", userId, unreadOnly, limit)
+	ret0, _ := ret[0].(*[]domain.Notification)
+	return ret0
+}
+
+// GetByUserId indicates an expected call of GetByUserId.
+func (mr *MockNotificationMockRecorder) GetByUserId(userId, unreadOnly, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserId", reflect.TypeOf((*MockNotification)(nil).GetByUserId), userId, unreadOnly, limit)
+}
+
+// MarkAllRead mocks base method.
+func (m *MockNotification) MarkAllRead(userId uuid.UUID, readAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", userId, readAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockNotificationMockRecorder) MarkAllRead(userId, readAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockNotification)(nil).MarkAllRead), userId, readAt)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotification) MarkRead(id uuid.UUID, readAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", id, readAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationMockRecorder) MarkRead(id, readAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotification)(nil).MarkRead), id, readAt)
+}
+
+// MockTaskHistory is a mock of TaskHistory interface.
+type MockTaskHistory struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskHistoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTaskHistoryMockRecorder is the mock recorder for MockTaskHistory.
+type MockTaskHistoryMockRecorder struct {
+	mock *MockTaskHistory
+}
+
+// NewMockTaskHistory creates a new mock instance.
+func NewMockTaskHistory(ctrl *gomock.Controller) *MockTaskHistory {
+	mock := &MockTaskHistory{ctrl: ctrl}
+	mock.recorder = &MockTaskHistoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskHistory) EXPECT() *MockTaskHistoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTaskHistory) Create(entries []domain.TaskHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", entries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTaskHistoryMockRecorder) Create(entries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTaskHistory)(nil).Create), entries)
+}
+
+// FindLastByActorId mocks base method.
+func (m *MockTaskHistory) FindLastByActorId(actorId uuid.UUID, since time.Time) (*domain.TaskHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLastByActorId", actorId, since)
+	ret0, _ := ret[0].(*domain.TaskHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLastByActorId indicates an expected call of FindLastByActorId.
+func (mr *MockTaskHistoryMockRecorder) FindLastByActorId(actorId, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLastByActorId", reflect.TypeOf((*MockTaskHistory)(nil).FindLastByActorId), actorId, since)
+}
+
+// GetByRevision mocks base method.
+func (m *MockTaskHistory) GetByRevision(taskId uuid.UUID, revision int) *[]domain.TaskHistory {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByRevision", taskId, revision)
+	ret0, _ := ret[0].(*[]domain.TaskHistory)
+	return ret0
+}
+
+// GetByRevision indicates an expected call of GetByRevision.
+func (mr *MockTaskHistoryMockRecorder) GetByRevision(taskId, revision any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRevision", reflect.TypeOf((*MockTaskHistory)(nil).GetByRevision), taskId, revision)
+}
+
+// GetByTaskId mocks base method.
+func (m *MockTaskHistory) GetByTaskId(taskId uuid.UUID) *[]domain.TaskHistory {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTaskId", taskId)
+	ret0, _ := ret[0].(*[]domain.TaskHistory)
+	return ret0
+}
+
+// GetByTaskId indicates an expected call of GetByTaskId.
+func (mr *MockTaskHistoryMockRecorder) GetByTaskId(taskId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTaskId", reflect.TypeOf((*MockTaskHistory)(nil).GetByTaskId), taskId)
+}
+
+// MarkUndone mocks base method.
+func (m *MockTaskHistory) MarkUndone(taskId uuid.UUID, revisions []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUndone", taskId, revisions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUndone indicates an expected call of MarkUndone.
+func (mr *MockTaskHistoryMockRecorder) MarkUndone(taskId, revisions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUndone", reflect.TypeOf((*MockTaskHistory)(nil).MarkUndone), taskId, revisions)
+}
+
+// MockIdempotencyKey is a mock of IdempotencyKey interface.
+type MockIdempotencyKey struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyKeyMockRecorder
+	isgomock struct{}
+}
+
+// MockIdempotencyKeyMockRecorder is the mock recorder for MockIdempotencyKey.
+type MockIdempotencyKeyMockRecorder struct {
+	mock *MockIdempotencyKey
+}
+
+// NewMockIdempotencyKey creates a new mock instance.
+func NewMockIdempotencyKey(ctrl *gomock.Controller) *MockIdempotencyKey {
+	mock := &MockIdempotencyKey{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyKeyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyKey) EXPECT() *MockIdempotencyKeyMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIdempotencyKey) Create(idempotencyKey *domain.IdempotencyKey) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", idempotencyKey)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIdempotencyKeyMockRecorder) Create(idempotencyKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIdempotencyKey)(nil).Create), idempotencyKey)
+}
+
+// Delete mocks base method.
+func (m *MockIdempotencyKey) Delete(userId uuid.UUID, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIdempotencyKeyMockRecorder) Delete(userId, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIdempotencyKey)(nil).Delete), userId, key)
+}
+
+// DeleteCreatedBefore mocks base method.
+func (m *MockIdempotencyKey) DeleteCreatedBefore(before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCreatedBefore", before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCreatedBefore indicates an expected call of DeleteCreatedBefore.
+func (mr *MockIdempotencyKeyMockRecorder) DeleteCreatedBefore(before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCreatedBefore", reflect.TypeOf((*MockIdempotencyKey)(nil).DeleteCreatedBefore), before)
+}
+
+// Find mocks base method.
+func (m *MockIdempotencyKey) Find(userId uuid.UUID, key string) (*domain.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", userId, key)
+	ret0, _ := ret[0].(*domain.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockIdempotencyKeyMockRecorder) Find(userId, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockIdempotencyKey)(nil).Find), userId, key)
+}
+
+// SaveResponse mocks base method.
+func (m *MockIdempotencyKey) SaveResponse(idempotencyKey *domain.IdempotencyKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveResponse", idempotencyKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveResponse indicates an expected call of SaveResponse.
+func (mr *MockIdempotencyKeyMockRecorder) SaveResponse(idempotencyKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveResponse", reflect.TypeOf((*MockIdempotencyKey)(nil).SaveResponse), idempotencyKey)
+}
+
+// MockWorkspace is a mock of Workspace interface.
+type MockWorkspace struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceMockRecorder
+	isgomock struct{}
+}
+
+// MockWorkspaceMockRecorder is the mock recorder for MockWorkspace.
+type MockWorkspaceMockRecorder struct {
+	mock *MockWorkspace
+}
+
+// NewMockWorkspace creates a new mock instance.
+func NewMockWorkspace(ctrl *gomock.Controller) *MockWorkspace {
+	mock := &MockWorkspace{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspace) EXPECT() *MockWorkspaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWorkspace) Create(workspace *domain.Workspace, ownerId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", workspace, ownerId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWorkspaceMockRecorder) Create(workspace, ownerId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWorkspace)(nil).Create), workspace, ownerId)
+}
+
+// FindByMember mocks base method.
+func (m *MockWorkspace) FindByMember(id, userId uuid.UUID) (*domain.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByMember", id, userId)
+	ret0, _ := ret[0].(*domain.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByMember indicates an expected call of FindByMember.
+func (mr *MockWorkspaceMockRecorder) FindByMember(id, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByMember", reflect.TypeOf((*MockWorkspace)(nil).FindByMember), id, userId)
+}
+
+// FindPersonal mocks base method.
+func (m *MockWorkspace) FindPersonal(userId uuid.UUID) (*domain.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPersonal", userId)
+	ret0, _ := ret[0].(*domain.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPersonal indicates an expected call of FindPersonal.
+func (mr *MockWorkspaceMockRecorder) FindPersonal(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPersonal", reflect.TypeOf((*MockWorkspace)(nil).FindPersonal), userId)
+}
+
+// GetAllByUserId mocks base method.
+func (m *MockWorkspace) GetAllByUserId(id uuid.UUID) *[]domain.Workspace {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByUserId", id)
+	ret0, _ := ret[0].(*[]domain.Workspace)
+	return ret0
+}
+
+// GetAllByUserId indicates an expected call of GetAllByUserId.
+func (mr *MockWorkspaceMockRecorder) GetAllByUserId(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByUserId", reflect.TypeOf((*MockWorkspace)(nil).GetAllByUserId), id)
+}
+
+// MockWorkspaceMember is a mock of WorkspaceMember interface.
+type MockWorkspaceMember struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceMemberMockRecorder
+	isgomock struct{}
+}
+
+// MockWorkspaceMemberMockRecorder is the mock recorder for MockWorkspaceMember.
+type MockWorkspaceMemberMockRecorder struct {
+	mock *MockWorkspaceMember
+}
+
+// NewMockWorkspaceMember creates a new mock instance.
+func NewMockWorkspaceMember(ctrl *gomock.Controller) *MockWorkspaceMember {
+	mock := &MockWorkspaceMember{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceMemberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspaceMember) EXPECT() *MockWorkspaceMemberMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWorkspaceMember) Create(member *domain.WorkspaceMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWorkspaceMemberMockRecorder) Create(member any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWorkspaceMember)(nil).Create), member)
+}
+
+// Delete mocks base method.
+func (m *MockWorkspaceMember) Delete(workspaceId, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", workspaceId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWorkspaceMemberMockRecorder) Delete(workspaceId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWorkspaceMember)(nil).Delete), workspaceId, userId)
+}
+
+// Find mocks base method.
+func (m *MockWorkspaceMember) Find(workspaceId, userId uuid.UUID) (*domain.WorkspaceMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", workspaceId, userId)
+	ret0, _ := ret[0].(*domain.WorkspaceMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockWorkspaceMemberMockRecorder) Find(workspaceId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockWorkspaceMember)(nil).Find), workspaceId, userId)
+}
+
+// GetByWorkspaceId mocks base method.
+func (m *MockWorkspaceMember) GetByWorkspaceId(workspaceId uuid.UUID) *[]domain.WorkspaceMember {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByWorkspaceId", workspaceId)
+	ret0, _ := ret[0].(*[]domain.WorkspaceMember)
+	return ret0
+}
+
+// GetByWorkspaceId indicates an expected call of GetByWorkspaceId.
+func (mr *MockWorkspaceMemberMockRecorder) GetByWorkspaceId(workspaceId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByWorkspaceId", reflect.TypeOf((*MockWorkspaceMember)(nil).GetByWorkspaceId), workspaceId)
+}
+
+// MockInvite is a mock of Invite interface.
+type MockInvite struct {
+	ctrl     *gomock.Controller
+	recorder *MockInviteMockRecorder
+	isgomock struct{}
+}
+
+// MockInviteMockRecorder is the mock recorder for MockInvite.
+type MockInviteMockRecorder struct {
+	mock *MockInvite
+}
+
+// NewMockInvite creates a new mock instance.
+func NewMockInvite(ctrl *gomock.Controller) *MockInvite {
+	mock := &MockInvite{ctrl: ctrl}
+	mock.recorder = &MockInviteMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvite) EXPECT() *MockInviteMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockInvite) Create(invite *domain.Invite) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", invite)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockInviteMockRecorder) Create(invite any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockInvite)(nil).Create), invite)
+}
+
+// FindById mocks base method.
+func (m *MockInvite) FindById(id uuid.UUID) (*domain.Invite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*domain.Invite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockInviteMockRecorder) FindById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockInvite)(nil).FindById), id)
+}
+
+// MarkUsed mocks base method.
+func (m *MockInvite) MarkUsed(id uuid.UUID, usedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", id, usedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockInviteMockRecorder) MarkUsed(id, usedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockInvite)(nil).MarkUsed), id, usedAt)
+}
+
+// MockSmartList is a mock of SmartList interface.
+type MockSmartList struct {
+	ctrl     *gomock.Controller
+	recorder *MockSmartListMockRecorder
+	isgomock struct{}
+}
+
+// MockSmartListMockRecorder is the mock recorder for MockSmartList.
+type MockSmartListMockRecorder struct {
+	mock *MockSmartList
+}
+
+// NewMockSmartList creates a new mock instance.
+func NewMockSmartList(ctrl *gomock.Controller) *MockSmartList {
+	mock := &MockSmartList{ctrl: ctrl}
+	mock.recorder = &MockSmartListMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSmartList) EXPECT() *MockSmartListMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSmartList) Create(smartList *domain.SmartList) (*domain.SmartList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", smartList)
+	ret0, _ := ret[0].(*domain.SmartList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSmartListMockRecorder) Create(smartList any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSmartList)(nil).Create), smartList)
+}
+
+// Delete mocks base method.
+func (m *MockSmartList) Delete(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSmartListMockRecorder) Delete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSmartList)(nil).Delete), id)
+}
+
+// FindById mocks base method.
+func (m *MockSmartList) FindById(id uuid.UUID) (*domain.SmartList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*domain.SmartList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockSmartListMockRecorder) FindById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockSmartList)(nil).FindById), id)
+}
+
+// GetAllByUserId mocks base method.
+func (m *MockSmartList) GetAllByUserId(id uuid.UUID) *[]domain.SmartList {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByUserId", id)
+	ret0, _ := ret[0].(*[]domain.SmartList)
+	return ret0
+}
+
+// GetAllByUserId indicates an expected call of GetAllByUserId.
+func (mr *MockSmartListMockRecorder) GetAllByUserId(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByUserId", reflect.TypeOf((*MockSmartList)(nil).GetAllByUserId), id)
+}
+
+// MockStatus is a mock of Status interface.
+type MockStatus struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusMockRecorder
+	isgomock struct{}
+}
+
+// MockStatusMockRecorder is the mock recorder for MockStatus.
+type MockStatusMockRecorder struct {
+	mock *MockStatus
+}
+
+// NewMockStatus creates a new mock instance.
+func NewMockStatus(ctrl *gomock.Controller) *MockStatus {
+	mock := &MockStatus{ctrl: ctrl}
+	mock.recorder = &MockStatusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatus) EXPECT() *MockStatusMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockStatus) Create(status *domain.Status) (*domain.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", status)
+	ret0, _ := ret[0].(*domain.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStatusMockRecorder) Create(status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStatus)(nil).Create), status)
+}
+
+// CreateBatch mocks base method.
+func (m *MockStatus) CreateBatch(statuses *[]domain.Status) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", statuses)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockStatusMockRecorder) CreateBatch(statuses any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockStatus)(nil).CreateBatch), statuses)
+}
+
+// Delete mocks base method.
+func (m *MockStatus) Delete(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockStatusMockRecorder) Delete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStatus)(nil).Delete), id)
+}
+
+// FindById mocks base method.
+func (m *MockStatus) FindById(id uuid.UUID) (*domain.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*domain.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockStatusMockRecorder) FindById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockStatus)(nil).FindById), id)
+}
+
+// GetByScope mocks base method.
+func (m *MockStatus) GetByScope(userId uuid.UUID, listId *uuid.UUID) *[]domain.Status {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByScope", userId, listId)
+	ret0, _ := ret[0].(*[]domain.Status)
+	return ret0
+}
+
+// GetByScope indicates an expected call of GetByScope.
+func (mr *MockStatusMockRecorder) GetByScope(userId, listId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByScope", reflect.TypeOf((*MockStatus)(nil).GetByScope), userId, listId)
+}
+
+// IsUsed mocks base method.
+func (m *MockStatus) IsUsed(id uuid.UUID) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUsed", id)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsUsed indicates an expected call of IsUsed.
+func (mr *MockStatusMockRecorder) IsUsed(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUsed", reflect.TypeOf((*MockStatus)(nil).IsUsed), id)
+}
+
+// MockUser is a mock of User interface.
+type MockUser struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserMockRecorder
+	isgomock struct{}
+}
+
+// MockUserMockRecorder is the mock recorder for MockUser.
+type MockUserMockRecorder struct {
+	mock *MockUser
+}
+
+// NewMockUser creates a new mock instance.
+func NewMockUser(ctrl *gomock.Controller) *MockUser {
+	mock := &MockUser{ctrl: ctrl}
+	mock.recorder = &MockUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUser) EXPECT() *MockUserMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUser) Create(user *domain.User) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", user)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserMockRecorder) Create(user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUser)(nil).Create), user)
+}
+
+// FindByEmail mocks base method.
+func (m *MockUser) FindByEmail(email string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByEmail", email)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByEmail indicates an expected call of FindByEmail.
+func (mr *MockUserMockRecorder) FindByEmail(email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockUser)(nil).FindByEmail), email)
+}
+
+// FindById mocks base method.
+func (m *MockUser) FindById(id uuid.UUID) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockUserMockRecorder) FindById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockUser)(nil).FindById), id)
+}
+
+// Search mocks base method.
+func (m *MockUser) Search(query string, limit, offset int) (*[]domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", query, limit, offset)
+	ret0, _ := ret[0].(*[]domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockUserMockRecorder) Search(query, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockUser)(nil).Search), query, limit, offset)
+}
+
+// UpdateAutoArchiveDays mocks base method.
+func (m *MockUser) UpdateAutoArchiveDays(id uuid.UUID, days *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAutoArchiveDays", id, days)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAutoArchiveDays indicates an expected call of UpdateAutoArchiveDays.
+func (mr *MockUserMockRecorder) UpdateAutoArchiveDays(id, days any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAutoArchiveDays", reflect.TypeOf((*MockUser)(nil).UpdateAutoArchiveDays), id, days)
+}
+
+// UpdateColumns mocks base method.
+func (m *MockUser) UpdateColumns(id uuid.UUID, columns map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateColumns", id, columns)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateColumns indicates an expected call of UpdateColumns.
+func (mr *MockUserMockRecorder) UpdateColumns(id, columns any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateColumns", reflect.TypeOf((*MockUser)(nil).UpdateColumns), id, columns)
+}
+
+// MockAuditEvent is a mock of AuditEvent interface.
+type MockAuditEvent struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditEventMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditEventMockRecorder is the mock recorder for MockAuditEvent.
+type MockAuditEventMockRecorder struct {
+	mock *MockAuditEvent
+}
+
+// NewMockAuditEvent creates a new mock instance.
+func NewMockAuditEvent(ctrl *gomock.Controller) *MockAuditEvent {
+	mock := &MockAuditEvent{ctrl: ctrl}
+	mock.recorder = &MockAuditEventMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditEvent) EXPECT() *MockAuditEventMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAuditEvent) Create(event *domain.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAuditEventMockRecorder) Create(event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAuditEvent)(nil).Create), event)
+}
+
+// Search mocks base method.
+func (m *MockAuditEvent) Search(filter domain.AuditFilter, limit, offset int) (*[]domain.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", filter, limit, offset)
+	ret0, _ := ret[0].(*[]domain.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockAuditEventMockRecorder) Search(filter, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockAuditEvent)(nil).Search), filter, limit, offset)
+}
+
+// MockWebhook is a mock of Webhook interface.
+type MockWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookMockRecorder is the mock recorder for MockWebhook.
+type MockWebhookMockRecorder struct {
+	mock *MockWebhook
+}
+
+// NewMockWebhook creates a new mock instance.
+func NewMockWebhook(ctrl *gomock.Controller) *MockWebhook {
+	mock := &MockWebhook{ctrl: ctrl}
+	mock.recorder = &MockWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhook) EXPECT() *MockWebhookMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWebhook) Create(webhook *domain.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookMockRecorder) Create(webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhook)(nil).Create), webhook)
+}
+
+// Delete mocks base method.
+func (m *MockWebhook) Delete(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookMockRecorder) Delete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhook)(nil).Delete), id)
+}
+
+// FindById mocks base method.
+func (m *MockWebhook) FindById(id uuid.UUID) (*domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockWebhookMockRecorder) FindById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockWebhook)(nil).FindById), id)
+}
+
+// GetByUserId mocks base method.
+func (m *MockWebhook) GetByUserId(userId uuid.UUID) *[]domain.Webhook {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserId", userId)
+	ret0, _ := ret[0].(*[]domain.Webhook)
+	return ret0
+}
+
+// GetByUserId indicates an expected call of GetByUserId.
+func (mr *MockWebhookMockRecorder) GetByUserId(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserId", reflect.TypeOf((*MockWebhook)(nil).GetByUserId), userId)
+}
+
+// MockWebhookDelivery is a mock of WebhookDelivery interface.
+type MockWebhookDelivery struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookDeliveryMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookDeliveryMockRecorder is the mock recorder for MockWebhookDelivery.
+type MockWebhookDeliveryMockRecorder struct {
+	mock *MockWebhookDelivery
+}
+
+// NewMockWebhookDelivery creates a new mock instance.
+func NewMockWebhookDelivery(ctrl *gomock.Controller) *MockWebhookDelivery {
+	mock := &MockWebhookDelivery{ctrl: ctrl}
+	mock.recorder = &MockWebhookDeliveryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookDelivery) EXPECT() *MockWebhookDeliveryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDue mocks base method.
+func (m *MockWebhookDelivery) ClaimDue(now time.Time, lease time.Duration, limit int) (*[]domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDue", now, lease, limit)
+	ret0, _ := ret[0].(*[]domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDue indicates an expected call of ClaimDue.
+func (mr *MockWebhookDeliveryMockRecorder) ClaimDue(now, lease, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDue", reflect.TypeOf((*MockWebhookDelivery)(nil).ClaimDue), now, lease, limit)
+}
+
+// Create mocks base method.
+func (m *MockWebhookDelivery) Create(delivery *domain.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookDeliveryMockRecorder) Create(delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookDelivery)(nil).Create), delivery)
+}
+
+// EnqueueTaskEvent mocks base method.
+func (m *MockWebhookDelivery) EnqueueTaskEvent(event domain.TaskEvent, payload string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueTaskEvent", event, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnqueueTaskEvent indicates an expected call of EnqueueTaskEvent.
+func (mr *MockWebhookDeliveryMockRecorder) EnqueueTaskEvent(event, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueTaskEvent", reflect.TypeOf((*MockWebhookDelivery)(nil).EnqueueTaskEvent), event, payload)
+}
+
+// FindById mocks base method.
+func (m *MockWebhookDelivery) FindById(id uuid.UUID) (*domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockWebhookDeliveryMockRecorder) FindById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockWebhookDelivery)(nil).FindById), id)
+}
+
+// GetByWebhookId mocks base method.
+func (m *MockWebhookDelivery) GetByWebhookId(webhookId uuid.UUID, limit int) *[]domain.WebhookDelivery {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByWebhookId", webhookId, limit)
+	ret0, _ := ret[0].(*[]domain.WebhookDelivery)
+	return ret0
+}
+
+// GetByWebhookId indicates an expected call of GetByWebhookId.
+func (mr *MockWebhookDeliveryMockRecorder) GetByWebhookId(webhookId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByWebhookId", reflect.TypeOf((*MockWebhookDelivery)(nil).GetByWebhookId), webhookId, limit)
+}
+
+// UpdateColumns mocks base method.
+func (m *MockWebhookDelivery) UpdateColumns(id uuid.UUID, columns map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateColumns", id, columns)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateColumns indicates an expected call of UpdateColumns.
+func (mr *MockWebhookDeliveryMockRecorder) UpdateColumns(id, columns any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateColumns", reflect.TypeOf((*MockWebhookDelivery)(nil).UpdateColumns), id, columns)
+}
+
+// MockOutboxEvent is a mock of OutboxEvent interface.
+type MockOutboxEvent struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxEventMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxEventMockRecorder is the mock recorder for MockOutboxEvent.
+type MockOutboxEventMockRecorder struct {
+	mock *MockOutboxEvent
+}
+
+// NewMockOutboxEvent creates a new mock instance.
+func NewMockOutboxEvent(ctrl *gomock.Controller) *MockOutboxEvent {
+	mock := &MockOutboxEvent{ctrl: ctrl}
+	mock.recorder = &MockOutboxEventMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxEvent) EXPECT() *MockOutboxEventMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOutboxEvent) Create(events []domain.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOutboxEventMockRecorder) Create(events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOutboxEvent)(nil).Create), events)
+}
+
+// FindByPublishedSequence mocks base method.
+func (m *MockOutboxEvent) FindByPublishedSequence(publishedSequence int64) (*domain.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPublishedSequence", publishedSequence)
+	ret0, _ := ret[0].(*domain.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPublishedSequence indicates an expected call of FindByPublishedSequence.
+func (mr *MockOutboxEventMockRecorder) FindByPublishedSequence(publishedSequence any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPublishedSequence", reflect.TypeOf((*MockOutboxEvent)(nil).FindByPublishedSequence), publishedSequence)
+}
+
+// GetLastPublishedSequence mocks base method.
+func (m *MockOutboxEvent) GetLastPublishedSequence() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastPublishedSequence")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastPublishedSequence indicates an expected call of GetLastPublishedSequence.
+func (mr *MockOutboxEventMockRecorder) GetLastPublishedSequence() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastPublishedSequence", reflect.TypeOf((*MockOutboxEvent)(nil).GetLastPublishedSequence))
+}
+
+// GetPublishedAfter mocks base method.
+func (m *MockOutboxEvent) GetPublishedAfter(after int64, limit int) *[]domain.OutboxEvent {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublishedAfter", after, limit)
+	ret0, _ := ret[0].(*[]domain.OutboxEvent)
+	return ret0
+}
+
+// GetPublishedAfter indicates an expected call of GetPublishedAfter.
+func (mr *MockOutboxEventMockRecorder) GetPublishedAfter(after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublishedAfter", reflect.TypeOf((*MockOutboxEvent)(nil).GetPublishedAfter), after, limit)
+}
+
+// GetUnpublished mocks base method.
+func (m *MockOutboxEvent) GetUnpublished(limit int) *[]domain.OutboxEvent {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnpublished", limit)
+	ret0, _ := ret[0].(*[]domain.OutboxEvent)
+	return ret0
+}
+
+// GetUnpublished indicates an expected call of GetUnpublished.
+func (mr *MockOutboxEventMockRecorder) GetUnpublished(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnpublished", reflect.TypeOf((*MockOutboxEvent)(nil).GetUnpublished), limit)
+}
+
+// MarkPublished mocks base method.
+func (m *MockOutboxEvent) MarkPublished(id uuid.UUID, publishedAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPublished", id, publishedAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkPublished indicates an expected call of MarkPublished.
+func (mr *MockOutboxEventMockRecorder) MarkPublished(id, publishedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPublished", reflect.TypeOf((*MockOutboxEvent)(nil).MarkPublished), id, publishedAt)
+}
+
+// Notify mocks base method.
+func (m *MockOutboxEvent) Notify(publishedSequence int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", publishedSequence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockOutboxEventMockRecorder) Notify(publishedSequence any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockOutboxEvent)(nil).Notify), publishedSequence)
+}
+
+// PurgePublished mocks base method.
+func (m *MockOutboxEvent) PurgePublished(before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgePublished", before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgePublished indicates an expected call of PurgePublished.
+func (mr *MockOutboxEventMockRecorder) PurgePublished(before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgePublished", reflect.TypeOf((*MockOutboxEvent)(nil).PurgePublished), before)
+}
+
+// RecordFailure mocks base method.
+func (m *MockOutboxEvent) RecordFailure(id uuid.UUID, lastError string, failedAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailure", id, lastError, failedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockOutboxEventMockRecorder) RecordFailure(id, lastError, failedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockOutboxEvent)(nil).RecordFailure), id, lastError, failedAt)
+}
+
+// WithRelayLock mocks base method.
+func (m *MockOutboxEvent) WithRelayLock(fn func() error) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithRelayLock", fn)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WithRelayLock indicates an expected call of WithRelayLock.
+func (mr *MockOutboxEventMockRecorder) WithRelayLock(fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithRelayLock", reflect.TypeOf((*MockOutboxEvent)(nil).WithRelayLock), fn)
+}
+
+// MockTaskTombstone is a mock of TaskTombstone interface.
+type MockTaskTombstone struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskTombstoneMockRecorder
+	isgomock struct{}
+}
+
+// MockTaskTombstoneMockRecorder is the mock recorder for MockTaskTombstone.
+type MockTaskTombstoneMockRecorder struct {
+	mock *MockTaskTombstone
+}
+
+// NewMockTaskTombstone creates a new mock instance.
+func NewMockTaskTombstone(ctrl *gomock.Controller) *MockTaskTombstone {
+	mock := &MockTaskTombstone{ctrl: ctrl}
+	mock.recorder = &MockTaskTombstoneMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskTombstone) EXPECT() *MockTaskTombstoneMockRecorder {
+	return m.recorder
+}
+
+// GetChangedSince mocks base method.
+func (m *MockTaskTombstone) GetChangedSince(userId uuid.UUID, since domain.SyncPosition, limit int) (*[]domain.TaskTombstone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangedSince", userId, since, limit)
+	ret0, _ := ret[0].(*[]domain.TaskTombstone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChangedSince indicates an expected call of GetChangedSince.
+func (mr *MockTaskTombstoneMockRecorder) GetChangedSince(userId, since, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangedSince", reflect.TypeOf((*MockTaskTombstone)(nil).GetChangedSince), userId, since, limit)
+}
+
+// MockListPresence is a mock of ListPresence interface.
+type MockListPresence struct {
+	ctrl     *gomock.Controller
+	recorder *MockListPresenceMockRecorder
+	isgomock struct{}
+}
+
+// MockListPresenceMockRecorder is the mock recorder for MockListPresence.
+type MockListPresenceMockRecorder struct {
+	mock *MockListPresence
+}
+
+// NewMockListPresence creates a new mock instance.
+func NewMockListPresence(ctrl *gomock.Controller) *MockListPresence {
+	mock := &MockListPresence{ctrl: ctrl}
+	mock.recorder = &MockListPresenceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockListPresence) EXPECT() *MockListPresenceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockListPresence) Delete(connectionId, listId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", connectionId, listId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockListPresenceMockRecorder) Delete(connectionId, listId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockListPresence)(nil).Delete), connectionId, listId)
+}
+
+// DeleteByConnectionId mocks base method.
+func (m *MockListPresence) DeleteByConnectionId(connectionId uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByConnectionId", connectionId)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByConnectionId indicates an expected call of DeleteByConnectionId.
+func (mr *MockListPresenceMockRecorder) DeleteByConnectionId(connectionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByConnectionId", reflect.TypeOf((*MockListPresence)(nil).DeleteByConnectionId), connectionId)
+}
+
+// GetUsers mocks base method.
+func (m *MockListPresence) GetUsers(listId uuid.UUID, now time.Time) *[]domain.PresenceUser {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", listId, now)
+	ret0, _ := ret[0].(*[]domain.PresenceUser)
+	return ret0
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockListPresenceMockRecorder) GetUsers(listId, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockListPresence)(nil).GetUsers), listId, now)
+}
+
+// Notify mocks base method.
+func (m *MockListPresence) Notify(listId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", listId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockListPresenceMockRecorder) Notify(listId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockListPresence)(nil).Notify), listId)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockListPresence) PurgeExpired(before time.Time) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired", before)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockListPresenceMockRecorder) PurgeExpired(before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockListPresence)(nil).PurgeExpired), before)
+}
+
+// Touch mocks base method.
+func (m *MockListPresence) Touch(connectionId uuid.UUID, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", connectionId, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockListPresenceMockRecorder) Touch(connectionId, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockListPresence)(nil).Touch), connectionId, expiresAt)
+}
+
+// Upsert mocks base method.
+func (m *MockListPresence) Upsert(presence *domain.ListPresence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", presence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockListPresenceMockRecorder) Upsert(presence any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockListPresence)(nil).Upsert), presence)
+}
+
+// MockTransactor is a mock of Transactor interface.
+type MockTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactorMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactorMockRecorder is the mock recorder for MockTransactor.
+type MockTransactorMockRecorder struct {
+	mock *MockTransactor
+}
+
+// NewMockTransactor creates a new mock instance.
+func NewMockTransactor(ctrl *gomock.Controller) *MockTransactor {
+	mock := &MockTransactor{ctrl: ctrl}
+	mock.recorder = &MockTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
+	return m.recorder
+}
+
+// Transaction mocks base method.
+func (m *MockTransactor) Transaction(fn func(*repository.Repositories) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MockTransactorMockRecorder) Transaction(fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockTransactor)(nil).Transaction), fn)
 }
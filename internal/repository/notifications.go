@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+	"time"
+)
+
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{db}
+}
+
+// NewWorkspaceNotificationRepository создает репозиторий, которому доступны только уведомления о задачах
+// рабочего пространства workspaceId и уведомления без задачи
+func NewWorkspaceNotificationRepository(db *gorm.DB, workspaceId uuid.UUID) *NotificationRepository {
+	return &NotificationRepository{inWorkspace(db, "notifications.task_id IS NULL OR notifications.task_id IN ("+workspaceTaskIds+")", workspaceId)}
+}
+
+func (repo *NotificationRepository) Create(notification *domain.Notification) error {
+	return repo.db.Create(notification).Error
+}
+
+func (repo *NotificationRepository) FindById(id uuid.UUID) (*domain.Notification, error) {
+	var notification domain.Notification
+	result := repo.db.First(&notification, "id = ?", id)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &notification, nil
+}
+
+// GetByUserId возвращает не более limit последних уведомлений пользователя, при unreadOnly - только непрочитанные
+func (repo *NotificationRepository) GetByUserId(userId uuid.UUID, unreadOnly bool, limit int) *[]domain.Notification {
+	var notifications []domain.Notification
+
+	query := repo.db.Where("user_id = ?", userId)
+
+	if unreadOnly {
+		query = query.Where("read_at is null")
+	}
+
+	query.Order("created_at desc").Limit(limit).Find(&notifications)
+
+	return &notifications
+}
+
+func (repo *NotificationRepository) MarkRead(id uuid.UUID, readAt time.Time) error {
+	result := repo.db.
+		Model(&domain.Notification{}).
+		Where("id = ? and read_at is null", id).
+		Update("read_at", readAt)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (repo *NotificationRepository) MarkAllRead(userId uuid.UUID, readAt time.Time) error {
+	result := repo.db.
+		Model(&domain.Notification{}).
+		Where("user_id = ? and read_at is null", userId).
+		Update("read_at", readAt)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
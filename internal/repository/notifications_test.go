@@ -0,0 +1,130 @@
+package repository_test
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/helpers"
+	"testing"
+	"time"
+)
+
+func TestNotificationRepositoryCreate_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, taskId := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"notifications\"").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(taskId))
+	mock.ExpectCommit()
+
+	notificationRepository := repository.NewNotificationRepository(mockedDatabase)
+
+	err := notificationRepository.Create(&domain.Notification{UserId: userId, Type: domain.NotificationTaskAssigned, TaskId: &taskId})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationRepositoryFindById_NotFound(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	id, _ := twoUuids(t)
+
+	mock.ExpectQuery("SELECT (.+) FROM \"notifications\"").
+		WithArgs(id, 1).
+		WillReturnError(errors.New("record not found"))
+
+	notificationRepository := repository.NewNotificationRepository(mockedDatabase)
+
+	notification, err := notificationRepository.FindById(id)
+
+	require.Error(t, err)
+	require.Nil(t, notification)
+}
+
+func TestNotificationRepositoryGetByUserId_UnreadOnly(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, id := twoUuids(t)
+
+	mock.ExpectQuery("user_id = \\$1 AND read_at is null ORDER BY created_at desc LIMIT \\$2").
+		WithArgs(userId, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(id, userId))
+
+	notificationRepository := repository.NewNotificationRepository(mockedDatabase)
+
+	require.Len(t, *notificationRepository.GetByUserId(userId, true, 10), 1)
+}
+
+func TestNotificationRepositoryMarkRead_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	id, _ := twoUuids(t)
+	readAt := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"notifications\" SET \"read_at\"").
+		WithArgs(readAt, id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	notificationRepository := repository.NewNotificationRepository(mockedDatabase)
+
+	require.NoError(t, notificationRepository.MarkRead(id, readAt))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationRepositoryMarkAllRead_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, _ := twoUuids(t)
+	readAt := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"notifications\" SET \"read_at\"").
+		WithArgs(readAt, userId).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	notificationRepository := repository.NewNotificationRepository(mockedDatabase)
+
+	require.NoError(t, notificationRepository.MarkAllRead(userId, readAt))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationRepositoryWorkspaceGetByUserId_FiltersWorkspace(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, userId := twoUuids(t)
+
+	mock.ExpectQuery(`WHERE \(notifications.task_id IS NULL OR notifications.task_id IN \(SELECT id FROM tasks WHERE tasks.workspace_id = \$1\)\) AND user_id = \$2`).
+		WithArgs(workspaceId, userId, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	notificationRepository := repository.NewWorkspaceNotificationRepository(mockedDatabase, workspaceId)
+
+	require.Empty(t, *notificationRepository.GetByUserId(userId, false, 10))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationRepositoryWorkspaceMarkAllRead_FiltersWorkspace(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, userId := twoUuids(t)
+	readAt := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "notifications" SET "read_at"=\$1 WHERE \(notifications.task_id IS NULL OR notifications.task_id IN \(SELECT id FROM tasks WHERE tasks.workspace_id = \$2\)\) AND \(user_id = \$3 and read_at is null\)`).
+		WithArgs(readAt, workspaceId, userId).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	notificationRepository := repository.NewWorkspaceNotificationRepository(mockedDatabase, workspaceId)
+
+	require.NoError(t, notificationRepository.MarkAllRead(userId, readAt))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+	"strconv"
+	"time"
+)
+
+// OutboxEventsChannel - канал NOTIFY, в который передается номер опубликованного события
+const OutboxEventsChannel = "outbox_events"
+
+// outboxRelayLockKey - ключ рекомендательной блокировки, которую удерживает публикующий события экземпляр приложения
+const outboxRelayLockKey = 7_305_520_411
+
+type OutboxEventRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxEventRepository(db *gorm.DB) *OutboxEventRepository {
+	return &OutboxEventRepository{db}
+}
+
+func (repo *OutboxEventRepository) Create(events []domain.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	return repo.db.Create(&events).Error
+}
+
+// WithRelayLock выполняет fn, удерживая блокировку публикации событий на отдельном соединении, вне транзакции.
+// Возвращает false, не выполняя fn, если события уже публикует другой экземпляр приложения, - так сохраняется
+// порядок публикации. Блокировка снимается после fn или при разрыве соединения.
+func (repo *OutboxEventRepository) WithRelayLock(fn func() error) (bool, error) {
+	var locked bool
+
+	err := repo.db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Raw("SELECT pg_try_advisory_lock(?)", outboxRelayLockKey).Scan(&locked).Error; err != nil || !locked {
+			return err
+		}
+
+		defer conn.Exec("SELECT pg_advisory_unlock(?)", outboxRelayLockKey)
+
+		return fn()
+	})
+
+	return locked, err
+}
+
+// GetUnpublished возвращает не более limit неопубликованных событий в порядке их возникновения
+func (repo *OutboxEventRepository) GetUnpublished(limit int) *[]domain.OutboxEvent {
+	var events []domain.OutboxEvent
+
+	repo.db.Where("published_at IS NULL AND failed_at IS NULL").Order("sequence").Limit(limit).Find(&events)
+
+	return &events
+}
+
+// GetPublishedAfter возвращает не более limit событий с номером публикации больше after в порядке публикации
+func (repo *OutboxEventRepository) GetPublishedAfter(after int64, limit int) *[]domain.OutboxEvent {
+	var events []domain.OutboxEvent
+
+	repo.db.Where("published_sequence > ?", after).Order("published_sequence").Limit(limit).Find(&events)
+
+	return &events
+}
+
+func (repo *OutboxEventRepository) FindByPublishedSequence(publishedSequence int64) (*domain.OutboxEvent, error) {
+	var event domain.OutboxEvent
+	result := repo.db.First(&event, "published_sequence = ?", publishedSequence)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &event, nil
+}
+
+// GetLastPublishedSequence возвращает номер последнего опубликованного события или 0, если событий нет
+func (repo *OutboxEventRepository) GetLastPublishedSequence() (int64, error) {
+	var last int64
+
+	result := repo.db.Model(&domain.OutboxEvent{}).Select("COALESCE(MAX(published_sequence), 0)").Scan(&last)
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return last, nil
+}
+
+// Notify сообщает экземплярам приложения, слушающим OutboxEventsChannel, о публикации события publishedSequence
+func (repo *OutboxEventRepository) Notify(publishedSequence int64) error {
+	return repo.db.Exec("SELECT pg_notify(?, ?)", OutboxEventsChannel, strconv.FormatInt(publishedSequence, 10)).Error
+}
+
+// MarkPublished отмечает событие id опубликованным и возвращает выданный ему номер публикации
+func (repo *OutboxEventRepository) MarkPublished(id uuid.UUID, publishedAt time.Time) (int64, error) {
+	var publishedSequence int64
+
+	result := repo.db.
+		Raw("UPDATE outbox_events SET published_at = ?, published_sequence = nextval('outbox_events_published_sequence_seq') "+
+			"WHERE id = ? RETURNING published_sequence", publishedAt, id).
+		Scan(&publishedSequence)
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return 0, gorm.ErrRecordNotFound
+	}
+
+	return publishedSequence, nil
+}
+
+// RecordFailure сохраняет ошибку публикации события. Событие с заполненным failedAt больше не публикуется.
+func (repo *OutboxEventRepository) RecordFailure(id uuid.UUID, lastError string, failedAt *time.Time) error {
+	return repo.db.Model(&domain.OutboxEvent{ID: id}).Updates(map[string]interface{}{
+		"attempts":   gorm.Expr("attempts + 1"),
+		"last_error": lastError,
+		"failed_at":  failedAt,
+	}).Error
+}
+
+// PurgePublished удаляет события, опубликованные раньше before, и возвращает их количество
+func (repo *OutboxEventRepository) PurgePublished(before time.Time) (int64, error) {
+	result := repo.db.Where("published_at < ?", before).Delete(&domain.OutboxEvent{})
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,229 @@
+package repository_test
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/helpers"
+	"testing"
+	"time"
+)
+
+func TestOutboxEventRepositoryCreate_Empty(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	outboxEventRepository := repository.NewOutboxEventRepository(mockedDatabase)
+
+	require.NoError(t, outboxEventRepository.Create(nil))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxEventRepositoryCreate_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	eventId, taskId := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "outbox_events" \("type","aggregate_id","payload","attempts","last_error","created_at","published_at","failed_at","id"\)`).
+		WithArgs(domain.TaskEventCreated, taskId, `{}`, 0, "", sqlmock.AnyArg(), nil, nil, eventId).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(eventId))
+	mock.ExpectCommit()
+
+	outboxEventRepository := repository.NewOutboxEventRepository(mockedDatabase)
+
+	err := outboxEventRepository.Create([]domain.OutboxEvent{
+		{ID: eventId, Type: domain.TaskEventCreated, AggregateId: taskId, Payload: `{}`, CreatedAt: time.Now()},
+	})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxEventRepositoryWithRelayLock_Locked(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	mock.ExpectQuery(`SELECT pg_try_advisory_lock\(\$1\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(true))
+	mock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	outboxEventRepository := repository.NewOutboxEventRepository(mockedDatabase)
+
+	called := false
+	locked, err := outboxEventRepository.WithRelayLock(func() error {
+		called = true
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.True(t, locked)
+	require.True(t, called)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxEventRepositoryWithRelayLock_Busy(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	mock.ExpectQuery(`SELECT pg_try_advisory_lock\(\$1\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(false))
+
+	outboxEventRepository := repository.NewOutboxEventRepository(mockedDatabase)
+
+	locked, err := outboxEventRepository.WithRelayLock(func() error {
+		t.Fatal("fn must not be called without the lock")
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.False(t, locked)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxEventRepositoryGetUnpublished_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	eventId, _ := twoUuids(t)
+
+	mock.ExpectQuery(`SELECT \* FROM "outbox_events" WHERE published_at IS NULL AND failed_at IS NULL ORDER BY sequence LIMIT \$1`).
+		WithArgs(100).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "sequence"}).AddRow(eventId, 42))
+
+	outboxEventRepository := repository.NewOutboxEventRepository(mockedDatabase)
+
+	events := outboxEventRepository.GetUnpublished(100)
+
+	require.Len(t, *events, 1)
+	require.Equal(t, int64(42), (*events)[0].Sequence)
+}
+
+func TestOutboxEventRepositoryMarkPublished_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	eventId, _ := twoUuids(t)
+	publishedAt := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`UPDATE outbox_events SET published_at = \$1, published_sequence = nextval\('outbox_events_published_sequence_seq'\) WHERE id = \$2 RETURNING published_sequence`).
+		WithArgs(publishedAt, eventId).
+		WillReturnRows(sqlmock.NewRows([]string{"published_sequence"}).AddRow(43))
+
+	outboxEventRepository := repository.NewOutboxEventRepository(mockedDatabase)
+
+	publishedSequence, err := outboxEventRepository.MarkPublished(eventId, publishedAt)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(43), publishedSequence)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxEventRepositoryMarkPublished_NotFound(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	eventId, _ := twoUuids(t)
+
+	mock.ExpectQuery(`UPDATE outbox_events SET published_at`).
+		WillReturnRows(sqlmock.NewRows([]string{"published_sequence"}))
+
+	outboxEventRepository := repository.NewOutboxEventRepository(mockedDatabase)
+
+	_, err := outboxEventRepository.MarkPublished(eventId, time.Now())
+
+	require.Error(t, err)
+}
+
+func TestOutboxEventRepositoryRecordFailure_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	eventId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "outbox_events" SET "attempts"=attempts \+ 1,"failed_at"=\$1,"last_error"=\$2 WHERE "id" = \$3`).
+		WithArgs(nil, "timeout", eventId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	outboxEventRepository := repository.NewOutboxEventRepository(mockedDatabase)
+
+	require.NoError(t, outboxEventRepository.RecordFailure(eventId, "timeout", nil))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxEventRepositoryPurgePublished_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	before := time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "outbox_events" WHERE published_at < \$1`).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+	mock.ExpectCommit()
+
+	outboxEventRepository := repository.NewOutboxEventRepository(mockedDatabase)
+
+	purged, err := outboxEventRepository.PurgePublished(before)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(5), purged)
+}
+
+func TestOutboxEventRepositoryGetPublishedAfter_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	eventId, _ := twoUuids(t)
+
+	mock.ExpectQuery(`SELECT \* FROM "outbox_events" WHERE published_sequence > \$1 ORDER BY published_sequence LIMIT \$2`).
+		WithArgs(int64(41), 500).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "sequence"}).AddRow(eventId, 42))
+
+	outboxEventRepository := repository.NewOutboxEventRepository(mockedDatabase)
+
+	events := outboxEventRepository.GetPublishedAfter(41, 500)
+
+	require.Len(t, *events, 1)
+	require.Equal(t, eventId, (*events)[0].ID)
+}
+
+func TestOutboxEventRepositoryFindByPublishedSequence_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	eventId, _ := twoUuids(t)
+
+	mock.ExpectQuery(`SELECT \* FROM "outbox_events" WHERE published_sequence = \$1 ORDER BY "outbox_events"."id" LIMIT \$2`).
+		WithArgs(int64(42), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "sequence"}).AddRow(eventId, 42))
+
+	outboxEventRepository := repository.NewOutboxEventRepository(mockedDatabase)
+
+	event, err := outboxEventRepository.FindByPublishedSequence(42)
+
+	require.NoError(t, err)
+	require.Equal(t, eventId, event.ID)
+}
+
+func TestOutboxEventRepositoryGetLastPublishedSequence_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	mock.ExpectQuery(`SELECT COALESCE\(MAX\(published_sequence\), 0\) FROM "outbox_events"$`).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(42))
+
+	outboxEventRepository := repository.NewOutboxEventRepository(mockedDatabase)
+
+	last, err := outboxEventRepository.GetLastPublishedSequence()
+
+	require.NoError(t, err)
+	require.Equal(t, int64(42), last)
+}
+
+func TestOutboxEventRepositoryNotify_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	mock.ExpectExec(`SELECT pg_notify\(\$1, \$2\)`).
+		WithArgs(repository.OutboxEventsChannel, "42").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	outboxEventRepository := repository.NewOutboxEventRepository(mockedDatabase)
+
+	require.NoError(t, outboxEventRepository.Notify(42))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,29 +4,297 @@ import (
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"poymanov/todo/internal/domain"
+	"time"
 )
 
 type Task interface {
+	WithVersion(version int) Task
 	Create(task *domain.Task) (*domain.Task, error)
 	Update(task *domain.Task) (*domain.Task, error)
+	UpdateColumns(id uuid.UUID, columns map[string]interface{}) error
 	Delete(id uuid.UUID) error
 	IsExistsById(id uuid.UUID) bool
+	FindById(id uuid.UUID) (*domain.Task, error)
 	GetAllByUserId(id uuid.UUID) *[]domain.Task
+	GetAssignedToUserId(id uuid.UUID) *[]domain.Task
+	GetAssignedInList(listId, assigneeId uuid.UUID) *[]domain.Task
+	GetAssignedInWorkspace(workspaceId, assigneeId uuid.UUID) *[]domain.Task
+	Unassign(id uuid.UUID) error
+	GetAllByListId(id uuid.UUID) *[]domain.Task
+	GetPageByUserId(filter domain.TaskFilter, sort domain.TaskSort, after *domain.TaskCursor, limit int) (*[]domain.Task, error)
+	CountByUserId(filter domain.TaskFilter) (domain.TaskCounts, error)
+	Search(userId uuid.UUID, tsquery, language string, limit int) (*[]domain.TaskSearchResult, error)
+	GetAllByIds(ids []uuid.UUID) *[]domain.Task
+	Move(id uuid.UUID, listId *uuid.UUID, statusId uuid.UUID) error
+	FindWithTrashedById(id uuid.UUID) (*domain.Task, error)
+	GetTrashByUserId(id uuid.UUID) *[]domain.Task
+	Restore(id uuid.UUID) error
+	Purge(id uuid.UUID) error
+	LockDeletedBefore(before time.Time) *[]domain.Task
+	PurgeDeletedBefore(before time.Time) (int64, error)
+	GetArchivedByUserId(id uuid.UUID) *[]domain.Task
+	Unarchive(id uuid.UUID, unarchivedAt time.Time) error
+	ArchiveCompleted(now time.Time) (int64, error)
+	GetChangedSince(userId uuid.UUID, since domain.SyncPosition, limit int) (*[]domain.Task, error)
+	CountAllByAuthorId(id uuid.UUID) (domain.UserTaskCounts, error)
+}
+
+type TaskDependency interface {
+	Lock() error
+	Create(dependency *domain.TaskDependency) error
+	Delete(taskId, blockedById uuid.UUID) error
+	IsExists(taskId, blockedById uuid.UUID) bool
+	IsReachable(fromId, toId uuid.UUID) bool
+	HasOpenBlockers(taskId uuid.UUID) bool
+	GetBlockersByTaskId(taskId uuid.UUID) *[]domain.Task
+}
+
+type TaskTag interface {
+	GetByTaskId(taskId uuid.UUID) []string
+	Replace(taskId uuid.UUID, names []string) error
+	Add(taskId uuid.UUID, names []string) error
+}
+
+type TimeEntry interface {
+	Create(entry *domain.TimeEntry) (*domain.TimeEntry, error)
+	Start(entry *domain.TimeEntry) (*domain.TimeEntry, error)
+	Update(entry *domain.TimeEntry) (*domain.TimeEntry, error)
+	Delete(id uuid.UUID) error
+	FindById(id uuid.UUID) (*domain.TimeEntry, error)
+	FindRunningByUserId(userId uuid.UUID) (*domain.TimeEntry, error)
+	GetAllByTaskId(taskId uuid.UUID) *[]domain.TimeEntry
+	GetReport(userId uuid.UUID, from, to time.Time, groupBy string) (*[]domain.TimeReportRow, error)
+}
+
+type List interface {
+	Create(list *domain.List) (*domain.List, error)
+	Delete(id uuid.UUID) error
+	FindById(id uuid.UUID) (*domain.List, error)
+	GetAllByUserId(id uuid.UUID) *[]domain.List
+	GetUserIds(id uuid.UUID) []uuid.UUID
+}
+
+type ListMember interface {
+	Create(member *domain.ListMember) error
+	Find(listId, userId uuid.UUID) (*domain.ListMember, error)
+	GetByListId(listId uuid.UUID) *[]domain.ListMember
+	UpdateRole(listId, userId uuid.UUID, role string) error
+	Delete(listId, userId uuid.UUID) error
+}
+
+type Notification interface {
+	Create(notification *domain.Notification) error
+	FindById(id uuid.UUID) (*domain.Notification, error)
+	GetByUserId(userId uuid.UUID, unreadOnly bool, limit int) *[]domain.Notification
+	MarkRead(id uuid.UUID, readAt time.Time) error
+	MarkAllRead(userId uuid.UUID, readAt time.Time) error
+}
+
+type TaskHistory interface {
+	Create(entries []domain.TaskHistory) error
+	GetByTaskId(taskId uuid.UUID) *[]domain.TaskHistory
+	FindLastByActorId(actorId uuid.UUID, since time.Time) (*domain.TaskHistory, error)
+	GetByRevision(taskId uuid.UUID, revision int) *[]domain.TaskHistory
+	MarkUndone(taskId uuid.UUID, revisions []int) error
+}
+
+type IdempotencyKey interface {
+	Create(idempotencyKey *domain.IdempotencyKey) (bool, error)
+	Find(userId uuid.UUID, key string) (*domain.IdempotencyKey, error)
+	SaveResponse(idempotencyKey *domain.IdempotencyKey) error
+	Delete(userId uuid.UUID, key string) error
+	DeleteCreatedBefore(before time.Time) (int64, error)
+}
+
+type Workspace interface {
+	Create(workspace *domain.Workspace, ownerId uuid.UUID) error
+	FindPersonal(userId uuid.UUID) (*domain.Workspace, error)
+	FindByMember(id, userId uuid.UUID) (*domain.Workspace, error)
+	GetAllByUserId(id uuid.UUID) *[]domain.Workspace
+}
+
+type WorkspaceMember interface {
+	Create(member *domain.WorkspaceMember) error
+	Find(workspaceId, userId uuid.UUID) (*domain.WorkspaceMember, error)
+	GetByWorkspaceId(workspaceId uuid.UUID) *[]domain.WorkspaceMember
+	Delete(workspaceId, userId uuid.UUID) error
+}
+
+type Invite interface {
+	Create(invite *domain.Invite) error
+	FindById(id uuid.UUID) (*domain.Invite, error)
+	MarkUsed(id uuid.UUID, usedAt time.Time) (bool, error)
+}
+
+type SmartList interface {
+	Create(smartList *domain.SmartList) (*domain.SmartList, error)
+	Delete(id uuid.UUID) error
+	FindById(id uuid.UUID) (*domain.SmartList, error)
+	GetAllByUserId(id uuid.UUID) *[]domain.SmartList
+}
+
+type Status interface {
+	Create(status *domain.Status) (*domain.Status, error)
+	CreateBatch(statuses *[]domain.Status) error
+	Delete(id uuid.UUID) error
+	FindById(id uuid.UUID) (*domain.Status, error)
+	GetByScope(userId uuid.UUID, listId *uuid.UUID) *[]domain.Status
+	IsUsed(id uuid.UUID) bool
 }
 
 type User interface {
 	Create(user *domain.User) (*domain.User, error)
 	FindByEmail(email string) (*domain.User, error)
+	UpdateAutoArchiveDays(id uuid.UUID, days *int) error
+	FindById(id uuid.UUID) (*domain.User, error)
+	Search(query string, limit, offset int) (*[]domain.User, error)
+	UpdateColumns(id uuid.UUID, columns map[string]interface{}) error
+}
+
+type AuditEvent interface {
+	Create(event *domain.AuditEvent) error
+	Search(filter domain.AuditFilter, limit, offset int) (*[]domain.AuditEvent, error)
+}
+
+type Webhook interface {
+	Create(webhook *domain.Webhook) error
+	FindById(id uuid.UUID) (*domain.Webhook, error)
+	GetByUserId(userId uuid.UUID) *[]domain.Webhook
+	Delete(id uuid.UUID) error
+}
+
+type WebhookDelivery interface {
+	Create(delivery *domain.WebhookDelivery) error
+	EnqueueTaskEvent(event domain.TaskEvent, payload string) error
+	FindById(id uuid.UUID) (*domain.WebhookDelivery, error)
+	GetByWebhookId(webhookId uuid.UUID, limit int) *[]domain.WebhookDelivery
+	ClaimDue(now time.Time, lease time.Duration, limit int) (*[]domain.WebhookDelivery, error)
+	UpdateColumns(id uuid.UUID, columns map[string]interface{}) error
+}
+
+type OutboxEvent interface {
+	Create(events []domain.OutboxEvent) error
+	WithRelayLock(fn func() error) (bool, error)
+	GetUnpublished(limit int) *[]domain.OutboxEvent
+	GetPublishedAfter(after int64, limit int) *[]domain.OutboxEvent
+	FindByPublishedSequence(publishedSequence int64) (*domain.OutboxEvent, error)
+	GetLastPublishedSequence() (int64, error)
+	Notify(publishedSequence int64) error
+	MarkPublished(id uuid.UUID, publishedAt time.Time) (int64, error)
+	RecordFailure(id uuid.UUID, lastError string, failedAt *time.Time) error
+	PurgePublished(before time.Time) (int64, error)
+}
+
+type TaskTombstone interface {
+	GetChangedSince(userId uuid.UUID, since domain.SyncPosition, limit int) (*[]domain.TaskTombstone, error)
+}
+
+type ListPresence interface {
+	Upsert(presence *domain.ListPresence) error
+	Delete(connectionId, listId uuid.UUID) error
+	DeleteByConnectionId(connectionId uuid.UUID) ([]uuid.UUID, error)
+	Touch(connectionId uuid.UUID, expiresAt time.Time) error
+	GetUsers(listId uuid.UUID, now time.Time) *[]domain.PresenceUser
+	PurgeExpired(before time.Time) ([]uuid.UUID, error)
+	Notify(listId uuid.UUID) error
+}
+
+// Transactor выполняет fn в транзакции, передавая ей репозитории, работающие в рамках этой транзакции
+type Transactor interface {
+	Transaction(fn func(repos *Repositories) error) error
 }
 
 type Repositories struct {
-	Task Task
-	User User
+	db *gorm.DB
+
+	Transactor      Transactor
+	Task            Task
+	TaskDependency  TaskDependency
+	TaskTag         TaskTag
+	TaskHistory     TaskHistory
+	TaskTombstone   TaskTombstone
+	TimeEntry       TimeEntry
+	List            List
+	ListMember      ListMember
+	Notification    Notification
+	SmartList       SmartList
+	Status          Status
+	User            User
+	IdempotencyKey  IdempotencyKey
+	Workspace       Workspace
+	WorkspaceMember WorkspaceMember
+	Invite          Invite
+	AuditEvent      AuditEvent
+	Webhook         Webhook
+	WebhookDelivery WebhookDelivery
+	OutboxEvent     OutboxEvent
+	ListPresence    ListPresence
 }
 
 func NewRepositories(db *gorm.DB) *Repositories {
-	return &Repositories{
-		Task: NewTaskRepository(db),
-		User: NewUserRepository(db),
+	return newRepositories(db, nil)
+}
+
+// workspaceTaskIds и workspaceListIds - подзапросы идентификаторов задач и списков рабочего пространства,
+// которыми ограничиваются данные, принадлежащие задачам и спискам
+const (
+	workspaceTaskIds = "SELECT id FROM tasks WHERE tasks.workspace_id = ?"
+	workspaceListIds = "SELECT id FROM lists WHERE lists.workspace_id = ?"
+)
+
+// ForWorkspace возвращает репозитории, в которых задачи, списки и принадлежащие им данные ограничены
+// рабочим пространством workspaceId. Пользователи, рабочие пространства, вебхуки, журнал аудита и outbox
+// не принадлежат пространству и остаются общими.
+func (r *Repositories) ForWorkspace(workspaceId uuid.UUID) *Repositories {
+	return newRepositories(r.db, &workspaceId)
+}
+
+func newRepositories(db *gorm.DB, workspaceId *uuid.UUID) *Repositories {
+	repos := &Repositories{
+		db:              db,
+		Transactor:      &TransactionRepository{db: db, workspaceId: workspaceId},
+		Task:            NewTaskRepository(db),
+		TaskDependency:  NewTaskDependencyRepository(db),
+		TaskTag:         NewTaskTagRepository(db),
+		TaskHistory:     NewTaskHistoryRepository(db),
+		TaskTombstone:   NewTaskTombstoneRepository(db),
+		TimeEntry:       NewTimeEntryRepository(db),
+		List:            NewListRepository(db),
+		ListMember:      NewListMemberRepository(db),
+		Notification:    NewNotificationRepository(db),
+		SmartList:       NewSmartListRepository(db),
+		Status:          NewStatusRepository(db),
+		User:            NewUserRepository(db),
+		IdempotencyKey:  NewIdempotencyKeyRepository(db),
+		Workspace:       NewWorkspaceRepository(db),
+		WorkspaceMember: NewWorkspaceMemberRepository(db),
+		Invite:          NewInviteRepository(db),
+		AuditEvent:      NewAuditEventRepository(db),
+		Webhook:         NewWebhookRepository(db),
+		WebhookDelivery: NewWebhookDeliveryRepository(db),
+		OutboxEvent:     NewOutboxEventRepository(db),
+		ListPresence:    NewListPresenceRepository(db),
+	}
+
+	if workspaceId != nil {
+		repos.Task = NewWorkspaceTaskRepository(db, *workspaceId)
+		repos.TaskDependency = NewWorkspaceTaskDependencyRepository(db, *workspaceId)
+		repos.TaskTag = NewWorkspaceTaskTagRepository(db, *workspaceId)
+		repos.TaskHistory = NewWorkspaceTaskHistoryRepository(db, *workspaceId)
+		repos.TaskTombstone = NewWorkspaceTaskTombstoneRepository(db, *workspaceId)
+		repos.TimeEntry = NewWorkspaceTimeEntryRepository(db, *workspaceId)
+		repos.List = NewWorkspaceListRepository(db, *workspaceId)
+		repos.ListMember = NewWorkspaceListMemberRepository(db, *workspaceId)
+		repos.Notification = NewWorkspaceNotificationRepository(db, *workspaceId)
+		repos.Status = NewWorkspaceStatusRepository(db, *workspaceId)
+		repos.ListPresence = NewWorkspaceListPresenceRepository(db, *workspaceId)
 	}
+
+	return repos
+}
+
+// inWorkspace возвращает соединение, добавляющее ко всем запросам условие condition на рабочее пространство
+// workspaceId. Как и для задач, условие не применяется к сырым запросам.
+func inWorkspace(db *gorm.DB, condition string, workspaceId uuid.UUID) *gorm.DB {
+	return db.Where(condition, workspaceId).Session(&gorm.Session{})
 }
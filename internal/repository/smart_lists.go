@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+)
+
+type SmartListRepository struct {
+	db *gorm.DB
+}
+
+func NewSmartListRepository(db *gorm.DB) *SmartListRepository {
+	return &SmartListRepository{db}
+}
+
+func (repo *SmartListRepository) Create(smartList *domain.SmartList) (*domain.SmartList, error) {
+	result := repo.db.Create(smartList)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return smartList, nil
+}
+
+func (repo *SmartListRepository) Delete(id uuid.UUID) error {
+	result := repo.db.Delete(&domain.SmartList{}, id)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (repo *SmartListRepository) FindById(id uuid.UUID) (*domain.SmartList, error) {
+	var smartList domain.SmartList
+	result := repo.db.First(&smartList, "id = ?", id)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &smartList, nil
+}
+
+func (repo *SmartListRepository) GetAllByUserId(id uuid.UUID) *[]domain.SmartList {
+	var smartLists []domain.SmartList
+
+	repo.db.
+		Where("user_id = ?", id).
+		Order("created_at asc").
+		Find(&smartLists)
+
+	return &smartLists
+}
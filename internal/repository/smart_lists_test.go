@@ -0,0 +1,96 @@
+package repository_test
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/helpers"
+	"testing"
+)
+
+func TestSmartListRepositoryCreate_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	smartListUuid := faker.UUIDHyphenated()
+	userId, _ := twoUuids(t)
+	isCompleted := false
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT").
+		WithArgs(userId, "Urgent", `{"completed":false,"tags_all":["urgent"]}`, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(smartListUuid))
+	mock.ExpectCommit()
+
+	smartListRepository := repository.NewSmartListRepository(mockedDatabase)
+
+	smartList, err := smartListRepository.Create(&domain.SmartList{
+		UserId: userId,
+		Name:   "Urgent",
+		Filter: domain.SmartListFilter{Completed: &isCompleted, TagsAll: []string{"urgent"}},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, smartListUuid, smartList.ID.String())
+}
+
+func TestSmartListRepositoryFindById_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	smartListId, _ := twoUuids(t)
+
+	mock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "filter"}).AddRow(smartListId, []byte(`{"tags_any":["home","work"]}`)))
+
+	smartListRepository := repository.NewSmartListRepository(mockedDatabase)
+
+	smartList, err := smartListRepository.FindById(smartListId)
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"home", "work"}, smartList.Filter.TagsAny)
+}
+
+func TestSmartListRepositoryFindById_Failed(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	smartListId, _ := twoUuids(t)
+
+	mock.ExpectQuery("SELECT").WillReturnError(gorm.ErrRecordNotFound)
+
+	smartListRepository := repository.NewSmartListRepository(mockedDatabase)
+
+	smartList, err := smartListRepository.FindById(smartListId)
+
+	require.Nil(t, smartList)
+	require.Equal(t, gorm.ErrRecordNotFound, err)
+}
+
+func TestSmartListRepositoryGetAllByUserId_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, smartListId := twoUuids(t)
+
+	mock.ExpectQuery("SELECT").
+		WithArgs(userId).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "filter"}).AddRow(smartListId, "{}"))
+
+	smartListRepository := repository.NewSmartListRepository(mockedDatabase)
+
+	require.Len(t, *smartListRepository.GetAllByUserId(userId), 1)
+}
+
+func TestSmartListRepositoryDelete_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	smartListId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	smartListRepository := repository.NewSmartListRepository(mockedDatabase)
+
+	require.NoError(t, smartListRepository.Delete(smartListId))
+}
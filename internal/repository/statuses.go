@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+)
+
+type StatusRepository struct {
+	db *gorm.DB
+}
+
+func NewStatusRepository(db *gorm.DB) *StatusRepository {
+	return &StatusRepository{db}
+}
+
+// NewWorkspaceStatusRepository создает репозиторий, которому доступны только статусы списков
+// рабочего пространства workspaceId и статусы пользователей по умолчанию
+func NewWorkspaceStatusRepository(db *gorm.DB, workspaceId uuid.UUID) *StatusRepository {
+	return &StatusRepository{inWorkspace(db, "statuses.list_id IS NULL OR statuses.list_id IN ("+workspaceListIds+")", workspaceId)}
+}
+
+func (repo *StatusRepository) Create(status *domain.Status) (*domain.Status, error) {
+	result := repo.db.Create(status)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return status, nil
+}
+
+func (repo *StatusRepository) CreateBatch(statuses *[]domain.Status) error {
+	result := repo.db.Create(statuses)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (repo *StatusRepository) Delete(id uuid.UUID) error {
+	result := repo.db.Delete(&domain.Status{}, id)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (repo *StatusRepository) FindById(id uuid.UUID) (*domain.Status, error) {
+	var status domain.Status
+	result := repo.db.First(&status, "id = ?", id)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &status, nil
+}
+
+// GetByScope возвращает статусы списка listId или, если listId не задан, статусы пользователя userId по умолчанию
+func (repo *StatusRepository) GetByScope(userId uuid.UUID, listId *uuid.UUID) *[]domain.Status {
+	var statuses []domain.Status
+
+	query := repo.db.Where("list_id = ?", listId)
+
+	if listId == nil {
+		query = repo.db.Where("user_id = ? and list_id is null", userId)
+	}
+
+	query.Order("position asc").Find(&statuses)
+
+	return &statuses
+}
+
+// IsUsed проверяет, есть ли задачи в статусе id. Условие на рабочее пространство относится к статусам,
+// поэтому запрос к задачам выполняется без него: идентификатор статуса уже определяет его список.
+func (repo *StatusRepository) IsUsed(id uuid.UUID) bool {
+	var count int64
+
+	repo.db.
+		Session(&gorm.Session{NewDB: true}).
+		Model(&domain.Task{}).
+		Where("status_id = ?", id).
+		Count(&count)
+
+	return count > 0
+}
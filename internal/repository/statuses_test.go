@@ -0,0 +1,137 @@
+package repository_test
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/helpers"
+	"testing"
+)
+
+func TestStatusRepositoryCreate_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	statusUuid := faker.UUIDHyphenated()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(statusUuid))
+	mock.ExpectCommit()
+
+	statusRepository := repository.NewStatusRepository(mockedDatabase)
+
+	status, err := statusRepository.Create(&domain.Status{Name: faker.Word()})
+
+	require.NoError(t, err)
+	require.Equal(t, statusUuid, status.ID.String())
+}
+
+func TestStatusRepositoryCreateBatch_Failed(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT").WillReturnError(gorm.ErrInvalidValue)
+	mock.ExpectRollback()
+
+	statusRepository := repository.NewStatusRepository(mockedDatabase)
+
+	err := statusRepository.CreateBatch(&[]domain.Status{{Name: faker.Word()}, {Name: faker.Word()}})
+
+	require.Equal(t, gorm.ErrInvalidValue, err)
+}
+
+func TestStatusRepositoryDelete_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	statusId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	statusRepository := repository.NewStatusRepository(mockedDatabase)
+
+	require.NoError(t, statusRepository.Delete(statusId))
+}
+
+func TestStatusRepositoryGetByScope_User(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	mock.ExpectQuery("list_id is null").
+		WithArgs(userId).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(faker.UUIDHyphenated(), "Backlog"))
+
+	statusRepository := repository.NewStatusRepository(mockedDatabase)
+
+	statuses := statusRepository.GetByScope(userId, nil)
+
+	require.Len(t, *statuses, 1)
+	require.Equal(t, "Backlog", (*statuses)[0].Name)
+}
+
+func TestStatusRepositoryGetByScope_List(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, listId := twoUuids(t)
+
+	mock.ExpectQuery("list_id = ").
+		WithArgs(listId).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	statusRepository := repository.NewStatusRepository(mockedDatabase)
+
+	require.Empty(t, *statusRepository.GetByScope(userId, &listId))
+}
+
+func TestStatusRepositoryIsUsed_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	statusId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	mock.ExpectQuery("SELECT count").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	statusRepository := repository.NewStatusRepository(mockedDatabase)
+
+	require.True(t, statusRepository.IsUsed(statusId))
+}
+
+func TestStatusRepositoryWorkspaceFindById_OtherWorkspace(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, statusId := twoUuids(t)
+
+	mock.ExpectQuery(`FROM "statuses" WHERE \(statuses.list_id IS NULL OR statuses.list_id IN \(SELECT id FROM lists WHERE lists.workspace_id = \$1\)\) AND id = \$2`).
+		WithArgs(workspaceId, statusId, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	statusRepository := repository.NewWorkspaceStatusRepository(mockedDatabase, workspaceId)
+
+	status, err := statusRepository.FindById(statusId)
+
+	require.Nil(t, status)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatusRepositoryWorkspaceIsUsed_QueriesTasks(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, statusId := twoUuids(t)
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "tasks" WHERE status_id = \$1 AND "tasks"."deleted_at" IS NULL$`).
+		WithArgs(statusId).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	statusRepository := repository.NewWorkspaceStatusRepository(mockedDatabase, workspaceId)
+
+	require.True(t, statusRepository.IsUsed(statusId))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
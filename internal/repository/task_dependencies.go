@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+)
+
+// taskDependencyLockKey - ключ рекомендательной блокировки, под которой создаются зависимости задач
+const taskDependencyLockKey = 7_305_520_412
+
+type TaskDependencyRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskDependencyRepository(db *gorm.DB) *TaskDependencyRepository {
+	return &TaskDependencyRepository{db}
+}
+
+// NewWorkspaceTaskDependencyRepository создает репозиторий, которому доступны только зависимости задач рабочего пространства workspaceId
+func NewWorkspaceTaskDependencyRepository(db *gorm.DB, workspaceId uuid.UUID) *TaskDependencyRepository {
+	return &TaskDependencyRepository{inWorkspace(db, "task_dependencies.task_id IN ("+workspaceTaskIds+")", workspaceId)}
+}
+
+// Lock берет до конца транзакции блокировку, под которой проверяется отсутствие циклов и создаются зависимости.
+// Вызывается в транзакции.
+func (repo *TaskDependencyRepository) Lock() error {
+	return repo.db.Exec("SELECT pg_advisory_xact_lock(?)", taskDependencyLockKey).Error
+}
+
+func (repo *TaskDependencyRepository) Create(dependency *domain.TaskDependency) error {
+	result := repo.db.Create(dependency)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (repo *TaskDependencyRepository) Delete(taskId, blockedById uuid.UUID) error {
+	result := repo.db.
+		Where("task_id = ? and blocked_by_id = ?", taskId, blockedById).
+		Delete(&domain.TaskDependency{})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (repo *TaskDependencyRepository) IsExists(taskId, blockedById uuid.UUID) bool {
+	var count int64
+
+	repo.db.
+		Model(&domain.TaskDependency{}).
+		Where("task_id = ? and blocked_by_id = ?", taskId, blockedById).
+		Count(&count)
+
+	return count > 0
+}
+
+// IsReachable проверяет, зависит ли задача fromId (напрямую или через цепочку других задач) от задачи toId
+func (repo *TaskDependencyRepository) IsReachable(fromId, toId uuid.UUID) bool {
+	var isReachable bool
+
+	repo.db.Raw(`
+		WITH RECURSIVE chain AS (
+			SELECT blocked_by_id FROM task_dependencies WHERE task_id = ?
+			UNION
+			SELECT d.blocked_by_id FROM task_dependencies d JOIN chain c ON d.task_id = c.blocked_by_id
+		)
+		SELECT exists(SELECT 1 FROM chain WHERE blocked_by_id = ?)`, fromId, toId).
+		Scan(&isReachable)
+
+	return isReachable
+}
+
+func (repo *TaskDependencyRepository) HasOpenBlockers(taskId uuid.UUID) bool {
+	var count int64
+
+	repo.db.
+		Table("task_dependencies").
+		Joins("join tasks on tasks.id = task_dependencies.blocked_by_id").
+		Where("task_dependencies.task_id = ? and tasks.deleted_at is null and tasks.is_completed = false", taskId).
+		Count(&count)
+
+	return count > 0
+}
+
+func (repo *TaskDependencyRepository) GetBlockersByTaskId(taskId uuid.UUID) *[]domain.Task {
+	var tasks []domain.Task
+
+	repo.db.
+		Table("tasks").
+		Joins("join task_dependencies on task_dependencies.blocked_by_id = tasks.id").
+		Where("tasks.deleted_at is null and task_dependencies.task_id = ?", taskId).
+		Order("tasks.created_at desc").
+		Select("tasks.*").
+		Scan(&tasks)
+
+	return &tasks
+}
@@ -0,0 +1,197 @@
+package repository_test
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/helpers"
+	"testing"
+)
+
+func TestTaskDependencyRepositoryCreate_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, blockedById := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	dependencyRepository := repository.NewTaskDependencyRepository(mockedDatabase)
+
+	err := dependencyRepository.Create(&domain.TaskDependency{TaskId: taskId, BlockedById: blockedById})
+
+	require.NoError(t, err)
+}
+
+func TestTaskDependencyRepositoryCreate_Failed(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, blockedById := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT").WillReturnError(gorm.ErrDuplicatedKey)
+	mock.ExpectRollback()
+
+	dependencyRepository := repository.NewTaskDependencyRepository(mockedDatabase)
+
+	err := dependencyRepository.Create(&domain.TaskDependency{TaskId: taskId, BlockedById: blockedById})
+
+	require.Error(t, err)
+	require.Equal(t, gorm.ErrDuplicatedKey, err)
+}
+
+func TestTaskDependencyRepositoryDelete_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, blockedById := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	dependencyRepository := repository.NewTaskDependencyRepository(mockedDatabase)
+
+	err := dependencyRepository.Delete(taskId, blockedById)
+
+	require.NoError(t, err)
+}
+
+func TestTaskDependencyRepositoryDelete_Failed(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, blockedById := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE").WillReturnError(gorm.ErrInvalidValue)
+	mock.ExpectRollback()
+
+	dependencyRepository := repository.NewTaskDependencyRepository(mockedDatabase)
+
+	err := dependencyRepository.Delete(taskId, blockedById)
+
+	require.Error(t, err)
+	require.Equal(t, gorm.ErrInvalidValue, err)
+}
+
+func TestTaskDependencyRepositoryIsExists_Existed(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, blockedById := twoUuids(t)
+
+	mock.ExpectQuery("SELECT count").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	dependencyRepository := repository.NewTaskDependencyRepository(mockedDatabase)
+
+	require.True(t, dependencyRepository.IsExists(taskId, blockedById))
+}
+
+func TestTaskDependencyRepositoryIsExists_NotExisted(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, blockedById := twoUuids(t)
+
+	mock.ExpectQuery("SELECT count").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	dependencyRepository := repository.NewTaskDependencyRepository(mockedDatabase)
+
+	require.False(t, dependencyRepository.IsExists(taskId, blockedById))
+}
+
+func TestTaskDependencyRepositoryLock_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	mock.ExpectExec("pg_advisory_xact_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	dependencyRepository := repository.NewTaskDependencyRepository(mockedDatabase)
+
+	require.NoError(t, dependencyRepository.Lock())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskDependencyRepositoryIsReachable_Reachable(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, blockedById := twoUuids(t)
+
+	mock.ExpectQuery("WITH RECURSIVE").
+		WithArgs(taskId, blockedById).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	dependencyRepository := repository.NewTaskDependencyRepository(mockedDatabase)
+
+	require.True(t, dependencyRepository.IsReachable(taskId, blockedById))
+}
+
+func TestTaskDependencyRepositoryIsReachable_NotReachable(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, blockedById := twoUuids(t)
+
+	mock.ExpectQuery("WITH RECURSIVE").
+		WithArgs(taskId, blockedById).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	dependencyRepository := repository.NewTaskDependencyRepository(mockedDatabase)
+
+	require.False(t, dependencyRepository.IsReachable(taskId, blockedById))
+}
+
+func TestTaskDependencyRepositoryHasOpenBlockers_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, _ := twoUuids(t)
+
+	mock.ExpectQuery("SELECT count").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	dependencyRepository := repository.NewTaskDependencyRepository(mockedDatabase)
+
+	require.True(t, dependencyRepository.HasOpenBlockers(taskId))
+}
+
+func TestTaskDependencyRepositoryGetBlockersByTaskId_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, blockedById := twoUuids(t)
+
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(blockedById))
+
+	dependencyRepository := repository.NewTaskDependencyRepository(mockedDatabase)
+
+	result := dependencyRepository.GetBlockersByTaskId(taskId)
+
+	require.NotEmpty(t, result)
+	blockers := *result
+	require.Equal(t, blockedById, blockers[0].ID)
+}
+
+func twoUuids(t *testing.T) (uuid.UUID, uuid.UUID) {
+	t.Helper()
+
+	taskId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	blockedById, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	return taskId, blockedById
+}
+
+func TestTaskDependencyRepositoryWorkspaceHasOpenBlockers_FiltersWorkspace(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, taskId := twoUuids(t)
+
+	mock.ExpectQuery(`WHERE task_dependencies.task_id IN \(SELECT id FROM tasks WHERE tasks.workspace_id = \$1\) AND \(task_dependencies.task_id = \$2`).
+		WithArgs(workspaceId, taskId).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	taskDependencyRepository := repository.NewWorkspaceTaskDependencyRepository(mockedDatabase, workspaceId)
+
+	require.False(t, taskDependencyRepository.HasOpenBlockers(taskId))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+	"time"
+)
+
+type TaskHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskHistoryRepository(db *gorm.DB) *TaskHistoryRepository {
+	return &TaskHistoryRepository{db}
+}
+
+// NewWorkspaceTaskHistoryRepository создает репозиторий, которому доступна только история задач рабочего пространства workspaceId
+func NewWorkspaceTaskHistoryRepository(db *gorm.DB, workspaceId uuid.UUID) *TaskHistoryRepository {
+	return &TaskHistoryRepository{inWorkspace(db, "task_history.task_id IN ("+workspaceTaskIds+")", workspaceId)}
+}
+
+func (repo *TaskHistoryRepository) Create(entries []domain.TaskHistory) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	return repo.db.Create(&entries).Error
+}
+
+// FindLastByActorId возвращает последнее неотмененное изменение пользователя actorId, сделанное не раньше since
+func (repo *TaskHistoryRepository) FindLastByActorId(actorId uuid.UUID, since time.Time) (*domain.TaskHistory, error) {
+	var entry domain.TaskHistory
+
+	result := repo.db.
+		Where("actor_id = ? and created_at >= ? and not undone and field <> ?", actorId, since, domain.TaskFieldArchived).
+		Order("created_at desc, revision desc").
+		First(&entry)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &entry, nil
+}
+
+func (repo *TaskHistoryRepository) GetByRevision(taskId uuid.UUID, revision int) *[]domain.TaskHistory {
+	var entries []domain.TaskHistory
+
+	repo.db.Where("task_id = ? and revision = ?", taskId, revision).Find(&entries)
+
+	return &entries
+}
+
+// MarkUndone исключает ревизии revisions задачи из стека отмены
+func (repo *TaskHistoryRepository) MarkUndone(taskId uuid.UUID, revisions []int) error {
+	result := repo.db.
+		Model(&domain.TaskHistory{}).
+		Where("task_id = ? and revision in ?", taskId, revisions).
+		Update("undone", true)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+// GetByTaskId возвращает историю изменений задачи, начиная с последних
+func (repo *TaskHistoryRepository) GetByTaskId(taskId uuid.UUID) *[]domain.TaskHistory {
+	var entries []domain.TaskHistory
+
+	repo.db.
+		Where("task_id = ?", taskId).
+		Order("revision desc, created_at desc").
+		Find(&entries)
+
+	return &entries
+}
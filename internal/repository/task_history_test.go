@@ -0,0 +1,131 @@
+package repository_test
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/helpers"
+	"testing"
+	"time"
+)
+
+func TestTaskHistoryRepositoryCreate_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, actorId := twoUuids(t)
+	description := "test"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"task_history\"").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(taskId))
+	mock.ExpectCommit()
+
+	taskHistoryRepository := repository.NewTaskHistoryRepository(mockedDatabase)
+
+	err := taskHistoryRepository.Create([]domain.TaskHistory{
+		{TaskId: taskId, Revision: 1, ActorId: &actorId, Field: domain.TaskFieldDescription, NewValue: &description},
+	})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskHistoryRepositoryCreate_Empty(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskHistoryRepository := repository.NewTaskHistoryRepository(mockedDatabase)
+
+	require.NoError(t, taskHistoryRepository.Create([]domain.TaskHistory{}))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskHistoryRepositoryGetByTaskId_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, _ := twoUuids(t)
+
+	mock.ExpectQuery("ORDER BY revision desc, created_at desc").
+		WithArgs(taskId).
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "revision", "field"}).
+			AddRow(taskId, 2, domain.TaskFieldIsCompleted).
+			AddRow(taskId, 1, domain.TaskFieldDescription))
+
+	taskHistoryRepository := repository.NewTaskHistoryRepository(mockedDatabase)
+
+	entries := taskHistoryRepository.GetByTaskId(taskId)
+
+	require.Len(t, *entries, 2)
+	require.Equal(t, 2, (*entries)[0].Revision)
+}
+
+func TestTaskHistoryRepositoryFindLastByActorId_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, actorId := twoUuids(t)
+	since := time.Now().Add(-time.Minute)
+
+	mock.ExpectQuery("not undone and field <> \\$3 ORDER BY created_at desc, revision desc").
+		WithArgs(actorId, since, domain.TaskFieldArchived, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "revision", "actor_id"}).AddRow(taskId, 3, actorId))
+
+	taskHistoryRepository := repository.NewTaskHistoryRepository(mockedDatabase)
+
+	entry, err := taskHistoryRepository.FindLastByActorId(actorId, since)
+
+	require.NoError(t, err)
+	require.Equal(t, taskId, entry.TaskId)
+	require.Equal(t, 3, entry.Revision)
+}
+
+func TestTaskHistoryRepositoryFindLastByActorId_NotFound(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	_, actorId := twoUuids(t)
+
+	mock.ExpectQuery("SELECT").WillReturnError(gorm.ErrRecordNotFound)
+
+	taskHistoryRepository := repository.NewTaskHistoryRepository(mockedDatabase)
+
+	entry, err := taskHistoryRepository.FindLastByActorId(actorId, time.Now())
+
+	require.Error(t, err)
+	require.Nil(t, entry)
+}
+
+func TestTaskHistoryRepositoryMarkUndone_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"task_history\" SET \"undone\"=\\$1 WHERE task_id = \\$2 and revision in \\(\\$3,\\$4\\)").
+		WithArgs(true, taskId, 3, 4).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	taskHistoryRepository := repository.NewTaskHistoryRepository(mockedDatabase)
+
+	require.NoError(t, taskHistoryRepository.MarkUndone(taskId, []int{3, 4}))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskHistoryRepositoryWorkspaceFindLastByActorId_OtherWorkspace(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, actorId := twoUuids(t)
+	since := time.Now().Add(-time.Minute)
+
+	mock.ExpectQuery(`FROM "task_history" WHERE task_history.task_id IN \(SELECT id FROM tasks WHERE tasks.workspace_id = \$1\) AND \(actor_id = \$2`).
+		WithArgs(workspaceId, actorId, since, domain.TaskFieldArchived, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	taskHistoryRepository := repository.NewWorkspaceTaskHistoryRepository(mockedDatabase, workspaceId)
+
+	entry, err := taskHistoryRepository.FindLastByActorId(actorId, since)
+
+	require.Nil(t, entry)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
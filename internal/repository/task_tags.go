@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"poymanov/todo/internal/domain"
+)
+
+type TaskTagRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskTagRepository(db *gorm.DB) *TaskTagRepository {
+	return &TaskTagRepository{db}
+}
+
+// NewWorkspaceTaskTagRepository создает репозиторий, которому доступны только теги задач рабочего пространства workspaceId
+func NewWorkspaceTaskTagRepository(db *gorm.DB, workspaceId uuid.UUID) *TaskTagRepository {
+	return &TaskTagRepository{inWorkspace(db, "task_tags.task_id IN ("+workspaceTaskIds+")", workspaceId)}
+}
+
+func (repo *TaskTagRepository) GetByTaskId(taskId uuid.UUID) []string {
+	var names []string
+
+	repo.db.
+		Model(&domain.TaskTag{}).
+		Where("task_id = ?", taskId).
+		Order("name asc").
+		Pluck("name", &names)
+
+	return names
+}
+
+// Replace заменяет набор тегов задачи целиком
+func (repo *TaskTagRepository) Replace(taskId uuid.UUID, names []string) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("task_id = ?", taskId).Delete(&domain.TaskTag{}).Error; err != nil {
+			return err
+		}
+
+		if len(names) == 0 {
+			return nil
+		}
+
+		tags := make([]domain.TaskTag, 0, len(names))
+
+		for _, name := range names {
+			tags = append(tags, domain.TaskTag{TaskId: taskId, Name: name})
+		}
+
+		return tx.Create(&tags).Error
+	})
+}
+
+// Add добавляет задаче теги, пропуская уже назначенные
+func (repo *TaskTagRepository) Add(taskId uuid.UUID, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	tags := make([]domain.TaskTag, 0, len(names))
+
+	for _, name := range names {
+		tags = append(tags, domain.TaskTag{TaskId: taskId, Name: name})
+	}
+
+	return repo.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error
+}
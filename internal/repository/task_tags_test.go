@@ -0,0 +1,100 @@
+package repository_test
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/helpers"
+	"testing"
+)
+
+func TestTaskTagRepositoryGetByTaskId_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, _ := twoUuids(t)
+
+	mock.ExpectQuery("SELECT").
+		WithArgs(taskId).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("home").AddRow("urgent"))
+
+	taskTagRepository := repository.NewTaskTagRepository(mockedDatabase)
+
+	require.Equal(t, []string{"home", "urgent"}, taskTagRepository.GetByTaskId(taskId))
+}
+
+func TestTaskTagRepositoryReplace_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	taskTagRepository := repository.NewTaskTagRepository(mockedDatabase)
+
+	require.NoError(t, taskTagRepository.Replace(taskId, []string{"home", "urgent"}))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskTagRepositoryReplace_Empty(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	taskTagRepository := repository.NewTaskTagRepository(mockedDatabase)
+
+	require.NoError(t, taskTagRepository.Replace(taskId, []string{}))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskTagRepositoryReplace_Failed(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT").WillReturnError(gorm.ErrInvalidValue)
+	mock.ExpectRollback()
+
+	taskTagRepository := repository.NewTaskTagRepository(mockedDatabase)
+
+	require.Equal(t, gorm.ErrInvalidValue, taskTagRepository.Replace(taskId, []string{"home"}))
+}
+
+func TestTaskTagRepositoryAdd_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("ON CONFLICT DO NOTHING").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	taskTagRepository := repository.NewTaskTagRepository(mockedDatabase)
+
+	require.NoError(t, taskTagRepository.Add(taskId, []string{"home"}))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskTagRepositoryWorkspaceGetByTaskId_OtherWorkspace(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, taskId := twoUuids(t)
+
+	mock.ExpectQuery(`WHERE task_tags.task_id IN \(SELECT id FROM tasks WHERE tasks.workspace_id = \$1\) AND task_id = \$2`).
+		WithArgs(workspaceId, taskId).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}))
+
+	taskTagRepository := repository.NewWorkspaceTaskTagRepository(mockedDatabase, workspaceId)
+
+	require.Empty(t, taskTagRepository.GetByTaskId(taskId))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+)
+
+type TaskTombstoneRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskTombstoneRepository(db *gorm.DB) *TaskTombstoneRepository {
+	return &TaskTombstoneRepository{db}
+}
+
+// NewWorkspaceTaskTombstoneRepository создает репозиторий, которому доступны только отметки задач рабочего пространства workspaceId
+func NewWorkspaceTaskTombstoneRepository(db *gorm.DB, workspaceId uuid.UUID) *TaskTombstoneRepository {
+	return &TaskTombstoneRepository{inWorkspace(db, "task_tombstones.workspace_id = ?", workspaceId)}
+}
+
+// GetChangedSince возвращает до limit отметок пользователя после позиции since в порядке журнала синхронизации.
+// Отметки задач, которые снова доступны пользователю, пропускаются: такие задачи приходят как измененные.
+// Как и для задач, возвращаются только отметки завершенных транзакций.
+func (repo *TaskTombstoneRepository) GetChangedSince(userId uuid.UUID, since domain.SyncPosition, limit int) (*[]domain.TaskTombstone, error) {
+	var tombstones []domain.TaskTombstone
+
+	result := repo.db.
+		Where("task_tombstones.user_id = @user", sql.Named("user", userId)).
+		Where("(task_tombstones.change_xid, task_tombstones.change_seq) > (?, ?)", since.Xid, since.Seq).
+		Where("task_tombstones.change_xid < pg_snapshot_xmin(pg_current_snapshot())::text::bigint").
+		Where("NOT EXISTS (SELECT 1 FROM tasks WHERE tasks.id = task_tombstones.task_id AND "+accessibleCondition+")", sql.Named("user", userId)).
+		Order("task_tombstones.change_xid, task_tombstones.change_seq").
+		Limit(limit).
+		Find(&tombstones)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &tombstones, nil
+}
@@ -0,0 +1,45 @@
+package repository_test
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/helpers"
+	"testing"
+)
+
+func TestTaskTombstoneRepositoryGetChangedSince_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, taskId := twoUuids(t)
+
+	mock.ExpectQuery(`task_tombstones.user_id = \$1 AND \(task_tombstones.change_xid, task_tombstones.change_seq\) > \(\$2, \$3\).+NOT EXISTS \(SELECT 1 FROM tasks WHERE tasks.id = task_tombstones.task_id.+ORDER BY task_tombstones.change_xid, task_tombstones.change_seq LIMIT \$7`).
+		WithArgs(userId, int64(700), int64(10), userId, userId, userId, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "change_xid", "change_seq"}).AddRow(taskId, 701, 11))
+
+	taskTombstoneRepository := repository.NewTaskTombstoneRepository(mockedDatabase)
+
+	tombstones, err := taskTombstoneRepository.GetChangedSince(userId, domain.SyncPosition{Xid: 700, Seq: 10}, 2)
+
+	require.NoError(t, err)
+	require.Len(t, *tombstones, 1)
+	require.Equal(t, taskId, (*tombstones)[0].TaskId)
+	require.Equal(t, domain.SyncPosition{Xid: 701, Seq: 11}, (*tombstones)[0].ChangePosition())
+}
+
+func TestTaskTombstoneRepositoryWorkspaceGetChangedSince_FiltersWorkspace(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, workspaceId := twoUuids(t)
+
+	mock.ExpectQuery(`WHERE task_tombstones.workspace_id = \$1 AND task_tombstones.user_id = \$2`).
+		WillReturnRows(sqlmock.NewRows([]string{"task_id"}))
+
+	taskTombstoneRepository := repository.NewWorkspaceTaskTombstoneRepository(mockedDatabase, workspaceId)
+
+	tombstones, err := taskTombstoneRepository.GetChangedSince(userId, domain.SyncPosition{}, 2)
+
+	require.NoError(t, err)
+	require.Empty(t, *tombstones)
+}
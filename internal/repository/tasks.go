@@ -1,21 +1,127 @@
 package repository
 
 import (
+	"database/sql"
 	"errors"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"poymanov/todo/internal/domain"
+	"strings"
+	"time"
 )
 
+// isBlockedCondition проверяет наличие у задачи незавершенных блокирующих задач
+const isBlockedCondition = `exists(
+	SELECT 1 FROM task_dependencies d
+	JOIN tasks b ON b.id = d.blocked_by_id
+	WHERE d.task_id = tasks.id AND b.deleted_at IS NULL AND b.is_completed = false
+)`
+
+const isBlockedSelect = isBlockedCondition + " AS is_blocked"
+
+// overdueCondition отбирает незавершенные задачи, срок которых истек к указанному моменту. Проверка due_at
+// на NULL нужна, чтобы отрицание условия включало задачи без срока.
+const overdueCondition = "(tasks.due_at IS NOT NULL AND tasks.due_at < ? AND tasks.is_completed = false)"
+
+// accessibleCondition отбирает задачи, доступные пользователю @user: его задачи вне списков,
+// задачи его собственных списков и списков, в которых он участник
+const accessibleCondition = `(tasks.list_id IS NULL AND tasks.user_id = @user OR tasks.list_id IN (
+	SELECT id FROM lists WHERE user_id = @user
+	UNION SELECT list_id FROM list_members WHERE user_id = @user
+))`
+
+// accessibleListsCondition отбирает задачи собственных списков пользователя и списков, в которых он участник
+const accessibleListsCondition = `tasks.list_id IN (
+	SELECT id FROM lists WHERE user_id = ?
+	UNION SELECT list_id FROM list_members WHERE user_id = ?
+)`
+
+// escapedDescription - описание задачи с экранированными символами разметки HTML. Фрагмент строится по нему,
+// чтобы теги <b> вокруг найденных слов были единственной разметкой в результате.
+const escapedDescription = `replace(replace(replace(tasks.description, '&', '&amp;'), '<', '&lt;'), '>', '&gt;')`
+
+// snippetOptions - параметры ts_headline: найденные слова выделяются тегом <b>
+const snippetOptions = "StartSel=<b>, StopSel=</b>, MaxFragments=2, MaxWords=20, MinWords=5, FragmentDelimiter=\" … \""
+
+// taskSortColumns сопоставляет допустимые поля сортировки с колонками таблицы
+var taskSortColumns = map[string]string{
+	domain.TaskSortCreatedAt:   "tasks.created_at",
+	domain.TaskSortUpdatedAt:   "tasks.updated_at",
+	domain.TaskSortDescription: "tasks.description",
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ErrTaskVersionConflict - задача не изменена, потому что ее версия отличается от ожидаемой
+var ErrTaskVersionConflict = errors.New("task version conflict")
+
 type TaskRepository struct {
-	db *gorm.DB
+	db          *gorm.DB
+	workspaceId *uuid.UUID
+	version     *int
+	forUpdate   bool
 }
 
 func NewTaskRepository(db *gorm.DB) *TaskRepository {
-	return &TaskRepository{db}
+	return &TaskRepository{db: db}
+}
+
+// NewWorkspaceTaskRepository создает репозиторий, которому доступны только задачи рабочего пространства workspaceId.
+// Условие на пространство добавляется ко всем запросам, а создаваемые задачи попадают в это пространство.
+func NewWorkspaceTaskRepository(db *gorm.DB, workspaceId uuid.UUID) *TaskRepository {
+	return &TaskRepository{
+		db:          db.Where("tasks.workspace_id = ?", workspaceId).Session(&gorm.Session{}),
+		workspaceId: &workspaceId,
+	}
+}
+
+// WithVersion возвращает репозиторий, который изменяет задачу, только если ее версия равна version.
+// Если ни одна строка не изменена, изменение завершается ошибкой ErrTaskVersionConflict.
+func (repo *TaskRepository) WithVersion(version int) Task {
+	versioned := *repo
+	versioned.version = &version
+
+	return &versioned
+}
+
+// read возвращает основу запроса на чтение задач, которые затем изменяются. В репозитории транзакции строки
+// задач блокируются до ее завершения, поэтому версия прочитанной задачи не меняется до записи ее истории.
+func (repo *TaskRepository) read() *gorm.DB {
+	if !repo.forUpdate {
+		return repo.db
+	}
+
+	return repo.db.Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "tasks"}})
+}
+
+// write возвращает основу запроса на изменение задачи с условием на версию, если оно задано
+func (repo *TaskRepository) write() *gorm.DB {
+	if repo.version == nil {
+		return repo.db
+	}
+
+	return repo.db.Where("tasks.version = ?", *repo.version)
+}
+
+// written проверяет результат изменения задачи: при условии на версию задача должна быть изменена
+func (repo *TaskRepository) written(result *gorm.DB) error {
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if repo.version != nil && result.RowsAffected == 0 {
+		return ErrTaskVersionConflict
+	}
+
+	return nil
 }
 
 func (repo *TaskRepository) Create(task *domain.Task) (*domain.Task, error) {
+	if repo.workspaceId != nil {
+		task.WorkspaceId = *repo.workspaceId
+	}
+
 	result := repo.db.Create(task)
 
 	if result.Error != nil {
@@ -26,23 +132,22 @@ func (repo *TaskRepository) Create(task *domain.Task) (*domain.Task, error) {
 }
 
 func (repo *TaskRepository) Update(task *domain.Task) (*domain.Task, error) {
-	result := repo.db.Updates(task)
-
-	if result.Error != nil {
-		return nil, result.Error
+	if err := repo.written(repo.write().Updates(task)); err != nil {
+		return nil, err
 	}
 
 	return task, nil
 }
 
-func (repo *TaskRepository) Delete(id uuid.UUID) error {
-	result := repo.db.Delete(&domain.Task{}, id)
-
-	if result.Error != nil {
-		return result.Error
-	}
+// UpdateColumns обновляет перечисленные колонки задачи, в том числе значениями NULL
+func (repo *TaskRepository) UpdateColumns(id uuid.UUID, columns map[string]interface{}) error {
+	return repo.written(repo.write().
+		Model(&domain.Task{ID: id}).
+		Updates(columns))
+}
 
-	return nil
+func (repo *TaskRepository) Delete(id uuid.UUID) error {
+	return repo.written(repo.write().Delete(&domain.Task{}, id))
 }
 
 func (repo *TaskRepository) IsExistsById(id uuid.UUID) bool {
@@ -53,14 +158,420 @@ func (repo *TaskRepository) IsExistsById(id uuid.UUID) bool {
 	return true
 }
 
+func (repo *TaskRepository) FindById(id uuid.UUID) (*domain.Task, error) {
+	var task domain.Task
+	result := repo.read().Select("tasks.*, "+isBlockedSelect).First(&task, "id = ?", id)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &task, nil
+}
+
+func (repo *TaskRepository) GetAllByIds(ids []uuid.UUID) *[]domain.Task {
+	var tasks []domain.Task
+
+	repo.db.Where("id IN ?", ids).Find(&tasks)
+
+	return &tasks
+}
+
+// Move переносит задачу в список listId (nil - вне списков) со статусом statusId
+func (repo *TaskRepository) Move(id uuid.UUID, listId *uuid.UUID, statusId uuid.UUID) error {
+	return repo.written(repo.write().
+		Model(&domain.Task{ID: id}).
+		Updates(map[string]interface{}{"list_id": listId, "status_id": statusId}))
+}
+
 func (repo *TaskRepository) GetAllByUserId(id uuid.UUID) *[]domain.Task {
 	var tasks []domain.Task
 
+	repo.activeAccessible(id).
+		Select("tasks.*, " + isBlockedSelect).
+		Order("created_at desc").
+		Scan(&tasks)
+
+	return &tasks
+}
+
+// activeAccessible возвращает основу запроса к задачам пользователя userId вне корзины и архива. Условие
+// accessibleCondition разделено на ветви UNION ALL: задачи вне списков выбираются по индексам (user_id, ...),
+// задачи списков - по индексам (list_id, ...). С условием OR планировщик не может использовать ни те, ни другие.
+func (repo *TaskRepository) activeAccessible(userId uuid.UUID) *gorm.DB {
+	own := repo.db.
+		Table("tasks").
+		Where("tasks.deleted_at is null and tasks.archived_at is null").
+		Where("tasks.list_id IS NULL AND tasks.user_id = ?", userId)
+
+	listed := repo.db.
+		Table("tasks").
+		Where("tasks.deleted_at is null and tasks.archived_at is null").
+		Where(accessibleListsCondition, userId, userId)
+
+	return repo.db.Table("(? UNION ALL ?) AS tasks", own, listed)
+}
+
+// GetAssignedToUserId возвращает задачи, назначенные пользователю, кроме задач в корзине и архиве
+func (repo *TaskRepository) GetAssignedToUserId(id uuid.UUID) *[]domain.Task {
+	var tasks []domain.Task
+
 	repo.db.
 		Table("tasks").
-		Where("deleted_at is null and user_id = ?", id).
+		Select("tasks.*, "+isBlockedSelect).
+		Where("deleted_at is null and archived_at is null and assignee_id = @user", sql.Named("user", id)).
+		Where(accessibleCondition, sql.Named("user", id)).
 		Order("created_at desc").
 		Scan(&tasks)
 
 	return &tasks
 }
+
+// GetAssignedInList возвращает задачи списка listId, назначенные пользователю assigneeId, в том числе задачи
+// в корзине и архиве
+func (repo *TaskRepository) GetAssignedInList(listId, assigneeId uuid.UUID) *[]domain.Task {
+	var tasks []domain.Task
+
+	repo.read().
+		Unscoped().
+		Where("list_id = ? and assignee_id = ?", listId, assigneeId).
+		Order("created_at").
+		Find(&tasks)
+
+	return &tasks
+}
+
+// GetAssignedInWorkspace возвращает задачи рабочего пространства workspaceId, назначенные пользователю assigneeId,
+// в том числе задачи в корзине и архиве
+func (repo *TaskRepository) GetAssignedInWorkspace(workspaceId, assigneeId uuid.UUID) *[]domain.Task {
+	var tasks []domain.Task
+
+	repo.read().
+		Unscoped().
+		Where("workspace_id = ? and assignee_id = ?", workspaceId, assigneeId).
+		Order("created_at").
+		Find(&tasks)
+
+	return &tasks
+}
+
+// Unassign снимает назначение задачи, в том числе находящейся в корзине
+func (repo *TaskRepository) Unassign(id uuid.UUID) error {
+	return repo.written(repo.write().
+		Unscoped().
+		Model(&domain.Task{ID: id}).
+		Update("assignee_id", nil))
+}
+
+// GetPageByUserId возвращает не более limit задач, следующих в порядке sort за позицией after
+func (repo *TaskRepository) GetPageByUserId(filter domain.TaskFilter, sort domain.TaskSort, after *domain.TaskCursor, limit int) (*[]domain.Task, error) {
+	var tasks []domain.Task
+
+	column, ok := taskSortColumns[sort.Field]
+
+	if !ok {
+		return nil, gorm.ErrInvalidField
+	}
+
+	direction, comparison := "asc", ">"
+
+	if sort.Desc {
+		direction, comparison = "desc", "<"
+	}
+
+	query := repo.filterByUser(filter).Select("tasks.*, " + isBlockedSelect)
+
+	if after != nil {
+		value, err := cursorValue(sort.Field, after.Value)
+
+		if err != nil {
+			return nil, err
+		}
+
+		query = query.Where("("+column+", tasks.id) "+comparison+" (?, ?)", value, after.Id)
+	}
+
+	result := query.
+		Order(column + " " + direction).
+		Order("tasks.id " + direction).
+		Limit(limit).
+		Scan(&tasks)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &tasks, nil
+}
+
+// CountByUserId подсчитывает задачи, удовлетворяющие фильтру, в том числе завершенные
+func (repo *TaskRepository) CountByUserId(filter domain.TaskFilter) (domain.TaskCounts, error) {
+	var counts domain.TaskCounts
+
+	result := repo.filterByUser(filter).
+		Select("count(*) AS total, count(*) FILTER (WHERE tasks.is_completed) AS completed").
+		Scan(&counts)
+
+	return counts, result.Error
+}
+
+// CountAllByAuthorId подсчитывает задачи, созданные пользователем id, включая архивные и удаленные в корзину
+func (repo *TaskRepository) CountAllByAuthorId(id uuid.UUID) (domain.UserTaskCounts, error) {
+	var counts domain.UserTaskCounts
+
+	result := repo.db.
+		Table("tasks").
+		Select(`count(*) FILTER (WHERE tasks.deleted_at IS NULL) AS total,
+			count(*) FILTER (WHERE tasks.deleted_at IS NULL AND tasks.is_completed) AS completed,
+			count(*) FILTER (WHERE tasks.deleted_at IS NULL AND tasks.archived_at IS NOT NULL) AS archived,
+			count(*) FILTER (WHERE tasks.deleted_at IS NOT NULL) AS trashed`).
+		Where("tasks.user_id = ?", id).
+		Scan(&counts)
+
+	return counts, result.Error
+}
+
+func (repo *TaskRepository) filterByUser(filter domain.TaskFilter) *gorm.DB {
+	query := repo.activeAccessible(filter.UserId)
+
+	if filter.Completed != nil {
+		query = query.Where("tasks.is_completed = ?", *filter.Completed)
+	}
+
+	if filter.Blocked != nil {
+		if *filter.Blocked {
+			query = query.Where(isBlockedCondition)
+		} else {
+			query = query.Not(isBlockedCondition)
+		}
+	}
+
+	if filter.ListId != nil {
+		query = query.Where("tasks.list_id = ?", *filter.ListId)
+	}
+
+	if filter.StatusId != nil {
+		query = query.Where("tasks.status_id = ?", *filter.StatusId)
+	}
+
+	if len(filter.TagsAll) > 0 {
+		query = query.Where(
+			"(SELECT count(*) FROM task_tags WHERE task_tags.task_id = tasks.id AND task_tags.name IN ?) = ?",
+			filter.TagsAll, len(filter.TagsAll),
+		)
+	}
+
+	if len(filter.TagsAny) > 0 {
+		query = query.Where(
+			"exists(SELECT 1 FROM task_tags WHERE task_tags.task_id = tasks.id AND task_tags.name IN ?)",
+			filter.TagsAny,
+		)
+	}
+
+	if filter.CreatedFrom != nil {
+		query = query.Where("tasks.created_at >= ?", *filter.CreatedFrom)
+	}
+
+	if filter.CreatedTo != nil {
+		query = query.Where("tasks.created_at < ?", *filter.CreatedTo)
+	}
+
+	if filter.Overdue != nil {
+		if *filter.Overdue {
+			query = query.Where(overdueCondition, filter.OverdueAt)
+		} else {
+			query = query.Not(overdueCondition, filter.OverdueAt)
+		}
+	}
+
+	if filter.Query != "" {
+		query = query.Where(`tasks.description ILIKE ? ESCAPE '\'`, "%"+likeEscaper.Replace(filter.Query)+"%")
+	}
+
+	return query
+}
+
+// cursorValue приводит значение курсора к типу поля сортировки
+func cursorValue(field, value string) (interface{}, error) {
+	if field == domain.TaskSortDescription {
+		return value, nil
+	}
+
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+func (repo *TaskRepository) GetAllByListId(id uuid.UUID) *[]domain.Task {
+	var tasks []domain.Task
+
+	repo.db.
+		Table("tasks").
+		Select("tasks.*, "+isBlockedSelect).
+		Where("deleted_at is null and archived_at is null and list_id = ?", id).
+		Order("created_at desc").
+		Scan(&tasks)
+
+	return &tasks
+}
+
+// GetChangedSince возвращает до limit задач пользователя, включая удаленные, измененных после позиции since,
+// в порядке изменения. Возвращаются только изменения завершенных транзакций: номер изменения выдается при записи,
+// и без этого условия изменение транзакции, зафиксированной позже, оказалось бы раньше уже отданных клиенту.
+func (repo *TaskRepository) GetChangedSince(userId uuid.UUID, since domain.SyncPosition, limit int) (*[]domain.Task, error) {
+	var tasks []domain.Task
+
+	result := repo.db.
+		Table("tasks").
+		Select("tasks.*, "+isBlockedSelect).
+		Where("(change_xid, change_seq) > (?, ?)", since.Xid, since.Seq).
+		Where("change_xid < pg_snapshot_xmin(pg_current_snapshot())::text::bigint").
+		Where(accessibleCondition, sql.Named("user", userId)).
+		Order("change_xid, change_seq").
+		Limit(limit).
+		Scan(&tasks)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &tasks, nil
+}
+
+// FindWithTrashedById ищет задачу по id, в том числе среди удаленных в корзину
+func (repo *TaskRepository) FindWithTrashedById(id uuid.UUID) (*domain.Task, error) {
+	var task domain.Task
+	result := repo.read().Unscoped().First(&task, "id = ?", id)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &task, nil
+}
+
+func (repo *TaskRepository) GetTrashByUserId(id uuid.UUID) *[]domain.Task {
+	var tasks []domain.Task
+
+	repo.db.
+		Unscoped().
+		Where("deleted_at is not null").
+		Where(accessibleCondition, sql.Named("user", id)).
+		Order("deleted_at desc").
+		Find(&tasks)
+
+	return &tasks
+}
+
+func (repo *TaskRepository) Restore(id uuid.UUID) error {
+	return repo.written(repo.write().
+		Unscoped().
+		Model(&domain.Task{}).
+		Where("id = ? and deleted_at is not null", id).
+		Update("deleted_at", nil))
+}
+
+// Purge окончательно удаляет задачу вместе со связанными с ней данными
+func (repo *TaskRepository) Purge(id uuid.UUID) error {
+	return repo.written(repo.write().Unscoped().Delete(&domain.Task{}, id))
+}
+
+// LockDeletedBefore возвращает задачи, перемещенные в корзину раньше before, блокируя их до конца транзакции
+func (repo *TaskRepository) LockDeletedBefore(before time.Time) *[]domain.Task {
+	var tasks []domain.Task
+
+	repo.db.
+		Unscoped().
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("deleted_at < ?", before).
+		Find(&tasks)
+
+	return &tasks
+}
+
+// PurgeDeletedBefore окончательно удаляет задачи, перемещенные в корзину раньше before
+func (repo *TaskRepository) PurgeDeletedBefore(before time.Time) (int64, error) {
+	result := repo.db.Unscoped().Where("deleted_at < ?", before).Delete(&domain.Task{})
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (repo *TaskRepository) GetArchivedByUserId(id uuid.UUID) *[]domain.Task {
+	var tasks []domain.Task
+
+	repo.db.
+		Where("archived_at is not null").
+		Where(accessibleCondition, sql.Named("user", id)).
+		Order("archived_at desc").
+		Find(&tasks)
+
+	return &tasks
+}
+
+// Unarchive возвращает задачу из архива. Дата завершения не меняется, а момент возврата сохраняется
+// в unarchived_at, чтобы задача не попала в архив повторно при следующем запуске автоархивации.
+func (repo *TaskRepository) Unarchive(id uuid.UUID, unarchivedAt time.Time) error {
+	return repo.written(repo.write().
+		Model(&domain.Task{}).
+		Where("id = ? and archived_at is not null", id).
+		Updates(map[string]interface{}{"archived_at": nil, "unarchived_at": unarchivedAt}))
+}
+
+// ArchiveCompleted архивирует завершенные задачи пользователей, у которых включена автоархивация,
+// если с момента завершения или последнего возврата из архива прошло больше заданного пользователем количества дней
+func (repo *TaskRepository) ArchiveCompleted(now time.Time) (int64, error) {
+	result := repo.db.Exec(`UPDATE tasks SET archived_at = @now
+		FROM users
+		WHERE users.id = tasks.user_id
+			AND users.auto_archive_days IS NOT NULL
+			AND tasks.deleted_at IS NULL
+			AND tasks.archived_at IS NULL
+			AND tasks.is_completed = true
+			AND greatest(tasks.completed_at, tasks.unarchived_at) < @now - make_interval(days => users.auto_archive_days)`,
+		sql.Named("now", now),
+	)
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+// Search ищет задачи пользователя, в том числе архивные, по запросу tsquery в синтаксисе to_tsquery
+func (repo *TaskRepository) Search(userId uuid.UUID, tsquery, language string, limit int) (*[]domain.TaskSearchResult, error) {
+	var results []domain.TaskSearchResult
+
+	// условие сессии не применяется к сырому запросу, поэтому пространство проверяется явно
+	workspaceCondition := ""
+
+	if repo.workspaceId != nil {
+		workspaceCondition = "AND tasks.workspace_id = @workspace"
+	}
+
+	result := repo.db.Raw(`SELECT tasks.*,
+			ts_rank(tasks.search_vector, query) AS rank,
+			ts_headline(CAST(@language AS regconfig), `+escapedDescription+`, query, @options) AS snippet
+		FROM tasks, to_tsquery(CAST(@language AS regconfig), @query) query
+		WHERE tasks.deleted_at IS NULL
+			AND `+accessibleCondition+`
+			AND tasks.search_vector @@ query
+			`+workspaceCondition+`
+		ORDER BY rank DESC, tasks.created_at DESC
+		LIMIT @limit`,
+		sql.Named("language", language),
+		sql.Named("query", tsquery),
+		sql.Named("options", snippetOptions),
+		sql.Named("user", userId),
+		sql.Named("limit", limit),
+		sql.Named("workspace", repo.workspaceId),
+	).Scan(&results)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &results, nil
+}
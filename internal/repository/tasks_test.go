@@ -10,6 +10,7 @@ import (
 	"poymanov/todo/internal/repository"
 	"poymanov/todo/pkg/helpers"
 	"testing"
+	"time"
 )
 
 func TestTaskRepositoryCreate_Success(t *testing.T) {
@@ -96,6 +97,57 @@ func TestTaskRepositoryUpdate_Failed(t *testing.T) {
 	require.Equal(t, gorm.ErrInvalidValue, err)
 }
 
+func TestTaskRepositoryUpdate_WithVersion(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "tasks" SET .+ WHERE tasks.version = \$\d+ AND "tasks"."deleted_at" IS NULL AND "id" = \$\d+`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 3, taskId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase).WithVersion(3)
+
+	_, err := taskRepository.Update(&domain.Task{ID: taskId, Description: "new"})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskRepositoryUpdate_VersionChanged(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, _ := twoUuids(t)
+
+	// между чтением задачи и записью ее версия увеличилась, поэтому условие не выполняется ни для одной строки
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "tasks" SET .+ WHERE tasks.version = \$\d+ AND "tasks"."deleted_at" IS NULL AND "id" = \$\d+`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase).WithVersion(3)
+
+	updatedTask, err := taskRepository.Update(&domain.Task{ID: taskId, Description: "new"})
+
+	require.Nil(t, updatedTask)
+	require.ErrorIs(t, err, repository.ErrTaskVersionConflict)
+}
+
+func TestTaskRepositoryDelete_VersionChanged(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "tasks" SET "deleted_at"=\$1 WHERE tasks.version = \$2 AND "tasks"."id" = \$3`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase).WithVersion(3)
+
+	require.ErrorIs(t, taskRepository.Delete(taskId), repository.ErrTaskVersionConflict)
+}
+
 func TestTaskRepositoryDelete_Success(t *testing.T) {
 	mockedDatabase, mock := helpers.InitMockDatabase()
 
@@ -164,6 +216,42 @@ func TestTaskRepositoryIsExistsById_NotExisted(t *testing.T) {
 	require.False(t, result)
 }
 
+func TestTaskRepositoryFindById_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	description := faker.Word()
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	mock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "description"}).AddRow(taskId, description))
+
+	task, err := taskRepository.FindById(taskId)
+
+	require.NoError(t, err)
+	require.Equal(t, taskId, task.ID)
+	require.Equal(t, description, task.Description)
+}
+
+func TestTaskRepositoryFindById_Failed(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	mock.ExpectQuery("SELECT").WillReturnError(gorm.ErrRecordNotFound)
+
+	task, err := taskRepository.FindById(taskId)
+
+	require.Nil(t, task)
+	require.Equal(t, gorm.ErrRecordNotFound, err)
+}
+
 func TestTaskRepositoryGetAllByUserId_Success(t *testing.T) {
 	mockedDatabase, mock := helpers.InitMockDatabase()
 
@@ -175,7 +263,7 @@ func TestTaskRepositoryGetAllByUserId_Success(t *testing.T) {
 
 	taskRepository := repository.NewTaskRepository(mockedDatabase)
 
-	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(taskId))
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id", "is_blocked"}).AddRow(taskId, true))
 
 	result := taskRepository.GetAllByUserId(userId)
 
@@ -183,6 +271,7 @@ func TestTaskRepositoryGetAllByUserId_Success(t *testing.T) {
 	require.NotEmpty(t, result)
 	tasks := *result
 	require.Equal(t, taskId, tasks[0].ID)
+	require.True(t, tasks[0].IsBlocked)
 }
 
 func TestTaskRepositoryGetAllByUserId_Empty(t *testing.T) {
@@ -201,3 +290,549 @@ func TestTaskRepositoryGetAllByUserId_Empty(t *testing.T) {
 	require.IsType(t, &[]domain.Task{}, result)
 	require.Empty(t, result)
 }
+
+func TestTaskRepositoryGetAllByListId_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	listId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	taskId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	mock.ExpectQuery("list_id = ").
+		WithArgs(listId).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id"}).AddRow(taskId, listId))
+
+	result := taskRepository.GetAllByListId(listId)
+
+	require.NotEmpty(t, result)
+	tasks := *result
+	require.Equal(t, taskId, tasks[0].ID)
+	require.Equal(t, listId, *tasks[0].ListId)
+}
+
+func TestTaskRepositoryGetTrashByUserId_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, taskId := twoUuids(t)
+
+	mock.ExpectQuery("deleted_at is not null.+list_members").
+		WithArgs(userId, userId, userId).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at"}).AddRow(taskId, time.Now()))
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	tasks := taskRepository.GetTrashByUserId(userId)
+
+	require.Len(t, *tasks, 1)
+	require.True(t, (*tasks)[0].DeletedAt.Valid)
+}
+
+func TestTaskRepositoryRestore_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	require.NoError(t, taskRepository.Restore(taskId))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskRepositoryPurge_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	require.NoError(t, taskRepository.Purge(taskId))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskRepositoryLockDeletedBefore_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	before := time.Now().AddDate(0, 0, -30)
+	taskId := uuid.New()
+
+	mock.ExpectQuery(`deleted_at < \$1 FOR UPDATE`).WithArgs(before).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(taskId))
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	tasks := taskRepository.LockDeletedBefore(before)
+
+	require.Len(t, *tasks, 1)
+	require.Equal(t, taskId, (*tasks)[0].ID)
+}
+
+func TestTaskRepositoryPurgeDeletedBefore_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	before := time.Now().AddDate(0, 0, -30)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE").WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	purged, err := taskRepository.PurgeDeletedBefore(before)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(3), purged)
+}
+
+func TestTaskRepositoryPurgeDeletedBefore_Failed(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE").WillReturnError(gorm.ErrInvalidDB)
+	mock.ExpectRollback()
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	purged, err := taskRepository.PurgeDeletedBefore(time.Now())
+
+	require.Error(t, err)
+	require.Zero(t, purged)
+}
+
+func TestTaskRepositoryGetArchivedByUserId_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, taskId := twoUuids(t)
+
+	mock.ExpectQuery("archived_at is not null").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "archived_at"}).AddRow(taskId, time.Now()))
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	tasks := taskRepository.GetArchivedByUserId(userId)
+
+	require.Len(t, *tasks, 1)
+	require.NotNil(t, (*tasks)[0].ArchivedAt)
+}
+
+func TestTaskRepositoryGetAssignedToUserId_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, taskId := twoUuids(t)
+
+	mock.ExpectQuery("assignee_id = \\$1").
+		WithArgs(userId, userId, userId, userId).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "assignee_id"}).AddRow(taskId, userId))
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	tasks := taskRepository.GetAssignedToUserId(userId)
+
+	require.Len(t, *tasks, 1)
+	require.Equal(t, userId, *(*tasks)[0].AssigneeId)
+}
+
+func TestTaskRepositoryUnarchive_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SET "archived_at"=\$1,"unarchived_at"=\$2,"updated_at"=\$3 WHERE`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	require.NoError(t, taskRepository.Unarchive(taskId, time.Now()))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskRepositoryArchiveCompleted_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	now := time.Now()
+
+	mock.ExpectExec(`greatest\(tasks.completed_at, tasks.unarchived_at\)`).WithArgs(now, now).WillReturnResult(sqlmock.NewResult(0, 4))
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	archived, err := taskRepository.ArchiveCompleted(now)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(4), archived)
+}
+
+func TestTaskRepositoryGetPageByUserId_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, taskId := twoUuids(t)
+	isCompleted := true
+	createdAt := time.Now()
+
+	mock.ExpectQuery(`tasks.list_id IS NULL AND tasks.user_id = \$1\) UNION ALL SELECT .+ AS tasks WHERE tasks.is_completed = .+ILIKE .+\(tasks.created_at, tasks.id\) < .+ORDER BY tasks.created_at desc,tasks.id desc LIMIT \$8`).
+		WithArgs(userId, userId, userId, isCompleted, "%50\\%%", sqlmock.AnyArg(), taskId, 11).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(taskId))
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	tasks, err := taskRepository.GetPageByUserId(
+		domain.TaskFilter{UserId: userId, Completed: &isCompleted, Query: "50%"},
+		domain.TaskSort{Field: domain.TaskSortCreatedAt, Desc: true},
+		&domain.TaskCursor{Value: createdAt.Format(time.RFC3339Nano), Id: taskId},
+		11,
+	)
+
+	require.NoError(t, err)
+	require.Len(t, *tasks, 1)
+}
+
+func TestTaskRepositoryGetPageByUserId_UnknownSort(t *testing.T) {
+	mockedDatabase, _ := helpers.InitMockDatabase()
+
+	userId, _ := twoUuids(t)
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	tasks, err := taskRepository.GetPageByUserId(domain.TaskFilter{UserId: userId}, domain.TaskSort{Field: "id"}, nil, 10)
+
+	require.Nil(t, tasks)
+	require.Error(t, err)
+}
+
+func TestTaskRepositoryCountByUserId_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, _ := twoUuids(t)
+
+	mock.ExpectQuery("count").
+		WithArgs(userId, userId, userId).
+		WillReturnRows(sqlmock.NewRows([]string{"total", "completed"}).AddRow(10, 4))
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	counts, err := taskRepository.CountByUserId(domain.TaskFilter{UserId: userId})
+
+	require.NoError(t, err)
+	require.Equal(t, domain.TaskCounts{Total: 10, Completed: 4}, counts)
+}
+
+func TestTaskRepositoryCountAllByAuthorId_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, _ := twoUuids(t)
+
+	mock.ExpectQuery(`tasks.deleted_at IS NOT NULL\) AS trashed FROM "tasks" WHERE tasks.user_id = \$1$`).
+		WithArgs(userId).
+		WillReturnRows(sqlmock.NewRows([]string{"total", "completed", "archived", "trashed"}).AddRow(10, 4, 2, 1))
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	counts, err := taskRepository.CountAllByAuthorId(userId)
+
+	require.NoError(t, err)
+	require.Equal(t, domain.UserTaskCounts{Total: 10, Completed: 4, Archived: 2, Trashed: 1}, counts)
+}
+
+func TestTaskRepositorySearch_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, taskId := twoUuids(t)
+
+	mock.ExpectQuery(`ts_rank.+ts_headline.+to_tsquery`).
+		WithArgs("russian", sqlmock.AnyArg(), "russian", "молок:*", userId, userId, userId, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "description", "rank", "snippet"}).
+			AddRow(taskId, "Купить молоко", 0.06, "Купить <b>молоко</b>"))
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	results, err := taskRepository.Search(userId, "молок:*", "russian", 20)
+
+	require.NoError(t, err)
+	require.Len(t, *results, 1)
+	require.Equal(t, taskId, (*results)[0].ID)
+	require.Equal(t, "Купить <b>молоко</b>", (*results)[0].Snippet)
+	require.Equal(t, 0.06, (*results)[0].Rank)
+}
+
+func TestTaskRepositorySearch_EscapesDescription(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, taskId := twoUuids(t)
+
+	mock.ExpectQuery(`ts_headline\(CAST\(\$\d AS regconfig\), replace\(replace\(replace\(tasks.description, '&', '&amp;'\), '<', '&lt;'\), '>', '&gt;'\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "description", "rank", "snippet"}).
+			AddRow(taskId, "<img src=x> молоко", 0.06, "&lt;img src=x&gt; <b>молоко</b>"))
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	results, err := taskRepository.Search(userId, "молок:*", "russian", 20)
+
+	require.NoError(t, err)
+	require.Equal(t, "&lt;img src=x&gt; <b>молоко</b>", (*results)[0].Snippet)
+}
+
+func TestTaskRepositoryCountByUserId_SmartListConditions(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, listId := twoUuids(t)
+	isBlocked := false
+
+	mock.ExpectQuery(`NOT \(exists.+tasks.list_id = .+count\(\*\) FROM task_tags.+IN \(\$5,\$6\)\) = \$7.+exists\(SELECT 1 FROM task_tags`).
+		WithArgs(userId, userId, userId, listId, "home", "urgent", 2, "work").
+		WillReturnRows(sqlmock.NewRows([]string{"total", "completed"}).AddRow(1, 0))
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	counts, err := taskRepository.CountByUserId(domain.TaskFilter{
+		UserId:  userId,
+		Blocked: &isBlocked,
+		ListId:  &listId,
+		TagsAll: []string{"home", "urgent"},
+		TagsAny: []string{"work"},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, int64(1), counts.Total)
+}
+
+func TestTaskRepositoryCountByUserId_Overdue(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, _ := twoUuids(t)
+	now := time.Now()
+
+	testCases := []struct {
+		name    string
+		overdue bool
+		query   string
+	}{
+		{"Overdue", true, `AS tasks WHERE \(tasks.due_at IS NOT NULL AND tasks.due_at < \$4 AND tasks.is_completed = false\)`},
+		{"Not overdue", false, `AS tasks WHERE NOT \(\(tasks.due_at IS NOT NULL AND tasks.due_at < \$4 AND tasks.is_completed = false\)`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock.ExpectQuery(tc.query).
+				WithArgs(userId, userId, userId, now).
+				WillReturnRows(sqlmock.NewRows([]string{"total", "completed"}).AddRow(2, 0))
+
+			taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+			counts, err := taskRepository.CountByUserId(domain.TaskFilter{UserId: userId, Overdue: &tc.overdue, OverdueAt: now})
+
+			require.NoError(t, err)
+			require.Equal(t, int64(2), counts.Total)
+		})
+	}
+}
+
+func TestTaskRepositoryGetAllByIds_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	firstId, secondId := twoUuids(t)
+
+	mock.ExpectQuery("SELECT").
+		WithArgs(firstId, secondId).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(firstId).AddRow(secondId))
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	require.Len(t, *taskRepository.GetAllByIds([]uuid.UUID{firstId, secondId}), 2)
+}
+
+func TestTaskRepositoryMove_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, statusId := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	require.NoError(t, taskRepository.Move(taskId, nil, statusId))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskRepositoryGetAssignedInList_IncludesTrashed(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	listId, assigneeId := twoUuids(t)
+	taskId, _ := twoUuids(t)
+
+	mock.ExpectQuery(`SELECT \* FROM "tasks" WHERE list_id = \$1 and assignee_id = \$2 ORDER BY created_at$`).
+		WithArgs(listId, assigneeId).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at"}).AddRow(taskId, time.Now()))
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	tasks := taskRepository.GetAssignedInList(listId, assigneeId)
+
+	require.Len(t, *tasks, 1)
+	require.True(t, (*tasks)[0].DeletedAt.Valid)
+}
+
+func TestTaskRepositoryGetAssignedInWorkspace_IncludesTrashed(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, assigneeId := twoUuids(t)
+	taskId, _ := twoUuids(t)
+
+	mock.ExpectQuery(`SELECT \* FROM "tasks" WHERE workspace_id = \$1 and assignee_id = \$2 ORDER BY created_at$`).
+		WithArgs(workspaceId, assigneeId).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at"}).AddRow(taskId, time.Now()))
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	tasks := taskRepository.GetAssignedInWorkspace(workspaceId, assigneeId)
+
+	require.Len(t, *tasks, 1)
+	require.True(t, (*tasks)[0].DeletedAt.Valid)
+}
+
+func TestTaskRepositoryUnassign_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "tasks" SET "assignee_id"=\$1,"updated_at"=\$2 WHERE "id" = \$3$`).
+		WithArgs(nil, sqlmock.AnyArg(), taskId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	require.NoError(t, taskRepository.Unassign(taskId))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskRepositoryUpdateColumns_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"tasks\" SET \"estimate_minutes\"=\\$1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	require.NoError(t, taskRepository.UpdateColumns(taskId, map[string]interface{}{"estimate_minutes": nil}))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskRepositoryGetChangedSince_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, taskId := twoUuids(t)
+
+	mock.ExpectQuery(`\(change_xid, change_seq\) > \(\$1, \$2\) AND change_xid < pg_snapshot_xmin\(pg_current_snapshot\(\)\).+list_members.+ORDER BY change_xid, change_seq LIMIT \$6`).
+		WithArgs(int64(700), int64(10), userId, userId, userId, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "change_xid", "change_seq", "deleted_at"}).AddRow(taskId, 701, 11, time.Now()))
+
+	taskRepository := repository.NewTaskRepository(mockedDatabase)
+
+	tasks, err := taskRepository.GetChangedSince(userId, domain.SyncPosition{Xid: 700, Seq: 10}, 2)
+
+	require.NoError(t, err)
+	require.Len(t, *tasks, 1)
+	require.Equal(t, domain.SyncPosition{Xid: 701, Seq: 11}, (*tasks)[0].ChangePosition())
+	require.True(t, (*tasks)[0].DeletedAt.Valid)
+}
+
+func TestTaskRepositoryWorkspaceCreate_SetsWorkspace(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, taskId := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"tasks\"").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(taskId))
+	mock.ExpectCommit()
+
+	taskRepository := repository.NewWorkspaceTaskRepository(mockedDatabase, workspaceId)
+
+	createdTask, err := taskRepository.Create(&domain.Task{Description: faker.Word()})
+
+	require.NoError(t, err)
+	require.Equal(t, workspaceId, createdTask.WorkspaceId)
+}
+
+func TestTaskRepositoryWorkspaceFindById_OtherWorkspace(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, taskId := twoUuids(t)
+
+	mock.ExpectQuery(`FROM "tasks" WHERE tasks.workspace_id = \$1 AND id = \$2`).
+		WithArgs(workspaceId, taskId, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	taskRepository := repository.NewWorkspaceTaskRepository(mockedDatabase, workspaceId)
+
+	task, err := taskRepository.FindById(taskId)
+
+	require.Nil(t, task)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskRepositoryWorkspaceGetAllByUserId_FiltersWorkspace(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, userId := twoUuids(t)
+
+	mock.ExpectQuery(`WHERE tasks.workspace_id = \$1 AND .+ UNION ALL SELECT \* FROM "tasks" WHERE tasks.workspace_id = \$3 AND`).
+		WithArgs(workspaceId, userId, workspaceId, userId, userId, workspaceId).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	taskRepository := repository.NewWorkspaceTaskRepository(mockedDatabase, workspaceId)
+
+	require.Empty(t, *taskRepository.GetAllByUserId(userId))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskRepositoryWorkspaceUpdateColumns_FiltersWorkspace(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, taskId := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "tasks" SET .+ WHERE tasks.workspace_id = \$3 .+ "id" = \$4`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	taskRepository := repository.NewWorkspaceTaskRepository(mockedDatabase, workspaceId)
+
+	require.NoError(t, taskRepository.UpdateColumns(taskId, map[string]interface{}{"description": "Купить молоко"}))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskRepositoryWorkspaceSearch_FiltersWorkspace(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, userId := twoUuids(t)
+
+	mock.ExpectQuery(`AND tasks.workspace_id = \$8`).
+		WithArgs("russian", sqlmock.AnyArg(), "russian", "молок:*", userId, userId, userId, workspaceId, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	taskRepository := repository.NewWorkspaceTaskRepository(mockedDatabase, workspaceId)
+
+	results, err := taskRepository.Search(userId, "молок:*", "russian", 20)
+
+	require.NoError(t, err)
+	require.Empty(t, *results)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+	"time"
+)
+
+var ErrTimerIsRunning = errors.New("timer is running")
+
+const (
+	TimeReportGroupByList = "list"
+	TimeReportGroupByTag  = "tag"
+	TimeReportGroupByDay  = "day"
+)
+
+type TimeEntryRepository struct {
+	db *gorm.DB
+}
+
+func NewTimeEntryRepository(db *gorm.DB) *TimeEntryRepository {
+	return &TimeEntryRepository{db}
+}
+
+// NewWorkspaceTimeEntryRepository создает репозиторий, которому доступны только интервалы учета времени
+// по задачам рабочего пространства workspaceId
+func NewWorkspaceTimeEntryRepository(db *gorm.DB, workspaceId uuid.UUID) *TimeEntryRepository {
+	return &TimeEntryRepository{inWorkspace(db, "time_entries.task_id IN ("+workspaceTaskIds+")", workspaceId)}
+}
+
+func (repo *TimeEntryRepository) Create(entry *domain.TimeEntry) (*domain.TimeEntry, error) {
+	result := repo.db.Create(entry)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return entry, nil
+}
+
+// Start создает запущенный таймер. Строка пользователя блокируется на время транзакции,
+// поэтому одновременные запуски выполняются последовательно и у пользователя не может оказаться
+// двух запущенных таймеров, в том числе в разных рабочих пространствах.
+func (repo *TimeEntryRepository) Start(entry *domain.TimeEntry) (*domain.TimeEntry, error) {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT id FROM users WHERE id = ? FOR UPDATE", entry.UserId).Error; err != nil {
+			return err
+		}
+
+		var count int64
+
+		if err := tx.Session(&gorm.Session{NewDB: true}).Model(&domain.TimeEntry{}).
+			Where("user_id = ? and stopped_at is null", entry.UserId).
+			Count(&count).Error; err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return ErrTimerIsRunning
+		}
+
+		return tx.Create(entry).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (repo *TimeEntryRepository) Update(entry *domain.TimeEntry) (*domain.TimeEntry, error) {
+	result := repo.db.Updates(entry)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return entry, nil
+}
+
+func (repo *TimeEntryRepository) Delete(id uuid.UUID) error {
+	result := repo.db.Delete(&domain.TimeEntry{}, id)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (repo *TimeEntryRepository) FindById(id uuid.UUID) (*domain.TimeEntry, error) {
+	var entry domain.TimeEntry
+	result := repo.db.First(&entry, "id = ?", id)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &entry, nil
+}
+
+func (repo *TimeEntryRepository) FindRunningByUserId(userId uuid.UUID) (*domain.TimeEntry, error) {
+	var entry domain.TimeEntry
+	result := repo.db.First(&entry, "user_id = ? and stopped_at is null", userId)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &entry, nil
+}
+
+func (repo *TimeEntryRepository) GetAllByTaskId(taskId uuid.UUID) *[]domain.TimeEntry {
+	var entries []domain.TimeEntry
+
+	repo.db.
+		Where("task_id = ?", taskId).
+		Order("started_at desc").
+		Find(&entries)
+
+	return &entries
+}
+
+// GetReport суммирует учтенное время пользователя по интервалам, начатым в периоде [from, to).
+// Запущенные таймеры учитываются по текущий момент. При группировке по тегам интервал
+// учитывается в каждом теге задачи, а задачи без тегов попадают в группу с пустым ключом.
+func (repo *TimeEntryRepository) GetReport(userId uuid.UUID, from, to time.Time, groupBy string) (*[]domain.TimeReportRow, error) {
+	var rows []domain.TimeReportRow
+
+	query := repo.db.
+		Table("time_entries").
+		Joins("join tasks on tasks.id = time_entries.task_id").
+		Where("time_entries.user_id = ? and time_entries.started_at >= ? and time_entries.started_at < ?", userId, from, to)
+
+	duration := "sum(extract(epoch from coalesce(time_entries.stopped_at, now()) - time_entries.started_at))::bigint as seconds"
+
+	switch groupBy {
+	case TimeReportGroupByList:
+		query = query.
+			Joins("left join lists on lists.id = tasks.list_id").
+			Select("coalesce(lists.id::text, '') as key, coalesce(lists.name, '') as label, " + duration).
+			Group("lists.id, lists.name")
+	case TimeReportGroupByTag:
+		query = query.
+			Joins("left join task_tags on task_tags.task_id = tasks.id").
+			Select("coalesce(task_tags.name, '') as key, coalesce(task_tags.name, '') as label, " + duration).
+			Group("task_tags.name")
+	case TimeReportGroupByDay:
+		query = query.
+			Select("to_char(date_trunc('day', time_entries.started_at at time zone 'UTC'), 'YYYY-MM-DD') as key, " +
+				"to_char(date_trunc('day', time_entries.started_at at time zone 'UTC'), 'YYYY-MM-DD') as label, " + duration).
+			Group("1, 2")
+	default:
+		return nil, gorm.ErrInvalidValue
+	}
+
+	if err := query.Order("key asc").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return &rows, nil
+}
@@ -0,0 +1,179 @@
+package repository_test
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/helpers"
+	"testing"
+	"time"
+)
+
+func TestTimeEntryRepositoryCreate_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	entryUuid := faker.UUIDHyphenated()
+	taskId, userId := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(entryUuid))
+	mock.ExpectCommit()
+
+	timeEntryRepository := repository.NewTimeEntryRepository(mockedDatabase)
+
+	entry, err := timeEntryRepository.Create(&domain.TimeEntry{TaskId: taskId, UserId: userId, StartedAt: time.Now()})
+
+	require.NoError(t, err)
+	require.Equal(t, entryUuid, entry.ID.String())
+}
+
+func TestTimeEntryRepositoryStart_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	entryUuid := faker.UUIDHyphenated()
+	taskId, userId := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("FOR UPDATE").WithArgs(userId).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT count").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("INSERT").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(entryUuid))
+	mock.ExpectCommit()
+
+	timeEntryRepository := repository.NewTimeEntryRepository(mockedDatabase)
+
+	entry, err := timeEntryRepository.Start(&domain.TimeEntry{TaskId: taskId, UserId: userId, StartedAt: time.Now()})
+
+	require.NoError(t, err)
+	require.Equal(t, entryUuid, entry.ID.String())
+}
+
+func TestTimeEntryRepositoryStart_AlreadyRunning(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, userId := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("FOR UPDATE").WithArgs(userId).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT count").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+
+	timeEntryRepository := repository.NewTimeEntryRepository(mockedDatabase)
+
+	entry, err := timeEntryRepository.Start(&domain.TimeEntry{TaskId: taskId, UserId: userId, StartedAt: time.Now()})
+
+	require.Nil(t, entry)
+	require.ErrorIs(t, err, repository.ErrTimerIsRunning)
+}
+
+func TestTimeEntryRepositoryFindRunningByUserId_Failed(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, _ := twoUuids(t)
+
+	mock.ExpectQuery("stopped_at is null").WillReturnError(gorm.ErrRecordNotFound)
+
+	timeEntryRepository := repository.NewTimeEntryRepository(mockedDatabase)
+
+	entry, err := timeEntryRepository.FindRunningByUserId(userId)
+
+	require.Nil(t, entry)
+	require.Equal(t, gorm.ErrRecordNotFound, err)
+}
+
+func TestTimeEntryRepositoryGetAllByTaskId_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, entryId := twoUuids(t)
+
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(entryId))
+
+	timeEntryRepository := repository.NewTimeEntryRepository(mockedDatabase)
+
+	entries := timeEntryRepository.GetAllByTaskId(taskId)
+
+	require.Len(t, *entries, 1)
+	require.Equal(t, entryId, (*entries)[0].ID)
+}
+
+func TestTimeEntryRepositoryGetReport_Success(t *testing.T) {
+	testCases := []struct {
+		groupBy string
+		query   string
+	}{
+		{repository.TimeReportGroupByList, "left join lists"},
+		{repository.TimeReportGroupByTag, "left join task_tags"},
+		{repository.TimeReportGroupByDay, "date_trunc"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.groupBy, func(t *testing.T) {
+			mockedDatabase, mock := helpers.InitMockDatabase()
+
+			userId, _ := twoUuids(t)
+
+			mock.ExpectQuery(tc.query).
+				WillReturnRows(sqlmock.NewRows([]string{"key", "label", "seconds"}).AddRow("key", "label", 3600))
+
+			timeEntryRepository := repository.NewTimeEntryRepository(mockedDatabase)
+
+			rows, err := timeEntryRepository.GetReport(userId, time.Now().AddDate(0, 0, -7), time.Now(), tc.groupBy)
+
+			require.NoError(t, err)
+			require.Equal(t, []domain.TimeReportRow{{Key: "key", Label: "label", Seconds: 3600}}, *rows)
+		})
+	}
+}
+
+func TestTimeEntryRepositoryGetReport_UnknownGroup(t *testing.T) {
+	mockedDatabase, _ := helpers.InitMockDatabase()
+
+	userId, _ := twoUuids(t)
+
+	timeEntryRepository := repository.NewTimeEntryRepository(mockedDatabase)
+
+	rows, err := timeEntryRepository.GetReport(userId, time.Now(), time.Now(), faker.Word())
+
+	require.Nil(t, rows)
+	require.Error(t, err)
+}
+
+func TestTimeEntryRepositoryWorkspaceFindById_OtherWorkspace(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, entryId := twoUuids(t)
+
+	mock.ExpectQuery(`FROM "time_entries" WHERE time_entries.task_id IN \(SELECT id FROM tasks WHERE tasks.workspace_id = \$1\) AND id = \$2`).
+		WithArgs(workspaceId, entryId, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	timeEntryRepository := repository.NewWorkspaceTimeEntryRepository(mockedDatabase, workspaceId)
+
+	entry, err := timeEntryRepository.FindById(entryId)
+
+	require.Nil(t, entry)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTimeEntryRepositoryWorkspaceStart_ChecksAllWorkspaces(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, userId := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT id FROM users WHERE id = \\$1 FOR UPDATE").WithArgs(userId).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "time_entries" WHERE user_id = \$1 and stopped_at is null$`).
+		WithArgs(userId).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+
+	timeEntryRepository := repository.NewWorkspaceTimeEntryRepository(mockedDatabase, workspaceId)
+
+	_, err := timeEntryRepository.Start(&domain.TimeEntry{UserId: userId, StartedAt: time.Now()})
+
+	require.ErrorIs(t, err, repository.ErrTimerIsRunning)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TransactionRepository struct {
+	db          *gorm.DB
+	workspaceId *uuid.UUID
+}
+
+func NewTransactionRepository(db *gorm.DB) *TransactionRepository {
+	return &TransactionRepository{db: db}
+}
+
+// Transaction выполняет fn в транзакции. Вложенный вызов создает точку сохранения,
+// поэтому ошибка внутри него откатывает только изменения этого вызова.
+// Репозитории транзакции ограничены тем же рабочим пространством, что и исходные,
+// а изменяемые задачи читаются в них с блокировкой строк (FOR UPDATE).
+func (repo *TransactionRepository) Transaction(fn func(repos *Repositories) error) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		repos := newRepositories(tx, repo.workspaceId)
+		repos.Task = lockingTaskRepository(tx, repo.workspaceId)
+
+		return fn(repos)
+	})
+}
+
+// lockingTaskRepository создает репозиторий задач транзакции, блокирующий читаемые для изменения задачи
+func lockingTaskRepository(db *gorm.DB, workspaceId *uuid.UUID) *TaskRepository {
+	task := NewTaskRepository(db)
+
+	if workspaceId != nil {
+		task = NewWorkspaceTaskRepository(db, *workspaceId)
+	}
+
+	task.forUpdate = true
+
+	return task
+}
@@ -0,0 +1,111 @@
+package repository_test
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/helpers"
+	"testing"
+)
+
+func TestTransactionRepositoryTransaction_Commit(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	transactionRepository := repository.NewTransactionRepository(mockedDatabase)
+
+	err := transactionRepository.Transaction(func(repos *repository.Repositories) error {
+		return repos.Task.Purge(taskId)
+	})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactionRepositoryTransaction_Rollback(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	transactionRepository := repository.NewTransactionRepository(mockedDatabase)
+
+	err := transactionRepository.Transaction(func(repos *repository.Repositories) error {
+		return gorm.ErrInvalidValue
+	})
+
+	require.Equal(t, gorm.ErrInvalidValue, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactionRepositoryTransaction_NestedRollbackToSavepoint(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	transactionRepository := repository.NewTransactionRepository(mockedDatabase)
+
+	err := transactionRepository.Transaction(func(repos *repository.Repositories) error {
+		nestedErr := repos.Transactor.Transaction(func(repos *repository.Repositories) error {
+			return gorm.ErrInvalidValue
+		})
+
+		require.Equal(t, gorm.ErrInvalidValue, nestedErr)
+
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactionRepositoryTransaction_KeepsWorkspace(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, taskId := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "tasks" WHERE tasks.workspace_id = \$1 AND "tasks"."id" = \$2`).
+		WithArgs(workspaceId, taskId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repos := repository.NewRepositories(mockedDatabase).ForWorkspace(workspaceId)
+
+	err := repos.Transactor.Transaction(func(repos *repository.Repositories) error {
+		return repos.Task.Purge(taskId)
+	})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactionRepositoryTransaction_LocksTask(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT .+ FROM "tasks" .+ FOR UPDATE OF "tasks"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(taskId))
+	mock.ExpectCommit()
+
+	transactionRepository := repository.NewTransactionRepository(mockedDatabase)
+
+	err := transactionRepository.Transaction(func(repos *repository.Repositories) error {
+		_, err := repos.Task.FindById(taskId)
+		return err
+	})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"poymanov/todo/internal/domain"
 )
@@ -33,3 +34,57 @@ func (repo *UserRepository) FindByEmail(email string) (*domain.User, error) {
 
 	return &user, nil
 }
+
+func (repo *UserRepository) UpdateAutoArchiveDays(id uuid.UUID, days *int) error {
+	result := repo.db.Model(&domain.User{}).Where("id = ?", id).Update("auto_archive_days", days)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (repo *UserRepository) FindById(id uuid.UUID) (*domain.User, error) {
+	var user domain.User
+	result := repo.db.First(&user, "id = ?", id)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &user, nil
+}
+
+// Search возвращает страницу пользователей, имя или email которых содержат query, в порядке регистрации.
+// Пустой query возвращает всех пользователей.
+func (repo *UserRepository) Search(query string, limit, offset int) (*[]domain.User, error) {
+	var users []domain.User
+
+	db := repo.db.Order("created_at, id").Limit(limit).Offset(offset)
+
+	if query != "" {
+		pattern := "%" + likeEscaper.Replace(query) + "%"
+		db = db.Where(`name ILIKE ? ESCAPE '\' OR email ILIKE ? ESCAPE '\'`, pattern, pattern)
+	}
+
+	result := db.Find(&users)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &users, nil
+}
+
+func (repo *UserRepository) UpdateColumns(id uuid.UUID, columns map[string]interface{}) error {
+	result := repo.db.
+		Model(&domain.User{ID: id}).
+		Updates(columns)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
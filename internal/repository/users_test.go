@@ -3,6 +3,7 @@ package repository_test
 import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 	"poymanov/todo/internal/domain"
@@ -84,3 +85,85 @@ func TestUserRepositoryFindByEmailFailedNoExists(t *testing.T) {
 	require.Error(t, err)
 	require.Equal(t, gorm.ErrRecordNotFound, err)
 }
+
+func TestUserRepositoryUpdateAutoArchiveDays_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	userRepository := repository.NewUserRepository(mockedDatabase)
+
+	require.NoError(t, userRepository.UpdateAutoArchiveDays(userId, nil))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryFindById_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	mock.ExpectQuery(`SELECT \* FROM "users" WHERE id = \$1`).
+		WithArgs(userId, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "role"}).AddRow(userId, domain.UserRoleAdmin))
+
+	userRepository := repository.NewUserRepository(mockedDatabase)
+
+	existedUser, err := userRepository.FindById(userId)
+
+	require.NoError(t, err)
+	require.True(t, existedUser.IsAdmin())
+}
+
+func TestUserRepositorySearch_WithQuery(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	mock.ExpectQuery(`name ILIKE \$1 ESCAPE '\\' OR email ILIKE \$2 ESCAPE '\\'.+ORDER BY created_at, id LIMIT \$3 OFFSET \$4`).
+		WithArgs(`%50\%%`, `%50\%%`, 10, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(faker.UUIDHyphenated(), "50% user"))
+
+	userRepository := repository.NewUserRepository(mockedDatabase)
+
+	users, err := userRepository.Search("50%", 10, 20)
+
+	require.NoError(t, err)
+	require.Len(t, *users, 1)
+}
+
+func TestUserRepositorySearch_WithoutQuery(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	mock.ExpectQuery(`SELECT \* FROM "users" WHERE "users"."deleted_at" IS NULL ORDER BY created_at, id LIMIT \$1$`).
+		WithArgs(50).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	userRepository := repository.NewUserRepository(mockedDatabase)
+
+	users, err := userRepository.Search("", 50, 0)
+
+	require.NoError(t, err)
+	require.Empty(t, *users)
+}
+
+func TestUserRepositoryUpdateColumns_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "users" SET "password_reset_required"=\$1,"updated_at"=\$2 WHERE .+"id" = \$3`).
+		WithArgs(true, sqlmock.AnyArg(), userId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	userRepository := repository.NewUserRepository(mockedDatabase)
+
+	require.NoError(t, userRepository.UpdateColumns(userId, map[string]interface{}{"password_reset_required": true}))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+	"time"
+)
+
+// enqueueTaskEventQuery создает доставки события задачи всем вебхукам, подписанным на событие,
+// владельцам которых доступна задача
+const enqueueTaskEventQuery = `INSERT INTO webhook_deliveries (webhook_id, event, payload, status, next_attempt_at, created_at, updated_at)
+SELECT webhooks.id, @event, @payload, 'pending', @now, @now, @now
+FROM webhooks
+JOIN tasks ON tasks.id = @task
+WHERE webhooks.events @> jsonb_build_array(CAST(@event AS text))
+AND (tasks.list_id IS NULL AND tasks.user_id = webhooks.user_id OR tasks.list_id IN (
+	SELECT id FROM lists WHERE user_id = webhooks.user_id
+	UNION SELECT list_id FROM list_members WHERE user_id = webhooks.user_id
+))`
+
+// claimDueQuery откладывает на время lease и возвращает доставки, время очередной попытки которых наступило.
+// Пока доставка отложена, ее не получит другой экземпляр приложения.
+const claimDueQuery = `UPDATE webhook_deliveries SET next_attempt_at = @lease
+WHERE id IN (
+	SELECT id FROM webhook_deliveries
+	WHERE status = 'pending' AND next_attempt_at <= @now
+	ORDER BY next_attempt_at
+	LIMIT @limit
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING *`
+
+type WebhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookDeliveryRepository(db *gorm.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{db}
+}
+
+func (repo *WebhookDeliveryRepository) Create(delivery *domain.WebhookDelivery) error {
+	return repo.db.Create(delivery).Error
+}
+
+// EnqueueTaskEvent ставит в очередь доставку события с содержимым payload вебхукам, подписанным на событие
+func (repo *WebhookDeliveryRepository) EnqueueTaskEvent(event domain.TaskEvent, payload string) error {
+	return repo.db.Exec(enqueueTaskEventQuery,
+		sql.Named("event", event.Type),
+		sql.Named("payload", payload),
+		sql.Named("task", event.TaskId),
+		sql.Named("now", event.OccurredAt),
+	).Error
+}
+
+func (repo *WebhookDeliveryRepository) FindById(id uuid.UUID) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	result := repo.db.First(&delivery, "id = ?", id)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &delivery, nil
+}
+
+// GetByWebhookId возвращает не более limit последних доставок вебхука
+func (repo *WebhookDeliveryRepository) GetByWebhookId(webhookId uuid.UUID, limit int) *[]domain.WebhookDelivery {
+	var deliveries []domain.WebhookDelivery
+
+	repo.db.Where("webhook_id = ?", webhookId).Order("created_at desc").Limit(limit).Find(&deliveries)
+
+	return &deliveries
+}
+
+// ClaimDue возвращает не более limit доставок, которые пора выполнить, и откладывает их до now + lease
+func (repo *WebhookDeliveryRepository) ClaimDue(now time.Time, lease time.Duration, limit int) (*[]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+
+	result := repo.db.Raw(claimDueQuery,
+		sql.Named("now", now),
+		sql.Named("lease", now.Add(lease)),
+		sql.Named("limit", limit),
+	).Scan(&deliveries)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &deliveries, nil
+}
+
+func (repo *WebhookDeliveryRepository) UpdateColumns(id uuid.UUID, columns map[string]interface{}) error {
+	result := repo.db.
+		Model(&domain.WebhookDelivery{ID: id}).
+		Updates(columns)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
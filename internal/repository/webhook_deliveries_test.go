@@ -0,0 +1,84 @@
+package repository_test
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/helpers"
+	"testing"
+	"time"
+)
+
+func TestWebhookDeliveryRepositoryEnqueueTaskEvent_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	taskId, _ := twoUuids(t)
+	occurredAt := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(`INSERT INTO webhook_deliveries .* FROM webhooks JOIN tasks ON tasks.id = \$6 WHERE webhooks.events @> jsonb_build_array\(CAST\(\$7 AS text\)\)`).
+		WithArgs(domain.TaskEventCompleted, `{}`, occurredAt, occurredAt, occurredAt, taskId, domain.TaskEventCompleted).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	webhookDeliveryRepository := repository.NewWebhookDeliveryRepository(mockedDatabase)
+
+	event := domain.TaskEvent{Type: domain.TaskEventCompleted, TaskId: taskId, OccurredAt: occurredAt}
+
+	require.NoError(t, webhookDeliveryRepository.EnqueueTaskEvent(event, `{}`))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookDeliveryRepositoryGetByWebhookId_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	webhookId, deliveryId := twoUuids(t)
+
+	mock.ExpectQuery(`SELECT \* FROM "webhook_deliveries" WHERE webhook_id = \$1 ORDER BY created_at desc LIMIT \$2`).
+		WithArgs(webhookId, 50).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(deliveryId))
+
+	webhookDeliveryRepository := repository.NewWebhookDeliveryRepository(mockedDatabase)
+
+	require.Len(t, *webhookDeliveryRepository.GetByWebhookId(webhookId, 50), 1)
+}
+
+func TestWebhookDeliveryRepositoryClaimDue_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	deliveryId, webhookId := twoUuids(t)
+	now := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`UPDATE webhook_deliveries SET next_attempt_at = \$1 .* FOR UPDATE SKIP LOCKED \) RETURNING \*`).
+		WithArgs(now.Add(time.Minute), now, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "webhook_id", "status"}).AddRow(deliveryId, webhookId, domain.WebhookDeliveryPending))
+
+	webhookDeliveryRepository := repository.NewWebhookDeliveryRepository(mockedDatabase)
+
+	deliveries, err := webhookDeliveryRepository.ClaimDue(now, time.Minute, 20)
+
+	require.NoError(t, err)
+	require.Len(t, *deliveries, 1)
+	require.Equal(t, webhookId, (*deliveries)[0].WebhookId)
+}
+
+func TestWebhookDeliveryRepositoryUpdateColumns_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	deliveryId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "webhook_deliveries" SET "attempts"=\$1,"status"=\$2,"updated_at"=\$3 WHERE "id" = \$4`).
+		WithArgs(1, domain.WebhookDeliveryDelivered, sqlmock.AnyArg(), deliveryId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	webhookDeliveryRepository := repository.NewWebhookDeliveryRepository(mockedDatabase)
+
+	err := webhookDeliveryRepository.UpdateColumns(deliveryId, map[string]interface{}{
+		"attempts": 1,
+		"status":   domain.WebhookDeliveryDelivered,
+	})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+)
+
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{db}
+}
+
+func (repo *WebhookRepository) Create(webhook *domain.Webhook) error {
+	return repo.db.Create(webhook).Error
+}
+
+func (repo *WebhookRepository) FindById(id uuid.UUID) (*domain.Webhook, error) {
+	var webhook domain.Webhook
+	result := repo.db.First(&webhook, "id = ?", id)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &webhook, nil
+}
+
+func (repo *WebhookRepository) GetByUserId(userId uuid.UUID) *[]domain.Webhook {
+	var webhooks []domain.Webhook
+
+	repo.db.Where("user_id = ?", userId).Order("created_at").Find(&webhooks)
+
+	return &webhooks
+}
+
+// Delete удаляет вебхук вместе с журналом его доставок
+func (repo *WebhookRepository) Delete(id uuid.UUID) error {
+	return repo.db.Delete(&domain.Webhook{}, "id = ?", id).Error
+}
@@ -0,0 +1,77 @@
+package repository_test
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/helpers"
+	"testing"
+)
+
+func TestWebhookRepositoryCreate_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, webhookId := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"webhooks\"").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(webhookId))
+	mock.ExpectCommit()
+
+	webhookRepository := repository.NewWebhookRepository(mockedDatabase)
+
+	webhook := &domain.Webhook{UserId: userId, URL: "https://example.com/hook", Events: []string{domain.TaskEventCreated}}
+
+	require.NoError(t, webhookRepository.Create(webhook))
+	require.Equal(t, webhookId, webhook.ID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepositoryFindById_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	webhookId, _ := twoUuids(t)
+
+	mock.ExpectQuery(`SELECT \* FROM "webhooks" WHERE id = \$1`).
+		WithArgs(webhookId, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "events"}).AddRow(webhookId, `["task.created","task.deleted"]`))
+
+	webhookRepository := repository.NewWebhookRepository(mockedDatabase)
+
+	webhook, err := webhookRepository.FindById(webhookId)
+
+	require.NoError(t, err)
+	require.Equal(t, domain.WebhookEvents{domain.TaskEventCreated, domain.TaskEventDeleted}, webhook.Events)
+}
+
+func TestWebhookRepositoryGetByUserId_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	userId, webhookId := twoUuids(t)
+
+	mock.ExpectQuery(`SELECT \* FROM "webhooks" WHERE user_id = \$1 ORDER BY created_at`).
+		WithArgs(userId).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(webhookId))
+
+	webhookRepository := repository.NewWebhookRepository(mockedDatabase)
+
+	require.Len(t, *webhookRepository.GetByUserId(userId), 1)
+}
+
+func TestWebhookRepositoryDelete_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	webhookId, _ := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "webhooks" WHERE id = \$1`).
+		WithArgs(webhookId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	webhookRepository := repository.NewWebhookRepository(mockedDatabase)
+
+	require.NoError(t, webhookRepository.Delete(webhookId))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+)
+
+type WorkspaceMemberRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkspaceMemberRepository(db *gorm.DB) *WorkspaceMemberRepository {
+	return &WorkspaceMemberRepository{db}
+}
+
+func (repo *WorkspaceMemberRepository) Create(member *domain.WorkspaceMember) error {
+	return repo.db.Create(member).Error
+}
+
+func (repo *WorkspaceMemberRepository) Find(workspaceId, userId uuid.UUID) (*domain.WorkspaceMember, error) {
+	var member domain.WorkspaceMember
+	result := repo.db.First(&member, "workspace_id = ? and user_id = ?", workspaceId, userId)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &member, nil
+}
+
+// GetByWorkspaceId возвращает участников рабочего пространства с именами и адресами электронной почты в порядке добавления
+func (repo *WorkspaceMemberRepository) GetByWorkspaceId(workspaceId uuid.UUID) *[]domain.WorkspaceMember {
+	var members []domain.WorkspaceMember
+
+	repo.db.
+		Table("workspace_members").
+		Select("workspace_members.*, users.name, users.email").
+		Joins("JOIN users ON users.id = workspace_members.user_id").
+		Where("workspace_members.workspace_id = ?", workspaceId).
+		Order("workspace_members.created_at asc").
+		Scan(&members)
+
+	return &members
+}
+
+// Delete исключает пользователя из рабочего пространства и закрывает ему доступ к спискам этого пространства
+func (repo *WorkspaceMemberRepository) Delete(workspaceId, userId uuid.UUID) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Where("user_id = ? AND list_id IN (SELECT id FROM lists WHERE workspace_id = ?)", userId, workspaceId).
+			Delete(&domain.ListMember{})
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return tx.Delete(&domain.WorkspaceMember{}, "workspace_id = ? and user_id = ?", workspaceId, userId).Error
+	})
+}
@@ -0,0 +1,83 @@
+package repository_test
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/helpers"
+	"testing"
+)
+
+func TestWorkspaceMemberRepositoryCreate_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, userId := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO \"workspace_members\"").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	workspaceMemberRepository := repository.NewWorkspaceMemberRepository(mockedDatabase)
+
+	err := workspaceMemberRepository.Create(&domain.WorkspaceMember{WorkspaceId: workspaceId, UserId: userId, Role: domain.WorkspaceRoleMember})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWorkspaceMemberRepositoryFind_NotFound(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, userId := twoUuids(t)
+
+	mock.ExpectQuery("SELECT (.+) FROM \"workspace_members\"").
+		WithArgs(workspaceId, userId, 1).
+		WillReturnError(errors.New("record not found"))
+
+	workspaceMemberRepository := repository.NewWorkspaceMemberRepository(mockedDatabase)
+
+	member, err := workspaceMemberRepository.Find(workspaceId, userId)
+
+	require.Error(t, err)
+	require.Nil(t, member)
+}
+
+func TestWorkspaceMemberRepositoryGetByWorkspaceId_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, userId := twoUuids(t)
+
+	mock.ExpectQuery("JOIN users ON users.id = workspace_members.user_id").
+		WithArgs(workspaceId).
+		WillReturnRows(sqlmock.NewRows([]string{"workspace_id", "user_id", "role", "name", "email"}).
+			AddRow(workspaceId, userId, domain.WorkspaceRoleAdmin, "John", "john@example.com"))
+
+	workspaceMemberRepository := repository.NewWorkspaceMemberRepository(mockedDatabase)
+
+	members := workspaceMemberRepository.GetByWorkspaceId(workspaceId)
+
+	require.Len(t, *members, 1)
+	require.Equal(t, "john@example.com", (*members)[0].Email)
+}
+
+func TestWorkspaceMemberRepositoryDelete_RemovesListAccess(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, userId := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM \"list_members\" WHERE user_id = \\$1 AND list_id IN \\(SELECT id FROM lists WHERE workspace_id = \\$2\\)").
+		WithArgs(userId, workspaceId).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("DELETE FROM \"workspace_members\"").
+		WithArgs(workspaceId, userId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	workspaceMemberRepository := repository.NewWorkspaceMemberRepository(mockedDatabase)
+
+	require.NoError(t, workspaceMemberRepository.Delete(workspaceId, userId))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
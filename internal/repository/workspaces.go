@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+)
+
+type WorkspaceRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkspaceRepository(db *gorm.DB) *WorkspaceRepository {
+	return &WorkspaceRepository{db}
+}
+
+// Create создает рабочее пространство и делает пользователя ownerId его владельцем
+func (repo *WorkspaceRepository) Create(workspace *domain.Workspace, ownerId uuid.UUID) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(workspace).Error; err != nil {
+			return err
+		}
+
+		workspace.Role = domain.WorkspaceRoleOwner
+
+		return tx.Create(&domain.WorkspaceMember{
+			WorkspaceId: workspace.ID,
+			UserId:      ownerId,
+			Role:        domain.WorkspaceRoleOwner,
+		}).Error
+	})
+}
+
+// FindPersonal возвращает личное рабочее пространство пользователя userId
+func (repo *WorkspaceRepository) FindPersonal(userId uuid.UUID) (*domain.Workspace, error) {
+	var workspace domain.Workspace
+	result := repo.db.First(&workspace, "personal_user_id = ?", userId)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	workspace.Role = domain.WorkspaceRoleOwner
+
+	return &workspace, nil
+}
+
+// FindByMember возвращает рабочее пространство id, если пользователь userId его участник, вместе с его ролью
+func (repo *WorkspaceRepository) FindByMember(id, userId uuid.UUID) (*domain.Workspace, error) {
+	var workspace domain.Workspace
+
+	result := repo.db.
+		Table("workspaces").
+		Select("workspaces.*, workspace_members.role").
+		Joins("JOIN workspace_members ON workspace_members.workspace_id = workspaces.id").
+		Where("workspaces.id = ? AND workspace_members.user_id = ?", id, userId).
+		Take(&workspace)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &workspace, nil
+}
+
+// GetAllByUserId возвращает рабочие пространства, в которых участвует пользователь, вместе с его ролью
+func (repo *WorkspaceRepository) GetAllByUserId(id uuid.UUID) *[]domain.Workspace {
+	var workspaces []domain.Workspace
+
+	repo.db.
+		Table("workspaces").
+		Select("workspaces.*, workspace_members.role").
+		Joins("JOIN workspace_members ON workspace_members.workspace_id = workspaces.id").
+		Where("workspace_members.user_id = ?", id).
+		Order("workspaces.personal_user_id IS NULL, workspaces.created_at asc").
+		Scan(&workspaces)
+
+	return &workspaces
+}
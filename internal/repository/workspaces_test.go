@@ -0,0 +1,120 @@
+package repository_test
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/helpers"
+	"testing"
+)
+
+func TestWorkspaceRepositoryCreate_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, userId := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"workspaces\"").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(workspaceId))
+	mock.ExpectExec("INSERT INTO \"workspace_members\"").
+		WithArgs(workspaceId, userId, domain.WorkspaceRoleOwner, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	workspaceRepository := repository.NewWorkspaceRepository(mockedDatabase)
+
+	workspace := &domain.Workspace{Name: "Team"}
+
+	require.NoError(t, workspaceRepository.Create(workspace, userId))
+	require.Equal(t, workspaceId, workspace.ID)
+	require.Equal(t, domain.WorkspaceRoleOwner, workspace.Role)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWorkspaceRepositoryCreate_MemberFailed(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, userId := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"workspaces\"").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(workspaceId))
+	mock.ExpectExec("INSERT INTO \"workspace_members\"").WillReturnError(gorm.ErrInvalidValue)
+	mock.ExpectRollback()
+
+	workspaceRepository := repository.NewWorkspaceRepository(mockedDatabase)
+
+	require.Equal(t, gorm.ErrInvalidValue, workspaceRepository.Create(&domain.Workspace{Name: "Team"}, userId))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWorkspaceRepositoryFindPersonal_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, userId := twoUuids(t)
+
+	mock.ExpectQuery("SELECT (.+) FROM \"workspaces\" WHERE personal_user_id = \\$1").
+		WithArgs(userId, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "personal_user_id"}).AddRow(workspaceId, userId))
+
+	workspaceRepository := repository.NewWorkspaceRepository(mockedDatabase)
+
+	workspace, err := workspaceRepository.FindPersonal(userId)
+
+	require.NoError(t, err)
+	require.Equal(t, workspaceId, workspace.ID)
+	require.True(t, workspace.IsPersonal())
+	require.Equal(t, domain.WorkspaceRoleOwner, workspace.Role)
+}
+
+func TestWorkspaceRepositoryFindByMember_NotMember(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, userId := twoUuids(t)
+
+	mock.ExpectQuery("JOIN workspace_members").
+		WithArgs(workspaceId, userId, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	workspaceRepository := repository.NewWorkspaceRepository(mockedDatabase)
+
+	workspace, err := workspaceRepository.FindByMember(workspaceId, userId)
+
+	require.Nil(t, workspace)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestWorkspaceRepositoryFindByMember_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, userId := twoUuids(t)
+
+	mock.ExpectQuery("JOIN workspace_members").
+		WithArgs(workspaceId, userId, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}).AddRow(workspaceId, "Team", domain.WorkspaceRoleAdmin))
+
+	workspaceRepository := repository.NewWorkspaceRepository(mockedDatabase)
+
+	workspace, err := workspaceRepository.FindByMember(workspaceId, userId)
+
+	require.NoError(t, err)
+	require.Equal(t, domain.WorkspaceRoleAdmin, workspace.Role)
+	require.False(t, workspace.IsPersonal())
+}
+
+func TestWorkspaceRepositoryGetAllByUserId_Success(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, userId := twoUuids(t)
+
+	mock.ExpectQuery("JOIN workspace_members").
+		WithArgs(userId).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}).AddRow(workspaceId, "Team", domain.WorkspaceRoleMember))
+
+	workspaceRepository := repository.NewWorkspaceRepository(mockedDatabase)
+
+	workspaces := workspaceRepository.GetAllByUserId(userId)
+
+	require.Len(t, *workspaces, 1)
+	require.Equal(t, domain.WorkspaceRoleMember, (*workspaces)[0].Role)
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/jwt"
+	"time"
+)
+
+const (
+	ErrUserNotFound      = "user not found"
+	ErrCannotDisableSelf = "administrator cannot disable own account"
+
+	DefaultUsersLimit = 50
+	MaxUsersLimit     = 200
+)
+
+// PasswordReset - токен, по которому пользователь задает новый пароль после сброса администратором
+type PasswordReset struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+type AdminService struct {
+	userRepo repository.User
+	taskRepo repository.Task
+	jwt      *jwt.JWT
+	resetTTL time.Duration
+}
+
+func NewAdminService(userRepo repository.User, taskRepo repository.Task, jwt *jwt.JWT, resetTTL time.Duration) *AdminService {
+	return &AdminService{userRepo: userRepo, taskRepo: taskRepo, jwt: jwt, resetTTL: resetTTL}
+}
+
+// GetUsers возвращает страницу пользователей, имя или email которых содержат query
+func (s *AdminService) GetUsers(query string, limit, offset int) (*[]domain.User, error) {
+	if limit <= 0 || limit > MaxUsersLimit {
+		limit = DefaultUsersLimit
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	return s.userRepo.Search(query, limit, offset)
+}
+
+func (s *AdminService) FindUser(id uuid.UUID) (*domain.User, error) {
+	user, err := s.userRepo.FindById(id)
+
+	if err != nil {
+		return nil, errors.New(ErrUserNotFound)
+	}
+
+	return user, nil
+}
+
+// Disable блокирует учетную запись пользователя id. Администратор actorId не может заблокировать сам себя.
+func (s *AdminService) Disable(actorId, id uuid.UUID) (*domain.User, error) {
+	if actorId == id {
+		return nil, errors.New(ErrCannotDisableSelf)
+	}
+
+	user, err := s.FindUser(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsDisabled() {
+		return user, nil
+	}
+
+	now := time.Now()
+
+	if err = s.userRepo.UpdateColumns(id, map[string]interface{}{"disabled_at": now}); err != nil {
+		return nil, err
+	}
+
+	user.DisabledAt = &now
+
+	return user, nil
+}
+
+// Enable снимает блокировку с учетной записи пользователя id
+func (s *AdminService) Enable(id uuid.UUID) (*domain.User, error) {
+	user, err := s.FindUser(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsDisabled() {
+		return user, nil
+	}
+
+	if err = s.userRepo.UpdateColumns(id, map[string]interface{}{"disabled_at": nil}); err != nil {
+		return nil, err
+	}
+
+	user.DisabledAt = nil
+
+	return user, nil
+}
+
+// ResetPassword требует от пользователя id сменить пароль и возвращает токен для смены.
+// До смены пароля пользователь не может войти в приложение или работать с ранее выданным токеном.
+func (s *AdminService) ResetPassword(id uuid.UUID) (*PasswordReset, error) {
+	if _, err := s.FindUser(id); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.UpdateColumns(id, map[string]interface{}{"password_reset_required": true}); err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(s.resetTTL)
+
+	token, err := s.jwt.CreatePasswordReset(id.String(), expiresAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &PasswordReset{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// GetTaskCounts возвращает количество задач, созданных пользователем id
+func (s *AdminService) GetTaskCounts(id uuid.UUID) (domain.UserTaskCounts, error) {
+	if _, err := s.FindUser(id); err != nil {
+		return domain.UserTaskCounts{}, err
+	}
+
+	return s.taskRepo.CountAllByAuthorId(id)
+}
+
+// Promote назначает администратором пользователя с адресом email
+func (s *AdminService) Promote(email string) (*domain.User, error) {
+	user, err := s.userRepo.FindByEmail(email)
+
+	if err != nil {
+		return nil, errors.New(ErrUserNotFound)
+	}
+
+	if err = s.userRepo.UpdateColumns(user.ID, map[string]interface{}{"role": domain.UserRoleAdmin}); err != nil {
+		return nil, err
+	}
+
+	user.Role = domain.UserRoleAdmin
+
+	return user, nil
+}
@@ -0,0 +1,178 @@
+package service_test
+
+import (
+	"errors"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"poymanov/todo/internal/domain"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/jwt"
+	"testing"
+	"time"
+)
+
+func TestAdminServiceGetUsers_DefaultLimit(t *testing.T) {
+	adminService, userRepo, _ := mockAdminService(t)
+
+	userRepo.EXPECT().Search("query", service.DefaultUsersLimit, 0).Return(&[]domain.User{{Name: "User"}}, nil)
+
+	users, err := adminService.GetUsers("query", 0, -1)
+
+	require.NoError(t, err)
+	require.Equal(t, "User", (*users)[0].Name)
+}
+
+func TestAdminServiceDisable_Self(t *testing.T) {
+	adminService, _, _ := mockAdminService(t)
+
+	userId, _ := twoUuids(t)
+
+	user, err := adminService.Disable(userId, userId)
+
+	require.Nil(t, user)
+	require.EqualError(t, err, service.ErrCannotDisableSelf)
+}
+
+func TestAdminServiceDisable_NotFound(t *testing.T) {
+	adminService, userRepo, _ := mockAdminService(t)
+
+	actorId, userId := twoUuids(t)
+
+	userRepo.EXPECT().FindById(userId).Return(nil, errors.New("failed"))
+
+	user, err := adminService.Disable(actorId, userId)
+
+	require.Nil(t, user)
+	require.EqualError(t, err, service.ErrUserNotFound)
+}
+
+func TestAdminServiceDisable_Success(t *testing.T) {
+	adminService, userRepo, _ := mockAdminService(t)
+
+	actorId, userId := twoUuids(t)
+
+	userRepo.EXPECT().FindById(userId).Return(&domain.User{ID: userId}, nil)
+	userRepo.EXPECT().UpdateColumns(userId, gomock.Any()).DoAndReturn(func(_ interface{}, columns map[string]interface{}) error {
+		require.IsType(t, time.Time{}, columns["disabled_at"])
+
+		return nil
+	})
+
+	user, err := adminService.Disable(actorId, userId)
+
+	require.NoError(t, err)
+	require.True(t, user.IsDisabled())
+}
+
+func TestAdminServiceEnable_AlreadyEnabled(t *testing.T) {
+	adminService, userRepo, _ := mockAdminService(t)
+
+	userId, _ := twoUuids(t)
+
+	userRepo.EXPECT().FindById(userId).Return(&domain.User{ID: userId}, nil)
+
+	user, err := adminService.Enable(userId)
+
+	require.NoError(t, err)
+	require.False(t, user.IsDisabled())
+}
+
+func TestAdminServiceEnable_Success(t *testing.T) {
+	adminService, userRepo, _ := mockAdminService(t)
+
+	userId, _ := twoUuids(t)
+	disabledAt := time.Now()
+
+	userRepo.EXPECT().FindById(userId).Return(&domain.User{ID: userId, DisabledAt: &disabledAt}, nil)
+	userRepo.EXPECT().UpdateColumns(userId, map[string]interface{}{"disabled_at": nil}).Return(nil)
+
+	user, err := adminService.Enable(userId)
+
+	require.NoError(t, err)
+	require.False(t, user.IsDisabled())
+}
+
+func TestAdminServiceResetPassword_Success(t *testing.T) {
+	adminService, userRepo, _ := mockAdminService(t)
+
+	userId, _ := twoUuids(t)
+
+	userRepo.EXPECT().FindById(userId).Return(&domain.User{ID: userId}, nil)
+	userRepo.EXPECT().UpdateColumns(userId, map[string]interface{}{"password_reset_required": true}).Return(nil)
+
+	reset, err := adminService.ResetPassword(userId)
+
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(time.Hour), reset.ExpiresAt, time.Minute)
+
+	id, ok := jwt.NewJWT(faker.JWT).ParsePasswordReset(reset.Token)
+
+	require.True(t, ok)
+	require.Equal(t, userId.String(), id)
+}
+
+func TestAdminServiceGetTaskCounts_NotFound(t *testing.T) {
+	adminService, userRepo, _ := mockAdminService(t)
+
+	userId, _ := twoUuids(t)
+
+	userRepo.EXPECT().FindById(userId).Return(nil, errors.New("failed"))
+
+	_, err := adminService.GetTaskCounts(userId)
+
+	require.EqualError(t, err, service.ErrUserNotFound)
+}
+
+func TestAdminServiceGetTaskCounts_Success(t *testing.T) {
+	adminService, userRepo, taskRepo := mockAdminService(t)
+
+	userId, _ := twoUuids(t)
+
+	userRepo.EXPECT().FindById(userId).Return(&domain.User{ID: userId}, nil)
+	taskRepo.EXPECT().CountAllByAuthorId(userId).Return(domain.UserTaskCounts{Total: 3, Trashed: 1}, nil)
+
+	counts, err := adminService.GetTaskCounts(userId)
+
+	require.NoError(t, err)
+	require.Equal(t, domain.UserTaskCounts{Total: 3, Trashed: 1}, counts)
+}
+
+func TestAdminServicePromote_NotFound(t *testing.T) {
+	adminService, userRepo, _ := mockAdminService(t)
+
+	userRepo.EXPECT().FindByEmail("admin@test.com").Return(nil, errors.New("failed"))
+
+	user, err := adminService.Promote("admin@test.com")
+
+	require.Nil(t, user)
+	require.EqualError(t, err, service.ErrUserNotFound)
+}
+
+func TestAdminServicePromote_Success(t *testing.T) {
+	adminService, userRepo, _ := mockAdminService(t)
+
+	userId, _ := twoUuids(t)
+
+	userRepo.EXPECT().FindByEmail("admin@test.com").Return(&domain.User{ID: userId}, nil)
+	userRepo.EXPECT().UpdateColumns(userId, map[string]interface{}{"role": domain.UserRoleAdmin}).Return(nil)
+
+	user, err := adminService.Promote("admin@test.com")
+
+	require.NoError(t, err)
+	require.True(t, user.IsAdmin())
+}
+
+func mockAdminService(t *testing.T) (*service.AdminService, *mock_repository.MockUser, *mock_repository.MockTask) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	userRepo := mock_repository.NewMockUser(mockCtl)
+	taskRepo := mock_repository.NewMockTask(mockCtl)
+	adminService := service.NewAdminService(userRepo, taskRepo, jwt.NewJWT(faker.JWT), time.Hour)
+
+	return adminService, userRepo, taskRepo
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+)
+
+const (
+	DefaultAuditLimit = 100
+	MaxAuditLimit     = 1000
+	// MaxAuditExportLimit - наибольшее количество записей в одной выгрузке журнала
+	MaxAuditExportLimit = 100000
+)
+
+type AuditEventService struct {
+	auditEventRepo repository.AuditEvent
+	userRepo       repository.User
+}
+
+func NewAuditEventService(auditEventRepo repository.AuditEvent, userRepo repository.User) *AuditEventService {
+	return &AuditEventService{auditEventRepo: auditEventRepo, userRepo: userRepo}
+}
+
+// Record добавляет запись в журнал аудита. Если известен только адрес пользователя, выполнившего действие,
+// его идентификатор определяется по адресу.
+func (s *AuditEventService) Record(event domain.AuditEvent) error {
+	if event.ActorId == nil && event.ActorEmail != "" {
+		if actor, _ := s.userRepo.FindByEmail(event.ActorEmail); actor != nil {
+			event.ActorId = &actor.ID
+		}
+	}
+
+	return s.auditEventRepo.Create(&event)
+}
+
+// Search возвращает страницу записей журнала, удовлетворяющих фильтру, начиная с последних
+func (s *AuditEventService) Search(filter domain.AuditFilter, limit, offset int) (*[]domain.AuditEvent, error) {
+	if limit <= 0 || limit > MaxAuditLimit {
+		limit = DefaultAuditLimit
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	return s.auditEventRepo.Search(filter, limit, offset)
+}
+
+// Export возвращает для выгрузки записи журнала, удовлетворяющие фильтру, но не более MaxAuditExportLimit.
+// Признак truncated сообщает, что под фильтр попало больше записей и выгрузка неполная.
+func (s *AuditEventService) Export(filter domain.AuditFilter) (*[]domain.AuditEvent, bool, error) {
+	events, err := s.auditEventRepo.Search(filter, MaxAuditExportLimit+1, 0)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(*events) <= MaxAuditExportLimit {
+		return events, false, nil
+	}
+
+	*events = (*events)[:MaxAuditExportLimit]
+
+	return events, true, nil
+}
@@ -0,0 +1,103 @@
+package service_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"poymanov/todo/internal/domain"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	"testing"
+)
+
+func TestAuditEventServiceRecord_ResolveActor(t *testing.T) {
+	auditEventService, auditEventRepo, userRepo := mockAuditEventService(t)
+
+	actorId, _ := twoUuids(t)
+
+	userRepo.EXPECT().FindByEmail("john@example.com").Return(&domain.User{ID: actorId}, nil)
+	auditEventRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(event *domain.AuditEvent) error {
+		require.Equal(t, domain.AuditLogin, event.Action)
+		require.Equal(t, actorId, *event.ActorId)
+
+		return nil
+	})
+
+	require.NoError(t, auditEventService.Record(domain.AuditEvent{Action: domain.AuditLogin, ActorEmail: "john@example.com"}))
+}
+
+func TestAuditEventServiceRecord_UnknownActor(t *testing.T) {
+	auditEventService, auditEventRepo, userRepo := mockAuditEventService(t)
+
+	userRepo.EXPECT().FindByEmail("john@example.com").Return(nil, errors.New("failed"))
+	auditEventRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(event *domain.AuditEvent) error {
+		require.Nil(t, event.ActorId)
+		require.Equal(t, "john@example.com", event.ActorEmail)
+
+		return nil
+	})
+
+	require.NoError(t, auditEventService.Record(domain.AuditEvent{Action: domain.AuditLoginFailed, ActorEmail: "john@example.com"}))
+}
+
+func TestAuditEventServiceRecord_KnownActor(t *testing.T) {
+	auditEventService, auditEventRepo, _ := mockAuditEventService(t)
+
+	actorId, _ := twoUuids(t)
+
+	auditEventRepo.EXPECT().Create(gomock.Any()).Return(errors.New("failed"))
+
+	require.Error(t, auditEventService.Record(domain.AuditEvent{ActorId: &actorId, ActorEmail: "john@example.com"}))
+}
+
+func TestAuditEventServiceSearch_Limits(t *testing.T) {
+	auditEventService, auditEventRepo, _ := mockAuditEventService(t)
+
+	auditEventRepo.EXPECT().Search(domain.AuditFilter{}, service.DefaultAuditLimit, 0).Return(&[]domain.AuditEvent{}, nil)
+
+	events, err := auditEventService.Search(domain.AuditFilter{}, service.MaxAuditLimit+1, -5)
+
+	require.NoError(t, err)
+	require.Empty(t, *events)
+}
+
+func TestAuditEventServiceExport_Success(t *testing.T) {
+	auditEventService, auditEventRepo, _ := mockAuditEventService(t)
+
+	filter := domain.AuditFilter{Action: domain.AuditLogin}
+
+	auditEventRepo.EXPECT().Search(filter, service.MaxAuditExportLimit+1, 0).Return(&[]domain.AuditEvent{{Action: domain.AuditLogin}}, nil)
+
+	events, truncated, err := auditEventService.Export(filter)
+
+	require.NoError(t, err)
+	require.Len(t, *events, 1)
+	require.False(t, truncated)
+}
+
+func TestAuditEventServiceExport_Truncated(t *testing.T) {
+	auditEventService, auditEventRepo, _ := mockAuditEventService(t)
+
+	events := make([]domain.AuditEvent, service.MaxAuditExportLimit+1)
+
+	auditEventRepo.EXPECT().Search(domain.AuditFilter{}, service.MaxAuditExportLimit+1, 0).Return(&events, nil)
+
+	exported, truncated, err := auditEventService.Export(domain.AuditFilter{})
+
+	require.NoError(t, err)
+	require.Len(t, *exported, service.MaxAuditExportLimit)
+	require.True(t, truncated)
+}
+
+func mockAuditEventService(t *testing.T) (*service.AuditEventService, *mock_repository.MockAuditEvent, *mock_repository.MockUser) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	auditEventRepo := mock_repository.NewMockAuditEvent(mockCtl)
+	userRepo := mock_repository.NewMockUser(mockCtl)
+	auditEventService := service.NewAuditEventService(auditEventRepo, userRepo)
+
+	return auditEventService, auditEventRepo, userRepo
+}
@@ -2,13 +2,17 @@ package service
 
 import (
 	"errors"
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 	"poymanov/todo/pkg/jwt"
 )
 
 const (
-	ErrUserExists       = "user exists"
-	ErrWrongCredentials = "wrong email or password"
+	ErrUserExists                = "user exists"
+	ErrWrongCredentials          = "wrong email or password"
+	ErrUserDisabled              = "account is disabled"
+	ErrPasswordResetRequired     = "password reset required"
+	ErrInvalidPasswordResetToken = "password reset token is invalid or expired"
 )
 
 type RegisterData struct {
@@ -74,6 +78,14 @@ func (s *AuthService) Login(data LoginData) (string, error) {
 		return "", errors.New(ErrWrongCredentials)
 	}
 
+	if existedUser.IsDisabled() {
+		return "", errors.New(ErrUserDisabled)
+	}
+
+	if existedUser.PasswordResetRequired {
+		return "", errors.New(ErrPasswordResetRequired)
+	}
+
 	token, err := s.JWT.Create(jwt.JWTData{
 		Email: existedUser.Email,
 	})
@@ -84,3 +96,43 @@ func (s *AuthService) Login(data LoginData) (string, error) {
 
 	return token, nil
 }
+
+// ResetPassword задает новый пароль по токену, выданному администратором при сбросе пароля,
+// и возвращает токен авторизации. Токен сброса действует, пока пароль не изменен.
+func (s *AuthService) ResetPassword(token, password string) (string, error) {
+	userId, ok := s.JWT.ParsePasswordReset(token)
+
+	if !ok {
+		return "", errors.New(ErrInvalidPasswordResetToken)
+	}
+
+	id, err := uuid.Parse(userId)
+
+	if err != nil {
+		return "", errors.New(ErrInvalidPasswordResetToken)
+	}
+
+	existedUser, _ := s.UserService.FindById(id)
+
+	if existedUser == nil || !existedUser.PasswordResetRequired {
+		return "", errors.New(ErrInvalidPasswordResetToken)
+	}
+
+	if existedUser.IsDisabled() {
+		return "", errors.New(ErrUserDisabled)
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+
+	if err != nil {
+		return "", err
+	}
+
+	if err = s.UserService.UpdatePassword(id, string(hashedPassword)); err != nil {
+		return "", err
+	}
+
+	return s.JWT.Create(jwt.JWTData{
+		Email: existedUser.Email,
+	})
+}
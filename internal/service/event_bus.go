@@ -0,0 +1,70 @@
+package service
+
+import (
+	"poymanov/todo/internal/domain"
+	"sync"
+)
+
+// EventBus рассылает опубликованные события подписчикам внутри процесса. Публикация не блокируется:
+// подписка, буфер которой переполнен, закрывается, и подписчик должен подписаться заново.
+type EventBus struct {
+	mu            sync.Mutex
+	subscriptions map[*EventSubscription]struct{}
+}
+
+// EventSubscription - подписка на события шины. Канал C закрывается при отмене подписки
+// или если подписчик не успевает читать события.
+type EventSubscription struct {
+	C <-chan domain.OutboxEvent
+
+	events chan domain.OutboxEvent
+	bus    *EventBus
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{subscriptions: make(map[*EventSubscription]struct{})}
+}
+
+// Subscribe создает подписку с буфером на buffer событий
+func (b *EventBus) Subscribe(buffer int) *EventSubscription {
+	events := make(chan domain.OutboxEvent, buffer)
+	subscription := &EventSubscription{C: events, events: events, bus: b}
+
+	b.mu.Lock()
+	b.subscriptions[subscription] = struct{}{}
+	b.mu.Unlock()
+
+	return subscription
+}
+
+func (b *EventBus) Publish(event domain.OutboxEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for subscription := range b.subscriptions {
+		select {
+		case subscription.events <- event:
+		default:
+			b.remove(subscription)
+		}
+	}
+
+	return nil
+}
+
+// Close отменяет подписку. Повторный вызов ничего не делает.
+func (s *EventSubscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+
+	s.bus.remove(s)
+}
+
+func (b *EventBus) remove(subscription *EventSubscription) {
+	if _, ok := b.subscriptions[subscription]; !ok {
+		return
+	}
+
+	delete(b.subscriptions, subscription)
+	close(subscription.events)
+}
@@ -0,0 +1,56 @@
+package service_test
+
+import (
+	"github.com/stretchr/testify/require"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/service"
+	"testing"
+)
+
+func TestEventBusPublish_DeliversToSubscribers(t *testing.T) {
+	bus := service.NewEventBus()
+
+	first := bus.Subscribe(1)
+	second := bus.Subscribe(1)
+	defer first.Close()
+	defer second.Close()
+
+	require.NoError(t, bus.Publish(domain.OutboxEvent{Type: domain.TaskEventCreated}))
+
+	require.Equal(t, domain.TaskEventCreated, (<-first.C).Type)
+	require.Equal(t, domain.TaskEventCreated, (<-second.C).Type)
+}
+
+func TestEventBusPublish_DropsSlowSubscriber(t *testing.T) {
+	bus := service.NewEventBus()
+
+	slow := bus.Subscribe(1)
+	fast := bus.Subscribe(2)
+	defer fast.Close()
+
+	require.NoError(t, bus.Publish(domain.OutboxEvent{Type: domain.TaskEventCreated}))
+	require.NoError(t, bus.Publish(domain.OutboxEvent{Type: domain.TaskEventDeleted}))
+
+	require.Equal(t, domain.TaskEventCreated, (<-slow.C).Type)
+
+	_, ok := <-slow.C
+	require.False(t, ok)
+
+	require.Equal(t, domain.TaskEventCreated, (<-fast.C).Type)
+	require.Equal(t, domain.TaskEventDeleted, (<-fast.C).Type)
+
+	slow.Close()
+}
+
+func TestEventSubscriptionClose(t *testing.T) {
+	bus := service.NewEventBus()
+
+	subscription := bus.Subscribe(1)
+	subscription.Close()
+	subscription.Close()
+
+	require.NoError(t, bus.Publish(domain.OutboxEvent{Type: domain.TaskEventCreated}))
+
+	_, ok := <-subscription.C
+	require.False(t, ok)
+}
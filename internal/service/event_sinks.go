@@ -0,0 +1,71 @@
+package service
+
+import (
+	"encoding/json"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/kafka"
+	"poymanov/todo/pkg/nats"
+)
+
+// WebhookEventSink ставит события задач в очередь доставки вебхукам, подписанным на них
+type WebhookEventSink struct{}
+
+func NewWebhookEventSink() *WebhookEventSink {
+	return &WebhookEventSink{}
+}
+
+func (s *WebhookEventSink) Publish(repos *repository.Repositories, event domain.OutboxEvent) error {
+	if !domain.IsTaskEventType(event.Type) {
+		return nil
+	}
+
+	var taskEvent domain.TaskEvent
+
+	if err := json.Unmarshal([]byte(event.Payload), &taskEvent); err != nil {
+		return err
+	}
+
+	return repos.WebhookDelivery.EnqueueTaskEvent(taskEvent, event.Payload)
+}
+
+// NotifyEventSink оповещает через Postgres NOTIFY все экземпляры приложения об опубликованном событии.
+// Каждый экземпляр получает оповещение после фиксации транзакции и передает событие в свою шину.
+type NotifyEventSink struct{}
+
+func NewNotifyEventSink() *NotifyEventSink {
+	return &NotifyEventSink{}
+}
+
+func (s *NotifyEventSink) Publish(repos *repository.Repositories, event domain.OutboxEvent) error {
+	return repos.OutboxEvent.Notify(*event.PublishedSequence)
+}
+
+// NatsEventSink публикует события в NATS в тему <subject>.<тип события>, например todo.task.created
+type NatsEventSink struct {
+	client  *nats.Client
+	subject string
+}
+
+func NewNatsEventSink(client *nats.Client, subject string) *NatsEventSink {
+	return &NatsEventSink{client: client, subject: subject}
+}
+
+func (s *NatsEventSink) Publish(event domain.OutboxEvent) error {
+	return s.client.Publish(s.subject+"."+event.Type, []byte(event.Payload))
+}
+
+// KafkaEventSink публикует события в топик Kafka. Ключом сообщения служит сущность события,
+// поэтому события одной задачи читаются в порядке возникновения.
+type KafkaEventSink struct {
+	client *kafka.Client
+	topic  string
+}
+
+func NewKafkaEventSink(client *kafka.Client, topic string) *KafkaEventSink {
+	return &KafkaEventSink{client: client, topic: topic}
+}
+
+func (s *KafkaEventSink) Publish(event domain.OutboxEvent) error {
+	return s.client.Produce(s.topic, event.AggregateId.String(), json.RawMessage(event.Payload))
+}
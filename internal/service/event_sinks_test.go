@@ -0,0 +1,89 @@
+package service_test
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/kafka"
+	"testing"
+	"time"
+)
+
+func TestWebhookEventSinkPublish_TaskEvent(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	webhookDeliveryRepo := mock_repository.NewMockWebhookDelivery(mockCtl)
+	sink := service.NewWebhookEventSink()
+
+	eventId, taskId := twoUuids(t)
+	payload := `{"id":"` + eventId.String() + `","event":"task.completed","task_id":"` + taskId.String() + `"}`
+
+	webhookDeliveryRepo.EXPECT().EnqueueTaskEvent(gomock.Any(), payload).DoAndReturn(func(event domain.TaskEvent, _ string) error {
+		require.Equal(t, domain.TaskEventCompleted, event.Type)
+		require.Equal(t, taskId, event.TaskId)
+
+		return nil
+	})
+
+	repos := &repository.Repositories{WebhookDelivery: webhookDeliveryRepo}
+
+	require.NoError(t, sink.Publish(repos, domain.OutboxEvent{ID: eventId, Type: domain.TaskEventCompleted, AggregateId: taskId, Payload: payload}))
+}
+
+func TestWebhookEventSinkPublish_OtherEvent(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	repos := &repository.Repositories{WebhookDelivery: mock_repository.NewMockWebhookDelivery(mockCtl)}
+
+	require.NoError(t, service.NewWebhookEventSink().Publish(repos, domain.OutboxEvent{Type: "list.created", Payload: `{}`}))
+}
+
+func TestKafkaEventSinkPublish_KeyedByAggregate(t *testing.T) {
+	_, taskId := twoUuids(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Records []struct {
+				Key   string          `json:"key"`
+				Value json.RawMessage `json:"value"`
+			} `json:"records"`
+		}
+
+		data, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(data, &body))
+		require.Equal(t, "/topics/todo.events", r.URL.Path)
+		require.Equal(t, taskId.String(), body.Records[0].Key)
+		require.JSONEq(t, `{"event":"task.deleted"}`, string(body.Records[0].Value))
+
+		_, _ = w.Write([]byte(`{"offsets":[{"partition":0,"offset":0}]}`))
+	}))
+	defer server.Close()
+
+	sink := service.NewKafkaEventSink(kafka.NewClient(server.URL, time.Second), "todo.events")
+
+	require.NoError(t, sink.Publish(domain.OutboxEvent{Type: domain.TaskEventDeleted, AggregateId: taskId, Payload: `{"event":"task.deleted"}`}))
+}
+
+func TestNotifyEventSinkPublish_NotifiesPublishedSequence(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	outboxEventRepo := mock_repository.NewMockOutboxEvent(mockCtl)
+	repos := &repository.Repositories{OutboxEvent: outboxEventRepo}
+
+	publishedSequence := int64(43)
+	outboxEventRepo.EXPECT().Notify(publishedSequence).Return(nil)
+
+	event := domain.OutboxEvent{Sequence: 42, PublishedSequence: &publishedSequence, Type: domain.TaskEventCreated}
+
+	require.NoError(t, service.NewNotifyEventSink().Publish(repos, event))
+}
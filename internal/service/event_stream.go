@@ -0,0 +1,206 @@
+package service
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"sync"
+)
+
+const (
+	// eventStreamBuffer - сколько событий может накопиться у подписчика, прежде чем подписка будет закрыта
+	eventStreamBuffer = 256
+	// eventStreamReplayLimit - сколько пропущенных событий можно досылать при возобновлении потока
+	eventStreamReplayLimit = 500
+	// eventStreamViewersCache - для скольких последних событий запоминаются пользователи, которым они доступны
+	eventStreamViewersCache = eventStreamReplayLimit
+)
+
+// EventStreamService раздает пользователям события задач из списков, к которым у них есть доступ
+type EventStreamService struct {
+	outboxEventRepo repository.OutboxEvent
+	listRepo        repository.List
+	bus             *EventBus
+
+	mu sync.Mutex
+	// lastForwarded - номер публикации последнего события, переданного в шину этого экземпляра
+	lastForwarded int64
+	caughtUp      bool
+
+	viewersMu sync.Mutex
+	// viewers - получатели последних событий по их идентификаторам, viewersOrder - порядок их вытеснения
+	viewers      map[uuid.UUID]*eventViewers
+	viewersOrder []uuid.UUID
+}
+
+// eventViewers - событие задачи и пользователи, которым оно доступно. Определяются один раз на событие
+// для всех подписчиков потока.
+type eventViewers struct {
+	once      sync.Once
+	taskEvent *domain.TaskEvent
+	userIds   map[uuid.UUID]struct{}
+}
+
+func NewEventStreamService(outboxEventRepo repository.OutboxEvent, listRepo repository.List, bus *EventBus) *EventStreamService {
+	return &EventStreamService{
+		outboxEventRepo: outboxEventRepo,
+		listRepo:        listRepo,
+		bus:             bus,
+		viewers:         make(map[uuid.UUID]*eventViewers),
+	}
+}
+
+func (s *EventStreamService) Subscribe() *EventSubscription {
+	return s.bus.Subscribe(eventStreamBuffer)
+}
+
+// Replay возвращает события, опубликованные после события afterSequence. Если событие afterSequence
+// уже удалено из outbox или пропущенных событий больше, чем можно досылать, complete равен false.
+func (s *EventStreamService) Replay(afterSequence int64) (events *[]domain.OutboxEvent, complete bool) {
+	if _, err := s.outboxEventRepo.FindByPublishedSequence(afterSequence); err != nil {
+		return &[]domain.OutboxEvent{}, false
+	}
+
+	events = s.outboxEventRepo.GetPublishedAfter(afterSequence, eventStreamReplayLimit)
+
+	return events, len(*events) < eventStreamReplayLimit
+}
+
+// Visible возвращает событие задачи, если оно доступно пользователю userId. Событие перемещения
+// задачи видно участникам как нового, так и прежнего списка. Участники списков запрашиваются один раз
+// на событие, остальные подписчики проверяются по запомненному составу.
+func (s *EventStreamService) Visible(userId uuid.UUID, event domain.OutboxEvent) (*domain.TaskEvent, bool) {
+	if !domain.IsTaskEventType(event.Type) {
+		return nil, false
+	}
+
+	viewers := s.eventViewers(event)
+
+	if _, ok := viewers.userIds[userId]; !ok || viewers.taskEvent == nil {
+		return nil, false
+	}
+
+	return viewers.taskEvent, true
+}
+
+// eventViewers возвращает получателей события, определяя их при первом обращении
+func (s *EventStreamService) eventViewers(event domain.OutboxEvent) *eventViewers {
+	s.viewersMu.Lock()
+	viewers, ok := s.viewers[event.ID]
+
+	if !ok {
+		viewers = &eventViewers{}
+		s.viewers[event.ID] = viewers
+		s.viewersOrder = append(s.viewersOrder, event.ID)
+
+		if len(s.viewersOrder) > eventStreamViewersCache {
+			delete(s.viewers, s.viewersOrder[0])
+			s.viewersOrder = s.viewersOrder[1:]
+		}
+	}
+	s.viewersMu.Unlock()
+
+	viewers.once.Do(func() {
+		viewers.taskEvent, viewers.userIds = s.resolveViewers(event)
+	})
+
+	return viewers
+}
+
+// resolveViewers разбирает событие задачи и определяет, кому оно доступно: владельцам и участникам нового
+// и прежнего списков задачи, а для задачи вне списков - ее автору
+func (s *EventStreamService) resolveViewers(event domain.OutboxEvent) (*domain.TaskEvent, map[uuid.UUID]struct{}) {
+	var taskEvent domain.TaskEvent
+
+	if err := json.Unmarshal([]byte(event.Payload), &taskEvent); err != nil {
+		return nil, nil
+	}
+
+	listIds := []*uuid.UUID{taskEvent.ListId}
+
+	for _, change := range taskEvent.Changes {
+		if change.Field == domain.TaskFieldListId {
+			listIds = append(listIds, parseUuidValue(change.OldValue))
+		}
+	}
+
+	userIds := make(map[uuid.UUID]struct{})
+
+	for _, listId := range listIds {
+		if listId == nil {
+			userIds[taskEvent.UserId] = struct{}{}
+			continue
+		}
+
+		for _, userId := range s.listRepo.GetUserIds(*listId) {
+			userIds[userId] = struct{}{}
+		}
+	}
+
+	return &taskEvent, userIds
+}
+
+// Forward передает в шину событие publishedSequence, о публикации которого сообщил экземпляр приложения.
+// Уже переданные события пропускаются.
+func (s *EventStreamService) Forward(publishedSequence int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if publishedSequence <= s.lastForwarded {
+		return nil
+	}
+
+	event, err := s.outboxEventRepo.FindByPublishedSequence(publishedSequence)
+
+	if err != nil {
+		return err
+	}
+
+	return s.forward(*event)
+}
+
+// CatchUp передает в шину события, опубликованные после последнего переданного, например пока не было
+// соединения для получения оповещений. При первом вызове только запоминает номер последнего опубликованного события.
+func (s *EventStreamService) CatchUp() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.caughtUp {
+		last, err := s.outboxEventRepo.GetLastPublishedSequence()
+
+		if err != nil {
+			return err
+		}
+
+		s.lastForwarded, s.caughtUp = last, true
+
+		return nil
+	}
+
+	for {
+		events := s.outboxEventRepo.GetPublishedAfter(s.lastForwarded, eventStreamReplayLimit)
+
+		for _, event := range *events {
+			if err := s.forward(event); err != nil {
+				return err
+			}
+		}
+
+		if len(*events) < eventStreamReplayLimit {
+			return nil
+		}
+	}
+}
+
+func (s *EventStreamService) forward(event domain.OutboxEvent) error {
+	if err := s.bus.Publish(event); err != nil {
+		return err
+	}
+
+	if event.PublishedSequence != nil {
+		s.lastForwarded = *event.PublishedSequence
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package service_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"poymanov/todo/internal/domain"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	"testing"
+)
+
+type eventStreamMocks struct {
+	outboxEventRepo *mock_repository.MockOutboxEvent
+	listRepo        *mock_repository.MockList
+}
+
+func TestEventStreamServiceReplay_Complete(t *testing.T) {
+	eventStreamService, mocks := mockEventStreamService(t)
+
+	mocks.outboxEventRepo.EXPECT().FindByPublishedSequence(int64(41)).Return(publishedOutboxEvent(41), nil)
+	mocks.outboxEventRepo.EXPECT().GetPublishedAfter(int64(41), 500).Return(&[]domain.OutboxEvent{*publishedOutboxEvent(42)})
+
+	events, complete := eventStreamService.Replay(41)
+
+	require.True(t, complete)
+	require.Len(t, *events, 1)
+}
+
+func TestEventStreamServiceReplay_TooManyEvents(t *testing.T) {
+	eventStreamService, mocks := mockEventStreamService(t)
+
+	events := make([]domain.OutboxEvent, 500)
+	mocks.outboxEventRepo.EXPECT().FindByPublishedSequence(int64(1)).Return(publishedOutboxEvent(1), nil)
+	mocks.outboxEventRepo.EXPECT().GetPublishedAfter(int64(1), 500).Return(&events)
+
+	_, complete := eventStreamService.Replay(1)
+
+	require.False(t, complete)
+}
+
+func TestEventStreamServiceReplay_LastEventPurged(t *testing.T) {
+	eventStreamService, mocks := mockEventStreamService(t)
+
+	mocks.outboxEventRepo.EXPECT().FindByPublishedSequence(int64(41)).Return(nil, errors.New("record not found"))
+
+	events, complete := eventStreamService.Replay(41)
+
+	require.False(t, complete)
+	require.Empty(t, *events)
+}
+
+func TestEventStreamServiceVisible_OtherEvent(t *testing.T) {
+	eventStreamService, _ := mockEventStreamService(t)
+
+	_, ok := eventStreamService.Visible(uuid.New(), domain.OutboxEvent{Type: "list.created", Payload: `{}`})
+
+	require.False(t, ok)
+}
+
+func TestEventStreamServiceVisible_TaskWithoutList(t *testing.T) {
+	eventStreamService, _ := mockEventStreamService(t)
+
+	userId, taskId := twoUuids(t)
+	event := taskOutboxEvent(t, domain.TaskEvent{Type: domain.TaskEventCreated, TaskId: taskId, UserId: userId})
+
+	taskEvent, ok := eventStreamService.Visible(userId, event)
+
+	require.True(t, ok)
+	require.Equal(t, taskId, taskEvent.TaskId)
+
+	_, ok = eventStreamService.Visible(uuid.New(), event)
+
+	require.False(t, ok)
+}
+
+func TestEventStreamServiceVisible_ListMember(t *testing.T) {
+	eventStreamService, mocks := mockEventStreamService(t)
+
+	userId, listId := twoUuids(t)
+	authorId, taskId := twoUuids(t)
+	event := taskOutboxEvent(t, domain.TaskEvent{Type: domain.TaskEventUpdated, TaskId: taskId, UserId: authorId, ListId: &listId})
+
+	mocks.listRepo.EXPECT().GetUserIds(listId).Return([]uuid.UUID{authorId, userId})
+
+	_, ok := eventStreamService.Visible(userId, event)
+
+	require.True(t, ok)
+
+	// остальные подписчики проверяются без повторного запроса участников
+	_, ok = eventStreamService.Visible(authorId, event)
+
+	require.True(t, ok)
+
+	_, ok = eventStreamService.Visible(uuid.New(), event)
+
+	require.False(t, ok)
+}
+
+func TestEventStreamServiceVisible_MovedFromList(t *testing.T) {
+	eventStreamService, mocks := mockEventStreamService(t)
+
+	userId, oldListId := twoUuids(t)
+	authorId, newListId := twoUuids(t)
+	oldValue, newValue := oldListId.String(), newListId.String()
+	event := taskOutboxEvent(t, domain.TaskEvent{
+		Type: domain.TaskEventUpdated, TaskId: uuid.New(), UserId: authorId, ListId: &newListId,
+		Changes: []domain.TaskChange{{Field: domain.TaskFieldListId, OldValue: &oldValue, NewValue: &newValue}},
+	})
+
+	mocks.listRepo.EXPECT().GetUserIds(newListId).Return([]uuid.UUID{authorId})
+	mocks.listRepo.EXPECT().GetUserIds(oldListId).Return([]uuid.UUID{userId})
+
+	_, ok := eventStreamService.Visible(userId, event)
+
+	require.True(t, ok)
+}
+
+func TestEventStreamServiceForward_PublishesToBus(t *testing.T) {
+	eventStreamService, mocks := mockEventStreamService(t)
+
+	subscription := eventStreamService.Subscribe()
+	defer subscription.Close()
+
+	mocks.outboxEventRepo.EXPECT().FindByPublishedSequence(int64(42)).Return(publishedOutboxEvent(42), nil)
+
+	require.NoError(t, eventStreamService.Forward(42))
+	require.Equal(t, int64(42), *(<-subscription.C).PublishedSequence)
+
+	// повторное оповещение о том же событии не передается
+	require.NoError(t, eventStreamService.Forward(42))
+	require.Empty(t, subscription.C)
+}
+
+func TestEventStreamServiceForward_NotFound(t *testing.T) {
+	eventStreamService, mocks := mockEventStreamService(t)
+
+	mocks.outboxEventRepo.EXPECT().FindByPublishedSequence(int64(42)).Return(nil, errors.New("record not found"))
+
+	require.Error(t, eventStreamService.Forward(42))
+}
+
+func TestEventStreamServiceCatchUp_ForwardsMissedEvents(t *testing.T) {
+	eventStreamService, mocks := mockEventStreamService(t)
+
+	subscription := eventStreamService.Subscribe()
+	defer subscription.Close()
+
+	mocks.outboxEventRepo.EXPECT().GetLastPublishedSequence().Return(int64(40), nil)
+	require.NoError(t, eventStreamService.CatchUp())
+
+	mocks.outboxEventRepo.EXPECT().FindByPublishedSequence(int64(41)).Return(publishedOutboxEvent(41), nil)
+	require.NoError(t, eventStreamService.Forward(41))
+
+	// после переподключения досылаются события, опубликованные без соединения
+	mocks.outboxEventRepo.EXPECT().GetPublishedAfter(int64(41), 500).
+		Return(&[]domain.OutboxEvent{*publishedOutboxEvent(42), *publishedOutboxEvent(43)})
+	require.NoError(t, eventStreamService.CatchUp())
+
+	require.Equal(t, int64(41), *(<-subscription.C).PublishedSequence)
+	require.Equal(t, int64(42), *(<-subscription.C).PublishedSequence)
+	require.Equal(t, int64(43), *(<-subscription.C).PublishedSequence)
+
+	// оповещение о событии, уже досланном после переподключения, пропускается
+	require.NoError(t, eventStreamService.Forward(43))
+	require.Empty(t, subscription.C)
+}
+
+func publishedOutboxEvent(publishedSequence int64) *domain.OutboxEvent {
+	return &domain.OutboxEvent{PublishedSequence: &publishedSequence}
+}
+
+func taskOutboxEvent(t *testing.T, event domain.TaskEvent) domain.OutboxEvent {
+	t.Helper()
+
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	return domain.OutboxEvent{ID: uuid.New(), Type: event.Type, AggregateId: event.TaskId, Payload: string(payload)}
+}
+
+func mockEventStreamService(t *testing.T) (*service.EventStreamService, eventStreamMocks) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	t.Cleanup(mockCtl.Finish)
+
+	mocks := eventStreamMocks{
+		outboxEventRepo: mock_repository.NewMockOutboxEvent(mockCtl),
+		listRepo:        mock_repository.NewMockList(mockCtl),
+	}
+	return service.NewEventStreamService(mocks.outboxEventRepo, mocks.listRepo, service.NewEventBus()), mocks
+}
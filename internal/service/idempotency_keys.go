@@ -0,0 +1,80 @@
+package service
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"time"
+)
+
+const (
+	ErrIdempotencyKeyReused     = "idempotency key is already used for another request"
+	ErrIdempotencyKeyInProgress = "request with this idempotency key is still in progress"
+)
+
+type IdempotencyKeyService struct {
+	idempotencyKeyRepo repository.IdempotencyKey
+	ttl                time.Duration
+}
+
+func NewIdempotencyKeyService(idempotencyKeyRepo repository.IdempotencyKey, ttl time.Duration) *IdempotencyKeyService {
+	return &IdempotencyKeyService{idempotencyKeyRepo: idempotencyKeyRepo, ttl: ttl}
+}
+
+// Begin резервирует ключ key пользователя userId для запроса с отпечатком fingerprint. Если запрос с этим ключом
+// уже выполнен, возвращается сохраненный результат, если он еще выполняется - ошибка ErrIdempotencyKeyInProgress.
+// Ключ, использованный для запроса с другим отпечатком, отклоняется с ошибкой ErrIdempotencyKeyReused.
+func (s *IdempotencyKeyService) Begin(userId uuid.UUID, key, fingerprint string) (*domain.IdempotencyKey, error) {
+	existed, err := s.idempotencyKeyRepo.Find(userId, key)
+
+	if err == nil && existed.CreatedAt.Before(time.Now().Add(-s.ttl)) {
+		if err = s.idempotencyKeyRepo.Delete(userId, key); err != nil {
+			return nil, err
+		}
+
+		existed = nil
+	}
+
+	if existed != nil {
+		switch {
+		case existed.Fingerprint != fingerprint:
+			return nil, errors.New(ErrIdempotencyKeyReused)
+		case existed.StatusCode == 0:
+			return nil, errors.New(ErrIdempotencyKeyInProgress)
+		}
+
+		return existed, nil
+	}
+
+	created, err := s.idempotencyKeyRepo.Create(&domain.IdempotencyKey{
+		UserId: userId, Key: key, Fingerprint: fingerprint, Headers: map[string]string{},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !created {
+		return nil, errors.New(ErrIdempotencyKeyInProgress)
+	}
+
+	return nil, nil
+}
+
+// Complete сохраняет ответ на запрос с ключом key для повторной отправки
+func (s *IdempotencyKeyService) Complete(userId uuid.UUID, key string, statusCode int, headers map[string]string, body []byte) error {
+	return s.idempotencyKeyRepo.SaveResponse(&domain.IdempotencyKey{
+		UserId: userId, Key: key, StatusCode: statusCode, Headers: headers, Body: body,
+	})
+}
+
+// Release освобождает ключ key, чтобы запрос можно было повторить
+func (s *IdempotencyKeyService) Release(userId uuid.UUID, key string) error {
+	return s.idempotencyKeyRepo.Delete(userId, key)
+}
+
+// PurgeExpired удаляет ключи старше срока хранения и возвращает их количество
+func (s *IdempotencyKeyService) PurgeExpired() (int64, error) {
+	return s.idempotencyKeyRepo.DeleteCreatedBefore(time.Now().Add(-s.ttl))
+}
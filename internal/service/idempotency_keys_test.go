@@ -0,0 +1,126 @@
+package service_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"poymanov/todo/internal/domain"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyKeyServiceBegin_NewKey(t *testing.T) {
+	idempotencyKeyService, idempotencyKeyRepo := mockIdempotencyKeyService(t)
+
+	userId, _ := twoUuids(t)
+
+	idempotencyKeyRepo.EXPECT().Find(userId, "key").Return(nil, errors.New("not found"))
+	idempotencyKeyRepo.EXPECT().Create(gomock.Any()).Return(true, nil)
+
+	stored, err := idempotencyKeyService.Begin(userId, "key", "abc")
+
+	require.NoError(t, err)
+	require.Nil(t, stored)
+}
+
+func TestIdempotencyKeyServiceBegin_Replay(t *testing.T) {
+	idempotencyKeyService, idempotencyKeyRepo := mockIdempotencyKeyService(t)
+
+	userId, _ := twoUuids(t)
+
+	idempotencyKeyRepo.EXPECT().Find(userId, "key").Return(&domain.IdempotencyKey{
+		Fingerprint: "abc", StatusCode: 201, CreatedAt: time.Now(),
+	}, nil)
+
+	stored, err := idempotencyKeyService.Begin(userId, "key", "abc")
+
+	require.NoError(t, err)
+	require.Equal(t, 201, stored.StatusCode)
+}
+
+func TestIdempotencyKeyServiceBegin_AnotherRequest(t *testing.T) {
+	idempotencyKeyService, idempotencyKeyRepo := mockIdempotencyKeyService(t)
+
+	userId, _ := twoUuids(t)
+
+	idempotencyKeyRepo.EXPECT().Find(userId, "key").Return(&domain.IdempotencyKey{
+		Fingerprint: "abc", StatusCode: 201, CreatedAt: time.Now(),
+	}, nil)
+
+	stored, err := idempotencyKeyService.Begin(userId, "key", "def")
+
+	require.EqualError(t, err, service.ErrIdempotencyKeyReused)
+	require.Nil(t, stored)
+}
+
+func TestIdempotencyKeyServiceBegin_InProgress(t *testing.T) {
+	idempotencyKeyService, idempotencyKeyRepo := mockIdempotencyKeyService(t)
+
+	userId, _ := twoUuids(t)
+
+	idempotencyKeyRepo.EXPECT().Find(userId, "key").Return(&domain.IdempotencyKey{
+		Fingerprint: "abc", CreatedAt: time.Now(),
+	}, nil)
+
+	_, err := idempotencyKeyService.Begin(userId, "key", "abc")
+
+	require.EqualError(t, err, service.ErrIdempotencyKeyInProgress)
+}
+
+func TestIdempotencyKeyServiceBegin_ReservedConcurrently(t *testing.T) {
+	idempotencyKeyService, idempotencyKeyRepo := mockIdempotencyKeyService(t)
+
+	userId, _ := twoUuids(t)
+
+	idempotencyKeyRepo.EXPECT().Find(userId, "key").Return(nil, errors.New("not found"))
+	idempotencyKeyRepo.EXPECT().Create(gomock.Any()).Return(false, nil)
+
+	_, err := idempotencyKeyService.Begin(userId, "key", "abc")
+
+	require.EqualError(t, err, service.ErrIdempotencyKeyInProgress)
+}
+
+func TestIdempotencyKeyServiceBegin_Expired(t *testing.T) {
+	idempotencyKeyService, idempotencyKeyRepo := mockIdempotencyKeyService(t)
+
+	userId, _ := twoUuids(t)
+
+	idempotencyKeyRepo.EXPECT().Find(userId, "key").Return(&domain.IdempotencyKey{
+		Fingerprint: "abc", StatusCode: 201, CreatedAt: time.Now().Add(-25 * time.Hour),
+	}, nil)
+	idempotencyKeyRepo.EXPECT().Delete(userId, "key").Return(nil)
+	idempotencyKeyRepo.EXPECT().Create(gomock.Any()).Return(true, nil)
+
+	stored, err := idempotencyKeyService.Begin(userId, "key", "def")
+
+	require.NoError(t, err)
+	require.Nil(t, stored)
+}
+
+func TestIdempotencyKeyServicePurgeExpired_Success(t *testing.T) {
+	idempotencyKeyService, idempotencyKeyRepo := mockIdempotencyKeyService(t)
+
+	idempotencyKeyRepo.EXPECT().DeleteCreatedBefore(gomock.Any()).DoAndReturn(func(before time.Time) (int64, error) {
+		require.WithinDuration(t, time.Now().Add(-24*time.Hour), before, time.Minute)
+		return 2, nil
+	})
+
+	purged, err := idempotencyKeyService.PurgeExpired()
+
+	require.NoError(t, err)
+	require.Equal(t, int64(2), purged)
+}
+
+func mockIdempotencyKeyService(t *testing.T) (*service.IdempotencyKeyService, *mock_repository.MockIdempotencyKey) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	idempotencyKeyRepo := mock_repository.NewMockIdempotencyKey(mockCtl)
+	idempotencyKeyService := service.NewIdempotencyKeyService(idempotencyKeyRepo, 24*time.Hour)
+
+	return idempotencyKeyService, idempotencyKeyRepo
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/jwt"
+	"time"
+)
+
+const (
+	ErrInvalidInviteRole   = "invalid role"
+	ErrInviteNotFound      = "invite not found or expired"
+	ErrInviteUsed          = "invite has already been used"
+	ErrInviteAlreadyJoined = "user already has access"
+)
+
+// InviteData - параметры создаваемого приглашения. Если ListId не задан, приглашение ведет в пространство
+// WorkspaceId. Нулевой TTL заменяется сроком действия по умолчанию.
+type InviteData struct {
+	UserId      uuid.UUID
+	WorkspaceId uuid.UUID
+	ListId      *uuid.UUID
+	Role        string
+	SingleUse   bool
+	TTL         time.Duration
+}
+
+type InviteService struct {
+	inviteRepo repository.Invite
+	transactor repository.Transactor
+	jwt        *jwt.JWT
+	ttl        time.Duration
+}
+
+func NewInviteService(inviteRepo repository.Invite, transactor repository.Transactor, jwt *jwt.JWT, ttl time.Duration) *InviteService {
+	return &InviteService{inviteRepo: inviteRepo, transactor: transactor, jwt: jwt, ttl: ttl}
+}
+
+// Create создает приглашение и возвращает его вместе с подписанным токеном
+func (s *InviteService) Create(data InviteData) (*domain.Invite, string, error) {
+	if data.ListId != nil && !domain.IsMemberRole(data.Role) || data.ListId == nil && !domain.IsWorkspaceMemberRole(data.Role) {
+		return nil, "", errors.New(ErrInvalidInviteRole)
+	}
+
+	ttl := data.TTL
+
+	if ttl == 0 {
+		ttl = s.ttl
+	}
+
+	invite := &domain.Invite{
+		UserId:      data.UserId,
+		WorkspaceId: data.WorkspaceId,
+		ListId:      data.ListId,
+		Role:        data.Role,
+		SingleUse:   data.SingleUse,
+		ExpiresAt:   time.Now().Add(ttl),
+	}
+
+	if err := s.inviteRepo.Create(invite); err != nil {
+		return nil, "", err
+	}
+
+	token, err := s.jwt.CreateInvite(invite.ID.String(), invite.ExpiresAt)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return invite, token, nil
+}
+
+// Preview возвращает приглашение по токену, если им еще можно воспользоваться
+func (s *InviteService) Preview(token string) (*domain.Invite, error) {
+	return s.find(s.inviteRepo, token)
+}
+
+// Accept принимает приглашение от имени пользователя userId. Приглашенный в список пользователь
+// становится и участником пространства списка, если еще не был им.
+func (s *InviteService) Accept(token string, userId uuid.UUID) (*domain.Invite, error) {
+	var invite *domain.Invite
+
+	err := s.transactor.Transaction(func(repos *repository.Repositories) error {
+		var err error
+
+		if invite, err = s.find(repos.Invite, token); err != nil {
+			return err
+		}
+
+		_, err = repos.WorkspaceMember.Find(invite.WorkspaceId, userId)
+		isWorkspaceMember := err == nil
+
+		if invite.IsList() {
+			list, err := repos.List.FindById(*invite.ListId)
+
+			if err != nil {
+				return errors.New(ErrInviteNotFound)
+			}
+
+			if listRole(repos.ListMember, list, userId) != "" {
+				return errors.New(ErrInviteAlreadyJoined)
+			}
+		} else if isWorkspaceMember {
+			return errors.New(ErrInviteAlreadyJoined)
+		}
+
+		used, err := repos.Invite.MarkUsed(invite.ID, time.Now())
+
+		if err != nil {
+			return err
+		}
+
+		if !used {
+			return errors.New(ErrInviteUsed)
+		}
+
+		if !isWorkspaceMember {
+			workspaceRole := invite.Role
+
+			if invite.IsList() {
+				workspaceRole = domain.WorkspaceRoleMember
+			}
+
+			member := &domain.WorkspaceMember{WorkspaceId: invite.WorkspaceId, UserId: userId, Role: workspaceRole}
+
+			if err = repos.WorkspaceMember.Create(member); err != nil {
+				return err
+			}
+		}
+
+		if !invite.IsList() {
+			return nil
+		}
+
+		return repos.ListMember.Create(&domain.ListMember{ListId: *invite.ListId, UserId: userId, Role: invite.Role})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return invite, nil
+}
+
+// find возвращает приглашение по токену, проверяя подпись, срок действия и использование
+func (s *InviteService) find(inviteRepo repository.Invite, token string) (*domain.Invite, error) {
+	id, ok := s.jwt.ParseInvite(token)
+
+	if !ok {
+		return nil, errors.New(ErrInviteNotFound)
+	}
+
+	inviteId, err := uuid.Parse(id)
+
+	if err != nil {
+		return nil, errors.New(ErrInviteNotFound)
+	}
+
+	invite, err := inviteRepo.FindById(inviteId)
+
+	if err != nil || invite.ExpiresAt.Before(time.Now()) {
+		return nil, errors.New(ErrInviteNotFound)
+	}
+
+	if invite.IsUsed() {
+		return nil, errors.New(ErrInviteUsed)
+	}
+
+	return invite, nil
+}
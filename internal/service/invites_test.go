@@ -0,0 +1,214 @@
+package service_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/jwt"
+	"testing"
+	"time"
+)
+
+type inviteRepos struct {
+	invite          *mock_repository.MockInvite
+	list            *mock_repository.MockList
+	listMember      *mock_repository.MockListMember
+	workspaceMember *mock_repository.MockWorkspaceMember
+}
+
+var inviteJWT = jwt.NewJWT("secret")
+
+func TestInviteServiceCreate_InvalidRole(t *testing.T) {
+	inviteService, _ := mockInviteService(t)
+
+	_, listId := twoUuids(t)
+
+	_, _, err := inviteService.Create(service.InviteData{Role: domain.ListRoleEditor})
+	require.EqualError(t, err, service.ErrInvalidInviteRole)
+
+	_, _, err = inviteService.Create(service.InviteData{ListId: &listId, Role: domain.WorkspaceRoleMember})
+	require.EqualError(t, err, service.ErrInvalidInviteRole)
+}
+
+func TestInviteServiceCreate_DefaultTTL(t *testing.T) {
+	inviteService, repos := mockInviteService(t)
+
+	userId, workspaceId := twoUuids(t)
+	inviteId, _ := twoUuids(t)
+
+	repos.invite.EXPECT().Create(gomock.Any()).DoAndReturn(func(invite *domain.Invite) error {
+		invite.ID = inviteId
+		return nil
+	})
+
+	invite, token, err := inviteService.Create(service.InviteData{UserId: userId, WorkspaceId: workspaceId, Role: domain.WorkspaceRoleMember})
+
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(72*time.Hour), invite.ExpiresAt, time.Minute)
+
+	id, ok := inviteJWT.ParseInvite(token)
+
+	require.True(t, ok)
+	require.Equal(t, inviteId.String(), id)
+}
+
+func TestInviteServicePreview_InvalidToken(t *testing.T) {
+	inviteService, _ := mockInviteService(t)
+
+	_, err := inviteService.Preview("token")
+
+	require.EqualError(t, err, service.ErrInviteNotFound)
+}
+
+func TestInviteServicePreview_Used(t *testing.T) {
+	inviteService, repos := mockInviteService(t)
+
+	inviteId, _ := twoUuids(t)
+	usedAt := time.Now()
+
+	repos.invite.EXPECT().FindById(inviteId).Return(&domain.Invite{ID: inviteId, SingleUse: true, UsedAt: &usedAt, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+
+	_, err := inviteService.Preview(inviteToken(t, inviteId.String()))
+
+	require.EqualError(t, err, service.ErrInviteUsed)
+}
+
+func TestInviteServicePreview_Success(t *testing.T) {
+	inviteService, repos := mockInviteService(t)
+
+	inviteId, _ := twoUuids(t)
+
+	repos.invite.EXPECT().FindById(inviteId).Return(&domain.Invite{ID: inviteId, TargetName: "Team", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+
+	invite, err := inviteService.Preview(inviteToken(t, inviteId.String()))
+
+	require.NoError(t, err)
+	require.Equal(t, "Team", invite.TargetName)
+}
+
+func TestInviteServiceAccept_AlreadyWorkspaceMember(t *testing.T) {
+	inviteService, repos := mockInviteService(t)
+
+	inviteId, workspaceId := twoUuids(t)
+	userId, _ := twoUuids(t)
+
+	repos.invite.EXPECT().FindById(inviteId).Return(&domain.Invite{ID: inviteId, WorkspaceId: workspaceId, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	repos.workspaceMember.EXPECT().Find(workspaceId, userId).Return(&domain.WorkspaceMember{}, nil)
+
+	_, err := inviteService.Accept(inviteToken(t, inviteId.String()), userId)
+
+	require.EqualError(t, err, service.ErrInviteAlreadyJoined)
+}
+
+func TestInviteServiceAccept_UsedConcurrently(t *testing.T) {
+	inviteService, repos := mockInviteService(t)
+
+	inviteId, workspaceId := twoUuids(t)
+	userId, _ := twoUuids(t)
+
+	repos.invite.EXPECT().FindById(inviteId).Return(&domain.Invite{ID: inviteId, WorkspaceId: workspaceId, SingleUse: true, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	repos.workspaceMember.EXPECT().Find(workspaceId, userId).Return(nil, errors.New("not found"))
+	repos.invite.EXPECT().MarkUsed(inviteId, gomock.Any()).Return(false, nil)
+
+	_, err := inviteService.Accept(inviteToken(t, inviteId.String()), userId)
+
+	require.EqualError(t, err, service.ErrInviteUsed)
+}
+
+func TestInviteServiceAccept_Workspace(t *testing.T) {
+	inviteService, repos := mockInviteService(t)
+
+	inviteId, workspaceId := twoUuids(t)
+	userId, _ := twoUuids(t)
+
+	repos.invite.EXPECT().FindById(inviteId).
+		Return(&domain.Invite{ID: inviteId, WorkspaceId: workspaceId, Role: domain.WorkspaceRoleAdmin, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	repos.workspaceMember.EXPECT().Find(workspaceId, userId).Return(nil, errors.New("not found"))
+	repos.invite.EXPECT().MarkUsed(inviteId, gomock.Any()).Return(true, nil)
+	repos.workspaceMember.EXPECT().Create(&domain.WorkspaceMember{WorkspaceId: workspaceId, UserId: userId, Role: domain.WorkspaceRoleAdmin}).Return(nil)
+
+	invite, err := inviteService.Accept(inviteToken(t, inviteId.String()), userId)
+
+	require.NoError(t, err)
+	require.Equal(t, workspaceId, invite.WorkspaceId)
+}
+
+func TestInviteServiceAccept_ListJoinsWorkspace(t *testing.T) {
+	inviteService, repos := mockInviteService(t)
+
+	inviteId, workspaceId := twoUuids(t)
+	userId, listId := twoUuids(t)
+
+	repos.invite.EXPECT().FindById(inviteId).
+		Return(&domain.Invite{ID: inviteId, WorkspaceId: workspaceId, ListId: &listId, Role: domain.ListRoleEditor, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	repos.workspaceMember.EXPECT().Find(workspaceId, userId).Return(nil, errors.New("not found"))
+	repos.list.EXPECT().FindById(listId).Return(&domain.List{ID: listId, WorkspaceId: workspaceId}, nil)
+	repos.listMember.EXPECT().Find(listId, userId).Return(nil, errors.New("not found"))
+	repos.invite.EXPECT().MarkUsed(inviteId, gomock.Any()).Return(true, nil)
+	repos.workspaceMember.EXPECT().Create(&domain.WorkspaceMember{WorkspaceId: workspaceId, UserId: userId, Role: domain.WorkspaceRoleMember}).Return(nil)
+	repos.listMember.EXPECT().Create(&domain.ListMember{ListId: listId, UserId: userId, Role: domain.ListRoleEditor}).Return(nil)
+
+	invite, err := inviteService.Accept(inviteToken(t, inviteId.String()), userId)
+
+	require.NoError(t, err)
+	require.Equal(t, listId, *invite.ListId)
+}
+
+func TestInviteServiceAccept_ListOwner(t *testing.T) {
+	inviteService, repos := mockInviteService(t)
+
+	inviteId, workspaceId := twoUuids(t)
+	userId, listId := twoUuids(t)
+
+	repos.invite.EXPECT().FindById(inviteId).
+		Return(&domain.Invite{ID: inviteId, WorkspaceId: workspaceId, ListId: &listId, Role: domain.ListRoleEditor, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	repos.workspaceMember.EXPECT().Find(workspaceId, userId).Return(&domain.WorkspaceMember{}, nil)
+	repos.list.EXPECT().FindById(listId).Return(&domain.List{ID: listId, UserId: userId}, nil)
+
+	_, err := inviteService.Accept(inviteToken(t, inviteId.String()), userId)
+
+	require.EqualError(t, err, service.ErrInviteAlreadyJoined)
+}
+
+func inviteToken(t *testing.T, id string) string {
+	t.Helper()
+
+	token, err := inviteJWT.CreateInvite(id, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	return token
+}
+
+func mockInviteService(t *testing.T) (*service.InviteService, inviteRepos) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mocks := inviteRepos{
+		invite:          mock_repository.NewMockInvite(mockCtl),
+		list:            mock_repository.NewMockList(mockCtl),
+		listMember:      mock_repository.NewMockListMember(mockCtl),
+		workspaceMember: mock_repository.NewMockWorkspaceMember(mockCtl),
+	}
+
+	transactor := mock_repository.NewMockTransactor(mockCtl)
+
+	repos := &repository.Repositories{
+		Transactor:      transactor,
+		Invite:          mocks.invite,
+		List:            mocks.list,
+		ListMember:      mocks.listMember,
+		WorkspaceMember: mocks.workspaceMember,
+	}
+
+	transactor.EXPECT().Transaction(gomock.Any()).DoAndReturn(func(fn func(repos *repository.Repositories) error) error {
+		return fn(repos)
+	}).AnyTimes()
+
+	return service.NewInviteService(mocks.invite, transactor, inviteJWT, 72*time.Hour), mocks
+}
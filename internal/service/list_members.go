@@ -0,0 +1,170 @@
+package service
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+)
+
+const (
+	ErrInvalidListRole          = "invalid role"
+	ErrListMemberUserNotFound   = "user not found"
+	ErrListMemberExists         = "user already has access to the list"
+	ErrListMemberNotFound       = "list member not found"
+	ErrListOwnerIsNotMember     = "list owner can't be removed from the list or change role"
+	ErrListMemberNotInWorkspace = "user is not a member of the list workspace"
+)
+
+type ListMemberService struct {
+	listRepo            repository.List
+	listMemberRepo      repository.ListMember
+	userRepo            repository.User
+	workspaceMemberRepo repository.WorkspaceMember
+	transactor          repository.Transactor
+}
+
+func NewListMemberService(
+	listRepo repository.List,
+	listMemberRepo repository.ListMember,
+	userRepo repository.User,
+	workspaceMemberRepo repository.WorkspaceMember,
+	transactor repository.Transactor,
+) *ListMemberService {
+	return &ListMemberService{
+		listRepo:            listRepo,
+		listMemberRepo:      listMemberRepo,
+		userRepo:            userRepo,
+		workspaceMemberRepo: workspaceMemberRepo,
+		transactor:          transactor,
+	}
+}
+
+// GetRole возвращает роль пользователя userId в списке list или пустую строку, если список ему недоступен
+func (s *ListMemberService) GetRole(list *domain.List, userId uuid.UUID) string {
+	return listRole(s.listMemberRepo, list, userId)
+}
+
+// GetTaskRole возвращает роль пользователя userId для задачи task или пустую строку, если задача ему недоступна
+func (s *ListMemberService) GetTaskRole(task *domain.Task, userId uuid.UUID) string {
+	return taskRole(s.listRepo, s.listMemberRepo, task, userId)
+}
+
+func (s *ListMemberService) GetAll(listId uuid.UUID) *[]domain.ListMember {
+	return s.listMemberRepo.GetByListId(listId)
+}
+
+// Invite добавляет в список list пользователя с адресом email и ролью role.
+// Пользователь должен быть участником рабочего пространства, которому принадлежит список.
+func (s *ListMemberService) Invite(list *domain.List, email, role string) (*domain.ListMember, error) {
+	if !domain.IsMemberRole(role) {
+		return nil, errors.New(ErrInvalidListRole)
+	}
+
+	user, err := s.userRepo.FindByEmail(email)
+
+	if err != nil || user == nil {
+		return nil, errors.New(ErrListMemberUserNotFound)
+	}
+
+	if listRole(s.listMemberRepo, list, user.ID) != "" {
+		return nil, errors.New(ErrListMemberExists)
+	}
+
+	if _, err = s.workspaceMemberRepo.Find(list.WorkspaceId, user.ID); err != nil {
+		return nil, errors.New(ErrListMemberNotInWorkspace)
+	}
+
+	member := &domain.ListMember{ListId: list.ID, UserId: user.ID, Role: role, Name: user.Name, Email: user.Email}
+
+	if err = s.listMemberRepo.Create(member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (s *ListMemberService) UpdateRole(list *domain.List, userId uuid.UUID, role string) error {
+	if !domain.IsMemberRole(role) {
+		return errors.New(ErrInvalidListRole)
+	}
+
+	if _, err := s.findMember(list, userId); err != nil {
+		return err
+	}
+
+	return s.listMemberRepo.UpdateRole(list.ID, userId, role)
+}
+
+// Remove исключает пользователя userId из списка list по действию пользователя actorId. Владельца исключить нельзя.
+// С задач списка, назначенных исключенному пользователю, назначение снимается с записью в историю от имени actorId.
+func (s *ListMemberService) Remove(actorId uuid.UUID, list *domain.List, userId uuid.UUID) error {
+	if _, err := s.findMember(list, userId); err != nil {
+		return err
+	}
+
+	return s.transactor.Transaction(func(repos *repository.Repositories) error {
+		if err := repos.ListMember.Delete(list.ID, userId); err != nil {
+			return err
+		}
+
+		taskService := NewTaskService(repos.Task, repos.TaskDependency, repos.Status, repos.List, repos.ListMember, repos.TaskHistory, repos.OutboxEvent, nil, false).
+			withActor(actorId)
+
+		for _, task := range *repos.Task.GetAssignedInList(list.ID, userId) {
+			if err := taskService.unassign(&task); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *ListMemberService) findMember(list *domain.List, userId uuid.UUID) (*domain.ListMember, error) {
+	if list.UserId == userId {
+		return nil, errors.New(ErrListOwnerIsNotMember)
+	}
+
+	member, err := s.listMemberRepo.Find(list.ID, userId)
+
+	if err != nil {
+		return nil, errors.New(ErrListMemberNotFound)
+	}
+
+	return member, nil
+}
+
+func listRole(listMemberRepo repository.ListMember, list *domain.List, userId uuid.UUID) string {
+	if list.UserId == userId {
+		return domain.ListRoleOwner
+	}
+
+	member, err := listMemberRepo.Find(list.ID, userId)
+
+	if err != nil {
+		return ""
+	}
+
+	return member.Role
+}
+
+// taskRole определяет роль пользователя для задачи: задачи вне списков доступны только их автору,
+// права на задачи списка определяются ролью пользователя в списке
+func taskRole(listRepo repository.List, listMemberRepo repository.ListMember, task *domain.Task, userId uuid.UUID) string {
+	if task.ListId == nil {
+		if task.UserId == userId {
+			return domain.ListRoleOwner
+		}
+
+		return ""
+	}
+
+	list, err := listRepo.FindById(*task.ListId)
+
+	if err != nil {
+		return ""
+	}
+
+	return listRole(listMemberRepo, list, userId)
+}
@@ -0,0 +1,238 @@
+package service_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	"testing"
+)
+
+type listMemberMocks struct {
+	listRepo            *mock_repository.MockList
+	listMemberRepo      *mock_repository.MockListMember
+	userRepo            *mock_repository.MockUser
+	workspaceMemberRepo *mock_repository.MockWorkspaceMember
+	taskRepo            *mock_repository.MockTask
+	taskHistoryRepo     *mock_repository.MockTaskHistory
+}
+
+func TestListMemberServiceGetRole_Owner(t *testing.T) {
+	listMemberService, _ := mockListMemberService(t)
+
+	userId, listId := twoUuids(t)
+
+	require.Equal(t, domain.ListRoleOwner, listMemberService.GetRole(&domain.List{ID: listId, UserId: userId}, userId))
+}
+
+func TestListMemberServiceGetRole_NotMember(t *testing.T) {
+	listMemberService, mocks := mockListMemberService(t)
+
+	userId, listId := twoUuids(t)
+
+	mocks.listMemberRepo.EXPECT().Find(listId, userId).Return(nil, errors.New("not found"))
+
+	require.Empty(t, listMemberService.GetRole(&domain.List{ID: listId}, userId))
+}
+
+func TestListMemberServiceGetTaskRole_TaskWithoutList(t *testing.T) {
+	listMemberService, _ := mockListMemberService(t)
+
+	userId, otherUserId := twoUuids(t)
+
+	require.Equal(t, domain.ListRoleOwner, listMemberService.GetTaskRole(&domain.Task{UserId: userId}, userId))
+	require.Empty(t, listMemberService.GetTaskRole(&domain.Task{UserId: userId}, otherUserId))
+}
+
+func TestListMemberServiceGetTaskRole_SharedList(t *testing.T) {
+	listMemberService, mocks := mockListMemberService(t)
+
+	userId, listId := twoUuids(t)
+
+	mocks.listRepo.EXPECT().FindById(listId).Return(&domain.List{ID: listId}, nil)
+	mocks.listMemberRepo.EXPECT().Find(listId, userId).Return(&domain.ListMember{Role: domain.ListRoleEditor}, nil)
+
+	require.Equal(t, domain.ListRoleEditor, listMemberService.GetTaskRole(&domain.Task{ListId: &listId}, userId))
+}
+
+func TestListMemberServiceInvite_InvalidRole(t *testing.T) {
+	listMemberService, _ := mockListMemberService(t)
+
+	_, err := listMemberService.Invite(&domain.List{}, "john@example.com", domain.ListRoleOwner)
+
+	require.EqualError(t, err, service.ErrInvalidListRole)
+}
+
+func TestListMemberServiceInvite_UserNotFound(t *testing.T) {
+	listMemberService, mocks := mockListMemberService(t)
+
+	mocks.userRepo.EXPECT().FindByEmail("john@example.com").Return(nil, errors.New("not found"))
+
+	_, err := listMemberService.Invite(&domain.List{}, "john@example.com", domain.ListRoleViewer)
+
+	require.EqualError(t, err, service.ErrListMemberUserNotFound)
+}
+
+func TestListMemberServiceInvite_Owner(t *testing.T) {
+	listMemberService, mocks := mockListMemberService(t)
+
+	userId, listId := twoUuids(t)
+
+	mocks.userRepo.EXPECT().FindByEmail("john@example.com").Return(&domain.User{ID: userId}, nil)
+
+	_, err := listMemberService.Invite(&domain.List{ID: listId, UserId: userId}, "john@example.com", domain.ListRoleViewer)
+
+	require.EqualError(t, err, service.ErrListMemberExists)
+}
+
+func TestListMemberServiceInvite_NotWorkspaceMember(t *testing.T) {
+	listMemberService, mocks := mockListMemberService(t)
+
+	userId, listId := twoUuids(t)
+	workspaceId, _ := twoUuids(t)
+
+	mocks.userRepo.EXPECT().FindByEmail("john@example.com").Return(&domain.User{ID: userId}, nil)
+	mocks.listMemberRepo.EXPECT().Find(listId, userId).Return(nil, errors.New("not found"))
+	mocks.workspaceMemberRepo.EXPECT().Find(workspaceId, userId).Return(nil, errors.New("not found"))
+
+	_, err := listMemberService.Invite(&domain.List{ID: listId, WorkspaceId: workspaceId}, "john@example.com", domain.ListRoleEditor)
+
+	require.EqualError(t, err, service.ErrListMemberNotInWorkspace)
+}
+
+func TestListMemberServiceInvite_Success(t *testing.T) {
+	listMemberService, mocks := mockListMemberService(t)
+
+	userId, listId := twoUuids(t)
+	workspaceId, _ := twoUuids(t)
+
+	mocks.userRepo.EXPECT().FindByEmail("john@example.com").Return(&domain.User{ID: userId, Name: "John", Email: "john@example.com"}, nil)
+	mocks.listMemberRepo.EXPECT().Find(listId, userId).Return(nil, errors.New("not found"))
+	mocks.workspaceMemberRepo.EXPECT().Find(workspaceId, userId).Return(&domain.WorkspaceMember{Role: domain.WorkspaceRoleMember}, nil)
+	mocks.listMemberRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	member, err := listMemberService.Invite(&domain.List{ID: listId, WorkspaceId: workspaceId}, "john@example.com", domain.ListRoleEditor)
+
+	require.NoError(t, err)
+	require.Equal(t, userId, member.UserId)
+	require.Equal(t, domain.ListRoleEditor, member.Role)
+	require.Equal(t, "John", member.Name)
+}
+
+func TestListMemberServiceUpdateRole_Owner(t *testing.T) {
+	listMemberService, _ := mockListMemberService(t)
+
+	userId, listId := twoUuids(t)
+
+	err := listMemberService.UpdateRole(&domain.List{ID: listId, UserId: userId}, userId, domain.ListRoleViewer)
+
+	require.EqualError(t, err, service.ErrListOwnerIsNotMember)
+}
+
+func TestListMemberServiceUpdateRole_NotMember(t *testing.T) {
+	listMemberService, mocks := mockListMemberService(t)
+
+	userId, listId := twoUuids(t)
+
+	mocks.listMemberRepo.EXPECT().Find(listId, userId).Return(nil, errors.New("not found"))
+
+	err := listMemberService.UpdateRole(&domain.List{ID: listId}, userId, domain.ListRoleViewer)
+
+	require.EqualError(t, err, service.ErrListMemberNotFound)
+}
+
+func TestListMemberServiceUpdateRole_Success(t *testing.T) {
+	listMemberService, mocks := mockListMemberService(t)
+
+	userId, listId := twoUuids(t)
+
+	mocks.listMemberRepo.EXPECT().Find(listId, userId).Return(&domain.ListMember{Role: domain.ListRoleViewer}, nil)
+	mocks.listMemberRepo.EXPECT().UpdateRole(listId, userId, domain.ListRoleAdmin).Return(nil)
+
+	require.NoError(t, listMemberService.UpdateRole(&domain.List{ID: listId}, userId, domain.ListRoleAdmin))
+}
+
+func TestListMemberServiceRemove_Success(t *testing.T) {
+	listMemberService, mocks := mockListMemberService(t)
+
+	userId, listId := twoUuids(t)
+
+	mocks.listMemberRepo.EXPECT().Find(listId, userId).Return(&domain.ListMember{Role: domain.ListRoleEditor}, nil)
+	mocks.listMemberRepo.EXPECT().Delete(listId, userId).Return(nil)
+	mocks.taskRepo.EXPECT().GetAssignedInList(listId, userId).Return(&[]domain.Task{})
+
+	require.NoError(t, listMemberService.Remove(userId, &domain.List{ID: listId}, userId))
+}
+
+func TestListMemberServiceRemove_UnassignsTasks(t *testing.T) {
+	listMemberService, mocks := mockListMemberService(t)
+
+	userId, listId := twoUuids(t)
+	actorId, taskId := twoUuids(t)
+
+	mocks.listMemberRepo.EXPECT().Find(listId, userId).Return(&domain.ListMember{Role: domain.ListRoleEditor}, nil)
+	mocks.listMemberRepo.EXPECT().Delete(listId, userId).Return(nil)
+	mocks.taskRepo.EXPECT().GetAssignedInList(listId, userId).Return(&[]domain.Task{
+		{ID: taskId, ListId: &listId, AssigneeId: &userId, Version: 3},
+	})
+	mocks.taskRepo.EXPECT().Unassign(taskId).Return(nil)
+	mocks.taskHistoryRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(entries []domain.TaskHistory) error {
+		require.Len(t, entries, 1)
+		require.Equal(t, domain.TaskFieldAssigneeId, entries[0].Field)
+		require.Equal(t, userId.String(), *entries[0].OldValue)
+		require.Nil(t, entries[0].NewValue)
+		require.Equal(t, 4, entries[0].Revision)
+		require.Equal(t, actorId, *entries[0].ActorId)
+		return nil
+	})
+
+	require.NoError(t, listMemberService.Remove(actorId, &domain.List{ID: listId}, userId))
+}
+
+func TestListMemberServiceRemove_Failed(t *testing.T) {
+	listMemberService, mocks := mockListMemberService(t)
+
+	userId, listId := twoUuids(t)
+	_, taskId := twoUuids(t)
+
+	mocks.listMemberRepo.EXPECT().Find(listId, userId).Return(&domain.ListMember{Role: domain.ListRoleEditor}, nil)
+	mocks.listMemberRepo.EXPECT().Delete(listId, userId).Return(nil)
+	mocks.taskRepo.EXPECT().GetAssignedInList(listId, userId).Return(&[]domain.Task{{ID: taskId, AssigneeId: &userId}})
+	mocks.taskRepo.EXPECT().Unassign(taskId).Return(errors.New("failed"))
+
+	require.Error(t, listMemberService.Remove(userId, &domain.List{ID: listId}, userId))
+}
+
+func mockListMemberService(t *testing.T) (*service.ListMemberService, listMemberMocks) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mocks := listMemberMocks{
+		listRepo:            mock_repository.NewMockList(mockCtl),
+		listMemberRepo:      mock_repository.NewMockListMember(mockCtl),
+		userRepo:            mock_repository.NewMockUser(mockCtl),
+		workspaceMemberRepo: mock_repository.NewMockWorkspaceMember(mockCtl),
+		taskRepo:            mock_repository.NewMockTask(mockCtl),
+		taskHistoryRepo:     mock_repository.NewMockTaskHistory(mockCtl),
+	}
+
+	repos := &repository.Repositories{
+		List:        mocks.listRepo,
+		ListMember:  mocks.listMemberRepo,
+		Task:        mocks.taskRepo,
+		TaskHistory: mocks.taskHistoryRepo,
+		OutboxEvent: mockOutboxEventRepo(mockCtl),
+	}
+
+	transactor := mock_repository.NewMockTransactor(mockCtl)
+	transactor.EXPECT().Transaction(gomock.Any()).DoAndReturn(func(fn func(repos *repository.Repositories) error) error {
+		return fn(repos)
+	}).AnyTimes()
+
+	return service.NewListMemberService(mocks.listRepo, mocks.listMemberRepo, mocks.userRepo, mocks.workspaceMemberRepo, transactor), mocks
+}
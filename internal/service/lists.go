@@ -0,0 +1,86 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+)
+
+const ErrListNotFound = "list not found"
+
+type BoardColumn struct {
+	Status domain.Status
+	Tasks  []domain.Task
+}
+
+type ListService struct {
+	listRepo   repository.List
+	taskRepo   repository.Task
+	statusRepo repository.Status
+}
+
+func NewListService(listRepo repository.List, taskRepo repository.Task, statusRepo repository.Status) *ListService {
+	return &ListService{listRepo: listRepo, taskRepo: taskRepo, statusRepo: statusRepo}
+}
+
+func (s *ListService) Create(name string, userId uuid.UUID) (*domain.List, error) {
+	createdList, err := s.listRepo.Create(&domain.List{Name: name, UserId: userId})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return createdList, nil
+}
+
+func (s *ListService) Delete(id uuid.UUID) error {
+	return s.listRepo.Delete(id)
+}
+
+func (s *ListService) FindById(id uuid.UUID) (*domain.List, error) {
+	return s.listRepo.FindById(id)
+}
+
+func (s *ListService) GetAllByUserId(id uuid.UUID) *[]domain.List {
+	return s.listRepo.GetAllByUserId(id)
+}
+
+// GetBoard группирует задачи списка по статусам в порядке их следования.
+// Задачи со статусом вне рабочего процесса списка попадают в начальную колонку
+// или, если они завершены, в завершающую.
+func (s *ListService) GetBoard(list *domain.List) (*[]BoardColumn, error) {
+	statuses, err := resolveStatuses(s.statusRepo, list.UserId, &list.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]BoardColumn, 0, len(*statuses))
+	columnIndexes := make(map[uuid.UUID]int, len(*statuses))
+
+	for i, status := range *statuses {
+		columns = append(columns, BoardColumn{Status: status, Tasks: make([]domain.Task, 0)})
+		columnIndexes[status.ID] = i
+	}
+
+	initialIndex := columnIndexes[initialStatus(*statuses).ID]
+	doneIndex := columnIndexes[doneStatus(*statuses).ID]
+
+	for _, task := range *s.taskRepo.GetAllByListId(list.ID) {
+		index := initialIndex
+
+		if task.IsCompleted != nil && *task.IsCompleted {
+			index = doneIndex
+		}
+
+		if task.StatusId != nil {
+			if statusIndex, ok := columnIndexes[*task.StatusId]; ok {
+				index = statusIndex
+			}
+		}
+
+		columns[index].Tasks = append(columns[index].Tasks, task)
+	}
+
+	return &columns, nil
+}
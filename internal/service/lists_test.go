@@ -0,0 +1,104 @@
+package service_test
+
+import (
+	"errors"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"poymanov/todo/internal/domain"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	"testing"
+)
+
+func TestListServiceCreate_Failed(t *testing.T) {
+	listService, listRepo, _, _ := mockListService(t)
+
+	userId, _ := twoUuids(t)
+
+	listRepo.EXPECT().Create(gomock.Any()).Return(nil, errors.New("failed"))
+
+	list, err := listService.Create(faker.Word(), userId)
+
+	require.Error(t, err)
+	require.Nil(t, list)
+}
+
+func TestListServiceCreate_Success(t *testing.T) {
+	listService, listRepo, _, _ := mockListService(t)
+
+	userId, _ := twoUuids(t)
+	name := faker.Word()
+
+	listRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(list *domain.List) (*domain.List, error) {
+		return list, nil
+	})
+
+	list, err := listService.Create(name, userId)
+
+	require.NoError(t, err)
+	require.Equal(t, name, list.Name)
+	require.Equal(t, userId, list.UserId)
+}
+
+func TestListServiceDelete_Success(t *testing.T) {
+	listService, listRepo, _, _ := mockListService(t)
+
+	listId, _ := twoUuids(t)
+
+	listRepo.EXPECT().Delete(listId).Return(nil)
+
+	require.NoError(t, listService.Delete(listId))
+}
+
+func TestListServiceGetAllByUserId_Success(t *testing.T) {
+	listService, listRepo, _, _ := mockListService(t)
+
+	userId, _ := twoUuids(t)
+
+	listRepo.EXPECT().GetAllByUserId(userId).Return(&[]domain.List{{}})
+
+	require.NotEmpty(t, listService.GetAllByUserId(userId))
+}
+
+func TestListServiceGetBoard_Success(t *testing.T) {
+	listService, _, taskRepo, statusRepo := mockListService(t)
+
+	userId, listId := twoUuids(t)
+	openStatus, reviewStatus := twoUuids(t)
+	doneStatus, foreignStatus := twoUuids(t)
+	isCompleted, isNotCompleted := true, false
+
+	statusRepo.EXPECT().GetByScope(userId, &listId).Return(&[]domain.Status{
+		{ID: openStatus, Position: 0},
+		{ID: reviewStatus, Position: 1},
+		{ID: doneStatus, Position: 2, IsDone: true},
+	})
+	taskRepo.EXPECT().GetAllByListId(listId).Return(&[]domain.Task{
+		{Description: "review", StatusId: &reviewStatus, IsCompleted: &isNotCompleted},
+		{Description: "foreign completed", StatusId: &foreignStatus, IsCompleted: &isCompleted},
+		{Description: "without status", IsCompleted: &isNotCompleted},
+	})
+
+	columns, err := listService.GetBoard(&domain.List{ID: listId, UserId: userId})
+
+	require.NoError(t, err)
+	require.Len(t, *columns, 3)
+	require.Equal(t, "without status", (*columns)[0].Tasks[0].Description)
+	require.Equal(t, "review", (*columns)[1].Tasks[0].Description)
+	require.Equal(t, "foreign completed", (*columns)[2].Tasks[0].Description)
+}
+
+func mockListService(t *testing.T) (*service.ListService, *mock_repository.MockList, *mock_repository.MockTask, *mock_repository.MockStatus) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	listRepo := mock_repository.NewMockList(mockCtl)
+	taskRepo := mock_repository.NewMockTask(mockCtl)
+	statusRepo := mock_repository.NewMockStatus(mockCtl)
+	listService := service.NewListService(listRepo, taskRepo, statusRepo)
+
+	return listService, listRepo, taskRepo, statusRepo
+}
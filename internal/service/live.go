@@ -0,0 +1,352 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"sync"
+	"sync/atomic"
+)
+
+// Типы сообщений, которые получают клиенты совместного редактирования
+const (
+	LiveMessageSubscribed   = "subscribed"
+	LiveMessageUnsubscribed = "unsubscribed"
+	LiveMessageTask         = "task"
+	LiveMessagePresence     = "presence"
+	LiveMessagePing         = "ping"
+	LiveMessageError        = "error"
+)
+
+// liveHubBuffer - сколько событий шины может ожидать рассылки по соединениям
+const liveHubBuffer = 1024
+
+// LiveMessage - сообщение клиенту. Event заполняется для изменений задач, Users - для изменений зрителей списка.
+type LiveMessage struct {
+	Type    string                 `json:"type"`
+	ListId  *uuid.UUID             `json:"list_id,omitempty"`
+	Event   *domain.TaskEvent      `json:"event,omitempty"`
+	Users   *[]domain.PresenceUser `json:"users,omitempty"`
+	Message string                 `json:"message,omitempty"`
+}
+
+// LiveSession - соединение пользователя, подписанное на изменения списков. Сообщения для отправки клиенту
+// приходят в канал C. Канал закрывается при отключении или если клиент не успевает принимать сообщения.
+type LiveSession struct {
+	ID     uuid.UUID
+	UserId uuid.UUID
+	C      <-chan LiveMessage
+
+	messages   chan LiveMessage
+	lists      map[uuid.UUID]struct{}
+	closed     bool
+	overflowed atomic.Bool
+}
+
+// Overflowed сообщает, что соединение закрыто из-за того, что клиент не успевал принимать сообщения
+func (s *LiveSession) Overflowed() bool {
+	return s.overflowed.Load()
+}
+
+// LiveHub рассылает изменения задач и зрителей списков соединениям, подписанным на эти списки.
+// Отправка не блокируется: соединение, очередь которого переполнена, закрывается.
+type LiveHub struct {
+	listRepo       repository.List
+	listMemberRepo repository.ListMember
+	presence       Presence
+	bus            *EventBus
+	buffer         int
+
+	mu       sync.Mutex
+	sessions map[*LiveSession]struct{}
+}
+
+func NewLiveHub(listRepo repository.List, listMemberRepo repository.ListMember, presence Presence, bus *EventBus, buffer int) *LiveHub {
+	return &LiveHub{
+		listRepo:       listRepo,
+		listMemberRepo: listMemberRepo,
+		presence:       presence,
+		bus:            bus,
+		buffer:         buffer,
+		sessions:       make(map[*LiveSession]struct{}),
+	}
+}
+
+func (h *LiveHub) Connect(userId uuid.UUID) *LiveSession {
+	messages := make(chan LiveMessage, h.buffer)
+	session := &LiveSession{ID: uuid.New(), UserId: userId, C: messages, messages: messages, lists: make(map[uuid.UUID]struct{})}
+
+	h.mu.Lock()
+	h.sessions[session] = struct{}{}
+	h.mu.Unlock()
+
+	return session
+}
+
+// Disconnect закрывает соединение и убирает пользователя из зрителей его списков
+func (h *LiveHub) Disconnect(session *LiveSession) error {
+	h.mu.Lock()
+	delete(h.sessions, session)
+	h.close(session)
+	subscribed := len(session.lists) > 0
+	h.mu.Unlock()
+
+	if !subscribed {
+		return nil
+	}
+
+	return h.presence.LeaveAll(session.ID)
+}
+
+// Subscribe подписывает соединение на изменения списка listId, если список доступен пользователю
+func (h *LiveHub) Subscribe(session *LiveSession, listId uuid.UUID) error {
+	if !h.canView(session.UserId, listId) {
+		return errors.New(ErrListNotFound)
+	}
+
+	h.mu.Lock()
+	session.lists[listId] = struct{}{}
+	h.deliver(session, LiveMessage{Type: LiveMessageSubscribed, ListId: &listId})
+	h.mu.Unlock()
+
+	return h.presence.Join(session.ID, listId, session.UserId)
+}
+
+func (h *LiveHub) Unsubscribe(session *LiveSession, listId uuid.UUID) error {
+	h.mu.Lock()
+	_, subscribed := session.lists[listId]
+	delete(session.lists, listId)
+	h.deliver(session, LiveMessage{Type: LiveMessageUnsubscribed, ListId: &listId})
+	h.mu.Unlock()
+
+	if !subscribed {
+		return nil
+	}
+
+	return h.presence.Leave(session.ID, listId)
+}
+
+// Refresh вызывается периодически, пока соединение открыто: отписывает от списков, доступ к которым
+// у пользователя пропал, и продлевает его присутствие в остальных. При рассылке событий доступ проверяется
+// для каждого события, поэтому здесь отписываются соединения списков, по которым событий не было.
+func (h *LiveHub) Refresh(session *LiveSession) error {
+	h.mu.Lock()
+	listIds := make([]uuid.UUID, 0, len(session.lists))
+
+	for listId := range session.lists {
+		listIds = append(listIds, listId)
+	}
+	h.mu.Unlock()
+
+	if len(listIds) == 0 {
+		return nil
+	}
+
+	for _, listId := range listIds {
+		if h.canView(session.UserId, listId) {
+			continue
+		}
+
+		if err := h.Unsubscribe(session, listId); err != nil {
+			return err
+		}
+	}
+
+	return h.presence.Touch(session.ID)
+}
+
+func (h *LiveHub) Send(session *LiveSession, message LiveMessage) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.deliver(session, message)
+}
+
+// PresenceChanged отправляет подписанным на список listId соединениям текущий состав его зрителей
+func (h *LiveHub) PresenceChanged(listId uuid.UUID) {
+	sessions := h.subscribers(listId)
+
+	if len(sessions) == 0 {
+		return
+	}
+
+	users := h.presence.GetUsers(listId)
+	viewers := userIdSet(h.listRepo.GetUserIds(listId))
+	var revoked []*LiveSession
+
+	h.mu.Lock()
+
+	for _, session := range sessions {
+		if _, ok := viewers[session.UserId]; !ok {
+			revoked = append(revoked, session)
+			continue
+		}
+
+		h.deliver(session, LiveMessage{Type: LiveMessagePresence, ListId: &listId, Users: users})
+	}
+
+	h.mu.Unlock()
+
+	for _, session := range revoked {
+		_ = h.Unsubscribe(session, listId)
+	}
+}
+
+// Run рассылает соединениям события задач из шины. Если шина закрыла подписку из-за переполнения, часть событий
+// потеряна: все соединения закрываются, чтобы клиенты переподключились и заново загрузили списки, после чего
+// hub подписывается заново.
+func (h *LiveHub) Run() {
+	for {
+		subscription := h.bus.Subscribe(liveHubBuffer)
+
+		for event := range subscription.C {
+			h.broadcast(event)
+		}
+
+		h.dropAll()
+	}
+}
+
+// dropAll закрывает все соединения как не успевающие принимать сообщения
+func (h *LiveHub) dropAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for session := range h.sessions {
+		if session.closed {
+			continue
+		}
+
+		session.overflowed.Store(true)
+		h.close(session)
+	}
+}
+
+// broadcast отправляет событие задачи соединениям, подписанным на ее список. Событие перемещения
+// задачи получают подписчики как нового, так и прежнего списка. Владелец и участники каждого списка
+// с подписчиками запрашиваются один раз на событие: соединения пользователей, исключенных из списка,
+// отписываются от него и события не получают.
+func (h *LiveHub) broadcast(event domain.OutboxEvent) {
+	if !domain.IsTaskEventType(event.Type) {
+		return
+	}
+
+	var taskEvent domain.TaskEvent
+
+	if err := json.Unmarshal([]byte(event.Payload), &taskEvent); err != nil {
+		return
+	}
+
+	listIds := make([]uuid.UUID, 0, 2)
+
+	if taskEvent.ListId != nil {
+		listIds = append(listIds, *taskEvent.ListId)
+	}
+
+	for _, change := range taskEvent.Changes {
+		if listId := parseUuidValue(change.OldValue); change.Field == domain.TaskFieldListId && listId != nil {
+			listIds = append(listIds, *listId)
+		}
+	}
+
+	viewers := make(map[uuid.UUID]map[uuid.UUID]struct{}, len(listIds))
+
+	for _, listId := range listIds {
+		if len(h.subscribers(listId)) > 0 {
+			viewers[listId] = userIdSet(h.listRepo.GetUserIds(listId))
+		}
+	}
+
+	var revoked []liveSubscription
+
+	h.mu.Lock()
+
+	for session := range h.sessions {
+		for _, listId := range listIds {
+			if _, ok := session.lists[listId]; !ok {
+				continue
+			}
+
+			// соединение, подписанное после запроса участников, уже проверено при подписке
+			if listViewers, resolved := viewers[listId]; resolved {
+				if _, ok := listViewers[session.UserId]; !ok {
+					revoked = append(revoked, liveSubscription{session: session, listId: listId})
+					continue
+				}
+			}
+
+			h.deliver(session, LiveMessage{Type: LiveMessageTask, ListId: &listId, Event: &taskEvent})
+			break
+		}
+	}
+
+	h.mu.Unlock()
+
+	for _, subscription := range revoked {
+		_ = h.Unsubscribe(subscription.session, subscription.listId)
+	}
+}
+
+// liveSubscription - подписка соединения на список
+type liveSubscription struct {
+	session *LiveSession
+	listId  uuid.UUID
+}
+
+// userIdSet возвращает множество идентификаторов пользователей
+func userIdSet(userIds []uuid.UUID) map[uuid.UUID]struct{} {
+	set := make(map[uuid.UUID]struct{}, len(userIds))
+
+	for _, userId := range userIds {
+		set[userId] = struct{}{}
+	}
+
+	return set
+}
+
+func (h *LiveHub) subscribers(listId uuid.UUID) []*LiveSession {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var sessions []*LiveSession
+
+	for session := range h.sessions {
+		if _, ok := session.lists[listId]; ok {
+			sessions = append(sessions, session)
+		}
+	}
+
+	return sessions
+}
+
+func (h *LiveHub) canView(userId, listId uuid.UUID) bool {
+	list, err := h.listRepo.FindById(listId)
+
+	return err == nil && listRole(h.listMemberRepo, list, userId) != ""
+}
+
+// deliver ставит сообщение в очередь соединения. Вызывается под h.mu.
+func (h *LiveHub) deliver(session *LiveSession, message LiveMessage) {
+	if session.closed {
+		return
+	}
+
+	select {
+	case session.messages <- message:
+	default:
+		session.overflowed.Store(true)
+		h.close(session)
+	}
+}
+
+// close закрывает очередь сообщений соединения. Вызывается под h.mu.
+func (h *LiveHub) close(session *LiveSession) {
+	if session.closed {
+		return
+	}
+
+	session.closed = true
+	close(session.messages)
+}
@@ -0,0 +1,283 @@
+package service_test
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"poymanov/todo/internal/domain"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"testing"
+	"time"
+)
+
+type liveHubMocks struct {
+	listRepo       *mock_repository.MockList
+	listMemberRepo *mock_repository.MockListMember
+	presence       *mock_service.MockPresence
+}
+
+func TestLiveHubSubscribe_Success(t *testing.T) {
+	liveHub, mocks, _ := mockLiveHub(t, 8)
+
+	userId, listId := twoUuids(t)
+	session := liveHub.Connect(userId)
+
+	mocks.listRepo.EXPECT().FindById(listId).Return(&domain.List{ID: listId, UserId: userId}, nil)
+	mocks.presence.EXPECT().Join(session.ID, listId, userId).Return(nil)
+
+	require.NoError(t, liveHub.Subscribe(session, listId))
+	require.Equal(t, service.LiveMessage{Type: service.LiveMessageSubscribed, ListId: &listId}, <-session.C)
+}
+
+func TestLiveHubSubscribe_NoAccess(t *testing.T) {
+	liveHub, mocks, _ := mockLiveHub(t, 8)
+
+	userId, listId := twoUuids(t)
+	session := liveHub.Connect(userId)
+
+	mocks.listRepo.EXPECT().FindById(listId).Return(&domain.List{ID: listId, UserId: uuid.New()}, nil)
+	mocks.listMemberRepo.EXPECT().Find(listId, userId).Return(nil, errors.New("not found"))
+
+	err := liveHub.Subscribe(session, listId)
+
+	require.EqualError(t, err, service.ErrListNotFound)
+	require.Empty(t, session.C)
+}
+
+func TestLiveHubUnsubscribe_LeavesList(t *testing.T) {
+	liveHub, mocks, _ := mockLiveHub(t, 8)
+
+	session, listId := subscribedLiveSession(t, liveHub, mocks)
+
+	mocks.presence.EXPECT().Leave(session.ID, listId).Return(nil)
+
+	require.NoError(t, liveHub.Unsubscribe(session, listId))
+	require.Equal(t, service.LiveMessageUnsubscribed, (<-session.C).Type)
+}
+
+func TestLiveHubDisconnect_LeavesAllLists(t *testing.T) {
+	liveHub, mocks, _ := mockLiveHub(t, 8)
+
+	session, _ := subscribedLiveSession(t, liveHub, mocks)
+
+	mocks.presence.EXPECT().LeaveAll(session.ID).Return(nil)
+
+	require.NoError(t, liveHub.Disconnect(session))
+
+	_, ok := <-session.C
+	require.False(t, ok)
+	require.False(t, session.Overflowed())
+}
+
+func TestLiveHubDisconnect_WithoutSubscriptions(t *testing.T) {
+	liveHub, _, _ := mockLiveHub(t, 8)
+
+	require.NoError(t, liveHub.Disconnect(liveHub.Connect(uuid.New())))
+}
+
+func TestLiveHubBroadcast_TaskOfSubscribedList(t *testing.T) {
+	liveHub, mocks, bus := mockLiveHub(t, 8)
+
+	session, listId := subscribedLiveSession(t, liveHub, mocks)
+	otherSession := liveHub.Connect(uuid.New())
+	otherListId, taskId := twoUuids(t)
+
+	mocks.listRepo.EXPECT().GetUserIds(listId).Return([]uuid.UUID{session.UserId}).AnyTimes()
+
+	go liveHub.Run()
+
+	// событие другого списка публикуется первым, но подписчику не приходит
+	message := publishLiveEvents(t, bus, session,
+		domain.TaskEvent{Type: domain.TaskEventCreated, TaskId: uuid.New(), ListId: &otherListId},
+		domain.TaskEvent{Type: domain.TaskEventUpdated, TaskId: taskId, ListId: &listId},
+	)
+
+	require.Equal(t, service.LiveMessageTask, message.Type)
+	require.Equal(t, listId, *message.ListId)
+	require.Equal(t, taskId, message.Event.TaskId)
+	require.Empty(t, otherSession.C)
+}
+
+func TestLiveHubBroadcast_TaskMovedFromSubscribedList(t *testing.T) {
+	liveHub, mocks, bus := mockLiveHub(t, 8)
+
+	session, listId := subscribedLiveSession(t, liveHub, mocks)
+	newListId, taskId := twoUuids(t)
+	oldValue, newValue := listId.String(), newListId.String()
+
+	mocks.listRepo.EXPECT().GetUserIds(listId).Return([]uuid.UUID{session.UserId}).AnyTimes()
+
+	go liveHub.Run()
+
+	message := publishLiveEvents(t, bus, session, domain.TaskEvent{
+		Type: domain.TaskEventUpdated, TaskId: taskId, ListId: &newListId,
+		Changes: []domain.TaskChange{{Field: domain.TaskFieldListId, OldValue: &oldValue, NewValue: &newValue}},
+	})
+
+	require.Equal(t, listId, *message.ListId)
+	require.Equal(t, newListId, *message.Event.ListId)
+}
+
+func TestLiveHubBroadcast_RemovedMemberUnsubscribed(t *testing.T) {
+	liveHub, mocks, bus := mockLiveHub(t, 8)
+
+	session, listId := subscribedLiveSession(t, liveHub, mocks)
+
+	mocks.listRepo.EXPECT().GetUserIds(listId).Return([]uuid.UUID{uuid.New()})
+	mocks.presence.EXPECT().Leave(session.ID, listId).Return(nil)
+
+	go liveHub.Run()
+
+	message := publishLiveEvents(t, bus, session, domain.TaskEvent{Type: domain.TaskEventUpdated, TaskId: uuid.New(), ListId: &listId})
+
+	require.Equal(t, service.LiveMessage{Type: service.LiveMessageUnsubscribed, ListId: &listId}, message)
+	require.Empty(t, session.C)
+}
+
+func TestLiveHubSend_SlowSessionClosed(t *testing.T) {
+	liveHub, _, _ := mockLiveHub(t, 1)
+
+	session := liveHub.Connect(uuid.New())
+
+	liveHub.Send(session, service.LiveMessage{Type: service.LiveMessagePing})
+	liveHub.Send(session, service.LiveMessage{Type: service.LiveMessagePing})
+
+	require.Equal(t, service.LiveMessagePing, (<-session.C).Type)
+
+	_, ok := <-session.C
+	require.False(t, ok)
+	require.True(t, session.Overflowed())
+}
+
+func TestLiveHubRun_BusOverflowClosesSessions(t *testing.T) {
+	liveHub, _, bus := mockLiveHub(t, 8)
+
+	session := liveHub.Connect(uuid.New())
+
+	// много соединений замедляют рассылку, и очередь подписки на шину переполняется
+	for i := 0; i < 1000; i++ {
+		liveHub.Connect(uuid.New())
+	}
+
+	go liveHub.Run()
+
+	listId := uuid.New()
+	event := taskOutboxEvent(t, domain.TaskEvent{Type: domain.TaskEventUpdated, TaskId: uuid.New(), ListId: &listId})
+
+	require.Eventually(t, func() bool {
+		for i := 0; i < 2000; i++ {
+			require.NoError(t, bus.Publish(event))
+		}
+
+		return session.Overflowed()
+	}, 5*time.Second, time.Millisecond)
+
+	_, ok := <-session.C
+	require.False(t, ok)
+}
+
+func TestLiveHubPresenceChanged_SendsUsersToSubscribers(t *testing.T) {
+	liveHub, mocks, _ := mockLiveHub(t, 8)
+
+	session, listId := subscribedLiveSession(t, liveHub, mocks)
+	users := &[]domain.PresenceUser{{UserId: session.UserId, Name: "Anna"}}
+
+	mocks.presence.EXPECT().GetUsers(listId).Return(users)
+	mocks.listRepo.EXPECT().GetUserIds(listId).Return([]uuid.UUID{session.UserId})
+
+	liveHub.PresenceChanged(listId)
+
+	require.Equal(t, service.LiveMessage{Type: service.LiveMessagePresence, ListId: &listId, Users: users}, <-session.C)
+}
+
+func TestLiveHubPresenceChanged_RemovedMemberUnsubscribed(t *testing.T) {
+	liveHub, mocks, _ := mockLiveHub(t, 8)
+
+	session, listId := subscribedLiveSession(t, liveHub, mocks)
+
+	mocks.presence.EXPECT().GetUsers(listId).Return(&[]domain.PresenceUser{})
+	mocks.listRepo.EXPECT().GetUserIds(listId).Return([]uuid.UUID{})
+	mocks.presence.EXPECT().Leave(session.ID, listId).Return(nil)
+
+	liveHub.PresenceChanged(listId)
+
+	require.Equal(t, service.LiveMessageUnsubscribed, (<-session.C).Type)
+	require.Empty(t, session.C)
+}
+
+func TestLiveHubPresenceChanged_NoSubscribers(t *testing.T) {
+	liveHub, _, _ := mockLiveHub(t, 8)
+
+	liveHub.PresenceChanged(uuid.New())
+}
+
+func TestLiveHubRefresh_UnsubscribesRevokedList(t *testing.T) {
+	liveHub, mocks, _ := mockLiveHub(t, 8)
+
+	session, listId := subscribedLiveSession(t, liveHub, mocks)
+
+	mocks.listRepo.EXPECT().FindById(listId).Return(nil, errors.New("not found"))
+	mocks.presence.EXPECT().Leave(session.ID, listId).Return(nil)
+	mocks.presence.EXPECT().Touch(session.ID).Return(nil)
+
+	require.NoError(t, liveHub.Refresh(session))
+	require.Equal(t, service.LiveMessageUnsubscribed, (<-session.C).Type)
+}
+
+// subscribedLiveSession подключает владельца списка и подписывает его на список
+func subscribedLiveSession(t *testing.T, liveHub *service.LiveHub, mocks liveHubMocks) (*service.LiveSession, uuid.UUID) {
+	t.Helper()
+
+	userId, listId := twoUuids(t)
+	session := liveHub.Connect(userId)
+
+	mocks.listRepo.EXPECT().FindById(listId).Return(&domain.List{ID: listId, UserId: userId}, nil)
+	mocks.presence.EXPECT().Join(session.ID, listId, userId).Return(nil)
+
+	require.NoError(t, liveHub.Subscribe(session, listId))
+	require.Equal(t, service.LiveMessageSubscribed, (<-session.C).Type)
+
+	return session, listId
+}
+
+// publishLiveEvents публикует события задач, пока хаб не разошлет их и соединение session не получит сообщение.
+// Хаб подписывается на шину асинхронно, поэтому события, опубликованные до подписки, теряются.
+func publishLiveEvents(t *testing.T, bus *service.EventBus, session *service.LiveSession, events ...domain.TaskEvent) service.LiveMessage {
+	t.Helper()
+
+	var message service.LiveMessage
+
+	require.Eventually(t, func() bool {
+		for _, event := range events {
+			require.NoError(t, bus.Publish(taskOutboxEvent(t, event)))
+		}
+
+		select {
+		case message = <-session.C:
+			return true
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, time.Second, time.Millisecond)
+
+	return message
+}
+
+func mockLiveHub(t *testing.T, buffer int) (*service.LiveHub, liveHubMocks, *service.EventBus) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	t.Cleanup(mockCtl.Finish)
+
+	mocks := liveHubMocks{
+		listRepo:       mock_repository.NewMockList(mockCtl),
+		listMemberRepo: mock_repository.NewMockListMember(mockCtl),
+		presence:       mock_service.NewMockPresence(mockCtl),
+	}
+	bus := service.NewEventBus()
+
+	return service.NewLiveHub(mocks.listRepo, mocks.listMemberRepo, mocks.presence, bus, buffer), mocks, bus
+}
@@ -13,6 +13,7 @@ import (
 	domain "poymanov/todo/internal/domain"
 	service "poymanov/todo/internal/service"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
@@ -72,6 +73,21 @@ func (mr *MockAuthMockRecorder) Register(data any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockAuth)(nil).Register), data)
 }
 
+// ResetPassword mocks base method.
+func (m *MockAuth) ResetPassword(token, password string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", token, password)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAuthMockRecorder) ResetPassword(token, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAuth)(nil).ResetPassword), token, password)
+}
+
 // MockTask is a mock of Task interface.
 type MockTask struct {
 	ctrl     *gomock.Controller
@@ -96,19 +112,34 @@ func (m *MockTask) EXPECT() *MockTaskMockRecorder {
 	return m.recorder
 }
 
+// ArchiveCompleted mocks base method.
+func (m *MockTask) ArchiveCompleted() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveCompleted")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveCompleted indicates an expected call of ArchiveCompleted.
+func (mr *MockTaskMockRecorder) ArchiveCompleted() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveCompleted", reflect.TypeOf((*MockTask)(nil).ArchiveCompleted))
+}
+
 // Create mocks base method.
-func (m *MockTask) Create(description string, userId uuid.UUID) (*domain.Task, error) {
+func (m *MockTask) Create(description string, userId uuid.UUID, listId *uuid.UUID) (*domain.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", description, userId)
+	ret := m.ctrl.Call(m, "Create", description, userId, listId)
 	ret0, _ := ret[0].(*domain.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockTaskMockRecorder) Create(description, userId any) *gomock.Call {
+func (mr *MockTaskMockRecorder) Create(description, userId, listId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTask)(nil).Create), description, userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTask)(nil).Create), description, userId, listId)
 }
 
 // Delete mocks base method.
@@ -125,6 +156,36 @@ func (mr *MockTaskMockRecorder) Delete(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTask)(nil).Delete), id)
 }
 
+// FindById mocks base method.
+func (m *MockTask) FindById(id uuid.UUID) (*domain.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*domain.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockTaskMockRecorder) FindById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockTask)(nil).FindById), id)
+}
+
+// FindWithTrashedById mocks base method.
+func (m *MockTask) FindWithTrashedById(id uuid.UUID) (*domain.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindWithTrashedById", id)
+	ret0, _ := ret[0].(*domain.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindWithTrashedById indicates an expected call of FindWithTrashedById.
+func (mr *MockTaskMockRecorder) FindWithTrashedById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWithTrashedById", reflect.TypeOf((*MockTask)(nil).FindWithTrashedById), id)
+}
+
 // GetAllByUserId mocks base method.
 func (m *MockTask) GetAllByUserId(id uuid.UUID) *[]domain.Task {
 	m.ctrl.T.Helper()
@@ -139,6 +200,77 @@ func (mr *MockTaskMockRecorder) GetAllByUserId(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByUserId", reflect.TypeOf((*MockTask)(nil).GetAllByUserId), id)
 }
 
+// GetArchive mocks base method.
+func (m *MockTask) GetArchive(userId uuid.UUID) *[]domain.Task {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchive", userId)
+	ret0, _ := ret[0].(*[]domain.Task)
+	return ret0
+}
+
+// GetArchive indicates an expected call of GetArchive.
+func (mr *MockTaskMockRecorder) GetArchive(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchive", reflect.TypeOf((*MockTask)(nil).GetArchive), userId)
+}
+
+// GetAssignedToUserId mocks base method.
+func (m *MockTask) GetAssignedToUserId(id uuid.UUID) *[]domain.Task {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignedToUserId", id)
+	ret0, _ := ret[0].(*[]domain.Task)
+	return ret0
+}
+
+// GetAssignedToUserId indicates an expected call of GetAssignedToUserId.
+func (mr *MockTaskMockRecorder) GetAssignedToUserId(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignedToUserId", reflect.TypeOf((*MockTask)(nil).GetAssignedToUserId), id)
+}
+
+// GetPageByUserId mocks base method.
+func (m *MockTask) GetPageByUserId(filter domain.TaskFilter, sort, cursor string, limit int) (*domain.TaskPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPageByUserId", filter, sort, cursor, limit)
+	ret0, _ := ret[0].(*domain.TaskPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPageByUserId indicates an expected call of GetPageByUserId.
+func (mr *MockTaskMockRecorder) GetPageByUserId(filter, sort, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPageByUserId", reflect.TypeOf((*MockTask)(nil).GetPageByUserId), filter, sort, cursor, limit)
+}
+
+// GetTrash mocks base method.
+func (m *MockTask) GetTrash(userId uuid.UUID) *[]domain.Task {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrash", userId)
+	ret0, _ := ret[0].(*[]domain.Task)
+	return ret0
+}
+
+// GetTrash indicates an expected call of GetTrash.
+func (mr *MockTaskMockRecorder) GetTrash(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrash", reflect.TypeOf((*MockTask)(nil).GetTrash), userId)
+}
+
+// History mocks base method.
+func (m *MockTask) History(id uuid.UUID) []domain.TaskRevision {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", id)
+	ret0, _ := ret[0].([]domain.TaskRevision)
+	return ret0
+}
+
+// History indicates an expected call of History.
+func (mr *MockTaskMockRecorder) History(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockTask)(nil).History), id)
+}
+
 // IsExistsById mocks base method.
 func (m *MockTask) IsExistsById(id uuid.UUID) bool {
 	m.ctrl.T.Helper()
@@ -153,6 +285,93 @@ func (mr *MockTaskMockRecorder) IsExistsById(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsExistsById", reflect.TypeOf((*MockTask)(nil).IsExistsById), id)
 }
 
+// Move mocks base method.
+func (m *MockTask) Move(id uuid.UUID, listId *uuid.UUID) (*domain.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", id, listId)
+	ret0, _ := ret[0].(*domain.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockTaskMockRecorder) Move(id, listId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockTask)(nil).Move), id, listId)
+}
+
+// Purge mocks base method.
+func (m *MockTask) Purge(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockTaskMockRecorder) Purge(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockTask)(nil).Purge), id)
+}
+
+// PurgeTrash mocks base method.
+func (m *MockTask) PurgeTrash(before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrash", before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrash indicates an expected call of PurgeTrash.
+func (mr *MockTaskMockRecorder) PurgeTrash(before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrash", reflect.TypeOf((*MockTask)(nil).PurgeTrash), before)
+}
+
+// Restore mocks base method.
+func (m *MockTask) Restore(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockTaskMockRecorder) Restore(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTask)(nil).Restore), id)
+}
+
+// Revert mocks base method.
+func (m *MockTask) Revert(id uuid.UUID, revision int) (*domain.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revert", id, revision)
+	ret0, _ := ret[0].(*domain.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revert indicates an expected call of Revert.
+func (mr *MockTaskMockRecorder) Revert(id, revision any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revert", reflect.TypeOf((*MockTask)(nil).Revert), id, revision)
+}
+
+// Unarchive mocks base method.
+func (m *MockTask) Unarchive(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unarchive", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unarchive indicates an expected call of Unarchive.
+func (mr *MockTaskMockRecorder) Unarchive(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unarchive", reflect.TypeOf((*MockTask)(nil).Unarchive), id)
+}
+
 // UpdateDescription mocks base method.
 func (m *MockTask) UpdateDescription(id uuid.UUID, description string) (*domain.Task, error) {
 	m.ctrl.T.Helper()
@@ -168,6 +387,36 @@ func (mr *MockTaskMockRecorder) UpdateDescription(id, description any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDescription", reflect.TypeOf((*MockTask)(nil).UpdateDescription), id, description)
 }
 
+// UpdateDueAt mocks base method.
+func (m *MockTask) UpdateDueAt(id uuid.UUID, dueAt *time.Time) (*domain.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDueAt", id, dueAt)
+	ret0, _ := ret[0].(*domain.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDueAt indicates an expected call of UpdateDueAt.
+func (mr *MockTaskMockRecorder) UpdateDueAt(id, dueAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDueAt", reflect.TypeOf((*MockTask)(nil).UpdateDueAt), id, dueAt)
+}
+
+// UpdateEstimate mocks base method.
+func (m *MockTask) UpdateEstimate(id uuid.UUID, minutes int) (*domain.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEstimate", id, minutes)
+	ret0, _ := ret[0].(*domain.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEstimate indicates an expected call of UpdateEstimate.
+func (mr *MockTaskMockRecorder) UpdateEstimate(id, minutes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEstimate", reflect.TypeOf((*MockTask)(nil).UpdateEstimate), id, minutes)
+}
+
 // UpdateIsCompleted mocks base method.
 func (m *MockTask) UpdateIsCompleted(id uuid.UUID, isCompleted bool) (*domain.Task, error) {
 	m.ctrl.T.Helper()
@@ -183,56 +432,2084 @@ func (mr *MockTaskMockRecorder) UpdateIsCompleted(id, isCompleted any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIsCompleted", reflect.TypeOf((*MockTask)(nil).UpdateIsCompleted), id, isCompleted)
 }
 
-// MockUser is a mock of User interface.
-type MockUser struct {
+// UpdateStatus mocks base method.
+func (m *MockTask) UpdateStatus(id, statusId uuid.UUID) (*domain.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", id, statusId)
+	ret0, _ := ret[0].(*domain.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockTaskMockRecorder) UpdateStatus(id, statusId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockTask)(nil).UpdateStatus), id, statusId)
+}
+
+// WithActor mocks base method.
+func (m *MockTask) WithActor(actorId uuid.UUID) service.Task {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithActor", actorId)
+	ret0, _ := ret[0].(service.Task)
+	return ret0
+}
+
+// WithActor indicates an expected call of WithActor.
+func (mr *MockTaskMockRecorder) WithActor(actorId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithActor", reflect.TypeOf((*MockTask)(nil).WithActor), actorId)
+}
+
+// WithVersion mocks base method.
+func (m *MockTask) WithVersion(version int) service.Task {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithVersion", version)
+	ret0, _ := ret[0].(service.Task)
+	return ret0
+}
+
+// WithVersion indicates an expected call of WithVersion.
+func (mr *MockTaskMockRecorder) WithVersion(version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithVersion", reflect.TypeOf((*MockTask)(nil).WithVersion), version)
+}
+
+// MockIdempotencyKey is a mock of IdempotencyKey interface.
+type MockIdempotencyKey struct {
 	ctrl     *gomock.Controller
-	recorder *MockUserMockRecorder
+	recorder *MockIdempotencyKeyMockRecorder
 	isgomock struct{}
 }
 
-// MockUserMockRecorder is the mock recorder for MockUser.
-type MockUserMockRecorder struct {
-	mock *MockUser
+// MockIdempotencyKeyMockRecorder is the mock recorder for MockIdempotencyKey.
+type MockIdempotencyKeyMockRecorder struct {
+	mock *MockIdempotencyKey
 }
 
-// NewMockUser creates a new mock instance.
-func NewMockUser(ctrl *gomock.Controller) *MockUser {
-	mock := &MockUser{ctrl: ctrl}
-	mock.recorder = &MockUserMockRecorder{mock}
+// NewMockIdempotencyKey creates a new mock instance.
+func NewMockIdempotencyKey(ctrl *gomock.Controller) *MockIdempotencyKey {
+	mock := &MockIdempotencyKey{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyKeyMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockUser) EXPECT() *MockUserMockRecorder {
+func (m *MockIdempotencyKey) EXPECT() *MockIdempotencyKeyMockRecorder {
 	return m.recorder
 }
 
-// Create mocks base method.
-func (m *MockUser) Create(name, email, password string) (*domain.User, error) {
+// Begin mocks base method.
+func (m *MockIdempotencyKey) Begin(userId uuid.UUID, key, fingerprint string) (*domain.IdempotencyKey, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", name, email, password)
-	ret0, _ := ret[0].(*domain.User)
+	ret := m.ctrl.Call(m, "Begin", userId, key, fingerprint)
+	ret0, _ := ret[0].(*domain.IdempotencyKey)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Create indicates an expected call of Create.
-func (mr *MockUserMockRecorder) Create(name, email, password any) *gomock.Call {
+// Begin indicates an expected call of Begin.
+func (mr *MockIdempotencyKeyMockRecorder) Begin(userId, key, fingerprint any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUser)(nil).Create), name, email, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockIdempotencyKey)(nil).Begin), userId, key, fingerprint)
 }
 
-// FindByEmail mocks base method.
-func (m *MockUser) FindByEmail(email string) (*domain.User, error) {
+// Complete mocks base method.
+func (m *MockIdempotencyKey) Complete(userId uuid.UUID, key string, statusCode int, headers map[string]string, body []byte) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByEmail", email)
-	ret0, _ := ret[0].(*domain.User)
+	ret := m.ctrl.Call(m, "Complete", userId, key, statusCode, headers, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockIdempotencyKeyMockRecorder) Complete(userId, key, statusCode, headers, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockIdempotencyKey)(nil).Complete), userId, key, statusCode, headers, body)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockIdempotencyKey) PurgeExpired() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired")
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindByEmail indicates an expected call of FindByEmail.
-func (mr *MockUserMockRecorder) FindByEmail(email any) *gomock.Call {
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockIdempotencyKeyMockRecorder) PurgeExpired() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockUser)(nil).FindByEmail), email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockIdempotencyKey)(nil).PurgeExpired))
+}
+
+// Release mocks base method.
+func (m *MockIdempotencyKey) Release(userId uuid.UUID, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", userId, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockIdempotencyKeyMockRecorder) Release(userId, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockIdempotencyKey)(nil).Release), userId, key)
+}
+
+// MockSync is a mock of Sync interface.
+type MockSync struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncMockRecorder
+	isgomock struct{}
+}
+
+// MockSyncMockRecorder is the mock recorder for MockSync.
+type MockSyncMockRecorder struct {
+	mock *MockSync
+}
+
+// NewMockSync creates a new mock instance.
+func NewMockSync(ctrl *gomock.Controller) *MockSync {
+	mock := &MockSync{ctrl: ctrl}
+	mock.recorder = &MockSyncMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSync) EXPECT() *MockSyncMockRecorder {
+	return m.recorder
+}
+
+// Pull mocks base method.
+func (m *MockSync) Pull(userId uuid.UUID, token string, limit int) (*service.SyncPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pull", userId, token, limit)
+	ret0, _ := ret[0].(*service.SyncPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pull indicates an expected call of Pull.
+func (mr *MockSyncMockRecorder) Pull(userId, token, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pull", reflect.TypeOf((*MockSync)(nil).Pull), userId, token, limit)
+}
+
+// Push mocks base method.
+func (m *MockSync) Push(userId uuid.UUID, changes []service.SyncChange) ([]service.SyncResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Push", userId, changes)
+	ret0, _ := ret[0].([]service.SyncResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Push indicates an expected call of Push.
+func (mr *MockSyncMockRecorder) Push(userId, changes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockSync)(nil).Push), userId, changes)
+}
+
+// MockTaskAssignment is a mock of TaskAssignment interface.
+type MockTaskAssignment struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskAssignmentMockRecorder
+	isgomock struct{}
+}
+
+// MockTaskAssignmentMockRecorder is the mock recorder for MockTaskAssignment.
+type MockTaskAssignmentMockRecorder struct {
+	mock *MockTaskAssignment
+}
+
+// NewMockTaskAssignment creates a new mock instance.
+func NewMockTaskAssignment(ctrl *gomock.Controller) *MockTaskAssignment {
+	mock := &MockTaskAssignment{ctrl: ctrl}
+	mock.recorder = &MockTaskAssignmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskAssignment) EXPECT() *MockTaskAssignmentMockRecorder {
+	return m.recorder
+}
+
+// Assign mocks base method.
+func (m *MockTaskAssignment) Assign(actorId uuid.UUID, task *domain.Task, assigneeId *uuid.UUID) (*domain.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assign", actorId, task, assigneeId)
+	ret0, _ := ret[0].(*domain.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Assign indicates an expected call of Assign.
+func (mr *MockTaskAssignmentMockRecorder) Assign(actorId, task, assigneeId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assign", reflect.TypeOf((*MockTaskAssignment)(nil).Assign), actorId, task, assigneeId)
+}
+
+// MockNotification is a mock of Notification interface.
+type MockNotification struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationMockRecorder is the mock recorder for MockNotification.
+type MockNotificationMockRecorder struct {
+	mock *MockNotification
+}
+
+// NewMockNotification creates a new mock instance.
+func NewMockNotification(ctrl *gomock.Controller) *MockNotification {
+	mock := &MockNotification{ctrl: ctrl}
+	mock.recorder = &MockNotificationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotification) EXPECT() *MockNotificationMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockNotification) GetAll(userId uuid.UUID, unreadOnly bool) *[]domain.Notification {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId, unreadOnly)
+	ret0, _ := ret[0].(*[]domain.Notification)
+	return ret0
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockNotificationMockRecorder) GetAll(userId, unreadOnly any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockNotification)(nil).GetAll), userId, unreadOnly)
+}
+
+// MarkAllRead mocks base method.
+func (m *MockNotification) MarkAllRead(userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockNotificationMockRecorder) MarkAllRead(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockNotification)(nil).MarkAllRead), userId)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotification) MarkRead(userId, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", userId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationMockRecorder) MarkRead(userId, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotification)(nil).MarkRead), userId, id)
+}
+
+// MockUndo is a mock of Undo interface.
+type MockUndo struct {
+	ctrl     *gomock.Controller
+	recorder *MockUndoMockRecorder
+	isgomock struct{}
+}
+
+// MockUndoMockRecorder is the mock recorder for MockUndo.
+type MockUndoMockRecorder struct {
+	mock *MockUndo
+}
+
+// NewMockUndo creates a new mock instance.
+func NewMockUndo(ctrl *gomock.Controller) *MockUndo {
+	mock := &MockUndo{ctrl: ctrl}
+	mock.recorder = &MockUndoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUndo) EXPECT() *MockUndoMockRecorder {
+	return m.recorder
+}
+
+// Undo mocks base method.
+func (m *MockUndo) Undo(userId uuid.UUID) (*service.UndoResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Undo", userId)
+	ret0, _ := ret[0].(*service.UndoResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Undo indicates an expected call of Undo.
+func (mr *MockUndoMockRecorder) Undo(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Undo", reflect.TypeOf((*MockUndo)(nil).Undo), userId)
+}
+
+// MockTaskBulk is a mock of TaskBulk interface.
+type MockTaskBulk struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskBulkMockRecorder
+	isgomock struct{}
+}
+
+// MockTaskBulkMockRecorder is the mock recorder for MockTaskBulk.
+type MockTaskBulkMockRecorder struct {
+	mock *MockTaskBulk
+}
+
+// NewMockTaskBulk creates a new mock instance.
+func NewMockTaskBulk(ctrl *gomock.Controller) *MockTaskBulk {
+	mock := &MockTaskBulk{ctrl: ctrl}
+	mock.recorder = &MockTaskBulkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskBulk) EXPECT() *MockTaskBulkMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockTaskBulk) Execute(userId uuid.UUID, operations []service.BulkOperation, atomic bool) ([]service.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", userId, operations, atomic)
+	ret0, _ := ret[0].([]service.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockTaskBulkMockRecorder) Execute(userId, operations, atomic any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockTaskBulk)(nil).Execute), userId, operations, atomic)
+}
+
+// MockTaskSearch is a mock of TaskSearch interface.
+type MockTaskSearch struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskSearchMockRecorder
+	isgomock struct{}
+}
+
+// MockTaskSearchMockRecorder is the mock recorder for MockTaskSearch.
+type MockTaskSearchMockRecorder struct {
+	mock *MockTaskSearch
+}
+
+// NewMockTaskSearch creates a new mock instance.
+func NewMockTaskSearch(ctrl *gomock.Controller) *MockTaskSearch {
+	mock := &MockTaskSearch{ctrl: ctrl}
+	mock.recorder = &MockTaskSearchMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskSearch) EXPECT() *MockTaskSearchMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockTaskSearch) Search(userId uuid.UUID, query string, limit int) (*[]domain.TaskSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", userId, query, limit)
+	ret0, _ := ret[0].(*[]domain.TaskSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockTaskSearchMockRecorder) Search(userId, query, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockTaskSearch)(nil).Search), userId, query, limit)
+}
+
+// MockTaskDependency is a mock of TaskDependency interface.
+type MockTaskDependency struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskDependencyMockRecorder
+	isgomock struct{}
+}
+
+// MockTaskDependencyMockRecorder is the mock recorder for MockTaskDependency.
+type MockTaskDependencyMockRecorder struct {
+	mock *MockTaskDependency
+}
+
+// NewMockTaskDependency creates a new mock instance.
+func NewMockTaskDependency(ctrl *gomock.Controller) *MockTaskDependency {
+	mock := &MockTaskDependency{ctrl: ctrl}
+	mock.recorder = &MockTaskDependencyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskDependency) EXPECT() *MockTaskDependencyMockRecorder {
+	return m.recorder
+}
+
+// GetBlockers mocks base method.
+func (m *MockTaskDependency) GetBlockers(taskId uuid.UUID) *[]domain.Task {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockers", taskId)
+	ret0, _ := ret[0].(*[]domain.Task)
+	return ret0
+}
+
+// GetBlockers indicates an expected call of GetBlockers.
+func (mr *MockTaskDependencyMockRecorder) GetBlockers(taskId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockers", reflect.TypeOf((*MockTaskDependency)(nil).GetBlockers), taskId)
+}
+
+// Link mocks base method.
+func (m *MockTaskDependency) Link(actorId, taskId, blockedById uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Link", actorId, taskId, blockedById)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Link indicates an expected call of Link.
+func (mr *MockTaskDependencyMockRecorder) Link(actorId, taskId, blockedById any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Link", reflect.TypeOf((*MockTaskDependency)(nil).Link), actorId, taskId, blockedById)
+}
+
+// Unlink mocks base method.
+func (m *MockTaskDependency) Unlink(taskId, blockedById uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlink", taskId, blockedById)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unlink indicates an expected call of Unlink.
+func (mr *MockTaskDependencyMockRecorder) Unlink(taskId, blockedById any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlink", reflect.TypeOf((*MockTaskDependency)(nil).Unlink), taskId, blockedById)
+}
+
+// MockTaskTag is a mock of TaskTag interface.
+type MockTaskTag struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskTagMockRecorder
+	isgomock struct{}
+}
+
+// MockTaskTagMockRecorder is the mock recorder for MockTaskTag.
+type MockTaskTagMockRecorder struct {
+	mock *MockTaskTag
+}
+
+// NewMockTaskTag creates a new mock instance.
+func NewMockTaskTag(ctrl *gomock.Controller) *MockTaskTag {
+	mock := &MockTaskTag{ctrl: ctrl}
+	mock.recorder = &MockTaskTagMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskTag) EXPECT() *MockTaskTagMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockTaskTag) Add(taskId uuid.UUID, names []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", taskId, names)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockTaskTagMockRecorder) Add(taskId, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockTaskTag)(nil).Add), taskId, names)
+}
+
+// GetByTaskId mocks base method.
+func (m *MockTaskTag) GetByTaskId(taskId uuid.UUID) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTaskId", taskId)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetByTaskId indicates an expected call of GetByTaskId.
+func (mr *MockTaskTagMockRecorder) GetByTaskId(taskId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTaskId", reflect.TypeOf((*MockTaskTag)(nil).GetByTaskId), taskId)
+}
+
+// Set mocks base method.
+func (m *MockTaskTag) Set(taskId uuid.UUID, names []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", taskId, names)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockTaskTagMockRecorder) Set(taskId, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockTaskTag)(nil).Set), taskId, names)
+}
+
+// MockTimeEntry is a mock of TimeEntry interface.
+type MockTimeEntry struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimeEntryMockRecorder
+	isgomock struct{}
+}
+
+// MockTimeEntryMockRecorder is the mock recorder for MockTimeEntry.
+type MockTimeEntryMockRecorder struct {
+	mock *MockTimeEntry
+}
+
+// NewMockTimeEntry creates a new mock instance.
+func NewMockTimeEntry(ctrl *gomock.Controller) *MockTimeEntry {
+	mock := &MockTimeEntry{ctrl: ctrl}
+	mock.recorder = &MockTimeEntryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimeEntry) EXPECT() *MockTimeEntryMockRecorder {
+	return m.recorder
+}
+
+// CreateManual mocks base method.
+func (m *MockTimeEntry) CreateManual(taskId, userId uuid.UUID, startedAt, stoppedAt time.Time, note string) (*domain.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateManual", taskId, userId, startedAt, stoppedAt, note)
+	ret0, _ := ret[0].(*domain.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateManual indicates an expected call of CreateManual.
+func (mr *MockTimeEntryMockRecorder) CreateManual(taskId, userId, startedAt, stoppedAt, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateManual", reflect.TypeOf((*MockTimeEntry)(nil).CreateManual), taskId, userId, startedAt, stoppedAt, note)
+}
+
+// Delete mocks base method.
+func (m *MockTimeEntry) Delete(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTimeEntryMockRecorder) Delete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTimeEntry)(nil).Delete), id)
+}
+
+// FindById mocks base method.
+func (m *MockTimeEntry) FindById(id uuid.UUID) (*domain.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*domain.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockTimeEntryMockRecorder) FindById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockTimeEntry)(nil).FindById), id)
+}
+
+// GetAllByTaskId mocks base method.
+func (m *MockTimeEntry) GetAllByTaskId(taskId uuid.UUID) *[]domain.TimeEntry {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByTaskId", taskId)
+	ret0, _ := ret[0].(*[]domain.TimeEntry)
+	return ret0
+}
+
+// GetAllByTaskId indicates an expected call of GetAllByTaskId.
+func (mr *MockTimeEntryMockRecorder) GetAllByTaskId(taskId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByTaskId", reflect.TypeOf((*MockTimeEntry)(nil).GetAllByTaskId), taskId)
+}
+
+// GetReport mocks base method.
+func (m *MockTimeEntry) GetReport(userId uuid.UUID, from, to time.Time, groupBy string) (*[]domain.TimeReportRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReport", userId, from, to, groupBy)
+	ret0, _ := ret[0].(*[]domain.TimeReportRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReport indicates an expected call of GetReport.
+func (mr *MockTimeEntryMockRecorder) GetReport(userId, from, to, groupBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReport", reflect.TypeOf((*MockTimeEntry)(nil).GetReport), userId, from, to, groupBy)
+}
+
+// GetTrackedTime mocks base method.
+func (m *MockTimeEntry) GetTrackedTime(entries []domain.TimeEntry) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrackedTime", entries)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetTrackedTime indicates an expected call of GetTrackedTime.
+func (mr *MockTimeEntryMockRecorder) GetTrackedTime(entries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrackedTime", reflect.TypeOf((*MockTimeEntry)(nil).GetTrackedTime), entries)
+}
+
+// Start mocks base method.
+func (m *MockTimeEntry) Start(taskId, userId uuid.UUID) (*domain.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", taskId, userId)
+	ret0, _ := ret[0].(*domain.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockTimeEntryMockRecorder) Start(taskId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockTimeEntry)(nil).Start), taskId, userId)
+}
+
+// Stop mocks base method.
+func (m *MockTimeEntry) Stop(taskId, userId uuid.UUID) (*domain.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", taskId, userId)
+	ret0, _ := ret[0].(*domain.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockTimeEntryMockRecorder) Stop(taskId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockTimeEntry)(nil).Stop), taskId, userId)
+}
+
+// MockList is a mock of List interface.
+type MockList struct {
+	ctrl     *gomock.Controller
+	recorder *MockListMockRecorder
+	isgomock struct{}
+}
+
+// MockListMockRecorder is the mock recorder for MockList.
+type MockListMockRecorder struct {
+	mock *MockList
+}
+
+// NewMockList creates a new mock instance.
+func NewMockList(ctrl *gomock.Controller) *MockList {
+	mock := &MockList{ctrl: ctrl}
+	mock.recorder = &MockListMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockList) EXPECT() *MockListMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockList) Create(name string, userId uuid.UUID) (*domain.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", name, userId)
+	ret0, _ := ret[0].(*domain.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockListMockRecorder) Create(name, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockList)(nil).Create), name, userId)
+}
+
+// Delete mocks base method.
+func (m *MockList) Delete(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockListMockRecorder) Delete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockList)(nil).Delete), id)
+}
+
+// FindById mocks base method.
+func (m *MockList) FindById(id uuid.UUID) (*domain.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*domain.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockListMockRecorder) FindById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockList)(nil).FindById), id)
+}
+
+// GetAllByUserId mocks base method.
+func (m *MockList) GetAllByUserId(id uuid.UUID) *[]domain.List {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByUserId", id)
+	ret0, _ := ret[0].(*[]domain.List)
+	return ret0
+}
+
+// GetAllByUserId indicates an expected call of GetAllByUserId.
+func (mr *MockListMockRecorder) GetAllByUserId(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByUserId", reflect.TypeOf((*MockList)(nil).GetAllByUserId), id)
+}
+
+// GetBoard mocks base method.
+func (m *MockList) GetBoard(list *domain.List) (*[]service.BoardColumn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoard", list)
+	ret0, _ := ret[0].(*[]service.BoardColumn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoard indicates an expected call of GetBoard.
+func (mr *MockListMockRecorder) GetBoard(list any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoard", reflect.TypeOf((*MockList)(nil).GetBoard), list)
+}
+
+// MockSmartList is a mock of SmartList interface.
+type MockSmartList struct {
+	ctrl     *gomock.Controller
+	recorder *MockSmartListMockRecorder
+	isgomock struct{}
+}
+
+// MockSmartListMockRecorder is the mock recorder for MockSmartList.
+type MockSmartListMockRecorder struct {
+	mock *MockSmartList
+}
+
+// NewMockSmartList creates a new mock instance.
+func NewMockSmartList(ctrl *gomock.Controller) *MockSmartList {
+	mock := &MockSmartList{ctrl: ctrl}
+	mock.recorder = &MockSmartListMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSmartList) EXPECT() *MockSmartListMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSmartList) Create(userId uuid.UUID, name string, filter []byte) (*domain.SmartList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, name, filter)
+	ret0, _ := ret[0].(*domain.SmartList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSmartListMockRecorder) Create(userId, name, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSmartList)(nil).Create), userId, name, filter)
+}
+
+// Delete mocks base method.
+func (m *MockSmartList) Delete(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSmartListMockRecorder) Delete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSmartList)(nil).Delete), id)
+}
+
+// FindById mocks base method.
+func (m *MockSmartList) FindById(id uuid.UUID) (*domain.SmartList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*domain.SmartList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockSmartListMockRecorder) FindById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockSmartList)(nil).FindById), id)
+}
+
+// GetAllByUserId mocks base method.
+func (m *MockSmartList) GetAllByUserId(id uuid.UUID) *[]domain.SmartList {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByUserId", id)
+	ret0, _ := ret[0].(*[]domain.SmartList)
+	return ret0
+}
+
+// GetAllByUserId indicates an expected call of GetAllByUserId.
+func (mr *MockSmartListMockRecorder) GetAllByUserId(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByUserId", reflect.TypeOf((*MockSmartList)(nil).GetAllByUserId), id)
+}
+
+// GetTasks mocks base method.
+func (m *MockSmartList) GetTasks(smartList *domain.SmartList, sort, cursor string, limit int) (*domain.TaskPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasks", smartList, sort, cursor, limit)
+	ret0, _ := ret[0].(*domain.TaskPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasks indicates an expected call of GetTasks.
+func (mr *MockSmartListMockRecorder) GetTasks(smartList, sort, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockSmartList)(nil).GetTasks), smartList, sort, cursor, limit)
+}
+
+// ParseFilter mocks base method.
+func (m *MockSmartList) ParseFilter(userId uuid.UUID, data []byte) (*domain.SmartListFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseFilter", userId, data)
+	ret0, _ := ret[0].(*domain.SmartListFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseFilter indicates an expected call of ParseFilter.
+func (mr *MockSmartListMockRecorder) ParseFilter(userId, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseFilter", reflect.TypeOf((*MockSmartList)(nil).ParseFilter), userId, data)
+}
+
+// MockListMember is a mock of ListMember interface.
+type MockListMember struct {
+	ctrl     *gomock.Controller
+	recorder *MockListMemberMockRecorder
+	isgomock struct{}
+}
+
+// MockListMemberMockRecorder is the mock recorder for MockListMember.
+type MockListMemberMockRecorder struct {
+	mock *MockListMember
+}
+
+// NewMockListMember creates a new mock instance.
+func NewMockListMember(ctrl *gomock.Controller) *MockListMember {
+	mock := &MockListMember{ctrl: ctrl}
+	mock.recorder = &MockListMemberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockListMember) EXPECT() *MockListMemberMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockListMember) GetAll(listId uuid.UUID) *[]domain.ListMember {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", listId)
+	ret0, _ := ret[0].(*[]domain.ListMember)
+	return ret0
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockListMemberMockRecorder) GetAll(listId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockListMember)(nil).GetAll), listId)
+}
+
+// GetRole mocks base method.
+func (m *MockListMember) GetRole(list *domain.List, userId uuid.UUID) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRole", list, userId)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockListMemberMockRecorder) GetRole(list, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockListMember)(nil).GetRole), list, userId)
+}
+
+// GetTaskRole mocks base method.
+func (m *MockListMember) GetTaskRole(task *domain.Task, userId uuid.UUID) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskRole", task, userId)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetTaskRole indicates an expected call of GetTaskRole.
+func (mr *MockListMemberMockRecorder) GetTaskRole(task, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskRole", reflect.TypeOf((*MockListMember)(nil).GetTaskRole), task, userId)
+}
+
+// Invite mocks base method.
+func (m *MockListMember) Invite(list *domain.List, email, role string) (*domain.ListMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite", list, email, role)
+	ret0, _ := ret[0].(*domain.ListMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockListMemberMockRecorder) Invite(list, email, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockListMember)(nil).Invite), list, email, role)
+}
+
+// Remove mocks base method.
+func (m *MockListMember) Remove(actorId uuid.UUID, list *domain.List, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", actorId, list, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockListMemberMockRecorder) Remove(actorId, list, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockListMember)(nil).Remove), actorId, list, userId)
+}
+
+// UpdateRole mocks base method.
+func (m *MockListMember) UpdateRole(list *domain.List, userId uuid.UUID, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", list, userId, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockListMemberMockRecorder) UpdateRole(list, userId, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockListMember)(nil).UpdateRole), list, userId, role)
+}
+
+// MockWorkspace is a mock of Workspace interface.
+type MockWorkspace struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceMockRecorder
+	isgomock struct{}
+}
+
+// MockWorkspaceMockRecorder is the mock recorder for MockWorkspace.
+type MockWorkspaceMockRecorder struct {
+	mock *MockWorkspace
+}
+
+// NewMockWorkspace creates a new mock instance.
+func NewMockWorkspace(ctrl *gomock.Controller) *MockWorkspace {
+	mock := &MockWorkspace{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspace) EXPECT() *MockWorkspaceMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockWorkspace) AddMember(workspace *domain.Workspace, email, role string) (*domain.WorkspaceMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", workspace, email, role)
+	ret0, _ := ret[0].(*domain.WorkspaceMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockWorkspaceMockRecorder) AddMember(workspace, email, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockWorkspace)(nil).AddMember), workspace, email, role)
+}
+
+// Create mocks base method.
+func (m *MockWorkspace) Create(userId uuid.UUID, name string) (*domain.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, name)
+	ret0, _ := ret[0].(*domain.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWorkspaceMockRecorder) Create(userId, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWorkspace)(nil).Create), userId, name)
+}
+
+// Find mocks base method.
+func (m *MockWorkspace) Find(id, userId uuid.UUID) (*domain.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", id, userId)
+	ret0, _ := ret[0].(*domain.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockWorkspaceMockRecorder) Find(id, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockWorkspace)(nil).Find), id, userId)
+}
+
+// GetAll mocks base method.
+func (m *MockWorkspace) GetAll(userId uuid.UUID) *[]domain.Workspace {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret0, _ := ret[0].(*[]domain.Workspace)
+	return ret0
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockWorkspaceMockRecorder) GetAll(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockWorkspace)(nil).GetAll), userId)
+}
+
+// GetMembers mocks base method.
+func (m *MockWorkspace) GetMembers(workspaceId uuid.UUID) *[]domain.WorkspaceMember {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", workspaceId)
+	ret0, _ := ret[0].(*[]domain.WorkspaceMember)
+	return ret0
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockWorkspaceMockRecorder) GetMembers(workspaceId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockWorkspace)(nil).GetMembers), workspaceId)
+}
+
+// RemoveMember mocks base method.
+func (m *MockWorkspace) RemoveMember(actorId uuid.UUID, workspace *domain.Workspace, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", actorId, workspace, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockWorkspaceMockRecorder) RemoveMember(actorId, workspace, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockWorkspace)(nil).RemoveMember), actorId, workspace, userId)
+}
+
+// Resolve mocks base method.
+func (m *MockWorkspace) Resolve(userId uuid.UUID, id *uuid.UUID) (*domain.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", userId, id)
+	ret0, _ := ret[0].(*domain.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockWorkspaceMockRecorder) Resolve(userId, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockWorkspace)(nil).Resolve), userId, id)
+}
+
+// MockInvite is a mock of Invite interface.
+type MockInvite struct {
+	ctrl     *gomock.Controller
+	recorder *MockInviteMockRecorder
+	isgomock struct{}
+}
+
+// MockInviteMockRecorder is the mock recorder for MockInvite.
+type MockInviteMockRecorder struct {
+	mock *MockInvite
+}
+
+// NewMockInvite creates a new mock instance.
+func NewMockInvite(ctrl *gomock.Controller) *MockInvite {
+	mock := &MockInvite{ctrl: ctrl}
+	mock.recorder = &MockInviteMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvite) EXPECT() *MockInviteMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockInvite) Accept(token string, userId uuid.UUID) (*domain.Invite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", token, userId)
+	ret0, _ := ret[0].(*domain.Invite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockInviteMockRecorder) Accept(token, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockInvite)(nil).Accept), token, userId)
+}
+
+// Create mocks base method.
+func (m *MockInvite) Create(data service.InviteData) (*domain.Invite, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", data)
+	ret0, _ := ret[0].(*domain.Invite)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockInviteMockRecorder) Create(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockInvite)(nil).Create), data)
+}
+
+// Preview mocks base method.
+func (m *MockInvite) Preview(token string) (*domain.Invite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preview", token)
+	ret0, _ := ret[0].(*domain.Invite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Preview indicates an expected call of Preview.
+func (mr *MockInviteMockRecorder) Preview(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preview", reflect.TypeOf((*MockInvite)(nil).Preview), token)
+}
+
+// MockStatus is a mock of Status interface.
+type MockStatus struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusMockRecorder
+	isgomock struct{}
+}
+
+// MockStatusMockRecorder is the mock recorder for MockStatus.
+type MockStatusMockRecorder struct {
+	mock *MockStatus
+}
+
+// NewMockStatus creates a new mock instance.
+func NewMockStatus(ctrl *gomock.Controller) *MockStatus {
+	mock := &MockStatus{ctrl: ctrl}
+	mock.recorder = &MockStatusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatus) EXPECT() *MockStatusMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockStatus) Create(userId uuid.UUID, listId *uuid.UUID, name string) (*domain.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, listId, name)
+	ret0, _ := ret[0].(*domain.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStatusMockRecorder) Create(userId, listId, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStatus)(nil).Create), userId, listId, name)
+}
+
+// Delete mocks base method.
+func (m *MockStatus) Delete(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockStatusMockRecorder) Delete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStatus)(nil).Delete), id)
+}
+
+// FindById mocks base method.
+func (m *MockStatus) FindById(id uuid.UUID) (*domain.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*domain.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockStatusMockRecorder) FindById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockStatus)(nil).FindById), id)
+}
+
+// GetAll mocks base method.
+func (m *MockStatus) GetAll(userId uuid.UUID, listId *uuid.UUID) (*[]domain.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId, listId)
+	ret0, _ := ret[0].(*[]domain.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockStatusMockRecorder) GetAll(userId, listId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockStatus)(nil).GetAll), userId, listId)
+}
+
+// MockUser is a mock of User interface.
+type MockUser struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserMockRecorder
+	isgomock struct{}
+}
+
+// MockUserMockRecorder is the mock recorder for MockUser.
+type MockUserMockRecorder struct {
+	mock *MockUser
+}
+
+// NewMockUser creates a new mock instance.
+func NewMockUser(ctrl *gomock.Controller) *MockUser {
+	mock := &MockUser{ctrl: ctrl}
+	mock.recorder = &MockUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUser) EXPECT() *MockUserMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUser) Create(name, email, password string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", name, email, password)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserMockRecorder) Create(name, email, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUser)(nil).Create), name, email, password)
+}
+
+// FindByEmail mocks base method.
+func (m *MockUser) FindByEmail(email string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByEmail", email)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByEmail indicates an expected call of FindByEmail.
+func (mr *MockUserMockRecorder) FindByEmail(email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockUser)(nil).FindByEmail), email)
+}
+
+// FindById mocks base method.
+func (m *MockUser) FindById(id uuid.UUID) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockUserMockRecorder) FindById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockUser)(nil).FindById), id)
+}
+
+// UpdateAutoArchiveDays mocks base method.
+func (m *MockUser) UpdateAutoArchiveDays(id uuid.UUID, days *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAutoArchiveDays", id, days)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAutoArchiveDays indicates an expected call of UpdateAutoArchiveDays.
+func (mr *MockUserMockRecorder) UpdateAutoArchiveDays(id, days any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAutoArchiveDays", reflect.TypeOf((*MockUser)(nil).UpdateAutoArchiveDays), id, days)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockUser) UpdatePassword(id uuid.UUID, hashedPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", id, hashedPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserMockRecorder) UpdatePassword(id, hashedPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUser)(nil).UpdatePassword), id, hashedPassword)
+}
+
+// MockAdmin is a mock of Admin interface.
+type MockAdmin struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminMockRecorder
+	isgomock struct{}
+}
+
+// MockAdminMockRecorder is the mock recorder for MockAdmin.
+type MockAdminMockRecorder struct {
+	mock *MockAdmin
+}
+
+// NewMockAdmin creates a new mock instance.
+func NewMockAdmin(ctrl *gomock.Controller) *MockAdmin {
+	mock := &MockAdmin{ctrl: ctrl}
+	mock.recorder = &MockAdminMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdmin) EXPECT() *MockAdminMockRecorder {
+	return m.recorder
+}
+
+// Disable mocks base method.
+func (m *MockAdmin) Disable(actorId, id uuid.UUID) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", actorId, id)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockAdminMockRecorder) Disable(actorId, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockAdmin)(nil).Disable), actorId, id)
+}
+
+// Enable mocks base method.
+func (m *MockAdmin) Enable(id uuid.UUID) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enable", id)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enable indicates an expected call of Enable.
+func (mr *MockAdminMockRecorder) Enable(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enable", reflect.TypeOf((*MockAdmin)(nil).Enable), id)
+}
+
+// FindUser mocks base method.
+func (m *MockAdmin) FindUser(id uuid.UUID) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUser", id)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUser indicates an expected call of FindUser.
+func (mr *MockAdminMockRecorder) FindUser(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUser", reflect.TypeOf((*MockAdmin)(nil).FindUser), id)
+}
+
+// GetTaskCounts mocks base method.
+func (m *MockAdmin) GetTaskCounts(id uuid.UUID) (domain.UserTaskCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskCounts", id)
+	ret0, _ := ret[0].(domain.UserTaskCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskCounts indicates an expected call of GetTaskCounts.
+func (mr *MockAdminMockRecorder) GetTaskCounts(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskCounts", reflect.TypeOf((*MockAdmin)(nil).GetTaskCounts), id)
+}
+
+// GetUsers mocks base method.
+func (m *MockAdmin) GetUsers(query string, limit, offset int) (*[]domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", query, limit, offset)
+	ret0, _ := ret[0].(*[]domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockAdminMockRecorder) GetUsers(query, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockAdmin)(nil).GetUsers), query, limit, offset)
+}
+
+// Promote mocks base method.
+func (m *MockAdmin) Promote(email string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Promote", email)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Promote indicates an expected call of Promote.
+func (mr *MockAdminMockRecorder) Promote(email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Promote", reflect.TypeOf((*MockAdmin)(nil).Promote), email)
+}
+
+// ResetPassword mocks base method.
+func (m *MockAdmin) ResetPassword(id uuid.UUID) (*service.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", id)
+	ret0, _ := ret[0].(*service.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAdminMockRecorder) ResetPassword(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAdmin)(nil).ResetPassword), id)
+}
+
+// MockWebhook is a mock of Webhook interface.
+type MockWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookMockRecorder is the mock recorder for MockWebhook.
+type MockWebhookMockRecorder struct {
+	mock *MockWebhook
+}
+
+// NewMockWebhook creates a new mock instance.
+func NewMockWebhook(ctrl *gomock.Controller) *MockWebhook {
+	mock := &MockWebhook{ctrl: ctrl}
+	mock.recorder = &MockWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhook) EXPECT() *MockWebhookMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWebhook) Create(userId uuid.UUID, url string, events []string) (*domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, url, events)
+	ret0, _ := ret[0].(*domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookMockRecorder) Create(userId, url, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhook)(nil).Create), userId, url, events)
+}
+
+// Delete mocks base method.
+func (m *MockWebhook) Delete(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookMockRecorder) Delete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhook)(nil).Delete), id)
+}
+
+// DeliverPending mocks base method.
+func (m *MockWebhook) DeliverPending() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverPending")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliverPending indicates an expected call of DeliverPending.
+func (mr *MockWebhookMockRecorder) DeliverPending() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverPending", reflect.TypeOf((*MockWebhook)(nil).DeliverPending))
+}
+
+// Find mocks base method.
+func (m *MockWebhook) Find(id, userId uuid.UUID) (*domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", id, userId)
+	ret0, _ := ret[0].(*domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockWebhookMockRecorder) Find(id, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockWebhook)(nil).Find), id, userId)
+}
+
+// GetAll mocks base method.
+func (m *MockWebhook) GetAll(userId uuid.UUID) *[]domain.Webhook {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret0, _ := ret[0].(*[]domain.Webhook)
+	return ret0
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockWebhookMockRecorder) GetAll(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockWebhook)(nil).GetAll), userId)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhook) GetDeliveries(webhookId uuid.UUID) *[]domain.WebhookDelivery {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", webhookId)
+	ret0, _ := ret[0].(*[]domain.WebhookDelivery)
+	return ret0
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhookMockRecorder) GetDeliveries(webhookId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhook)(nil).GetDeliveries), webhookId)
+}
+
+// Redeliver mocks base method.
+func (m *MockWebhook) Redeliver(webhookId, deliveryId uuid.UUID) (*domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", webhookId, deliveryId)
+	ret0, _ := ret[0].(*domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockWebhookMockRecorder) Redeliver(webhookId, deliveryId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockWebhook)(nil).Redeliver), webhookId, deliveryId)
+}
+
+// MockOutboxRelay is a mock of OutboxRelay interface.
+type MockOutboxRelay struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRelayMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxRelayMockRecorder is the mock recorder for MockOutboxRelay.
+type MockOutboxRelayMockRecorder struct {
+	mock *MockOutboxRelay
+}
+
+// NewMockOutboxRelay creates a new mock instance.
+func NewMockOutboxRelay(ctrl *gomock.Controller) *MockOutboxRelay {
+	mock := &MockOutboxRelay{ctrl: ctrl}
+	mock.recorder = &MockOutboxRelayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRelay) EXPECT() *MockOutboxRelayMockRecorder {
+	return m.recorder
+}
+
+// PurgePublished mocks base method.
+func (m *MockOutboxRelay) PurgePublished(before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgePublished", before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgePublished indicates an expected call of PurgePublished.
+func (mr *MockOutboxRelayMockRecorder) PurgePublished(before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgePublished", reflect.TypeOf((*MockOutboxRelay)(nil).PurgePublished), before)
+}
+
+// Relay mocks base method.
+func (m *MockOutboxRelay) Relay() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Relay")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Relay indicates an expected call of Relay.
+func (mr *MockOutboxRelayMockRecorder) Relay() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Relay", reflect.TypeOf((*MockOutboxRelay)(nil).Relay))
+}
+
+// MockEventStream is a mock of EventStream interface.
+type MockEventStream struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventStreamMockRecorder
+	isgomock struct{}
+}
+
+// MockEventStreamMockRecorder is the mock recorder for MockEventStream.
+type MockEventStreamMockRecorder struct {
+	mock *MockEventStream
+}
+
+// NewMockEventStream creates a new mock instance.
+func NewMockEventStream(ctrl *gomock.Controller) *MockEventStream {
+	mock := &MockEventStream{ctrl: ctrl}
+	mock.recorder = &MockEventStreamMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventStream) EXPECT() *MockEventStreamMockRecorder {
+	return m.recorder
+}
+
+// CatchUp mocks base method.
+func (m *MockEventStream) CatchUp() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CatchUp")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CatchUp indicates an expected call of CatchUp.
+func (mr *MockEventStreamMockRecorder) CatchUp() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CatchUp", reflect.TypeOf((*MockEventStream)(nil).CatchUp))
+}
+
+// Forward mocks base method.
+func (m *MockEventStream) Forward(publishedSequence int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Forward", publishedSequence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Forward indicates an expected call of Forward.
+func (mr *MockEventStreamMockRecorder) Forward(publishedSequence any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Forward", reflect.TypeOf((*MockEventStream)(nil).Forward), publishedSequence)
+}
+
+// Replay mocks base method.
+func (m *MockEventStream) Replay(afterSequence int64) (*[]domain.OutboxEvent, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replay", afterSequence)
+	ret0, _ := ret[0].(*[]domain.OutboxEvent)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Replay indicates an expected call of Replay.
+func (mr *MockEventStreamMockRecorder) Replay(afterSequence any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replay", reflect.TypeOf((*MockEventStream)(nil).Replay), afterSequence)
+}
+
+// Subscribe mocks base method.
+func (m *MockEventStream) Subscribe() *service.EventSubscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe")
+	ret0, _ := ret[0].(*service.EventSubscription)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockEventStreamMockRecorder) Subscribe() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockEventStream)(nil).Subscribe))
+}
+
+// Visible mocks base method.
+func (m *MockEventStream) Visible(userId uuid.UUID, event domain.OutboxEvent) (*domain.TaskEvent, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Visible", userId, event)
+	ret0, _ := ret[0].(*domain.TaskEvent)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Visible indicates an expected call of Visible.
+func (mr *MockEventStreamMockRecorder) Visible(userId, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Visible", reflect.TypeOf((*MockEventStream)(nil).Visible), userId, event)
+}
+
+// MockPresence is a mock of Presence interface.
+type MockPresence struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresenceMockRecorder
+	isgomock struct{}
+}
+
+// MockPresenceMockRecorder is the mock recorder for MockPresence.
+type MockPresenceMockRecorder struct {
+	mock *MockPresence
+}
+
+// NewMockPresence creates a new mock instance.
+func NewMockPresence(ctrl *gomock.Controller) *MockPresence {
+	mock := &MockPresence{ctrl: ctrl}
+	mock.recorder = &MockPresenceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresence) EXPECT() *MockPresenceMockRecorder {
+	return m.recorder
+}
+
+// GetUsers mocks base method.
+func (m *MockPresence) GetUsers(listId uuid.UUID) *[]domain.PresenceUser {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", listId)
+	ret0, _ := ret[0].(*[]domain.PresenceUser)
+	return ret0
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockPresenceMockRecorder) GetUsers(listId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockPresence)(nil).GetUsers), listId)
+}
+
+// Join mocks base method.
+func (m *MockPresence) Join(connectionId, listId, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Join", connectionId, listId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Join indicates an expected call of Join.
+func (mr *MockPresenceMockRecorder) Join(connectionId, listId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Join", reflect.TypeOf((*MockPresence)(nil).Join), connectionId, listId, userId)
+}
+
+// Leave mocks base method.
+func (m *MockPresence) Leave(connectionId, listId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Leave", connectionId, listId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Leave indicates an expected call of Leave.
+func (mr *MockPresenceMockRecorder) Leave(connectionId, listId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Leave", reflect.TypeOf((*MockPresence)(nil).Leave), connectionId, listId)
+}
+
+// LeaveAll mocks base method.
+func (m *MockPresence) LeaveAll(connectionId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeaveAll", connectionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LeaveAll indicates an expected call of LeaveAll.
+func (mr *MockPresenceMockRecorder) LeaveAll(connectionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaveAll", reflect.TypeOf((*MockPresence)(nil).LeaveAll), connectionId)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockPresence) PurgeExpired() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockPresenceMockRecorder) PurgeExpired() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockPresence)(nil).PurgeExpired))
+}
+
+// Touch mocks base method.
+func (m *MockPresence) Touch(connectionId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", connectionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockPresenceMockRecorder) Touch(connectionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockPresence)(nil).Touch), connectionId)
+}
+
+// MockLive is a mock of Live interface.
+type MockLive struct {
+	ctrl     *gomock.Controller
+	recorder *MockLiveMockRecorder
+	isgomock struct{}
+}
+
+// MockLiveMockRecorder is the mock recorder for MockLive.
+type MockLiveMockRecorder struct {
+	mock *MockLive
+}
+
+// NewMockLive creates a new mock instance.
+func NewMockLive(ctrl *gomock.Controller) *MockLive {
+	mock := &MockLive{ctrl: ctrl}
+	mock.recorder = &MockLiveMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLive) EXPECT() *MockLiveMockRecorder {
+	return m.recorder
+}
+
+// Connect mocks base method.
+func (m *MockLive) Connect(userId uuid.UUID) *service.LiveSession {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Connect", userId)
+	ret0, _ := ret[0].(*service.LiveSession)
+	return ret0
+}
+
+// Connect indicates an expected call of Connect.
+func (mr *MockLiveMockRecorder) Connect(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Connect", reflect.TypeOf((*MockLive)(nil).Connect), userId)
+}
+
+// Disconnect mocks base method.
+func (m *MockLive) Disconnect(session *service.LiveSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disconnect", session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disconnect indicates an expected call of Disconnect.
+func (mr *MockLiveMockRecorder) Disconnect(session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disconnect", reflect.TypeOf((*MockLive)(nil).Disconnect), session)
+}
+
+// PresenceChanged mocks base method.
+func (m *MockLive) PresenceChanged(listId uuid.UUID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PresenceChanged", listId)
+}
+
+// PresenceChanged indicates an expected call of PresenceChanged.
+func (mr *MockLiveMockRecorder) PresenceChanged(listId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresenceChanged", reflect.TypeOf((*MockLive)(nil).PresenceChanged), listId)
+}
+
+// Refresh mocks base method.
+func (m *MockLive) Refresh(session *service.LiveSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockLiveMockRecorder) Refresh(session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockLive)(nil).Refresh), session)
+}
+
+// Run mocks base method.
+func (m *MockLive) Run() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run")
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockLiveMockRecorder) Run() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockLive)(nil).Run))
+}
+
+// Send mocks base method.
+func (m *MockLive) Send(session *service.LiveSession, message service.LiveMessage) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Send", session, message)
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockLiveMockRecorder) Send(session, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockLive)(nil).Send), session, message)
+}
+
+// Subscribe mocks base method.
+func (m *MockLive) Subscribe(session *service.LiveSession, listId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", session, listId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockLiveMockRecorder) Subscribe(session, listId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockLive)(nil).Subscribe), session, listId)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockLive) Unsubscribe(session *service.LiveSession, listId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", session, listId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockLiveMockRecorder) Unsubscribe(session, listId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockLive)(nil).Unsubscribe), session, listId)
+}
+
+// MockAuditEvent is a mock of AuditEvent interface.
+type MockAuditEvent struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditEventMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditEventMockRecorder is the mock recorder for MockAuditEvent.
+type MockAuditEventMockRecorder struct {
+	mock *MockAuditEvent
+}
+
+// NewMockAuditEvent creates a new mock instance.
+func NewMockAuditEvent(ctrl *gomock.Controller) *MockAuditEvent {
+	mock := &MockAuditEvent{ctrl: ctrl}
+	mock.recorder = &MockAuditEventMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditEvent) EXPECT() *MockAuditEventMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockAuditEvent) Export(filter domain.AuditFilter) (*[]domain.AuditEvent, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", filter)
+	ret0, _ := ret[0].(*[]domain.AuditEvent)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockAuditEventMockRecorder) Export(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockAuditEvent)(nil).Export), filter)
+}
+
+// Record mocks base method.
+func (m *MockAuditEvent) Record(event domain.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuditEventMockRecorder) Record(event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuditEvent)(nil).Record), event)
+}
+
+// Search mocks base method.
+func (m *MockAuditEvent) Search(filter domain.AuditFilter, limit, offset int) (*[]domain.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", filter, limit, offset)
+	ret0, _ := ret[0].(*[]domain.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockAuditEventMockRecorder) Search(filter, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockAuditEvent)(nil).Search), filter, limit, offset)
 }
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"time"
+)
+
+const ErrNotificationNotFound = "notification not found"
+
+// notificationsLimit - максимальное количество уведомлений в ответе
+const notificationsLimit = 100
+
+type NotificationService struct {
+	notificationRepo repository.Notification
+}
+
+func NewNotificationService(notificationRepo repository.Notification) *NotificationService {
+	return &NotificationService{notificationRepo: notificationRepo}
+}
+
+// GetAll возвращает последние уведомления пользователя, при unreadOnly - только непрочитанные
+func (s *NotificationService) GetAll(userId uuid.UUID, unreadOnly bool) *[]domain.Notification {
+	return s.notificationRepo.GetByUserId(userId, unreadOnly, notificationsLimit)
+}
+
+// MarkRead отмечает уведомление id пользователя userId прочитанным
+func (s *NotificationService) MarkRead(userId, id uuid.UUID) error {
+	notification, err := s.notificationRepo.FindById(id)
+
+	if err != nil || notification.UserId != userId {
+		return errors.New(ErrNotificationNotFound)
+	}
+
+	return s.notificationRepo.MarkRead(id, time.Now())
+}
+
+func (s *NotificationService) MarkAllRead(userId uuid.UUID) error {
+	return s.notificationRepo.MarkAllRead(userId, time.Now())
+}
@@ -0,0 +1,73 @@
+package service_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"poymanov/todo/internal/domain"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	"testing"
+)
+
+func TestNotificationServiceGetAll_Success(t *testing.T) {
+	notificationService, notificationRepo := mockNotificationService(t)
+
+	userId, _ := twoUuids(t)
+
+	notificationRepo.EXPECT().GetByUserId(userId, true, 100).Return(&[]domain.Notification{{UserId: userId}})
+
+	require.Len(t, *notificationService.GetAll(userId, true), 1)
+}
+
+func TestNotificationServiceMarkRead_NotFound(t *testing.T) {
+	notificationService, notificationRepo := mockNotificationService(t)
+
+	userId, id := twoUuids(t)
+
+	notificationRepo.EXPECT().FindById(id).Return(nil, errors.New("not found"))
+
+	require.EqualError(t, notificationService.MarkRead(userId, id), service.ErrNotificationNotFound)
+}
+
+func TestNotificationServiceMarkRead_AnotherUser(t *testing.T) {
+	notificationService, notificationRepo := mockNotificationService(t)
+
+	userId, id := twoUuids(t)
+
+	notificationRepo.EXPECT().FindById(id).Return(&domain.Notification{ID: id}, nil)
+
+	require.EqualError(t, notificationService.MarkRead(userId, id), service.ErrNotificationNotFound)
+}
+
+func TestNotificationServiceMarkRead_Success(t *testing.T) {
+	notificationService, notificationRepo := mockNotificationService(t)
+
+	userId, id := twoUuids(t)
+
+	notificationRepo.EXPECT().FindById(id).Return(&domain.Notification{ID: id, UserId: userId}, nil)
+	notificationRepo.EXPECT().MarkRead(id, gomock.Any()).Return(nil)
+
+	require.NoError(t, notificationService.MarkRead(userId, id))
+}
+
+func TestNotificationServiceMarkAllRead_Success(t *testing.T) {
+	notificationService, notificationRepo := mockNotificationService(t)
+
+	userId, _ := twoUuids(t)
+
+	notificationRepo.EXPECT().MarkAllRead(userId, gomock.Any()).Return(nil)
+
+	require.NoError(t, notificationService.MarkAllRead(userId))
+}
+
+func mockNotificationService(t *testing.T) (*service.NotificationService, *mock_repository.MockNotification) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	notificationRepo := mock_repository.NewMockNotification(mockCtl)
+
+	return service.NewNotificationService(notificationRepo), notificationRepo
+}
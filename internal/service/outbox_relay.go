@@ -0,0 +1,127 @@
+package service
+
+import (
+	"fmt"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"time"
+)
+
+// EventSink - внешний получатель доменных событий, опубликованных из outbox. Публикация выполняется
+// вне транзакции, поэтому ожидание ответа получателя не удерживает соединение с базой данных.
+type EventSink interface {
+	Publish(event domain.OutboxEvent) error
+}
+
+// TxEventSink - получатель, который записывает событие в базу данных в той же транзакции,
+// в которой событие отмечается опубликованным
+type TxEventSink interface {
+	Publish(repos *repository.Repositories, event domain.OutboxEvent) error
+}
+
+// OutboxRelayService публикует события из outbox во все получатели в порядке их возникновения.
+// Событие считается опубликованным, только когда его приняли все получатели, поэтому после сбоя
+// получатель может получить событие повторно.
+type OutboxRelayService struct {
+	outboxEventRepo repository.OutboxEvent
+	transactor      repository.Transactor
+	txSinks         []TxEventSink
+	sinks           []EventSink
+	batchSize       int
+	maxAttempts     int
+}
+
+func NewOutboxRelayService(
+	outboxEventRepo repository.OutboxEvent,
+	transactor repository.Transactor,
+	txSinks []TxEventSink,
+	sinks []EventSink,
+	batchSize int,
+	maxAttempts int,
+) *OutboxRelayService {
+	return &OutboxRelayService{
+		outboxEventRepo: outboxEventRepo,
+		transactor:      transactor,
+		txSinks:         txSinks,
+		sinks:           sinks,
+		batchSize:       batchSize,
+		maxAttempts:     maxAttempts,
+	}
+}
+
+// Relay публикует очередную порцию событий и возвращает количество опубликованных. Если событие не удалось
+// опубликовать, следующие ждут повторной попытки, пока у события не закончатся попытки.
+// Пока события публикует другой экземпляр приложения, Relay ничего не делает.
+func (s *OutboxRelayService) Relay() (int, error) {
+	var published int
+	var publishErr error
+
+	_, err := s.outboxEventRepo.WithRelayLock(func() error {
+		for _, event := range *s.outboxEventRepo.GetUnpublished(s.batchSize) {
+			err := s.publish(event)
+
+			if err == nil {
+				published++
+				continue
+			}
+
+			publishErr = fmt.Errorf("event %s: %w", event.ID, err)
+
+			var failedAt *time.Time
+
+			if event.Attempts+1 >= s.maxAttempts {
+				now := time.Now()
+				failedAt = &now
+			}
+
+			if err = s.outboxEventRepo.RecordFailure(event.ID, publishErr.Error(), failedAt); err != nil {
+				return err
+			}
+
+			if failedAt == nil {
+				break
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return published, err
+	}
+
+	return published, publishErr
+}
+
+// PurgePublished удаляет события, опубликованные раньше before, и возвращает их количество
+func (s *OutboxRelayService) PurgePublished(before time.Time) (int64, error) {
+	return s.outboxEventRepo.PurgePublished(before)
+}
+
+// publish передает событие внешним получателям, затем в одной транзакции отмечает его опубликованным
+// и записывает получателями в базе данных
+func (s *OutboxRelayService) publish(event domain.OutboxEvent) error {
+	for _, sink := range s.sinks {
+		if err := sink.Publish(event); err != nil {
+			return err
+		}
+	}
+
+	return s.transactor.Transaction(func(repos *repository.Repositories) error {
+		publishedSequence, err := repos.OutboxEvent.MarkPublished(event.ID, time.Now())
+
+		if err != nil {
+			return err
+		}
+
+		event.PublishedSequence = &publishedSequence
+
+		for _, sink := range s.txSinks {
+			if err = sink.Publish(repos, event); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
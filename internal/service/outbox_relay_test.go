@@ -0,0 +1,199 @@
+package service_test
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	"testing"
+	"time"
+)
+
+// sinkStub запоминает опубликованные события и возвращает ошибку для событий из fail
+type sinkStub struct {
+	published []uuid.UUID
+	fail      map[uuid.UUID]bool
+}
+
+func (s *sinkStub) Publish(event domain.OutboxEvent) error {
+	if s.fail[event.ID] {
+		return errors.New("unavailable")
+	}
+
+	s.published = append(s.published, event.ID)
+
+	return nil
+}
+
+// txSinkStub запоминает события, записанные в транзакции публикации, и возвращает ошибку для событий из fail
+type txSinkStub struct {
+	stored    []uuid.UUID
+	sequences []int64
+	repos     []*repository.Repositories
+	fail      map[uuid.UUID]bool
+}
+
+func (s *txSinkStub) Publish(repos *repository.Repositories, event domain.OutboxEvent) error {
+	if s.fail[event.ID] {
+		return errors.New("failed to store")
+	}
+
+	s.stored = append(s.stored, event.ID)
+	s.sequences = append(s.sequences, *event.PublishedSequence)
+	s.repos = append(s.repos, repos)
+
+	return nil
+}
+
+func TestOutboxRelayServiceRelay_NotLocked(t *testing.T) {
+	relay, outboxEventRepo, _, _, _ := mockOutboxRelayService(t)
+
+	outboxEventRepo.EXPECT().WithRelayLock(gomock.Any()).Return(false, nil)
+
+	published, err := relay.Relay()
+
+	require.NoError(t, err)
+	require.Zero(t, published)
+}
+
+func TestOutboxRelayServiceRelay_Success(t *testing.T) {
+	relay, outboxEventRepo, sink, txSink, txRepos := mockOutboxRelayService(t)
+
+	firstId, secondId := twoUuids(t)
+
+	expectRelayLock(outboxEventRepo)
+	outboxEventRepo.EXPECT().GetUnpublished(100).Return(&[]domain.OutboxEvent{{ID: firstId}, {ID: secondId}})
+	outboxEventRepo.EXPECT().MarkPublished(firstId, gomock.Any()).Return(int64(7), nil)
+	outboxEventRepo.EXPECT().MarkPublished(secondId, gomock.Any()).Return(int64(8), nil)
+
+	published, err := relay.Relay()
+
+	require.NoError(t, err)
+	require.Equal(t, 2, published)
+	require.Equal(t, []uuid.UUID{firstId, secondId}, sink.published)
+	require.Equal(t, []uuid.UUID{firstId, secondId}, txSink.stored)
+	require.Equal(t, []int64{7, 8}, txSink.sequences)
+	require.Same(t, txRepos, txSink.repos[0])
+}
+
+func TestOutboxRelayServiceRelay_StopsOnFailure(t *testing.T) {
+	relay, outboxEventRepo, sink, txSink, _ := mockOutboxRelayService(t)
+
+	firstId, secondId := twoUuids(t)
+	sink.fail[firstId] = true
+
+	expectRelayLock(outboxEventRepo)
+	outboxEventRepo.EXPECT().GetUnpublished(100).Return(&[]domain.OutboxEvent{{ID: firstId, Attempts: 1}, {ID: secondId}})
+	outboxEventRepo.EXPECT().RecordFailure(firstId, gomock.Any(), nil).Return(nil)
+
+	published, err := relay.Relay()
+
+	require.ErrorContains(t, err, "unavailable")
+	require.Zero(t, published)
+	require.Empty(t, sink.published)
+	require.Empty(t, txSink.stored)
+}
+
+func TestOutboxRelayServiceRelay_SkipsExhaustedEvent(t *testing.T) {
+	relay, outboxEventRepo, sink, _, _ := mockOutboxRelayService(t)
+
+	firstId, secondId := twoUuids(t)
+	sink.fail[firstId] = true
+
+	expectRelayLock(outboxEventRepo)
+	outboxEventRepo.EXPECT().GetUnpublished(100).Return(&[]domain.OutboxEvent{{ID: firstId, Attempts: 2}, {ID: secondId}})
+	outboxEventRepo.EXPECT().RecordFailure(firstId, gomock.Any(), gomock.Not(gomock.Nil())).Return(nil)
+	outboxEventRepo.EXPECT().MarkPublished(secondId, gomock.Any()).Return(int64(7), nil)
+
+	published, err := relay.Relay()
+
+	require.ErrorContains(t, err, "unavailable")
+	require.Equal(t, 1, published)
+	require.Equal(t, []uuid.UUID{secondId}, sink.published)
+}
+
+func TestOutboxRelayServiceRelay_MarkFailed(t *testing.T) {
+	relay, outboxEventRepo, sink, _, _ := mockOutboxRelayService(t)
+
+	eventId, _ := twoUuids(t)
+
+	expectRelayLock(outboxEventRepo)
+	outboxEventRepo.EXPECT().GetUnpublished(100).Return(&[]domain.OutboxEvent{{ID: eventId}})
+	outboxEventRepo.EXPECT().MarkPublished(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("failed"))
+	outboxEventRepo.EXPECT().RecordFailure(eventId, gomock.Any(), nil).Return(nil)
+
+	published, err := relay.Relay()
+
+	require.Error(t, err)
+	require.Zero(t, published)
+	require.Equal(t, []uuid.UUID{eventId}, sink.published)
+}
+
+func TestOutboxRelayServiceRelay_TxSinkFailed(t *testing.T) {
+	relay, outboxEventRepo, _, txSink, _ := mockOutboxRelayService(t)
+
+	firstId, secondId := twoUuids(t)
+	txSink.fail[firstId] = true
+
+	expectRelayLock(outboxEventRepo)
+	outboxEventRepo.EXPECT().GetUnpublished(100).Return(&[]domain.OutboxEvent{{ID: firstId}, {ID: secondId}})
+	outboxEventRepo.EXPECT().MarkPublished(firstId, gomock.Any()).Return(int64(7), nil)
+	outboxEventRepo.EXPECT().RecordFailure(firstId, gomock.Any(), nil).Return(nil)
+
+	published, err := relay.Relay()
+
+	require.ErrorContains(t, err, "failed to store")
+	require.Zero(t, published)
+	require.Empty(t, txSink.stored)
+}
+
+func TestOutboxRelayServicePurgePublished(t *testing.T) {
+	relay, outboxEventRepo, _, _, _ := mockOutboxRelayService(t)
+
+	before := time.Now()
+
+	outboxEventRepo.EXPECT().PurgePublished(before).Return(int64(3), nil)
+
+	purged, err := relay.PurgePublished(before)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(3), purged)
+}
+
+func mockOutboxRelayService(t *testing.T) (
+	*service.OutboxRelayService,
+	*mock_repository.MockOutboxEvent,
+	*sinkStub,
+	*txSinkStub,
+	*repository.Repositories,
+) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	outboxEventRepo := mock_repository.NewMockOutboxEvent(mockCtl)
+	transactor := mock_repository.NewMockTransactor(mockCtl)
+	repos := &repository.Repositories{Transactor: transactor, OutboxEvent: outboxEventRepo}
+
+	transactor.EXPECT().Transaction(gomock.Any()).DoAndReturn(func(fn func(repos *repository.Repositories) error) error {
+		return fn(repos)
+	}).AnyTimes()
+
+	sink := &sinkStub{fail: make(map[uuid.UUID]bool)}
+	txSink := &txSinkStub{fail: make(map[uuid.UUID]bool)}
+	relay := service.NewOutboxRelayService(outboxEventRepo, transactor, []service.TxEventSink{txSink}, []service.EventSink{sink}, 100, 3)
+
+	return relay, outboxEventRepo, sink, txSink, repos
+}
+
+// expectRelayLock ожидает захват блокировки публикации и выполняет переданную функцию
+func expectRelayLock(outboxEventRepo *mock_repository.MockOutboxEvent) {
+	outboxEventRepo.EXPECT().WithRelayLock(gomock.Any()).DoAndReturn(func(fn func() error) (bool, error) {
+		return true, fn()
+	})
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"time"
+)
+
+// PresenceService отмечает, какие пользователи просматривают списки. Отметки хранятся в базе данных,
+// поэтому зрителей видно независимо от того, к какому экземпляру приложения они подключены.
+// Об изменении зрителей списка сообщается всем экземплярам через Postgres NOTIFY.
+type PresenceService struct {
+	listPresenceRepo repository.ListPresence
+	ttl              time.Duration
+}
+
+func NewPresenceService(listPresenceRepo repository.ListPresence, ttl time.Duration) *PresenceService {
+	return &PresenceService{listPresenceRepo: listPresenceRepo, ttl: ttl}
+}
+
+func (s *PresenceService) Join(connectionId, listId, userId uuid.UUID) error {
+	presence := &domain.ListPresence{ConnectionId: connectionId, ListId: listId, UserId: userId, ExpiresAt: time.Now().Add(s.ttl)}
+
+	if err := s.listPresenceRepo.Upsert(presence); err != nil {
+		return err
+	}
+
+	return s.listPresenceRepo.Notify(listId)
+}
+
+func (s *PresenceService) Leave(connectionId, listId uuid.UUID) error {
+	if err := s.listPresenceRepo.Delete(connectionId, listId); err != nil {
+		return err
+	}
+
+	return s.listPresenceRepo.Notify(listId)
+}
+
+// LeaveAll удаляет отметки соединения connectionId во всех списках
+func (s *PresenceService) LeaveAll(connectionId uuid.UUID) error {
+	listIds, err := s.listPresenceRepo.DeleteByConnectionId(connectionId)
+
+	if err != nil {
+		return err
+	}
+
+	return s.notify(listIds)
+}
+
+// Touch продлевает отметки соединения connectionId, пока оно открыто
+func (s *PresenceService) Touch(connectionId uuid.UUID) error {
+	return s.listPresenceRepo.Touch(connectionId, time.Now().Add(s.ttl))
+}
+
+func (s *PresenceService) GetUsers(listId uuid.UUID) *[]domain.PresenceUser {
+	return s.listPresenceRepo.GetUsers(listId, time.Now())
+}
+
+// PurgeExpired удаляет отметки соединений, переставших подавать признаки жизни, например из-за остановки экземпляра приложения
+func (s *PresenceService) PurgeExpired() (int64, error) {
+	listIds, err := s.listPresenceRepo.PurgeExpired(time.Now())
+
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(listIds)), s.notify(listIds)
+}
+
+// notify сообщает об изменении зрителей каждого из списков listIds один раз
+func (s *PresenceService) notify(listIds []uuid.UUID) error {
+	notified := make(map[uuid.UUID]bool, len(listIds))
+
+	for _, listId := range listIds {
+		if notified[listId] {
+			continue
+		}
+
+		if err := s.listPresenceRepo.Notify(listId); err != nil {
+			return err
+		}
+
+		notified[listId] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package service_test
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"poymanov/todo/internal/domain"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	"testing"
+	"time"
+)
+
+func TestPresenceServiceJoin_Success(t *testing.T) {
+	presenceService, listPresenceRepo := mockPresenceService(t)
+
+	connectionId, listId := twoUuids(t)
+	userId, _ := twoUuids(t)
+
+	listPresenceRepo.EXPECT().Upsert(gomock.Any()).DoAndReturn(func(presence *domain.ListPresence) error {
+		require.Equal(t, connectionId, presence.ConnectionId)
+		require.Equal(t, listId, presence.ListId)
+		require.Equal(t, userId, presence.UserId)
+		require.WithinDuration(t, time.Now().Add(90*time.Second), presence.ExpiresAt, time.Second)
+
+		return nil
+	})
+	listPresenceRepo.EXPECT().Notify(listId).Return(nil)
+
+	require.NoError(t, presenceService.Join(connectionId, listId, userId))
+}
+
+func TestPresenceServiceJoin_Failed(t *testing.T) {
+	presenceService, listPresenceRepo := mockPresenceService(t)
+
+	connectionId, listId := twoUuids(t)
+
+	listPresenceRepo.EXPECT().Upsert(gomock.Any()).Return(errors.New("failed"))
+
+	require.Error(t, presenceService.Join(connectionId, listId, uuid.New()))
+}
+
+func TestPresenceServiceLeave_Success(t *testing.T) {
+	presenceService, listPresenceRepo := mockPresenceService(t)
+
+	connectionId, listId := twoUuids(t)
+
+	listPresenceRepo.EXPECT().Delete(connectionId, listId).Return(nil)
+	listPresenceRepo.EXPECT().Notify(listId).Return(nil)
+
+	require.NoError(t, presenceService.Leave(connectionId, listId))
+}
+
+func TestPresenceServiceLeaveAll_NotifiesEachList(t *testing.T) {
+	presenceService, listPresenceRepo := mockPresenceService(t)
+
+	connectionId, listId := twoUuids(t)
+	otherListId, _ := twoUuids(t)
+
+	listPresenceRepo.EXPECT().DeleteByConnectionId(connectionId).Return([]uuid.UUID{listId, otherListId}, nil)
+	listPresenceRepo.EXPECT().Notify(listId).Return(nil)
+	listPresenceRepo.EXPECT().Notify(otherListId).Return(nil)
+
+	require.NoError(t, presenceService.LeaveAll(connectionId))
+}
+
+func TestPresenceServicePurgeExpired_NotifiesListOnce(t *testing.T) {
+	presenceService, listPresenceRepo := mockPresenceService(t)
+
+	listId, _ := twoUuids(t)
+
+	listPresenceRepo.EXPECT().PurgeExpired(gomock.Any()).Return([]uuid.UUID{listId, listId}, nil)
+	listPresenceRepo.EXPECT().Notify(listId).Return(nil)
+
+	purged, err := presenceService.PurgeExpired()
+
+	require.NoError(t, err)
+	require.Equal(t, int64(2), purged)
+}
+
+func mockPresenceService(t *testing.T) (*service.PresenceService, *mock_repository.MockListPresence) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	t.Cleanup(mockCtl.Finish)
+
+	listPresenceRepo := mock_repository.NewMockListPresence(mockCtl)
+
+	return service.NewPresenceService(listPresenceRepo, 90*time.Second), listPresenceRepo
+}
@@ -2,44 +2,322 @@ package service
 
 import (
 	"github.com/google/uuid"
+	"poymanov/todo/config"
 	"poymanov/todo/internal/domain"
 	"poymanov/todo/internal/repository"
 	"poymanov/todo/pkg/jwt"
+	"poymanov/todo/pkg/webhook"
+	"time"
 )
 
 type Auth interface {
 	Register(data RegisterData) (string, error)
 	Login(data LoginData) (string, error)
+	ResetPassword(token, password string) (string, error)
 }
 
 type Task interface {
-	Create(description string, userId uuid.UUID) (*domain.Task, error)
+	Create(description string, userId uuid.UUID, listId *uuid.UUID) (*domain.Task, error)
 	UpdateDescription(id uuid.UUID, description string) (*domain.Task, error)
 	UpdateIsCompleted(id uuid.UUID, isCompleted bool) (*domain.Task, error)
+	UpdateStatus(id, statusId uuid.UUID) (*domain.Task, error)
+	UpdateEstimate(id uuid.UUID, minutes int) (*domain.Task, error)
+	UpdateDueAt(id uuid.UUID, dueAt *time.Time) (*domain.Task, error)
+	Move(id uuid.UUID, listId *uuid.UUID) (*domain.Task, error)
+	WithActor(actorId uuid.UUID) Task
+	WithVersion(version int) Task
+	History(id uuid.UUID) []domain.TaskRevision
+	Revert(id uuid.UUID, revision int) (*domain.Task, error)
 	Delete(id uuid.UUID) error
 	IsExistsById(id uuid.UUID) bool
+	FindById(id uuid.UUID) (*domain.Task, error)
 	GetAllByUserId(id uuid.UUID) *[]domain.Task
+	GetAssignedToUserId(id uuid.UUID) *[]domain.Task
+	GetPageByUserId(filter domain.TaskFilter, sort, cursor string, limit int) (*domain.TaskPage, error)
+	FindWithTrashedById(id uuid.UUID) (*domain.Task, error)
+	GetTrash(userId uuid.UUID) *[]domain.Task
+	Restore(id uuid.UUID) error
+	Purge(id uuid.UUID) error
+	PurgeTrash(before time.Time) (int64, error)
+	GetArchive(userId uuid.UUID) *[]domain.Task
+	Unarchive(id uuid.UUID) error
+	ArchiveCompleted() (int64, error)
+}
+
+type IdempotencyKey interface {
+	Begin(userId uuid.UUID, key, fingerprint string) (*domain.IdempotencyKey, error)
+	Complete(userId uuid.UUID, key string, statusCode int, headers map[string]string, body []byte) error
+	Release(userId uuid.UUID, key string) error
+	PurgeExpired() (int64, error)
+}
+
+type Sync interface {
+	Pull(userId uuid.UUID, token string, limit int) (*SyncPage, error)
+	Push(userId uuid.UUID, changes []SyncChange) ([]SyncResult, error)
+}
+
+type TaskAssignment interface {
+	Assign(actorId uuid.UUID, task *domain.Task, assigneeId *uuid.UUID) (*domain.Task, error)
+}
+
+type Notification interface {
+	GetAll(userId uuid.UUID, unreadOnly bool) *[]domain.Notification
+	MarkRead(userId, id uuid.UUID) error
+	MarkAllRead(userId uuid.UUID) error
+}
+
+type Undo interface {
+	Undo(userId uuid.UUID) (*UndoResult, error)
+}
+
+type TaskBulk interface {
+	Execute(userId uuid.UUID, operations []BulkOperation, atomic bool) ([]BulkResult, error)
+}
+
+type TaskSearch interface {
+	Search(userId uuid.UUID, query string, limit int) (*[]domain.TaskSearchResult, error)
+}
+
+type TaskDependency interface {
+	Link(actorId, taskId, blockedById uuid.UUID) error
+	Unlink(taskId, blockedById uuid.UUID) error
+	GetBlockers(taskId uuid.UUID) *[]domain.Task
+}
+
+type TaskTag interface {
+	GetByTaskId(taskId uuid.UUID) []string
+	Set(taskId uuid.UUID, names []string) ([]string, error)
+	Add(taskId uuid.UUID, names []string) error
+}
+
+type TimeEntry interface {
+	Start(taskId, userId uuid.UUID) (*domain.TimeEntry, error)
+	Stop(taskId, userId uuid.UUID) (*domain.TimeEntry, error)
+	CreateManual(taskId, userId uuid.UUID, startedAt, stoppedAt time.Time, note string) (*domain.TimeEntry, error)
+	Delete(id uuid.UUID) error
+	FindById(id uuid.UUID) (*domain.TimeEntry, error)
+	GetAllByTaskId(taskId uuid.UUID) *[]domain.TimeEntry
+	GetTrackedTime(entries []domain.TimeEntry) time.Duration
+	GetReport(userId uuid.UUID, from, to time.Time, groupBy string) (*[]domain.TimeReportRow, error)
+}
+
+type List interface {
+	Create(name string, userId uuid.UUID) (*domain.List, error)
+	Delete(id uuid.UUID) error
+	FindById(id uuid.UUID) (*domain.List, error)
+	GetAllByUserId(id uuid.UUID) *[]domain.List
+	GetBoard(list *domain.List) (*[]BoardColumn, error)
+}
+
+type SmartList interface {
+	Create(userId uuid.UUID, name string, filter []byte) (*domain.SmartList, error)
+	Delete(id uuid.UUID) error
+	FindById(id uuid.UUID) (*domain.SmartList, error)
+	GetAllByUserId(id uuid.UUID) *[]domain.SmartList
+	GetTasks(smartList *domain.SmartList, sort, cursor string, limit int) (*domain.TaskPage, error)
+	ParseFilter(userId uuid.UUID, data []byte) (*domain.SmartListFilter, error)
+}
+
+type ListMember interface {
+	GetRole(list *domain.List, userId uuid.UUID) string
+	GetTaskRole(task *domain.Task, userId uuid.UUID) string
+	GetAll(listId uuid.UUID) *[]domain.ListMember
+	Invite(list *domain.List, email, role string) (*domain.ListMember, error)
+	UpdateRole(list *domain.List, userId uuid.UUID, role string) error
+	Remove(actorId uuid.UUID, list *domain.List, userId uuid.UUID) error
+}
+
+type Workspace interface {
+	Resolve(userId uuid.UUID, id *uuid.UUID) (*domain.Workspace, error)
+	Find(id, userId uuid.UUID) (*domain.Workspace, error)
+	GetAll(userId uuid.UUID) *[]domain.Workspace
+	Create(userId uuid.UUID, name string) (*domain.Workspace, error)
+	GetMembers(workspaceId uuid.UUID) *[]domain.WorkspaceMember
+	AddMember(workspace *domain.Workspace, email, role string) (*domain.WorkspaceMember, error)
+	RemoveMember(actorId uuid.UUID, workspace *domain.Workspace, userId uuid.UUID) error
+}
+
+type Invite interface {
+	Create(data InviteData) (*domain.Invite, string, error)
+	Preview(token string) (*domain.Invite, error)
+	Accept(token string, userId uuid.UUID) (*domain.Invite, error)
+}
+
+type Status interface {
+	GetAll(userId uuid.UUID, listId *uuid.UUID) (*[]domain.Status, error)
+	FindById(id uuid.UUID) (*domain.Status, error)
+	Create(userId uuid.UUID, listId *uuid.UUID, name string) (*domain.Status, error)
+	Delete(id uuid.UUID) error
 }
 
 type User interface {
 	Create(name, email, password string) (*domain.User, error)
 	FindByEmail(email string) (*domain.User, error)
+	UpdateAutoArchiveDays(id uuid.UUID, days *int) error
+	FindById(id uuid.UUID) (*domain.User, error)
+	UpdatePassword(id uuid.UUID, hashedPassword string) error
+}
+
+type Admin interface {
+	GetUsers(query string, limit, offset int) (*[]domain.User, error)
+	FindUser(id uuid.UUID) (*domain.User, error)
+	Disable(actorId, id uuid.UUID) (*domain.User, error)
+	Enable(id uuid.UUID) (*domain.User, error)
+	ResetPassword(id uuid.UUID) (*PasswordReset, error)
+	GetTaskCounts(id uuid.UUID) (domain.UserTaskCounts, error)
+	Promote(email string) (*domain.User, error)
+}
+
+type Webhook interface {
+	GetAll(userId uuid.UUID) *[]domain.Webhook
+	Find(id, userId uuid.UUID) (*domain.Webhook, error)
+	Create(userId uuid.UUID, url string, events []string) (*domain.Webhook, error)
+	Delete(id uuid.UUID) error
+	GetDeliveries(webhookId uuid.UUID) *[]domain.WebhookDelivery
+	Redeliver(webhookId, deliveryId uuid.UUID) (*domain.WebhookDelivery, error)
+	DeliverPending() (int, error)
+}
+
+type OutboxRelay interface {
+	Relay() (int, error)
+	PurgePublished(before time.Time) (int64, error)
+}
+
+type EventStream interface {
+	Subscribe() *EventSubscription
+	Replay(afterSequence int64) (*[]domain.OutboxEvent, bool)
+	Visible(userId uuid.UUID, event domain.OutboxEvent) (*domain.TaskEvent, bool)
+	Forward(publishedSequence int64) error
+	CatchUp() error
+}
+
+type Presence interface {
+	Join(connectionId, listId, userId uuid.UUID) error
+	Leave(connectionId, listId uuid.UUID) error
+	LeaveAll(connectionId uuid.UUID) error
+	Touch(connectionId uuid.UUID) error
+	GetUsers(listId uuid.UUID) *[]domain.PresenceUser
+	PurgeExpired() (int64, error)
+}
+
+type Live interface {
+	Connect(userId uuid.UUID) *LiveSession
+	Disconnect(session *LiveSession) error
+	Subscribe(session *LiveSession, listId uuid.UUID) error
+	Unsubscribe(session *LiveSession, listId uuid.UUID) error
+	Refresh(session *LiveSession) error
+	Send(session *LiveSession, message LiveMessage)
+	PresenceChanged(listId uuid.UUID)
+	Run()
+}
+
+type AuditEvent interface {
+	Record(event domain.AuditEvent) error
+	Search(filter domain.AuditFilter, limit, offset int) (*[]domain.AuditEvent, error)
+	Export(filter domain.AuditFilter) (*[]domain.AuditEvent, bool, error)
 }
 
 type Services struct {
-	Auth Auth
-	Task Task
-	User User
+	Auth           Auth
+	Task           Task
+	TaskSearch     TaskSearch
+	TaskBulk       TaskBulk
+	Sync           Sync
+	Undo           Undo
+	TaskAssignment TaskAssignment
+	TaskDependency TaskDependency
+	TaskTag        TaskTag
+	TimeEntry      TimeEntry
+	List           List
+	ListMember     ListMember
+	Notification   Notification
+	SmartList      SmartList
+	Status         Status
+	User           User
+	Admin          Admin
+	AuditEvent     AuditEvent
+	Webhook        Webhook
+	EventStream    EventStream
+	Presence       Presence
+	Live           Live
+	IdempotencyKey IdempotencyKey
+	Workspace      Workspace
+	Invite         Invite
+
+	repos *repository.Repositories
+	jwt   *jwt.JWT
+	conf  *config.Config
 }
 
-func NewServices(repos *repository.Repositories, jwt *jwt.JWT) *Services {
+func NewServices(repos *repository.Repositories, jwt *jwt.JWT, conf *config.Config) *Services {
 	usersService := NewUserService(repos.User)
 	authService := NewAuthService(usersService, jwt)
-	tasksService := NewTaskService(repos.Task)
+	workspacesService := NewWorkspaceService(repos.Workspace, repos.WorkspaceMember, repos.User, repos.Transactor)
+	invitesService := NewInviteService(repos.Invite, repos.Transactor, jwt, time.Duration(conf.Invites.TTLHours)*time.Hour)
+	adminService := NewAdminService(repos.User, repos.Task, jwt, time.Duration(conf.Auth.PasswordResetTTLHours)*time.Hour)
+	auditEventsService := NewAuditEventService(repos.AuditEvent, repos.User)
+	webhooksService := NewWebhookService(
+		repos.Webhook, repos.WebhookDelivery, webhook.NewClient(time.Duration(conf.Webhooks.TimeoutSeconds)*time.Second, conf.Webhooks.AllowPrivateNetworks), conf.Webhooks.MaxAttempts,
+	)
+	presenceService := NewPresenceService(repos.ListPresence, time.Duration(conf.Live.PresenceTTLSeconds)*time.Second)
+	idempotencyKeysService := NewIdempotencyKeyService(repos.IdempotencyKey, time.Duration(conf.Idempotency.TTLHours)*time.Hour)
 
-	return &Services{
-		Auth: authService,
-		Task: tasksService,
-		User: usersService,
+	services := &Services{
+		Auth:           authService,
+		User:           usersService,
+		Admin:          adminService,
+		AuditEvent:     auditEventsService,
+		Webhook:        webhooksService,
+		Presence:       presenceService,
+		IdempotencyKey: idempotencyKeysService,
+		Workspace:      workspacesService,
+		Invite:         invitesService,
+		jwt:            jwt,
+		conf:           conf,
 	}
+
+	services.setWorkspaceServices(repos)
+
+	return services
+}
+
+// ForWorkspace возвращает сервисы, в которых задачи, списки и принадлежащие им данные ограничены рабочим
+// пространством workspaceId. Заново создаются только сервисы, работающие с данными пространства, остальные,
+// в том числе поток событий и совместное редактирование, общие с s.
+func (s *Services) ForWorkspace(workspaceId uuid.UUID) *Services {
+	scoped := *s
+	scoped.setWorkspaceServices(s.repos.ForWorkspace(workspaceId))
+
+	return &scoped
+}
+
+// setWorkspaceServices создает сервисы, работающие с данными рабочих пространств, над репозиториями repos
+func (s *Services) setWorkspaceServices(repos *repository.Repositories) {
+	conf := s.conf
+
+	tasksService := NewTaskService(repos.Task, repos.TaskDependency, repos.Status, repos.List, repos.ListMember, repos.TaskHistory, repos.OutboxEvent, repos.Transactor, conf.Tasks.ForbidBlockedCompletion)
+
+	s.Task = tasksService
+	s.TaskSearch = NewTaskSearchService(repos.Task, conf.Search.Language)
+	s.TaskBulk = NewTaskBulkService(repos.Transactor, conf.Tasks.ForbidBlockedCompletion)
+	s.Sync = NewSyncService(repos.Task, repos.TaskTombstone, repos.Transactor, conf.Tasks.ForbidBlockedCompletion)
+	s.Undo = NewUndoService(repos.Transactor, time.Duration(conf.Tasks.UndoWindowSeconds)*time.Second, conf.Tasks.ForbidBlockedCompletion)
+	s.TaskAssignment = NewTaskAssignmentService(repos.Transactor, conf.Tasks.ForbidBlockedCompletion)
+	s.TaskDependency = NewTaskDependencyService(repos.TaskDependency, repos.Task, repos.List, repos.ListMember, repos.Transactor)
+	s.TaskTag = NewTaskTagService(repos.TaskTag)
+	s.TimeEntry = NewTimeEntryService(repos.TimeEntry)
+	s.List = NewListService(repos.List, repos.Task, repos.Status)
+	s.ListMember = NewListMemberService(repos.List, repos.ListMember, repos.User, repos.WorkspaceMember, repos.Transactor)
+	s.Notification = NewNotificationService(repos.Notification)
+	s.SmartList = NewSmartListService(repos.SmartList, repos.List, repos.Status, tasksService)
+	s.Status = NewStatusService(repos.Status)
+	s.repos = repos
+}
+
+// WithEventBus подключает поток событий задач и совместное редактирование к шине bus, общей для всего процесса
+func (s *Services) WithEventBus(bus *EventBus) *Services {
+	s.EventStream = NewEventStreamService(s.repos.OutboxEvent, s.repos.List, bus)
+	s.Live = NewLiveHub(s.repos.List, s.repos.ListMember, s.Presence, bus, s.conf.Live.SendBuffer)
+
+	return s
 }
@@ -0,0 +1,47 @@
+package service_test
+
+import (
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"poymanov/todo/config"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/helpers"
+	"testing"
+)
+
+func TestServicesForWorkspace_KeepsSharedServices(t *testing.T) {
+	mockedDatabase, _ := helpers.InitMockDatabase()
+
+	workspaceId, _ := twoUuids(t)
+
+	services := service.NewServices(repository.NewRepositories(mockedDatabase), nil, &config.Config{}).WithEventBus(service.NewEventBus())
+	scoped := services.ForWorkspace(workspaceId)
+
+	require.NotSame(t, services, scoped)
+	require.Same(t, services.EventStream, scoped.EventStream)
+	require.Same(t, services.Live, scoped.Live)
+	require.Same(t, services.Webhook, scoped.Webhook)
+	require.Same(t, services.User, scoped.User)
+	require.NotSame(t, services.Task, scoped.Task)
+	require.NotSame(t, services.Undo, scoped.Undo)
+}
+
+func TestServicesForWorkspace_UndoOtherWorkspace(t *testing.T) {
+	mockedDatabase, mock := helpers.InitMockDatabase()
+
+	workspaceId, userId := twoUuids(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM "task_history" WHERE task_history.task_id IN \(SELECT id FROM tasks WHERE tasks.workspace_id = \$1\)`).
+		WillReturnError(gorm.ErrRecordNotFound)
+	mock.ExpectRollback()
+
+	services := service.NewServices(repository.NewRepositories(mockedDatabase), nil, &config.Config{})
+
+	result, err := services.ForWorkspace(workspaceId).Undo.Undo(userId)
+
+	require.Nil(t, result)
+	require.EqualError(t, err, service.ErrNothingToUndo)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"time"
+	"unicode/utf8"
+)
+
+const ErrSmartListNotFound = "smart list not found"
+
+var ErrInvalidSmartListFilter = errors.New("invalid smart list filter")
+
+type SmartListService struct {
+	smartListRepo repository.SmartList
+	listRepo      repository.List
+	statusRepo    repository.Status
+	taskService   Task
+}
+
+func NewSmartListService(
+	smartListRepo repository.SmartList,
+	listRepo repository.List,
+	statusRepo repository.Status,
+	taskService Task,
+) *SmartListService {
+	return &SmartListService{
+		smartListRepo: smartListRepo,
+		listRepo:      listRepo,
+		statusRepo:    statusRepo,
+		taskService:   taskService,
+	}
+}
+
+// Create сохраняет умный список. Фильтр передается в виде JSON и проверяется ParseFilter.
+func (s *SmartListService) Create(userId uuid.UUID, name string, filter []byte) (*domain.SmartList, error) {
+	parsedFilter, err := s.ParseFilter(userId, filter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.smartListRepo.Create(&domain.SmartList{UserId: userId, Name: name, Filter: *parsedFilter})
+}
+
+func (s *SmartListService) Delete(id uuid.UUID) error {
+	return s.smartListRepo.Delete(id)
+}
+
+func (s *SmartListService) FindById(id uuid.UUID) (*domain.SmartList, error) {
+	return s.smartListRepo.FindById(id)
+}
+
+func (s *SmartListService) GetAllByUserId(id uuid.UUID) *[]domain.SmartList {
+	return s.smartListRepo.GetAllByUserId(id)
+}
+
+// GetTasks возвращает страницу задач, удовлетворяющих фильтру умного списка на текущий момент
+func (s *SmartListService) GetTasks(smartList *domain.SmartList, sort, cursor string, limit int) (*domain.TaskPage, error) {
+	filter := smartList.Filter.TaskFilter(smartList.UserId, time.Now())
+
+	return s.taskService.GetPageByUserId(filter, sort, cursor, limit)
+}
+
+// ParseFilter разбирает JSON фильтра, отклоняя неизвестные поля, и проверяет, что указанные в нем
+// список и статус принадлежат пользователю userId
+func (s *SmartListService) ParseFilter(userId uuid.UUID, data []byte) (*domain.SmartListFilter, error) {
+	var filter domain.SmartListFilter
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&filter); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSmartListFilter, err.Error())
+	}
+
+	if decoder.More() {
+		return nil, fmt.Errorf("%w: unexpected data after filter", ErrInvalidSmartListFilter)
+	}
+
+	if filter.CreatedWithinDays != nil && (*filter.CreatedWithinDays < 1 || *filter.CreatedWithinDays > 3650) {
+		return nil, fmt.Errorf("%w: created_within_days must be between 1 and 3650", ErrInvalidSmartListFilter)
+	}
+
+	if utf8.RuneCountInString(filter.Query) > 200 {
+		return nil, fmt.Errorf("%w: q must be at most 200 characters", ErrInvalidSmartListFilter)
+	}
+
+	if filter.ListId != nil {
+		list, err := s.listRepo.FindById(*filter.ListId)
+
+		if err != nil || list.UserId != userId {
+			return nil, fmt.Errorf("%w: list_id: %s", ErrInvalidSmartListFilter, ErrListNotFound)
+		}
+	}
+
+	if filter.StatusId != nil {
+		status, err := s.statusRepo.FindById(*filter.StatusId)
+
+		if err != nil || status.UserId != userId {
+			return nil, fmt.Errorf("%w: status_id: %s", ErrInvalidSmartListFilter, ErrStatusNotFound)
+		}
+	}
+
+	filter.TagsAll = normalizeTags(filter.TagsAll)
+	filter.TagsAny = normalizeTags(filter.TagsAny)
+
+	return &filter, nil
+}
@@ -0,0 +1,172 @@
+package service_test
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"poymanov/todo/internal/domain"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	mock_service "poymanov/todo/internal/service/mocks"
+	"testing"
+	"time"
+)
+
+type smartListMocks struct {
+	smartListRepo *mock_repository.MockSmartList
+	listRepo      *mock_repository.MockList
+	statusRepo    *mock_repository.MockStatus
+	taskService   *mock_service.MockTask
+}
+
+func TestSmartListServiceParseFilter_Invalid(t *testing.T) {
+	testCases := []struct {
+		name   string
+		filter string
+		error  string
+	}{
+		{"Unknown field", `{"priority":"high"}`, `invalid smart list filter: json: unknown field "priority"`},
+		{"Wrong type", `{"completed":"yes"}`, "invalid smart list filter: json: cannot unmarshal string into Go struct field SmartListFilter.completed of type bool"},
+		{"Trailing data", `{} {}`, "invalid smart list filter: unexpected data after filter"},
+		{"Not an object", `[]`, "invalid smart list filter: json: cannot unmarshal array into Go value of type domain.SmartListFilter"},
+		{"Days out of range", `{"created_within_days":0}`, "invalid smart list filter: created_within_days must be between 1 and 3650"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			smartListService, _ := mockSmartListService(t)
+
+			filter, err := smartListService.ParseFilter(uuid.New(), []byte(tc.filter))
+
+			require.Nil(t, filter)
+			require.ErrorIs(t, err, service.ErrInvalidSmartListFilter)
+			require.EqualError(t, err, tc.error)
+		})
+	}
+}
+
+func TestSmartListServiceParseFilter_ListOfAnotherUser(t *testing.T) {
+	smartListService, mocks := mockSmartListService(t)
+
+	listId := uuid.New()
+
+	mocks.listRepo.EXPECT().FindById(listId).Return(&domain.List{ID: listId, UserId: uuid.New()}, nil)
+
+	filter, err := smartListService.ParseFilter(uuid.New(), []byte(`{"list_id":"`+listId.String()+`"}`))
+
+	require.Nil(t, filter)
+	require.EqualError(t, err, "invalid smart list filter: list_id: list not found")
+}
+
+func TestSmartListServiceParseFilter_StatusNotFound(t *testing.T) {
+	smartListService, mocks := mockSmartListService(t)
+
+	statusId := uuid.New()
+
+	mocks.statusRepo.EXPECT().FindById(statusId).Return(nil, errors.New("failed"))
+
+	filter, err := smartListService.ParseFilter(uuid.New(), []byte(`{"status_id":"`+statusId.String()+`"}`))
+
+	require.Nil(t, filter)
+	require.EqualError(t, err, "invalid smart list filter: status_id: status not found")
+}
+
+func TestSmartListServiceParseFilter_Success(t *testing.T) {
+	smartListService, mocks := mockSmartListService(t)
+
+	userId, listId := uuid.New(), uuid.New()
+
+	mocks.listRepo.EXPECT().FindById(listId).Return(&domain.List{ID: listId, UserId: userId}, nil)
+
+	filter, err := smartListService.ParseFilter(userId, []byte(
+		`{"completed":false,"list_id":"`+listId.String()+`","tags_all":["Urgent","urgent "],"created_within_days":7}`,
+	))
+
+	require.NoError(t, err)
+	require.False(t, *filter.Completed)
+	require.Equal(t, listId, *filter.ListId)
+	require.Equal(t, []string{"urgent"}, filter.TagsAll)
+	require.Equal(t, 7, *filter.CreatedWithinDays)
+}
+
+func TestSmartListServiceCreate_Success(t *testing.T) {
+	smartListService, mocks := mockSmartListService(t)
+
+	userId := uuid.New()
+	isCompleted := true
+
+	mocks.smartListRepo.EXPECT().Create(&domain.SmartList{
+		UserId: userId,
+		Name:   "Done",
+		Filter: domain.SmartListFilter{Completed: &isCompleted, TagsAll: []string{}, TagsAny: []string{}},
+	}).DoAndReturn(func(smartList *domain.SmartList) (*domain.SmartList, error) {
+		return smartList, nil
+	})
+
+	smartList, err := smartListService.Create(userId, "Done", []byte(`{"completed":true}`))
+
+	require.NoError(t, err)
+	require.Equal(t, "Done", smartList.Name)
+}
+
+func TestSmartListServiceGetTasks_Success(t *testing.T) {
+	smartListService, mocks := mockSmartListService(t)
+
+	userId := uuid.New()
+	days := 7
+	smartList := &domain.SmartList{UserId: userId, Filter: domain.SmartListFilter{TagsAny: []string{"home"}, CreatedWithinDays: &days}}
+
+	mocks.taskService.EXPECT().GetPageByUserId(gomock.Any(), "-created_at", "", 10).
+		DoAndReturn(func(filter domain.TaskFilter, sort, cursor string, limit int) (*domain.TaskPage, error) {
+			require.Equal(t, userId, filter.UserId)
+			require.Equal(t, []string{"home"}, filter.TagsAny)
+			require.WithinDuration(t, time.Now().AddDate(0, 0, -7), *filter.CreatedFrom, time.Minute)
+
+			return &domain.TaskPage{}, nil
+		})
+
+	page, err := smartListService.GetTasks(smartList, "-created_at", "", 10)
+
+	require.NoError(t, err)
+	require.NotNil(t, page)
+}
+
+func TestSmartListServiceGetTasks_Overdue(t *testing.T) {
+	smartListService, mocks := mockSmartListService(t)
+
+	userId := uuid.New()
+	isOverdue := true
+	smartList := &domain.SmartList{UserId: userId, Filter: domain.SmartListFilter{Overdue: &isOverdue, TagsAll: []string{"urgent"}}}
+
+	mocks.taskService.EXPECT().GetPageByUserId(gomock.Any(), "-created_at", "", 10).
+		DoAndReturn(func(filter domain.TaskFilter, sort, cursor string, limit int) (*domain.TaskPage, error) {
+			require.True(t, *filter.Overdue)
+			require.Equal(t, []string{"urgent"}, filter.TagsAll)
+			require.WithinDuration(t, time.Now(), filter.OverdueAt, time.Minute)
+
+			return &domain.TaskPage{}, nil
+		})
+
+	_, err := smartListService.GetTasks(smartList, "-created_at", "", 10)
+
+	require.NoError(t, err)
+}
+
+func mockSmartListService(t *testing.T) (*service.SmartListService, smartListMocks) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mocks := smartListMocks{
+		smartListRepo: mock_repository.NewMockSmartList(mockCtl),
+		listRepo:      mock_repository.NewMockList(mockCtl),
+		statusRepo:    mock_repository.NewMockStatus(mockCtl),
+		taskService:   mock_service.NewMockTask(mockCtl),
+	}
+
+	smartListService := service.NewSmartListService(mocks.smartListRepo, mocks.listRepo, mocks.statusRepo, mocks.taskService)
+
+	return smartListService, mocks
+}
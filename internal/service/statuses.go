@@ -0,0 +1,167 @@
+package service
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+)
+
+const (
+	ErrStatusNotFound = "status not found"
+	ErrStatusIsDone   = "done status can't be deleted"
+	ErrStatusIsUsed   = "status is used by tasks"
+	ErrStatusIsLast   = "workflow must keep at least one open status"
+)
+
+var defaultStatuses = []struct {
+	name   string
+	isDone bool
+}{
+	{"Backlog", false},
+	{"In Progress", false},
+	{"Review", false},
+	{"Done", true},
+}
+
+type StatusService struct {
+	statusRepo repository.Status
+}
+
+func NewStatusService(statusRepo repository.Status) *StatusService {
+	return &StatusService{statusRepo: statusRepo}
+}
+
+func (s *StatusService) GetAll(userId uuid.UUID, listId *uuid.UUID) (*[]domain.Status, error) {
+	return resolveStatuses(s.statusRepo, userId, listId)
+}
+
+func (s *StatusService) FindById(id uuid.UUID) (*domain.Status, error) {
+	return s.statusRepo.FindById(id)
+}
+
+// Create добавляет новый статус в конец рабочего процесса списка listId (или пользователя, если список не задан).
+// Если у списка еще нет собственных статусов, для него создается набор статусов по умолчанию.
+func (s *StatusService) Create(userId uuid.UUID, listId *uuid.UUID, name string) (*domain.Status, error) {
+	statuses := s.statusRepo.GetByScope(userId, listId)
+
+	if len(*statuses) == 0 {
+		var err error
+		statuses, err = createDefaultStatuses(s.statusRepo, userId, listId)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	position := 0
+
+	for _, status := range *statuses {
+		if status.Position >= position {
+			position = status.Position + 1
+		}
+	}
+
+	return s.statusRepo.Create(&domain.Status{UserId: userId, ListId: listId, Name: name, Position: position})
+}
+
+func (s *StatusService) Delete(id uuid.UUID) error {
+	status, err := s.statusRepo.FindById(id)
+
+	if err != nil {
+		return errors.New(ErrStatusNotFound)
+	}
+
+	if status.IsDone {
+		return errors.New(ErrStatusIsDone)
+	}
+
+	if s.statusRepo.IsUsed(id) {
+		return errors.New(ErrStatusIsUsed)
+	}
+
+	openStatuses := 0
+
+	for _, scopeStatus := range *s.statusRepo.GetByScope(status.UserId, status.ListId) {
+		if !scopeStatus.IsDone {
+			openStatuses++
+		}
+	}
+
+	if openStatuses <= 1 {
+		return errors.New(ErrStatusIsLast)
+	}
+
+	return s.statusRepo.Delete(id)
+}
+
+// resolveStatuses возвращает статусы, действующие для задач списка listId:
+// собственные статусы списка, а при их отсутствии - статусы пользователя по умолчанию
+func resolveStatuses(statusRepo repository.Status, userId uuid.UUID, listId *uuid.UUID) (*[]domain.Status, error) {
+	if listId != nil {
+		statuses := statusRepo.GetByScope(userId, listId)
+
+		if len(*statuses) > 0 {
+			return statuses, nil
+		}
+	}
+
+	statuses := statusRepo.GetByScope(userId, nil)
+
+	if len(*statuses) > 0 {
+		return statuses, nil
+	}
+
+	return createDefaultStatuses(statusRepo, userId, nil)
+}
+
+func createDefaultStatuses(statusRepo repository.Status, userId uuid.UUID, listId *uuid.UUID) (*[]domain.Status, error) {
+	statuses := make([]domain.Status, 0, len(defaultStatuses))
+
+	for position, status := range defaultStatuses {
+		statuses = append(statuses, domain.Status{
+			UserId:   userId,
+			ListId:   listId,
+			Name:     status.name,
+			Position: position,
+			IsDone:   status.isDone,
+		})
+	}
+
+	if err := statusRepo.CreateBatch(&statuses); err != nil {
+		return nil, err
+	}
+
+	return &statuses, nil
+}
+
+// initialStatus возвращает первый незавершающий статус, в который попадают новые задачи
+func initialStatus(statuses []domain.Status) *domain.Status {
+	for i := range statuses {
+		if !statuses[i].IsDone {
+			return &statuses[i]
+		}
+	}
+
+	return nil
+}
+
+func doneStatus(statuses []domain.Status) *domain.Status {
+	for i := range statuses {
+		if statuses[i].IsDone {
+			return &statuses[i]
+		}
+	}
+
+	return nil
+}
+
+func findStatus(statuses []domain.Status, id uuid.UUID) *domain.Status {
+	for i := range statuses {
+		if statuses[i].ID == id {
+			return &statuses[i]
+		}
+	}
+
+	return nil
+}
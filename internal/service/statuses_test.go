@@ -0,0 +1,174 @@
+package service_test
+
+import (
+	"errors"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"poymanov/todo/internal/domain"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	"testing"
+)
+
+func TestStatusServiceGetAll_ListStatuses(t *testing.T) {
+	statusService, statusRepo := mockStatusService(t)
+
+	userId, listId := twoUuids(t)
+
+	statusRepo.EXPECT().GetByScope(userId, &listId).Return(&[]domain.Status{{Name: "List"}})
+
+	statuses, err := statusService.GetAll(userId, &listId)
+
+	require.NoError(t, err)
+	require.Equal(t, "List", (*statuses)[0].Name)
+}
+
+func TestStatusServiceGetAll_FallbackToUserStatuses(t *testing.T) {
+	statusService, statusRepo := mockStatusService(t)
+
+	userId, listId := twoUuids(t)
+
+	statusRepo.EXPECT().GetByScope(userId, &listId).Return(&[]domain.Status{})
+	statusRepo.EXPECT().GetByScope(userId, nil).Return(&[]domain.Status{{Name: "User"}})
+
+	statuses, err := statusService.GetAll(userId, &listId)
+
+	require.NoError(t, err)
+	require.Equal(t, "User", (*statuses)[0].Name)
+}
+
+func TestStatusServiceGetAll_CreateDefaults(t *testing.T) {
+	statusService, statusRepo := mockStatusService(t)
+
+	userId, _ := twoUuids(t)
+
+	statusRepo.EXPECT().GetByScope(userId, nil).Return(&[]domain.Status{})
+	statusRepo.EXPECT().CreateBatch(gomock.Any()).Return(nil)
+
+	statuses, err := statusService.GetAll(userId, nil)
+
+	require.NoError(t, err)
+	require.Len(t, *statuses, 4)
+	require.Equal(t, "Backlog", (*statuses)[0].Name)
+	require.True(t, (*statuses)[3].IsDone)
+}
+
+func TestStatusServiceGetAll_CreateDefaultsFailed(t *testing.T) {
+	statusService, statusRepo := mockStatusService(t)
+
+	userId, _ := twoUuids(t)
+
+	statusRepo.EXPECT().GetByScope(userId, nil).Return(&[]domain.Status{})
+	statusRepo.EXPECT().CreateBatch(gomock.Any()).Return(errors.New("failed"))
+
+	statuses, err := statusService.GetAll(userId, nil)
+
+	require.Error(t, err)
+	require.Nil(t, statuses)
+}
+
+func TestStatusServiceCreate_Success(t *testing.T) {
+	statusService, statusRepo := mockStatusService(t)
+
+	userId, _ := twoUuids(t)
+	name := faker.Word()
+
+	statusRepo.EXPECT().GetByScope(userId, nil).Return(&[]domain.Status{{Position: 0}, {Position: 1, IsDone: true}})
+	statusRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(status *domain.Status) (*domain.Status, error) {
+		return status, nil
+	})
+
+	status, err := statusService.Create(userId, nil, name)
+
+	require.NoError(t, err)
+	require.Equal(t, name, status.Name)
+	require.Equal(t, 2, status.Position)
+	require.False(t, status.IsDone)
+}
+
+func TestStatusServiceCreate_ListWithoutStatuses(t *testing.T) {
+	statusService, statusRepo := mockStatusService(t)
+
+	userId, listId := twoUuids(t)
+
+	statusRepo.EXPECT().GetByScope(userId, &listId).Return(&[]domain.Status{})
+	statusRepo.EXPECT().CreateBatch(gomock.Any()).Return(nil)
+	statusRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(status *domain.Status) (*domain.Status, error) {
+		return status, nil
+	})
+
+	status, err := statusService.Create(userId, &listId, faker.Word())
+
+	require.NoError(t, err)
+	require.Equal(t, listId, *status.ListId)
+	require.Equal(t, 4, status.Position)
+}
+
+func TestStatusServiceDelete_NotFound(t *testing.T) {
+	statusService, statusRepo := mockStatusService(t)
+
+	statusId, _ := twoUuids(t)
+
+	statusRepo.EXPECT().FindById(statusId).Return(nil, errors.New("failed"))
+
+	require.EqualError(t, statusService.Delete(statusId), service.ErrStatusNotFound)
+}
+
+func TestStatusServiceDelete_Done(t *testing.T) {
+	statusService, statusRepo := mockStatusService(t)
+
+	statusId, _ := twoUuids(t)
+
+	statusRepo.EXPECT().FindById(statusId).Return(&domain.Status{ID: statusId, IsDone: true}, nil)
+
+	require.EqualError(t, statusService.Delete(statusId), service.ErrStatusIsDone)
+}
+
+func TestStatusServiceDelete_Used(t *testing.T) {
+	statusService, statusRepo := mockStatusService(t)
+
+	statusId, _ := twoUuids(t)
+
+	statusRepo.EXPECT().FindById(statusId).Return(&domain.Status{ID: statusId}, nil)
+	statusRepo.EXPECT().IsUsed(statusId).Return(true)
+
+	require.EqualError(t, statusService.Delete(statusId), service.ErrStatusIsUsed)
+}
+
+func TestStatusServiceDelete_LastOpenStatus(t *testing.T) {
+	statusService, statusRepo := mockStatusService(t)
+
+	statusId, _ := twoUuids(t)
+
+	statusRepo.EXPECT().FindById(statusId).Return(&domain.Status{ID: statusId}, nil)
+	statusRepo.EXPECT().IsUsed(statusId).Return(false)
+	statusRepo.EXPECT().GetByScope(gomock.Any(), gomock.Any()).Return(&[]domain.Status{{ID: statusId}, {IsDone: true}})
+
+	require.EqualError(t, statusService.Delete(statusId), service.ErrStatusIsLast)
+}
+
+func TestStatusServiceDelete_Success(t *testing.T) {
+	statusService, statusRepo := mockStatusService(t)
+
+	statusId, _ := twoUuids(t)
+
+	statusRepo.EXPECT().FindById(statusId).Return(&domain.Status{ID: statusId}, nil)
+	statusRepo.EXPECT().IsUsed(statusId).Return(false)
+	statusRepo.EXPECT().GetByScope(gomock.Any(), gomock.Any()).Return(&[]domain.Status{{ID: statusId}, {}, {IsDone: true}})
+	statusRepo.EXPECT().Delete(statusId).Return(nil)
+
+	require.NoError(t, statusService.Delete(statusId))
+}
+
+func mockStatusService(t *testing.T) (*service.StatusService, *mock_repository.MockStatus) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	statusRepo := mock_repository.NewMockStatus(mockCtl)
+	statusService := service.NewStatusService(statusRepo)
+
+	return statusService, statusRepo
+}
@@ -0,0 +1,393 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"github.com/google/uuid"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"strconv"
+	"strings"
+)
+
+// Операции, которые клиент может передать при синхронизации
+const (
+	SyncOperationCreate = "create"
+	SyncOperationUpdate = "update"
+	SyncOperationDelete = "delete"
+)
+
+// Результаты применения изменений клиента
+const (
+	SyncStatusApplied  = "applied"
+	SyncStatusConflict = "conflict"
+	SyncStatusRejected = "rejected"
+)
+
+const (
+	ErrInvalidSyncToken        = "invalid sync token"
+	ErrSyncUnknownOperation    = "unknown operation"
+	ErrSyncTaskIdTaken         = "task id is already taken"
+	ErrSyncDescriptionRequired = "description is required"
+	ErrSyncBaseVersionRequired = "base_version is required"
+	ErrSyncTaskModified        = "task was modified on the server"
+	ErrSyncTaskDeleted         = "task was deleted on the server"
+	ErrSyncChangeFailed        = "change failed"
+)
+
+const (
+	DefaultSyncLimit = 500
+	MaxSyncLimit     = 1000
+)
+
+// syncErrors - ошибки применения изменений, которые возвращаются клиенту как есть
+var syncErrors = map[string]bool{
+	ErrTaskNotFound:            true,
+	ErrTaskIsBlocked:           true,
+	ErrSyncUnknownOperation:    true,
+	ErrSyncTaskIdTaken:         true,
+	ErrSyncDescriptionRequired: true,
+	ErrSyncBaseVersionRequired: true,
+}
+
+// SyncPage - изменения задач пользователя после переданного клиентом токена синхронизации
+type SyncPage struct {
+	Created []domain.Task
+	Updated []domain.Task
+	Deleted []uuid.UUID
+	// Token - токен для следующего запроса изменений
+	Token   string
+	HasMore bool
+}
+
+// SyncChange - изменение задачи, сделанное клиентом без связи с сервером. Для create идентификатор
+// задачи генерирует клиент, для update и delete BaseVersion - версия задачи, которую изменял клиент.
+type SyncChange struct {
+	Op          string
+	Id          uuid.UUID
+	BaseVersion *int
+	Description *string
+	IsCompleted *bool
+}
+
+// SyncResult - результат применения изменения. Task - актуальное состояние задачи на сервере.
+type SyncResult struct {
+	Op     string
+	Id     uuid.UUID
+	Status string
+	Error  string
+	Task   *domain.Task
+}
+
+// syncConflict - изменение клиента основано на устаревшей версии задачи
+type syncConflict struct {
+	reason string
+	task   *domain.Task
+}
+
+func (c *syncConflict) Error() string {
+	return c.reason
+}
+
+type SyncService struct {
+	taskRepo                repository.Task
+	taskTombstoneRepo       repository.TaskTombstone
+	transactor              repository.Transactor
+	forbidBlockedCompletion bool
+}
+
+func NewSyncService(taskRepo repository.Task, taskTombstoneRepo repository.TaskTombstone, transactor repository.Transactor, forbidBlockedCompletion bool) *SyncService {
+	return &SyncService{taskRepo: taskRepo, taskTombstoneRepo: taskTombstoneRepo, transactor: transactor, forbidBlockedCompletion: forbidBlockedCompletion}
+}
+
+// syncEntry - изменение задачи или отметка о потере доступа к ней в общем порядке журнала синхронизации
+type syncEntry struct {
+	position  domain.SyncPosition
+	task      *domain.Task
+	tombstone *domain.TaskTombstone
+}
+
+// Pull возвращает изменения задач пользователя после токена token. Пустой токен означает полную синхронизацию.
+// Задачи, удаленные окончательно или ставшие недоступными пользователю, возвращаются как удаленные.
+func (s *SyncService) Pull(userId uuid.UUID, token string, limit int) (*SyncPage, error) {
+	since, err := decodeSyncToken(token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > MaxSyncLimit {
+		limit = DefaultSyncLimit
+	}
+
+	tasks, err := s.taskRepo.GetChangedSince(userId, since, limit+1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tombstones, err := s.taskTombstoneRepo.GetChangedSince(userId, since, limit+1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	page := &SyncPage{Created: []domain.Task{}, Updated: []domain.Task{}, Deleted: []uuid.UUID{}}
+	changed := mergeSyncEntries(*tasks, *tombstones)
+
+	if len(changed) > limit {
+		changed, page.HasMore = changed[:limit], true
+	}
+
+	for _, entry := range changed {
+		switch {
+		case entry.tombstone != nil:
+			page.Deleted = append(page.Deleted, entry.tombstone.TaskId)
+		case entry.task.DeletedAt.Valid:
+			page.Deleted = append(page.Deleted, entry.task.ID)
+		case since.Less(entry.task.CreatePosition()):
+			page.Created = append(page.Created, *entry.task)
+		default:
+			page.Updated = append(page.Updated, *entry.task)
+		}
+	}
+
+	if len(changed) > 0 {
+		since = changed[len(changed)-1].position
+	}
+
+	page.Token = encodeSyncToken(since)
+
+	return page, nil
+}
+
+// mergeSyncEntries объединяет упорядоченные по позиции изменения задач и отметки в один упорядоченный список
+func mergeSyncEntries(tasks []domain.Task, tombstones []domain.TaskTombstone) []syncEntry {
+	entries := make([]syncEntry, 0, len(tasks)+len(tombstones))
+	i, j := 0, 0
+
+	for i < len(tasks) || j < len(tombstones) {
+		if j == len(tombstones) || i < len(tasks) && tasks[i].ChangePosition().Less(tombstones[j].ChangePosition()) {
+			entries = append(entries, syncEntry{position: tasks[i].ChangePosition(), task: &tasks[i]})
+			i++
+		} else {
+			entries = append(entries, syncEntry{position: tombstones[j].ChangePosition(), tombstone: &tombstones[j]})
+			j++
+		}
+	}
+
+	return entries
+}
+
+// Push применяет изменения клиента по порядку. Каждое изменение применяется независимо: ошибка или конфликт
+// одного изменения не отменяет остальные.
+func (s *SyncService) Push(userId uuid.UUID, changes []SyncChange) ([]SyncResult, error) {
+	results := make([]SyncResult, len(changes))
+
+	err := s.transactor.Transaction(func(repos *repository.Repositories) error {
+		for i, change := range changes {
+			results[i] = SyncResult{Op: change.Op, Id: change.Id, Status: SyncStatusApplied}
+
+			var task *domain.Task
+
+			err := repos.Transactor.Transaction(func(itemRepos *repository.Repositories) error {
+				var err error
+				task, err = s.apply(itemRepos, userId, change)
+
+				return err
+			})
+
+			var conflict *syncConflict
+
+			switch {
+			case errors.As(err, &conflict):
+				results[i].Status, results[i].Error, results[i].Task = SyncStatusConflict, conflict.reason, conflict.task
+			case err != nil:
+				results[i].Status, results[i].Error = SyncStatusRejected, syncErrorMessage(err)
+			default:
+				results[i].Task = task
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (s *SyncService) apply(repos *repository.Repositories, userId uuid.UUID, change SyncChange) (*domain.Task, error) {
+	if change.Op == SyncOperationCreate {
+		return s.create(repos, s.taskService(repos, repos.Task, userId), userId, change)
+	}
+
+	if change.Op != SyncOperationUpdate && change.Op != SyncOperationDelete {
+		return nil, errors.New(ErrSyncUnknownOperation)
+	}
+
+	task, err := repos.Task.FindWithTrashedById(change.Id)
+
+	if err != nil || !domain.ListRoleAllows(taskRole(repos.List, repos.ListMember, task, userId), domain.ListRoleEditor) {
+		return nil, errors.New(ErrTaskNotFound)
+	}
+
+	if task.DeletedAt.Valid {
+		if change.Op == SyncOperationDelete {
+			return nil, nil
+		}
+
+		return nil, &syncConflict{reason: ErrSyncTaskDeleted}
+	}
+
+	if change.BaseVersion == nil {
+		return nil, errors.New(ErrSyncBaseVersionRequired)
+	}
+
+	if task.Version != *change.BaseVersion {
+		return nil, modifiedConflict(repos.Task, task.ID)
+	}
+
+	if change.Description != nil && *change.Description == "" {
+		return nil, errors.New(ErrSyncDescriptionRequired)
+	}
+
+	// Каждая запись изменяет задачу, только если ее версия не изменилась с проверенной: изменение,
+	// сделанное другим запросом после проверки, не будет перезаписано. Запись увеличивает версию на единицу.
+	version := *change.BaseVersion
+
+	if change.Op == SyncOperationDelete {
+		return nil, versionConflict(repos.Task, task.ID, s.taskService(repos, repos.Task.WithVersion(version), userId).Delete(task.ID))
+	}
+
+	if change.Description != nil {
+		if _, err = s.taskService(repos, repos.Task.WithVersion(version), userId).UpdateDescription(task.ID, *change.Description); err != nil {
+			return nil, versionConflict(repos.Task, task.ID, err)
+		}
+
+		version++
+	}
+
+	if change.IsCompleted != nil {
+		if _, err = s.taskService(repos, repos.Task.WithVersion(version), userId).UpdateIsCompleted(task.ID, *change.IsCompleted); err != nil {
+			return nil, versionConflict(repos.Task, task.ID, err)
+		}
+	}
+
+	return repos.Task.FindById(task.ID)
+}
+
+// taskService возвращает сервис задач, работающий с репозиториями транзакции repos и изменяющий задачи через taskRepo
+func (s *SyncService) taskService(repos *repository.Repositories, taskRepo repository.Task, userId uuid.UUID) *TaskService {
+	return NewTaskService(taskRepo, repos.TaskDependency, repos.Status, repos.List, repos.ListMember, repos.TaskHistory, repos.OutboxEvent, nil, s.forbidBlockedCompletion).
+		withActor(userId)
+}
+
+// modifiedConflict возвращает конфликт изменения задачи id с ее актуальным состоянием
+func modifiedConflict(taskRepo repository.Task, id uuid.UUID) error {
+	current, err := taskRepo.FindById(id)
+
+	if err != nil {
+		return err
+	}
+
+	return &syncConflict{reason: ErrSyncTaskModified, task: current}
+}
+
+// versionConflict заменяет ошибку записи задачи id, вызванную изменением ее версии, конфликтом
+func versionConflict(taskRepo repository.Task, id uuid.UUID, err error) error {
+	if err != nil && err.Error() == ErrTaskVersionConflict {
+		return modifiedConflict(taskRepo, id)
+	}
+
+	return err
+}
+
+// create создает задачу с идентификатором клиента. Повторная отправка уже созданной задачи не считается ошибкой.
+func (s *SyncService) create(repos *repository.Repositories, taskService *TaskService, userId uuid.UUID, change SyncChange) (*domain.Task, error) {
+	if existed, err := repos.Task.FindWithTrashedById(change.Id); err == nil {
+		if existed.UserId != userId {
+			return nil, errors.New(ErrSyncTaskIdTaken)
+		}
+
+		return existed, nil
+	}
+
+	if change.Description == nil || *change.Description == "" {
+		return nil, errors.New(ErrSyncDescriptionRequired)
+	}
+
+	statuses, err := resolveStatuses(repos.Status, userId, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	createdTask, err := repos.Task.Create(&domain.Task{
+		ID:          change.Id,
+		Description: *change.Description,
+		UserId:      userId,
+		StatusId:    &initialStatus(*statuses).ID,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = taskService.recordCreated(createdTask); err != nil {
+		return nil, err
+	}
+
+	if change.IsCompleted != nil && *change.IsCompleted {
+		if _, err = taskService.UpdateIsCompleted(change.Id, true); err != nil {
+			return nil, err
+		}
+	}
+
+	return repos.Task.FindById(change.Id)
+}
+
+// encodeSyncToken кодирует позицию в журнале изменений как "номер транзакции.номер изменения"
+func encodeSyncToken(position domain.SyncPosition) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(position.Xid, 10) + "." + strconv.FormatInt(position.Seq, 10)))
+}
+
+func decodeSyncToken(token string) (domain.SyncPosition, error) {
+	if token == "" {
+		return domain.SyncPosition{}, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+
+	if err != nil {
+		return domain.SyncPosition{}, errors.New(ErrInvalidSyncToken)
+	}
+
+	xid, seq, found := strings.Cut(string(data), ".")
+
+	if !found {
+		return domain.SyncPosition{}, errors.New(ErrInvalidSyncToken)
+	}
+
+	var position domain.SyncPosition
+
+	if position.Xid, err = strconv.ParseInt(xid, 10, 64); err != nil || position.Xid < 0 {
+		return domain.SyncPosition{}, errors.New(ErrInvalidSyncToken)
+	}
+
+	if position.Seq, err = strconv.ParseInt(seq, 10, 64); err != nil || position.Seq < 0 {
+		return domain.SyncPosition{}, errors.New(ErrInvalidSyncToken)
+	}
+
+	return position, nil
+}
+
+func syncErrorMessage(err error) string {
+	if syncErrors[err.Error()] {
+		return err.Error()
+	}
+
+	return ErrSyncChangeFailed
+}
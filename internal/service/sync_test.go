@@ -0,0 +1,337 @@
+package service_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	"testing"
+	"time"
+)
+
+func TestSyncServicePull_Classified(t *testing.T) {
+	syncService, taskRepo := mockSyncService(t)
+
+	userId, createdId := twoUuids(t)
+	updatedId, deletedId := twoUuids(t)
+	since := syncToken("700.10")
+
+	taskRepo.EXPECT().GetChangedSince(userId, domain.SyncPosition{Xid: 700, Seq: 10}, 4).Return(&[]domain.Task{
+		{ID: updatedId, CreatedXid: 690, CreatedSeq: 5, ChangeXid: 700, ChangeSeq: 11},
+		{ID: createdId, CreatedXid: 701, CreatedSeq: 9, ChangeXid: 701, ChangeSeq: 9},
+		{ID: deletedId, CreatedXid: 695, CreatedSeq: 6, ChangeXid: 702, ChangeSeq: 13, DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}},
+	}, nil)
+
+	page, err := syncService.Pull(userId, since, 3)
+
+	require.NoError(t, err)
+	require.Equal(t, createdId, page.Created[0].ID)
+	require.Equal(t, updatedId, page.Updated[0].ID)
+	require.Equal(t, deletedId, page.Deleted[0])
+	require.False(t, page.HasMore)
+	require.Equal(t, syncToken("702.13"), page.Token)
+}
+
+func TestSyncServicePull_HasMore(t *testing.T) {
+	syncService, taskRepo := mockSyncService(t)
+
+	userId, taskId := twoUuids(t)
+
+	taskRepo.EXPECT().GetChangedSince(userId, domain.SyncPosition{}, 2).Return(&[]domain.Task{
+		{ID: taskId, CreatedXid: 5, CreatedSeq: 1, ChangeXid: 5, ChangeSeq: 1},
+		{CreatedXid: 5, CreatedSeq: 2, ChangeXid: 5, ChangeSeq: 2},
+	}, nil)
+
+	page, err := syncService.Pull(userId, "", 1)
+
+	require.NoError(t, err)
+	require.True(t, page.HasMore)
+	require.Len(t, page.Created, 1)
+	require.Equal(t, syncToken("5.1"), page.Token)
+}
+
+func TestSyncServicePull_TombstonesMergedInOrder(t *testing.T) {
+	syncService, taskRepo, taskTombstoneRepo := mockSyncServiceWithTombstones(t)
+
+	userId, updatedId := twoUuids(t)
+	purgedId, removedId := twoUuids(t)
+	since := domain.SyncPosition{Xid: 700, Seq: 10}
+
+	taskRepo.EXPECT().GetChangedSince(userId, since, 3).Return(&[]domain.Task{
+		{ID: updatedId, CreatedXid: 690, CreatedSeq: 5, ChangeXid: 701, ChangeSeq: 12},
+	}, nil)
+	taskTombstoneRepo.EXPECT().GetChangedSince(userId, since, 3).Return(&[]domain.TaskTombstone{
+		{TaskId: purgedId, ChangeXid: 700, ChangeSeq: 11},
+		{TaskId: removedId, ChangeXid: 702, ChangeSeq: 13},
+	}, nil)
+
+	page, err := syncService.Pull(userId, syncToken("700.10"), 2)
+
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{purgedId}, page.Deleted)
+	require.Equal(t, updatedId, page.Updated[0].ID)
+	require.True(t, page.HasMore)
+	require.Equal(t, syncToken("701.12"), page.Token)
+}
+
+func TestSyncServicePull_NoChanges(t *testing.T) {
+	syncService, taskRepo := mockSyncService(t)
+
+	userId, _ := twoUuids(t)
+	since := syncToken("700.7")
+
+	taskRepo.EXPECT().GetChangedSince(userId, domain.SyncPosition{Xid: 700, Seq: 7}, service.DefaultSyncLimit+1).Return(&[]domain.Task{}, nil)
+
+	page, err := syncService.Pull(userId, since, 0)
+
+	require.NoError(t, err)
+	require.Equal(t, since, page.Token)
+}
+
+func TestSyncServicePull_InvalidToken(t *testing.T) {
+	syncService, _ := mockSyncService(t)
+
+	userId, _ := twoUuids(t)
+
+	for _, token := range []string{"not a token", syncToken("7"), syncToken("-1.7")} {
+		page, err := syncService.Pull(userId, token, 0)
+
+		require.EqualError(t, err, service.ErrInvalidSyncToken)
+		require.Nil(t, page)
+	}
+}
+
+func TestSyncServicePush_Create(t *testing.T) {
+	syncService, taskRepo := mockSyncService(t)
+
+	userId, taskId := twoUuids(t)
+	description := "test"
+
+	taskRepo.EXPECT().FindWithTrashedById(taskId).Return(nil, errors.New("not found"))
+	taskRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(task *domain.Task) (*domain.Task, error) {
+		require.Equal(t, taskId, task.ID)
+		require.Equal(t, openStatusId, *task.StatusId)
+		return task, nil
+	})
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, Version: 1}, nil)
+
+	results, err := syncService.Push(userId, []service.SyncChange{
+		{Op: service.SyncOperationCreate, Id: taskId, Description: &description},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, service.SyncStatusApplied, results[0].Status)
+	require.Equal(t, taskId, results[0].Task.ID)
+}
+
+func TestSyncServicePush_CreateRetried(t *testing.T) {
+	syncService, taskRepo := mockSyncService(t)
+
+	userId, taskId := twoUuids(t)
+	description := "test"
+
+	taskRepo.EXPECT().FindWithTrashedById(taskId).Return(&domain.Task{ID: taskId, UserId: userId}, nil)
+
+	results, err := syncService.Push(userId, []service.SyncChange{
+		{Op: service.SyncOperationCreate, Id: taskId, Description: &description},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, service.SyncStatusApplied, results[0].Status)
+}
+
+func TestSyncServicePush_UpdateConflict(t *testing.T) {
+	syncService, taskRepo := mockSyncService(t)
+
+	userId, taskId := twoUuids(t)
+	description, baseVersion := "test", 2
+
+	taskRepo.EXPECT().FindWithTrashedById(taskId).Return(&domain.Task{ID: taskId, UserId: userId, Version: 3}, nil)
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, UserId: userId, Version: 3}, nil)
+
+	results, err := syncService.Push(userId, []service.SyncChange{
+		{Op: service.SyncOperationUpdate, Id: taskId, BaseVersion: &baseVersion, Description: &description},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, service.SyncStatusConflict, results[0].Status)
+	require.Equal(t, service.ErrSyncTaskModified, results[0].Error)
+	require.Equal(t, 3, results[0].Task.Version)
+}
+
+func TestSyncServicePush_UpdateConflictOnWrite(t *testing.T) {
+	syncService, taskRepo := mockSyncService(t)
+
+	userId, taskId := twoUuids(t)
+	description, baseVersion := "test", 2
+
+	taskRepo.EXPECT().FindWithTrashedById(taskId).Return(&domain.Task{ID: taskId, UserId: userId, Version: 2}, nil)
+	taskRepo.EXPECT().WithVersion(2).Return(taskRepo)
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, UserId: userId, Version: 2}, nil)
+	taskRepo.EXPECT().Update(gomock.Any()).Return(nil, repository.ErrTaskVersionConflict)
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, UserId: userId, Version: 3}, nil)
+
+	results, err := syncService.Push(userId, []service.SyncChange{
+		{Op: service.SyncOperationUpdate, Id: taskId, BaseVersion: &baseVersion, Description: &description},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, service.SyncStatusConflict, results[0].Status)
+	require.Equal(t, service.ErrSyncTaskModified, results[0].Error)
+	require.Equal(t, 3, results[0].Task.Version)
+}
+
+func TestSyncServicePush_UpdateDeleted(t *testing.T) {
+	syncService, taskRepo := mockSyncService(t)
+
+	userId, taskId := twoUuids(t)
+	baseVersion := 1
+
+	taskRepo.EXPECT().FindWithTrashedById(taskId).Return(&domain.Task{
+		ID: taskId, UserId: userId, DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true},
+	}, nil)
+
+	results, err := syncService.Push(userId, []service.SyncChange{
+		{Op: service.SyncOperationUpdate, Id: taskId, BaseVersion: &baseVersion},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, service.SyncStatusConflict, results[0].Status)
+	require.Equal(t, service.ErrSyncTaskDeleted, results[0].Error)
+	require.Nil(t, results[0].Task)
+}
+
+func TestSyncServicePush_Update(t *testing.T) {
+	syncService, taskRepo := mockSyncService(t)
+
+	userId, taskId := twoUuids(t)
+	description, baseVersion := "test", 1
+
+	taskRepo.EXPECT().FindWithTrashedById(taskId).Return(&domain.Task{ID: taskId, UserId: userId, Version: 1}, nil)
+	taskRepo.EXPECT().WithVersion(1).Return(taskRepo)
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, UserId: userId, Version: 1}, nil)
+	taskRepo.EXPECT().Update(gomock.Any()).Return(&domain.Task{}, nil)
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, Description: description, Version: 2}, nil)
+
+	results, err := syncService.Push(userId, []service.SyncChange{
+		{Op: service.SyncOperationUpdate, Id: taskId, BaseVersion: &baseVersion, Description: &description},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, service.SyncStatusApplied, results[0].Status)
+	require.Equal(t, 2, results[0].Task.Version)
+}
+
+func TestSyncServicePush_UpdateSeveralFields(t *testing.T) {
+	syncService, taskRepo := mockSyncService(t)
+
+	userId, taskId := twoUuids(t)
+	description, isCompleted, baseVersion := "test", true, 1
+
+	taskRepo.EXPECT().FindWithTrashedById(taskId).Return(&domain.Task{ID: taskId, UserId: userId, Version: 1}, nil)
+	gomock.InOrder(
+		taskRepo.EXPECT().WithVersion(1).Return(taskRepo),
+		taskRepo.EXPECT().WithVersion(2).Return(taskRepo),
+	)
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, UserId: userId, Version: 1}, nil).Times(2)
+	taskRepo.EXPECT().Update(gomock.Any()).Return(&domain.Task{}, nil).Times(2)
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, Description: description, Version: 3}, nil)
+
+	results, err := syncService.Push(userId, []service.SyncChange{
+		{Op: service.SyncOperationUpdate, Id: taskId, BaseVersion: &baseVersion, Description: &description, IsCompleted: &isCompleted},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, service.SyncStatusApplied, results[0].Status)
+	require.Equal(t, 3, results[0].Task.Version)
+}
+
+func TestSyncServicePush_Rejected(t *testing.T) {
+	syncService, taskRepo := mockSyncService(t)
+
+	userId, taskId := twoUuids(t)
+	foreignId, _ := twoUuids(t)
+	baseVersion := 1
+
+	taskRepo.EXPECT().FindWithTrashedById(taskId).Return(&domain.Task{ID: taskId, UserId: userId, Version: 1}, nil).Times(2)
+	taskRepo.EXPECT().FindWithTrashedById(foreignId).Return(&domain.Task{ID: foreignId}, nil)
+	taskRepo.EXPECT().WithVersion(1).Return(taskRepo)
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, UserId: userId, Version: 1}, nil)
+	taskRepo.EXPECT().Delete(taskId).Return(errors.New("connection reset"))
+
+	results, err := syncService.Push(userId, []service.SyncChange{
+		{Op: service.SyncOperationDelete, Id: taskId},
+		{Op: service.SyncOperationDelete, Id: foreignId, BaseVersion: &baseVersion},
+		{Op: service.SyncOperationDelete, Id: taskId, BaseVersion: &baseVersion},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, service.ErrSyncBaseVersionRequired, results[0].Error)
+	require.Equal(t, service.ErrTaskNotFound, results[1].Error)
+	require.Equal(t, service.ErrSyncChangeFailed, results[2].Error)
+
+	for _, result := range results {
+		require.Equal(t, service.SyncStatusRejected, result.Status)
+	}
+}
+
+func TestSyncServicePush_DeleteAlreadyDeleted(t *testing.T) {
+	syncService, taskRepo := mockSyncService(t)
+
+	userId, taskId := twoUuids(t)
+
+	taskRepo.EXPECT().FindWithTrashedById(taskId).Return(&domain.Task{
+		ID: taskId, UserId: userId, DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true},
+	}, nil)
+
+	results, err := syncService.Push(userId, []service.SyncChange{{Op: service.SyncOperationDelete, Id: taskId}})
+
+	require.NoError(t, err)
+	require.Equal(t, service.SyncStatusApplied, results[0].Status)
+}
+
+func syncToken(position string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(position))
+}
+
+func mockSyncService(t *testing.T) (*service.SyncService, *mock_repository.MockTask) {
+	t.Helper()
+
+	syncService, taskRepo, taskTombstoneRepo := mockSyncServiceWithTombstones(t)
+
+	taskTombstoneRepo.EXPECT().GetChangedSince(gomock.Any(), gomock.Any(), gomock.Any()).Return(&[]domain.TaskTombstone{}, nil).AnyTimes()
+
+	return syncService, taskRepo
+}
+
+func mockSyncServiceWithTombstones(t *testing.T) (*service.SyncService, *mock_repository.MockTask, *mock_repository.MockTaskTombstone) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	transactor := mock_repository.NewMockTransactor(mockCtl)
+	taskRepo := mock_repository.NewMockTask(mockCtl)
+	taskTombstoneRepo := mock_repository.NewMockTaskTombstone(mockCtl)
+
+	repos := &repository.Repositories{
+		Transactor:     transactor,
+		Task:           taskRepo,
+		TaskDependency: mock_repository.NewMockTaskDependency(mockCtl),
+		Status:         mockStatusRepoWithDefaults(mockCtl),
+		TaskHistory:    mockTaskHistoryRepo(mockCtl),
+		OutboxEvent:    mockOutboxEventRepo(mockCtl),
+	}
+
+	transactor.EXPECT().Transaction(gomock.Any()).DoAndReturn(func(fn func(repos *repository.Repositories) error) error {
+		return fn(repos)
+	}).AnyTimes()
+
+	return service.NewSyncService(taskRepo, taskTombstoneRepo, transactor, false), taskRepo, taskTombstoneRepo
+}
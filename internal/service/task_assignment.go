@@ -0,0 +1,64 @@
+package service
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+)
+
+const ErrAssigneeNotMember = "assignee has no access to the task"
+
+type TaskAssignmentService struct {
+	transactor              repository.Transactor
+	forbidBlockedCompletion bool
+}
+
+func NewTaskAssignmentService(transactor repository.Transactor, forbidBlockedCompletion bool) *TaskAssignmentService {
+	return &TaskAssignmentService{transactor: transactor, forbidBlockedCompletion: forbidBlockedCompletion}
+}
+
+// Assign назначает задачу task пользователю assigneeId от имени actorId. Назначить можно только участника
+// списка задачи, задачу вне списков - только ее автору. Назначенный пользователь получает уведомление,
+// если назначил задачу не он сам. Задача изменяется, только если с момента чтения task ее версия не изменилась,
+// иначе возвращается ErrTaskVersionConflict: доступ назначаемого проверен для прочитанного состояния задачи.
+func (s *TaskAssignmentService) Assign(actorId uuid.UUID, task *domain.Task, assigneeId *uuid.UUID) (*domain.Task, error) {
+	var assignedTask *domain.Task
+
+	err := s.transactor.Transaction(func(repos *repository.Repositories) error {
+		if assigneeId != nil && taskRole(repos.List, repos.ListMember, task, *assigneeId) == "" {
+			return errors.New(ErrAssigneeNotMember)
+		}
+
+		if equalValues(uuidValue(task.AssigneeId), uuidValue(assigneeId)) {
+			assignedTask = task
+			return nil
+		}
+
+		taskService := NewTaskService(repos.Task.WithVersion(task.Version), repos.TaskDependency, repos.Status, repos.List, repos.ListMember, repos.TaskHistory, repos.OutboxEvent, nil, s.forbidBlockedCompletion).
+			withActor(actorId)
+
+		var err error
+
+		if assignedTask, err = taskService.Assign(task.ID, assigneeId); err != nil {
+			return err
+		}
+
+		if assigneeId == nil || *assigneeId == actorId {
+			return nil
+		}
+
+		return repos.Notification.Create(&domain.Notification{
+			UserId:  *assigneeId,
+			Type:    domain.NotificationTaskAssigned,
+			TaskId:  &task.ID,
+			ActorId: &actorId,
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return assignedTask, nil
+}
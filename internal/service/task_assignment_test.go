@@ -0,0 +1,166 @@
+package service_test
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	"testing"
+)
+
+type assignmentRepos struct {
+	task         *mock_repository.MockTask
+	list         *mock_repository.MockList
+	listMember   *mock_repository.MockListMember
+	taskHistory  *mock_repository.MockTaskHistory
+	notification *mock_repository.MockNotification
+}
+
+func TestTaskAssignmentServiceAssign_NotMember(t *testing.T) {
+	assignmentService, repos := mockTaskAssignmentService(t)
+
+	actorId, assigneeId := twoUuids(t)
+	taskId, listId := twoUuids(t)
+
+	repos.list.EXPECT().FindById(listId).Return(&domain.List{ID: listId, UserId: actorId}, nil)
+	repos.listMember.EXPECT().Find(listId, assigneeId).Return(nil, errors.New("not found"))
+
+	_, err := assignmentService.Assign(actorId, &domain.Task{ID: taskId, UserId: actorId, ListId: &listId}, &assigneeId)
+
+	require.EqualError(t, err, service.ErrAssigneeNotMember)
+}
+
+func TestTaskAssignmentServiceAssign_TaskWithoutList(t *testing.T) {
+	assignmentService, _ := mockTaskAssignmentService(t)
+
+	actorId, assigneeId := twoUuids(t)
+	taskId, _ := twoUuids(t)
+
+	_, err := assignmentService.Assign(actorId, &domain.Task{ID: taskId, UserId: actorId}, &assigneeId)
+
+	require.EqualError(t, err, service.ErrAssigneeNotMember)
+}
+
+func TestTaskAssignmentServiceAssign_SameAssignee(t *testing.T) {
+	assignmentService, _ := mockTaskAssignmentService(t)
+
+	actorId, taskId := twoUuids(t)
+	task := &domain.Task{ID: taskId, UserId: actorId, AssigneeId: &actorId}
+
+	assignedTask, err := assignmentService.Assign(actorId, task, &actorId)
+
+	require.NoError(t, err)
+	require.Equal(t, task, assignedTask)
+}
+
+func TestTaskAssignmentServiceAssign_NotifiesAssignee(t *testing.T) {
+	assignmentService, repos := mockTaskAssignmentService(t)
+
+	actorId, assigneeId := twoUuids(t)
+	taskId, listId := twoUuids(t)
+	task := &domain.Task{ID: taskId, UserId: actorId, ListId: &listId, Version: 3}
+
+	repos.list.EXPECT().FindById(listId).Return(&domain.List{ID: listId, UserId: actorId}, nil)
+	repos.listMember.EXPECT().Find(listId, assigneeId).Return(&domain.ListMember{Role: domain.ListRoleViewer}, nil)
+	repos.task.EXPECT().WithVersion(3).Return(repos.task)
+	repos.task.EXPECT().FindById(taskId).Return(task, nil)
+	repos.task.EXPECT().UpdateColumns(taskId, map[string]interface{}{"assignee_id": &assigneeId}).Return(nil)
+	repos.taskHistory.EXPECT().Create(gomock.Any()).DoAndReturn(func(entries []domain.TaskHistory) error {
+		require.Len(t, entries, 1)
+		require.Equal(t, domain.TaskFieldAssigneeId, entries[0].Field)
+		require.Equal(t, 4, entries[0].Revision)
+		require.Nil(t, entries[0].OldValue)
+		require.Equal(t, assigneeId.String(), *entries[0].NewValue)
+		require.Equal(t, actorId, *entries[0].ActorId)
+
+		return nil
+	})
+	repos.task.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, AssigneeId: &assigneeId}, nil)
+	repos.notification.EXPECT().Create(gomock.Any()).DoAndReturn(func(notification *domain.Notification) error {
+		require.Equal(t, assigneeId, notification.UserId)
+		require.Equal(t, domain.NotificationTaskAssigned, notification.Type)
+		require.Equal(t, taskId, *notification.TaskId)
+		require.Equal(t, actorId, *notification.ActorId)
+
+		return nil
+	})
+
+	assignedTask, err := assignmentService.Assign(actorId, task, &assigneeId)
+
+	require.NoError(t, err)
+	require.Equal(t, assigneeId, *assignedTask.AssigneeId)
+}
+
+func TestTaskAssignmentServiceAssign_Unassign(t *testing.T) {
+	assignmentService, repos := mockTaskAssignmentService(t)
+
+	actorId, assigneeId := twoUuids(t)
+	taskId, _ := twoUuids(t)
+	task := &domain.Task{ID: taskId, UserId: actorId, AssigneeId: &assigneeId}
+
+	repos.task.EXPECT().WithVersion(0).Return(repos.task)
+	repos.task.EXPECT().FindById(taskId).Return(task, nil)
+	repos.task.EXPECT().UpdateColumns(taskId, map[string]interface{}{"assignee_id": (*uuid.UUID)(nil)}).Return(nil)
+	repos.taskHistory.EXPECT().Create(gomock.Any()).Return(nil)
+	repos.task.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId}, nil)
+
+	assignedTask, err := assignmentService.Assign(actorId, task, nil)
+
+	require.NoError(t, err)
+	require.Nil(t, assignedTask.AssigneeId)
+}
+
+func TestTaskAssignmentServiceAssign_TaskModified(t *testing.T) {
+	assignmentService, repos := mockTaskAssignmentService(t)
+
+	actorId, assigneeId := twoUuids(t)
+	taskId, _ := twoUuids(t)
+	task := &domain.Task{ID: taskId, UserId: actorId, AssigneeId: &assigneeId, Version: 2}
+
+	repos.task.EXPECT().WithVersion(2).Return(repos.task)
+	repos.task.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, UserId: actorId, AssigneeId: &assigneeId, Version: 3}, nil)
+	repos.task.EXPECT().UpdateColumns(taskId, gomock.Any()).Return(repository.ErrTaskVersionConflict)
+
+	_, err := assignmentService.Assign(actorId, task, nil)
+
+	require.EqualError(t, err, service.ErrTaskVersionConflict)
+}
+
+func mockTaskAssignmentService(t *testing.T) (*service.TaskAssignmentService, assignmentRepos) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mocks := assignmentRepos{
+		task:         mock_repository.NewMockTask(mockCtl),
+		list:         mock_repository.NewMockList(mockCtl),
+		listMember:   mock_repository.NewMockListMember(mockCtl),
+		taskHistory:  mock_repository.NewMockTaskHistory(mockCtl),
+		notification: mock_repository.NewMockNotification(mockCtl),
+	}
+
+	transactor := mock_repository.NewMockTransactor(mockCtl)
+
+	repos := &repository.Repositories{
+		Transactor:     transactor,
+		Task:           mocks.task,
+		TaskDependency: mock_repository.NewMockTaskDependency(mockCtl),
+		List:           mocks.list,
+		ListMember:     mocks.listMember,
+		Status:         mockStatusRepoWithDefaults(mockCtl),
+		TaskHistory:    mocks.taskHistory,
+		OutboxEvent:    mockOutboxEventRepo(mockCtl),
+		Notification:   mocks.notification,
+	}
+
+	transactor.EXPECT().Transaction(gomock.Any()).DoAndReturn(func(fn func(repos *repository.Repositories) error) error {
+		return fn(repos)
+	}).AnyTimes()
+
+	return service.NewTaskAssignmentService(transactor, false), mocks
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+)
+
+// Операции, доступные для массовой обработки задач
+const (
+	BulkOperationComplete   = "complete"
+	BulkOperationIncomplete = "incomplete"
+	BulkOperationDelete     = "delete"
+	BulkOperationMove       = "move"
+	BulkOperationTag        = "tag"
+)
+
+const (
+	ErrTaskNotFound            = "task not found"
+	ErrBulkUnknownOperation    = "unknown operation"
+	ErrBulkTagsRequired        = "tags are required"
+	ErrBulkOperationFailed     = "operation failed"
+	ErrBulkOperationRolledBack = "rolled back because another operation failed"
+)
+
+// bulkErrors - ошибки операций, которые возвращаются клиенту как есть. Остальные заменяются ErrBulkOperationFailed.
+var bulkErrors = map[string]bool{
+	ErrTaskNotFound:         true,
+	ErrTaskIsBlocked:        true,
+	ErrListNotFound:         true,
+	ErrBulkUnknownOperation: true,
+	ErrBulkTagsRequired:     true,
+}
+
+var errBulkAborted = errors.New("bulk operations aborted")
+
+type BulkOperation struct {
+	Op     string
+	TaskId uuid.UUID
+	ListId *uuid.UUID
+	Tags   []string
+}
+
+// BulkResult - результат отдельной операции, пустой Error означает успех
+type BulkResult struct {
+	Op     string
+	TaskId uuid.UUID
+	Error  string
+}
+
+type TaskBulkService struct {
+	transactor              repository.Transactor
+	forbidBlockedCompletion bool
+}
+
+func NewTaskBulkService(transactor repository.Transactor, forbidBlockedCompletion bool) *TaskBulkService {
+	return &TaskBulkService{transactor: transactor, forbidBlockedCompletion: forbidBlockedCompletion}
+}
+
+// Execute выполняет операции пользователя userId по порядку в одной транзакции. Каждая операция выполняется
+// в собственной точке сохранения: ошибка откатывает только ее. При atomic ошибка любой операции откатывает все.
+func (s *TaskBulkService) Execute(userId uuid.UUID, operations []BulkOperation, atomic bool) ([]BulkResult, error) {
+	results := make([]BulkResult, len(operations))
+
+	err := s.transactor.Transaction(func(repos *repository.Repositories) error {
+		editable := editableTaskIds(repos, userId, operations)
+		failed := false
+
+		for i, operation := range operations {
+			results[i] = BulkResult{Op: operation.Op, TaskId: operation.TaskId}
+
+			if !editable[operation.TaskId] {
+				results[i].Error, failed = ErrTaskNotFound, true
+				continue
+			}
+
+			err := repos.Transactor.Transaction(func(itemRepos *repository.Repositories) error {
+				return s.apply(itemRepos, userId, operation)
+			})
+
+			if err != nil {
+				results[i].Error, failed = bulkErrorMessage(err), true
+			}
+		}
+
+		if atomic && failed {
+			return errBulkAborted
+		}
+
+		return nil
+	})
+
+	if errors.Is(err, errBulkAborted) {
+		for i := range results {
+			if results[i].Error == "" {
+				results[i].Error = ErrBulkOperationRolledBack
+			}
+		}
+
+		return results, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (s *TaskBulkService) apply(repos *repository.Repositories, userId uuid.UUID, operation BulkOperation) error {
+	if _, err := repos.Task.FindById(operation.TaskId); err != nil {
+		return errors.New(ErrTaskNotFound)
+	}
+
+	taskService := NewTaskService(repos.Task, repos.TaskDependency, repos.Status, repos.List, repos.ListMember, repos.TaskHistory, repos.OutboxEvent, nil, s.forbidBlockedCompletion).
+		withActor(userId)
+
+	switch operation.Op {
+	case BulkOperationComplete, BulkOperationIncomplete:
+		_, err := taskService.UpdateIsCompleted(operation.TaskId, operation.Op == BulkOperationComplete)
+		return err
+	case BulkOperationDelete:
+		return taskService.Delete(operation.TaskId)
+	case BulkOperationMove:
+		if operation.ListId != nil {
+			list, err := repos.List.FindById(*operation.ListId)
+
+			if err != nil || !domain.ListRoleAllows(listRole(repos.ListMember, list, userId), domain.ListRoleEditor) {
+				return errors.New(ErrListNotFound)
+			}
+		}
+
+		_, err := taskService.Move(operation.TaskId, operation.ListId)
+		return err
+	case BulkOperationTag:
+		tags := normalizeTags(operation.Tags)
+
+		if len(tags) == 0 {
+			return errors.New(ErrBulkTagsRequired)
+		}
+
+		return repos.TaskTag.Add(operation.TaskId, tags)
+	}
+
+	return errors.New(ErrBulkUnknownOperation)
+}
+
+// editableTaskIds возвращает множество идентификаторов задач из операций, которые пользователь userId может изменять
+func editableTaskIds(repos *repository.Repositories, userId uuid.UUID, operations []BulkOperation) map[uuid.UUID]bool {
+	ids := make([]uuid.UUID, 0, len(operations))
+
+	for _, operation := range operations {
+		ids = append(ids, operation.TaskId)
+	}
+
+	editable := make(map[uuid.UUID]bool, len(ids))
+
+	for _, task := range *repos.Task.GetAllByIds(ids) {
+		editable[task.ID] = domain.ListRoleAllows(taskRole(repos.List, repos.ListMember, &task, userId), domain.ListRoleEditor)
+	}
+
+	return editable
+}
+
+func bulkErrorMessage(err error) string {
+	if bulkErrors[err.Error()] {
+		return err.Error()
+	}
+
+	return ErrBulkOperationFailed
+}
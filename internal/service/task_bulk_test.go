@@ -0,0 +1,170 @@
+package service_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	"testing"
+)
+
+type bulkRepos struct {
+	transactor *mock_repository.MockTransactor
+	task       *mock_repository.MockTask
+	taskTag    *mock_repository.MockTaskTag
+	list       *mock_repository.MockList
+	listMember *mock_repository.MockListMember
+}
+
+func TestTaskBulkServiceExecute_PartialFailure(t *testing.T) {
+	bulkService, repos := mockTaskBulkService(t)
+
+	userId, taskId := twoUuids(t)
+	foreignTaskId, _ := twoUuids(t)
+
+	repos.task.EXPECT().GetAllByIds(gomock.Any()).Return(&[]domain.Task{
+		{ID: taskId, UserId: userId},
+		{ID: foreignTaskId},
+	})
+	repos.task.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, UserId: userId}, nil).Times(3)
+	repos.task.EXPECT().Update(gomock.Any()).Return(&domain.Task{ID: taskId}, nil)
+
+	results, err := bulkService.Execute(userId, []service.BulkOperation{
+		{Op: service.BulkOperationComplete, TaskId: taskId},
+		{Op: service.BulkOperationDelete, TaskId: foreignTaskId},
+		{Op: service.BulkOperationTag, TaskId: taskId, Tags: []string{" "}},
+	}, false)
+
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	require.Empty(t, results[0].Error)
+	require.Equal(t, service.ErrTaskNotFound, results[1].Error)
+	require.Equal(t, service.ErrBulkTagsRequired, results[2].Error)
+}
+
+func TestTaskBulkServiceExecute_AtomicRollback(t *testing.T) {
+	bulkService, repos := mockTaskBulkService(t)
+
+	userId, taskId := twoUuids(t)
+	missingTaskId, _ := twoUuids(t)
+
+	repos.task.EXPECT().GetAllByIds(gomock.Any()).Return(&[]domain.Task{{ID: taskId, UserId: userId}})
+	repos.task.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, UserId: userId}, nil)
+	repos.taskTag.EXPECT().Add(taskId, []string{"home"}).Return(nil)
+
+	results, err := bulkService.Execute(userId, []service.BulkOperation{
+		{Op: service.BulkOperationTag, TaskId: taskId, Tags: []string{"Home"}},
+		{Op: service.BulkOperationComplete, TaskId: missingTaskId},
+	}, true)
+
+	require.NoError(t, err)
+	require.Equal(t, service.ErrBulkOperationRolledBack, results[0].Error)
+	require.Equal(t, service.ErrTaskNotFound, results[1].Error)
+}
+
+func TestTaskBulkServiceExecute_MoveToForeignList(t *testing.T) {
+	bulkService, repos := mockTaskBulkService(t)
+
+	userId, taskId := twoUuids(t)
+	listId, _ := twoUuids(t)
+
+	repos.task.EXPECT().GetAllByIds(gomock.Any()).Return(&[]domain.Task{{ID: taskId, UserId: userId}})
+	repos.task.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, UserId: userId}, nil)
+	repos.list.EXPECT().FindById(listId).Return(&domain.List{ID: listId}, nil)
+	repos.listMember.EXPECT().Find(listId, userId).Return(nil, errors.New("not found"))
+
+	results, err := bulkService.Execute(userId, []service.BulkOperation{
+		{Op: service.BulkOperationMove, TaskId: taskId, ListId: &listId},
+	}, false)
+
+	require.NoError(t, err)
+	require.Equal(t, service.ErrListNotFound, results[0].Error)
+}
+
+func TestTaskBulkServiceExecute_SharedListViewer(t *testing.T) {
+	bulkService, repos := mockTaskBulkService(t)
+
+	userId, taskId := twoUuids(t)
+	listId, ownerId := twoUuids(t)
+
+	repos.task.EXPECT().GetAllByIds(gomock.Any()).Return(&[]domain.Task{{ID: taskId, UserId: ownerId, ListId: &listId}})
+	repos.list.EXPECT().FindById(listId).Return(&domain.List{ID: listId, UserId: ownerId}, nil)
+	repos.listMember.EXPECT().Find(listId, userId).Return(&domain.ListMember{Role: domain.ListRoleViewer}, nil)
+
+	results, err := bulkService.Execute(userId, []service.BulkOperation{
+		{Op: service.BulkOperationComplete, TaskId: taskId},
+	}, false)
+
+	require.NoError(t, err)
+	require.Equal(t, service.ErrTaskNotFound, results[0].Error)
+}
+
+func TestTaskBulkServiceExecute_UnknownErrorHidden(t *testing.T) {
+	bulkService, repos := mockTaskBulkService(t)
+
+	userId, taskId := twoUuids(t)
+
+	repos.task.EXPECT().GetAllByIds(gomock.Any()).Return(&[]domain.Task{{ID: taskId, UserId: userId}})
+	repos.task.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, UserId: userId}, nil).Times(2)
+	repos.task.EXPECT().Delete(taskId).Return(errors.New("connection reset"))
+
+	results, err := bulkService.Execute(userId, []service.BulkOperation{
+		{Op: service.BulkOperationDelete, TaskId: taskId},
+	}, false)
+
+	require.NoError(t, err)
+	require.Equal(t, service.ErrBulkOperationFailed, results[0].Error)
+}
+
+func TestTaskBulkServiceExecute_TransactionFailed(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	transactor := mock_repository.NewMockTransactor(mockCtl)
+	transactor.EXPECT().Transaction(gomock.Any()).Return(errors.New("failed"))
+
+	userId, taskId := twoUuids(t)
+
+	results, err := service.NewTaskBulkService(transactor, false).Execute(userId, []service.BulkOperation{
+		{Op: service.BulkOperationDelete, TaskId: taskId},
+	}, false)
+
+	require.Error(t, err)
+	require.Nil(t, results)
+}
+
+func mockTaskBulkService(t *testing.T) (*service.TaskBulkService, bulkRepos) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mocks := bulkRepos{
+		transactor: mock_repository.NewMockTransactor(mockCtl),
+		task:       mock_repository.NewMockTask(mockCtl),
+		taskTag:    mock_repository.NewMockTaskTag(mockCtl),
+		list:       mock_repository.NewMockList(mockCtl),
+		listMember: mock_repository.NewMockListMember(mockCtl),
+	}
+
+	repos := &repository.Repositories{
+		Transactor:     mocks.transactor,
+		Task:           mocks.task,
+		TaskDependency: mock_repository.NewMockTaskDependency(mockCtl),
+		TaskTag:        mocks.taskTag,
+		List:           mocks.list,
+		ListMember:     mocks.listMember,
+		Status:         mockStatusRepoWithDefaults(mockCtl),
+		TaskHistory:    mockTaskHistoryRepo(mockCtl),
+		OutboxEvent:    mockOutboxEventRepo(mockCtl),
+	}
+
+	mocks.transactor.EXPECT().Transaction(gomock.Any()).DoAndReturn(func(fn func(repos *repository.Repositories) error) error {
+		return fn(repos)
+	}).AnyTimes()
+
+	return service.NewTaskBulkService(mocks.transactor, false), mocks
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+)
+
+const (
+	ErrTaskDependencySelf     = "task can't be blocked by itself"
+	ErrTaskDependencyExists   = "dependency already exists"
+	ErrTaskDependencyNotFound = "dependency not found"
+	ErrTaskDependencyCycle    = "dependency creates a cycle"
+	ErrBlockingTaskNotFound   = "blocking task not found"
+	ErrBlockingTaskOtherList  = "blocking task must be in the same list"
+)
+
+type TaskDependencyService struct {
+	taskDependencyRepo repository.TaskDependency
+	taskRepo           repository.Task
+	listRepo           repository.List
+	listMemberRepo     repository.ListMember
+	transactor         repository.Transactor
+}
+
+func NewTaskDependencyService(
+	taskDependencyRepo repository.TaskDependency,
+	taskRepo repository.Task,
+	listRepo repository.List,
+	listMemberRepo repository.ListMember,
+	transactor repository.Transactor,
+) *TaskDependencyService {
+	return &TaskDependencyService{
+		taskDependencyRepo: taskDependencyRepo,
+		taskRepo:           taskRepo,
+		listRepo:           listRepo,
+		listMemberRepo:     listMemberRepo,
+		transactor:         transactor,
+	}
+}
+
+// Link отмечает задачу taskId как заблокированную задачей blockedById по действию пользователя actorId.
+// Блокирующая задача должна быть доступна actorId и находиться в том же списке, что и задача, либо обе задачи
+// должны быть вне списков и принадлежать одному пользователю. Новая связь не должна образовывать цикл: проверка
+// и создание связи выполняются в транзакции под блокировкой, чтобы встречные связи не создали цикл одновременно.
+func (s *TaskDependencyService) Link(actorId, taskId, blockedById uuid.UUID) error {
+	if taskId == blockedById {
+		return errors.New(ErrTaskDependencySelf)
+	}
+
+	task, err := s.taskRepo.FindById(taskId)
+
+	if err != nil {
+		return err
+	}
+
+	blockingTask, err := s.taskRepo.FindById(blockedById)
+
+	if err != nil || taskRole(s.listRepo, s.listMemberRepo, blockingTask, actorId) == "" {
+		return errors.New(ErrBlockingTaskNotFound)
+	}
+
+	if !isSameList(task, blockingTask) && !isSameOwnerOutsideLists(task, blockingTask) {
+		return errors.New(ErrBlockingTaskOtherList)
+	}
+
+	return s.transactor.Transaction(func(repos *repository.Repositories) error {
+		if err := repos.TaskDependency.Lock(); err != nil {
+			return err
+		}
+
+		if repos.TaskDependency.IsExists(taskId, blockedById) {
+			return errors.New(ErrTaskDependencyExists)
+		}
+
+		if repos.TaskDependency.IsReachable(blockedById, taskId) {
+			return errors.New(ErrTaskDependencyCycle)
+		}
+
+		return repos.TaskDependency.Create(&domain.TaskDependency{TaskId: taskId, BlockedById: blockedById})
+	})
+}
+
+func (s *TaskDependencyService) Unlink(taskId, blockedById uuid.UUID) error {
+	if !s.taskDependencyRepo.IsExists(taskId, blockedById) {
+		return errors.New(ErrTaskDependencyNotFound)
+	}
+
+	return s.taskDependencyRepo.Delete(taskId, blockedById)
+}
+
+func (s *TaskDependencyService) GetBlockers(taskId uuid.UUID) *[]domain.Task {
+	return s.taskDependencyRepo.GetBlockersByTaskId(taskId)
+}
+
+// isSameList сообщает, находятся ли обе задачи в одном списке
+func isSameList(task, other *domain.Task) bool {
+	return task.ListId != nil && other.ListId != nil && *task.ListId == *other.ListId
+}
+
+// isSameOwnerOutsideLists сообщает, находятся ли обе задачи вне списков и принадлежат ли одному пользователю
+func isSameOwnerOutsideLists(task, other *domain.Task) bool {
+	return task.ListId == nil && other.ListId == nil && task.UserId == other.UserId
+}
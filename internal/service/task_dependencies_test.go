@@ -0,0 +1,231 @@
+package service_test
+
+import (
+	"errors"
+	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	"testing"
+)
+
+func TestTaskDependencyServiceLink_Self(t *testing.T) {
+	dependencyService, _ := mockTaskDependencyService(t)
+
+	taskId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	err = dependencyService.Link(uuid.New(), taskId, taskId)
+
+	require.EqualError(t, err, service.ErrTaskDependencySelf)
+}
+
+func TestTaskDependencyServiceLink_BlockingTaskNotFound(t *testing.T) {
+	dependencyService, mocks := mockTaskDependencyService(t)
+
+	taskId, blockedById := twoUuids(t)
+
+	mocks.taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId}, nil)
+	mocks.taskRepo.EXPECT().FindById(blockedById).Return(nil, errors.New("failed"))
+
+	err := dependencyService.Link(uuid.New(), taskId, blockedById)
+
+	require.EqualError(t, err, service.ErrBlockingTaskNotFound)
+}
+
+func TestTaskDependencyServiceLink_AnotherUser(t *testing.T) {
+	dependencyService, mocks := mockTaskDependencyService(t)
+
+	taskId, blockedById := twoUuids(t)
+	userId, anotherUserId := twoUuids(t)
+
+	mocks.taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, UserId: userId}, nil)
+	mocks.taskRepo.EXPECT().FindById(blockedById).Return(&domain.Task{ID: blockedById, UserId: anotherUserId}, nil)
+
+	err := dependencyService.Link(userId, taskId, blockedById)
+
+	require.EqualError(t, err, service.ErrBlockingTaskNotFound)
+}
+
+func TestTaskDependencyServiceLink_BlockingTaskNotAccessible(t *testing.T) {
+	dependencyService, mocks := mockTaskDependencyService(t)
+
+	taskId, blockedById := twoUuids(t)
+	ownerId, editorId := twoUuids(t)
+	listId := uuid.New()
+
+	// редактор общего списка не видит личную задачу владельца списка
+	mocks.taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, UserId: ownerId, ListId: &listId}, nil)
+	mocks.taskRepo.EXPECT().FindById(blockedById).Return(&domain.Task{ID: blockedById, UserId: ownerId}, nil)
+
+	err := dependencyService.Link(editorId, taskId, blockedById)
+
+	require.EqualError(t, err, service.ErrBlockingTaskNotFound)
+}
+
+func TestTaskDependencyServiceLink_OtherList(t *testing.T) {
+	dependencyService, mocks := mockTaskDependencyService(t)
+
+	taskId, blockedById := twoUuids(t)
+	userId := uuid.New()
+	listId := uuid.New()
+
+	mocks.taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, UserId: userId, ListId: &listId}, nil)
+	mocks.taskRepo.EXPECT().FindById(blockedById).Return(&domain.Task{ID: blockedById, UserId: userId}, nil)
+
+	err := dependencyService.Link(userId, taskId, blockedById)
+
+	require.EqualError(t, err, service.ErrBlockingTaskOtherList)
+}
+
+func TestTaskDependencyServiceLink_AnotherUserInSameList(t *testing.T) {
+	dependencyService, mocks := mockTaskDependencyService(t)
+
+	taskId, blockedById := twoUuids(t)
+	userId, anotherUserId := twoUuids(t)
+	listId := uuid.New()
+
+	mocks.taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, UserId: userId, ListId: &listId}, nil)
+	mocks.taskRepo.EXPECT().FindById(blockedById).Return(&domain.Task{ID: blockedById, UserId: anotherUserId, ListId: &listId}, nil)
+	mocks.listRepo.EXPECT().FindById(listId).Return(&domain.List{ID: listId, UserId: anotherUserId}, nil)
+	mocks.listMemberRepo.EXPECT().Find(listId, userId).Return(&domain.ListMember{Role: domain.ListRoleEditor}, nil)
+	mocks.dependencyRepo.EXPECT().Lock().Return(nil)
+	mocks.dependencyRepo.EXPECT().IsExists(taskId, blockedById).Return(false)
+	mocks.dependencyRepo.EXPECT().IsReachable(blockedById, taskId).Return(false)
+	mocks.dependencyRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	require.NoError(t, dependencyService.Link(userId, taskId, blockedById))
+}
+
+func TestTaskDependencyServiceLink_Exists(t *testing.T) {
+	dependencyService, mocks := mockTaskDependencyService(t)
+
+	taskId, blockedById := twoUuids(t)
+	userId := uuid.New()
+
+	mocks.taskRepo.EXPECT().FindById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil).Times(2)
+	mocks.dependencyRepo.EXPECT().Lock().Return(nil)
+	mocks.dependencyRepo.EXPECT().IsExists(taskId, blockedById).Return(true)
+
+	err := dependencyService.Link(userId, taskId, blockedById)
+
+	require.EqualError(t, err, service.ErrTaskDependencyExists)
+}
+
+func TestTaskDependencyServiceLink_Cycle(t *testing.T) {
+	dependencyService, mocks := mockTaskDependencyService(t)
+
+	taskId, blockedById := twoUuids(t)
+	userId := uuid.New()
+
+	mocks.taskRepo.EXPECT().FindById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil).Times(2)
+	mocks.dependencyRepo.EXPECT().Lock().Return(nil)
+	mocks.dependencyRepo.EXPECT().IsExists(taskId, blockedById).Return(false)
+	mocks.dependencyRepo.EXPECT().IsReachable(blockedById, taskId).Return(true)
+
+	err := dependencyService.Link(userId, taskId, blockedById)
+
+	require.EqualError(t, err, service.ErrTaskDependencyCycle)
+}
+
+func TestTaskDependencyServiceLink_Success(t *testing.T) {
+	dependencyService, mocks := mockTaskDependencyService(t)
+
+	taskId, blockedById := twoUuids(t)
+	userId := uuid.New()
+
+	mocks.taskRepo.EXPECT().FindById(gomock.Any()).Return(&domain.Task{UserId: userId}, nil).Times(2)
+	gomock.InOrder(
+		mocks.dependencyRepo.EXPECT().Lock().Return(nil),
+		mocks.dependencyRepo.EXPECT().IsExists(taskId, blockedById).Return(false),
+		mocks.dependencyRepo.EXPECT().IsReachable(blockedById, taskId).Return(false),
+		mocks.dependencyRepo.EXPECT().Create(&domain.TaskDependency{TaskId: taskId, BlockedById: blockedById}).Return(nil),
+	)
+
+	err := dependencyService.Link(userId, taskId, blockedById)
+
+	require.NoError(t, err)
+}
+
+func TestTaskDependencyServiceUnlink_NotFound(t *testing.T) {
+	dependencyService, mocks := mockTaskDependencyService(t)
+
+	taskId, blockedById := twoUuids(t)
+
+	mocks.dependencyRepo.EXPECT().IsExists(taskId, blockedById).Return(false)
+
+	err := dependencyService.Unlink(taskId, blockedById)
+
+	require.EqualError(t, err, service.ErrTaskDependencyNotFound)
+}
+
+func TestTaskDependencyServiceUnlink_Success(t *testing.T) {
+	dependencyService, mocks := mockTaskDependencyService(t)
+
+	taskId, blockedById := twoUuids(t)
+
+	mocks.dependencyRepo.EXPECT().IsExists(taskId, blockedById).Return(true)
+	mocks.dependencyRepo.EXPECT().Delete(taskId, blockedById).Return(nil)
+
+	err := dependencyService.Unlink(taskId, blockedById)
+
+	require.NoError(t, err)
+}
+
+func TestTaskDependencyServiceGetBlockers_Success(t *testing.T) {
+	dependencyService, mocks := mockTaskDependencyService(t)
+
+	taskId, _ := twoUuids(t)
+
+	mocks.dependencyRepo.EXPECT().GetBlockersByTaskId(taskId).Return(&[]domain.Task{{}})
+
+	blockers := dependencyService.GetBlockers(taskId)
+
+	require.NotEmpty(t, blockers)
+}
+
+func twoUuids(t *testing.T) (uuid.UUID, uuid.UUID) {
+	t.Helper()
+
+	first, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	second, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	return first, second
+}
+
+type taskDependencyMocks struct {
+	dependencyRepo *mock_repository.MockTaskDependency
+	taskRepo       *mock_repository.MockTask
+	listRepo       *mock_repository.MockList
+	listMemberRepo *mock_repository.MockListMember
+}
+
+func mockTaskDependencyService(t *testing.T) (*service.TaskDependencyService, taskDependencyMocks) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mocks := taskDependencyMocks{
+		dependencyRepo: mock_repository.NewMockTaskDependency(mockCtl),
+		taskRepo:       mock_repository.NewMockTask(mockCtl),
+		listRepo:       mock_repository.NewMockList(mockCtl),
+		listMemberRepo: mock_repository.NewMockListMember(mockCtl),
+	}
+
+	transactor := mock_repository.NewMockTransactor(mockCtl)
+	transactor.EXPECT().Transaction(gomock.Any()).DoAndReturn(func(fn func(repos *repository.Repositories) error) error {
+		return fn(&repository.Repositories{TaskDependency: mocks.dependencyRepo})
+	}).AnyTimes()
+
+	dependencyService := service.NewTaskDependencyService(mocks.dependencyRepo, mocks.taskRepo, mocks.listRepo, mocks.listMemberRepo, transactor)
+
+	return dependencyService, mocks
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"regexp"
+	"strings"
+)
+
+const (
+	ErrEmptySearchQuery = "search query must contain at least one word"
+
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+)
+
+// searchLanguages сопоставляет допустимые в конфигурации языки с конфигурациями текстового поиска PostgreSQL
+var searchLanguages = map[string]string{
+	"ru":      "russian",
+	"russian": "russian",
+	"en":      "english",
+	"english": "english",
+}
+
+var searchWord = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+type TaskSearchService struct {
+	taskRepo repository.Task
+	language string
+}
+
+// NewTaskSearchService создает сервис поиска. Неизвестный язык заменяется русским.
+func NewTaskSearchService(taskRepo repository.Task, language string) *TaskSearchService {
+	config, ok := searchLanguages[strings.ToLower(language)]
+
+	if !ok {
+		config = searchLanguages["russian"]
+	}
+
+	return &TaskSearchService{taskRepo: taskRepo, language: config}
+}
+
+// Search ищет задачи пользователя по словам запроса. Каждое слово ищется по префиксу, все слова должны присутствовать.
+func (s *TaskSearchService) Search(userId uuid.UUID, query string, limit int) (*[]domain.TaskSearchResult, error) {
+	tsquery := prefixTsQuery(query)
+
+	if tsquery == "" {
+		return nil, errors.New(ErrEmptySearchQuery)
+	}
+
+	if limit <= 0 || limit > MaxSearchLimit {
+		limit = DefaultSearchLimit
+	}
+
+	return s.taskRepo.Search(userId, tsquery, s.language, limit)
+}
+
+// prefixTsQuery преобразует пользовательский запрос в tsquery вида "слово:* & слово:*",
+// отбрасывая символы, имеющие специальное значение в синтаксисе tsquery
+func prefixTsQuery(query string) string {
+	words := searchWord.FindAllString(query, -1)
+
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+
+	return strings.Join(words, " & ")
+}
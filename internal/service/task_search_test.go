@@ -0,0 +1,74 @@
+package service_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"poymanov/todo/internal/domain"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	"testing"
+)
+
+func TestTaskSearchServiceSearch_PrefixQuery(t *testing.T) {
+	testCases := []struct {
+		name     string
+		language string
+		query    string
+		tsquery  string
+		config   string
+	}{
+		{"Single word", "russian", "молоко", "молоко:*", "russian"},
+		{"Several words", "en", "buy  Milk!", "buy:* & Milk:*", "english"},
+		{"Operators are dropped", "english", "milk & !(bread | 'eggs'):*", "milk:* & bread:* & eggs:*", "english"},
+		{"Unknown language", "klingon", "milk", "milk:*", "russian"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			taskSearchService, taskRepo := mockTaskSearchService(t, tc.language)
+
+			userId := uuid.New()
+
+			taskRepo.EXPECT().Search(userId, tc.tsquery, tc.config, service.DefaultSearchLimit).
+				Return(&[]domain.TaskSearchResult{}, nil)
+
+			results, err := taskSearchService.Search(userId, tc.query, 0)
+
+			require.NoError(t, err)
+			require.Empty(t, *results)
+		})
+	}
+}
+
+func TestTaskSearchServiceSearch_EmptyQuery(t *testing.T) {
+	taskSearchService, _ := mockTaskSearchService(t, "russian")
+
+	results, err := taskSearchService.Search(uuid.New(), " & !", 10)
+
+	require.Nil(t, results)
+	require.EqualError(t, err, service.ErrEmptySearchQuery)
+}
+
+func TestTaskSearchServiceSearch_Limit(t *testing.T) {
+	taskSearchService, taskRepo := mockTaskSearchService(t, "russian")
+
+	taskRepo.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any(), 5).Return(&[]domain.TaskSearchResult{{}}, nil)
+
+	results, err := taskSearchService.Search(uuid.New(), "milk", 5)
+
+	require.NoError(t, err)
+	require.Len(t, *results, 1)
+}
+
+func mockTaskSearchService(t *testing.T, language string) (*service.TaskSearchService, *mock_repository.MockTask) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	taskRepo := mock_repository.NewMockTask(mockCtl)
+	taskSearchService := service.NewTaskSearchService(taskRepo, language)
+
+	return taskSearchService, taskRepo
+}
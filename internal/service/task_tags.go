@@ -0,0 +1,56 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"poymanov/todo/internal/repository"
+	"sort"
+	"strings"
+)
+
+type TaskTagService struct {
+	taskTagRepo repository.TaskTag
+}
+
+func NewTaskTagService(taskTagRepo repository.TaskTag) *TaskTagService {
+	return &TaskTagService{taskTagRepo: taskTagRepo}
+}
+
+func (s *TaskTagService) GetByTaskId(taskId uuid.UUID) []string {
+	return s.taskTagRepo.GetByTaskId(taskId)
+}
+
+// Set заменяет теги задачи. Теги приводятся к нижнему регистру, пустые и повторяющиеся отбрасываются.
+func (s *TaskTagService) Set(taskId uuid.UUID, names []string) ([]string, error) {
+	tags := normalizeTags(names)
+
+	if err := s.taskTagRepo.Replace(taskId, tags); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// Add добавляет задаче теги к уже назначенным
+func (s *TaskTagService) Add(taskId uuid.UUID, names []string) error {
+	return s.taskTagRepo.Add(taskId, normalizeTags(names))
+}
+
+func normalizeTags(names []string) []string {
+	unique := make(map[string]struct{}, len(names))
+	tags := make([]string, 0, len(names))
+
+	for _, name := range names {
+		tag := strings.ToLower(strings.TrimSpace(name))
+
+		if _, ok := unique[tag]; ok || tag == "" {
+			continue
+		}
+
+		unique[tag] = struct{}{}
+		tags = append(tags, tag)
+	}
+
+	sort.Strings(tags)
+
+	return tags
+}
@@ -0,0 +1,68 @@
+package service_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	"testing"
+)
+
+func TestTaskTagServiceSet_Normalized(t *testing.T) {
+	taskTagService, taskTagRepo := mockTaskTagService(t)
+
+	taskId, _ := twoUuids(t)
+
+	taskTagRepo.EXPECT().Replace(taskId, []string{"home", "urgent"}).Return(nil)
+
+	tags, err := taskTagService.Set(taskId, []string{" Urgent", "home", "URGENT", ""})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"home", "urgent"}, tags)
+}
+
+func TestTaskTagServiceSet_Failed(t *testing.T) {
+	taskTagService, taskTagRepo := mockTaskTagService(t)
+
+	taskId, _ := twoUuids(t)
+
+	taskTagRepo.EXPECT().Replace(gomock.Any(), gomock.Any()).Return(errors.New("failed"))
+
+	tags, err := taskTagService.Set(taskId, []string{"home"})
+
+	require.Error(t, err)
+	require.Nil(t, tags)
+}
+
+func TestTaskTagServiceGetByTaskId_Success(t *testing.T) {
+	taskTagService, taskTagRepo := mockTaskTagService(t)
+
+	taskId, _ := twoUuids(t)
+
+	taskTagRepo.EXPECT().GetByTaskId(taskId).Return([]string{"home"})
+
+	require.Equal(t, []string{"home"}, taskTagService.GetByTaskId(taskId))
+}
+
+func mockTaskTagService(t *testing.T) (*service.TaskTagService, *mock_repository.MockTaskTag) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	taskTagRepo := mock_repository.NewMockTaskTag(mockCtl)
+	taskTagService := service.NewTaskTagService(taskTagRepo)
+
+	return taskTagService, taskTagRepo
+}
+
+func TestTaskTagServiceAdd_Normalized(t *testing.T) {
+	taskTagService, taskTagRepo := mockTaskTagService(t)
+
+	taskId, _ := twoUuids(t)
+
+	taskTagRepo.EXPECT().Add(taskId, []string{"home"}).Return(nil)
+
+	require.NoError(t, taskTagService.Add(taskId, []string{"Home ", "home"}))
+}
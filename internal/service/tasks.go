@@ -1,67 +1,1051 @@
 package service
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"github.com/google/uuid"
 	"poymanov/todo/internal/domain"
 	"poymanov/todo/internal/repository"
+	"strconv"
+	"strings"
+	"time"
 )
 
+const (
+	ErrTaskIsBlocked       = "task is blocked by uncompleted tasks"
+	ErrTaskIsNotTrashed    = "task is not in trash"
+	ErrTaskIsNotArchived   = "task is not archived"
+	ErrInvalidTaskCursor   = "invalid cursor"
+	ErrInvalidTaskSort     = "invalid sort"
+	ErrRevisionNotFound    = "revision not found"
+	ErrTaskVersionConflict = "task has been modified"
+)
+
+const (
+	DefaultTasksPageLimit = 50
+	MaxTasksPageLimit     = 200
+)
+
+// taskCursor - содержимое курсора, передаваемого клиенту. Сортировка сохраняется в курсоре,
+// чтобы курсор не применялся к выборке с другим порядком.
+type taskCursor struct {
+	Sort  string    `json:"s"`
+	Value string    `json:"v"`
+	Id    uuid.UUID `json:"id"`
+}
+
 type TaskService struct {
-	taskRepo repository.Task
+	taskRepo                repository.Task
+	taskDependencyRepo      repository.TaskDependency
+	statusRepo              repository.Status
+	listRepo                repository.List
+	listMemberRepo          repository.ListMember
+	taskHistoryRepo         repository.TaskHistory
+	outboxEventRepo         repository.OutboxEvent
+	transactor              repository.Transactor
+	forbidBlockedCompletion bool
+	actorId                 *uuid.UUID
+	version                 *int
 }
 
-func NewTaskService(taskRepo repository.Task) *TaskService {
-	return &TaskService{taskRepo: taskRepo}
+// NewTaskService создает сервис задач. Изменение задачи, запись истории и доменные события сохраняются
+// в транзакции transactor; сервису, созданному внутри транзакции, передается nil.
+func NewTaskService(
+	taskRepo repository.Task,
+	taskDependencyRepo repository.TaskDependency,
+	statusRepo repository.Status,
+	listRepo repository.List,
+	listMemberRepo repository.ListMember,
+	taskHistoryRepo repository.TaskHistory,
+	outboxEventRepo repository.OutboxEvent,
+	transactor repository.Transactor,
+	forbidBlockedCompletion bool,
+) *TaskService {
+	return &TaskService{
+		taskRepo:                taskRepo,
+		taskDependencyRepo:      taskDependencyRepo,
+		statusRepo:              statusRepo,
+		listRepo:                listRepo,
+		listMemberRepo:          listMemberRepo,
+		taskHistoryRepo:         taskHistoryRepo,
+		outboxEventRepo:         outboxEventRepo,
+		transactor:              transactor,
+		forbidBlockedCompletion: forbidBlockedCompletion,
+	}
 }
 
-func (s *TaskService) Create(description string, userId uuid.UUID) (*domain.Task, error) {
-	createdTask, err := s.taskRepo.Create(&domain.Task{Description: description, UserId: userId})
+// WithActor возвращает сервис, записывающий изменения задач в историю от имени пользователя actorId
+func (s *TaskService) WithActor(actorId uuid.UUID) Task {
+	return s.withActor(actorId)
+}
 
-	if err != nil {
-		return nil, err
+func (s *TaskService) withActor(actorId uuid.UUID) *TaskService {
+	actor := *s
+	actor.actorId = &actorId
+
+	return &actor
+}
+
+// WithVersion возвращает сервис, изменяющий задачу, только если ее версия в момент записи равна version.
+// Если задачу успели изменить, операция завершается ошибкой ErrTaskVersionConflict.
+func (s *TaskService) WithVersion(version int) Task {
+	versioned := *s
+	versioned.version = &version
+	versioned.taskRepo = s.taskRepo.WithVersion(version)
+
+	return &versioned
+}
+
+// transaction выполняет fn над сервисом, репозитории которого работают в одной транзакции.
+// Если сервис уже создан внутри транзакции, fn выполняется в ней.
+func (s *TaskService) transaction(fn func(tx *TaskService) error) error {
+	var err error
+
+	if s.transactor == nil {
+		err = fn(s)
+	} else {
+		err = s.transactor.Transaction(func(repos *repository.Repositories) error {
+			tx := *s
+			tx.taskRepo, tx.taskDependencyRepo, tx.statusRepo = repos.Task, repos.TaskDependency, repos.Status
+			tx.listRepo, tx.listMemberRepo = repos.List, repos.ListMember
+			tx.taskHistoryRepo, tx.outboxEventRepo = repos.TaskHistory, repos.OutboxEvent
+			tx.transactor = nil
+
+			if s.version != nil {
+				tx.taskRepo = repos.Task.WithVersion(*s.version)
+			}
+
+			return fn(&tx)
+		})
+	}
+
+	if errors.Is(err, repository.ErrTaskVersionConflict) {
+		return errors.New(ErrTaskVersionConflict)
 	}
 
-	return createdTask, nil
+	return err
 }
 
-func (s *TaskService) UpdateDescription(id uuid.UUID, description string) (*domain.Task, error) {
-	updatedTask, err := s.taskRepo.Update(&domain.Task{
-		ID: id, Description: description,
+// inTransaction выполняет fn в транзакции сервиса s и возвращает ее результат
+func inTransaction[T any](s *TaskService, fn func(tx *TaskService) (T, error)) (T, error) {
+	var result T
+
+	err := s.transaction(func(tx *TaskService) error {
+		var err error
+		result, err = fn(tx)
+
+		return err
 	})
 
 	if err != nil {
-		return nil, err
+		var empty T
+		return empty, err
 	}
 
-	return updatedTask, nil
+	return result, nil
+}
+
+func (s *TaskService) Create(description string, userId uuid.UUID, listId *uuid.UUID) (*domain.Task, error) {
+	return inTransaction(s, func(tx *TaskService) (*domain.Task, error) {
+		statuses, err := tx.resolveStatuses(userId, listId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		createdTask, err := tx.taskRepo.Create(&domain.Task{
+			Description: description,
+			UserId:      userId,
+			ListId:      listId,
+			StatusId:    &initialStatus(*statuses).ID,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err = tx.recordCreated(createdTask); err != nil {
+			return nil, err
+		}
+
+		return createdTask, nil
+	})
+}
+
+func (s *TaskService) UpdateDescription(id uuid.UUID, description string) (*domain.Task, error) {
+	return inTransaction(s, func(tx *TaskService) (*domain.Task, error) {
+		task, err := tx.taskRepo.FindById(id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		updatedTask, err := tx.taskRepo.Update(&domain.Task{
+			ID: id, Description: description,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err = tx.record(task, domain.TaskChange{
+			Field: domain.TaskFieldDescription, OldValue: &task.Description, NewValue: &description,
+		}); err != nil {
+			return nil, err
+		}
+
+		return updatedTask, nil
+	})
 }
 
 func (s *TaskService) UpdateIsCompleted(id uuid.UUID, isCompleted bool) (*domain.Task, error) {
-	updatedTask, err := s.taskRepo.Update(&domain.Task{
-		ID: id, IsCompleted: &isCompleted,
+	return inTransaction(s, func(tx *TaskService) (*domain.Task, error) {
+		if isCompleted && tx.forbidBlockedCompletion && tx.taskDependencyRepo.HasOpenBlockers(id) {
+			return nil, errors.New(ErrTaskIsBlocked)
+		}
+
+		task, err := tx.taskRepo.FindById(id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		statuses, err := tx.resolveStatuses(task.UserId, task.ListId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		status := initialStatus(*statuses)
+
+		if isCompleted {
+			status = doneStatus(*statuses)
+		}
+
+		updatedTask, err := tx.taskRepo.Update(&domain.Task{
+			ID: id, IsCompleted: &isCompleted, StatusId: &status.ID, CompletedAt: completedAt(isCompleted),
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err = tx.record(task, completionChanges(task, isCompleted, status.ID)...); err != nil {
+			return nil, err
+		}
+
+		return updatedTask, nil
 	})
+}
 
-	if err != nil {
-		return nil, err
-	}
+// UpdateStatus переводит задачу в статус statusId, синхронизируя признак завершенности с типом статуса
+func (s *TaskService) UpdateStatus(id, statusId uuid.UUID) (*domain.Task, error) {
+	return inTransaction(s, func(tx *TaskService) (*domain.Task, error) {
+		task, err := tx.taskRepo.FindById(id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		statuses, err := tx.resolveStatuses(task.UserId, task.ListId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		status := findStatus(*statuses, statusId)
+
+		if status == nil {
+			return nil, errors.New(ErrStatusNotFound)
+		}
+
+		if status.IsDone && tx.forbidBlockedCompletion && tx.taskDependencyRepo.HasOpenBlockers(id) {
+			return nil, errors.New(ErrTaskIsBlocked)
+		}
+
+		updatedTask, err := tx.taskRepo.Update(&domain.Task{
+			ID: id, IsCompleted: &status.IsDone, StatusId: &status.ID, CompletedAt: completedAt(status.IsDone),
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err = tx.record(task, completionChanges(task, status.IsDone, status.ID)...); err != nil {
+			return nil, err
+		}
+
+		return updatedTask, nil
+	})
+}
+
+// Move переносит задачу в список listId (nil - вне списков). Задача получает начальный или завершающий
+// статус рабочего процесса нового списка в зависимости от своей завершенности. Если у назначенного
+// пользователя нет доступа к задаче в новом списке, назначение снимается.
+func (s *TaskService) Move(id uuid.UUID, listId *uuid.UUID) (*domain.Task, error) {
+	return inTransaction(s, func(tx *TaskService) (*domain.Task, error) {
+		task, err := tx.taskRepo.FindById(id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		statuses, err := tx.resolveStatuses(task.UserId, listId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		status := initialStatus(*statuses)
+
+		if task.IsCompleted != nil && *task.IsCompleted {
+			status = doneStatus(*statuses)
+		}
+
+		changes := []domain.TaskChange{
+			{Field: domain.TaskFieldListId, OldValue: uuidValue(task.ListId), NewValue: uuidValue(listId)},
+			{Field: domain.TaskFieldStatusId, OldValue: uuidValue(task.StatusId), NewValue: uuidValue(&status.ID)},
+		}
+
+		moved := *task
+		moved.ListId, moved.StatusId = listId, &status.ID
+
+		if moved.AssigneeId != nil && !tx.hasAccess(&moved, *moved.AssigneeId) {
+			err = tx.taskRepo.UpdateColumns(id, map[string]interface{}{"list_id": listId, "status_id": status.ID, "assignee_id": nil})
+			changes = append(changes, domain.TaskChange{Field: domain.TaskFieldAssigneeId, OldValue: uuidValue(task.AssigneeId)})
+			moved.AssigneeId = nil
+		} else {
+			err = tx.taskRepo.Move(id, listId, status.ID)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err = tx.record(task, changes...); err != nil {
+			return nil, err
+		}
+
+		return &moved, nil
+	})
+}
+
+func (s *TaskService) UpdateEstimate(id uuid.UUID, minutes int) (*domain.Task, error) {
+	return inTransaction(s, func(tx *TaskService) (*domain.Task, error) {
+		task, err := tx.taskRepo.FindById(id)
 
-	return updatedTask, nil
+		if err != nil {
+			return nil, err
+		}
+
+		updatedTask, err := tx.taskRepo.Update(&domain.Task{
+			ID: id, EstimateMinutes: &minutes,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err = tx.record(task, domain.TaskChange{
+			Field: domain.TaskFieldEstimateMinutes, OldValue: intValue(task.EstimateMinutes), NewValue: intValue(&minutes),
+		}); err != nil {
+			return nil, err
+		}
+
+		return updatedTask, nil
+	})
+}
+
+// UpdateDueAt устанавливает срок выполнения задачи (nil - снимает срок)
+func (s *TaskService) UpdateDueAt(id uuid.UUID, dueAt *time.Time) (*domain.Task, error) {
+	return inTransaction(s, func(tx *TaskService) (*domain.Task, error) {
+		task, err := tx.taskRepo.FindById(id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err = tx.taskRepo.UpdateColumns(id, map[string]interface{}{"due_at": dueAt}); err != nil {
+			return nil, err
+		}
+
+		if err = tx.record(task, domain.TaskChange{
+			Field: domain.TaskFieldDueAt, OldValue: timeValue(task.DueAt), NewValue: timeValue(dueAt),
+		}); err != nil {
+			return nil, err
+		}
+
+		return tx.taskRepo.FindById(id)
+	})
+}
+
+// Assign назначает задачу пользователю assigneeId (nil - снимает назначение)
+func (s *TaskService) Assign(id uuid.UUID, assigneeId *uuid.UUID) (*domain.Task, error) {
+	return inTransaction(s, func(tx *TaskService) (*domain.Task, error) {
+		task, err := tx.taskRepo.FindById(id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err = tx.taskRepo.UpdateColumns(id, map[string]interface{}{"assignee_id": assigneeId}); err != nil {
+			return nil, err
+		}
+
+		if err = tx.record(task, domain.TaskChange{
+			Field: domain.TaskFieldAssigneeId, OldValue: uuidValue(task.AssigneeId), NewValue: uuidValue(assigneeId),
+		}); err != nil {
+			return nil, err
+		}
+
+		return tx.taskRepo.FindById(id)
+	})
 }
 
 func (s *TaskService) Delete(id uuid.UUID) error {
-	result := s.taskRepo.Delete(id)
+	return s.transaction(func(tx *TaskService) error {
+		task, err := tx.taskRepo.FindById(id)
 
-	if result != nil {
-		return result
-	}
+		if err != nil {
+			return err
+		}
 
-	return nil
+		if err = tx.taskRepo.Delete(id); err != nil {
+			return err
+		}
+
+		return tx.record(task, flagChange(domain.TaskFieldDeleted, true))
+	})
 }
 
 func (s *TaskService) IsExistsById(id uuid.UUID) bool {
 	return s.taskRepo.IsExistsById(id)
 }
 
+func (s *TaskService) FindById(id uuid.UUID) (*domain.Task, error) {
+	return s.taskRepo.FindById(id)
+}
+
+func (s *TaskService) GetAssignedToUserId(id uuid.UUID) *[]domain.Task {
+	return s.taskRepo.GetAssignedToUserId(id)
+}
+
 func (s *TaskService) GetAllByUserId(id uuid.UUID) *[]domain.Task {
 	return s.taskRepo.GetAllByUserId(id)
 }
+
+// GetPageByUserId возвращает страницу задач пользователя. sort - имя поля, с префиксом "-" для обратного порядка,
+// cursor - значение next_cursor предыдущей страницы.
+func (s *TaskService) GetPageByUserId(filter domain.TaskFilter, sort, cursor string, limit int) (*domain.TaskPage, error) {
+	if sort == "" {
+		sort = "-" + domain.TaskSortCreatedAt
+	}
+
+	taskSort, err := parseTaskSort(sort)
+
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := decodeTaskCursor(cursor, sort)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > MaxTasksPageLimit {
+		limit = DefaultTasksPageLimit
+	}
+
+	tasks, err := s.taskRepo.GetPageByUserId(filter, taskSort, after, limit+1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.taskRepo.CountByUserId(filter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.TaskPage{Tasks: *tasks, Counts: counts}
+
+	if len(page.Tasks) > limit {
+		page.Tasks = page.Tasks[:limit]
+		page.NextCursor = encodeTaskCursor(page.Tasks[limit-1], sort, taskSort.Field)
+	}
+
+	return page, nil
+}
+
+func parseTaskSort(sort string) (domain.TaskSort, error) {
+	taskSort := domain.TaskSort{Field: strings.TrimPrefix(sort, "-"), Desc: strings.HasPrefix(sort, "-")}
+
+	switch taskSort.Field {
+	case domain.TaskSortCreatedAt, domain.TaskSortUpdatedAt, domain.TaskSortDescription:
+		return taskSort, nil
+	}
+
+	return taskSort, errors.New(ErrInvalidTaskSort)
+}
+
+func encodeTaskCursor(task domain.Task, sort, field string) string {
+	cursor := taskCursor{Sort: sort, Id: task.ID}
+
+	switch field {
+	case domain.TaskSortCreatedAt:
+		cursor.Value = task.CreatedAt.Format(time.RFC3339Nano)
+	case domain.TaskSortUpdatedAt:
+		cursor.Value = task.UpdatedAt.Format(time.RFC3339Nano)
+	case domain.TaskSortDescription:
+		cursor.Value = task.Description
+	}
+
+	data, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeTaskCursor(value, sort string) (*domain.TaskCursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+
+	if err != nil {
+		return nil, errors.New(ErrInvalidTaskCursor)
+	}
+
+	var cursor taskCursor
+
+	if err = json.Unmarshal(data, &cursor); err != nil || cursor.Sort != sort {
+		return nil, errors.New(ErrInvalidTaskCursor)
+	}
+
+	return &domain.TaskCursor{Value: cursor.Value, Id: cursor.Id}, nil
+}
+
+func (s *TaskService) FindWithTrashedById(id uuid.UUID) (*domain.Task, error) {
+	return s.taskRepo.FindWithTrashedById(id)
+}
+
+func (s *TaskService) GetTrash(userId uuid.UUID) *[]domain.Task {
+	return s.taskRepo.GetTrashByUserId(userId)
+}
+
+// Restore возвращает задачу из корзины
+func (s *TaskService) Restore(id uuid.UUID) error {
+	return s.transaction(func(tx *TaskService) error {
+		task, err := tx.taskRepo.FindWithTrashedById(id)
+
+		if err != nil {
+			return err
+		}
+
+		if !task.DeletedAt.Valid {
+			return errors.New(ErrTaskIsNotTrashed)
+		}
+
+		if err = tx.taskRepo.Restore(id); err != nil {
+			return err
+		}
+
+		return tx.record(task, flagChange(domain.TaskFieldDeleted, false))
+	})
+}
+
+// Purge окончательно удаляет задачу независимо от того, находится ли она в корзине.
+// Событие удаления сохраняется до удаления задачи в той же транзакции.
+func (s *TaskService) Purge(id uuid.UUID) error {
+	return s.transaction(func(tx *TaskService) error {
+		task, err := tx.taskRepo.FindWithTrashedById(id)
+
+		if err != nil {
+			return err
+		}
+
+		if err = tx.record(task, flagChange(domain.TaskFieldPurged, true)); err != nil {
+			return err
+		}
+
+		return tx.taskRepo.Purge(id)
+	})
+}
+
+// PurgeTrash окончательно удаляет задачи, перемещенные в корзину раньше before, и возвращает их количество.
+// Задачи блокируются до удаления, чтобы их не успели восстановить после сохранения событий удаления.
+func (s *TaskService) PurgeTrash(before time.Time) (int64, error) {
+	return inTransaction(s, func(tx *TaskService) (int64, error) {
+		for _, task := range *tx.taskRepo.LockDeletedBefore(before) {
+			if err := tx.record(&task, flagChange(domain.TaskFieldPurged, true)); err != nil {
+				return 0, err
+			}
+		}
+
+		return tx.taskRepo.PurgeDeletedBefore(before)
+	})
+}
+
+func (s *TaskService) GetArchive(userId uuid.UUID) *[]domain.Task {
+	return s.taskRepo.GetArchivedByUserId(userId)
+}
+
+// Unarchive возвращает задачу из архива в общий список задач
+func (s *TaskService) Unarchive(id uuid.UUID) error {
+	return s.transaction(func(tx *TaskService) error {
+		task, err := tx.taskRepo.FindById(id)
+
+		if err != nil {
+			return err
+		}
+
+		if task.ArchivedAt == nil {
+			return errors.New(ErrTaskIsNotArchived)
+		}
+
+		if err = tx.taskRepo.Unarchive(id, time.Now()); err != nil {
+			return err
+		}
+
+		return tx.record(task, flagChange(domain.TaskFieldArchived, false))
+	})
+}
+
+// ArchiveCompleted архивирует давно завершенные задачи согласно настройкам пользователей и возвращает их количество
+func (s *TaskService) ArchiveCompleted() (int64, error) {
+	return s.taskRepo.ArchiveCompleted(time.Now())
+}
+
+// resolveStatuses возвращает рабочий процесс задач списка listId. Если у списка нет собственных статусов,
+// применяются статусы владельца списка, чтобы задачи всех участников совместного списка вели себя одинаково.
+func (s *TaskService) resolveStatuses(userId uuid.UUID, listId *uuid.UUID) (*[]domain.Status, error) {
+	if listId != nil {
+		if list, err := s.listRepo.FindById(*listId); err == nil {
+			userId = list.UserId
+		}
+	}
+
+	return resolveStatuses(s.statusRepo, userId, listId)
+}
+
+// History возвращает изменения задачи, сгруппированные по ревизиям, начиная с последней
+func (s *TaskService) History(id uuid.UUID) []domain.TaskRevision {
+	revisions := make([]domain.TaskRevision, 0)
+
+	for _, entry := range *s.taskHistoryRepo.GetByTaskId(id) {
+		if len(revisions) == 0 || revisions[len(revisions)-1].Revision != entry.Revision {
+			revisions = append(revisions, domain.TaskRevision{
+				Revision: entry.Revision, ActorId: entry.ActorId, CreatedAt: entry.CreatedAt,
+			})
+		}
+
+		last := &revisions[len(revisions)-1]
+		last.Changes = append(last.Changes, domain.TaskChange{
+			Field: entry.Field, OldValue: entry.OldValue, NewValue: entry.NewValue,
+		})
+	}
+
+	return revisions
+}
+
+// Revert возвращает поля задачи к значениям, которые они имели в ревизии revision.
+// Откат сам записывается в историю новой ревизией, поэтому его тоже можно отменить.
+func (s *TaskService) Revert(id uuid.UUID, revision int) (*domain.Task, error) {
+	return inTransaction(s, func(tx *TaskService) (*domain.Task, error) {
+		task, err := tx.taskRepo.FindById(id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if revision < 1 || revision >= task.Version {
+			return nil, errors.New(ErrRevisionNotFound)
+		}
+
+		// История отсортирована от новых ревизий к старым, поэтому для каждого поля остается
+		// прежнее значение самого раннего изменения после revision
+		values := make(map[string]*string)
+
+		for _, entry := range *tx.taskHistoryRepo.GetByTaskId(id) {
+			if entry.Revision <= revision {
+				break
+			}
+
+			values[entry.Field] = entry.OldValue
+		}
+
+		columns := make(map[string]interface{})
+		changes := make([]domain.TaskChange, 0, len(values))
+		current := taskValues(task)
+
+		for _, field := range revertableTaskFields {
+			value, ok := values[field]
+
+			if !ok || equalValues(current[field], value) {
+				continue
+			}
+
+			column, err := parseTaskValue(field, value)
+
+			if err != nil {
+				return nil, err
+			}
+
+			columns[field] = column
+			changes = append(changes, domain.TaskChange{Field: field, OldValue: current[field], NewValue: value})
+		}
+
+		// Восстанавливаемое назначение должно быть доступно назначенному, а при возврате задачи в прежний
+		// список назначение снимается, если у назначенного в нем нет доступа
+		reverted := *task
+
+		if listId, ok := columns[domain.TaskFieldListId]; ok {
+			reverted.ListId = uuidColumn(listId)
+		}
+
+		if assigneeId, ok := columns[domain.TaskFieldAssigneeId]; ok {
+			reverted.AssigneeId = uuidColumn(assigneeId)
+		}
+
+		if reverted.AssigneeId != nil && !tx.hasAccess(&reverted, *reverted.AssigneeId) {
+			if _, ok := columns[domain.TaskFieldAssigneeId]; ok {
+				return nil, errors.New(ErrAssigneeNotMember)
+			}
+
+			columns[domain.TaskFieldAssigneeId] = nil
+			changes = append(changes, domain.TaskChange{Field: domain.TaskFieldAssigneeId, OldValue: current[domain.TaskFieldAssigneeId]})
+		}
+
+		if len(columns) == 0 {
+			return task, nil
+		}
+
+		if isCompleted, ok := columns[domain.TaskFieldIsCompleted].(bool); ok && isCompleted {
+			if tx.forbidBlockedCompletion && tx.taskDependencyRepo.HasOpenBlockers(id) {
+				return nil, errors.New(ErrTaskIsBlocked)
+			}
+
+			columns["completed_at"] = time.Now()
+		}
+
+		if err = tx.taskRepo.UpdateColumns(id, columns); err != nil {
+			return nil, err
+		}
+
+		if err = tx.record(task, changes...); err != nil {
+			return nil, err
+		}
+
+		return tx.taskRepo.FindById(id)
+	})
+}
+
+// unassign снимает назначение задачи task, в том числе находящейся в корзине или архиве. Используется,
+// когда назначенный пользователь теряет доступ к задаче.
+func (s *TaskService) unassign(task *domain.Task) error {
+	return s.transaction(func(tx *TaskService) error {
+		if err := tx.taskRepo.Unassign(task.ID); err != nil {
+			return err
+		}
+
+		return tx.record(task, domain.TaskChange{Field: domain.TaskFieldAssigneeId, OldValue: uuidValue(task.AssigneeId)})
+	})
+}
+
+// hasAccess проверяет, доступна ли задача task пользователю userId
+func (s *TaskService) hasAccess(task *domain.Task, userId uuid.UUID) bool {
+	return taskRole(s.listRepo, s.listMemberRepo, task, userId) != ""
+}
+
+// uuidColumn возвращает идентификатор из значения колонки, полученного parseTaskValue
+func uuidColumn(value interface{}) *uuid.UUID {
+	if id, ok := value.(uuid.UUID); ok {
+		return &id
+	}
+
+	return nil
+}
+
+// revertableTaskFields - поля, которые можно вернуть к прежней ревизии. Перемещение в корзину и архив
+// отменяются отдельными операциями.
+var revertableTaskFields = []string{
+	domain.TaskFieldDescription,
+	domain.TaskFieldIsCompleted,
+	domain.TaskFieldStatusId,
+	domain.TaskFieldListId,
+	domain.TaskFieldEstimateMinutes,
+	domain.TaskFieldAssigneeId,
+	domain.TaskFieldDueAt,
+}
+
+// record сохраняет в историю изменившиеся поля задачи task. Ревизия совпадает с версией,
+// которую задача получила после изменения: задача прочитана в транзакции с блокировкой строки,
+// поэтому параллельное изменение не может получить ту же версию.
+func (s *TaskService) record(task *domain.Task, changes ...domain.TaskChange) error {
+	return s.recordRevision(task, task.Version+1, changes)
+}
+
+func (s *TaskService) recordCreated(task *domain.Task) error {
+	return s.recordRevision(task, 1, []domain.TaskChange{
+		{Field: domain.TaskFieldDescription, NewValue: &task.Description},
+		{Field: domain.TaskFieldListId, NewValue: uuidValue(task.ListId)},
+		{Field: domain.TaskFieldStatusId, NewValue: uuidValue(task.StatusId)},
+	})
+}
+
+func (s *TaskService) recordRevision(task *domain.Task, revision int, changes []domain.TaskChange) error {
+	entries := make([]domain.TaskHistory, 0, len(changes))
+
+	for _, change := range changes {
+		if equalValues(change.OldValue, change.NewValue) {
+			continue
+		}
+
+		entries = append(entries, domain.TaskHistory{
+			TaskId:   task.ID,
+			Revision: revision,
+			ActorId:  s.actorId,
+			Field:    change.Field,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+
+	if err := s.taskHistoryRepo.Create(entries); err != nil {
+		return err
+	}
+
+	return s.publish(task, revision, entries)
+}
+
+// publish сохраняет в outbox доменные события, соответствующие изменениям ревизии revision задачи task
+func (s *TaskService) publish(task *domain.Task, revision int, entries []domain.TaskHistory) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	changes := make([]domain.TaskChange, 0, len(entries))
+	listId := task.ListId
+
+	for _, entry := range entries {
+		changes = append(changes, domain.TaskChange{Field: entry.Field, OldValue: entry.OldValue, NewValue: entry.NewValue})
+
+		if entry.Field == domain.TaskFieldListId {
+			listId = parseUuidValue(entry.NewValue)
+		}
+	}
+
+	eventTypes := taskEventTypes(revision, changes)
+	events := make([]domain.OutboxEvent, 0, len(eventTypes))
+
+	for _, eventType := range eventTypes {
+		event := domain.TaskEvent{
+			ID:         uuid.New(),
+			Type:       eventType,
+			TaskId:     task.ID,
+			UserId:     task.UserId,
+			ListId:     listId,
+			Revision:   revision,
+			ActorId:    s.actorId,
+			Changes:    changes,
+			OccurredAt: time.Now(),
+		}
+
+		payload, err := json.Marshal(event)
+
+		if err != nil {
+			return err
+		}
+
+		events = append(events, domain.OutboxEvent{
+			ID: event.ID, Type: eventType, AggregateId: task.ID, Payload: string(payload), CreatedAt: event.OccurredAt,
+		})
+	}
+
+	return s.outboxEventRepo.Create(events)
+}
+
+// taskEventTypes определяет события, которые порождают изменения changes ревизии revision. Создание задачи
+// порождает только task.created, изменение статуса вместе с завершенностью - только task.completed или task.reopened.
+func taskEventTypes(revision int, changes []domain.TaskChange) []string {
+	if revision == 1 {
+		return []string{domain.TaskEventCreated}
+	}
+
+	var events []string
+	var updated, statusChanged, completionChanged bool
+
+	for _, change := range changes {
+		isSet := change.NewValue != nil && *change.NewValue == "true"
+
+		switch change.Field {
+		case domain.TaskFieldIsCompleted:
+			completionChanged = true
+
+			if isSet {
+				events = append(events, domain.TaskEventCompleted)
+			} else {
+				events = append(events, domain.TaskEventReopened)
+			}
+		case domain.TaskFieldPurged:
+			events = append(events, domain.TaskEventDeleted)
+		case domain.TaskFieldDeleted:
+			if isSet {
+				events = append(events, domain.TaskEventDeleted)
+			} else {
+				events = append(events, domain.TaskEventRestored)
+			}
+		case domain.TaskFieldArchived:
+			if !isSet {
+				events = append(events, domain.TaskEventUnarchived)
+			}
+		case domain.TaskFieldAssigneeId:
+			events = append(events, domain.TaskEventAssigned)
+		case domain.TaskFieldStatusId:
+			statusChanged = true
+		default:
+			updated = true
+		}
+	}
+
+	if updated || statusChanged && !completionChanged {
+		events = append(events, domain.TaskEventUpdated)
+	}
+
+	return events
+}
+
+func completionChanges(task *domain.Task, isCompleted bool, statusId uuid.UUID) []domain.TaskChange {
+	return []domain.TaskChange{
+		{Field: domain.TaskFieldIsCompleted, OldValue: boolValue(isTaskCompleted(task)), NewValue: boolValue(isCompleted)},
+		{Field: domain.TaskFieldStatusId, OldValue: uuidValue(task.StatusId), NewValue: uuidValue(&statusId)},
+	}
+}
+
+func flagChange(field string, value bool) domain.TaskChange {
+	return domain.TaskChange{Field: field, OldValue: boolValue(!value), NewValue: boolValue(value)}
+}
+
+// taskValues возвращает значения отслеживаемых полей задачи в том виде, в котором они хранятся в истории
+func taskValues(task *domain.Task) map[string]*string {
+	return map[string]*string{
+		domain.TaskFieldDescription:     &task.Description,
+		domain.TaskFieldIsCompleted:     boolValue(isTaskCompleted(task)),
+		domain.TaskFieldStatusId:        uuidValue(task.StatusId),
+		domain.TaskFieldListId:          uuidValue(task.ListId),
+		domain.TaskFieldEstimateMinutes: intValue(task.EstimateMinutes),
+		domain.TaskFieldAssigneeId:      uuidValue(task.AssigneeId),
+		domain.TaskFieldDueAt:           timeValue(task.DueAt),
+	}
+}
+
+// parseTaskValue преобразует значение из истории в значение колонки задачи
+func parseTaskValue(field string, value *string) (interface{}, error) {
+	switch field {
+	case domain.TaskFieldDescription:
+		if value == nil {
+			return "", nil
+		}
+
+		return *value, nil
+	case domain.TaskFieldIsCompleted:
+		return value != nil && *value == "true", nil
+	case domain.TaskFieldStatusId, domain.TaskFieldListId, domain.TaskFieldAssigneeId:
+		if value == nil {
+			return nil, nil
+		}
+
+		return uuid.Parse(*value)
+	case domain.TaskFieldEstimateMinutes:
+		if value == nil {
+			return nil, nil
+		}
+
+		return strconv.Atoi(*value)
+	case domain.TaskFieldDueAt:
+		if value == nil {
+			return nil, nil
+		}
+
+		return time.Parse(time.RFC3339Nano, *value)
+	}
+
+	return nil, errors.New(ErrRevisionNotFound)
+}
+
+func isTaskCompleted(task *domain.Task) bool {
+	return task.IsCompleted != nil && *task.IsCompleted
+}
+
+func boolValue(value bool) *string {
+	result := strconv.FormatBool(value)
+
+	return &result
+}
+
+func intValue(value *int) *string {
+	if value == nil {
+		return nil
+	}
+
+	result := strconv.Itoa(*value)
+
+	return &result
+}
+
+func timeValue(value *time.Time) *string {
+	if value == nil {
+		return nil
+	}
+
+	result := value.UTC().Format(time.RFC3339Nano)
+
+	return &result
+}
+
+func uuidValue(value *uuid.UUID) *string {
+	if value == nil {
+		return nil
+	}
+
+	result := value.String()
+
+	return &result
+}
+
+// parseUuidValue возвращает идентификатор из значения поля истории, nil - если значения нет
+func parseUuidValue(value *string) *uuid.UUID {
+	if value == nil {
+		return nil
+	}
+
+	id, err := uuid.Parse(*value)
+
+	if err != nil {
+		return nil
+	}
+
+	return &id
+}
+
+func equalValues(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// completedAt возвращает момент завершения задачи. Для незавершенной задачи дата не меняется.
+func completedAt(isCompleted bool) *time.Time {
+	if !isCompleted {
+		return nil
+	}
+
+	now := time.Now()
+
+	return &now
+}
@@ -1,15 +1,19 @@
 package service_test
 
 import (
+	"encoding/json"
 	"errors"
 	"github.com/go-faker/faker/v4"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
 	mock_repository "poymanov/todo/internal/repository/mocks"
 	"poymanov/todo/internal/service"
 	"testing"
+	"time"
 )
 
 func TestTaskServiceCreate_Failed(t *testing.T) {
@@ -20,7 +24,7 @@ func TestTaskServiceCreate_Failed(t *testing.T) {
 	userId, err := uuid.Parse(faker.UUIDHyphenated())
 	require.NoError(t, err)
 
-	createdTask, err := taskService.Create(faker.Word(), userId)
+	createdTask, err := taskService.Create(faker.Word(), userId, nil)
 
 	require.Error(t, err)
 	require.Nil(t, createdTask)
@@ -36,7 +40,7 @@ func TestTaskServiceCreate_Success(t *testing.T) {
 
 	taskRepo.EXPECT().Create(gomock.Any()).Return(&taskData, nil)
 
-	createdTask, err := taskService.Create(faker.Word(), userId)
+	createdTask, err := taskService.Create(faker.Word(), userId, nil)
 
 	require.NoError(t, err)
 	require.NotNil(t, createdTask)
@@ -50,6 +54,7 @@ func TestTaskServiceUpdateDescription_Failed(t *testing.T) {
 	taskId, err := uuid.Parse(faker.UUIDHyphenated())
 	require.NoError(t, err)
 
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId}, nil)
 	taskRepo.EXPECT().Update(gomock.Any()).Return(nil, errors.New("failed"))
 
 	updatedTask, err := taskService.UpdateDescription(taskId, faker.Word())
@@ -67,6 +72,7 @@ func TestTaskServiceUpdateDescription_Success(t *testing.T) {
 	newDescription := faker.Word()
 	taskData := domain.Task{ID: taskId, Description: newDescription}
 
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId}, nil)
 	taskRepo.EXPECT().Update(gomock.Any()).Return(&taskData, nil)
 
 	updatedTask, err := taskService.UpdateDescription(taskId, newDescription)
@@ -82,6 +88,7 @@ func TestTaskServiceUpdateIsCompleted_Failed(t *testing.T) {
 	taskId, err := uuid.Parse(faker.UUIDHyphenated())
 	require.NoError(t, err)
 
+	taskRepo.EXPECT().FindById(gomock.Any()).Return(&domain.Task{ID: taskId}, nil)
 	taskRepo.EXPECT().Update(gomock.Any()).Return(nil, errors.New("failed"))
 
 	updatedTask, err := taskService.UpdateIsCompleted(taskId, false)
@@ -100,6 +107,7 @@ func TestTaskServiceUpdateIsCompleted_Completed(t *testing.T) {
 
 	taskData := domain.Task{ID: taskId, IsCompleted: &isCompleted}
 
+	taskRepo.EXPECT().FindById(gomock.Any()).Return(&domain.Task{ID: taskId}, nil)
 	taskRepo.EXPECT().Update(gomock.Any()).Return(&taskData, nil)
 
 	updatedTask, err := taskService.UpdateIsCompleted(taskId, true)
@@ -119,6 +127,7 @@ func TestTaskServiceUpdateIsCompleted_NotCompleted(t *testing.T) {
 
 	taskData := domain.Task{ID: taskId, IsCompleted: &isCompleted}
 
+	taskRepo.EXPECT().FindById(gomock.Any()).Return(&domain.Task{ID: taskId}, nil)
 	taskRepo.EXPECT().Update(gomock.Any()).Return(&taskData, nil)
 
 	updatedTask, err := taskService.UpdateIsCompleted(taskId, true)
@@ -128,12 +137,182 @@ func TestTaskServiceUpdateIsCompleted_NotCompleted(t *testing.T) {
 	require.False(t, *updatedTask.IsCompleted)
 }
 
+func TestTaskServiceUpdateIsCompleted_Blocked(t *testing.T) {
+	taskService, _, taskDependencyRepo := mockStrictTaskService(t)
+
+	taskId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	taskDependencyRepo.EXPECT().HasOpenBlockers(gomock.Any()).Return(true)
+
+	updatedTask, err := taskService.UpdateIsCompleted(taskId, true)
+
+	require.Nil(t, updatedTask)
+	require.EqualError(t, err, service.ErrTaskIsBlocked)
+}
+
+func TestTaskServiceUpdateIsCompleted_NotBlocked(t *testing.T) {
+	taskService, taskRepo, taskDependencyRepo := mockStrictTaskService(t)
+
+	taskId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	isCompleted := true
+	taskDependencyRepo.EXPECT().HasOpenBlockers(gomock.Any()).Return(false)
+	taskRepo.EXPECT().FindById(gomock.Any()).Return(&domain.Task{ID: taskId}, nil)
+	taskRepo.EXPECT().Update(gomock.Any()).Return(&domain.Task{ID: taskId, IsCompleted: &isCompleted}, nil)
+
+	updatedTask, err := taskService.UpdateIsCompleted(taskId, true)
+
+	require.NoError(t, err)
+	require.True(t, *updatedTask.IsCompleted)
+}
+
+func TestTaskServiceCreate_InitialStatus(t *testing.T) {
+	taskService, taskRepo := mockTaskService(t)
+
+	userId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	taskRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(task *domain.Task) (*domain.Task, error) {
+		return task, nil
+	})
+
+	createdTask, err := taskService.Create(faker.Word(), userId, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, openStatusId, *createdTask.StatusId)
+}
+
+func TestTaskServiceUpdateIsCompleted_DoneStatus(t *testing.T) {
+	taskService, taskRepo := mockTaskService(t)
+
+	taskId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	taskRepo.EXPECT().FindById(gomock.Any()).Return(&domain.Task{ID: taskId}, nil)
+	taskRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(task *domain.Task) (*domain.Task, error) {
+		return task, nil
+	})
+
+	updatedTask, err := taskService.UpdateIsCompleted(taskId, true)
+
+	require.NoError(t, err)
+	require.Equal(t, doneStatusId, *updatedTask.StatusId)
+}
+
+func TestTaskServiceUpdateStatus_StatusNotFound(t *testing.T) {
+	taskService, taskRepo := mockTaskService(t)
+
+	taskId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	statusId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	taskRepo.EXPECT().FindById(gomock.Any()).Return(&domain.Task{ID: taskId}, nil)
+
+	updatedTask, err := taskService.UpdateStatus(taskId, statusId)
+
+	require.Nil(t, updatedTask)
+	require.EqualError(t, err, service.ErrStatusNotFound)
+}
+
+func TestTaskServiceUpdateStatus_Blocked(t *testing.T) {
+	taskService, taskRepo, taskDependencyRepo := mockStrictTaskService(t)
+
+	taskId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	taskRepo.EXPECT().FindById(gomock.Any()).Return(&domain.Task{ID: taskId}, nil)
+	taskDependencyRepo.EXPECT().HasOpenBlockers(gomock.Any()).Return(true)
+
+	updatedTask, err := taskService.UpdateStatus(taskId, doneStatusId)
+
+	require.Nil(t, updatedTask)
+	require.EqualError(t, err, service.ErrTaskIsBlocked)
+}
+
+func TestTaskServiceUpdateStatus_Success(t *testing.T) {
+	taskService, taskRepo := mockTaskService(t)
+
+	taskId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	taskRepo.EXPECT().FindById(gomock.Any()).Return(&domain.Task{ID: taskId}, nil)
+	taskRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(task *domain.Task) (*domain.Task, error) {
+		return task, nil
+	})
+
+	updatedTask, err := taskService.UpdateStatus(taskId, doneStatusId)
+
+	require.NoError(t, err)
+	require.Equal(t, doneStatusId, *updatedTask.StatusId)
+	require.True(t, *updatedTask.IsCompleted)
+}
+
+func TestTaskServiceUpdateEstimate_Success(t *testing.T) {
+	taskService, taskRepo := mockTaskService(t)
+
+	taskId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	estimate := 90
+
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId}, nil)
+	taskRepo.EXPECT().Update(&domain.Task{ID: taskId, EstimateMinutes: &estimate}).
+		Return(&domain.Task{ID: taskId, EstimateMinutes: &estimate}, nil)
+
+	updatedTask, err := taskService.UpdateEstimate(taskId, estimate)
+
+	require.NoError(t, err)
+	require.Equal(t, estimate, *updatedTask.EstimateMinutes)
+}
+
+func TestTaskServiceUpdateDescription_VersionChangedBeforeWrite(t *testing.T) {
+	taskService, taskRepo := mockTaskService(t)
+
+	taskId := uuid.New()
+
+	// сервис и транзакция получают репозиторий с условием на версию, прочитанную клиентом
+	taskRepo.EXPECT().WithVersion(2).Return(taskRepo).Times(2)
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, Version: 2}, nil)
+	// другой запрос изменил задачу между чтением и записью, условие на версию не выполнено
+	taskRepo.EXPECT().Update(&domain.Task{ID: taskId, Description: "new"}).Return(nil, repository.ErrTaskVersionConflict)
+
+	updatedTask, err := taskService.WithVersion(2).UpdateDescription(taskId, "new")
+
+	require.Nil(t, updatedTask)
+	require.EqualError(t, err, service.ErrTaskVersionConflict)
+}
+
+func TestTaskServiceUpdateDueAt_Success(t *testing.T) {
+	taskService, taskRepo, taskHistoryRepo := mockTaskServiceWithHistory(t)
+
+	taskId := uuid.New()
+	dueAt := time.Date(2026, 10, 25, 18, 0, 0, 0, time.UTC)
+	dueValue := "2026-10-25T18:00:00Z"
+
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, Version: 2}, nil)
+	taskRepo.EXPECT().UpdateColumns(taskId, map[string]interface{}{"due_at": &dueAt}).Return(nil)
+	taskHistoryRepo.EXPECT().Create([]domain.TaskHistory{
+		{TaskId: taskId, Revision: 3, Field: domain.TaskFieldDueAt, NewValue: &dueValue},
+	}).Return(nil)
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, DueAt: &dueAt, Version: 3}, nil)
+
+	updatedTask, err := taskService.UpdateDueAt(taskId, &dueAt)
+
+	require.NoError(t, err)
+	require.Equal(t, dueAt, *updatedTask.DueAt)
+}
+
 func TestTaskServiceDelete_Failed(t *testing.T) {
 	taskService, taskRepo := mockTaskService(t)
 
 	taskId, err := uuid.Parse(faker.UUIDHyphenated())
 	require.NoError(t, err)
 
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId}, nil)
 	taskRepo.EXPECT().Delete(gomock.Any()).Return(errors.New("failed"))
 
 	err = taskService.Delete(taskId)
@@ -147,6 +326,7 @@ func TestTaskServiceDelete_Success(t *testing.T) {
 	taskId, err := uuid.Parse(faker.UUIDHyphenated())
 	require.NoError(t, err)
 
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId}, nil)
 	taskRepo.EXPECT().Delete(gomock.Any()).Return(nil)
 
 	err = taskService.Delete(taskId)
@@ -206,6 +386,532 @@ func TestTaskServiceGetAllByUserId_Success(t *testing.T) {
 	require.NotEmpty(t, tasks)
 }
 
+func TestTaskServiceFindById_Failed(t *testing.T) {
+	taskService, taskRepo := mockTaskService(t)
+
+	taskId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	taskRepo.EXPECT().FindById(gomock.Any()).Return(nil, errors.New("failed"))
+
+	task, err := taskService.FindById(taskId)
+
+	require.Error(t, err)
+	require.Nil(t, task)
+}
+
+func TestTaskServiceFindById_Success(t *testing.T) {
+	taskService, taskRepo := mockTaskService(t)
+
+	taskId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	taskRepo.EXPECT().FindById(gomock.Any()).Return(&domain.Task{ID: taskId}, nil)
+
+	task, err := taskService.FindById(taskId)
+
+	require.NoError(t, err)
+	require.Equal(t, taskId, task.ID)
+}
+
+func TestTaskServiceRestore_NotTrashed(t *testing.T) {
+	taskService, taskRepo := mockTaskService(t)
+
+	taskId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	taskRepo.EXPECT().FindWithTrashedById(taskId).Return(&domain.Task{ID: taskId}, nil)
+
+	err = taskService.Restore(taskId)
+
+	require.EqualError(t, err, service.ErrTaskIsNotTrashed)
+}
+
+func TestTaskServiceRestore_Success(t *testing.T) {
+	taskService, taskRepo := mockTaskService(t)
+
+	taskId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	taskRepo.EXPECT().FindWithTrashedById(taskId).
+		Return(&domain.Task{ID: taskId, DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}, nil)
+	taskRepo.EXPECT().Restore(taskId).Return(nil)
+
+	require.NoError(t, taskService.Restore(taskId))
+}
+
+func TestTaskServicePurge_PublishesDeletedBeforePurge(t *testing.T) {
+	taskService, taskRepo, outboxEventRepo := mockTaskServiceWithEvents(t)
+
+	taskId, actorId := twoUuids(t)
+	listId := uuid.New()
+
+	taskRepo.EXPECT().FindWithTrashedById(taskId).Return(&domain.Task{ID: taskId, ListId: &listId, Version: 3}, nil)
+	gomock.InOrder(
+		outboxEventRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(events []domain.OutboxEvent) error {
+			require.Len(t, events, 1)
+			require.Equal(t, domain.TaskEventDeleted, events[0].Type)
+
+			var event domain.TaskEvent
+			require.NoError(t, json.Unmarshal([]byte(events[0].Payload), &event))
+			require.Equal(t, actorId, *event.ActorId)
+			require.Equal(t, listId, *event.ListId)
+			require.Equal(t, domain.TaskFieldPurged, event.Changes[0].Field)
+
+			return nil
+		}),
+		taskRepo.EXPECT().Purge(taskId).Return(nil),
+	)
+
+	require.NoError(t, taskService.WithActor(actorId).Purge(taskId))
+}
+
+func TestTaskServicePurgeTrash_Success(t *testing.T) {
+	taskService, taskRepo, outboxEventRepo := mockTaskServiceWithEvents(t)
+
+	before := time.Now().AddDate(0, 0, -30)
+
+	gomock.InOrder(
+		taskRepo.EXPECT().LockDeletedBefore(before).Return(&[]domain.Task{{ID: uuid.New()}, {ID: uuid.New()}}),
+		outboxEventRepo.EXPECT().Create(gomock.Any()).Return(nil).Times(2),
+		taskRepo.EXPECT().PurgeDeletedBefore(before).Return(int64(2), nil),
+	)
+
+	purged, err := taskService.PurgeTrash(before)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(2), purged)
+}
+
+func TestTaskServiceUpdateIsCompleted_SetsCompletedAt(t *testing.T) {
+	taskService, taskRepo := mockTaskService(t)
+
+	taskId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	taskRepo.EXPECT().FindById(gomock.Any()).Return(&domain.Task{ID: taskId}, nil)
+	taskRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(task *domain.Task) (*domain.Task, error) {
+		return task, nil
+	})
+
+	updatedTask, err := taskService.UpdateIsCompleted(taskId, true)
+
+	require.NoError(t, err)
+	require.NotNil(t, updatedTask.CompletedAt)
+}
+
+func TestTaskServiceUnarchive_NotArchived(t *testing.T) {
+	taskService, taskRepo := mockTaskService(t)
+
+	taskId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId}, nil)
+
+	err = taskService.Unarchive(taskId)
+
+	require.EqualError(t, err, service.ErrTaskIsNotArchived)
+}
+
+func TestTaskServiceUnarchive_Success(t *testing.T) {
+	taskService, taskRepo := mockTaskService(t)
+
+	taskId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	archivedAt := time.Now()
+
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, ArchivedAt: &archivedAt}, nil)
+	taskRepo.EXPECT().Unarchive(taskId, gomock.Any()).Return(nil)
+
+	require.NoError(t, taskService.Unarchive(taskId))
+}
+
+func TestTaskServiceGetPageByUserId_DefaultSortAndLimit(t *testing.T) {
+	taskService, taskRepo := mockTaskService(t)
+
+	filter := domain.TaskFilter{UserId: uuid.New()}
+
+	taskRepo.EXPECT().
+		GetPageByUserId(filter, domain.TaskSort{Field: domain.TaskSortCreatedAt, Desc: true}, nil, service.DefaultTasksPageLimit+1).
+		Return(&[]domain.Task{{}}, nil)
+	taskRepo.EXPECT().CountByUserId(filter).Return(domain.TaskCounts{Total: 1}, nil)
+
+	page, err := taskService.GetPageByUserId(filter, "", "", 0)
+
+	require.NoError(t, err)
+	require.Len(t, page.Tasks, 1)
+	require.Empty(t, page.NextCursor)
+	require.Equal(t, int64(1), page.Counts.Total)
+}
+
+func TestTaskServiceGetPageByUserId_InvalidSort(t *testing.T) {
+	taskService, _ := mockTaskService(t)
+
+	page, err := taskService.GetPageByUserId(domain.TaskFilter{}, "is_completed", "", 10)
+
+	require.Nil(t, page)
+	require.EqualError(t, err, service.ErrInvalidTaskSort)
+}
+
+func TestTaskServiceGetPageByUserId_InvalidCursor(t *testing.T) {
+	taskService, _ := mockTaskService(t)
+
+	page, err := taskService.GetPageByUserId(domain.TaskFilter{}, "", faker.Word(), 10)
+
+	require.Nil(t, page)
+	require.EqualError(t, err, service.ErrInvalidTaskCursor)
+}
+
+func TestTaskServiceGetPageByUserId_NextPage(t *testing.T) {
+	taskService, taskRepo := mockTaskService(t)
+
+	filter := domain.TaskFilter{UserId: uuid.New()}
+	sort := domain.TaskSort{Field: domain.TaskSortCreatedAt}
+	createdAt := time.Date(2026, 10, 19, 10, 0, 0, 123456000, time.UTC)
+	tasks := []domain.Task{
+		{ID: uuid.New(), CreatedAt: createdAt.Add(-time.Hour)},
+		{ID: uuid.New(), CreatedAt: createdAt},
+		{ID: uuid.New(), CreatedAt: createdAt.Add(time.Hour)},
+	}
+
+	taskRepo.EXPECT().GetPageByUserId(filter, sort, nil, 3).Return(&tasks, nil)
+	taskRepo.EXPECT().CountByUserId(filter).Return(domain.TaskCounts{Total: 5}, nil).Times(2)
+
+	page, err := taskService.GetPageByUserId(filter, "created_at", "", 2)
+
+	require.NoError(t, err)
+	require.Len(t, page.Tasks, 2)
+	require.NotEmpty(t, page.NextCursor)
+
+	after := &domain.TaskCursor{Value: createdAt.Format(time.RFC3339Nano), Id: tasks[1].ID}
+
+	taskRepo.EXPECT().GetPageByUserId(filter, sort, after, 3).Return(&[]domain.Task{tasks[2]}, nil)
+
+	page, err = taskService.GetPageByUserId(filter, "created_at", page.NextCursor, 2)
+
+	require.NoError(t, err)
+	require.Len(t, page.Tasks, 1)
+	require.Empty(t, page.NextCursor)
+}
+
+func TestTaskServiceGetPageByUserId_CursorOfAnotherSort(t *testing.T) {
+	taskService, taskRepo := mockTaskService(t)
+
+	filter := domain.TaskFilter{UserId: uuid.New()}
+
+	taskRepo.EXPECT().GetPageByUserId(gomock.Any(), gomock.Any(), nil, 2).
+		Return(&[]domain.Task{{ID: uuid.New()}, {ID: uuid.New()}}, nil)
+	taskRepo.EXPECT().CountByUserId(filter).Return(domain.TaskCounts{Total: 2}, nil)
+
+	page, err := taskService.GetPageByUserId(filter, "description", "", 1)
+	require.NoError(t, err)
+
+	page, err = taskService.GetPageByUserId(filter, "-description", page.NextCursor, 1)
+
+	require.Nil(t, page)
+	require.EqualError(t, err, service.ErrInvalidTaskCursor)
+}
+
+func TestTaskServiceMove_KeepsCompletion(t *testing.T) {
+	taskService, taskRepo := mockTaskService(t)
+
+	taskId, listId := twoUuids(t)
+	isCompleted := true
+
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, IsCompleted: &isCompleted}, nil)
+	taskRepo.EXPECT().Move(taskId, &listId, doneStatusId).Return(nil)
+
+	movedTask, err := taskService.Move(taskId, &listId)
+
+	require.NoError(t, err)
+	require.Equal(t, listId, *movedTask.ListId)
+	require.Equal(t, doneStatusId, *movedTask.StatusId)
+}
+
+func TestTaskServiceMove_Failed(t *testing.T) {
+	taskService, taskRepo := mockTaskService(t)
+
+	taskId, _ := twoUuids(t)
+
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId}, nil)
+	taskRepo.EXPECT().Move(taskId, nil, openStatusId).Return(errors.New("failed"))
+
+	movedTask, err := taskService.Move(taskId, nil)
+
+	require.Error(t, err)
+	require.Nil(t, movedTask)
+}
+
+func TestTaskServiceMove_UnassignsWithoutAccess(t *testing.T) {
+	taskService, taskRepo, taskHistoryRepo := mockTaskServiceWithHistory(t)
+
+	taskId, listId := twoUuids(t)
+	authorId, assigneeId := twoUuids(t)
+
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, UserId: authorId, AssigneeId: &assigneeId, Version: 1}, nil)
+	taskRepo.EXPECT().UpdateColumns(taskId, map[string]interface{}{
+		"list_id": &listId, "status_id": openStatusId, "assignee_id": nil,
+	}).Return(nil)
+	taskHistoryRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(entries []domain.TaskHistory) error {
+		require.Len(t, entries, 3)
+		require.Equal(t, domain.TaskFieldAssigneeId, entries[2].Field)
+		require.Nil(t, entries[2].NewValue)
+		return nil
+	})
+
+	movedTask, err := taskService.Move(taskId, &listId)
+
+	require.NoError(t, err)
+	require.Nil(t, movedTask.AssigneeId)
+}
+
+func TestTaskServiceMove_KeepsAssigneeWithAccess(t *testing.T) {
+	taskService, taskRepo := mockTaskService(t)
+
+	taskId, authorId := twoUuids(t)
+
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, UserId: authorId, AssigneeId: &authorId}, nil)
+	taskRepo.EXPECT().Move(taskId, nil, openStatusId).Return(nil)
+
+	movedTask, err := taskService.Move(taskId, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, authorId, *movedTask.AssigneeId)
+}
+
+func TestTaskServiceUpdateDescription_RecordsHistory(t *testing.T) {
+	taskService, taskRepo, taskHistoryRepo := mockTaskServiceWithHistory(t)
+
+	taskId, actorId := twoUuids(t)
+
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, Description: "old", Version: 3}, nil)
+	taskRepo.EXPECT().Update(gomock.Any()).Return(&domain.Task{ID: taskId, Description: "new"}, nil)
+	taskHistoryRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(entries []domain.TaskHistory) error {
+		require.Len(t, entries, 1)
+		require.Equal(t, 4, entries[0].Revision)
+		require.Equal(t, actorId, *entries[0].ActorId)
+		require.Equal(t, domain.TaskFieldDescription, entries[0].Field)
+		require.Equal(t, "old", *entries[0].OldValue)
+		require.Equal(t, "new", *entries[0].NewValue)
+
+		return nil
+	})
+
+	_, err := taskService.WithActor(actorId).UpdateDescription(taskId, "new")
+
+	require.NoError(t, err)
+}
+
+func TestTaskServiceUpdateIsCompleted_RecordsOnlyChangedFields(t *testing.T) {
+	taskService, taskRepo, taskHistoryRepo := mockTaskServiceWithHistory(t)
+
+	taskId, _ := twoUuids(t)
+	isCompleted := false
+
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, IsCompleted: &isCompleted, StatusId: &openStatusId, Version: 1}, nil)
+	taskRepo.EXPECT().Update(gomock.Any()).Return(&domain.Task{ID: taskId}, nil)
+	taskHistoryRepo.EXPECT().Create([]domain.TaskHistory{}).Return(nil)
+
+	_, err := taskService.UpdateIsCompleted(taskId, false)
+
+	require.NoError(t, err)
+}
+
+func TestTaskServiceUpdateIsCompleted_WritesCompletedEvent(t *testing.T) {
+	taskService, taskRepo, outboxEventRepo := mockTaskServiceWithEvents(t)
+
+	taskId, actorId := twoUuids(t)
+	userId, listId := twoUuids(t)
+	isCompleted := false
+
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{
+		ID: taskId, UserId: userId, ListId: &listId, IsCompleted: &isCompleted, StatusId: &openStatusId, Version: 1,
+	}, nil)
+	taskRepo.EXPECT().Update(gomock.Any()).Return(&domain.Task{ID: taskId}, nil)
+	outboxEventRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(events []domain.OutboxEvent) error {
+		require.Len(t, events, 1)
+		require.Equal(t, domain.TaskEventCompleted, events[0].Type)
+		require.Equal(t, taskId, events[0].AggregateId)
+
+		var event domain.TaskEvent
+		require.NoError(t, json.Unmarshal([]byte(events[0].Payload), &event))
+		require.Equal(t, events[0].ID, event.ID)
+		require.Equal(t, 2, event.Revision)
+		require.Equal(t, actorId, *event.ActorId)
+		require.Equal(t, userId, event.UserId)
+		require.Equal(t, listId, *event.ListId)
+		require.Len(t, event.Changes, 2)
+
+		return nil
+	})
+
+	_, err := taskService.WithActor(actorId).UpdateIsCompleted(taskId, true)
+
+	require.NoError(t, err)
+}
+
+func TestTaskServiceUpdateDescription_WritesUpdatedEvent(t *testing.T) {
+	taskService, taskRepo, outboxEventRepo := mockTaskServiceWithEvents(t)
+
+	taskId, _ := twoUuids(t)
+
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, Description: "old", Version: 1}, nil)
+	taskRepo.EXPECT().Update(gomock.Any()).Return(&domain.Task{ID: taskId, Description: "new"}, nil)
+	outboxEventRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(events []domain.OutboxEvent) error {
+		require.Len(t, events, 1)
+		require.Equal(t, domain.TaskEventUpdated, events[0].Type)
+
+		return nil
+	})
+
+	_, err := taskService.UpdateDescription(taskId, "new")
+
+	require.NoError(t, err)
+}
+
+func TestTaskServiceUpdateDescription_OutboxFailed(t *testing.T) {
+	taskService, taskRepo, outboxEventRepo := mockTaskServiceWithEvents(t)
+
+	taskId, _ := twoUuids(t)
+
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, Description: "old", Version: 1}, nil)
+	taskRepo.EXPECT().Update(gomock.Any()).Return(&domain.Task{ID: taskId, Description: "new"}, nil)
+	outboxEventRepo.EXPECT().Create(gomock.Any()).Return(errors.New("failed"))
+
+	task, err := taskService.UpdateDescription(taskId, "new")
+
+	require.Error(t, err)
+	require.Nil(t, task)
+}
+
+func TestTaskServiceUpdateDescription_TransactionFailed(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	transactor := mock_repository.NewMockTransactor(mockCtl)
+	transactor.EXPECT().Transaction(gomock.Any()).Return(errors.New("failed"))
+
+	taskService := service.NewTaskService(nil, nil, nil, nil, nil, nil, nil, transactor, false)
+
+	_, err := taskService.UpdateDescription(uuid.New(), "new")
+
+	require.Error(t, err)
+}
+
+func TestTaskServiceHistory_GroupedByRevision(t *testing.T) {
+	taskService, _, taskHistoryRepo := mockTaskServiceWithHistory(t)
+
+	taskId, _ := twoUuids(t)
+
+	taskHistoryRepo.EXPECT().GetByTaskId(taskId).Return(&[]domain.TaskHistory{
+		{Revision: 2, Field: domain.TaskFieldIsCompleted},
+		{Revision: 2, Field: domain.TaskFieldStatusId},
+		{Revision: 1, Field: domain.TaskFieldDescription},
+	})
+
+	revisions := taskService.History(taskId)
+
+	require.Len(t, revisions, 2)
+	require.Equal(t, 2, revisions[0].Revision)
+	require.Len(t, revisions[0].Changes, 2)
+	require.Equal(t, domain.TaskFieldDescription, revisions[1].Changes[0].Field)
+}
+
+func TestTaskServiceRevert_RevisionNotFound(t *testing.T) {
+	taskService, taskRepo, _ := mockTaskServiceWithHistory(t)
+
+	taskId, _ := twoUuids(t)
+
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, Version: 3}, nil).Times(2)
+
+	_, err := taskService.Revert(taskId, 3)
+	require.EqualError(t, err, service.ErrRevisionNotFound)
+
+	_, err = taskService.Revert(taskId, 0)
+	require.EqualError(t, err, service.ErrRevisionNotFound)
+}
+
+func TestTaskServiceRevert_AssigneeWithoutAccess(t *testing.T) {
+	taskService, taskRepo, taskHistoryRepo := mockTaskServiceWithHistory(t)
+
+	taskId, listId := twoUuids(t)
+	_, assigneeId := twoUuids(t)
+	assignee := assigneeId.String()
+
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, ListId: &listId, Version: 3}, nil)
+	taskHistoryRepo.EXPECT().GetByTaskId(taskId).Return(&[]domain.TaskHistory{
+		{Revision: 3, Field: domain.TaskFieldAssigneeId, OldValue: &assignee, NewValue: nil},
+	})
+
+	revertedTask, err := taskService.Revert(taskId, 2)
+
+	require.Nil(t, revertedTask)
+	require.EqualError(t, err, service.ErrAssigneeNotMember)
+}
+
+func TestTaskServiceRevert_UnassignsWithoutAccess(t *testing.T) {
+	taskService, taskRepo, taskHistoryRepo := mockTaskServiceWithHistory(t)
+
+	taskId, listId := twoUuids(t)
+	authorId, assigneeId := twoUuids(t)
+	list := listId.String()
+
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, UserId: authorId, AssigneeId: &assigneeId, Version: 3}, nil)
+	taskHistoryRepo.EXPECT().GetByTaskId(taskId).Return(&[]domain.TaskHistory{
+		{Revision: 3, Field: domain.TaskFieldListId, OldValue: &list, NewValue: nil},
+	})
+	taskRepo.EXPECT().UpdateColumns(taskId, map[string]interface{}{
+		domain.TaskFieldListId:     listId,
+		domain.TaskFieldAssigneeId: nil,
+	}).Return(nil)
+	taskHistoryRepo.EXPECT().Create(gomock.Any()).Return(nil)
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, ListId: &listId, Version: 4}, nil)
+
+	revertedTask, err := taskService.Revert(taskId, 2)
+
+	require.NoError(t, err)
+	require.Nil(t, revertedTask.AssigneeId)
+}
+
+func TestTaskServiceRevert_Success(t *testing.T) {
+	taskService, taskRepo, taskHistoryRepo := mockTaskServiceWithHistory(t)
+
+	taskId, _ := twoUuids(t)
+	first, second, third, estimate, isCompleted := "first", "second", "third", 30, true
+	open, done, estimateValue := openStatusId.String(), doneStatusId.String(), "30"
+	incompleteValue, completeValue := "false", "true"
+
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{
+		ID: taskId, Description: third, IsCompleted: &isCompleted, StatusId: &doneStatusId, EstimateMinutes: &estimate, Version: 4,
+	}, nil)
+	taskHistoryRepo.EXPECT().GetByTaskId(taskId).Return(&[]domain.TaskHistory{
+		{Revision: 4, Field: domain.TaskFieldIsCompleted, OldValue: &incompleteValue, NewValue: &completeValue},
+		{Revision: 4, Field: domain.TaskFieldStatusId, OldValue: &open, NewValue: &done},
+		{Revision: 3, Field: domain.TaskFieldDescription, OldValue: &second, NewValue: &third},
+		{Revision: 3, Field: domain.TaskFieldEstimateMinutes, OldValue: nil, NewValue: &estimateValue},
+		{Revision: 2, Field: domain.TaskFieldDescription, OldValue: &first, NewValue: &second},
+	})
+	taskRepo.EXPECT().UpdateColumns(taskId, map[string]interface{}{
+		domain.TaskFieldDescription:     second,
+		domain.TaskFieldIsCompleted:     false,
+		domain.TaskFieldStatusId:        openStatusId,
+		domain.TaskFieldEstimateMinutes: nil,
+	}).Return(nil)
+	taskHistoryRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(entries []domain.TaskHistory) error {
+		require.Len(t, entries, 4)
+		require.Equal(t, 5, entries[0].Revision)
+
+		return nil
+	})
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, Description: second, Version: 5}, nil)
+
+	task, err := taskService.Revert(taskId, 2)
+
+	require.NoError(t, err)
+	require.Equal(t, 5, task.Version)
+}
+
 func mockTaskService(t *testing.T) (*service.TaskService, *mock_repository.MockTask) {
 	t.Helper()
 
@@ -213,8 +919,132 @@ func mockTaskService(t *testing.T) (*service.TaskService, *mock_repository.MockT
 	defer mockCtl.Finish()
 
 	taskRepo := mock_repository.NewMockTask(mockCtl)
+	taskDependencyRepo := mock_repository.NewMockTaskDependency(mockCtl)
+	statusRepo := mockStatusRepoWithDefaults(mockCtl)
+	listRepo := mockListRepoWithoutLists(mockCtl)
+	taskHistoryRepo := mockTaskHistoryRepo(mockCtl)
 
-	taskService := service.NewTaskService(taskRepo)
+	taskService := newTaskServiceInTransaction(mockCtl, &repository.Repositories{
+		Task: taskRepo, TaskDependency: taskDependencyRepo, Status: statusRepo, List: listRepo, TaskHistory: taskHistoryRepo,
+		OutboxEvent: mockOutboxEventRepo(mockCtl),
+	}, false)
 
 	return taskService, taskRepo
 }
+
+func mockStrictTaskService(t *testing.T) (*service.TaskService, *mock_repository.MockTask, *mock_repository.MockTaskDependency) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	taskRepo := mock_repository.NewMockTask(mockCtl)
+	taskDependencyRepo := mock_repository.NewMockTaskDependency(mockCtl)
+	statusRepo := mockStatusRepoWithDefaults(mockCtl)
+	listRepo := mockListRepoWithoutLists(mockCtl)
+	taskHistoryRepo := mockTaskHistoryRepo(mockCtl)
+
+	taskService := newTaskServiceInTransaction(mockCtl, &repository.Repositories{
+		Task: taskRepo, TaskDependency: taskDependencyRepo, Status: statusRepo, List: listRepo, TaskHistory: taskHistoryRepo,
+		OutboxEvent: mockOutboxEventRepo(mockCtl),
+	}, true)
+
+	return taskService, taskRepo, taskDependencyRepo
+}
+
+func mockTaskServiceWithHistory(t *testing.T) (*service.TaskService, *mock_repository.MockTask, *mock_repository.MockTaskHistory) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	taskRepo := mock_repository.NewMockTask(mockCtl)
+	taskDependencyRepo := mock_repository.NewMockTaskDependency(mockCtl)
+	statusRepo := mockStatusRepoWithDefaults(mockCtl)
+	listRepo := mockListRepoWithoutLists(mockCtl)
+	taskHistoryRepo := mock_repository.NewMockTaskHistory(mockCtl)
+
+	taskService := newTaskServiceInTransaction(mockCtl, &repository.Repositories{
+		Task: taskRepo, TaskDependency: taskDependencyRepo, Status: statusRepo, List: listRepo, TaskHistory: taskHistoryRepo,
+		OutboxEvent: mockOutboxEventRepo(mockCtl),
+	}, false)
+
+	return taskService, taskRepo, taskHistoryRepo
+}
+
+func mockTaskServiceWithEvents(t *testing.T) (*service.TaskService, *mock_repository.MockTask, *mock_repository.MockOutboxEvent) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	taskRepo := mock_repository.NewMockTask(mockCtl)
+	taskDependencyRepo := mock_repository.NewMockTaskDependency(mockCtl)
+	statusRepo := mockStatusRepoWithDefaults(mockCtl)
+	listRepo := mockListRepoWithoutLists(mockCtl)
+	outboxEventRepo := mock_repository.NewMockOutboxEvent(mockCtl)
+
+	taskService := newTaskServiceInTransaction(mockCtl, &repository.Repositories{
+		Task: taskRepo, TaskDependency: taskDependencyRepo, Status: statusRepo, List: listRepo, TaskHistory: mockTaskHistoryRepo(mockCtl),
+		OutboxEvent: outboxEventRepo,
+	}, false)
+
+	return taskService, taskRepo, outboxEventRepo
+}
+
+// mockListRepoWithoutLists возвращает репозиторий, в котором не находится ни один список
+func mockListRepoWithoutLists(mockCtl *gomock.Controller) *mock_repository.MockList {
+	listRepo := mock_repository.NewMockList(mockCtl)
+
+	listRepo.EXPECT().FindById(gomock.Any()).Return(nil, errors.New("record not found")).AnyTimes()
+
+	return listRepo
+}
+
+// mockTaskHistoryRepo возвращает репозиторий истории, принимающий любые записи
+func mockTaskHistoryRepo(mockCtl *gomock.Controller) *mock_repository.MockTaskHistory {
+	taskHistoryRepo := mock_repository.NewMockTaskHistory(mockCtl)
+
+	taskHistoryRepo.EXPECT().Create(gomock.Any()).Return(nil).AnyTimes()
+
+	return taskHistoryRepo
+}
+
+// mockOutboxEventRepo возвращает репозиторий outbox, принимающий любые события
+func mockOutboxEventRepo(mockCtl *gomock.Controller) *mock_repository.MockOutboxEvent {
+	outboxEventRepo := mock_repository.NewMockOutboxEvent(mockCtl)
+
+	outboxEventRepo.EXPECT().Create(gomock.Any()).Return(nil).AnyTimes()
+
+	return outboxEventRepo
+}
+
+// newTaskServiceInTransaction создает сервис задач, изменения которого выполняются в транзакции
+// над теми же репозиториями
+func newTaskServiceInTransaction(mockCtl *gomock.Controller, repos *repository.Repositories, forbidBlockedCompletion bool) *service.TaskService {
+	transactor := mock_repository.NewMockTransactor(mockCtl)
+
+	transactor.EXPECT().Transaction(gomock.Any()).DoAndReturn(func(fn func(repos *repository.Repositories) error) error {
+		return fn(repos)
+	}).AnyTimes()
+
+	return service.NewTaskService(
+		repos.Task, repos.TaskDependency, repos.Status, repos.List, repos.ListMember, repos.TaskHistory, repos.OutboxEvent, transactor, forbidBlockedCompletion,
+	)
+}
+
+var (
+	openStatusId = uuid.MustParse("3f0c9b2e-6c1a-4d2b-9f7e-1a2b3c4d5e6f")
+	doneStatusId = uuid.MustParse("7a8b9c0d-1e2f-4a5b-8c6d-7e8f9a0b1c2d")
+)
+
+func mockStatusRepoWithDefaults(mockCtl *gomock.Controller) *mock_repository.MockStatus {
+	statusRepo := mock_repository.NewMockStatus(mockCtl)
+
+	statusRepo.EXPECT().GetByScope(gomock.Any(), gomock.Any()).Return(&[]domain.Status{
+		{ID: openStatusId, Name: "Backlog", Position: 0},
+		{ID: doneStatusId, Name: "Done", Position: 1, IsDone: true},
+	}).AnyTimes()
+
+	return statusRepo
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"time"
+)
+
+const (
+	ErrTimerIsRunning         = "another timer is already running"
+	ErrTimerIsNotRunning      = "timer is not running"
+	ErrTimeEntryInvalidPeriod = "time entry must end after it starts"
+	ErrTimeReportInvalidGroup = "unknown report grouping"
+)
+
+type TimeEntryService struct {
+	timeEntryRepo repository.TimeEntry
+	now           func() time.Time
+}
+
+func NewTimeEntryService(timeEntryRepo repository.TimeEntry) *TimeEntryService {
+	return &TimeEntryService{timeEntryRepo: timeEntryRepo, now: func() time.Time { return time.Now().UTC() }}
+}
+
+func (s *TimeEntryService) Start(taskId, userId uuid.UUID) (*domain.TimeEntry, error) {
+	entry, err := s.timeEntryRepo.Start(&domain.TimeEntry{TaskId: taskId, UserId: userId, StartedAt: s.now()})
+
+	if errors.Is(err, repository.ErrTimerIsRunning) {
+		return nil, errors.New(ErrTimerIsRunning)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (s *TimeEntryService) Stop(taskId, userId uuid.UUID) (*domain.TimeEntry, error) {
+	entry, err := s.timeEntryRepo.FindRunningByUserId(userId)
+
+	if err != nil || entry.TaskId != taskId {
+		return nil, errors.New(ErrTimerIsNotRunning)
+	}
+
+	stoppedAt := s.now()
+	entry.StoppedAt = &stoppedAt
+
+	return s.timeEntryRepo.Update(entry)
+}
+
+func (s *TimeEntryService) CreateManual(taskId, userId uuid.UUID, startedAt, stoppedAt time.Time, note string) (*domain.TimeEntry, error) {
+	if !stoppedAt.After(startedAt) {
+		return nil, errors.New(ErrTimeEntryInvalidPeriod)
+	}
+
+	startedAt, stoppedAt = startedAt.UTC(), stoppedAt.UTC()
+
+	return s.timeEntryRepo.Create(&domain.TimeEntry{
+		TaskId:    taskId,
+		UserId:    userId,
+		StartedAt: startedAt,
+		StoppedAt: &stoppedAt,
+		Note:      note,
+	})
+}
+
+func (s *TimeEntryService) Delete(id uuid.UUID) error {
+	return s.timeEntryRepo.Delete(id)
+}
+
+func (s *TimeEntryService) FindById(id uuid.UUID) (*domain.TimeEntry, error) {
+	return s.timeEntryRepo.FindById(id)
+}
+
+func (s *TimeEntryService) GetAllByTaskId(taskId uuid.UUID) *[]domain.TimeEntry {
+	return s.timeEntryRepo.GetAllByTaskId(taskId)
+}
+
+// GetTrackedTime возвращает суммарное учтенное время задачи с учетом запущенного таймера
+func (s *TimeEntryService) GetTrackedTime(entries []domain.TimeEntry) time.Duration {
+	var total time.Duration
+	now := s.now()
+
+	for _, entry := range entries {
+		total += entry.Duration(now)
+	}
+
+	return total
+}
+
+// GetReport строит отчет по учтенному времени за дни с from по to включительно
+func (s *TimeEntryService) GetReport(userId uuid.UUID, from, to time.Time, groupBy string) (*[]domain.TimeReportRow, error) {
+	switch groupBy {
+	case repository.TimeReportGroupByList, repository.TimeReportGroupByTag, repository.TimeReportGroupByDay:
+	default:
+		return nil, errors.New(ErrTimeReportInvalidGroup)
+	}
+
+	if to.Before(from) {
+		return nil, errors.New(ErrTimeEntryInvalidPeriod)
+	}
+
+	return s.timeEntryRepo.GetReport(userId, from, to.AddDate(0, 0, 1), groupBy)
+}
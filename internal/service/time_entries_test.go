@@ -0,0 +1,189 @@
+package service_test
+
+import (
+	"errors"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	"testing"
+	"time"
+)
+
+func TestTimeEntryServiceStart_AlreadyRunning(t *testing.T) {
+	timeEntryService, timeEntryRepo := mockTimeEntryService(t)
+
+	taskId, userId := twoUuids(t)
+
+	timeEntryRepo.EXPECT().Start(gomock.Any()).Return(nil, repository.ErrTimerIsRunning)
+
+	entry, err := timeEntryService.Start(taskId, userId)
+
+	require.Nil(t, entry)
+	require.EqualError(t, err, service.ErrTimerIsRunning)
+}
+
+func TestTimeEntryServiceStart_Success(t *testing.T) {
+	timeEntryService, timeEntryRepo := mockTimeEntryService(t)
+
+	taskId, userId := twoUuids(t)
+
+	timeEntryRepo.EXPECT().Start(gomock.Any()).DoAndReturn(func(entry *domain.TimeEntry) (*domain.TimeEntry, error) {
+		return entry, nil
+	})
+
+	entry, err := timeEntryService.Start(taskId, userId)
+
+	require.NoError(t, err)
+	require.Equal(t, taskId, entry.TaskId)
+	require.Equal(t, userId, entry.UserId)
+	require.Nil(t, entry.StoppedAt)
+}
+
+func TestTimeEntryServiceStop_NotRunning(t *testing.T) {
+	timeEntryService, timeEntryRepo := mockTimeEntryService(t)
+
+	taskId, userId := twoUuids(t)
+
+	timeEntryRepo.EXPECT().FindRunningByUserId(userId).Return(nil, gorm.ErrRecordNotFound)
+
+	entry, err := timeEntryService.Stop(taskId, userId)
+
+	require.Nil(t, entry)
+	require.EqualError(t, err, service.ErrTimerIsNotRunning)
+}
+
+func TestTimeEntryServiceStop_OtherTask(t *testing.T) {
+	timeEntryService, timeEntryRepo := mockTimeEntryService(t)
+
+	taskId, userId := twoUuids(t)
+	otherTaskId, _ := twoUuids(t)
+
+	timeEntryRepo.EXPECT().FindRunningByUserId(userId).Return(&domain.TimeEntry{TaskId: otherTaskId}, nil)
+
+	entry, err := timeEntryService.Stop(taskId, userId)
+
+	require.Nil(t, entry)
+	require.EqualError(t, err, service.ErrTimerIsNotRunning)
+}
+
+func TestTimeEntryServiceStop_Success(t *testing.T) {
+	timeEntryService, timeEntryRepo := mockTimeEntryService(t)
+
+	taskId, userId := twoUuids(t)
+	running := &domain.TimeEntry{TaskId: taskId, UserId: userId, StartedAt: time.Now().Add(-time.Hour)}
+
+	timeEntryRepo.EXPECT().FindRunningByUserId(userId).Return(running, nil)
+	timeEntryRepo.EXPECT().Update(running).Return(running, nil)
+
+	entry, err := timeEntryService.Stop(taskId, userId)
+
+	require.NoError(t, err)
+	require.NotNil(t, entry.StoppedAt)
+}
+
+func TestTimeEntryServiceCreateManual_InvalidPeriod(t *testing.T) {
+	timeEntryService, _ := mockTimeEntryService(t)
+
+	taskId, userId := twoUuids(t)
+	startedAt := time.Now()
+
+	entry, err := timeEntryService.CreateManual(taskId, userId, startedAt, startedAt.Add(-time.Minute), faker.Sentence())
+
+	require.Nil(t, entry)
+	require.EqualError(t, err, service.ErrTimeEntryInvalidPeriod)
+}
+
+func TestTimeEntryServiceCreateManual_Success(t *testing.T) {
+	timeEntryService, timeEntryRepo := mockTimeEntryService(t)
+
+	taskId, userId := twoUuids(t)
+	startedAt := time.Now()
+
+	timeEntryRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(entry *domain.TimeEntry) (*domain.TimeEntry, error) {
+		return entry, nil
+	})
+
+	entry, err := timeEntryService.CreateManual(taskId, userId, startedAt, startedAt.Add(time.Hour), "")
+
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, entry.Duration(time.Now()))
+}
+
+func TestTimeEntryServiceGetTrackedTime_WithRunningTimer(t *testing.T) {
+	timeEntryService, _ := mockTimeEntryService(t)
+
+	now := time.Now()
+	stoppedAt := now.Add(-time.Hour)
+
+	total := timeEntryService.GetTrackedTime([]domain.TimeEntry{
+		{StartedAt: now.Add(-2 * time.Hour), StoppedAt: &stoppedAt},
+		{StartedAt: now.Add(-30 * time.Minute)},
+	})
+
+	require.GreaterOrEqual(t, total, 90*time.Minute)
+}
+
+func TestTimeEntryServiceGetReport_InvalidGroup(t *testing.T) {
+	timeEntryService, _ := mockTimeEntryService(t)
+
+	userId, _ := twoUuids(t)
+
+	rows, err := timeEntryService.GetReport(userId, time.Now(), time.Now(), faker.Word())
+
+	require.Nil(t, rows)
+	require.EqualError(t, err, service.ErrTimeReportInvalidGroup)
+}
+
+func TestTimeEntryServiceGetReport_InvalidPeriod(t *testing.T) {
+	timeEntryService, _ := mockTimeEntryService(t)
+
+	userId, _ := twoUuids(t)
+
+	rows, err := timeEntryService.GetReport(userId, time.Now(), time.Now().AddDate(0, 0, -1), repository.TimeReportGroupByDay)
+
+	require.Nil(t, rows)
+	require.EqualError(t, err, service.ErrTimeEntryInvalidPeriod)
+}
+
+func TestTimeEntryServiceGetReport_InclusiveEnd(t *testing.T) {
+	timeEntryService, timeEntryRepo := mockTimeEntryService(t)
+
+	userId, _ := twoUuids(t)
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 7, 0, 0, 0, 0, time.UTC)
+
+	timeEntryRepo.EXPECT().GetReport(userId, from, to.AddDate(0, 0, 1), repository.TimeReportGroupByList).
+		Return(&[]domain.TimeReportRow{}, nil)
+
+	rows, err := timeEntryService.GetReport(userId, from, to, repository.TimeReportGroupByList)
+
+	require.NoError(t, err)
+	require.Empty(t, *rows)
+}
+
+func TestTimeEntryServiceDelete_Failed(t *testing.T) {
+	timeEntryService, timeEntryRepo := mockTimeEntryService(t)
+
+	entryId, _ := twoUuids(t)
+
+	timeEntryRepo.EXPECT().Delete(entryId).Return(errors.New("failed"))
+
+	require.Error(t, timeEntryService.Delete(entryId))
+}
+
+func mockTimeEntryService(t *testing.T) (*service.TimeEntryService, *mock_repository.MockTimeEntry) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	timeEntryRepo := mock_repository.NewMockTimeEntry(mockCtl)
+	timeEntryService := service.NewTimeEntryService(timeEntryRepo)
+
+	return timeEntryService, timeEntryRepo
+}
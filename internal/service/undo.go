@@ -0,0 +1,114 @@
+package service
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"time"
+)
+
+const (
+	ErrNothingToUndo    = "nothing to undo"
+	ErrUndoTaskModified = "task was modified after the last action"
+	ErrUndoAccessDenied = "insufficient permissions"
+)
+
+// UndoResult - отмененное действие. Task - состояние задачи после отмены, nil если задача оказалась в корзине.
+type UndoResult struct {
+	TaskId   uuid.UUID
+	Revision int
+	Task     *domain.Task
+}
+
+type UndoService struct {
+	transactor              repository.Transactor
+	window                  time.Duration
+	forbidBlockedCompletion bool
+}
+
+func NewUndoService(transactor repository.Transactor, window time.Duration, forbidBlockedCompletion bool) *UndoService {
+	return &UndoService{transactor: transactor, window: window, forbidBlockedCompletion: forbidBlockedCompletion}
+}
+
+// Undo отменяет последнее действие пользователя с задачами, сделанное не раньше окна отмены.
+// Отмененное действие и сама отмена исключаются из стека, поэтому повторный вызов отменяет предыдущее действие.
+// Пользователь должен по-прежнему иметь право изменять задачу: если доступ к ней пропал, возвращается
+// ErrTaskNotFound, если роль понижена до просмотра - ErrUndoAccessDenied.
+func (s *UndoService) Undo(userId uuid.UUID) (*UndoResult, error) {
+	var result *UndoResult
+
+	err := s.transactor.Transaction(func(repos *repository.Repositories) error {
+		last, err := repos.TaskHistory.FindLastByActorId(userId, time.Now().Add(-s.window))
+
+		if err != nil {
+			return errors.New(ErrNothingToUndo)
+		}
+
+		task, err := repos.Task.FindWithTrashedById(last.TaskId)
+
+		if err != nil {
+			return errors.New(ErrNothingToUndo)
+		}
+
+		role := taskRole(repos.List, repos.ListMember, task, userId)
+
+		if role == "" {
+			return errors.New(ErrTaskNotFound)
+		}
+
+		if !domain.ListRoleAllows(role, domain.ListRoleEditor) {
+			return errors.New(ErrUndoAccessDenied)
+		}
+
+		if task.Version != last.Revision {
+			return errors.New(ErrUndoTaskModified)
+		}
+
+		taskService := NewTaskService(repos.Task, repos.TaskDependency, repos.Status, repos.List, repos.ListMember, repos.TaskHistory, repos.OutboxEvent, nil, s.forbidBlockedCompletion).
+			withActor(userId)
+
+		if err = undoRevision(taskService, task, *repos.TaskHistory.GetByRevision(task.ID, last.Revision)); err != nil {
+			return err
+		}
+
+		if err = repos.TaskHistory.MarkUndone(task.ID, []int{last.Revision, last.Revision + 1}); err != nil {
+			return err
+		}
+
+		result = &UndoResult{TaskId: task.ID, Revision: last.Revision}
+		result.Task, _ = repos.Task.FindById(task.ID)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// undoRevision выполняет действие, обратное ревизии задачи task с изменениями entries: удаление в корзину
+// отменяется восстановлением и наоборот, создание - удалением в корзину, изменение полей - возвратом к предыдущей ревизии
+func undoRevision(taskService *TaskService, task *domain.Task, entries []domain.TaskHistory) error {
+	for _, entry := range entries {
+		if entry.Field != domain.TaskFieldDeleted {
+			continue
+		}
+
+		if entry.NewValue != nil && *entry.NewValue == "true" {
+			return taskService.Restore(task.ID)
+		}
+
+		return taskService.Delete(task.ID)
+	}
+
+	if task.Version == 1 {
+		return taskService.Delete(task.ID)
+	}
+
+	_, err := taskService.Revert(task.ID, task.Version-1)
+
+	return err
+}
@@ -0,0 +1,218 @@
+package service_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	"testing"
+	"time"
+)
+
+func TestUndoServiceUndo_NothingToUndo(t *testing.T) {
+	undoService, taskRepo, taskHistoryRepo := mockUndoService(t)
+
+	userId, _ := twoUuids(t)
+
+	taskHistoryRepo.EXPECT().FindLastByActorId(userId, gomock.Any()).Return(nil, errors.New("record not found"))
+	taskRepo.EXPECT().FindWithTrashedById(gomock.Any()).Times(0)
+
+	result, err := undoService.Undo(userId)
+
+	require.EqualError(t, err, service.ErrNothingToUndo)
+	require.Nil(t, result)
+}
+
+func TestUndoServiceUndo_WindowApplied(t *testing.T) {
+	undoService, _, taskHistoryRepo := mockUndoService(t)
+
+	userId, _ := twoUuids(t)
+
+	taskHistoryRepo.EXPECT().FindLastByActorId(userId, gomock.Any()).
+		DoAndReturn(func(actorId any, since time.Time) (*domain.TaskHistory, error) {
+			require.WithinDuration(t, time.Now().Add(-time.Minute), since, time.Second)
+
+			return nil, errors.New("record not found")
+		})
+
+	_, err := undoService.Undo(userId)
+
+	require.EqualError(t, err, service.ErrNothingToUndo)
+}
+
+func TestUndoServiceUndo_TaskModified(t *testing.T) {
+	undoService, taskRepo, taskHistoryRepo := mockUndoService(t)
+
+	userId, taskId := twoUuids(t)
+
+	taskHistoryRepo.EXPECT().FindLastByActorId(userId, gomock.Any()).Return(&domain.TaskHistory{TaskId: taskId, Revision: 2}, nil)
+	taskRepo.EXPECT().FindWithTrashedById(taskId).Return(&domain.Task{ID: taskId, UserId: userId, Version: 3}, nil)
+
+	_, err := undoService.Undo(userId)
+
+	require.EqualError(t, err, service.ErrUndoTaskModified)
+}
+
+func TestUndoServiceUndo_RemovedFromList(t *testing.T) {
+	undoService, mocks := mockUndoServiceWithLists(t)
+
+	userId, taskId := twoUuids(t)
+	ownerId, listId := twoUuids(t)
+
+	mocks.taskHistoryRepo.EXPECT().FindLastByActorId(userId, gomock.Any()).Return(&domain.TaskHistory{TaskId: taskId, Revision: 2}, nil)
+	mocks.taskRepo.EXPECT().FindWithTrashedById(taskId).Return(&domain.Task{ID: taskId, UserId: userId, ListId: &listId, Version: 2}, nil)
+	mocks.listRepo.EXPECT().FindById(listId).Return(&domain.List{ID: listId, UserId: ownerId}, nil)
+	mocks.listMemberRepo.EXPECT().Find(listId, userId).Return(nil, errors.New("record not found"))
+	mocks.taskHistoryRepo.EXPECT().GetByRevision(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := undoService.Undo(userId)
+
+	require.EqualError(t, err, service.ErrTaskNotFound)
+}
+
+func TestUndoServiceUndo_DemotedToViewer(t *testing.T) {
+	undoService, mocks := mockUndoServiceWithLists(t)
+
+	userId, taskId := twoUuids(t)
+	ownerId, listId := twoUuids(t)
+
+	mocks.taskHistoryRepo.EXPECT().FindLastByActorId(userId, gomock.Any()).Return(&domain.TaskHistory{TaskId: taskId, Revision: 2}, nil)
+	mocks.taskRepo.EXPECT().FindWithTrashedById(taskId).Return(&domain.Task{ID: taskId, UserId: userId, ListId: &listId, Version: 2}, nil)
+	mocks.listRepo.EXPECT().FindById(listId).Return(&domain.List{ID: listId, UserId: ownerId}, nil)
+	mocks.listMemberRepo.EXPECT().Find(listId, userId).Return(&domain.ListMember{Role: domain.ListRoleViewer}, nil)
+	mocks.taskHistoryRepo.EXPECT().GetByRevision(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := undoService.Undo(userId)
+
+	require.EqualError(t, err, service.ErrUndoAccessDenied)
+}
+
+func TestUndoServiceUndo_Delete(t *testing.T) {
+	undoService, taskRepo, taskHistoryRepo := mockUndoService(t)
+
+	userId, taskId := twoUuids(t)
+	deleted, restored := "true", "false"
+	trashedTask := &domain.Task{ID: taskId, UserId: userId, Version: 3, DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}
+
+	taskHistoryRepo.EXPECT().FindLastByActorId(userId, gomock.Any()).Return(&domain.TaskHistory{TaskId: taskId, Revision: 3}, nil)
+	taskRepo.EXPECT().FindWithTrashedById(taskId).Return(trashedTask, nil).Times(2)
+	taskHistoryRepo.EXPECT().GetByRevision(taskId, 3).Return(&[]domain.TaskHistory{
+		{TaskId: taskId, Revision: 3, Field: domain.TaskFieldDeleted, OldValue: &restored, NewValue: &deleted},
+	})
+	taskRepo.EXPECT().Restore(taskId).Return(nil)
+	taskHistoryRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(entries []domain.TaskHistory) error {
+		require.Equal(t, 4, entries[0].Revision)
+		require.Equal(t, userId, *entries[0].ActorId)
+
+		return nil
+	})
+	taskHistoryRepo.EXPECT().MarkUndone(taskId, []int{3, 4}).Return(nil)
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, Version: 4}, nil)
+
+	result, err := undoService.Undo(userId)
+
+	require.NoError(t, err)
+	require.Equal(t, taskId, result.TaskId)
+	require.Equal(t, 3, result.Revision)
+	require.Equal(t, 4, result.Task.Version)
+}
+
+func TestUndoServiceUndo_Create(t *testing.T) {
+	undoService, taskRepo, taskHistoryRepo := mockUndoService(t)
+
+	userId, taskId := twoUuids(t)
+
+	taskHistoryRepo.EXPECT().FindLastByActorId(userId, gomock.Any()).Return(&domain.TaskHistory{TaskId: taskId, Revision: 1}, nil)
+	taskRepo.EXPECT().FindWithTrashedById(taskId).Return(&domain.Task{ID: taskId, UserId: userId, Version: 1}, nil)
+	taskHistoryRepo.EXPECT().GetByRevision(taskId, 1).Return(&[]domain.TaskHistory{
+		{TaskId: taskId, Revision: 1, Field: domain.TaskFieldDescription},
+	})
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, Version: 1}, nil)
+	taskRepo.EXPECT().Delete(taskId).Return(nil)
+	taskHistoryRepo.EXPECT().Create(gomock.Any()).Return(nil)
+	taskHistoryRepo.EXPECT().MarkUndone(taskId, []int{1, 2}).Return(nil)
+	taskRepo.EXPECT().FindById(taskId).Return(nil, errors.New("record not found"))
+
+	result, err := undoService.Undo(userId)
+
+	require.NoError(t, err)
+	require.Nil(t, result.Task)
+}
+
+func TestUndoServiceUndo_Update(t *testing.T) {
+	undoService, taskRepo, taskHistoryRepo := mockUndoService(t)
+
+	userId, taskId := twoUuids(t)
+	before, after := "before", "after"
+	task := &domain.Task{ID: taskId, UserId: userId, Description: after, Version: 2}
+
+	taskHistoryRepo.EXPECT().FindLastByActorId(userId, gomock.Any()).Return(&domain.TaskHistory{TaskId: taskId, Revision: 2}, nil)
+	taskRepo.EXPECT().FindWithTrashedById(taskId).Return(task, nil)
+	taskHistoryRepo.EXPECT().GetByRevision(taskId, 2).Return(&[]domain.TaskHistory{
+		{TaskId: taskId, Revision: 2, Field: domain.TaskFieldDescription, OldValue: &before, NewValue: &after},
+	})
+	taskRepo.EXPECT().FindById(taskId).Return(task, nil)
+	taskHistoryRepo.EXPECT().GetByTaskId(taskId).Return(&[]domain.TaskHistory{
+		{TaskId: taskId, Revision: 2, Field: domain.TaskFieldDescription, OldValue: &before, NewValue: &after},
+	})
+	taskRepo.EXPECT().UpdateColumns(taskId, map[string]interface{}{domain.TaskFieldDescription: before}).Return(nil)
+	taskHistoryRepo.EXPECT().Create(gomock.Any()).Return(nil)
+	taskRepo.EXPECT().FindById(taskId).Return(&domain.Task{ID: taskId, Description: before, Version: 3}, nil).Times(2)
+	taskHistoryRepo.EXPECT().MarkUndone(taskId, []int{2, 3}).Return(nil)
+
+	result, err := undoService.Undo(userId)
+
+	require.NoError(t, err)
+	require.Equal(t, before, result.Task.Description)
+}
+
+type undoMocks struct {
+	taskRepo        *mock_repository.MockTask
+	taskHistoryRepo *mock_repository.MockTaskHistory
+	listRepo        *mock_repository.MockList
+	listMemberRepo  *mock_repository.MockListMember
+}
+
+func mockUndoService(t *testing.T) (*service.UndoService, *mock_repository.MockTask, *mock_repository.MockTaskHistory) {
+	t.Helper()
+
+	undoService, mocks := mockUndoServiceWithLists(t)
+
+	return undoService, mocks.taskRepo, mocks.taskHistoryRepo
+}
+
+func mockUndoServiceWithLists(t *testing.T) (*service.UndoService, undoMocks) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	transactor := mock_repository.NewMockTransactor(mockCtl)
+	mocks := undoMocks{
+		taskRepo:        mock_repository.NewMockTask(mockCtl),
+		taskHistoryRepo: mock_repository.NewMockTaskHistory(mockCtl),
+		listRepo:        mock_repository.NewMockList(mockCtl),
+		listMemberRepo:  mock_repository.NewMockListMember(mockCtl),
+	}
+
+	repos := &repository.Repositories{
+		Transactor:     transactor,
+		Task:           mocks.taskRepo,
+		TaskDependency: mock_repository.NewMockTaskDependency(mockCtl),
+		Status:         mockStatusRepoWithDefaults(mockCtl),
+		List:           mocks.listRepo,
+		ListMember:     mocks.listMemberRepo,
+		TaskHistory:    mocks.taskHistoryRepo,
+		OutboxEvent:    mockOutboxEventRepo(mockCtl),
+	}
+
+	transactor.EXPECT().Transaction(gomock.Any()).DoAndReturn(func(fn func(repos *repository.Repositories) error) error {
+		return fn(repos)
+	}).AnyTimes()
+
+	return service.NewUndoService(transactor, time.Minute, false), mocks
+}
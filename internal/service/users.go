@@ -1,6 +1,7 @@
 package service
 
 import (
+	"github.com/google/uuid"
 	"poymanov/todo/internal/domain"
 	"poymanov/todo/internal/repository"
 )
@@ -32,3 +33,19 @@ func (s *UserService) FindByEmail(email string) (*domain.User, error) {
 
 	return findUser, nil
 }
+
+func (s *UserService) UpdateAutoArchiveDays(id uuid.UUID, days *int) error {
+	return s.userRepo.UpdateAutoArchiveDays(id, days)
+}
+
+func (s *UserService) FindById(id uuid.UUID) (*domain.User, error) {
+	return s.userRepo.FindById(id)
+}
+
+// UpdatePassword сохраняет хеш нового пароля и снимает требование смены пароля
+func (s *UserService) UpdatePassword(id uuid.UUID, hashedPassword string) error {
+	return s.userRepo.UpdateColumns(id, map[string]interface{}{
+		"password":                hashedPassword,
+		"password_reset_required": false,
+	})
+}
@@ -3,6 +3,7 @@ package service_test
 import (
 	"errors"
 	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"poymanov/todo/internal/domain"
@@ -63,6 +64,32 @@ func TestUserServiceFindByEmail_Success(t *testing.T) {
 	require.Equal(t, userData.Name, userFind.Name)
 }
 
+func TestUserServiceUpdateAutoArchiveDays_Success(t *testing.T) {
+	userService, userRepo := mockUserService(t)
+
+	userId, err := uuid.Parse(faker.UUIDHyphenated())
+	require.NoError(t, err)
+
+	days := 14
+
+	userRepo.EXPECT().UpdateAutoArchiveDays(userId, &days).Return(nil)
+
+	require.NoError(t, userService.UpdateAutoArchiveDays(userId, &days))
+}
+
+func TestUserServiceUpdatePassword_Success(t *testing.T) {
+	userService, userRepo := mockUserService(t)
+
+	userId, _ := twoUuids(t)
+
+	userRepo.EXPECT().UpdateColumns(userId, map[string]interface{}{
+		"password":                "hash",
+		"password_reset_required": false,
+	}).Return(nil)
+
+	require.NoError(t, userService.UpdatePassword(userId, "hash"))
+}
+
 func mockUserService(t *testing.T) (*service.UserService, *mock_repository.MockUser) {
 	t.Helper()
 
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	"poymanov/todo/pkg/webhook"
+	"time"
+)
+
+const (
+	ErrWebhookNotFound          = "webhook not found"
+	ErrWebhookDeliveryNotFound  = "webhook delivery not found"
+	ErrInvalidWebhookEvent      = "unknown event type"
+	ErrWebhookEventsRequired    = "at least one event type is required"
+	ErrWebhookDeliveryIsPending = "webhook delivery is still pending"
+	ErrInvalidWebhookURL        = "webhook url must be an http or https url of a public host"
+)
+
+const (
+	webhookDeliveriesLimit = 50
+	webhookDeliveryBatch   = 20
+	// webhookDeliveryLease - на сколько откладывается доставка, взятая в работу, на случай остановки приложения
+	webhookDeliveryLease = 5 * time.Minute
+	// webhookRetryBaseDelay и webhookRetryMaxDelay задают экспоненциальную задержку повторных попыток
+	webhookRetryBaseDelay = 30 * time.Second
+	webhookRetryMaxDelay  = 6 * time.Hour
+)
+
+type WebhookService struct {
+	webhookRepo         repository.Webhook
+	webhookDeliveryRepo repository.WebhookDelivery
+	client              *webhook.Client
+	maxAttempts         int
+}
+
+func NewWebhookService(
+	webhookRepo repository.Webhook,
+	webhookDeliveryRepo repository.WebhookDelivery,
+	client *webhook.Client,
+	maxAttempts int,
+) *WebhookService {
+	return &WebhookService{
+		webhookRepo:         webhookRepo,
+		webhookDeliveryRepo: webhookDeliveryRepo,
+		client:              client,
+		maxAttempts:         maxAttempts,
+	}
+}
+
+func (s *WebhookService) GetAll(userId uuid.UUID) *[]domain.Webhook {
+	return s.webhookRepo.GetByUserId(userId)
+}
+
+// Find возвращает вебхук id, если он принадлежит пользователю userId
+func (s *WebhookService) Find(id, userId uuid.UUID) (*domain.Webhook, error) {
+	found, err := s.webhookRepo.FindById(id)
+
+	if err != nil || found.UserId != userId {
+		return nil, errors.New(ErrWebhookNotFound)
+	}
+
+	return found, nil
+}
+
+// Create регистрирует вебхук пользователя userId с новым ключом подписи
+func (s *WebhookService) Create(userId uuid.UUID, url string, events []string) (*domain.Webhook, error) {
+	if err := s.client.ValidateURL(url); err != nil {
+		return nil, errors.New(ErrInvalidWebhookURL)
+	}
+
+	if len(events) == 0 {
+		return nil, errors.New(ErrWebhookEventsRequired)
+	}
+
+	for _, event := range events {
+		if !domain.IsTaskEventType(event) {
+			return nil, errors.New(ErrInvalidWebhookEvent)
+		}
+	}
+
+	secret, err := webhook.GenerateSecret()
+
+	if err != nil {
+		return nil, err
+	}
+
+	created := &domain.Webhook{UserId: userId, URL: url, Secret: secret, Events: events}
+
+	if err = s.webhookRepo.Create(created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *WebhookService) Delete(id uuid.UUID) error {
+	return s.webhookRepo.Delete(id)
+}
+
+// GetDeliveries возвращает последние доставки вебхука webhookId
+func (s *WebhookService) GetDeliveries(webhookId uuid.UUID) *[]domain.WebhookDelivery {
+	return s.webhookDeliveryRepo.GetByWebhookId(webhookId, webhookDeliveriesLimit)
+}
+
+// Redeliver ставит в очередь повторную отправку события доставки deliveryId вебхука webhookId.
+// Исходная доставка остается в журнале без изменений.
+func (s *WebhookService) Redeliver(webhookId, deliveryId uuid.UUID) (*domain.WebhookDelivery, error) {
+	delivery, err := s.webhookDeliveryRepo.FindById(deliveryId)
+
+	if err != nil || delivery.WebhookId != webhookId {
+		return nil, errors.New(ErrWebhookDeliveryNotFound)
+	}
+
+	if delivery.Status == domain.WebhookDeliveryPending {
+		return nil, errors.New(ErrWebhookDeliveryIsPending)
+	}
+
+	now := time.Now()
+
+	redelivery := &domain.WebhookDelivery{
+		WebhookId:     webhookId,
+		Event:         delivery.Event,
+		Payload:       delivery.Payload,
+		Status:        domain.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+	}
+
+	if err = s.webhookDeliveryRepo.Create(redelivery); err != nil {
+		return nil, err
+	}
+
+	return redelivery, nil
+}
+
+// DeliverPending отправляет доставки, время попытки которых наступило, и возвращает количество успешных.
+// Неудачная попытка повторяется с экспоненциально растущей задержкой, пока не исчерпано maxAttempts попыток.
+func (s *WebhookService) DeliverPending() (int, error) {
+	deliveries, err := s.webhookDeliveryRepo.ClaimDue(time.Now(), webhookDeliveryLease, webhookDeliveryBatch)
+
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	webhooks := make(map[uuid.UUID]*domain.Webhook)
+
+	for _, delivery := range *deliveries {
+		target, ok := webhooks[delivery.WebhookId]
+
+		if !ok {
+			if target, err = s.webhookRepo.FindById(delivery.WebhookId); err != nil {
+				continue
+			}
+
+			webhooks[delivery.WebhookId] = target
+		}
+
+		success, err := s.deliver(target, delivery)
+
+		if err != nil {
+			return delivered, err
+		}
+
+		if success {
+			delivered++
+		}
+	}
+
+	return delivered, nil
+}
+
+// deliver выполняет одну попытку доставки и сохраняет ее результат
+func (s *WebhookService) deliver(target *domain.Webhook, delivery domain.WebhookDelivery) (bool, error) {
+	response, sendErr := s.client.Send(webhook.Request{
+		URL:        target.URL,
+		Secret:     target.Secret,
+		Event:      delivery.Event,
+		DeliveryId: delivery.ID.String(),
+		Payload:    []byte(delivery.Payload),
+	})
+
+	now := time.Now()
+	attempts := delivery.Attempts + 1
+
+	columns := map[string]interface{}{
+		"attempts":        attempts,
+		"response_status": nil,
+		"response_body":   "",
+		"error":           "",
+	}
+
+	if response != nil {
+		columns["response_status"] = response.StatusCode
+		columns["response_body"] = response.Body
+	}
+
+	switch {
+	case sendErr == nil:
+		columns["status"] = domain.WebhookDeliveryDelivered
+		columns["delivered_at"] = now
+		columns["next_attempt_at"] = nil
+	case attempts >= s.maxAttempts:
+		columns["status"] = domain.WebhookDeliveryFailed
+		columns["error"] = sendErr.Error()
+		columns["next_attempt_at"] = nil
+	default:
+		columns["error"] = sendErr.Error()
+		columns["next_attempt_at"] = now.Add(webhookRetryDelay(attempts))
+	}
+
+	return sendErr == nil, s.webhookDeliveryRepo.UpdateColumns(delivery.ID, columns)
+}
+
+// webhookRetryDelay возвращает задержку перед попыткой, следующей за попыткой attempts
+func webhookRetryDelay(attempts int) time.Duration {
+	delay := webhookRetryBaseDelay
+
+	for i := 1; i < attempts && delay < webhookRetryMaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, webhookRetryMaxDelay)
+}
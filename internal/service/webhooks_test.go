@@ -0,0 +1,230 @@
+package service_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/internal/domain"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	"poymanov/todo/pkg/webhook"
+	"testing"
+	"time"
+)
+
+func TestWebhookServiceFind_AnotherUser(t *testing.T) {
+	webhookService, webhookRepo, _ := mockWebhookService(t)
+
+	webhookId, userId := twoUuids(t)
+
+	webhookRepo.EXPECT().FindById(webhookId).Return(&domain.Webhook{ID: webhookId, UserId: webhookId}, nil)
+
+	_, err := webhookService.Find(webhookId, userId)
+
+	require.EqualError(t, err, service.ErrWebhookNotFound)
+}
+
+func TestWebhookServiceCreate_InvalidURL(t *testing.T) {
+	webhookService := service.NewWebhookService(nil, nil, webhook.NewClient(time.Second, false), 3)
+
+	userId, _ := twoUuids(t)
+
+	for _, url := range []string{"ftp://example.com/hook", "http://127.0.0.1:8099/hook", "http://169.254.169.254/latest"} {
+		_, err := webhookService.Create(userId, url, []string{domain.TaskEventCreated})
+
+		require.EqualError(t, err, service.ErrInvalidWebhookURL)
+	}
+}
+
+func TestWebhookServiceCreate_InvalidEvent(t *testing.T) {
+	webhookService, _, _ := mockWebhookService(t)
+
+	userId, _ := twoUuids(t)
+
+	_, err := webhookService.Create(userId, "https://example.com/hook", []string{domain.TaskEventCreated, "task.unknown"})
+
+	require.EqualError(t, err, service.ErrInvalidWebhookEvent)
+}
+
+func TestWebhookServiceCreate_WithoutEvents(t *testing.T) {
+	webhookService, _, _ := mockWebhookService(t)
+
+	userId, _ := twoUuids(t)
+
+	_, err := webhookService.Create(userId, "https://example.com/hook", nil)
+
+	require.EqualError(t, err, service.ErrWebhookEventsRequired)
+}
+
+func TestWebhookServiceCreate_Success(t *testing.T) {
+	webhookService, webhookRepo, _ := mockWebhookService(t)
+
+	userId, _ := twoUuids(t)
+
+	webhookRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	created, err := webhookService.Create(userId, "https://example.com/hook", []string{domain.TaskEventCompleted})
+
+	require.NoError(t, err)
+	require.Equal(t, userId, created.UserId)
+	require.Len(t, created.Secret, 64)
+	require.Equal(t, domain.WebhookEvents{domain.TaskEventCompleted}, created.Events)
+}
+
+func TestWebhookServiceRedeliver_AnotherWebhook(t *testing.T) {
+	webhookService, _, deliveryRepo := mockWebhookService(t)
+
+	webhookId, deliveryId := twoUuids(t)
+
+	deliveryRepo.EXPECT().FindById(deliveryId).Return(&domain.WebhookDelivery{ID: deliveryId, WebhookId: deliveryId}, nil)
+
+	_, err := webhookService.Redeliver(webhookId, deliveryId)
+
+	require.EqualError(t, err, service.ErrWebhookDeliveryNotFound)
+}
+
+func TestWebhookServiceRedeliver_Pending(t *testing.T) {
+	webhookService, _, deliveryRepo := mockWebhookService(t)
+
+	webhookId, deliveryId := twoUuids(t)
+
+	deliveryRepo.EXPECT().FindById(deliveryId).
+		Return(&domain.WebhookDelivery{ID: deliveryId, WebhookId: webhookId, Status: domain.WebhookDeliveryPending}, nil)
+
+	_, err := webhookService.Redeliver(webhookId, deliveryId)
+
+	require.EqualError(t, err, service.ErrWebhookDeliveryIsPending)
+}
+
+func TestWebhookServiceRedeliver_Success(t *testing.T) {
+	webhookService, _, deliveryRepo := mockWebhookService(t)
+
+	webhookId, deliveryId := twoUuids(t)
+
+	deliveryRepo.EXPECT().FindById(deliveryId).Return(&domain.WebhookDelivery{
+		ID:        deliveryId,
+		WebhookId: webhookId,
+		Event:     domain.TaskEventDeleted,
+		Payload:   `{"event":"task.deleted"}`,
+		Status:    domain.WebhookDeliveryFailed,
+		Attempts:  8,
+	}, nil)
+	deliveryRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	redelivery, err := webhookService.Redeliver(webhookId, deliveryId)
+
+	require.NoError(t, err)
+	require.Equal(t, domain.WebhookDeliveryPending, redelivery.Status)
+	require.Equal(t, `{"event":"task.deleted"}`, redelivery.Payload)
+	require.Zero(t, redelivery.Attempts)
+	require.NotNil(t, redelivery.NextAttemptAt)
+}
+
+func TestWebhookServiceDeliverPending_Delivered(t *testing.T) {
+	var signature string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get(webhook.SignatureHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	webhookService, webhookRepo, deliveryRepo := mockWebhookService(t)
+
+	webhookId, deliveryId := twoUuids(t)
+
+	deliveryRepo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&[]domain.WebhookDelivery{{ID: deliveryId, WebhookId: webhookId, Payload: `{}`}}, nil)
+	webhookRepo.EXPECT().FindById(webhookId).Return(&domain.Webhook{ID: webhookId, URL: server.URL, Secret: "secret"}, nil)
+	deliveryRepo.EXPECT().UpdateColumns(deliveryId, gomock.Any()).DoAndReturn(func(_ any, columns map[string]interface{}) error {
+		require.Equal(t, domain.WebhookDeliveryDelivered, columns["status"])
+		require.Equal(t, 1, columns["attempts"])
+		require.Equal(t, http.StatusNoContent, columns["response_status"])
+
+		return nil
+	})
+
+	delivered, err := webhookService.DeliverPending()
+
+	require.NoError(t, err)
+	require.Equal(t, 1, delivered)
+	require.NotEmpty(t, signature)
+}
+
+func TestWebhookServiceDeliverPending_Retry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	webhookService, webhookRepo, deliveryRepo := mockWebhookService(t)
+
+	webhookId, deliveryId := twoUuids(t)
+
+	deliveryRepo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&[]domain.WebhookDelivery{{ID: deliveryId, WebhookId: webhookId, Attempts: 1}}, nil)
+	webhookRepo.EXPECT().FindById(webhookId).Return(&domain.Webhook{ID: webhookId, URL: server.URL}, nil)
+	deliveryRepo.EXPECT().UpdateColumns(deliveryId, gomock.Any()).DoAndReturn(func(_ any, columns map[string]interface{}) error {
+		require.Nil(t, columns["status"])
+		require.Equal(t, 2, columns["attempts"])
+		require.NotEmpty(t, columns["error"])
+		require.WithinDuration(t, time.Now().Add(time.Minute), columns["next_attempt_at"].(time.Time), 5*time.Second)
+
+		return nil
+	})
+
+	delivered, err := webhookService.DeliverPending()
+
+	require.NoError(t, err)
+	require.Zero(t, delivered)
+}
+
+func TestWebhookServiceDeliverPending_AttemptsExhausted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer server.Close()
+
+	webhookService, webhookRepo, deliveryRepo := mockWebhookService(t)
+
+	webhookId, deliveryId := twoUuids(t)
+
+	deliveryRepo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&[]domain.WebhookDelivery{{ID: deliveryId, WebhookId: webhookId, Attempts: 2}}, nil)
+	webhookRepo.EXPECT().FindById(webhookId).Return(&domain.Webhook{ID: webhookId, URL: server.URL}, nil)
+	deliveryRepo.EXPECT().UpdateColumns(deliveryId, gomock.Any()).DoAndReturn(func(_ any, columns map[string]interface{}) error {
+		require.Equal(t, domain.WebhookDeliveryFailed, columns["status"])
+		require.Nil(t, columns["next_attempt_at"])
+
+		return nil
+	})
+
+	_, err := webhookService.DeliverPending()
+
+	require.NoError(t, err)
+}
+
+func TestWebhookServiceDeliverPending_ClaimFailed(t *testing.T) {
+	webhookService, _, deliveryRepo := mockWebhookService(t)
+
+	deliveryRepo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("failed"))
+
+	_, err := webhookService.DeliverPending()
+
+	require.Error(t, err)
+}
+
+func mockWebhookService(t *testing.T) (*service.WebhookService, *mock_repository.MockWebhook, *mock_repository.MockWebhookDelivery) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	webhookRepo := mock_repository.NewMockWebhook(mockCtl)
+	deliveryRepo := mock_repository.NewMockWebhookDelivery(mockCtl)
+	webhookService := service.NewWebhookService(webhookRepo, deliveryRepo, webhook.NewClient(time.Second, true), 3)
+
+	return webhookService, webhookRepo, deliveryRepo
+}
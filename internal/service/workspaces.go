@@ -0,0 +1,156 @@
+package service
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+)
+
+const (
+	ErrWorkspaceNotFound           = "workspace not found"
+	ErrInvalidWorkspaceRole        = "invalid role"
+	ErrWorkspaceMemberUserNotFound = "user not found"
+	ErrWorkspaceMemberExists       = "user is already a member of the workspace"
+	ErrWorkspaceMemberNotFound     = "workspace member not found"
+	ErrWorkspaceOwnerIsNotMember   = "workspace owner can't be removed from the workspace"
+)
+
+// personalWorkspaceName - название личного рабочего пространства, создаваемого для пользователя
+const personalWorkspaceName = "Personal"
+
+type WorkspaceService struct {
+	workspaceRepo       repository.Workspace
+	workspaceMemberRepo repository.WorkspaceMember
+	userRepo            repository.User
+	transactor          repository.Transactor
+}
+
+func NewWorkspaceService(
+	workspaceRepo repository.Workspace,
+	workspaceMemberRepo repository.WorkspaceMember,
+	userRepo repository.User,
+	transactor repository.Transactor,
+) *WorkspaceService {
+	return &WorkspaceService{
+		workspaceRepo:       workspaceRepo,
+		workspaceMemberRepo: workspaceMemberRepo,
+		userRepo:            userRepo,
+		transactor:          transactor,
+	}
+}
+
+// Resolve возвращает рабочее пространство id, если пользователь userId его участник.
+// Без id возвращается личное пространство пользователя, которое создается при первом обращении.
+func (s *WorkspaceService) Resolve(userId uuid.UUID, id *uuid.UUID) (*domain.Workspace, error) {
+	if id != nil {
+		return s.Find(*id, userId)
+	}
+
+	workspace, err := s.workspaceRepo.FindPersonal(userId)
+
+	if err == nil {
+		return workspace, nil
+	}
+
+	workspace = &domain.Workspace{Name: personalWorkspaceName, PersonalUserId: &userId}
+
+	if err = s.workspaceRepo.Create(workspace, userId); err != nil {
+		// пространство могло быть создано параллельным запросом
+		return s.workspaceRepo.FindPersonal(userId)
+	}
+
+	return workspace, nil
+}
+
+// Find возвращает рабочее пространство id с ролью пользователя userId, если он его участник
+func (s *WorkspaceService) Find(id, userId uuid.UUID) (*domain.Workspace, error) {
+	workspace, err := s.workspaceRepo.FindByMember(id, userId)
+
+	if err != nil {
+		return nil, errors.New(ErrWorkspaceNotFound)
+	}
+
+	return workspace, nil
+}
+
+func (s *WorkspaceService) GetAll(userId uuid.UUID) *[]domain.Workspace {
+	return s.workspaceRepo.GetAllByUserId(userId)
+}
+
+// Create создает рабочее пространство name, владельцем которого становится пользователь userId
+func (s *WorkspaceService) Create(userId uuid.UUID, name string) (*domain.Workspace, error) {
+	workspace := &domain.Workspace{Name: name}
+
+	if err := s.workspaceRepo.Create(workspace, userId); err != nil {
+		return nil, err
+	}
+
+	return workspace, nil
+}
+
+func (s *WorkspaceService) GetMembers(workspaceId uuid.UUID) *[]domain.WorkspaceMember {
+	return s.workspaceMemberRepo.GetByWorkspaceId(workspaceId)
+}
+
+// AddMember добавляет в рабочее пространство пользователя с адресом email и ролью role
+func (s *WorkspaceService) AddMember(workspace *domain.Workspace, email, role string) (*domain.WorkspaceMember, error) {
+	if !domain.IsWorkspaceMemberRole(role) {
+		return nil, errors.New(ErrInvalidWorkspaceRole)
+	}
+
+	user, err := s.userRepo.FindByEmail(email)
+
+	if err != nil || user == nil {
+		return nil, errors.New(ErrWorkspaceMemberUserNotFound)
+	}
+
+	if _, err = s.workspaceMemberRepo.Find(workspace.ID, user.ID); err == nil {
+		return nil, errors.New(ErrWorkspaceMemberExists)
+	}
+
+	member := &domain.WorkspaceMember{WorkspaceId: workspace.ID, UserId: user.ID, Role: role, Name: user.Name, Email: user.Email}
+
+	if err = s.workspaceMemberRepo.Create(member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// RemoveMember исключает пользователя userId из рабочего пространства по действию пользователя actorId.
+// Владельца исключить нельзя. С задач пространства, которые стали недоступны исключенному пользователю,
+// назначение снимается с записью в историю от имени actorId.
+func (s *WorkspaceService) RemoveMember(actorId uuid.UUID, workspace *domain.Workspace, userId uuid.UUID) error {
+	member, err := s.workspaceMemberRepo.Find(workspace.ID, userId)
+
+	if err != nil {
+		return errors.New(ErrWorkspaceMemberNotFound)
+	}
+
+	if member.Role == domain.WorkspaceRoleOwner {
+		return errors.New(ErrWorkspaceOwnerIsNotMember)
+	}
+
+	return s.transactor.Transaction(func(repos *repository.Repositories) error {
+		if err := repos.WorkspaceMember.Delete(workspace.ID, userId); err != nil {
+			return err
+		}
+
+		taskService := NewTaskService(repos.Task, repos.TaskDependency, repos.Status, repos.List, repos.ListMember, repos.TaskHistory, repos.OutboxEvent, nil, false).
+			withActor(actorId)
+
+		for _, task := range *repos.Task.GetAssignedInWorkspace(workspace.ID, userId) {
+			// собственные списки и задачи вне списков остаются доступны исключенному пользователю
+			if taskService.hasAccess(&task, userId) {
+				continue
+			}
+
+			if err := taskService.unassign(&task); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,254 @@
+package service_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"poymanov/todo/internal/domain"
+	"poymanov/todo/internal/repository"
+	mock_repository "poymanov/todo/internal/repository/mocks"
+	"poymanov/todo/internal/service"
+	"testing"
+)
+
+type workspaceMocks struct {
+	workspaceRepo       *mock_repository.MockWorkspace
+	workspaceMemberRepo *mock_repository.MockWorkspaceMember
+	userRepo            *mock_repository.MockUser
+	taskRepo            *mock_repository.MockTask
+	listRepo            *mock_repository.MockList
+	listMemberRepo      *mock_repository.MockListMember
+	taskHistoryRepo     *mock_repository.MockTaskHistory
+}
+
+func TestWorkspaceServiceResolve_Personal(t *testing.T) {
+	workspaceService, mocks := mockWorkspaceService(t)
+
+	userId, workspaceId := twoUuids(t)
+
+	mocks.workspaceRepo.EXPECT().FindPersonal(userId).Return(&domain.Workspace{ID: workspaceId, PersonalUserId: &userId}, nil)
+
+	workspace, err := workspaceService.Resolve(userId, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, workspaceId, workspace.ID)
+}
+
+func TestWorkspaceServiceResolve_CreatePersonal(t *testing.T) {
+	workspaceService, mocks := mockWorkspaceService(t)
+
+	userId, _ := twoUuids(t)
+
+	mocks.workspaceRepo.EXPECT().FindPersonal(userId).Return(nil, errors.New("not found"))
+	mocks.workspaceRepo.EXPECT().Create(gomock.Any(), userId).Return(nil)
+
+	workspace, err := workspaceService.Resolve(userId, nil)
+
+	require.NoError(t, err)
+	require.True(t, workspace.IsPersonal())
+	require.Equal(t, userId, *workspace.PersonalUserId)
+}
+
+func TestWorkspaceServiceResolve_CreatedConcurrently(t *testing.T) {
+	workspaceService, mocks := mockWorkspaceService(t)
+
+	userId, workspaceId := twoUuids(t)
+
+	gomock.InOrder(
+		mocks.workspaceRepo.EXPECT().FindPersonal(userId).Return(nil, errors.New("not found")),
+		mocks.workspaceRepo.EXPECT().Create(gomock.Any(), userId).Return(errors.New("duplicate key")),
+		mocks.workspaceRepo.EXPECT().FindPersonal(userId).Return(&domain.Workspace{ID: workspaceId, PersonalUserId: &userId}, nil),
+	)
+
+	workspace, err := workspaceService.Resolve(userId, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, workspaceId, workspace.ID)
+}
+
+func TestWorkspaceServiceResolve_NotMember(t *testing.T) {
+	workspaceService, mocks := mockWorkspaceService(t)
+
+	userId, workspaceId := twoUuids(t)
+
+	mocks.workspaceRepo.EXPECT().FindByMember(workspaceId, userId).Return(nil, errors.New("not found"))
+
+	workspace, err := workspaceService.Resolve(userId, &workspaceId)
+
+	require.Nil(t, workspace)
+	require.EqualError(t, err, service.ErrWorkspaceNotFound)
+}
+
+func TestWorkspaceServiceResolve_Selected(t *testing.T) {
+	workspaceService, mocks := mockWorkspaceService(t)
+
+	userId, workspaceId := twoUuids(t)
+
+	mocks.workspaceRepo.EXPECT().FindByMember(workspaceId, userId).Return(&domain.Workspace{ID: workspaceId, Role: domain.WorkspaceRoleMember}, nil)
+
+	workspace, err := workspaceService.Resolve(userId, &workspaceId)
+
+	require.NoError(t, err)
+	require.Equal(t, domain.WorkspaceRoleMember, workspace.Role)
+}
+
+func TestWorkspaceServiceCreate_Success(t *testing.T) {
+	workspaceService, mocks := mockWorkspaceService(t)
+
+	userId, _ := twoUuids(t)
+
+	mocks.workspaceRepo.EXPECT().Create(gomock.Any(), userId).DoAndReturn(func(workspace *domain.Workspace, _ interface{}) error {
+		workspace.Role = domain.WorkspaceRoleOwner
+		return nil
+	})
+
+	workspace, err := workspaceService.Create(userId, "Team")
+
+	require.NoError(t, err)
+	require.Equal(t, "Team", workspace.Name)
+	require.False(t, workspace.IsPersonal())
+	require.Equal(t, domain.WorkspaceRoleOwner, workspace.Role)
+}
+
+func TestWorkspaceServiceAddMember_InvalidRole(t *testing.T) {
+	workspaceService, _ := mockWorkspaceService(t)
+
+	_, err := workspaceService.AddMember(&domain.Workspace{}, "john@example.com", domain.WorkspaceRoleOwner)
+
+	require.EqualError(t, err, service.ErrInvalidWorkspaceRole)
+}
+
+func TestWorkspaceServiceAddMember_UserNotFound(t *testing.T) {
+	workspaceService, mocks := mockWorkspaceService(t)
+
+	mocks.userRepo.EXPECT().FindByEmail("john@example.com").Return(nil, errors.New("not found"))
+
+	_, err := workspaceService.AddMember(&domain.Workspace{}, "john@example.com", domain.WorkspaceRoleMember)
+
+	require.EqualError(t, err, service.ErrWorkspaceMemberUserNotFound)
+}
+
+func TestWorkspaceServiceAddMember_Exists(t *testing.T) {
+	workspaceService, mocks := mockWorkspaceService(t)
+
+	userId, workspaceId := twoUuids(t)
+
+	mocks.userRepo.EXPECT().FindByEmail("john@example.com").Return(&domain.User{ID: userId}, nil)
+	mocks.workspaceMemberRepo.EXPECT().Find(workspaceId, userId).Return(&domain.WorkspaceMember{}, nil)
+
+	_, err := workspaceService.AddMember(&domain.Workspace{ID: workspaceId}, "john@example.com", domain.WorkspaceRoleMember)
+
+	require.EqualError(t, err, service.ErrWorkspaceMemberExists)
+}
+
+func TestWorkspaceServiceAddMember_Success(t *testing.T) {
+	workspaceService, mocks := mockWorkspaceService(t)
+
+	userId, workspaceId := twoUuids(t)
+
+	mocks.userRepo.EXPECT().FindByEmail("john@example.com").Return(&domain.User{ID: userId, Name: "John", Email: "john@example.com"}, nil)
+	mocks.workspaceMemberRepo.EXPECT().Find(workspaceId, userId).Return(nil, errors.New("not found"))
+	mocks.workspaceMemberRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	member, err := workspaceService.AddMember(&domain.Workspace{ID: workspaceId}, "john@example.com", domain.WorkspaceRoleAdmin)
+
+	require.NoError(t, err)
+	require.Equal(t, userId, member.UserId)
+	require.Equal(t, domain.WorkspaceRoleAdmin, member.Role)
+	require.Equal(t, "John", member.Name)
+}
+
+func TestWorkspaceServiceRemoveMember_NotFound(t *testing.T) {
+	workspaceService, mocks := mockWorkspaceService(t)
+
+	userId, workspaceId := twoUuids(t)
+
+	mocks.workspaceMemberRepo.EXPECT().Find(workspaceId, userId).Return(nil, errors.New("not found"))
+
+	require.EqualError(t, workspaceService.RemoveMember(userId, &domain.Workspace{ID: workspaceId}, userId), service.ErrWorkspaceMemberNotFound)
+}
+
+func TestWorkspaceServiceRemoveMember_Owner(t *testing.T) {
+	workspaceService, mocks := mockWorkspaceService(t)
+
+	userId, workspaceId := twoUuids(t)
+
+	mocks.workspaceMemberRepo.EXPECT().Find(workspaceId, userId).Return(&domain.WorkspaceMember{Role: domain.WorkspaceRoleOwner}, nil)
+
+	require.EqualError(t, workspaceService.RemoveMember(userId, &domain.Workspace{ID: workspaceId}, userId), service.ErrWorkspaceOwnerIsNotMember)
+}
+
+func TestWorkspaceServiceRemoveMember_Success(t *testing.T) {
+	workspaceService, mocks := mockWorkspaceService(t)
+
+	userId, workspaceId := twoUuids(t)
+
+	mocks.workspaceMemberRepo.EXPECT().Find(workspaceId, userId).Return(&domain.WorkspaceMember{Role: domain.WorkspaceRoleMember}, nil)
+	mocks.workspaceMemberRepo.EXPECT().Delete(workspaceId, userId).Return(nil)
+	mocks.taskRepo.EXPECT().GetAssignedInWorkspace(workspaceId, userId).Return(&[]domain.Task{})
+
+	require.NoError(t, workspaceService.RemoveMember(userId, &domain.Workspace{ID: workspaceId}, userId))
+}
+
+func TestWorkspaceServiceRemoveMember_UnassignsInaccessibleTasks(t *testing.T) {
+	workspaceService, mocks := mockWorkspaceService(t)
+
+	userId, workspaceId := twoUuids(t)
+	actorId, listId := twoUuids(t)
+	sharedTaskId, ownTaskId := twoUuids(t)
+	personalTaskId, ownListId := twoUuids(t)
+
+	mocks.workspaceMemberRepo.EXPECT().Find(workspaceId, userId).Return(&domain.WorkspaceMember{Role: domain.WorkspaceRoleMember}, nil)
+	mocks.workspaceMemberRepo.EXPECT().Delete(workspaceId, userId).Return(nil)
+	mocks.taskRepo.EXPECT().GetAssignedInWorkspace(workspaceId, userId).Return(&[]domain.Task{
+		{ID: sharedTaskId, ListId: &listId, AssigneeId: &userId, Version: 2},
+		{ID: ownTaskId, ListId: &ownListId, AssigneeId: &userId},
+		{ID: personalTaskId, UserId: userId, AssigneeId: &userId},
+	})
+	mocks.listRepo.EXPECT().FindById(listId).Return(&domain.List{ID: listId, UserId: actorId}, nil)
+	mocks.listMemberRepo.EXPECT().Find(listId, userId).Return(nil, errors.New("not found"))
+	mocks.listRepo.EXPECT().FindById(ownListId).Return(&domain.List{ID: ownListId, UserId: userId}, nil)
+	mocks.taskRepo.EXPECT().Unassign(sharedTaskId).Return(nil)
+	mocks.taskHistoryRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(entries []domain.TaskHistory) error {
+		require.Len(t, entries, 1)
+		require.Equal(t, sharedTaskId, entries[0].TaskId)
+		require.Equal(t, domain.TaskFieldAssigneeId, entries[0].Field)
+		require.Equal(t, actorId, *entries[0].ActorId)
+		return nil
+	})
+
+	require.NoError(t, workspaceService.RemoveMember(actorId, &domain.Workspace{ID: workspaceId}, userId))
+}
+
+func mockWorkspaceService(t *testing.T) (*service.WorkspaceService, workspaceMocks) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mocks := workspaceMocks{
+		workspaceRepo:       mock_repository.NewMockWorkspace(mockCtl),
+		workspaceMemberRepo: mock_repository.NewMockWorkspaceMember(mockCtl),
+		userRepo:            mock_repository.NewMockUser(mockCtl),
+		taskRepo:            mock_repository.NewMockTask(mockCtl),
+		listRepo:            mock_repository.NewMockList(mockCtl),
+		listMemberRepo:      mock_repository.NewMockListMember(mockCtl),
+		taskHistoryRepo:     mock_repository.NewMockTaskHistory(mockCtl),
+	}
+
+	repos := &repository.Repositories{
+		WorkspaceMember: mocks.workspaceMemberRepo,
+		Task:            mocks.taskRepo,
+		List:            mocks.listRepo,
+		ListMember:      mocks.listMemberRepo,
+		TaskHistory:     mocks.taskHistoryRepo,
+		OutboxEvent:     mockOutboxEventRepo(mockCtl),
+	}
+
+	transactor := mock_repository.NewMockTransactor(mockCtl)
+	transactor.EXPECT().Transaction(gomock.Any()).DoAndReturn(func(fn func(repos *repository.Repositories) error) error {
+		return fn(repos)
+	}).AnyTimes()
+
+	return service.NewWorkspaceService(mocks.workspaceRepo, mocks.workspaceMemberRepo, mocks.userRepo, transactor), mocks
+}
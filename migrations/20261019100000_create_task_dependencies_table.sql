@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE task_dependencies
+(
+    task_id       uuid not null,
+    blocked_by_id uuid not null,
+    created_at    timestamp with time zone,
+    primary key (task_id, blocked_by_id),
+    foreign key (task_id) references public.tasks (id)
+        match simple on update cascade on delete cascade,
+    foreign key (blocked_by_id) references public.tasks (id)
+        match simple on update cascade on delete cascade,
+    check (task_id <> blocked_by_id)
+);
+CREATE INDEX idx_task_dependencies_blocked_by_id ON task_dependencies USING btree (blocked_by_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE task_dependencies;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE lists
+(
+    id         uuid primary key not null default gen_random_uuid(),
+    user_id    uuid             not null,
+    name       text,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    foreign key (user_id) references public.users (id)
+        match simple on update cascade on delete cascade
+);
+CREATE INDEX idx_lists_user_id ON lists USING btree (user_id);
+
+ALTER TABLE tasks
+    ADD COLUMN list_id uuid references public.lists (id) on update cascade on delete set null;
+CREATE INDEX idx_tasks_list_id ON tasks USING btree (list_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tasks DROP COLUMN list_id;
+DROP TABLE lists;
+-- +goose StatementEnd
@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE statuses
+(
+    id         uuid primary key not null default gen_random_uuid(),
+    user_id    uuid             not null,
+    list_id    uuid,
+    name       text,
+    position   integer          not null default 0,
+    is_done    boolean          not null default false,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    foreign key (user_id) references public.users (id)
+        match simple on update cascade on delete cascade,
+    foreign key (list_id) references public.lists (id)
+        match simple on update cascade on delete cascade
+);
+CREATE INDEX idx_statuses_user_id_list_id ON statuses USING btree (user_id, list_id);
+CREATE UNIQUE INDEX idx_statuses_done ON statuses USING btree (user_id, coalesce(list_id, '00000000-0000-0000-0000-000000000000'::uuid)) WHERE is_done;
+
+ALTER TABLE tasks
+    ADD COLUMN status_id uuid references public.statuses (id) on update cascade on delete set null;
+CREATE INDEX idx_tasks_status_id ON tasks USING btree (status_id);
+
+INSERT INTO statuses (user_id, name, position, is_done, created_at, updated_at)
+SELECT users.id, defaults.name, defaults.position, defaults.is_done, now(), now()
+FROM users
+         CROSS JOIN (VALUES ('Backlog', 0, false),
+                            ('In Progress', 1, false),
+                            ('Review', 2, false),
+                            ('Done', 3, true)) AS defaults(name, position, is_done);
+
+UPDATE tasks
+SET status_id = statuses.id
+FROM statuses
+WHERE statuses.user_id = tasks.user_id
+  AND statuses.list_id IS NULL
+  AND statuses.position = CASE WHEN coalesce(tasks.is_completed, false) THEN 3 ELSE 0 END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE tasks
+SET is_completed = statuses.is_done
+FROM statuses
+WHERE statuses.id = tasks.status_id;
+
+ALTER TABLE tasks DROP COLUMN status_id;
+DROP TABLE statuses;
+-- +goose StatementEnd
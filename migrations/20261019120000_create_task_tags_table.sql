@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE task_tags
+(
+    task_id uuid not null,
+    name    text not null,
+    primary key (task_id, name),
+    foreign key (task_id) references public.tasks (id)
+        match simple on update cascade on delete cascade
+);
+CREATE INDEX idx_task_tags_name ON task_tags USING btree (name);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE task_tags;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE time_entries
+(
+    id         uuid primary key not null default gen_random_uuid(),
+    task_id    uuid             not null,
+    user_id    uuid             not null,
+    started_at timestamp with time zone not null,
+    stopped_at timestamp with time zone,
+    note       text,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    foreign key (task_id) references public.tasks (id)
+        match simple on update cascade on delete cascade,
+    foreign key (user_id) references public.users (id)
+        match simple on update cascade on delete cascade,
+    check (stopped_at IS NULL OR stopped_at >= started_at)
+);
+CREATE INDEX idx_time_entries_task_id ON time_entries USING btree (task_id);
+CREATE INDEX idx_time_entries_user_id_started_at ON time_entries USING btree (user_id, started_at);
+CREATE UNIQUE INDEX idx_time_entries_running ON time_entries USING btree (user_id) WHERE stopped_at IS NULL;
+
+ALTER TABLE tasks ADD COLUMN estimate_minutes integer;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tasks DROP COLUMN estimate_minutes;
+DROP TABLE time_entries;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tasks
+    ADD COLUMN completed_at timestamp with time zone,
+    ADD COLUMN archived_at  timestamp with time zone;
+UPDATE tasks SET completed_at = updated_at WHERE is_completed = true;
+CREATE INDEX idx_tasks_archived_at ON tasks USING btree (archived_at);
+
+ALTER TABLE users
+    ADD COLUMN auto_archive_days integer check (auto_archive_days > 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    DROP COLUMN auto_archive_days;
+
+DROP INDEX idx_tasks_archived_at;
+ALTER TABLE tasks
+    DROP COLUMN archived_at,
+    DROP COLUMN completed_at;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_tasks_user_created_at ON tasks USING btree (user_id, created_at, id)
+    WHERE deleted_at IS NULL AND archived_at IS NULL;
+CREATE INDEX idx_tasks_user_updated_at ON tasks USING btree (user_id, updated_at, id)
+    WHERE deleted_at IS NULL AND archived_at IS NULL;
+CREATE INDEX idx_tasks_user_description ON tasks USING btree (user_id, description, id)
+    WHERE deleted_at IS NULL AND archived_at IS NULL;
+CREATE INDEX idx_tasks_user_completed_created_at ON tasks USING btree (user_id, is_completed, created_at, id)
+    WHERE deleted_at IS NULL AND archived_at IS NULL;
+CREATE INDEX idx_tasks_description_trgm ON tasks USING gin (description gin_trgm_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_tasks_description_trgm;
+DROP INDEX idx_tasks_user_completed_created_at;
+DROP INDEX idx_tasks_user_description;
+DROP INDEX idx_tasks_user_updated_at;
+DROP INDEX idx_tasks_user_created_at;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tasks
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        to_tsvector('russian', coalesce(description, '')) || to_tsvector('english', coalesce(description, ''))
+    ) STORED;
+CREATE INDEX idx_tasks_search_vector ON tasks USING gin (search_vector);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_tasks_search_vector;
+ALTER TABLE tasks
+    DROP COLUMN search_vector;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE smart_lists
+(
+    id         uuid primary key not null default gen_random_uuid(),
+    user_id    uuid             not null,
+    name       text             not null,
+    filter     jsonb            not null default '{}',
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    foreign key (user_id) references public.users (id)
+        match simple on update cascade on delete cascade
+);
+CREATE INDEX idx_smart_lists_user_id ON smart_lists USING btree (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE smart_lists;
+-- +goose StatementEnd
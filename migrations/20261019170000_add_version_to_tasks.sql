@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tasks
+    ADD COLUMN version integer not null default 1;
+
+CREATE FUNCTION increment_task_version() RETURNS trigger AS
+$$
+BEGIN
+    NEW.version := OLD.version + 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tasks_increment_version
+    BEFORE UPDATE
+    ON tasks
+    FOR EACH ROW
+EXECUTE FUNCTION increment_task_version();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER tasks_increment_version ON tasks;
+DROP FUNCTION increment_task_version();
+
+ALTER TABLE tasks
+    DROP COLUMN version;
+-- +goose StatementEnd
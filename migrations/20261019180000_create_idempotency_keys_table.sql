@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE idempotency_keys
+(
+    user_id     uuid                     not null,
+    key         text                     not null,
+    fingerprint text                     not null,
+    status_code integer                  not null default 0,
+    headers     jsonb                    not null default '{}',
+    body        bytea,
+    created_at  timestamp with time zone not null,
+    primary key (user_id, key),
+    foreign key (user_id) references public.users (id)
+        match simple on update cascade on delete cascade
+);
+CREATE INDEX idx_idempotency_keys_created_at ON idempotency_keys USING btree (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE idempotency_keys;
+-- +goose StatementEnd
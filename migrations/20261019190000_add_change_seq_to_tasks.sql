@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE SEQUENCE tasks_change_seq;
+
+ALTER TABLE tasks
+    ADD COLUMN change_seq  bigint not null default nextval('tasks_change_seq'),
+    ADD COLUMN created_seq bigint;
+UPDATE tasks SET created_seq = change_seq;
+ALTER TABLE tasks
+    ALTER COLUMN created_seq SET NOT NULL;
+CREATE INDEX idx_tasks_user_id_change_seq ON tasks USING btree (user_id, change_seq);
+
+CREATE FUNCTION set_task_change_seq() RETURNS trigger AS
+$$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        NEW.created_seq := NEW.change_seq;
+    ELSE
+        NEW.change_seq := nextval('tasks_change_seq');
+        NEW.created_seq := OLD.created_seq;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tasks_set_change_seq
+    BEFORE INSERT OR UPDATE
+    ON tasks
+    FOR EACH ROW
+EXECUTE FUNCTION set_task_change_seq();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER tasks_set_change_seq ON tasks;
+DROP FUNCTION set_task_change_seq();
+
+DROP INDEX idx_tasks_user_id_change_seq;
+ALTER TABLE tasks
+    DROP COLUMN created_seq,
+    DROP COLUMN change_seq;
+DROP SEQUENCE tasks_change_seq;
+-- +goose StatementEnd
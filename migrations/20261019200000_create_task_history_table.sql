@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE task_history
+(
+    id         uuid primary key not null default gen_random_uuid(),
+    task_id    uuid             not null,
+    revision   integer          not null,
+    actor_id   uuid,
+    field      text             not null,
+    old_value  text,
+    new_value  text,
+    created_at timestamp with time zone,
+    foreign key (task_id) references public.tasks (id)
+        match simple on update cascade on delete cascade,
+    foreign key (actor_id) references public.users (id)
+        match simple on update cascade on delete set null
+);
+CREATE INDEX idx_task_history_task_id_revision ON task_history USING btree (task_id, revision);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE task_history;
+-- +goose StatementEnd
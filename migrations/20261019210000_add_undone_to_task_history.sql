@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE task_history ADD COLUMN undone boolean not null default false;
+CREATE INDEX idx_task_history_actor_id_created_at ON task_history USING btree (actor_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_task_history_actor_id_created_at;
+ALTER TABLE task_history DROP COLUMN undone;
+-- +goose StatementEnd
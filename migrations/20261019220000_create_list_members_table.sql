@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE list_members
+(
+    list_id    uuid not null,
+    user_id    uuid not null,
+    role       text not null,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    primary key (list_id, user_id),
+    foreign key (list_id) references public.lists (id)
+        match simple on update cascade on delete cascade,
+    foreign key (user_id) references public.users (id)
+        match simple on update cascade on delete cascade
+);
+CREATE INDEX idx_list_members_user_id ON list_members USING btree (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE list_members;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tasks ADD COLUMN assignee_id uuid;
+ALTER TABLE tasks ADD FOREIGN KEY (assignee_id) REFERENCES public.users (id)
+    MATCH SIMPLE ON UPDATE CASCADE ON DELETE SET NULL;
+CREATE INDEX idx_tasks_assignee_id ON tasks USING btree (assignee_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_tasks_assignee_id;
+ALTER TABLE tasks DROP COLUMN assignee_id;
+-- +goose StatementEnd
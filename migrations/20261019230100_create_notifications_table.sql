@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE notifications
+(
+    id         uuid primary key not null default gen_random_uuid(),
+    user_id    uuid             not null,
+    type       text             not null,
+    task_id    uuid,
+    actor_id   uuid,
+    read_at    timestamp with time zone,
+    created_at timestamp with time zone,
+    foreign key (user_id) references public.users (id)
+        match simple on update cascade on delete cascade,
+    foreign key (task_id) references public.tasks (id)
+        match simple on update cascade on delete cascade,
+    foreign key (actor_id) references public.users (id)
+        match simple on update cascade on delete set null
+);
+CREATE INDEX idx_notifications_user_id_created_at ON notifications USING btree (user_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE notifications;
+-- +goose StatementEnd
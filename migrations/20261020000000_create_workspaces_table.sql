@@ -0,0 +1,72 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE workspaces
+(
+    id               uuid primary key not null default gen_random_uuid(),
+    name             text             not null,
+    personal_user_id uuid unique,
+    created_at       timestamp with time zone,
+    updated_at       timestamp with time zone,
+    foreign key (personal_user_id) references public.users (id)
+        match simple on update cascade on delete cascade
+);
+
+CREATE TABLE workspace_members
+(
+    workspace_id uuid not null,
+    user_id      uuid not null,
+    role         text not null,
+    created_at   timestamp with time zone,
+    updated_at   timestamp with time zone,
+    primary key (workspace_id, user_id),
+    foreign key (workspace_id) references public.workspaces (id)
+        match simple on update cascade on delete cascade,
+    foreign key (user_id) references public.users (id)
+        match simple on update cascade on delete cascade
+);
+CREATE INDEX idx_workspace_members_user_id ON workspace_members USING btree (user_id);
+
+INSERT INTO workspaces (name, personal_user_id, created_at, updated_at)
+SELECT 'Personal', id, now(), now() FROM users;
+
+INSERT INTO workspace_members (workspace_id, user_id, role, created_at, updated_at)
+SELECT id, personal_user_id, 'owner', now(), now() FROM workspaces;
+
+ALTER TABLE lists ADD COLUMN workspace_id uuid;
+ALTER TABLE lists ADD FOREIGN KEY (workspace_id) REFERENCES public.workspaces (id)
+    MATCH SIMPLE ON UPDATE CASCADE ON DELETE CASCADE;
+UPDATE lists SET workspace_id = workspaces.id
+FROM workspaces
+WHERE workspaces.personal_user_id = lists.user_id;
+ALTER TABLE lists ALTER COLUMN workspace_id SET NOT NULL;
+CREATE INDEX idx_lists_workspace_id ON lists USING btree (workspace_id);
+
+ALTER TABLE tasks ADD COLUMN workspace_id uuid;
+ALTER TABLE tasks ADD FOREIGN KEY (workspace_id) REFERENCES public.workspaces (id)
+    MATCH SIMPLE ON UPDATE CASCADE ON DELETE CASCADE;
+UPDATE tasks SET workspace_id = lists.workspace_id
+FROM lists
+WHERE lists.id = tasks.list_id;
+UPDATE tasks SET workspace_id = workspaces.id
+FROM workspaces
+WHERE tasks.workspace_id IS NULL AND workspaces.personal_user_id = tasks.user_id;
+ALTER TABLE tasks ALTER COLUMN workspace_id SET NOT NULL;
+CREATE INDEX idx_tasks_workspace_id ON tasks USING btree (workspace_id);
+
+-- участники общих списков сохраняют к ним доступ, становясь участниками рабочего пространства владельца
+INSERT INTO workspace_members (workspace_id, user_id, role, created_at, updated_at)
+SELECT DISTINCT lists.workspace_id, list_members.user_id, 'member', now(), now()
+FROM list_members
+JOIN lists ON lists.id = list_members.list_id
+ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_tasks_workspace_id;
+ALTER TABLE tasks DROP COLUMN workspace_id;
+DROP INDEX idx_lists_workspace_id;
+ALTER TABLE lists DROP COLUMN workspace_id;
+DROP TABLE workspace_members;
+DROP TABLE workspaces;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE invites
+(
+    id           uuid primary key not null default gen_random_uuid(),
+    user_id      uuid             not null,
+    workspace_id uuid             not null,
+    list_id      uuid,
+    role         text             not null,
+    single_use   boolean          not null default false,
+    expires_at   timestamp with time zone not null,
+    used_at      timestamp with time zone,
+    created_at   timestamp with time zone,
+    foreign key (user_id) references public.users (id)
+        match simple on update cascade on delete cascade,
+    foreign key (workspace_id) references public.workspaces (id)
+        match simple on update cascade on delete cascade,
+    foreign key (list_id) references public.lists (id)
+        match simple on update cascade on delete cascade
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE invites;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN role                    text    not null default 'user' check (role in ('user', 'admin')),
+    ADD COLUMN disabled_at             timestamp with time zone,
+    ADD COLUMN password_reset_required boolean not null default false;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    DROP COLUMN password_reset_required,
+    DROP COLUMN disabled_at,
+    DROP COLUMN role;
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE audit_events
+(
+    id          uuid primary key not null default gen_random_uuid(),
+    action      text             not null,
+    actor_id    uuid,
+    actor_email text             not null default '',
+    target_id   uuid,
+    details     text             not null default '',
+    ip          text             not null default '',
+    user_agent  text             not null default '',
+    request_id  text             not null default '',
+    created_at  timestamp with time zone not null default now()
+);
+CREATE INDEX idx_audit_events_created_at ON audit_events USING btree (created_at);
+CREATE INDEX idx_audit_events_actor_id ON audit_events USING btree (actor_id);
+CREATE INDEX idx_audit_events_target_id ON audit_events USING btree (target_id);
+
+CREATE FUNCTION forbid_audit_events_change() RETURNS trigger AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE OR TRUNCATE
+    ON audit_events
+    FOR EACH STATEMENT
+EXECUTE FUNCTION forbid_audit_events_change();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE audit_events;
+DROP FUNCTION forbid_audit_events_change();
+-- +goose StatementEnd
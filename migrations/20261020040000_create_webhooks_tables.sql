@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE webhooks
+(
+    id         uuid primary key not null default gen_random_uuid(),
+    user_id    uuid             not null,
+    url        text             not null,
+    secret     text             not null,
+    events     jsonb            not null default '[]',
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    foreign key (user_id) references public.users (id)
+        match simple on update cascade on delete cascade
+);
+CREATE INDEX idx_webhooks_user_id ON webhooks USING btree (user_id);
+
+CREATE TABLE webhook_deliveries
+(
+    id              uuid primary key not null default gen_random_uuid(),
+    webhook_id      uuid             not null,
+    event           text             not null,
+    payload         jsonb            not null,
+    status          text             not null default 'pending',
+    attempts        integer          not null default 0,
+    next_attempt_at timestamp with time zone,
+    response_status integer,
+    response_body   text             not null default '',
+    error           text             not null default '',
+    delivered_at    timestamp with time zone,
+    created_at      timestamp with time zone,
+    updated_at      timestamp with time zone,
+    foreign key (webhook_id) references public.webhooks (id)
+        match simple on update cascade on delete cascade
+);
+CREATE INDEX idx_webhook_deliveries_webhook_id_created_at ON webhook_deliveries USING btree (webhook_id, created_at);
+CREATE INDEX idx_webhook_deliveries_pending ON webhook_deliveries USING btree (next_attempt_at) WHERE status = 'pending';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE webhook_deliveries;
+DROP TABLE webhooks;
+-- +goose StatementEnd
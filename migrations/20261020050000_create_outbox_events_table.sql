@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE outbox_events
+(
+    id           uuid primary key not null default gen_random_uuid(),
+    sequence     bigserial        not null unique,
+    type         text             not null,
+    aggregate_id uuid             not null,
+    payload      jsonb            not null,
+    attempts     integer          not null default 0,
+    last_error   text             not null default '',
+    created_at   timestamp with time zone not null default now(),
+    published_at timestamp with time zone,
+    failed_at    timestamp with time zone
+);
+CREATE INDEX idx_outbox_events_unpublished ON outbox_events USING btree (sequence) WHERE published_at IS NULL AND failed_at IS NULL;
+CREATE INDEX idx_outbox_events_published_at ON outbox_events USING btree (published_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE outbox_events;
+-- +goose StatementEnd
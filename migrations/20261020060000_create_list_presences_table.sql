@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE list_presences
+(
+    connection_id uuid                     not null,
+    list_id       uuid                     not null references lists (id) on delete cascade,
+    user_id       uuid                     not null references users (id) on delete cascade,
+    expires_at    timestamp with time zone not null,
+    primary key (connection_id, list_id)
+);
+CREATE INDEX idx_list_presences_list_id ON list_presences USING btree (list_id);
+CREATE INDEX idx_list_presences_expires_at ON list_presences USING btree (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE list_presences;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tasks
+    ADD COLUMN due_at timestamp with time zone;
+CREATE INDEX idx_tasks_due_at ON tasks USING btree (due_at) WHERE due_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_tasks_due_at;
+ALTER TABLE tasks
+    DROP COLUMN due_at;
+-- +goose StatementEnd
@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tasks
+    ADD COLUMN change_xid  bigint not null default 0,
+    ADD COLUMN created_xid bigint not null default 0;
+CREATE INDEX idx_tasks_change_xid_change_seq ON tasks USING btree (change_xid, change_seq);
+
+-- Номер транзакции изменения нужен синхронизации: change_seq выдается при записи, а не при фиксации,
+-- поэтому изменения видны в другом порядке. Транзакции с номером меньше xmin снимка завершены,
+-- и изменения с такими номерами уже не появятся.
+CREATE OR REPLACE FUNCTION set_task_change_seq() RETURNS trigger AS
+$$
+BEGIN
+    NEW.change_xid := pg_current_xact_id()::text::bigint;
+    IF TG_OP = 'INSERT' THEN
+        NEW.created_seq := NEW.change_seq;
+        NEW.created_xid := NEW.change_xid;
+    ELSE
+        NEW.change_seq := nextval('tasks_change_seq');
+        NEW.created_seq := OLD.created_seq;
+        NEW.created_xid := OLD.created_xid;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION set_task_change_seq() RETURNS trigger AS
+$$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        NEW.created_seq := NEW.change_seq;
+    ELSE
+        NEW.change_seq := nextval('tasks_change_seq');
+        NEW.created_seq := OLD.created_seq;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX idx_tasks_change_xid_change_seq;
+ALTER TABLE tasks
+    DROP COLUMN created_xid,
+    DROP COLUMN change_xid;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- sequence выдается при записи события, а транзакции фиксируются в другом порядке, поэтому клиенты потока
+-- событий могли пропустить событие с меньшим номером. published_sequence выдается при публикации, которую
+-- выполняет один экземпляр приложения, и растет в порядке фиксации.
+ALTER TABLE outbox_events ADD COLUMN published_sequence bigint;
+CREATE SEQUENCE outbox_events_published_sequence_seq OWNED BY outbox_events.published_sequence;
+
+-- уже опубликованные события сохраняют номера, под которыми их получили клиенты
+UPDATE outbox_events SET published_sequence = sequence WHERE published_at IS NOT NULL;
+SELECT setval('outbox_events_published_sequence_seq', (SELECT COALESCE(MAX(sequence), 0) + 1 FROM outbox_events), false);
+
+CREATE UNIQUE INDEX idx_outbox_events_published_sequence ON outbox_events USING btree (published_sequence);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE outbox_events DROP COLUMN published_sequence;
+-- +goose StatementEnd
@@ -0,0 +1,96 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Отметки об удалении задач для синхронизации: окончательно удаленные задачи и задачи, к которым пользователь
+-- потерял доступ, не попадают в выборку измененных задач. Позиция отметки берется из того же журнала,
+-- что и позиция изменения задачи.
+CREATE TABLE task_tombstones
+(
+    id           uuid primary key not null default gen_random_uuid(),
+    task_id      uuid             not null,
+    user_id      uuid             not null,
+    workspace_id uuid,
+    change_xid   bigint           not null default pg_current_xact_id()::text::bigint,
+    change_seq   bigint           not null default nextval('tasks_change_seq'),
+    created_at   timestamp with time zone not null default now()
+);
+CREATE INDEX idx_task_tombstones_user_id_change ON task_tombstones USING btree (user_id, change_xid, change_seq);
+
+-- Задача окончательно удалена или перенесена в другой список: отметку получают все, кто видел ее раньше
+CREATE OR REPLACE FUNCTION add_task_tombstones() RETURNS trigger AS
+$$
+BEGIN
+    IF TG_OP = 'UPDATE' AND NEW.list_id IS NOT DISTINCT FROM OLD.list_id THEN
+        RETURN NULL;
+    END IF;
+
+    IF OLD.list_id IS NULL THEN
+        INSERT INTO task_tombstones (task_id, user_id, workspace_id) VALUES (OLD.id, OLD.user_id, OLD.workspace_id);
+    ELSE
+        INSERT INTO task_tombstones (task_id, user_id, workspace_id)
+        SELECT OLD.id, viewers.user_id, OLD.workspace_id
+        FROM (SELECT user_id FROM lists WHERE id = OLD.list_id
+              UNION
+              SELECT user_id FROM list_members WHERE list_id = OLD.list_id) viewers;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tasks_add_tombstones
+    AFTER UPDATE OF list_id OR DELETE
+    ON tasks
+    FOR EACH ROW
+EXECUTE FUNCTION add_task_tombstones();
+
+-- Участник исключен из списка: отметки получают все задачи списка
+CREATE OR REPLACE FUNCTION add_list_member_tombstones() RETURNS trigger AS
+$$
+BEGIN
+    INSERT INTO task_tombstones (task_id, user_id, workspace_id)
+    SELECT id, OLD.user_id, workspace_id FROM tasks WHERE list_id = OLD.list_id;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER list_members_add_tombstones
+    AFTER DELETE
+    ON list_members
+    FOR EACH ROW
+EXECUTE FUNCTION add_list_member_tombstones();
+
+-- Список удален: задачи остаются у авторов вне списков, остальные участники теряют к ним доступ.
+-- Отметки создаются до удаления, пока известны задачи и участники списка.
+CREATE OR REPLACE FUNCTION add_list_tombstones() RETURNS trigger AS
+$$
+BEGIN
+    INSERT INTO task_tombstones (task_id, user_id, workspace_id)
+    SELECT tasks.id, viewers.user_id, tasks.workspace_id
+    FROM tasks,
+         (SELECT OLD.user_id AS user_id
+          UNION
+          SELECT user_id FROM list_members WHERE list_id = OLD.id) viewers
+    WHERE tasks.list_id = OLD.id;
+
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER lists_add_tombstones
+    BEFORE DELETE
+    ON lists
+    FOR EACH ROW
+EXECUTE FUNCTION add_list_tombstones();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER lists_add_tombstones ON lists;
+DROP TRIGGER list_members_add_tombstones ON list_members;
+DROP TRIGGER tasks_add_tombstones ON tasks;
+DROP FUNCTION add_list_tombstones();
+DROP FUNCTION add_list_member_tombstones();
+DROP FUNCTION add_task_tombstones();
+DROP TABLE task_tombstones;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Ревизия состоит из нескольких записей - по одной на изменившееся поле, поэтому уникальность задается
+-- вместе с полем. Повторная запись того же поля в той же ревизии завершается ошибкой, а не дублирует историю.
+DROP INDEX idx_task_history_task_id_revision;
+CREATE UNIQUE INDEX idx_task_history_task_id_revision_field ON task_history USING btree (task_id, revision, field);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_task_history_task_id_revision_field;
+CREATE INDEX idx_task_history_task_id_revision ON task_history USING btree (task_id, revision);
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Выборка задач пользователя состоит из двух ветвей UNION ALL: задачи вне списков отбираются по индексам
+-- idx_tasks_user_*, задачи доступных списков - по индексам ниже. План проверяется запросом
+--   EXPLAIN SELECT * FROM (SELECT * FROM tasks WHERE deleted_at IS NULL AND archived_at IS NULL
+--       AND list_id IS NULL AND user_id = :user
+--     UNION ALL SELECT * FROM tasks WHERE deleted_at IS NULL AND archived_at IS NULL
+--       AND list_id IN (SELECT id FROM lists WHERE user_id = :user
+--         UNION SELECT list_id FROM list_members WHERE user_id = :user)) AS tasks
+--   ORDER BY created_at DESC, id DESC LIMIT 20;
+-- первая ветвь должна читать idx_tasks_user_created_at, вторая - idx_tasks_list_created_at по каждому списку.
+CREATE INDEX idx_tasks_list_created_at ON tasks USING btree (list_id, created_at, id)
+    WHERE deleted_at IS NULL AND archived_at IS NULL;
+CREATE INDEX idx_tasks_list_updated_at ON tasks USING btree (list_id, updated_at, id)
+    WHERE deleted_at IS NULL AND archived_at IS NULL;
+CREATE INDEX idx_tasks_list_description ON tasks USING btree (list_id, description, id)
+    WHERE deleted_at IS NULL AND archived_at IS NULL;
+CREATE INDEX idx_tasks_list_completed_created_at ON tasks USING btree (list_id, is_completed, created_at, id)
+    WHERE deleted_at IS NULL AND archived_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_tasks_list_completed_created_at;
+DROP INDEX idx_tasks_list_description;
+DROP INDEX idx_tasks_list_updated_at;
+DROP INDEX idx_tasks_list_created_at;
+-- +goose StatementEnd
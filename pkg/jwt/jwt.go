@@ -2,6 +2,7 @@ package jwt
 
 import (
 	"github.com/golang-jwt/jwt/v5"
+	"time"
 )
 
 type JWT struct {
@@ -41,9 +42,59 @@ func (j *JWT) Parse(token string) (bool, *JWTData) {
 		return false, nil
 	}
 
-	email := t.Claims.(jwt.MapClaims)["email"]
+	email, ok := t.Claims.(jwt.MapClaims)["email"].(string)
+
+	if !ok {
+		return false, nil
+	}
 
 	return t.Valid, &JWTData{
-		Email: email.(string),
+		Email: email,
+	}
+}
+
+// CreateInvite подписывает идентификатор приглашения id. Токен действителен до expiresAt.
+func (j *JWT) CreateInvite(id string, expiresAt time.Time) (string, error) {
+	return j.createExpiring("invite", id, expiresAt)
+}
+
+// ParseInvite возвращает идентификатор приглашения из подписанного и не истекшего токена
+func (j *JWT) ParseInvite(token string) (string, bool) {
+	return j.parseExpiring("invite", token)
+}
+
+// CreatePasswordReset подписывает идентификатор пользователя, которому нужно сменить пароль.
+// Токен действителен до expiresAt.
+func (j *JWT) CreatePasswordReset(userId string, expiresAt time.Time) (string, error) {
+	return j.createExpiring("password_reset", userId, expiresAt)
+}
+
+// ParsePasswordReset возвращает идентификатор пользователя из подписанного и не истекшего токена смены пароля
+func (j *JWT) ParsePasswordReset(token string) (string, bool) {
+	return j.parseExpiring("password_reset", token)
+}
+
+// createExpiring подписывает значение value утверждения claim. Токен действителен до expiresAt.
+func (j *JWT) createExpiring(claim, value string, expiresAt time.Time) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		claim: value,
+		"exp": expiresAt.Unix(),
+	})
+
+	return token.SignedString([]byte(j.Secret))
+}
+
+// parseExpiring возвращает значение утверждения claim из подписанного токена с не истекшим сроком действия
+func (j *JWT) parseExpiring(claim, token string) (string, bool) {
+	t, err := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) {
+		return []byte(j.Secret), nil
+	}, jwt.WithExpirationRequired())
+
+	if err != nil || !t.Valid {
+		return "", false
 	}
+
+	value, ok := t.Claims.(jwt.MapClaims)[claim].(string)
+
+	return value, ok
 }
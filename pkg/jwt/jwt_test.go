@@ -4,6 +4,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"poymanov/todo/pkg/jwt"
 	"testing"
+	"time"
 )
 
 const secret = "test"
@@ -48,3 +49,69 @@ func TestParseFailed(t *testing.T) {
 	require.False(t, isSuccess)
 	require.Nil(t, jwtData)
 }
+
+func TestParseWithoutEmail(t *testing.T) {
+	jwtLib := jwt.NewJWT(secret)
+
+	token, err := jwtLib.CreateInvite("invite", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	isSuccess, jwtData := jwtLib.Parse(token)
+
+	require.False(t, isSuccess)
+	require.Nil(t, jwtData)
+}
+
+func TestParseInviteSuccess(t *testing.T) {
+	jwtLib := jwt.NewJWT(secret)
+
+	token, err := jwtLib.CreateInvite("invite", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	id, ok := jwtLib.ParseInvite(token)
+
+	require.True(t, ok)
+	require.Equal(t, "invite", id)
+}
+
+func TestParseInviteExpired(t *testing.T) {
+	jwtLib := jwt.NewJWT(secret)
+
+	token, err := jwtLib.CreateInvite("invite", time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+
+	_, ok := jwtLib.ParseInvite(token)
+
+	require.False(t, ok)
+}
+
+func TestParseInviteFailed(t *testing.T) {
+	jwtLib := jwt.NewJWT(secret)
+
+	_, ok := jwtLib.ParseInvite(expectedToken)
+
+	require.False(t, ok)
+}
+
+func TestParsePasswordResetSuccess(t *testing.T) {
+	jwtLib := jwt.NewJWT(secret)
+
+	token, err := jwtLib.CreatePasswordReset("user", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	id, ok := jwtLib.ParsePasswordReset(token)
+
+	require.True(t, ok)
+	require.Equal(t, "user", id)
+}
+
+func TestParsePasswordResetInviteToken(t *testing.T) {
+	jwtLib := jwt.NewJWT(secret)
+
+	token, err := jwtLib.CreateInvite("invite", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	_, ok := jwtLib.ParsePasswordReset(token)
+
+	require.False(t, ok)
+}
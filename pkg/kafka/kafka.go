@@ -0,0 +1,84 @@
+package kafka
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const contentType = "application/vnd.kafka.json.v2+json"
+
+// Client публикует сообщения в Kafka через REST Proxy (API v2)
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+type record struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+type produceRequest struct {
+	Records []record `json:"records"`
+}
+
+type produceResponse struct {
+	Offsets []struct {
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+func NewClient(baseURL string, timeout time.Duration) *Client {
+	return &Client{baseURL: strings.TrimRight(baseURL, "/"), http: &http.Client{Timeout: timeout}}
+}
+
+// Produce отправляет в topic сообщение value с ключом key. Сообщения с одним ключом попадают
+// в одну партицию и читаются в порядке отправки.
+func (c *Client) Produce(topic, key string, value json.RawMessage) error {
+	body, err := json.Marshal(produceRequest{Records: []record{{Key: key, Value: value}}})
+
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.baseURL+"/topics/"+url.PathEscape(topic), bytes.NewReader(body))
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	resp, err := c.http.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var result produceResponse
+
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+
+	for _, offset := range result.Offsets {
+		if offset.ErrorCode != nil {
+			return fmt.Errorf("produce failed: %s", offset.Error)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package kafka_test
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/pkg/kafka"
+	"testing"
+	"time"
+)
+
+func TestProduceSuccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		require.Equal(t, "/topics/todo.events", r.URL.Path)
+		require.Equal(t, "application/vnd.kafka.json.v2+json", r.Header.Get("Content-Type"))
+		require.JSONEq(t, `{"records":[{"key":"task","value":{"event":"task.created"}}]}`, string(body))
+
+		_, _ = w.Write([]byte(`{"offsets":[{"partition":0,"offset":1,"error_code":null,"error":null}]}`))
+	}))
+	defer server.Close()
+
+	client := kafka.NewClient(server.URL+"/", time.Second)
+
+	require.NoError(t, client.Produce("todo.events", "task", json.RawMessage(`{"event":"task.created"}`)))
+}
+
+func TestProduceRecordError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"offsets":[{"partition":null,"offset":null,"error_code":50002,"error":"broker unavailable"}]}`))
+	}))
+	defer server.Close()
+
+	client := kafka.NewClient(server.URL, time.Second)
+
+	require.EqualError(t, client.Produce("todo.events", "task", json.RawMessage(`{}`)), "produce failed: broker unavailable")
+}
+
+func TestProduceUnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := kafka.NewClient(server.URL, time.Second)
+
+	require.EqualError(t, client.Produce("missing", "task", json.RawMessage(`{}`)), "unexpected status 404")
+}
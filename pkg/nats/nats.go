@@ -0,0 +1,147 @@
+package nats
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Client публикует сообщения в NATS по базовому текстовому протоколу. Соединение устанавливается
+// при первой публикации и восстанавливается после ошибки.
+type Client struct {
+	addr    string
+	timeout time.Duration
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewClient создает клиента сервера address в формате nats://host:port или host:port
+func NewClient(address string, timeout time.Duration) *Client {
+	if parsed, err := url.Parse(address); err == nil && parsed.Host != "" {
+		address = parsed.Host
+	}
+
+	return &Client{addr: address, timeout: timeout}
+}
+
+// Publish отправляет data в тему subject и дожидается подтверждения сервером
+func (c *Client) Publish(subject string, data []byte) error {
+	if subject == "" || strings.ContainsAny(subject, " \t\r\n") {
+		return errors.New("invalid subject")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.publish(subject, data); err != nil {
+		c.close()
+
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.close()
+}
+
+func (c *Client) publish(subject string, data []byte) error {
+	if c.conn == nil {
+		if err := c.connect(); err != nil {
+			return err
+		}
+	}
+
+	if err := c.conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		return err
+	}
+
+	// PING после PUB позволяет убедиться, что сервер принял сообщение
+	if _, err := fmt.Fprintf(c.conn, "PUB %s %d\r\n%s\r\nPING\r\n", subject, len(data), data); err != nil {
+		return err
+	}
+
+	return c.waitPong()
+}
+
+func (c *Client) connect() error {
+	conn, err := net.DialTimeout("tcp", c.addr, c.timeout)
+
+	if err != nil {
+		return err
+	}
+
+	c.conn, c.reader = conn, bufio.NewReader(conn)
+
+	if err = conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		return err
+	}
+
+	line, err := c.readLine()
+
+	if err != nil {
+		return err
+	}
+
+	if !strings.HasPrefix(line, "INFO") {
+		return fmt.Errorf("unexpected greeting: %s", line)
+	}
+
+	_, err = fmt.Fprint(c.conn, "CONNECT {\"verbose\":false,\"pedantic\":false,\"name\":\"todo\"}\r\n")
+
+	return err
+}
+
+// waitPong читает ответы сервера до PONG, отвечая на его собственные PING
+func (c *Client) waitPong() error {
+	for {
+		line, err := c.readLine()
+
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err = fmt.Fprint(c.conn, "PONG\r\n"); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.New(strings.Trim(strings.TrimPrefix(line, "-ERR"), " '"))
+		}
+	}
+}
+
+func (c *Client) readLine() (string, error) {
+	line, err := c.reader.ReadString('\n')
+
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (c *Client) close() error {
+	if c.conn == nil {
+		return nil
+	}
+
+	err := c.conn.Close()
+	c.conn, c.reader = nil, nil
+
+	return err
+}
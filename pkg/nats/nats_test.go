@@ -0,0 +1,120 @@
+package nats_test
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net"
+	"poymanov/todo/pkg/nats"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type message struct {
+	subject string
+	data    string
+}
+
+// serveNats запускает заглушку сервера NATS, которая передает принятые сообщения в messages
+// и отвечает на PUB ошибкой reject
+func serveNats(t *testing.T, reject string) (string, <-chan message) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	messages := make(chan message, 10)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+
+			if err != nil {
+				return
+			}
+
+			go handleNatsConn(conn, reject, messages)
+		}
+	}()
+
+	return "nats://" + listener.Addr().String(), messages
+}
+
+func handleNatsConn(conn net.Conn, reject string, messages chan<- message) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	_, _ = fmt.Fprint(conn, "INFO {\"server_id\":\"stub\"}\r\n")
+
+	for {
+		line, err := reader.ReadString('\n')
+
+		if err != nil {
+			return
+		}
+
+		fields := strings.Fields(line)
+
+		switch {
+		case len(fields) == 3 && fields[0] == "PUB":
+			size, _ := strconv.Atoi(fields[2])
+			data := make([]byte, size+2)
+
+			if _, err = io.ReadFull(reader, data); err != nil {
+				return
+			}
+
+			if reject != "" {
+				_, _ = fmt.Fprintf(conn, "-ERR '%s'\r\n", reject)
+				continue
+			}
+
+			messages <- message{subject: fields[1], data: string(data[:size])}
+		case len(fields) == 1 && fields[0] == "PING":
+			_, _ = fmt.Fprint(conn, "PONG\r\n")
+		}
+	}
+}
+
+func TestPublishSuccess(t *testing.T) {
+	address, messages := serveNats(t, "")
+
+	client := nats.NewClient(address, time.Second)
+	defer client.Close()
+
+	require.NoError(t, client.Publish("todo.task.created", []byte(`{"event":"task.created"}`)))
+	require.NoError(t, client.Publish("todo.task.deleted", []byte(`{}`)))
+
+	require.Equal(t, message{subject: "todo.task.created", data: `{"event":"task.created"}`}, <-messages)
+	require.Equal(t, message{subject: "todo.task.deleted", data: `{}`}, <-messages)
+}
+
+func TestPublishRejected(t *testing.T) {
+	address, _ := serveNats(t, "Permissions Violation")
+
+	client := nats.NewClient(address, time.Second)
+	defer client.Close()
+
+	require.EqualError(t, client.Publish("todo.task.created", []byte(`{}`)), "Permissions Violation")
+}
+
+func TestPublishInvalidSubject(t *testing.T) {
+	client := nats.NewClient("127.0.0.1:0", time.Second)
+
+	require.EqualError(t, client.Publish("todo task", []byte(`{}`)), "invalid subject")
+}
+
+func TestPublishServerUnavailable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	client := nats.NewClient(address, time.Second)
+
+	require.Error(t, client.Publish("todo.task.created", []byte(`{}`)))
+}
@@ -0,0 +1,47 @@
+package pgnotify
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+)
+
+// Listener получает оповещения Postgres NOTIFY из канала channel по отдельному соединению
+type Listener struct {
+	dsn     string
+	channel string
+}
+
+func NewListener(dsn, channel string) *Listener {
+	return &Listener{dsn: dsn, channel: channel}
+}
+
+// Listen подписывается на канал и передает handler содержимое каждого оповещения. Если задан onListen,
+// он вызывается после подписки, до обработки оповещений, - например, чтобы наверстать пропущенное без соединения.
+// Возвращает ошибку при разрыве соединения или отмене ctx.
+func (l *Listener) Listen(ctx context.Context, onListen func(), handler func(payload string)) error {
+	conn, err := pgx.Connect(ctx, l.dsn)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close(context.Background())
+
+	if _, err = conn.Exec(ctx, "LISTEN "+pgx.Identifier{l.channel}.Sanitize()); err != nil {
+		return err
+	}
+
+	if onListen != nil {
+		onListen()
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		handler(notification.Payload)
+	}
+}
@@ -0,0 +1,249 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+
+	// maxResponseBodyLength - сколько байт ответа получателя сохраняется в журнале доставок
+	maxResponseBodyLength = 1024
+)
+
+var (
+	ErrInvalidURL       = errors.New("webhook url must be an absolute http or https url")
+	ErrForbiddenAddress = errors.New("webhook url points to a non-public address")
+)
+
+// deniedNetworks - диапазоны реестров IANA IPv4 и IPv6 Special-Purpose Address Registry, недоступные
+// из интернета или зарезервированные, а также групповые адреса. Глобально доступные служебные диапазоны
+// (AS112, AMT) в список не входят.
+var deniedNetworks = parseNetworks(
+	"0.0.0.0/8",       // "этот" сегмент сети (RFC 791)
+	"10.0.0.0/8",      // частные адреса (RFC 1918)
+	"100.64.0.0/10",   // адреса операторов связи (RFC 6598)
+	"127.0.0.0/8",     // loopback (RFC 1122)
+	"169.254.0.0/16",  // link-local (RFC 3927)
+	"172.16.0.0/12",   // частные адреса (RFC 1918)
+	"192.0.0.0/24",    // назначения протоколов IETF (RFC 6890)
+	"192.0.2.0/24",    // документация TEST-NET-1 (RFC 5737)
+	"192.88.99.0/24",  // ретрансляторы 6to4 (RFC 7526)
+	"192.168.0.0/16",  // частные адреса (RFC 1918)
+	"198.18.0.0/15",   // тестирование производительности (RFC 2544)
+	"198.51.100.0/24", // документация TEST-NET-2 (RFC 5737)
+	"203.0.113.0/24",  // документация TEST-NET-3 (RFC 5737)
+	"224.0.0.0/4",     // групповые адреса (RFC 5771)
+	"240.0.0.0/4",     // зарезервировано, включая широковещательный адрес (RFC 1112, RFC 919)
+	"::/96",           // неуказанный адрес, loopback и IPv4-совместимые адреса (RFC 4291)
+	"64:ff9b::/96",    // трансляция NAT64 (RFC 6052)
+	"64:ff9b:1::/48",  // локальная трансляция NAT64 (RFC 8215)
+	"100::/64",        // отбрасываемые адреса (RFC 6666)
+	"2001::/23",       // назначения протоколов IETF, включая Teredo (RFC 2928)
+	"2001:db8::/32",   // документация (RFC 3849)
+	"3fff::/20",       // документация (RFC 9637)
+	"5f00::/16",       // сегменты SRv6 (RFC 9602)
+	"fc00::/7",        // уникальные локальные адреса (RFC 4193)
+	"fe80::/10",       // link-local (RFC 4291)
+	"fec0::/10",       // site-local (RFC 3879)
+	"ff00::/8",        // групповые адреса (RFC 4291)
+)
+
+// sixToFourNetwork - адреса 6to4 (RFC 3056), во втором и третьем хекстетах которых вложен IPv4-адрес
+var sixToFourNetwork = parseNetworks("2002::/16")[0]
+
+// parseNetworks разбирает диапазоны адресов в нотации CIDR
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+
+		if err != nil {
+			panic(err)
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks
+}
+
+type Client struct {
+	http *http.Client
+	// allowPrivateNetworks разрешает отправку во внутреннюю сеть, например для локальной разработки
+	allowPrivateNetworks bool
+}
+
+type Request struct {
+	URL        string
+	Secret     string
+	Event      string
+	DeliveryId string
+	Payload    []byte
+}
+
+type Response struct {
+	StatusCode int
+	Body       string
+}
+
+// NewClient создает клиент, который без allowPrivateNetworks подключается только к публичным адресам.
+// Адрес проверяется при каждом подключении после разрешения имени, поэтому смена DNS-записи получателя
+// не позволяет обратиться во внутреннюю сеть. Перенаправления не выполняются: ответ 3xx считается неудачей.
+func NewClient(timeout time.Duration, allowPrivateNetworks bool) *Client {
+	dialer := &net.Dialer{Timeout: timeout}
+
+	if !allowPrivateNetworks {
+		dialer.Control = checkAddress
+	}
+
+	return &Client{
+		http: &http.Client{
+			Timeout:   timeout,
+			Transport: &http.Transport{DialContext: dialer.DialContext, TLSHandshakeTimeout: timeout},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		allowPrivateNetworks: allowPrivateNetworks,
+	}
+}
+
+// ValidateURL проверяет адрес получателя: схема http или https и, если хост задан IP-адресом или localhost,
+// публичный адрес. Адреса, которые получаются разрешением имени, проверяются при подключении.
+func (c *Client) ValidateURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return ErrInvalidURL
+	}
+
+	if c.allowPrivateNetworks {
+		return nil
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrForbiddenAddress
+	}
+
+	if ip := net.ParseIP(host); ip != nil && !isPublicIP(ip) {
+		return ErrForbiddenAddress
+	}
+
+	return nil
+}
+
+// Send отправляет подписанный запрос получателю. Ошибка возвращается и при ответе со статусом вне 2xx,
+// в этом случае Response тоже заполнен.
+func (c *Client) Send(request Request) (*Response, error) {
+	if err := c.ValidateURL(request.URL); err != nil {
+		return nil, err
+	}
+
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequest(http.MethodPost, request.URL, bytes.NewReader(request.Payload))
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "todo-webhooks/1.0")
+	req.Header.Set(EventHeader, request.Event)
+	req.Header.Set(DeliveryHeader, request.DeliveryId)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(request.Secret, timestamp, request.Payload))
+
+	resp, err := c.http.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodyLength))
+
+	response := &Response{StatusCode: resp.StatusCode, Body: string(body)}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return response, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return response, nil
+}
+
+// checkAddress запрещает подключение к адресу, который не является публичным
+func checkAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+
+	if err != nil {
+		return err
+	}
+
+	if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+		return ErrForbiddenAddress
+	}
+
+	return nil
+}
+
+// isPublicIP сообщает, что адрес не входит в диапазоны deniedNetworks. IPv4-адрес в форме IPv6 (::ffff:a.b.c.d)
+// проверяется как IPv4, у адреса 6to4 дополнительно проверяется вложенный IPv4-адрес.
+func isPublicIP(ip net.IP) bool {
+	if ipv4 := ip.To4(); ipv4 != nil {
+		ip = ipv4
+	} else if sixToFourNetwork.Contains(ip) && !isPublicIP(ip[2:6]) {
+		return false
+	}
+
+	for _, network := range deniedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Sign возвращает подпись запроса: HMAC-SHA256 строки "<timestamp>.<payload>" с ключом secret в формате sha256=<hex>.
+// Метка времени в подписи позволяет получателю отклонять повторно отправленные старые запросы.
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// GenerateSecret возвращает случайный ключ подписи
+func GenerateSecret() (string, error) {
+	secret := make([]byte, 32)
+
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
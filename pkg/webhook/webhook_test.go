@@ -0,0 +1,168 @@
+package webhook_test
+
+import (
+	"crypto/hmac"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"poymanov/todo/pkg/webhook"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	signature := webhook.Sign("secret", 1700000000, []byte(`{"event":"task.created"}`))
+
+	require.Equal(t, "sha256=fc53e1d22cb0ed2216fe98c535f28e2e668e9a812f23e87d18f07b966afb540a", signature)
+}
+
+func TestSendSuccess(t *testing.T) {
+	payload := []byte(`{"event":"task.created"}`)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, err := strconv.ParseInt(r.Header.Get(webhook.TimestampHeader), 10, 64)
+
+		require.NoError(t, err)
+		require.Equal(t, payload, body)
+		require.Equal(t, "task.created", r.Header.Get(webhook.EventHeader))
+		require.Equal(t, "delivery", r.Header.Get(webhook.DeliveryHeader))
+		require.True(t, hmac.Equal([]byte(webhook.Sign("secret", timestamp, body)), []byte(r.Header.Get(webhook.SignatureHeader))))
+
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	response, err := webhook.NewClient(time.Second, true).Send(webhook.Request{
+		URL: server.URL, Secret: "secret", Event: "task.created", DeliveryId: "delivery", Payload: payload,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Equal(t, "ok", response.Body)
+}
+
+func TestSendFailedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	response, err := webhook.NewClient(time.Second, true).Send(webhook.Request{URL: server.URL, Payload: []byte(`{}`)})
+
+	require.EqualError(t, err, "unexpected response status 500")
+	require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+}
+
+func TestSendDoesNotFollowRedirects(t *testing.T) {
+	redirected := false
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected = true
+	}))
+	defer target.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+
+	response, err := webhook.NewClient(time.Second, true).Send(webhook.Request{URL: server.URL, Payload: []byte(`{}`)})
+
+	require.EqualError(t, err, "unexpected response status 307")
+	require.Equal(t, http.StatusTemporaryRedirect, response.StatusCode)
+	require.False(t, redirected)
+}
+
+func TestSendForbiddenAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("request to a private address must not be sent")
+	}))
+	defer server.Close()
+
+	_, err := webhook.NewClient(time.Second, false).Send(webhook.Request{URL: server.URL, Payload: []byte(`{}`)})
+
+	require.ErrorIs(t, err, webhook.ErrForbiddenAddress)
+}
+
+func TestValidateURL(t *testing.T) {
+	testCases := []struct {
+		url                  string
+		allowPrivateNetworks bool
+		err                  error
+	}{
+		{url: "https://example.com/hook"},
+		{url: "http://93.184.216.34:8080/hook"},
+		{url: "ftp://example.com/hook", err: webhook.ErrInvalidURL},
+		{url: "file:///etc/passwd", err: webhook.ErrInvalidURL},
+		{url: "/hook", err: webhook.ErrInvalidURL},
+		{url: "http://localhost:8099/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://127.0.0.1/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://10.0.0.5/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://192.168.1.1/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://169.254.169.254/latest/meta-data", err: webhook.ErrForbiddenAddress},
+		{url: "http://100.64.0.1/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://0.0.0.0/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://[::1]/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://[::ffff:127.0.0.1]/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://[fe80::1]/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://[fd00::1]/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://0.1.2.3/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://172.16.0.1/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://192.0.0.8/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://192.0.2.1/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://192.88.99.1/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://198.18.0.1/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://198.19.255.254/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://198.51.100.1/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://203.0.113.1/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://224.0.0.1/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://240.0.0.1/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://255.255.255.255/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://[::]/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://[::10.0.0.1]/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://[::ffff:10.0.0.1]/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://[::ffff:169.254.169.254]/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://[64:ff9b::a9fe:a9fe]/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://[64:ff9b:1::1]/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://[100::1]/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://[2001::1]/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://[2001:db8::1]/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://[2002:a00:1::1]/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://[2002:7f00:1::1]/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://[3fff::1]/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://[5f00::1]/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://[fec0::1]/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://[ff02::1]/hook", err: webhook.ErrForbiddenAddress},
+		{url: "http://[2606:4700:4700::1111]/hook"},
+		{url: "http://[::ffff:93.184.216.34]/hook"},
+		{url: "http://[2002:5db8:d822::1]/hook"},
+		{url: "http://127.0.0.1/hook", allowPrivateNetworks: true},
+		{url: "ftp://127.0.0.1/hook", allowPrivateNetworks: true, err: webhook.ErrInvalidURL},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.url, func(t *testing.T) {
+			err := webhook.NewClient(time.Second, tc.allowPrivateNetworks).ValidateURL(tc.url)
+
+			if tc.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	first, err := webhook.GenerateSecret()
+	require.NoError(t, err)
+
+	second, err := webhook.GenerateSecret()
+	require.NoError(t, err)
+
+	require.Len(t, first, 64)
+	require.NotEqual(t, first, second)
+}